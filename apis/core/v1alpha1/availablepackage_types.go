@@ -0,0 +1,31 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// AvailablePackages are created by PackageRepositories and list the versions of a single package.
+// Named <repository name>.<package name>.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Repository",type="string",JSONPath=".package.repository"
+// +kubebuilder:printcolumn:name="Latest",type="string",JSONPath=".package.latestVersion"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type AvailablePackage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Package and its available versions.
+	Package RepositoryPackage `json:"package"`
+}
+
+// AvailablePackageList contains a list of AvailablePackages.
+// +kubebuilder:object:root=true
+type AvailablePackageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AvailablePackage `json:"items"`
+}
+
+// Label on AvailablePackages referencing the PackageRepository they were created by.
+const PackageRepositoryLabel = "package-operator.run/package-repository"
+
+func init() { register(&AvailablePackage{}, &AvailablePackageList{}) }
@@ -13,6 +13,13 @@ type ClusterObjectDeploymentSpec struct {
 	Selector metav1.LabelSelector `json:"selector"`
 	// Template to create new ObjectSets from.
 	Template ObjectSetTemplate `json:"template"`
+	// Specifies how ObjectSets created from this ClusterObjectDeployment are named.
+	// +kubebuilder:default=TemplateHash
+	// +kubebuilder:validation:Enum=TemplateHash;Revision
+	ObjectSetNaming ObjectSetNamingStrategy `json:"objectSetNaming,omitempty"`
+	// Pauses the active ObjectSets and stops rolling out new revisions.
+	// Status is still reported while paused.
+	Paused bool `json:"paused,omitempty"`
 }
 
 // ClusterObjectDeploymentStatus defines the observed state of a ClusterObjectDeployment.
@@ -29,6 +36,14 @@ type ClusterObjectDeploymentStatus struct {
 	TemplateHash string `json:"templateHash,omitempty"`
 	// Deployment revision.
 	Revision int64 `json:"revision,omitempty"`
+	// Fields of objects projected via FieldMappings, keyed by destination.
+	// +example={"my-package.example.com/endpoint": "db.example.svc:5432"}
+	MappedFields map[string]string `json:"mappedFields,omitempty"`
+	// Objects controlled by the revisions of this deployment that are not archived.
+	// During a rollout, objects of the new and previous revisions are listed.
+	ControllerOf []ControlledObjectReference `json:"controllerOf,omitempty"`
+	// Health of the deployment, summarizing its conditions.
+	Health *Health `json:"health,omitempty"`
 }
 
 // ClusterObjectDeployment is the Schema for the ClusterObjectDeployments API
@@ -61,6 +61,19 @@ type ClusterObjectSetStatus struct {
 	RemotePhases []RemotePhaseReference `json:"remotePhases,omitempty"`
 	// References all objects controlled by this instance.
 	ControllerOf []ControlledObjectReference `json:"controllerOf,omitempty"`
+	// Fields of objects recently taken over from other field managers.
+	Conflicts []ObjectSetObjectConflict `json:"conflicts,omitempty"`
+	// Rollout progress of this revision.
+	Rollout *ObjectSetRolloutStatus `json:"rollout,omitempty"`
+	// Per-phase breakdown of the rollout, in phase order.
+	Phases []ObjectSetRolloutPhase `json:"phases,omitempty"`
+	// Changes that would be applied to objects, computed via server-side dry-run while paused.
+	Diff []ObjectSetObjectDiff `json:"diff,omitempty"`
+	// Fields of objects projected via FieldMappings, keyed by destination.
+	// +example={"my-package.example.com/endpoint": "db.example.svc:5432"}
+	MappedFields map[string]string `json:"mappedFields,omitempty"`
+	// Version of the Package Operator manager that last reconciled this ObjectSet.
+	ManagerVersion string `json:"managerVersion,omitempty"`
 }
 
 func init() { register(&ClusterObjectSet{}, &ClusterObjectSetList{}) }
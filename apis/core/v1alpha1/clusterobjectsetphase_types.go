@@ -49,6 +49,18 @@ type ClusterObjectSetPhaseSpec struct {
 
 	// ExternalObjects observed, but not reconciled by this phase.
 	ExternalObjects []ObjectSetObject `json:"externalObjects,omitempty"`
+
+	// If true, objects created by this revision in this phase are deleted again,
+	// when any object of the phase fails preflight checks or can't be applied.
+	Atomic bool `json:"atomic,omitempty"`
+
+	// Limits how long objects of this phase may take to apply.
+	// Can be overridden per object.
+	ApplyPolicy *ObjectSetApplyPolicy `json:"applyPolicy,omitempty"`
+
+	// Allows managing cluster-critical kinds, copied from the parent ObjectSet.
+	// +optional
+	AllowCriticalKinds bool `json:"allowCriticalKinds,omitempty"`
 }
 
 // ClusterObjectSetPhaseStatus defines the observed state of a ClusterObjectSetPhase.
@@ -58,6 +70,13 @@ type ClusterObjectSetPhaseStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// References all objects controlled by this instance.
 	ControllerOf []ControlledObjectReference `json:"controllerOf,omitempty"`
+	// Failure messages of all probes that did not pass during the last reconciliation.
+	// Propagated to the parent ObjectSet to report detailed availability information.
+	// +example=["apps Deployment example/controller-manager: condition \"Available\" == \"True\": wrong status"]
+	FailedProbes []string `json:"failedProbes,omitempty"`
+	// Fields of objects projected via FieldMappings, keyed by destination.
+	// +example={"my-package.example.com/endpoint": "db.example.svc:5432"}
+	MappedFields map[string]string `json:"mappedFields,omitempty"`
 }
 
 func init() { register(&ClusterObjectSetPhase{}, &ClusterObjectSetPhaseList{}) }
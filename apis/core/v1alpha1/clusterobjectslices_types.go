@@ -13,6 +13,10 @@ type ClusterObjectSlice struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Objects []ObjectSetObject `json:"objects"`
+	// SHA-256 checksum of the JSON encoded objects.
+	// Verified when the slice is loaded, to detect modified or truncated slices.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
 }
 
 // ClusterObjectSliceList contains a list of ClusterObjectSlices.
@@ -6,6 +6,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // Specifies the lifecycle state of the ObjectSet.
@@ -39,6 +40,11 @@ type ObjectSetTemplateSpec struct {
 	// the underlying objects may initially satisfy the availability
 	// probes, but are ultimately unstable.
 	SuccessDelaySeconds int32 `json:"successDelaySeconds,omitempty"`
+	// Allows managing cluster-critical kinds, e.g. Nodes, APIServices
+	// and admission webhook configurations intercepting Kubernetes API groups.
+	// Objects of these kinds fail preflight checks, unless explicitly allowed.
+	// +optional
+	AllowCriticalKinds bool `json:"allowCriticalKinds,omitempty"`
 }
 
 // ObjectSet reconcile phase.
@@ -56,6 +62,13 @@ type ObjectSetTemplatePhase struct {
 	ExternalObjects []ObjectSetObject `json:"externalObjects,omitempty"`
 	// References to ObjectSlices containing objects for this phase.
 	Slices []string `json:"slices,omitempty"`
+	// If true, objects created by this revision in this phase are deleted again,
+	// when any object of the phase fails preflight checks or can't be applied.
+	// Prevents the phase from staying partially applied.
+	Atomic bool `json:"atomic,omitempty"`
+	// Limits how long objects of this phase may take to apply.
+	// Can be overridden per object.
+	ApplyPolicy *ObjectSetApplyPolicy `json:"applyPolicy,omitempty"`
 }
 
 // An object that is part of the phase of an ObjectSet.
@@ -66,12 +79,94 @@ type ObjectSetObject struct {
 	Object unstructured.Unstructured `json:"object"`
 	// Maps conditions from this object into the Package Operator APIs.
 	ConditionMappings []ConditionMapping `json:"conditionMappings,omitempty"`
+	// Maps fields from this object into the status of Package Operator APIs.
+	FieldMappings []FieldMapping `json:"fieldMappings,omitempty"`
+	// Specifies what happens to the object, when it is no longer part of any active revision.
+	// Defaults to "Delete".
+	// +kubebuilder:validation:Enum=Delete;Orphan;ScaleDown
+	// +example=Orphan
+	DeletionPolicy ObjectSetObjectDeletionPolicy `json:"deletionPolicy,omitempty"`
+	// Only creates the object, if it does not exist yet.
+	// Existing objects are never patched, e.g. to not overwrite a generated initial password.
+	// Combine with the "Orphan" deletion policy to also keep the object on teardown.
+	CreateOnly bool `json:"createOnly,omitempty"`
+	// Limits how long this object may take to apply.
+	// Overrides the apply policy of the phase.
+	ApplyPolicy *ObjectSetApplyPolicy `json:"applyPolicy,omitempty"`
+	// Runs the object, which must be a batch/v1 Job, as hook of the phase.
+	// "Pre" hooks complete before the other objects of the phase are applied,
+	// "Post" hooks run once all other objects of the phase are available.
+	// Hooks run again for every new revision and fail the phase,
+	// when their Job fails after exhausting its backoffLimit.
+	// +kubebuilder:validation:Enum=Pre;Post
+	// +example=Pre
+	Hook ObjectSetObjectHook `json:"hook,omitempty"`
 }
 
+// Specifies when a hook Job runs within its phase.
+type ObjectSetObjectHook string
+
+const (
+	// Runs before the other objects of the phase are applied.
+	ObjectSetObjectHookPre ObjectSetObjectHook = "Pre"
+	// Runs after all other objects of the phase are available.
+	ObjectSetObjectHookPost ObjectSetObjectHook = "Post"
+)
+
+// ObjectSetApplyPolicy isolates objects that are slow to apply or rejected,
+// e.g. by a hanging or denying admission webhook.
+// Instead of blocking the whole phase, such objects are reported as failed
+// and the remaining objects of the phase are still applied and probed.
+type ObjectSetApplyPolicy struct {
+	// Maximum duration of a single apply request.
+	// +example="30s"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Time to wait before retrying to apply a failed object.
+	// Defaults to 10s.
+	// +example="1m"
+	RetryBackoff *metav1.Duration `json:"retryBackoff,omitempty"`
+}
+
+// Specifies what happens to an object, when it is no longer part of any active revision.
+type ObjectSetObjectDeletionPolicy string
+
+const (
+	// "Delete" is the default deletion policy.
+	// Objects dropped from a newer revision are cleaned up once the newer revision is Available,
+	// all objects are deleted when the ObjectSet is archived or deleted.
+	ObjectSetObjectDeletionPolicyDelete ObjectSetObjectDeletionPolicy = "Delete"
+	// "Orphan" leaves the object on the cluster and only removes the owner reference.
+	ObjectSetObjectDeletionPolicyOrphan ObjectSetObjectDeletionPolicy = "Orphan"
+	// "ScaleDown" scales Deployments and StatefulSets to zero replicas when the ObjectSet is archived,
+	// instead of deleting them, so rolling back to the archived revision is near-instant.
+	// The objects are deleted together with the archived ObjectSet, e.g. when pruned by the revisionHistoryLimit.
+	// Objects of other kinds are deleted like with "Delete".
+	ObjectSetObjectDeletionPolicyScaleDown ObjectSetObjectDeletionPolicy = "ScaleDown"
+)
+
+// ObjectSourceFileAnnotation references the file within the package
+// and the index of the object within this file, e.g. "deploy/deployment.yaml#0".
+const ObjectSourceFileAnnotation = "package-operator.run/source-file"
+
+// AdoptionAnnotation marks an existing object without controller for adoption
+// by the ObjectDeployment or ObjectSet named in the annotation value,
+// e.g. to take over objects previously managed by Helm.
+const AdoptionAnnotation = "package-operator.run/adopt-into"
+
+// ObjectSetCompactedAnnotation is set on archived ObjectSets,
+// after their phase objects have been stripped down to their identity to save etcd space.
+// Compacted ObjectSets can only leave the Archived lifecycle state, if they were templated from a package image.
+// Their phase objects are then rehydrated from the same image and configuration.
+const ObjectSetCompactedAnnotation = "package-operator.run/compacted"
+
 func (o ObjectSetObject) String() string {
 	obj := o.Object
 
-	return fmt.Sprintf("object %s/%s kind:%s", obj.GetNamespace(), obj.GetName(), obj.GetKind())
+	msg := fmt.Sprintf("object %s/%s kind:%s", obj.GetNamespace(), obj.GetName(), obj.GetKind())
+	if source := obj.GetAnnotations()[ObjectSourceFileAnnotation]; len(source) > 0 {
+		msg += " from " + source
+	}
+	return msg
 }
 
 // ObjectSet Condition Types.
@@ -90,6 +185,13 @@ const (
 	// InTransition condition is True when the ObjectSet is not in control of all objects defined in spec.
 	// This holds true during rollout of the first instance or while handing over objects between two ObjectSets.
 	ObjectSetInTransition = "InTransition"
+	// RemoteUnreachable indicates that a remote phase manager
+	// responsible for a phase of this ObjectSet stopped sending heartbeats
+	// or is not authorized by its target cluster.
+	ObjectSetRemoteUnreachable = "RemoteUnreachable"
+	// ApplyConflict is True, when fields of objects were recently taken over from other field managers.
+	// Details are reported in .status.conflicts.
+	ObjectSetApplyConflict = "ApplyConflict"
 )
 
 type ObjectSetStatusPhase string
@@ -115,6 +217,33 @@ type ObjectSetProbe struct {
 	Probes []Probe `json:"probes"`
 	// Selector specifies which objects this probe should target.
 	Selector ProbeSelector `json:"selector"`
+	// Suggested interval in seconds to check objects failing these probes again.
+	// Useful for objects that are known to take a while to become available,
+	// e.g. a Job expected to run for 10 minutes, or that don't emit events while progressing.
+	// If unset, objects are checked again when they change.
+	// +kubebuilder:validation:Minimum=1
+	// +example=600
+	RecheckIntervalSeconds int32 `json:"recheckIntervalSeconds,omitempty"`
+	// Seconds after an object was created before it is probed.
+	// Objects are reported as failing these probes until then.
+	// +kubebuilder:validation:Minimum=0
+	// +example=30
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+	// Interval in seconds to probe failing objects again.
+	// Failures count towards the failureThreshold at most once per period.
+	// Defaults to 10 seconds, if failureThreshold is greater than 1.
+	// +kubebuilder:validation:Minimum=1
+	// +example=10
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+	// Number of consecutive failures, at least periodSeconds apart,
+	// before an object that passed these probes before is reported as failing.
+	// Objects that never passed are reported as failing right away,
+	// so later phases are not rolled out early.
+	// Failures are counted in memory and start over when Package Operator restarts.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +example=3
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
 }
 
 type ConditionMapping struct {
@@ -125,6 +254,19 @@ type ConditionMapping struct {
 	DestinationType string `json:"destinationType"`
 }
 
+// Projects a field of an object into the mappedFields status of Package Operator APIs,
+// e.g. to report the endpoint of a provisioned database.
+type FieldMapping struct {
+	// JSONPath of the source field, as supported by kubectl.
+	// Lists and objects are reported as JSON.
+	// +example=.status.endpoint
+	Source string `json:"source"`
+	// Destination key to report the field value under.
+	// +kubebuilder:validation:Pattern=`[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*\/([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]`
+	// +example=my-package.example.com/endpoint
+	Destination string `json:"destination"`
+}
+
 // Selects a subset of objects to apply probes to.
 // e.g. ensures that probes defined for apps/Deployments are not checked against ConfigMaps.
 type ProbeSelector struct {
@@ -150,6 +292,9 @@ type PackageProbeKindSpec struct {
 type Probe struct {
 	Condition   *ProbeConditionSpec   `json:"condition,omitempty"`
 	FieldsEqual *ProbeFieldsEqualSpec `json:"fieldsEqual,omitempty"`
+	HTTPGet     *ProbeHTTPGetSpec     `json:"httpGet,omitempty"`
+	TCPSocket   *ProbeTCPSocketSpec   `json:"tcpSocket,omitempty"`
+	Count       *ProbeCountSpec       `json:"count,omitempty"`
 }
 
 // Checks whether or not the object reports a condition with given type and status.
@@ -172,6 +317,119 @@ type ProbeFieldsEqualSpec struct {
 	FieldB string `json:"fieldB"`
 }
 
+// Performs an in-cluster HTTP GET request against the probed Service or Pod.
+// The endpoint is resolved from the probed object:
+// Services are reached via their cluster DNS name, Pods via their Pod IP.
+type ProbeHTTPGetSpec struct {
+	// Path to request from the HTTP server.
+	// +kubebuilder:default="/"
+	// +example=/healthz
+	Path string `json:"path,omitempty"`
+	// Name or number of the port to access.
+	// Named ports are resolved from the Service or Pod spec.
+	// +kubebuilder:validation:XIntOrString
+	// +example=8080
+	Port intstr.IntOrString `json:"port"`
+	// Scheme to use for connecting to the host.
+	// +kubebuilder:default=HTTP
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	Scheme string `json:"scheme,omitempty"`
+	// HTTP status code the endpoint is expected to return.
+	// If unset, any code in the 200-399 range is considered a success.
+	// +example=200
+	ExpectedStatusCode int32 `json:"expectedStatusCode,omitempty"`
+	// Number of seconds after which the request times out.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// Opens a TCP connection to the probed Service or Pod.
+// The endpoint is resolved from the probed object:
+// Services are reached via their cluster DNS name, Pods via their Pod IP.
+type ProbeTCPSocketSpec struct {
+	// Name or number of the port to access.
+	// Named ports are resolved from the Service or Pod spec.
+	// +kubebuilder:validation:XIntOrString
+	// +example=8080
+	Port intstr.IntOrString `json:"port"`
+	// Number of seconds after which the connection attempt times out.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// Lists objects from the namespace of the probed object and checks them,
+// instead of the probed object itself.
+// e.g. ensures that at least 2 Pods of a Deployment are Ready.
+// Only objects carrying the Package Operator cache label can be listed,
+// which is set on all objects managed by Package Operator.
+type ProbeCountSpec struct {
+	// APIVersion of the objects to list.
+	// +example=monitoring.coreos.com/v1
+	APIVersion string `json:"apiVersion"`
+	// Kind of the objects to list.
+	// +example=PrometheusRule
+	Kind string `json:"kind"`
+	// Selects the objects to list.
+	// If unset, all objects of the given kind are listed.
+	// +example={matchLabels: {app.kubernetes.io/name: example-operator}}
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Checks whether listed objects report a condition with given type and status.
+	Condition *ProbeConditionSpec `json:"condition,omitempty"`
+	// Compares two fields of listed objects.
+	FieldsEqual *ProbeFieldsEqualSpec `json:"fieldsEqual,omitempty"`
+	// Minimum number of listed objects that have to pass the probe.
+	// If unset, all listed objects have to pass.
+	// +kubebuilder:validation:Minimum=0
+	// +example=2
+	MinCount *int32 `json:"minCount,omitempty"`
+}
+
+// Reports the rollout progress of an ObjectSet.
+// Progress is monotonic: once completed, phases are not reported as pending again,
+// even if their objects become unavailable later on.
+type ObjectSetRolloutStatus struct {
+	// Name of the phase that is currently rolled out.
+	// Empty, when all phases completed.
+	CurrentPhase string `json:"currentPhase,omitempty"`
+	// Number of phases that have been reconciled and passed their availability probes.
+	PhasesCompleted int32 `json:"phasesCompleted"`
+	// Total number of phases.
+	TotalPhases int32 `json:"totalPhases"`
+	// Time the rollout was started.
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+}
+
+// Reports the state of a single phase of an ObjectSet.
+type ObjectSetRolloutPhase struct {
+	// Name of the phase.
+	Name string `json:"name"`
+	// State of the phase.
+	// +kubebuilder:validation:Enum=Pending;Progressing;Available;Failed
+	State ObjectSetRolloutPhaseState `json:"state"`
+	// Messages of availability probes that are failing for objects of this phase.
+	FailedProbes []string `json:"failedProbes,omitempty"`
+	// Error that prevented the phase from being reconciled.
+	Message string `json:"message,omitempty"`
+	// Time the phase first passed its availability probes.
+	AvailableAt *metav1.Time `json:"availableAt,omitempty"`
+}
+
+// State of a single phase of an ObjectSet.
+type ObjectSetRolloutPhaseState string
+
+const (
+	// The phase waits for previous phases to become available.
+	ObjectSetRolloutPhaseStatePending ObjectSetRolloutPhaseState = "Pending"
+	// The phase has been reconciled, but not all objects pass their availability probes.
+	ObjectSetRolloutPhaseStateProgressing ObjectSetRolloutPhaseState = "Progressing"
+	// All objects of the phase pass their availability probes.
+	ObjectSetRolloutPhaseStateAvailable ObjectSetRolloutPhaseState = "Available"
+	// The phase could not be reconciled.
+	ObjectSetRolloutPhaseStateFailed ObjectSetRolloutPhaseState = "Failed"
+)
+
 // References a previous revision of an ObjectSet or ClusterObjectSet.
 type PreviousRevisionReference struct {
 	// Name of a previous revision.
@@ -196,3 +454,40 @@ type ControlledObjectReference struct {
 	// Object Namespace.
 	Namespace string `json:"namespace,omitempty"`
 }
+
+// Summarizes how an object would change, if the ObjectSet was not paused.
+type ObjectSetObjectDiff struct {
+	// References the changed object.
+	Object ControlledObjectReference `json:"object"`
+	// Action that would be performed on the object.
+	// +kubebuilder:validation:Enum=Create;Update
+	Action ObjectSetObjectDiffAction `json:"action"`
+	// Paths of the fields that would change.
+	// +example=[spec.replicas]
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Action that would be performed on an object.
+type ObjectSetObjectDiffAction string
+
+const (
+	// The object does not exist and would be created.
+	ObjectSetObjectDiffActionCreate ObjectSetObjectDiffAction = "Create"
+	// The object exists and would be updated.
+	ObjectSetObjectDiffActionUpdate ObjectSetObjectDiffAction = "Update"
+)
+
+// Reports fields of an object, that another field manager changed
+// and Package Operator took over again via server-side apply.
+type ObjectSetObjectConflict struct {
+	// References the conflicting object.
+	Object ControlledObjectReference `json:"object"`
+	// Field manager that changed the fields.
+	// +example=kubectl-edit
+	Manager string `json:"manager"`
+	// Paths of the conflicting fields.
+	// +example=[spec.replicas]
+	Fields []string `json:"fields,omitempty"`
+	// Last time the conflict was observed.
+	LastObservedTime metav1.Time `json:"lastObservedTime"`
+}
@@ -6,6 +6,12 @@ const (
 	ObjectSetPhaseAvailable = "Available"
 	// Paused indicates that object changes are not reconciled, but status is still reported.
 	ObjectSetPhasePaused = "Paused"
+	// Invalid indicates that the ObjectSetPhase does not match a phase declared by its parent ObjectSet.
+	ObjectSetPhaseInvalid = "Invalid"
+	// RemoteUnreachable indicates that the remote phase manager
+	// responsible for this ObjectSetPhase stopped sending heartbeats
+	// or is not authorized by its target cluster.
+	ObjectSetPhaseRemoteUnreachable = "RemoteUnreachable"
 )
 
 const ObjectSetPhaseClassLabel = "package-operator.run/phase-class"
@@ -1,6 +1,9 @@
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // ObjectTemplateSpec specification.
 type ObjectTemplateSpec struct {
@@ -9,18 +12,63 @@ type ObjectTemplateSpec struct {
 
 	// Objects in which configuration parameters are fetched
 	Sources []ObjectTemplateSource `json:"sources"`
+
+	// Secrets in external secret managers in which configuration parameters are fetched.
+	// +optional
+	ExternalSources []ObjectTemplateExternalSource `json:"externalSources,omitempty"`
+
+	// Maps conditions of the templated object into the status of the ObjectTemplate.
+	// When empty, all conditions of the templated object are copied as-is.
+	// +optional
+	ConditionMappings []ConditionMapping `json:"conditionMappings,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.pruneOnMissing) || !self.pruneOnMissing || (has(self.optional) && self.optional)",message="pruneOnMissing requires optional"
 type ObjectTemplateSource struct {
-	APIVersion string                     `json:"apiVersion"`
-	Kind       string                     `json:"kind"`
-	Namespace  string                     `json:"namespace,omitempty"`
-	Name       string                     `json:"name"`
-	Items      []ObjectTemplateSourceItem `json:"items"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// Namespace of the source object.
+	// May be a Go template with access to the metadata of the ObjectTemplate,
+	// e.g. {{.metadata.labels.tenant}}-config.
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the source object.
+	// May be a Go template with access to the metadata of the ObjectTemplate,
+	// e.g. {{index .metadata.annotations "example.com/source"}}.
+	Name  string                     `json:"name"`
+	Items []ObjectTemplateSourceItem `json:"items"`
 	// Marks this source as optional.
 	// The templated object will still be applied if optional sources are not found.
 	// If the source object is created later on, it will be eventually picked up.
 	Optional bool `json:"optional,omitempty"`
+	// Deletes the templated object while this optional source is not found,
+	// instead of rendering the template without the values of this source.
+	// The object is templated again as soon as the source object is recreated.
+	// Requires optional to be set.
+	PruneOnMissing bool `json:"pruneOnMissing,omitempty"`
+}
+
+// ObjectTemplateExternalSource reads configuration parameters from a secret
+// in an external secret manager configured by a SecretProvider.
+// Secrets containing a JSON object, like key-value secrets in Vault, expose their fields to items.
+// All other secrets expose their content as "value".
+type ObjectTemplateExternalSource struct {
+	// Name of the SecretProvider.
+	Provider string `json:"provider"`
+	// Namespace of the SecretProvider.
+	// Required for ClusterObjectTemplates, ObjectTemplates can only reference SecretProviders in their own namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Path of the secret in the external secret manager,
+	// e.g. the path within the Vault KV secrets engine or the name of the secret in AWS or GCP.
+	// +example=team-a/database
+	Path string `json:"path"`
+	// Version of the secret, defaults to the latest version.
+	// +optional
+	Version string                     `json:"version,omitempty"`
+	Items   []ObjectTemplateSourceItem `json:"items"`
+	// Marks this source as optional.
+	// The templated object will still be applied if the secret is not found.
+	Optional bool `json:"optional,omitempty"`
 }
 
 type ObjectTemplateSourceItem struct {
@@ -28,8 +76,32 @@ type ObjectTemplateSourceItem struct {
 	Key string `json:"key"`
 	// JSONPath to destination in which to store copy of the source value.
 	Destination string `json:"destination"`
+	// Value to store in the destination, if the optional source object is not found.
+	// +example=example-value
+	Default *apiextensionsv1.JSON `json:"default,omitempty"`
+	// Decodes the base64 encoded source value, e.g. from the data of a Secret, before it is parsed.
+	DecodeBase64 bool `json:"decodeBase64,omitempty"`
+	// Parses the source value from a string into structured data before storing it in the destination.
+	// Defaults are not parsed.
+	// +kubebuilder:validation:Enum=json;yaml;int;bool
+	// +example=json
+	Parse ObjectTemplateSourceItemParse `json:"parse,omitempty"`
 }
 
+// Format to parse source values from.
+type ObjectTemplateSourceItemParse string
+
+const (
+	// "json" parses the value as JSON document.
+	ObjectTemplateSourceItemParseJSON ObjectTemplateSourceItemParse = "json"
+	// "yaml" parses the value as YAML document.
+	ObjectTemplateSourceItemParseYAML ObjectTemplateSourceItemParse = "yaml"
+	// "int" parses the value as integer.
+	ObjectTemplateSourceItemParseInt ObjectTemplateSourceItemParse = "int"
+	// "bool" parses the value as boolean, accepting e.g. "true", "false", "1" and "0".
+	ObjectTemplateSourceItemParseBool ObjectTemplateSourceItemParse = "bool"
+)
+
 // ObjectTemplateStatus defines the observed state of a ObjectTemplate ie the status of the templated object.
 type ObjectTemplateStatus struct {
 	// Conditions is a list of status conditions the templated object is in.
@@ -38,12 +110,29 @@ type ObjectTemplateStatus struct {
 	// it will go away as soon as kubectl can print conditions!
 	// When evaluating object state in code, use .Conditions instead.
 	Phase ObjectTemplateStatusPhase `json:"phase,omitempty"`
+	// References the object created from the template.
+	// When the template renders a different object, the referenced object is deleted.
+	TemplatedObject *ObjectTemplateObjectReference `json:"templatedObject,omitempty"`
+}
+
+// References an object created from an ObjectTemplate.
+type ObjectTemplateObjectReference struct {
+	// Object APIVersion.
+	APIVersion string `json:"apiVersion"`
+	// Object Kind.
+	Kind string `json:"kind"`
+	// Object Name.
+	Name string `json:"name"`
+	// Object Namespace.
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // ObjectTemplate condition types.
 const (
 	// Invalid indicates an issue with the ObjectTemplates own configuration.
 	ObjectTemplateInvalid = "package-operator.run/Invalid"
+	// TeardownPending indicates that an object previously created from the template is still being deleted.
+	ObjectTemplateTeardownPending = "package-operator.run/TeardownPending"
 )
 
 type ObjectTemplateStatusPhase string
@@ -15,10 +15,43 @@ type PackageStatus struct {
 	Phase PackageStatusPhase `json:"phase,omitempty"`
 	// Hash of image + config that was successfully unpacked.
 	UnpackedHash string `json:"unpackedHash,omitempty"`
+	// Digest of the package image that was successfully unpacked.
+	// +example=sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+	ImageDigest string `json:"imageDigest,omitempty"`
 	// Package revision as reported by the ObjectDeployment.
 	Revision int64 `json:"revision,omitempty"`
+	// Latest version in the channel of the upgrade policy,
+	// if newer than the version of the current image.
+	AvailableUpgrade string `json:"availableUpgrade,omitempty"`
+	// Fields of objects projected via FieldMappings, keyed by destination.
+	// +example={"my-package.example.com/endpoint": "db.example.svc:5432"}
+	MappedFields map[string]string `json:"mappedFields,omitempty"`
+	// Objects managed by the package.
+	// Packages managing more objects than fit into the status list them in an inventory ConfigMap instead.
+	Objects []ControlledObjectReference `json:"objects,omitempty"`
+	// Inventory ConfigMap listing all objects managed by the package,
+	// when the package manages too many objects to list them in the status.
+	Inventory *PackageInventoryReference `json:"inventory,omitempty"`
+	// Health of the package, summarizing its conditions.
+	Health *Health `json:"health,omitempty"`
 }
 
+// PackageInventoryReference references the inventory ConfigMap of a package.
+// The ConfigMap contains the JSON encoded list of objects managed by the package
+// under the "objects.json" key, in the same format as .status.objects.
+type PackageInventoryReference struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+	// Namespace of the ConfigMap.
+	// ClusterPackages store their inventory in the namespace of Package Operator.
+	Namespace string `json:"namespace"`
+	// Number of objects in the inventory.
+	ObjectCount int32 `json:"objectCount"`
+}
+
+// Key of the inventory ConfigMap containing the objects managed by the package.
+const PackageInventoryObjectsKey = "objects.json"
+
 // Package condition types.
 const (
 	// A Packages "Available" condition tracks the availability of the underlying ObjectDeployment objects.
@@ -36,6 +69,9 @@ const (
 	// - Malformed Yaml
 	// - Issues resulting from the template process.
 	PackageInvalid = "Invalid"
+	// Unsupported condition is True, when the cluster does not satisfy the constraints of the PackageManifest.
+	// No objects are applied while the package is unsupported.
+	PackageUnsupported = "Unsupported"
 )
 
 type PackageStatusPhase string
@@ -49,15 +85,146 @@ const (
 	PackagePhaseUnpacking   PackageStatusPhase = "Unpacking"
 	PackagePhaseNotReady    PackageStatusPhase = "NotReady"
 	PackagePhaseInvalid     PackageStatusPhase = "Invalid"
+	PackagePhaseUnsupported PackageStatusPhase = "Unsupported"
 )
 
 // Package specification.
 type PackageSpec struct {
 	// the image containing the contents of the package
 	// this image will be unpacked by the package-loader to render the ObjectDeployment for propagating the installation of the package.
-	// +kubebuilder:validation:Required
-	Image string `json:"image"`
+	// Either image or source has to be set.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Alternative source of the package contents,
+	// e.g. to install packages in disconnected clusters without access to an image registry.
+	// Either image or source has to be set.
+	// +optional
+	Source *PackageSource `json:"source,omitempty"`
 	// Package configuration parameters.
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Config *runtime.RawExtension `json:"config,omitempty"`
+	// Follows a channel of the PackageRepository listing the repository of the image.
+	// +optional
+	UpgradePolicy *PackageUpgradePolicy `json:"upgradePolicy,omitempty"`
+	// Labels and annotations added to the pod templates of all workloads of the package,
+	// e.g. to select them in NetworkPolicies or to configure a service mesh.
+	// Other objects of the package are not modified.
+	// +optional
+	PodTemplateMetadata *PackagePodTemplateMetadata `json:"podTemplateMetadata,omitempty"`
+	// Allows managing cluster-critical kinds, e.g. Nodes, APIServices
+	// and admission webhook configurations intercepting Kubernetes API groups.
+	// Objects of these kinds fail preflight checks, unless explicitly allowed.
+	// +optional
+	AllowCriticalKinds bool `json:"allowCriticalKinds,omitempty"`
+	// Patches applied to objects of the package after rendering,
+	// to override settings the package does not expose via its configuration.
+	// +optional
+	Patches []PackageObjectPatch `json:"patches,omitempty"`
+	// Component of a multi-component package to install instead of the package itself.
+	// The component has to be declared in the PackageManifest of the package.
+	// +example=console-plugin
+	// +optional
+	Component string `json:"component,omitempty"`
+}
+
+// PackageSource selects where the contents of a package are loaded from.
+// Exactly one source has to be set.
+type PackageSource struct {
+	// Image containing the contents of the package, same as .spec.image.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// ConfigMap containing the contents of the package.
+	// Every key is a file at the root of the package,
+	// packages with directories can be stored as archive under the "package.tar.gz.b64" key.
+	// +optional
+	ConfigMap *PackageSourceObjectReference `json:"configMap,omitempty"`
+	// Secret containing the contents of the package.
+	// Keys are interpreted the same as for configMap.
+	// +optional
+	Secret *PackageSourceObjectReference `json:"secret,omitempty"`
+	// Path of a directory containing the contents of the package,
+	// relative to the local package directory mounted into Package Operator.
+	// +example=my-package/v1.2.3
+	// +optional
+	LocalPath string `json:"localPath,omitempty"`
+}
+
+// PackageSourceObjectReference references a ConfigMap or Secret containing the contents of a package.
+type PackageSourceObjectReference struct {
+	// Name of the object.
+	Name string `json:"name"`
+	// Namespace of the object.
+	// Required for ClusterPackages, Packages can only reference objects in their own namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// PackagePodTemplateMetadata is merged into the pod templates of all workloads of a package.
+// Labels and annotations already set by the package itself take precedence.
+type PackagePodTemplateMetadata struct {
+	// Labels added to pod templates.
+	// +example={team: payments}
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations added to pod templates.
+	// +example={sidecar.istio.io/inject: "true"}
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Adds the package name and instance labels of Package Operator to pod templates.
+	// +optional
+	PackageLabels bool `json:"packageLabels,omitempty"`
+}
+
+// PackageObjectPatch overrides parts of an object of the package.
+type PackageObjectPatch struct {
+	// Object of the package to patch.
+	Target PackageObjectPatchTarget `json:"target"`
+	// Type of the patch.
+	// JSON6902 patches are lists of JSON patch operations,
+	// StrategicMerge patches are partial objects merged into the target.
+	// Kinds unknown to Package Operator are merged as JSON merge patch (RFC 7386).
+	// +kubebuilder:default=JSON6902
+	// +kubebuilder:validation:Enum=JSON6902;StrategicMerge
+	// +optional
+	Type PackageObjectPatchType `json:"type,omitempty"`
+	// Patch in YAML or JSON.
+	Patch string `json:"patch"`
+}
+
+// PackageObjectPatchType is the format of a PackageObjectPatch.
+type PackageObjectPatchType string
+
+const (
+	// JSON patch as defined in RFC 6902.
+	PackageObjectPatchTypeJSON6902 PackageObjectPatchType = "JSON6902"
+	// Kubernetes strategic merge patch.
+	PackageObjectPatchTypeStrategicMerge PackageObjectPatchType = "StrategicMerge"
+)
+
+// PackageObjectPatchTarget selects an object of a package by group, kind, name and namespace.
+type PackageObjectPatchTarget struct {
+	// API group of the object, empty for the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Kind of the object.
+	// +example=Deployment
+	Kind string `json:"kind"`
+	// Name of the object.
+	Name string `json:"name"`
+	// Namespace of the object.
+	// Matches objects in any namespace, if empty.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// PackageUpgradePolicy tracks new versions of the package in a PackageRepository channel.
+type PackageUpgradePolicy struct {
+	// Name of the PackageRepository channel to follow.
+	// +example=stable
+	Channel string `json:"channel"`
+	// Updates the image to the latest version of the channel, once the Package is Available.
+	// The image is pinned to the digest of the latest version, if known.
+	// Otherwise newer versions are only reported in status.
+	// +optional
+	Auto bool `json:"auto,omitempty"`
 }
@@ -0,0 +1,105 @@
+package v1alpha1
+
+// Condition Reasons reported by Package Operator.
+// Automation may rely on these values, they are not changed without notice.
+// Reasons of conditions mapped from objects via probes are passed through unchanged.
+const (
+	// Objects
+
+	// All objects pass their availability probes.
+	ReasonAvailable = "Available"
+	// At least one object failed its availability probe.
+	ReasonProbeFailure = "ProbeFailure"
+	// Objects would violate preflight checks, e.g. because an API is not installed.
+	ReasonPreflightViolation = "PreflightViolation"
+	// Objects are handed over between revisions.
+	ReasonInTransition = "InTransition"
+	// Revision became available for the first time.
+	ReasonRolloutSuccess = "RolloutSuccess"
+	// Lifecycle state is set to paused.
+	ReasonPaused = "Paused"
+	// Some, but not all remote phases are paused.
+	ReasonPartiallyPaused = "PartiallyPaused"
+	// Package Operator is in maintenance mode.
+	ReasonMaintenanceMode = "MaintenanceMode"
+	// Package Operator is in observe-only mode.
+	ReasonObserveOnly = "ObserveOnly"
+	// Objects are being torn down for archival.
+	ReasonArchivalInProgress = "ArchivalInProgress"
+	// All objects have been torn down and the revision is archived.
+	ReasonArchived = "Archived"
+	// A remote phase manager stopped sending heartbeats.
+	ReasonHeartbeatExpired = "HeartbeatExpired"
+	// Target cluster rejected the credentials of a remote phase manager.
+	ReasonUnauthorized = "Unauthorized"
+	// Another field manager changed fields of objects, that Package Operator took over again.
+	ReasonFieldManagerConflict = "FieldManagerConflict"
+	// Objects can't be reconciled until they are changed, e.g. because the apiserver rejects them.
+	ReasonUserFixRequired = "UserFixRequired"
+	// Objects can't be reconciled and retrying won't help, e.g. because of a revision collision.
+	ReasonTerminalError = "TerminalError"
+
+	// ObjectSetPhases
+
+	// ObjectSetPhase is not controlled by an ObjectSet.
+	ReasonMissingParent = "MissingParent"
+	// Phase is not declared by the parent ObjectSet.
+	ReasonPhaseNotFound = "PhaseNotFound"
+	// Phase is declared more than once by the parent ObjectSet.
+	ReasonDuplicatePhase = "DuplicatePhase"
+	// Class does not match the class of the phase in the parent ObjectSet.
+	ReasonClassMismatch = "ClassMismatch"
+	// ObjectSetPhase does not match its parent ObjectSet.
+	ReasonInvalid = "Invalid"
+
+	// ObjectDeployments
+
+	// Latest revision is not yet available.
+	ReasonObjectSetUnready = "ObjectSetUnready"
+	// No rollout in progress.
+	ReasonIdle = "Idle"
+	// Latest revision has not yet become available for the first time.
+	ReasonLatestRevisionPendingSuccess = "LatestRevisionPendingSuccess"
+	// Rollout of a new revision is in progress.
+	ReasonProgressing = "Progressing"
+
+	// Packages
+
+	// Package image is being unpacked.
+	ReasonUnpacking = "Unpacking"
+	// Package image could not be pulled or unpacked.
+	ReasonUnpackFailure = "UnpackFailure"
+	// Package image was unpacked successfully.
+	ReasonUnpackSuccess = "UnpackSuccess"
+	// Package contents could not be loaded.
+	ReasonLoadError = "LoadError"
+	// Package configuration does not match the config schema of the PackageManifest.
+	ReasonInvalidConfiguration = "InvalidConfiguration"
+	// Cluster does not satisfy the constraints of the PackageManifest.
+	ReasonConstraintsNotSatisfied = "ConstraintsNotSatisfied"
+	// ObjectDeployment of the Package has not yet reported on its latest generation.
+	ReasonPending = "Pending"
+
+	// ObjectTemplates
+
+	// Source objects could not be read.
+	ReasonSourceError = "SourceError"
+	// Template could not be rendered.
+	ReasonTemplateError = "TemplateError"
+	// Waiting for previously templated objects to be deleted.
+	ReasonDeleting = "Deleting"
+	// Templated object already exists and is controlled by another object.
+	ReasonObjectCollision = "ObjectCollision"
+
+	// PackageRepositories
+
+	// Repository index could not be listed.
+	ReasonListFailed = "ListFailed"
+	// Repository index is synced.
+	ReasonSynced = "Synced"
+
+	// PackageFleets
+
+	// Packages in some target namespaces are unavailable or could not be created.
+	ReasonPackagesUnavailable = "PackagesUnavailable"
+)
@@ -0,0 +1,17 @@
+package v1alpha1
+
+// v1alpha1 is the conversion hub of the ObjectSet and ObjectDeployment APIs.
+// Newer API versions implement conversion from and to these types,
+// so the conversion webhook can translate stored objects between all served versions.
+
+// Hub marks this type as a conversion hub.
+func (*ObjectSet) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*ClusterObjectSet) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*ObjectDeployment) Hub() {}
+
+// Hub marks this type as a conversion hub.
+func (*ClusterObjectDeployment) Hub() {}
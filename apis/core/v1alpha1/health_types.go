@@ -0,0 +1,33 @@
+package v1alpha1
+
+// Health summarizes the conditions of an object into a single status,
+// using the health statuses of ArgoCD, so custom health checks only need to copy it.
+type Health struct {
+	// Health status of the object.
+	Status HealthStatus `json:"status"`
+	// Human readable message explaining the status.
+	Message string `json:"message,omitempty"`
+}
+
+// HealthStatus is the summarized health of an object.
+// +kubebuilder:validation:Enum=Healthy;Progressing;Degraded;Suspended
+type HealthStatus string
+
+// Health statuses ordered by severity.
+const (
+	// The object is available.
+	HealthStatusHealthy HealthStatus = "Healthy"
+	// The object is paused and does not roll out changes.
+	HealthStatusSuspended HealthStatus = "Suspended"
+	// The object is not yet available or rolls out a change, but may become healthy without intervention.
+	HealthStatusProgressing HealthStatus = "Progressing"
+	// The object is not available or failed to roll out a change.
+	HealthStatusDegraded HealthStatus = "Degraded"
+)
+
+// ArgoCDApplicationAnnotation enables the ArgoCD compatibility mode
+// for the objects of Packages, ObjectDeployments and ObjectSets.
+// Objects carry the tracking label and annotation of the ArgoCD Application named in the annotation value,
+// so they show up in its resource tree, but are never pruned by ArgoCD.
+// Fields managed by ArgoCD are not taken over, to not fight about objects included in the Application.
+const ArgoCDApplicationAnnotation = "package-operator.run/argocd-application"
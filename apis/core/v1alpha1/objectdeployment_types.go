@@ -13,6 +13,13 @@ type ObjectDeploymentSpec struct {
 	Selector metav1.LabelSelector `json:"selector"`
 	// Template to create new ObjectSets from.
 	Template ObjectSetTemplate `json:"template"`
+	// Specifies how ObjectSets created from this ObjectDeployment are named.
+	// +kubebuilder:default=TemplateHash
+	// +kubebuilder:validation:Enum=TemplateHash;Revision
+	ObjectSetNaming ObjectSetNamingStrategy `json:"objectSetNaming,omitempty"`
+	// Pauses the active ObjectSets and stops rolling out new revisions.
+	// Status is still reported while paused.
+	Paused bool `json:"paused,omitempty"`
 }
 
 // ObjectSetTemplate describes the template to create new ObjectSets from.
@@ -23,6 +30,19 @@ type ObjectSetTemplate struct {
 	Spec ObjectSetTemplateSpec `json:"spec"`
 }
 
+// Specifies how ObjectSets of an ObjectDeployment are named.
+type ObjectSetNamingStrategy string
+
+const (
+	// "TemplateHash" names ObjectSets "<deployment-name>-<template-hash>",
+	// like Deployments name their ReplicaSets. This is the default.
+	ObjectSetNamingTemplateHash ObjectSetNamingStrategy = "TemplateHash"
+	// "Revision" names ObjectSets "<deployment-name>-<revision>",
+	// so revision names can be predicted from the previous revision.
+	// After hash collisions the collision count is appended as well.
+	ObjectSetNamingRevision ObjectSetNamingStrategy = "Revision"
+)
+
 // ObjectDeploymentStatus defines the observed state of a ObjectDeployment.
 type ObjectDeploymentStatus struct {
 	// Conditions is a list of status conditions ths object is in.
@@ -37,12 +57,22 @@ type ObjectDeploymentStatus struct {
 	TemplateHash string `json:"templateHash,omitempty"`
 	// Deployment revision.
 	Revision int64 `json:"revision,omitempty"`
+	// Fields of objects projected via FieldMappings, keyed by destination.
+	// +example={"my-package.example.com/endpoint": "db.example.svc:5432"}
+	MappedFields map[string]string `json:"mappedFields,omitempty"`
+	// Objects controlled by the revisions of this deployment that are not archived.
+	// During a rollout, objects of the new and previous revisions are listed.
+	ControllerOf []ControlledObjectReference `json:"controllerOf,omitempty"`
+	// Health of the deployment, summarizing its conditions.
+	Health *Health `json:"health,omitempty"`
 }
 
 // ObjectDeployment Condition Types.
 const (
 	ObjectDeploymentAvailable   = "Available"
 	ObjectDeploymentProgressing = "Progressing"
+	// Paused is True, while .spec.paused is set and new revisions are not rolled out.
+	ObjectDeploymentPaused = "Paused"
 )
 
 type ObjectDeploymentPhase string
@@ -54,6 +84,7 @@ const (
 	ObjectDeploymentPhaseAvailable   ObjectDeploymentPhase = "Available"
 	ObjectDeploymentPhaseNotReady    ObjectDeploymentPhase = "NotReady"
 	ObjectDeploymentPhaseProgressing ObjectDeploymentPhase = "Progressing"
+	ObjectDeploymentPhasePaused      ObjectDeploymentPhase = "Paused"
 )
 
 // ObjectDeployment is the Schema for the ObjectDeployments API
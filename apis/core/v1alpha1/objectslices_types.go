@@ -12,6 +12,10 @@ type ObjectSlice struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Objects []ObjectSetObject `json:"objects"`
+	// SHA-256 checksum of the JSON encoded objects.
+	// Verified when the slice is loaded, to detect modified or truncated slices.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
 }
 
 // ObjectSliceList contains a list of ObjectSlices.
@@ -0,0 +1,74 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// PackageFleet rolls out the same Package into every namespace matching a selector,
+// e.g. into every tenant namespace or into the namespaces of remote phase managers
+// targeting external clusters, and aggregates the rollout status of all Packages.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Targets",type="integer",JSONPath=".status.targetCount"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyCount"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failedCount"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PackageFleet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PackageFleetSpec   `json:"spec,omitempty"`
+	Status PackageFleetStatus `json:"status,omitempty"`
+}
+
+// PackageFleetSpec selects the target namespaces and templates their Packages.
+type PackageFleetSpec struct {
+	// Selects the namespaces to create a Package in.
+	// Packages in namespaces no longer matching the selector are deleted.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	// Template of the Packages created in the selected namespaces.
+	Template PackageTemplate `json:"template"`
+}
+
+// PackageTemplate is the template of Packages created by a PackageFleet.
+type PackageTemplate struct {
+	// Labels and annotations of the Packages.
+	// Packages are named after the PackageFleet.
+	Metadata metav1.ObjectMeta `json:"metadata"`
+	// Package specification.
+	Spec PackageSpec `json:"spec"`
+}
+
+// PackageFleetStatus aggregates the status of all Packages of the fleet.
+type PackageFleetStatus struct {
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Number of namespaces selected by the fleet.
+	TargetCount int32 `json:"targetCount,omitempty"`
+	// Number of Packages that are available and have rolled out their latest spec.
+	ReadyCount int32 `json:"readyCount,omitempty"`
+	// Number of Packages still rolling out their latest spec.
+	ProgressingCount int32 `json:"progressingCount,omitempty"`
+	// Number of Packages that are unavailable or could not be created.
+	FailedCount int32 `json:"failedCount,omitempty"`
+}
+
+// PackageFleet condition types.
+const (
+	// Available is True when the Packages in all target namespaces are available.
+	PackageFleetAvailable = "Available"
+	// Progressing is True while Packages of the fleet are rolling out.
+	PackageFleetProgressing = "Progressing"
+)
+
+// Label on Packages referencing the PackageFleet they were created by.
+const PackageFleetLabel = "package-operator.run/package-fleet"
+
+// PackageFleetList contains a list of PackageFleets.
+// +kubebuilder:object:root=true
+type PackageFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PackageFleet `json:"items"`
+}
+
+func init() { register(&PackageFleet{}, &PackageFleetList{}) }
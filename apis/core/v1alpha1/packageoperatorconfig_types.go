@@ -0,0 +1,222 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PackageOperatorConfig configures the Package Operator itself.
+// Only the instance named "cluster" is honored.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Maintenance",type="boolean",JSONPath=".spec.maintenanceMode"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PackageOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PackageOperatorConfigSpec `json:"spec,omitempty"`
+}
+
+// PackageOperatorConfigSpec defines the desired configuration of Package Operator.
+type PackageOperatorConfigSpec struct {
+	// Stops Package Operator from changing any object on the cluster.
+	// Objects are still read and their status is still reported.
+	// Can be overridden per Package via the package-operator.run/maintenance-mode annotation.
+	// +optional
+	MaintenanceMode bool `json:"maintenanceMode,omitempty"`
+	// Overrides the deletion policy of all objects managed by Package Operator.
+	// Set to "Orphan" before uninstalling or replacing Package Operator,
+	// to only remove owner references and finalizers on teardown,
+	// leaving all installed objects running.
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	// +example=Orphan
+	// +optional
+	DeletionPolicy ObjectSetObjectDeletionPolicy `json:"deletionPolicy,omitempty"`
+	// Restricts the objects ClusterObjectTemplates may read as sources,
+	// so they can't be used to expose e.g. Secrets of arbitrary namespaces.
+	// ClusterObjectTemplates may read any object, if not set.
+	// +optional
+	ClusterObjectTemplateSourcePolicy *ObjectTemplateSourcePolicy `json:"clusterObjectTemplateSourcePolicy,omitempty"`
+	// Requires Packages and ClusterPackages to reference their image by digest,
+	// so rollouts are reproducible even when tags are moved.
+	// The Package webhook rejects Packages referencing a tag,
+	// existing Packages still referencing a tag fail to unpack.
+	// +optional
+	RequireImageDigests bool `json:"requireImageDigests,omitempty"`
+	// Denies objects, that Packages are not allowed to deploy,
+	// e.g. hostPath volumes or bindings to cluster-admin.
+	// Phases containing denied objects fail their preflight checks.
+	// +optional
+	PreflightPolicy *PreflightPolicy `json:"preflightPolicy,omitempty"`
+	// Decides which existing objects phases may take over.
+	// "Annotated" only adopts objects controlled by previous revisions
+	// or marked with the package-operator.run/adopt-into annotation.
+	// "Uncontrolled" additionally adopts all objects without controller.
+	// "Always" adopts every object, even if controlled by someone else.
+	// Defaults to "Annotated".
+	// +kubebuilder:validation:Enum=Annotated;Uncontrolled;Always
+	// +example=Annotated
+	// +optional
+	AdoptionPolicy AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+	// Mirrors to try before pulling package images from their source,
+	// in addition to mirrors configured via the --registry-mirrors flag.
+	// +optional
+	RegistryMirrors []RegistryMirror `json:"registryMirrors,omitempty"`
+	// Number of objects controllers reconcile concurrently.
+	// Only read when the manager starts, manager flags and environment variables take precedence.
+	// +optional
+	Concurrency *ConcurrencyConfig `json:"concurrency,omitempty"`
+	// Number of unpacked package images to cache by image digest.
+	// Set to 0 to disable caching.
+	// Only read when the manager starts, manager flags and environment variables take precedence.
+	// +example=64
+	// +optional
+	PackageCacheSize *int32 `json:"packageCacheSize,omitempty"`
+	// Configures the metrics endpoint of the manager.
+	// Only read when the manager starts, manager flags and environment variables take precedence.
+	// +optional
+	Metrics *MetricsConfig `json:"metrics,omitempty"`
+}
+
+// AdoptionPolicy decides which existing objects phases may take over.
+type AdoptionPolicy string
+
+const (
+	// Only adopt objects controlled by previous revisions or marked for adoption.
+	AdoptionPolicyAnnotated AdoptionPolicy = "Annotated"
+	// Also adopt objects without controller.
+	AdoptionPolicyUncontrolled AdoptionPolicy = "Uncontrolled"
+	// Adopt every object, even if controlled by someone else.
+	AdoptionPolicyAlways AdoptionPolicy = "Always"
+)
+
+// RegistryMirror redirects pulls of package images under the Source repository prefix
+// to the given Mirror repository prefixes, similar to an ImageContentSourcePolicy.
+type RegistryMirror struct {
+	// Repository prefix to mirror.
+	// +example=quay.io/package-operator
+	Source string `json:"source"`
+	// Repository prefixes to try in order, before pulling from the source.
+	// +example=[mirror.local/package-operator]
+	// +kubebuilder:validation:MinItems=1
+	Mirrors []string `json:"mirrors"`
+}
+
+// ConcurrencyConfig configures how many objects controllers reconcile concurrently.
+type ConcurrencyConfig struct {
+	// Number of objects each controller reconciles concurrently.
+	// +example=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentReconciles *int32 `json:"maxConcurrentReconciles,omitempty"`
+	// Number of concurrent reconciles per controller, overriding maxConcurrentReconciles.
+	// +optional
+	Controllers []ControllerConcurrency `json:"controllers,omitempty"`
+}
+
+// ControllerConcurrency overrides the concurrency of the controller reconciling the given kind.
+type ControllerConcurrency struct {
+	// Kind reconciled by the controller.
+	// +example=ObjectSet
+	Kind string `json:"kind"`
+	// Number of objects the controller reconciles concurrently.
+	// +example=10
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentReconciles int32 `json:"maxConcurrentReconciles"`
+}
+
+// MetricsConfig configures the metrics endpoint of the manager.
+type MetricsConfig struct {
+	// The address the metrics endpoint binds to.
+	// Set to "0" to disable the metrics endpoint.
+	// +example=:8080
+	// +optional
+	BindAddress string `json:"bindAddress,omitempty"`
+}
+
+// PreflightPolicy denies objects of phases, so platform teams can
+// bound what tenant-managed Packages are allowed to deploy.
+type PreflightPolicy struct {
+	// Namespaces of the owners the policy applies to.
+	// Applies to all owners, including cluster-scoped ones, if empty.
+	// +example=[team-a, team-b]
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Objects of these kinds are denied.
+	// +optional
+	DeniedKinds []PreflightPolicyKind `json:"deniedKinds,omitempty"`
+	// RoleBindings and ClusterRoleBindings referencing these ClusterRoles are denied.
+	// +example=[cluster-admin]
+	// +optional
+	DeniedClusterRoles []string `json:"deniedClusterRoles,omitempty"`
+	// Denies Pods and pod templates mounting hostPath volumes.
+	// +optional
+	DenyHostPathVolumes bool `json:"denyHostPathVolumes,omitempty"`
+	// Denies Pods and pod templates with privileged containers.
+	// +optional
+	DenyPrivilegedContainers bool `json:"denyPrivilegedContainers,omitempty"`
+}
+
+// PreflightPolicyKind matches objects by API group and kind.
+type PreflightPolicyKind struct {
+	// API group of matching objects, "*" matches all groups.
+	// Empty for the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Kind of matching objects, "*" matches all kinds.
+	// +example=ClusterRoleBinding
+	Kind string `json:"kind"`
+}
+
+// ObjectTemplateSourcePolicy restricts the objects ObjectTemplates may read as sources.
+// Sources have to match an allow rule and must not match any deny rule.
+type ObjectTemplateSourcePolicy struct {
+	// Sources matching any of these rules are allowed.
+	// All sources are allowed, if empty.
+	// +optional
+	Allow []ObjectTemplateSourceRule `json:"allow,omitempty"`
+	// Sources matching any of these rules are denied, even when allowed.
+	// +optional
+	Deny []ObjectTemplateSourceRule `json:"deny,omitempty"`
+}
+
+// ObjectTemplateSourceRule matches source objects by namespace and kind.
+type ObjectTemplateSourceRule struct {
+	// Namespaces of matching source objects.
+	// Matches all namespaces, if empty.
+	// +example=[team-a, team-b]
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Kinds of matching source objects.
+	// Matches all kinds, if empty.
+	// +optional
+	Kinds []ObjectTemplateSourceRuleKind `json:"kinds,omitempty"`
+}
+
+// ObjectTemplateSourceRuleKind matches source objects by API group and kind.
+type ObjectTemplateSourceRuleKind struct {
+	// API group of matching source objects, "*" matches all groups.
+	// Empty for the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Kind of matching source objects, "*" matches all kinds.
+	// +example=Secret
+	Kind string `json:"kind"`
+}
+
+// PackageOperatorConfigList contains a list of PackageOperatorConfigs.
+// +kubebuilder:object:root=true
+type PackageOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PackageOperatorConfig `json:"items"`
+}
+
+// Name of the PackageOperatorConfig instance that is honored.
+const PackageOperatorConfigName = "cluster"
+
+// Annotation on Packages and ClusterPackages overriding the global maintenance mode.
+// Set to "true" to freeze changes to a single Package, or "false" to exempt it.
+const MaintenanceModeAnnotation = "package-operator.run/maintenance-mode"
+
+func init() { register(&PackageOperatorConfig{}, &PackageOperatorConfigList{}) }
@@ -0,0 +1,123 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PackageRepository lists the versions of packages published to OCI repositories,
+// to discover packages available for installation and upgrades.
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Packages",type="integer",JSONPath=".status.packageCount"
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PackageRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Spec   PackageRepositorySpec   `json:"spec,omitempty"`
+	Status PackageRepositoryStatus `json:"status,omitempty"`
+}
+
+// PackageRepositorySpec specifies where to discover packages.
+// +kubebuilder:validation:XValidation:rule="(has(self.repositories) && size(self.repositories) > 0) || (has(self.catalog) && size(self.catalog) > 0)",message="at least one of repositories or catalog is required"
+type PackageRepositorySpec struct {
+	// OCI repositories containing package images, one repository per package.
+	// Tags following semantic versioning are listed as versions of the package.
+	// +example=["quay.io/package-operator/test-stub-package"]
+	// +optional
+	Repositories []string `json:"repositories,omitempty"`
+	// Catalog image containing a PackageCatalog, listing the repositories of packages
+	// in addition to .spec.repositories. The catalog is pulled again on every refresh,
+	// to discover newly published packages.
+	// +example=quay.io/package-operator/catalog:latest
+	// +optional
+	Catalog string `json:"catalog,omitempty"`
+	// Interval in which available versions are refreshed.
+	// Defaults to 1h.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+	// Creates an AvailablePackage object for every package in this repository,
+	// so packages can be listed via `kubectl get availablepackages`.
+	// +optional
+	CreateAvailablePackages bool `json:"createAvailablePackages,omitempty"`
+	// Channels group versions of all packages in this repository,
+	// e.g. "stable" or "candidate". Packages can follow a channel via their upgrade policy.
+	// +optional
+	Channels []PackageRepositoryChannel `json:"channels,omitempty"`
+}
+
+// PackageRepositoryChannel selects versions of packages via semantic version constraints.
+type PackageRepositoryChannel struct {
+	// Name of the channel.
+	Name string `json:"name"`
+	// Semantic version constraint selecting the versions in this channel.
+	// Pre-releases are only selected, if the constraint contains a pre-release.
+	// +example=">= 1.0.0"
+	Versions string `json:"versions"`
+	// Maximum number or percentage of packages following this channel,
+	// that may be unavailable at the same time during automatic upgrades.
+	// Packages are only upgraded, while fewer packages of the same kind
+	// following this channel are unavailable or still progressing.
+	// Defaults to no limit.
+	// +example=10%
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// PackageRepositoryStatus lists the packages found in the repository.
+type PackageRepositoryStatus struct {
+	// Conditions is a list of status conditions ths object is in.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Packages available in this repository.
+	Packages []RepositoryPackage `json:"packages,omitempty"`
+	// Number of packages available in this repository.
+	PackageCount int `json:"packageCount,omitempty"`
+	// Last time available versions were refreshed.
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// RepositoryPackage is a package found in a PackageRepository.
+type RepositoryPackage struct {
+	// Name of the package, the last path element of its repository.
+	Name string `json:"name"`
+	// OCI repository of the package.
+	Repository string `json:"repository"`
+	// Available versions, latest first.
+	Versions []string `json:"versions,omitempty"`
+	// Latest available version.
+	LatestVersion string `json:"latestVersion,omitempty"`
+	// Latest version of the package in each channel of the repository.
+	Channels []RepositoryPackageChannel `json:"channels,omitempty"`
+}
+
+// RepositoryPackageChannel is the latest version of a package in a channel.
+type RepositoryPackageChannel struct {
+	// Name of the channel.
+	Name string `json:"name"`
+	// Latest version in this channel.
+	LatestVersion string `json:"latestVersion,omitempty"`
+	// Digest of the image of the latest version in this channel.
+	// Automatic upgrades pin this digest, so all packages following
+	// the channel roll out the same image.
+	LatestDigest string `json:"latestDigest,omitempty"`
+}
+
+// PackageRepository condition types.
+const (
+	// Synced is True when all repositories have been listed successfully.
+	PackageRepositorySynced = "package-operator.run/Synced"
+)
+
+// PackageRepositoryList contains a list of PackageRepositories.
+// +kubebuilder:object:root=true
+type PackageRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PackageRepository `json:"items"`
+}
+
+func init() { register(&PackageRepository{}, &PackageRepositoryList{}) }
@@ -0,0 +1,83 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PhaseClass maps the class of ObjectSet phases to the handler executing them.
+// The name of the PhaseClass is the class name referenced by phases.
+// Phases with a class that has no PhaseClass are delegated to remote phase managers
+// via ObjectSetPhase objects, unless a handler was registered in-process for the class.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Handler",type="string",JSONPath=".spec.handler"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PhaseClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PhaseClassSpec `json:"spec,omitempty"`
+}
+
+// PhaseClassSpec defines the handler of a phase class.
+type PhaseClassSpec struct {
+	// Type of handler executing phases of this class.
+	// +kubebuilder:validation:Enum=InProcess;RemotePhaseManager;Webhook
+	// +kubebuilder:default=RemotePhaseManager
+	// +example=Webhook
+	Handler PhaseClassHandler `json:"handler"`
+	// Configures the InProcess handler.
+	// +optional
+	InProcess *PhaseClassInProcess `json:"inProcess,omitempty"`
+	// Configures the Webhook handler, required for the Webhook handler.
+	// +optional
+	Webhook *PhaseClassWebhook `json:"webhook,omitempty"`
+}
+
+type PhaseClassHandler string
+
+const (
+	// Phases are executed by a handler registered with the Package Operator manager.
+	PhaseClassHandlerInProcess PhaseClassHandler = "InProcess"
+	// Phases are delegated to remote phase managers via ObjectSetPhase objects.
+	PhaseClassHandlerRemotePhaseManager PhaseClassHandler = "RemotePhaseManager"
+	// Phases are delegated to an external webhook.
+	PhaseClassHandlerWebhook PhaseClassHandler = "Webhook"
+)
+
+// PhaseClassInProcess configures a handler registered with the Package Operator manager.
+type PhaseClassInProcess struct {
+	// Name the handler was registered with.
+	// Defaults to the name of the PhaseClass.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// PhaseClassWebhook configures an external webhook executing phases.
+// The webhook receives a JSON encoded request for each phase reconciliation or teardown
+// and reports the objects it controls and failing probes in its response.
+type PhaseClassWebhook struct {
+	// HTTPS URL of the webhook.
+	// +example=https://terraform-phases.example.svc/phase
+	URL string `json:"url"`
+	// PEM encoded CA bundle to validate the certificate of the webhook.
+	// Uses the system trust store, if not set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// Timeout of a single webhook call in seconds.
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// PhaseClassList contains a list of PhaseClasses.
+// +kubebuilder:object:root=true
+type PhaseClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PhaseClass `json:"items"`
+}
+
+func init() { register(&PhaseClass{}, &PhaseClassList{}) }
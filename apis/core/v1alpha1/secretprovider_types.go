@@ -0,0 +1,120 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SecretProvider configures access to an external secret manager,
+// so ObjectTemplates can consume secrets that never exist as Kubernetes Secrets.
+// Credentials are read from Secrets in the namespace of the SecretProvider.
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type SecretProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SecretProviderSpec `json:"spec,omitempty"`
+}
+
+// SecretProviderSpec configures the external secret manager.
+type SecretProviderSpec struct {
+	// Type of the external secret manager.
+	// +kubebuilder:validation:Enum=Vault;AWSSecretsManager;GCPSecretManager
+	// +example=Vault
+	Type SecretProviderType `json:"type"`
+	// Interval in which ObjectTemplates reading secrets from this provider are refreshed,
+	// as external secret managers do not emit events on changes.
+	// Defaults to 5m.
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+	// Configures HashiCorp Vault, required for the Vault type.
+	// +optional
+	Vault *SecretProviderVault `json:"vault,omitempty"`
+	// Configures AWS Secrets Manager, required for the AWSSecretsManager type.
+	// +optional
+	AWSSecretsManager *SecretProviderAWSSecretsManager `json:"awsSecretsManager,omitempty"`
+	// Configures GCP Secret Manager, required for the GCPSecretManager type.
+	// +optional
+	GCPSecretManager *SecretProviderGCPSecretManager `json:"gcpSecretManager,omitempty"`
+}
+
+type SecretProviderType string
+
+const (
+	// Secrets are read from the KV version 2 secrets engine of HashiCorp Vault.
+	SecretProviderTypeVault SecretProviderType = "Vault"
+	// Secrets are read from AWS Secrets Manager.
+	SecretProviderTypeAWSSecretsManager SecretProviderType = "AWSSecretsManager"
+	// Secrets are read from GCP Secret Manager.
+	SecretProviderTypeGCPSecretManager SecretProviderType = "GCPSecretManager"
+)
+
+// SecretProviderVault configures access to HashiCorp Vault via token authentication.
+type SecretProviderVault struct {
+	// Address of the Vault server.
+	// +example=https://vault.example.com:8200
+	Address string `json:"address"`
+	// Mount path of the KV version 2 secrets engine.
+	// +kubebuilder:default=secret
+	// +optional
+	Mount string `json:"mount,omitempty"`
+	// Vault Enterprise namespace of the secrets engine.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Secret key containing the Vault token.
+	TokenSecretRef SecretProviderSecretKeyRef `json:"tokenSecretRef"`
+	// PEM encoded CA bundle to validate the certificate of the Vault server.
+	// Uses the system trust store, if not set.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// SecretProviderAWSSecretsManager configures access to AWS Secrets Manager via access keys.
+type SecretProviderAWSSecretsManager struct {
+	// AWS region of the secrets.
+	// +example=eu-central-1
+	Region string `json:"region"`
+	// Overrides the Secrets Manager endpoint of the region, e.g. to use a VPC endpoint.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Secret containing the "accessKeyID" and "secretAccessKey" keys
+	// and optionally a "sessionToken" key.
+	CredentialsSecretRef SecretProviderSecretRef `json:"credentialsSecretRef"`
+}
+
+// SecretProviderGCPSecretManager configures access to GCP Secret Manager via a service account key.
+type SecretProviderGCPSecretManager struct {
+	// ID of the project containing the secrets.
+	// +example=my-project
+	ProjectID string `json:"projectID"`
+	// Overrides the Secret Manager endpoint, e.g. to use a regional endpoint.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Secret key containing the JSON key of a service account with access to the secrets.
+	ServiceAccountKeySecretRef SecretProviderSecretKeyRef `json:"serviceAccountKeySecretRef"`
+}
+
+// SecretProviderSecretRef references a Secret in the namespace of the SecretProvider.
+type SecretProviderSecretRef struct {
+	// Name of the Secret.
+	Name string `json:"name"`
+}
+
+// SecretProviderSecretKeyRef references a key of a Secret in the namespace of the SecretProvider.
+type SecretProviderSecretKeyRef struct {
+	// Name of the Secret.
+	Name string `json:"name"`
+	// Key within the Secret.
+	Key string `json:"key"`
+}
+
+// SecretProviderList contains a list of SecretProviders.
+// +kubebuilder:object:root=true
+type SecretProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretProvider `json:"items"`
+}
+
+func init() { register(&SecretProvider{}, &SecretProviderList{}) }
@@ -6,10 +6,70 @@
 package v1alpha1
 
 import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailablePackage) DeepCopyInto(out *AvailablePackage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Package.DeepCopyInto(&out.Package)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailablePackage.
+func (in *AvailablePackage) DeepCopy() *AvailablePackage {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailablePackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AvailablePackage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailablePackageList) DeepCopyInto(out *AvailablePackageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AvailablePackage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailablePackageList.
+func (in *AvailablePackageList) DeepCopy() *AvailablePackageList {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailablePackageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AvailablePackageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterObjectDeployment) DeepCopyInto(out *ClusterObjectDeployment) {
 	*out = *in
@@ -106,6 +166,23 @@ func (in *ClusterObjectDeploymentStatus) DeepCopyInto(out *ClusterObjectDeployme
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MappedFields != nil {
+		in, out := &in.MappedFields, &out.MappedFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ControllerOf != nil {
+		in, out := &in.ControllerOf, &out.ControllerOf
+		*out = make([]ControlledObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(Health)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObjectDeploymentStatus.
@@ -265,6 +342,11 @@ func (in *ClusterObjectSetPhaseSpec) DeepCopyInto(out *ClusterObjectSetPhaseSpec
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ApplyPolicy != nil {
+		in, out := &in.ApplyPolicy, &out.ApplyPolicy
+		*out = new(ObjectSetApplyPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObjectSetPhaseSpec.
@@ -292,6 +374,18 @@ func (in *ClusterObjectSetPhaseStatus) DeepCopyInto(out *ClusterObjectSetPhaseSt
 		*out = make([]ControlledObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.FailedProbes != nil {
+		in, out := &in.FailedProbes, &out.FailedProbes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MappedFields != nil {
+		in, out := &in.MappedFields, &out.MappedFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObjectSetPhaseStatus.
@@ -345,6 +439,39 @@ func (in *ClusterObjectSetStatus) DeepCopyInto(out *ClusterObjectSetStatus) {
 		*out = make([]ControlledObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]ObjectSetObjectConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(ObjectSetRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]ObjectSetRolloutPhase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = make([]ObjectSetObjectDiff, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MappedFields != nil {
+		in, out := &in.MappedFields, &out.MappedFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObjectSetStatus.
@@ -539,6 +666,31 @@ func (in *ClusterPackageList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConcurrencyConfig) DeepCopyInto(out *ConcurrencyConfig) {
+	*out = *in
+	if in.MaxConcurrentReconciles != nil {
+		in, out := &in.MaxConcurrentReconciles, &out.MaxConcurrentReconciles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]ControllerConcurrency, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyConfig.
+func (in *ConcurrencyConfig) DeepCopy() *ConcurrencyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConcurrencyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConditionMapping) DeepCopyInto(out *ConditionMapping) {
 	*out = *in
@@ -569,6 +721,66 @@ func (in *ControlledObjectReference) DeepCopy() *ControlledObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerConcurrency) DeepCopyInto(out *ControllerConcurrency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConcurrency.
+func (in *ControllerConcurrency) DeepCopy() *ControllerConcurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerConcurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldMapping) DeepCopyInto(out *FieldMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldMapping.
+func (in *FieldMapping) DeepCopy() *FieldMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Health) DeepCopyInto(out *Health) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Health.
+func (in *Health) DeepCopy() *Health {
+	if in == nil {
+		return nil
+	}
+	out := new(Health)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsConfig) DeepCopyInto(out *MetricsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsConfig.
+func (in *MetricsConfig) DeepCopy() *MetricsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectDeployment) DeepCopyInto(out *ObjectDeployment) {
 	*out = *in
@@ -665,6 +877,23 @@ func (in *ObjectDeploymentStatus) DeepCopyInto(out *ObjectDeploymentStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MappedFields != nil {
+		in, out := &in.MappedFields, &out.MappedFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ControllerOf != nil {
+		in, out := &in.ControllerOf, &out.ControllerOf
+		*out = make([]ControlledObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(Health)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectDeploymentStatus.
@@ -704,6 +933,31 @@ func (in *ObjectSet) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSetApplyPolicy) DeepCopyInto(out *ObjectSetApplyPolicy) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetApplyPolicy.
+func (in *ObjectSetApplyPolicy) DeepCopy() *ObjectSetApplyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectSetApplyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectSetList) DeepCopyInto(out *ObjectSetList) {
 	*out = *in
@@ -745,6 +999,16 @@ func (in *ObjectSetObject) DeepCopyInto(out *ObjectSetObject) {
 		*out = make([]ConditionMapping, len(*in))
 		copy(*out, *in)
 	}
+	if in.FieldMappings != nil {
+		in, out := &in.FieldMappings, &out.FieldMappings
+		*out = make([]FieldMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApplyPolicy != nil {
+		in, out := &in.ApplyPolicy, &out.ApplyPolicy
+		*out = new(ObjectSetApplyPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetObject.
@@ -757,6 +1021,49 @@ func (in *ObjectSetObject) DeepCopy() *ObjectSetObject {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSetObjectConflict) DeepCopyInto(out *ObjectSetObjectConflict) {
+	*out = *in
+	out.Object = in.Object
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastObservedTime.DeepCopyInto(&out.LastObservedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetObjectConflict.
+func (in *ObjectSetObjectConflict) DeepCopy() *ObjectSetObjectConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectSetObjectConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSetObjectDiff) DeepCopyInto(out *ObjectSetObjectDiff) {
+	*out = *in
+	out.Object = in.Object
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetObjectDiff.
+func (in *ObjectSetObjectDiff) DeepCopy() *ObjectSetObjectDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectSetObjectDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectSetPhase) DeepCopyInto(out *ObjectSetPhase) {
 	*out = *in
@@ -845,6 +1152,11 @@ func (in *ObjectSetPhaseSpec) DeepCopyInto(out *ObjectSetPhaseSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ApplyPolicy != nil {
+		in, out := &in.ApplyPolicy, &out.ApplyPolicy
+		*out = new(ObjectSetApplyPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetPhaseSpec.
@@ -872,6 +1184,18 @@ func (in *ObjectSetPhaseStatus) DeepCopyInto(out *ObjectSetPhaseStatus) {
 		*out = make([]ControlledObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.FailedProbes != nil {
+		in, out := &in.FailedProbes, &out.FailedProbes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MappedFields != nil {
+		in, out := &in.MappedFields, &out.MappedFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetPhaseStatus.
@@ -907,6 +1231,49 @@ func (in *ObjectSetProbe) DeepCopy() *ObjectSetProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSetRolloutPhase) DeepCopyInto(out *ObjectSetRolloutPhase) {
+	*out = *in
+	if in.FailedProbes != nil {
+		in, out := &in.FailedProbes, &out.FailedProbes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AvailableAt != nil {
+		in, out := &in.AvailableAt, &out.AvailableAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetRolloutPhase.
+func (in *ObjectSetRolloutPhase) DeepCopy() *ObjectSetRolloutPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectSetRolloutPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSetRolloutStatus) DeepCopyInto(out *ObjectSetRolloutStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetRolloutStatus.
+func (in *ObjectSetRolloutStatus) DeepCopy() *ObjectSetRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectSetRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectSetSpec) DeepCopyInto(out *ObjectSetSpec) {
 	*out = *in
@@ -948,6 +1315,39 @@ func (in *ObjectSetStatus) DeepCopyInto(out *ObjectSetStatus) {
 		*out = make([]ControlledObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]ObjectSetObjectConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(ObjectSetRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]ObjectSetRolloutPhase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = make([]ObjectSetObjectDiff, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MappedFields != nil {
+		in, out := &in.MappedFields, &out.MappedFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetStatus.
@@ -999,6 +1399,11 @@ func (in *ObjectSetTemplatePhase) DeepCopyInto(out *ObjectSetTemplatePhase) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ApplyPolicy != nil {
+		in, out := &in.ApplyPolicy, &out.ApplyPolicy
+		*out = new(ObjectSetApplyPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSetTemplatePhase.
@@ -1132,20 +1537,42 @@ func (in *ObjectTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectTemplateList) DeepCopyInto(out *ObjectTemplateList) {
+func (in *ObjectTemplateExternalSource) DeepCopyInto(out *ObjectTemplateExternalSource) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ObjectTemplate, len(*in))
+		*out = make([]ObjectTemplateSourceItem, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateList.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateExternalSource.
+func (in *ObjectTemplateExternalSource) DeepCopy() *ObjectTemplateExternalSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectTemplateExternalSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectTemplateList) DeepCopyInto(out *ObjectTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ObjectTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateList.
 func (in *ObjectTemplateList) DeepCopy() *ObjectTemplateList {
 	if in == nil {
 		return nil
@@ -1163,13 +1590,30 @@ func (in *ObjectTemplateList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectTemplateObjectReference) DeepCopyInto(out *ObjectTemplateObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateObjectReference.
+func (in *ObjectTemplateObjectReference) DeepCopy() *ObjectTemplateObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectTemplateObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectTemplateSource) DeepCopyInto(out *ObjectTemplateSource) {
 	*out = *in
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
 		*out = make([]ObjectTemplateSourceItem, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -1186,6 +1630,11 @@ func (in *ObjectTemplateSource) DeepCopy() *ObjectTemplateSource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectTemplateSourceItem) DeepCopyInto(out *ObjectTemplateSourceItem) {
 	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateSourceItem.
@@ -1198,6 +1647,75 @@ func (in *ObjectTemplateSourceItem) DeepCopy() *ObjectTemplateSourceItem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectTemplateSourcePolicy) DeepCopyInto(out *ObjectTemplateSourcePolicy) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]ObjectTemplateSourceRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]ObjectTemplateSourceRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateSourcePolicy.
+func (in *ObjectTemplateSourcePolicy) DeepCopy() *ObjectTemplateSourcePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectTemplateSourcePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectTemplateSourceRule) DeepCopyInto(out *ObjectTemplateSourceRule) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]ObjectTemplateSourceRuleKind, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateSourceRule.
+func (in *ObjectTemplateSourceRule) DeepCopy() *ObjectTemplateSourceRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectTemplateSourceRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectTemplateSourceRuleKind) DeepCopyInto(out *ObjectTemplateSourceRuleKind) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateSourceRuleKind.
+func (in *ObjectTemplateSourceRuleKind) DeepCopy() *ObjectTemplateSourceRuleKind {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectTemplateSourceRuleKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectTemplateSpec) DeepCopyInto(out *ObjectTemplateSpec) {
 	*out = *in
@@ -1208,6 +1726,18 @@ func (in *ObjectTemplateSpec) DeepCopyInto(out *ObjectTemplateSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExternalSources != nil {
+		in, out := &in.ExternalSources, &out.ExternalSources
+		*out = make([]ObjectTemplateExternalSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConditionMappings != nil {
+		in, out := &in.ConditionMappings, &out.ConditionMappings
+		*out = make([]ConditionMapping, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateSpec.
@@ -1230,6 +1760,11 @@ func (in *ObjectTemplateStatus) DeepCopyInto(out *ObjectTemplateStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TemplatedObject != nil {
+		in, out := &in.TemplatedObject, &out.TemplatedObject
+		*out = new(ObjectTemplateObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectTemplateStatus.
@@ -1270,31 +1805,26 @@ func (in *Package) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PackageList) DeepCopyInto(out *PackageList) {
+func (in *PackageFleet) DeepCopyInto(out *PackageFleet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Package, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageList.
-func (in *PackageList) DeepCopy() *PackageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageFleet.
+func (in *PackageFleet) DeepCopy() *PackageFleet {
 	if in == nil {
 		return nil
 	}
-	out := new(PackageList)
+	out := new(PackageFleet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PackageList) DeepCopyObject() runtime.Object {
+func (in *PackageFleet) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1302,42 +1832,56 @@ func (in *PackageList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PackageProbeKindSpec) DeepCopyInto(out *PackageProbeKindSpec) {
+func (in *PackageFleetList) DeepCopyInto(out *PackageFleetList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PackageFleet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageProbeKindSpec.
-func (in *PackageProbeKindSpec) DeepCopy() *PackageProbeKindSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageFleetList.
+func (in *PackageFleetList) DeepCopy() *PackageFleetList {
 	if in == nil {
 		return nil
 	}
-	out := new(PackageProbeKindSpec)
+	out := new(PackageFleetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageFleetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PackageSpec) DeepCopyInto(out *PackageSpec) {
+func (in *PackageFleetSpec) DeepCopyInto(out *PackageFleetSpec) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = new(runtime.RawExtension)
-		(*in).DeepCopyInto(*out)
-	}
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.Template.DeepCopyInto(&out.Template)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageSpec.
-func (in *PackageSpec) DeepCopy() *PackageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageFleetSpec.
+func (in *PackageFleetSpec) DeepCopy() *PackageFleetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PackageSpec)
+	out := new(PackageFleetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PackageStatus) DeepCopyInto(out *PackageStatus) {
+func (in *PackageFleetStatus) DeepCopyInto(out *PackageFleetStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1348,122 +1892,1130 @@ func (in *PackageStatus) DeepCopyInto(out *PackageStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageStatus.
-func (in *PackageStatus) DeepCopy() *PackageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageFleetStatus.
+func (in *PackageFleetStatus) DeepCopy() *PackageFleetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PackageStatus)
+	out := new(PackageFleetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PreviousRevisionReference) DeepCopyInto(out *PreviousRevisionReference) {
+func (in *PackageInventoryReference) DeepCopyInto(out *PackageInventoryReference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviousRevisionReference.
-func (in *PreviousRevisionReference) DeepCopy() *PreviousRevisionReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageInventoryReference.
+func (in *PackageInventoryReference) DeepCopy() *PackageInventoryReference {
 	if in == nil {
 		return nil
 	}
-	out := new(PreviousRevisionReference)
+	out := new(PackageInventoryReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Probe) DeepCopyInto(out *Probe) {
+func (in *PackageList) DeepCopyInto(out *PackageList) {
 	*out = *in
-	if in.Condition != nil {
-		in, out := &in.Condition, &out.Condition
-		*out = new(ProbeConditionSpec)
-		**out = **in
-	}
-	if in.FieldsEqual != nil {
-		in, out := &in.FieldsEqual, &out.FieldsEqual
-		*out = new(ProbeFieldsEqualSpec)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Package, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Probe.
-func (in *Probe) DeepCopy() *Probe {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageList.
+func (in *PackageList) DeepCopy() *PackageList {
 	if in == nil {
 		return nil
 	}
-	out := new(Probe)
+	out := new(PackageList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProbeConditionSpec) DeepCopyInto(out *ProbeConditionSpec) {
+func (in *PackageObjectPatch) DeepCopyInto(out *PackageObjectPatch) {
 	*out = *in
+	out.Target = in.Target
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeConditionSpec.
-func (in *ProbeConditionSpec) DeepCopy() *ProbeConditionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageObjectPatch.
+func (in *PackageObjectPatch) DeepCopy() *PackageObjectPatch {
 	if in == nil {
 		return nil
 	}
-	out := new(ProbeConditionSpec)
+	out := new(PackageObjectPatch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProbeFieldsEqualSpec) DeepCopyInto(out *ProbeFieldsEqualSpec) {
+func (in *PackageObjectPatchTarget) DeepCopyInto(out *PackageObjectPatchTarget) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeFieldsEqualSpec.
-func (in *ProbeFieldsEqualSpec) DeepCopy() *ProbeFieldsEqualSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageObjectPatchTarget.
+func (in *PackageObjectPatchTarget) DeepCopy() *PackageObjectPatchTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(ProbeFieldsEqualSpec)
+	out := new(PackageObjectPatchTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProbeSelector) DeepCopyInto(out *ProbeSelector) {
+func (in *PackageOperatorConfig) DeepCopyInto(out *PackageOperatorConfig) {
 	*out = *in
-	if in.Kind != nil {
-		in, out := &in.Kind, &out.Kind
-		*out = new(PackageProbeKindSpec)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageOperatorConfig.
+func (in *PackageOperatorConfig) DeepCopy() *PackageOperatorConfig {
+	if in == nil {
+		return nil
 	}
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	out := new(PackageOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageOperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSelector.
-func (in *ProbeSelector) DeepCopy() *ProbeSelector {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageOperatorConfigList) DeepCopyInto(out *PackageOperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PackageOperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageOperatorConfigList.
+func (in *PackageOperatorConfigList) DeepCopy() *PackageOperatorConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProbeSelector)
+	out := new(PackageOperatorConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageOperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RemotePhaseReference) DeepCopyInto(out *RemotePhaseReference) {
+func (in *PackageOperatorConfigSpec) DeepCopyInto(out *PackageOperatorConfigSpec) {
 	*out = *in
+	if in.ClusterObjectTemplateSourcePolicy != nil {
+		in, out := &in.ClusterObjectTemplateSourcePolicy, &out.ClusterObjectTemplateSourcePolicy
+		*out = new(ObjectTemplateSourcePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreflightPolicy != nil {
+		in, out := &in.PreflightPolicy, &out.PreflightPolicy
+		*out = new(PreflightPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryMirrors != nil {
+		in, out := &in.RegistryMirrors, &out.RegistryMirrors
+		*out = make([]RegistryMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(ConcurrencyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PackageCacheSize != nil {
+		in, out := &in.PackageCacheSize, &out.PackageCacheSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MetricsConfig)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemotePhaseReference.
-func (in *RemotePhaseReference) DeepCopy() *RemotePhaseReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageOperatorConfigSpec.
+func (in *PackageOperatorConfigSpec) DeepCopy() *PackageOperatorConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RemotePhaseReference)
+	out := new(PackageOperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackagePodTemplateMetadata) DeepCopyInto(out *PackagePodTemplateMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackagePodTemplateMetadata.
+func (in *PackagePodTemplateMetadata) DeepCopy() *PackagePodTemplateMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(PackagePodTemplateMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageProbeKindSpec) DeepCopyInto(out *PackageProbeKindSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageProbeKindSpec.
+func (in *PackageProbeKindSpec) DeepCopy() *PackageProbeKindSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageProbeKindSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepository) DeepCopyInto(out *PackageRepository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepository.
+func (in *PackageRepository) DeepCopy() *PackageRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageRepository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryChannel) DeepCopyInto(out *PackageRepositoryChannel) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryChannel.
+func (in *PackageRepositoryChannel) DeepCopy() *PackageRepositoryChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryList) DeepCopyInto(out *PackageRepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PackageRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryList.
+func (in *PackageRepositoryList) DeepCopy() *PackageRepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageRepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositorySpec) DeepCopyInto(out *PackageRepositorySpec) {
+	*out = *in
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]PackageRepositoryChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositorySpec.
+func (in *PackageRepositorySpec) DeepCopy() *PackageRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRepositoryStatus) DeepCopyInto(out *PackageRepositoryStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = make([]RepositoryPackage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRepositoryStatus.
+func (in *PackageRepositoryStatus) DeepCopy() *PackageRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageSource) DeepCopyInto(out *PackageSource) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(PackageSourceObjectReference)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(PackageSourceObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageSource.
+func (in *PackageSource) DeepCopy() *PackageSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageSourceObjectReference) DeepCopyInto(out *PackageSourceObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageSourceObjectReference.
+func (in *PackageSourceObjectReference) DeepCopy() *PackageSourceObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageSourceObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageSpec) DeepCopyInto(out *PackageSpec) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(PackageSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(PackageUpgradePolicy)
+		**out = **in
+	}
+	if in.PodTemplateMetadata != nil {
+		in, out := &in.PodTemplateMetadata, &out.PodTemplateMetadata
+		*out = new(PackagePodTemplateMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]PackageObjectPatch, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageSpec.
+func (in *PackageSpec) DeepCopy() *PackageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageStatus) DeepCopyInto(out *PackageStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MappedFields != nil {
+		in, out := &in.MappedFields, &out.MappedFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]ControlledObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = new(PackageInventoryReference)
+		**out = **in
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(Health)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageStatus.
+func (in *PackageStatus) DeepCopy() *PackageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageTemplate) DeepCopyInto(out *PackageTemplate) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageTemplate.
+func (in *PackageTemplate) DeepCopy() *PackageTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageUpgradePolicy) DeepCopyInto(out *PackageUpgradePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageUpgradePolicy.
+func (in *PackageUpgradePolicy) DeepCopy() *PackageUpgradePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageUpgradePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseClass) DeepCopyInto(out *PhaseClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseClass.
+func (in *PhaseClass) DeepCopy() *PhaseClass {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PhaseClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseClassInProcess) DeepCopyInto(out *PhaseClassInProcess) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseClassInProcess.
+func (in *PhaseClassInProcess) DeepCopy() *PhaseClassInProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseClassInProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseClassList) DeepCopyInto(out *PhaseClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PhaseClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseClassList.
+func (in *PhaseClassList) DeepCopy() *PhaseClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PhaseClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseClassSpec) DeepCopyInto(out *PhaseClassSpec) {
+	*out = *in
+	if in.InProcess != nil {
+		in, out := &in.InProcess, &out.InProcess
+		*out = new(PhaseClassInProcess)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(PhaseClassWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseClassSpec.
+func (in *PhaseClassSpec) DeepCopy() *PhaseClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseClassWebhook) DeepCopyInto(out *PhaseClassWebhook) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseClassWebhook.
+func (in *PhaseClassWebhook) DeepCopy() *PhaseClassWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseClassWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreflightPolicy) DeepCopyInto(out *PreflightPolicy) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedKinds != nil {
+		in, out := &in.DeniedKinds, &out.DeniedKinds
+		*out = make([]PreflightPolicyKind, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedClusterRoles != nil {
+		in, out := &in.DeniedClusterRoles, &out.DeniedClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreflightPolicy.
+func (in *PreflightPolicy) DeepCopy() *PreflightPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PreflightPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreflightPolicyKind) DeepCopyInto(out *PreflightPolicyKind) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreflightPolicyKind.
+func (in *PreflightPolicyKind) DeepCopy() *PreflightPolicyKind {
+	if in == nil {
+		return nil
+	}
+	out := new(PreflightPolicyKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviousRevisionReference) DeepCopyInto(out *PreviousRevisionReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviousRevisionReference.
+func (in *PreviousRevisionReference) DeepCopy() *PreviousRevisionReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviousRevisionReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Probe) DeepCopyInto(out *Probe) {
+	*out = *in
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(ProbeConditionSpec)
+		**out = **in
+	}
+	if in.FieldsEqual != nil {
+		in, out := &in.FieldsEqual, &out.FieldsEqual
+		*out = new(ProbeFieldsEqualSpec)
+		**out = **in
+	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(ProbeHTTPGetSpec)
+		**out = **in
+	}
+	if in.TCPSocket != nil {
+		in, out := &in.TCPSocket, &out.TCPSocket
+		*out = new(ProbeTCPSocketSpec)
+		**out = **in
+	}
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(ProbeCountSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Probe.
+func (in *Probe) DeepCopy() *Probe {
+	if in == nil {
+		return nil
+	}
+	out := new(Probe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeConditionSpec) DeepCopyInto(out *ProbeConditionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeConditionSpec.
+func (in *ProbeConditionSpec) DeepCopy() *ProbeConditionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeConditionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeCountSpec) DeepCopyInto(out *ProbeCountSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(ProbeConditionSpec)
+		**out = **in
+	}
+	if in.FieldsEqual != nil {
+		in, out := &in.FieldsEqual, &out.FieldsEqual
+		*out = new(ProbeFieldsEqualSpec)
+		**out = **in
+	}
+	if in.MinCount != nil {
+		in, out := &in.MinCount, &out.MinCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeCountSpec.
+func (in *ProbeCountSpec) DeepCopy() *ProbeCountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeCountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeFieldsEqualSpec) DeepCopyInto(out *ProbeFieldsEqualSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeFieldsEqualSpec.
+func (in *ProbeFieldsEqualSpec) DeepCopy() *ProbeFieldsEqualSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeFieldsEqualSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeHTTPGetSpec) DeepCopyInto(out *ProbeHTTPGetSpec) {
+	*out = *in
+	out.Port = in.Port
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeHTTPGetSpec.
+func (in *ProbeHTTPGetSpec) DeepCopy() *ProbeHTTPGetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeHTTPGetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeSelector) DeepCopyInto(out *ProbeSelector) {
+	*out = *in
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(PackageProbeKindSpec)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSelector.
+func (in *ProbeSelector) DeepCopy() *ProbeSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeTCPSocketSpec) DeepCopyInto(out *ProbeTCPSocketSpec) {
+	*out = *in
+	out.Port = in.Port
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeTCPSocketSpec.
+func (in *ProbeTCPSocketSpec) DeepCopy() *ProbeTCPSocketSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeTCPSocketSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryMirror) DeepCopyInto(out *RegistryMirror) {
+	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirror.
+func (in *RegistryMirror) DeepCopy() *RegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemotePhaseReference) DeepCopyInto(out *RemotePhaseReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemotePhaseReference.
+func (in *RemotePhaseReference) DeepCopy() *RemotePhaseReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RemotePhaseReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryPackage) DeepCopyInto(out *RepositoryPackage) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]RepositoryPackageChannel, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryPackage.
+func (in *RepositoryPackage) DeepCopy() *RepositoryPackage {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryPackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryPackageChannel) DeepCopyInto(out *RepositoryPackageChannel) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryPackageChannel.
+func (in *RepositoryPackageChannel) DeepCopy() *RepositoryPackageChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryPackageChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProvider) DeepCopyInto(out *SecretProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProvider.
+func (in *SecretProvider) DeepCopy() *SecretProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderAWSSecretsManager) DeepCopyInto(out *SecretProviderAWSSecretsManager) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderAWSSecretsManager.
+func (in *SecretProviderAWSSecretsManager) DeepCopy() *SecretProviderAWSSecretsManager {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderAWSSecretsManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderGCPSecretManager) DeepCopyInto(out *SecretProviderGCPSecretManager) {
+	*out = *in
+	out.ServiceAccountKeySecretRef = in.ServiceAccountKeySecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderGCPSecretManager.
+func (in *SecretProviderGCPSecretManager) DeepCopy() *SecretProviderGCPSecretManager {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderGCPSecretManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderList) DeepCopyInto(out *SecretProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecretProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderList.
+func (in *SecretProviderList) DeepCopy() *SecretProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderSecretKeyRef) DeepCopyInto(out *SecretProviderSecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderSecretKeyRef.
+func (in *SecretProviderSecretKeyRef) DeepCopy() *SecretProviderSecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderSecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderSecretRef) DeepCopyInto(out *SecretProviderSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderSecretRef.
+func (in *SecretProviderSecretRef) DeepCopy() *SecretProviderSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderSpec) DeepCopyInto(out *SecretProviderSpec) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(SecretProviderVault)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSSecretsManager != nil {
+		in, out := &in.AWSSecretsManager, &out.AWSSecretsManager
+		*out = new(SecretProviderAWSSecretsManager)
+		**out = **in
+	}
+	if in.GCPSecretManager != nil {
+		in, out := &in.GCPSecretManager, &out.GCPSecretManager
+		*out = new(SecretProviderGCPSecretManager)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderSpec.
+func (in *SecretProviderSpec) DeepCopy() *SecretProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretProviderVault) DeepCopyInto(out *SecretProviderVault) {
+	*out = *in
+	out.TokenSecretRef = in.TokenSecretRef
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretProviderVault.
+func (in *SecretProviderVault) DeepCopy() *SecretProviderVault {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretProviderVault)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,25 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PackageCatalog lists packages published together, e.g. by the same vendor.
+// Catalog images contain the PackageCatalog as catalog.yaml
+// and are referenced by PackageRepositories to discover packages.
+// +kubebuilder:object:root=true
+type PackageCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PackageCatalogSpec `json:"spec,omitempty"`
+}
+
+// PackageCatalogSpec lists the packages of a catalog.
+type PackageCatalogSpec struct {
+	// OCI repositories containing package images, one repository per package.
+	// +example=["quay.io/package-operator/test-stub-package"]
+	Repositories []string `json:"repositories"`
+}
+
+func init() { register(&PackageCatalog{}) }
@@ -14,10 +14,34 @@ const (
 	// Package ConditionMap annotation specifies object conditions to map back into Package Operator APIs.
 	// Example: Available => my-own-prefix/Available.
 	PackageConditionMapAnnotation = "package-operator.run/condition-map"
+	// Package FieldMap annotation specifies object fields to report in the status of Package Operator APIs.
+	// Example: .status.endpoint => my-own-prefix/endpoint.
+	PackageFieldMapAnnotation = "package-operator.run/field-map"
 	// Package ExternalObject annotation, when set to "True", indicates
 	// that the referenced object should only be observed during a phase
 	// rather than reconciled.
 	PackageExternalObjectAnnotation = "package-operator.run/external"
+	// Package DeletionPolicy annotation, when set to "Orphan", indicates
+	// that the object should be left on the cluster instead of being deleted,
+	// when it is no longer part of the package.
+	// "ScaleDown" scales Deployments and StatefulSets to zero replicas, when their revision is archived.
+	PackageDeletionPolicyAnnotation = "package-operator.run/deletion-policy"
+	// Package CreateOnly annotation, when set to "True", indicates
+	// that the object should only be created, if it does not exist,
+	// but never be patched afterwards.
+	PackageCreateOnlyAnnotation = "package-operator.run/create-only"
+	// Package ContentHashSuffix annotation, when set to "True" on a ConfigMap or Secret,
+	// appends a hash of the content to the object name and rewrites references in pod templates,
+	// so content changes roll out as a new immutable object.
+	PackageContentHashSuffixAnnotation = "package-operator.run/content-hash-suffix"
+	// Package InjectProxy annotation, when set to "True" on a workload,
+	// injects the proxy environment variables into all containers of its pod template
+	// and mounts the trusted CA bundle of the environment, if present.
+	PackageInjectProxyAnnotation = "package-operator.run/inject-proxy"
+	// Package Hook annotation, when set to "Pre" or "Post" on a Job,
+	// runs the Job before or after the other objects of its phase
+	// and waits for it to complete on every new revision.
+	PackageHookAnnotation = "package-operator.run/hook"
 )
 
 const (
@@ -67,6 +91,47 @@ type PackageManifestSpec struct {
 	Config PackageManifestSpecConfig `json:"config,omitempty"`
 	// List of images to be resolved
 	Images []PackageManifestImage `json:"images"`
+	// Constraints the cluster has to satisfy to install the package.
+	// +optional
+	Constraints *PackageManifestConstraints `json:"constraints,omitempty"`
+	// Rejects unknown fields in this PackageManifest when loading the package,
+	// instead of silently ignoring them.
+	// +optional
+	Strict bool `json:"strict,omitempty"`
+	// Components shipped with the package, that can be installed on their own
+	// by selecting them via .spec.component of a Package.
+	// Each component is a package with its own PackageManifest, phases and config schema,
+	// located in the components/<name> folder.
+	// +optional
+	Components []PackageManifestComponent `json:"components,omitempty"`
+}
+
+// PackageManifestComponent declares a component of a package.
+type PackageManifestComponent struct {
+	// Name of the component and its folder within the components folder of the package.
+	// +example=console-plugin
+	Name string `json:"name"`
+	// Short description of what the component installs.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// PackageManifestConstraints are checked against the cluster before any object of the package is applied.
+// Packages are reported as Unsupported, when a constraint is not satisfied.
+type PackageManifestConstraints struct {
+	// Semantic version constraint the Kubernetes version of the cluster has to satisfy.
+	// +example=">= 1.25.0"
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Semantic version constraint the OpenShift version of the cluster has to satisfy.
+	// Requires the cluster to be an OpenShift cluster.
+	// +example=">= 4.12.0"
+	OpenShiftVersion string `json:"openShiftVersion,omitempty"`
+	// API groups that have to be served by the cluster.
+	// +example=[route.openshift.io]
+	APIGroups []string `json:"apiGroups,omitempty"`
+	// APIs that have to be served by the cluster.
+	// +example=[{group: monitoring.coreos.com, version: v1, kind: ServiceMonitor}]
+	APIs []metav1.GroupVersionKind `json:"apis,omitempty"`
 }
 
 type PackageManifestSpecConfig struct {
@@ -81,6 +146,12 @@ type PackageManifestPhase struct {
 	// If set to the string "default" the built-in controller reconciling the object.
 	// If set to any other string, an out-of-tree controller needs to be present to handle ObjectSetPhase objects.
 	Class string `json:"class,omitempty"`
+	// If true, objects created in this phase are deleted again,
+	// when any object of the phase can't be applied.
+	Atomic bool `json:"atomic,omitempty"`
+	// Limits how long objects of this phase may take to apply,
+	// so a slow or rejected object doesn't block the rest of the phase.
+	ApplyPolicy *corev1alpha1.ObjectSetApplyPolicy `json:"applyPolicy,omitempty"`
 }
 
 // PackageManifestImage specifies an image tag to be resolved
@@ -94,6 +165,8 @@ type PackageManifestImage struct {
 // PackageManifestTest configures test cases.
 type PackageManifestTest struct {
 	// Template testing configuration.
+	// Expected results of a test case may be shipped within the package
+	// in a "test/" subfolder named after the test case to compare them against the rendered templates.
 	Template []PackageManifestTestCaseTemplate `json:"template,omitempty"`
 }
 
@@ -123,6 +196,8 @@ type PackageEnvironment struct {
 	OpenShift *PackageEnvironmentOpenShift `json:"openShift,omitempty"`
 	// Proxy configuration.
 	Proxy *PackageEnvironmentProxy `json:"proxy,omitempty"`
+	// HyperShift environment information.
+	HyperShift *PackageEnvironmentHyperShift `json:"hyperShift,omitempty"`
 }
 
 type PackageEnvironmentKubernetes struct {
@@ -135,6 +210,12 @@ type PackageEnvironmentOpenShift struct {
 	Version string `json:"version"`
 }
 
+type PackageEnvironmentHyperShift struct {
+	// True, if the cluster is a HyperShift management cluster
+	// hosting the control planes of other clusters.
+	ManagementCluster bool `json:"managementCluster"`
+}
+
 // Environment proxy settings.
 // On OpenShift, this config is taken from the cluster Proxy object.
 // https://docs.openshift.com/container-platform/4.13/networking/enable-cluster-wide-proxy.html
@@ -145,6 +226,8 @@ type PackageEnvironmentProxy struct {
 	HTTPSProxy string `json:"httpsProxy,omitempty"`
 	// NO_PROXY
 	NoProxy string `json:"noProxy,omitempty"`
+	// PEM encoded CA bundle to trust, e.g. to connect through a TLS intercepting proxy.
+	TrustedCABundle string `json:"trustedCABundle,omitempty"`
 }
 
 // TemplateContextPackage represents the (Cluster)Package object requesting this package content.
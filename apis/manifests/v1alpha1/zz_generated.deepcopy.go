@@ -6,10 +6,57 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageCatalog) DeepCopyInto(out *PackageCatalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageCatalog.
+func (in *PackageCatalog) DeepCopy() *PackageCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackageCatalog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageCatalogSpec) DeepCopyInto(out *PackageCatalogSpec) {
+	*out = *in
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageCatalogSpec.
+func (in *PackageCatalogSpec) DeepCopy() *PackageCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageEnvironment) DeepCopyInto(out *PackageEnvironment) {
 	*out = *in
@@ -24,6 +71,11 @@ func (in *PackageEnvironment) DeepCopyInto(out *PackageEnvironment) {
 		*out = new(PackageEnvironmentProxy)
 		**out = **in
 	}
+	if in.HyperShift != nil {
+		in, out := &in.HyperShift, &out.HyperShift
+		*out = new(PackageEnvironmentHyperShift)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageEnvironment.
@@ -36,6 +88,21 @@ func (in *PackageEnvironment) DeepCopy() *PackageEnvironment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageEnvironmentHyperShift) DeepCopyInto(out *PackageEnvironmentHyperShift) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageEnvironmentHyperShift.
+func (in *PackageEnvironmentHyperShift) DeepCopy() *PackageEnvironmentHyperShift {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageEnvironmentHyperShift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageEnvironmentKubernetes) DeepCopyInto(out *PackageEnvironmentKubernetes) {
 	*out = *in
@@ -108,6 +175,46 @@ func (in *PackageManifest) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageManifestComponent) DeepCopyInto(out *PackageManifestComponent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageManifestComponent.
+func (in *PackageManifestComponent) DeepCopy() *PackageManifestComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageManifestComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageManifestConstraints) DeepCopyInto(out *PackageManifestConstraints) {
+	*out = *in
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.APIs != nil {
+		in, out := &in.APIs, &out.APIs
+		*out = make([]v1.GroupVersionKind, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageManifestConstraints.
+func (in *PackageManifestConstraints) DeepCopy() *PackageManifestConstraints {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageManifestConstraints)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageManifestImage) DeepCopyInto(out *PackageManifestImage) {
 	*out = *in
@@ -187,6 +294,11 @@ func (in *PackageManifestLockSpec) DeepCopy() *PackageManifestLockSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageManifestPhase) DeepCopyInto(out *PackageManifestPhase) {
 	*out = *in
+	if in.ApplyPolicy != nil {
+		in, out := &in.ApplyPolicy, &out.ApplyPolicy
+		*out = new(corev1alpha1.ObjectSetApplyPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageManifestPhase.
@@ -210,7 +322,9 @@ func (in *PackageManifestSpec) DeepCopyInto(out *PackageManifestSpec) {
 	if in.Phases != nil {
 		in, out := &in.Phases, &out.Phases
 		*out = make([]PackageManifestPhase, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.AvailabilityProbes != nil {
 		in, out := &in.AvailabilityProbes, &out.AvailabilityProbes
@@ -225,6 +339,16 @@ func (in *PackageManifestSpec) DeepCopyInto(out *PackageManifestSpec) {
 		*out = make([]PackageManifestImage, len(*in))
 		copy(*out, *in)
 	}
+	if in.Constraints != nil {
+		in, out := &in.Constraints, &out.Constraints
+		*out = new(PackageManifestConstraints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]PackageManifestComponent, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageManifestSpec.
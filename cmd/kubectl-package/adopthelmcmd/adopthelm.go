@@ -0,0 +1,98 @@
+package adopthelmcmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"package-operator.run/package-operator/internal/cli"
+	internalcmd "package-operator.run/package-operator/internal/cmd"
+)
+
+func NewCmd(clientFactory internalcmd.ClientFactory) *cobra.Command {
+	const (
+		cmdUse   = "adopt-helm release_name"
+		cmdShort = "migrates a helm release to an ObjectDeployment"
+		cmdLong  = "marks all objects of a helm release for adoption and outputs an ObjectDeployment matching their current state. " +
+			"Once the ObjectDeployment is applied and available, " +
+			"the helm release secrets can be deleted without affecting the adopted objects."
+	)
+
+	var opts options
+
+	cmd := &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		Use:   cmdUse,
+		Short: cmdShort,
+		Long:  cmdLong,
+	}
+	opts.AddFlags(cmd.Flags())
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		format, err := cli.ParseOutputFormat(opts.Output)
+		if err != nil {
+			return err
+		}
+		if !format.IsStructured() {
+			format = cli.OutputFormatYAML
+		}
+
+		client, err := clientFactory.Client()
+		if err != nil {
+			return err
+		}
+
+		deployment, err := client.AdoptHelmRelease(
+			cmd.Context(), args[0],
+			internalcmd.WithNamespace(opts.Namespace),
+			internalcmd.WithDeploymentName(opts.Name),
+			internalcmd.WithDryRun(opts.DryRun),
+		)
+		if err != nil {
+			return fmt.Errorf("adopting helm release: %w", err)
+		}
+
+		printer := cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()})
+
+		return printer.PrintStructured(format, deployment)
+	}
+
+	return cmd
+}
+
+type options struct {
+	DryRun    bool
+	Name      string
+	Namespace string
+	Output    string
+}
+
+func (o *options) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&o.DryRun,
+		"dry-run",
+		o.DryRun,
+		"only output the ObjectDeployment without marking objects for adoption",
+	)
+	flags.StringVar(
+		&o.Name,
+		"name",
+		o.Name,
+		"name of the generated ObjectDeployment, defaults to the release name",
+	)
+	flags.StringVarP(
+		&o.Namespace,
+		"namespace",
+		"n",
+		o.Namespace,
+		"namespace of the helm release",
+	)
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format. One of: json|yaml",
+	)
+}
@@ -3,7 +3,10 @@ package buildcmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/spf13/cobra"
@@ -22,9 +25,9 @@ type Builder interface {
 
 func NewCmd(builderFactory BuilderFactory) *cobra.Command {
 	const (
-		buildUse   = "build source_path [--tag tag]... [--output output_path] [--push]"
+		buildUse   = "build source_path [--tag tag]... [--output output_path] [--push] [--source url] [--revision revision]"
 		buildShort = "build an PKO package image using manifests at the given path"
-		buildLong  = "builds and optionally pushes an OCI image in the Package Operator package format from the specified build context directory."
+		buildLong  = "builds and optionally pushes an OCI image in the Package Operator package format from the specified build context directory. The image creation time is taken from SOURCE_DATE_EPOCH if set."
 	)
 
 	cmd := &cobra.Command{
@@ -52,13 +55,23 @@ func NewCmd(builderFactory BuilderFactory) *cobra.Command {
 			}
 		}
 
-		if err := builderFactory.Builder().BuildFromSource(
-			cmd.Context(), src,
+		buildOpts := []internalcmd.BuildFromSourceOption{
 			internalcmd.WithInsecure(opts.Insecure),
 			internalcmd.WithOutputPath(opts.OutputPath),
 			internalcmd.WithPush(opts.Push),
 			internalcmd.WithTags(opts.Tags),
-		); err != nil {
+			internalcmd.WithSource(opts.Source),
+			internalcmd.WithRevision(opts.Revision),
+		}
+		if epoch, ok := os.LookupEnv(sourceDateEpochEnv); ok {
+			created, err := parseSourceDateEpoch(epoch)
+			if err != nil {
+				return err
+			}
+			buildOpts = append(buildOpts, internalcmd.WithCreated(created))
+		}
+
+		if err := builderFactory.Builder().BuildFromSource(cmd.Context(), src, buildOpts...); err != nil {
 			return fmt.Errorf("building from source: %w", err)
 		}
 
@@ -68,11 +81,26 @@ func NewCmd(builderFactory BuilderFactory) *cobra.Command {
 	return cmd
 }
 
+// Standard environment variable for reproducible builds,
+// see https://reproducible-builds.org/specs/source-date-epoch/.
+const sourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
+// Parses SOURCE_DATE_EPOCH, which holds the creation time as unix seconds.
+func parseSourceDateEpoch(epoch string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid %s %q: %w", internalcmd.ErrInvalidArgs, sourceDateEpochEnv, epoch, err)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
 type options struct {
 	Insecure   bool
 	OutputPath string
 	Push       bool
 	Tags       []string
+	Source     string
+	Revision   string
 }
 
 func (o *options) AddFlags(flags *pflag.FlagSet) {
@@ -93,7 +121,19 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 		&o.Push,
 		"push",
 		o.Push,
-		"Push the created image tags. Defaults to false",
+		"Push the created image tags using credentials from the local docker config. Defaults to false",
+	)
+	flags.StringVar(
+		&o.Source,
+		"source",
+		o.Source,
+		"URL of the package source, set as org.opencontainers.image.source annotation. Defaults to none.",
+	)
+	flags.StringVar(
+		&o.Revision,
+		"revision",
+		o.Revision,
+		"Source control revision of the package, set as org.opencontainers.image.revision annotation. Defaults to none.",
 	)
 	flags.StringVarP(
 		&o.OutputPath,
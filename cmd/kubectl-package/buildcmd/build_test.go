@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/stretchr/testify/mock"
@@ -152,3 +153,14 @@ func (m *builderFactoryMock) Builder() Builder {
 
 	return args.Get(0).(Builder)
 }
+
+func TestParseSourceDateEpoch(t *testing.T) {
+	t.Parallel()
+
+	created, err := parseSourceDateEpoch("1685620800")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC), created)
+
+	_, err = parseSourceDateEpoch("yesterday")
+	require.ErrorIs(t, err, internalcmd.ErrInvalidArgs)
+}
@@ -7,6 +7,7 @@ import (
 	"go.uber.org/dig"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"package-operator.run/package-operator/cmd/kubectl-package/adopthelmcmd"
 	"package-operator.run/package-operator/cmd/kubectl-package/buildcmd"
 	"package-operator.run/package-operator/cmd/kubectl-package/rolloutcmd"
 	"package-operator.run/package-operator/cmd/kubectl-package/rootcmd"
@@ -35,10 +36,13 @@ type RootSubCommandResult struct {
 	SubCommand *cobra.Command `group:"rootSubCommands"`
 }
 
-func ProvideTreeCmd(rendererFactory treecmd.RendererFactory) RootSubCommandResult {
+func ProvideTreeCmd(
+	rendererFactory treecmd.RendererFactory, clientFactory internalcmd.ClientFactory,
+) RootSubCommandResult {
 	return RootSubCommandResult{
 		SubCommand: treecmd.NewCmd(
 			rendererFactory,
+			clientFactory,
 		),
 	}
 }
@@ -133,6 +137,12 @@ func ProvideRolloutCmd(params rolloutcmd.Params) RootSubCommandResult {
 	}
 }
 
+func ProvideAdoptHelmCmd(clientFactory internalcmd.ClientFactory) RootSubCommandResult {
+	return RootSubCommandResult{
+		SubCommand: adopthelmcmd.NewCmd(clientFactory),
+	}
+}
+
 type RolloutSubCommandResult struct {
 	dig.Out
 
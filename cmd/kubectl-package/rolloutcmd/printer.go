@@ -2,7 +2,6 @@ package rolloutcmd
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strings"
 
@@ -34,7 +33,7 @@ func (p *printer) PrintObjectSet(os internalcmd.ObjectSet, opts options) error {
 	case "", "yaml":
 		data, err = os.MarshalYAML()
 	default:
-		return fmt.Errorf("%w: %q", errInvalidOutputFormat, opts.Output)
+		return fmt.Errorf("%w: %q", cli.ErrInvalidOutputFormat, opts.Output)
 	}
 	if err != nil {
 		return err
@@ -64,8 +63,6 @@ func (p *printer) PrintObjectSetList(l internalcmd.ObjectSetList, opts options)
 
 		return p.printer.PrintTable(table)
 	default:
-		return fmt.Errorf("%w: %q", errInvalidOutputFormat, opts.Output)
+		return fmt.Errorf("%w: %q", cli.ErrInvalidOutputFormat, opts.Output)
 	}
 }
-
-var errInvalidOutputFormat = errors.New("invalid output format")
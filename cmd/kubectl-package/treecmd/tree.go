@@ -3,10 +3,15 @@ package treecmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/term"
 
+	"package-operator.run/package-operator/internal/cli"
 	internalcmd "package-operator.run/package-operator/internal/cmd"
 )
 
@@ -15,20 +20,22 @@ type RendererFactory interface {
 }
 
 type Renderer interface {
-	RenderPackage(ctx context.Context, srcPath string, opts ...internalcmd.RenderPackageOption) (string, error)
+	RenderPackageTree(ctx context.Context, srcPath string, opts ...internalcmd.RenderPackageOption) (*internalcmd.PackageTree, error)
 }
 
-func NewCmd(rendererFactory RendererFactory) *cobra.Command {
+func NewCmd(rendererFactory RendererFactory, clientFactory internalcmd.ClientFactory) *cobra.Command {
 	const (
-		cmdUse   = "tree source_path"
-		cmdShort = "outputs a logical tree view of the package contents"
-		cmdLong  = "outputs a logical tree view of the package by printing root->phases->objects"
+		cmdUse   = "tree source_path | (package|clusterpackage|objectdeployment|clusterobjectdeployment)[/ ]name"
+		cmdShort = "outputs a logical tree view of the package contents or revisions"
+		cmdLong  = "outputs a logical tree view of the package by printing root->phases->objects, " +
+			"or of a package or object deployment in the cluster by printing " +
+			"package->objectdeployment->objectsets->phases->objects with their status"
 	)
 
 	var opts options
 
 	cmd := &cobra.Command{
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.RangeArgs(1, 2),
 		Use:   cmdUse,
 		Short: cmdShort,
 		Long:  cmdLong,
@@ -37,7 +44,19 @@ func NewCmd(rendererFactory RendererFactory) *cobra.Command {
 
 	cmd.MarkFlagsMutuallyExclusive("config-path", "config-testcase")
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		out, err := rendererFactory.Renderer().RenderPackage(
+		format, err := cli.ParseOutputFormat(opts.Output)
+		if err != nil {
+			return err
+		}
+
+		if rsrc, name, ok := getClusterArgs(args); ok {
+			return renderRevisionTree(cmd, clientFactory, format, rsrc, name, opts)
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("%w: %s is not a known resource type", internalcmd.ErrInvalidArgs, args[0])
+		}
+
+		tree, err := rendererFactory.Renderer().RenderPackageTree(
 			cmd.Context(), args[0],
 			internalcmd.WithClusterScope(opts.ClusterScope),
 			internalcmd.WithConfigPath(opts.ConfigPath),
@@ -47,18 +66,86 @@ func NewCmd(rendererFactory RendererFactory) *cobra.Command {
 			return fmt.Errorf("rendering package: %w", err)
 		}
 
-		_, err = fmt.Fprint(cmd.OutOrStdout(), out)
+		printer := cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()})
+		if format.IsStructured() {
+			return printer.PrintStructured(format, tree)
+		}
 
-		return err
+		return printer.PrintfOut("%s", tree.String())
 	}
 
 	return cmd
 }
 
+// Arguments in resource/name form or as two separate arguments
+// reference a package or object deployment in the cluster instead of a source path.
+// Use e.g. "./package/name" for source paths colliding with a resource type.
+func getClusterArgs(args []string) (rsrc, name string, ok bool) {
+	if len(args) == 2 {
+		rsrc, name = args[0], args[1]
+	} else {
+		rsrc, name, ok = strings.Cut(args[0], "/")
+		if !ok {
+			return "", "", false
+		}
+	}
+
+	switch strings.ToLower(rsrc) {
+	case "package", "clusterpackage", "objectdeployment", "clusterobjectdeployment":
+		return strings.ToLower(rsrc), name, len(name) > 0
+	default:
+		return "", "", false
+	}
+}
+
+func renderRevisionTree(
+	cmd *cobra.Command, clientFactory internalcmd.ClientFactory,
+	format cli.OutputFormat, rsrc, name string, opts options,
+) error {
+	client, err := clientFactory.Client()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	var tree *internalcmd.RevisionTree
+	switch rsrc {
+	case "clusterpackage":
+		tree, err = client.PackageRevisionTree(ctx, name)
+	case "package":
+		tree, err = client.PackageRevisionTree(ctx, name, internalcmd.WithNamespace(opts.Namespace))
+	case "clusterobjectdeployment":
+		tree, err = client.ObjectDeploymentRevisionTree(ctx, name)
+	case "objectdeployment":
+		tree, err = client.ObjectDeploymentRevisionTree(ctx, name, internalcmd.WithNamespace(opts.Namespace))
+	}
+	if err != nil {
+		return fmt.Errorf("getting revisions of %s/%s: %w", rsrc, name, err)
+	}
+
+	printer := cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()})
+	if format.IsStructured() {
+		return printer.PrintStructured(format, tree)
+	}
+
+	color := !opts.NoColor && isTerminal(cmd.OutOrStdout())
+
+	return printer.PrintfOut("%s", tree.Render(color))
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
 type options struct {
 	ClusterScope   bool
 	ConfigPath     string
 	ConfigTestcase string
+	Namespace      string
+	NoColor        bool
+	Output         string
 }
 
 func (o *options) AddFlags(flags *pflag.FlagSet) {
@@ -86,4 +173,24 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 		o.ConfigTestcase,
 		configTestcaseUse,
 	)
+	flags.StringVarP(
+		&o.Namespace,
+		"namespace",
+		"n",
+		o.Namespace,
+		"namespace of the package or object deployment, when rendering revisions from the cluster",
+	)
+	flags.BoolVar(
+		&o.NoColor,
+		"no-color",
+		o.NoColor,
+		"disable color coding of conditions, when rendering revisions from the cluster",
+	)
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format. One of: json|yaml",
+	)
 }
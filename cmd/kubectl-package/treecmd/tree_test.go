@@ -7,7 +7,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	internalcmd "package-operator.run/package-operator/internal/cmd"
 )
 
@@ -23,7 +29,7 @@ func TestTree_Success(t *testing.T) {
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -51,7 +57,7 @@ Package namespace/name
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -73,6 +79,46 @@ ClusterPackage /name
 	})
 }
 
+func TestTree_StructuredOutput(t *testing.T) {
+	t.Parallel()
+
+	scheme, err := internalcmd.NewScheme()
+	require.NoError(t, err)
+
+	factory := &rendererFactoryMock{}
+	factory.On("Renderer").Return(internalcmd.NewTree(scheme))
+
+	cmd := NewCmd(factory, nil)
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	cmd.SetArgs([]string{"--config-testcase", "namespace-scope", "-o", "yaml", "testdata"})
+
+	require.NoError(t, cmd.Execute())
+	require.Len(t, stderr.String(), 0)
+
+	const expectedOutput = `apiVersion: kubectl-package.package-operator.run/v1alpha1
+kind: PackageTree
+name: name
+namespace: namespace
+packageKind: Package
+packageName: test-stub
+phases:
+- name: deploy
+  objects:
+  - apiVersion: apps/v1
+    kind: Deployment
+    name: test-stub-name
+  - apiVersion: apps/v1
+    external: true
+    kind: Deployment
+    name: test-external-name
+    namespace: external-name
+`
+	assert.Equal(t, expectedOutput, stdout.String())
+}
+
 func TestTree_InvalidArgs(t *testing.T) {
 	t.Parallel()
 
@@ -85,7 +131,7 @@ func TestTree_InvalidArgs(t *testing.T) {
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -103,7 +149,7 @@ func TestTree_InvalidArgs(t *testing.T) {
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -122,7 +168,7 @@ func TestTree_InvalidArgs(t *testing.T) {
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -141,7 +187,7 @@ func TestTree_InvalidArgs(t *testing.T) {
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -164,7 +210,7 @@ func TestTree_ConfigPath(t *testing.T) {
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -187,7 +233,7 @@ func TestTree_ConfigTemplate(t *testing.T) {
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -210,7 +256,7 @@ func TestTree_NoConfig(t *testing.T) {
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -229,7 +275,7 @@ func TestTree_NoConfig(t *testing.T) {
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -252,7 +298,7 @@ func TestTree_FileConfig(t *testing.T) {
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -280,7 +326,7 @@ Package namespace/name
 		factory := &rendererFactoryMock{}
 		factory.On("Renderer").Return(internalcmd.NewTree(scheme))
 
-		cmd := NewCmd(factory)
+		cmd := NewCmd(factory, nil)
 		stdout := &bytes.Buffer{}
 		stderr := &bytes.Buffer{}
 		cmd.SetOut(stdout)
@@ -302,6 +348,138 @@ ClusterPackage /name
 	})
 }
 
+func TestTree_Revisions(t *testing.T) {
+	t.Parallel()
+
+	scheme, err := internalcmd.NewScheme()
+	require.NoError(t, err)
+
+	deployment := unstructured.Unstructured{}
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	deployment.SetName("test")
+	deployment.SetNamespace("test")
+
+	newObjectSet := func(name string, revision int64, status corev1alpha1.ObjectSetStatus) *corev1alpha1.ObjectSet {
+		status.Revision = revision
+		return &corev1alpha1.ObjectSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+				Labels: map[string]string{
+					manv1alpha1.PackageInstanceLabel: "test",
+				},
+			},
+			Spec: corev1alpha1.ObjectSetSpec{
+				ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+					Phases: []corev1alpha1.ObjectSetTemplatePhase{{
+						Name:    "deploy",
+						Objects: []corev1alpha1.ObjectSetObject{{Object: deployment}},
+					}},
+				},
+			},
+			Status: status,
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Status: corev1alpha1.PackageStatus{
+				Conditions: []metav1.Condition{{Type: "Available", Status: metav1.ConditionFalse}},
+			},
+		},
+		&corev1alpha1.ObjectDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "test",
+				Labels: map[string]string{
+					manv1alpha1.PackageInstanceLabel: "test",
+				},
+			},
+		},
+		newObjectSet("test-2", 2, corev1alpha1.ObjectSetStatus{
+			Conditions: []metav1.Condition{{Type: "Available", Status: metav1.ConditionFalse}},
+			Phases: []corev1alpha1.ObjectSetRolloutPhase{{
+				Name:         "deploy",
+				State:        corev1alpha1.ObjectSetRolloutPhaseStateProgressing,
+				FailedProbes: []string{"apps Deployment test/test: replicas not ready"},
+			}},
+		}),
+		newObjectSet("test-1", 1, corev1alpha1.ObjectSetStatus{
+			Conditions: []metav1.Condition{{Type: "Archived", Status: metav1.ConditionTrue}},
+			Phases: []corev1alpha1.ObjectSetRolloutPhase{{
+				Name:  "deploy",
+				State: corev1alpha1.ObjectSetRolloutPhaseStateAvailable,
+			}},
+		}),
+	).Build()
+
+	clientFactory := internalcmd.NewDefaultClientFactory(&kubeClientFactoryMock{Client: fakeClient})
+
+	for name, args := range map[string][]string{
+		"separate args": {"package", "test", "-n", "test"},
+		"single arg":    {"package/test", "-n", "test"},
+	} {
+		args := args
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := NewCmd(&rendererFactoryMock{}, clientFactory)
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+			cmd.SetOut(stdout)
+			cmd.SetErr(stderr)
+			cmd.SetArgs(args)
+
+			require.NoError(t, cmd.Execute())
+			require.Len(t, stderr.String(), 0)
+
+			const expectedOutput = `Package test/test [Available=False]
+└── ObjectDeployment test/test
+    └── ObjectSet test/test-1 revision 1 [Archived=True]
+    │   ├── Phase deploy (Available)
+    │       └── apps/v1, Kind=Deployment test/test (Available)
+    └── ObjectSet test/test-2 revision 2 [Available=False]
+        └── Phase deploy (Progressing)
+            └── apps/v1, Kind=Deployment test/test (ProbeFailure): replicas not ready
+`
+			assert.Equal(t, expectedOutput, stdout.String())
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := NewCmd(&rendererFactoryMock{}, clientFactory)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"objectdeployment/missing", "-n", "test"})
+
+		require.Error(t, cmd.Execute())
+	})
+
+	t.Run("unknown resource", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := NewCmd(&rendererFactoryMock{}, clientFactory)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"deployment", "test"})
+
+		require.Error(t, cmd.Execute())
+	})
+}
+
+type kubeClientFactoryMock struct {
+	Client client.Client
+}
+
+func (m *kubeClientFactoryMock) GetKubeClient() (client.Client, error) {
+	return m.Client, nil
+}
+
 type rendererFactoryMock struct {
 	mock.Mock
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"package-operator.run/package-operator/internal/cli"
 	internalcmd "package-operator.run/package-operator/internal/cmd"
 	"package-operator.run/package-operator/internal/packages"
 )
@@ -41,22 +42,34 @@ func NewCmd(updater Updater) *cobra.Command {
 			return fmt.Errorf("%w: target path empty", internalcmd.ErrInvalidArgs)
 		}
 
+		format, err := cli.ParseOutputFormat(opts.Output)
+		if err != nil {
+			return err
+		}
+
 		srcPath := args[0]
+		lockFilePath := filepath.Join(srcPath, packages.PackageManifestLockFile)
+		printer := cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()})
 
 		data, err := updater.GenerateLockData(cmd.Context(), srcPath, internalcmd.WithInsecure(opts.Insecure))
 		if errors.Is(err, internalcmd.ErrLockDataUnchanged) {
-			fmt.Fprintln(cmd.OutOrStdout(), "Package is already up-to-date")
+			if format.IsStructured() {
+				return printer.PrintStructured(format, internalcmd.NewUpdateResult(lockFilePath, false))
+			}
 
-			return nil
+			return printer.PrintfOut("Package is already up-to-date\n")
 		} else if err != nil {
 			return fmt.Errorf("generating lock data: %w", err)
 		}
 
-		lockFilePath := filepath.Join(srcPath, packages.PackageManifestLockFile)
 		if err := os.WriteFile(lockFilePath, data, 0o644); err != nil {
 			return fmt.Errorf("writing lock file: %w", err)
 		}
 
+		if format.IsStructured() {
+			return printer.PrintStructured(format, internalcmd.NewUpdateResult(lockFilePath, true))
+		}
+
 		return nil
 	}
 
@@ -65,6 +78,7 @@ func NewCmd(updater Updater) *cobra.Command {
 
 type options struct {
 	Insecure bool
+	Output   string
 	Pull     bool
 }
 
@@ -75,4 +89,11 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 		o.Insecure,
 		"Allows pulling images without TLS or using TLS with unverified certificates.",
 	)
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format. One of: json|yaml",
+	)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"package-operator.run/package-operator/internal/cli"
 	internalcmd "package-operator.run/package-operator/internal/cmd"
 )
 
@@ -16,9 +17,9 @@ type Validator interface {
 
 func NewCmd(validator Validator) *cobra.Command {
 	const (
-		validateUse   = "validate [--pull] target"
+		validateUse   = "validate [--pull] [--strict] [--component name] target"
 		validateShort = "validate a package."
-		validateLong  = "validate a package. Target may be a source directory, a package in a tar[.gz] or a fully qualified tag if --pull is set."
+		validateLong  = "validate a package. Target may be a source directory, a package in a tar[.gz] or a fully qualified tag if --pull is set. Template test cases are compared against the snapshots in the test/<test case name> folder of the package."
 	)
 
 	cmd := &cobra.Command{
@@ -38,8 +39,15 @@ func NewCmd(validator Validator) *cobra.Command {
 			return fmt.Errorf("%w: 'target' must not be empty", internalcmd.ErrInvalidArgs)
 		}
 
+		format, err := cli.ParseOutputFormat(opts.Output)
+		if err != nil {
+			return err
+		}
+
 		validateOptions := []internalcmd.ValidatePackageOption{
 			internalcmd.WithInsecure(opts.Insecure),
+			internalcmd.WithStrict(opts.Strict),
+			internalcmd.WithComponent(opts.Component),
 		}
 
 		if opts.Pull {
@@ -48,8 +56,15 @@ func NewCmd(validator Validator) *cobra.Command {
 			validateOptions = append(validateOptions, internalcmd.WithPath(src))
 		}
 
-		if err := validator.ValidatePackage(cmd.Context(), validateOptions...); err != nil {
-			return fmt.Errorf("validating package: %w", err)
+		validateErr := validator.ValidatePackage(cmd.Context(), validateOptions...)
+		if format.IsStructured() {
+			printer := cli.NewPrinter(cli.WithOut{Out: cmd.OutOrStdout()})
+			if err := printer.PrintStructured(format, internalcmd.NewValidationResult(src, validateErr)); err != nil {
+				return err
+			}
+		}
+		if validateErr != nil {
+			return fmt.Errorf("validating package: %w", validateErr)
 		}
 
 		return nil
@@ -59,11 +74,20 @@ func NewCmd(validator Validator) *cobra.Command {
 }
 
 type options struct {
-	Insecure bool
-	Pull     bool
+	Component string
+	Insecure  bool
+	Output    string
+	Pull      bool
+	Strict    bool
 }
 
 func (o *options) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&o.Component,
+		"component",
+		o.Component,
+		"only validate the given component of a multi-component package",
+	)
 	flags.BoolVar(
 		&o.Insecure,
 		"insecure",
@@ -76,4 +100,17 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 		o.Pull,
 		"treat target as image reference and pull it instead of looking on the filesystem",
 	)
+	flags.BoolVar(
+		&o.Strict,
+		"strict",
+		o.Strict,
+		"reject unknown fields in the PackageManifest and PackageManifestLock",
+	)
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format. One of: json|yaml",
+	)
 }
@@ -26,3 +26,29 @@ func TestValidateFolder(t *testing.T) {
 	require.Len(t, stdout.String(), 0)
 	require.Len(t, stderr.String(), 0)
 }
+
+func TestValidateFolder_StructuredOutput(t *testing.T) {
+	t.Parallel()
+
+	scheme, err := internalcmd.NewScheme()
+	require.NoError(t, err)
+
+	cmd := NewCmd(internalcmd.NewValidate(scheme))
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	cmd.SetArgs([]string{"-o", "json", "testdata"})
+
+	require.Nil(t, cmd.Execute())
+	require.Len(t, stderr.String(), 0)
+
+	const expectedOutput = `{
+    "apiVersion": "kubectl-package.package-operator.run/v1alpha1",
+    "kind": "ValidationResult",
+    "target": "testdata",
+    "valid": true
+}
+`
+	require.Equal(t, expectedOutput, stdout.String())
+}
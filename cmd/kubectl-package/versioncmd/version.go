@@ -6,6 +6,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"package-operator.run/package-operator/internal/cli"
+	internalcmd "package-operator.run/package-operator/internal/cmd"
 	"package-operator.run/package-operator/internal/version"
 )
 
@@ -24,11 +26,22 @@ func NewCmd() *cobra.Command {
 
 	opts.AddFlags(cmd.Flags())
 
-	cmd.Run = func(cmd *cobra.Command, args []string) {
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		format, err := cli.ParseOutputFormat(opts.Output)
+		if err != nil {
+			return err
+		}
+
 		out := cmd.OutOrStdout()
 
 		info := version.Get()
 
+		if format.IsStructured() {
+			printer := cli.NewPrinter(cli.WithOut{Out: out})
+
+			return printer.PrintStructured(format, internalcmd.NewVersionResult(info, opts.Embedded))
+		}
+
 		if info.ApplicationVersion != "" {
 			fmt.Fprintln(out, "version", info.ApplicationVersion)
 		}
@@ -45,6 +58,8 @@ func NewCmd() *cobra.Command {
 				fmt.Fprintln(out, "build", setting.Key, setting.Value)
 			}
 		}
+
+		return nil
 	}
 
 	return cmd
@@ -52,6 +67,7 @@ func NewCmd() *cobra.Command {
 
 type options struct {
 	Embedded bool
+	Output   string
 }
 
 func (o *options) AddFlags(flags *pflag.FlagSet) {
@@ -61,4 +77,11 @@ func (o *options) AddFlags(flags *pflag.FlagSet) {
 		o.Embedded,
 		"Output embedded build information as well",
 	)
+	flags.StringVarP(
+		&o.Output,
+		"output",
+		"o",
+		o.Output,
+		"Output format. One of: json|yaml",
+	)
 }
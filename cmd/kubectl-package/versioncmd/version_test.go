@@ -20,3 +20,17 @@ func TestCobraVersion(t *testing.T) {
 	require.Len(t, stderr.String(), 0)
 	require.Contains(t, stdout.String(), runtime.Version())
 }
+
+func TestCobraVersion_StructuredOutput(t *testing.T) {
+	cmd := NewCmd()
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	cmd.SetArgs([]string{"--embedded", "-o", "json"})
+
+	require.Nil(t, cmd.Execute())
+	require.Len(t, stderr.String(), 0)
+	require.Contains(t, stdout.String(), `"kind": "VersionResult"`)
+	require.Contains(t, stdout.String(), `"goVersion": "`+runtime.Version()+`"`)
+}
@@ -6,9 +6,6 @@ import (
 	"os"
 
 	"github.com/go-logr/logr"
-	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,8 +29,7 @@ type Bootstrapper struct {
 	init   func(ctx context.Context) (
 		*corev1alpha1.ClusterPackage, error,
 	)
-
-	pkoNamespace string
+	reconciler *bootstrapReconciler
 }
 
 func NewBootstrapper(
@@ -46,14 +42,19 @@ func NewBootstrapper(
 	init := newInitializer(
 		c, packageloader.New(scheme, packageloader.WithDefaults),
 		registry.Pull, opts.SelfBootstrap, opts.SelfBootstrapConfig,
+		opts.Identity(),
 	)
 
 	return &Bootstrapper{
 		log:    log.WithName("bootstrapper"),
 		client: c,
 		init:   init.Init,
-
-		pkoNamespace: opts.Namespace,
+		reconciler: &bootstrapReconciler{
+			client:          c,
+			ensurePackage:   init.ensureClusterPackage,
+			pkoNamespace:    opts.Namespace,
+			teardownTimeout: opts.SelfBootstrapTeardownTimeout,
+		},
 	}, nil
 }
 
@@ -75,23 +76,24 @@ func (b *Bootstrapper) Bootstrap(ctx context.Context, runManager func(ctx contex
 		return err
 	}
 
-	available, err := b.isPKOAvailable(ctx)
+	done, err := b.reconciler.Reconcile(ctx)
 	if err != nil {
 		return fmt.Errorf("checking if self-bootstrap is needed: %w", err)
 	}
-	if available {
+	if done {
 		return nil
 	}
 	return b.bootstrap(ctx, runManager)
 }
 
 func (b *Bootstrapper) bootstrap(ctx context.Context, runManager func(ctx context.Context) error) error {
-	// Stop when Package Operator is installed.
+	// Stop when Package Operator is installed and managing itself.
 	ctx, cancel := context.WithCancel(ctx)
 	go b.cancelWhenPackageAvailable(ctx, cancel)
 
 	// Force Adoption of objects during initial bootstrap to take ownership of
-	// CRDs, Namespace, ServiceAccount and ClusterRoleBinding.
+	// CRDs, Namespace, ServiceAccount and ClusterRoleBinding
+	// and of all objects of installations from static manifests.
 	if err := os.Setenv(controllers.ForceAdoptionEnvironmentVariable, "1"); err != nil {
 		return err
 	}
@@ -101,30 +103,6 @@ func (b *Bootstrapper) bootstrap(ctx context.Context, runManager func(ctx contex
 	return nil
 }
 
-func (b *Bootstrapper) isPKOAvailable(ctx context.Context) (bool, error) {
-	deploy := &appsv1.Deployment{}
-	err := b.client.Get(ctx, client.ObjectKey{
-		Name:      packageOperatorDeploymentName,
-		Namespace: b.pkoNamespace,
-	}, deploy)
-	if errors.IsNotFound(err) {
-		// Deployment does not exist.
-		return false, nil
-	}
-	if err != nil {
-		return false, err
-	}
-
-	for _, cond := range deploy.Status.Conditions {
-		if cond.Type == appsv1.DeploymentAvailable &&
-			cond.Status == corev1.ConditionTrue {
-			// Deployment is available -> nothing to do.
-			return true, nil
-		}
-	}
-	return false, nil
-}
-
 func (b *Bootstrapper) cancelWhenPackageAvailable(
 	ctx context.Context, cancel context.CancelFunc,
 ) {
@@ -132,7 +110,13 @@ func (b *Bootstrapper) cancelWhenPackageAvailable(
 	err := wait.PollImmediateUntilWithContext(
 		ctx, packageOperatorPackageCheckInterval,
 		func(ctx context.Context) (done bool, err error) {
-			return b.isPKOAvailable(ctx)
+			done, err = b.reconciler.Reconcile(ctx)
+			if err != nil {
+				// Retry, the bootstrap manager may still be working on it.
+				log.Error(err, "reconciling self-bootstrap")
+				return false, nil
+			}
+			return done, nil
 		})
 	if err != nil {
 		panic(err)
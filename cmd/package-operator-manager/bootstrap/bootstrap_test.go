@@ -11,9 +11,6 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
@@ -24,8 +21,9 @@ func TestBootstrapperBootstrap(t *testing.T) {
 	c := testutil.NewClient()
 	var initCalled bool
 	b := &Bootstrapper{
-		log:    testr.New(t),
-		client: c,
+		log:        testr.New(t),
+		client:     c,
+		reconciler: &bootstrapReconciler{client: c},
 		init: func(ctx context.Context) (
 			*corev1alpha1.ClusterPackage, error,
 		) {
@@ -41,17 +39,16 @@ func TestBootstrapperBootstrap(t *testing.T) {
 		},
 	})
 
+	c.On("Get", mock.Anything, mock.Anything,
+		mock.AnythingOfType("*v1alpha1.ClusterPackage"),
+		mock.Anything).
+		Return(nil)
 	c.On("Get", mock.Anything, mock.Anything,
 		mock.AnythingOfType("*v1.Deployment"),
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			depl := args.Get(2).(*appsv1.Deployment)
-			depl.Status.Conditions = []appsv1.DeploymentCondition{
-				{
-					Type:   appsv1.DeploymentAvailable,
-					Status: corev1.ConditionTrue,
-				},
-			}
+			setManagedAndAvailable(depl)
 		}).
 		Return(nil)
 
@@ -68,24 +65,26 @@ func TestBootstrapperBootstrap(t *testing.T) {
 
 func TestBootstrapper_bootstrap(t *testing.T) {
 	c := testutil.NewClient()
-	b := &Bootstrapper{client: c}
+	b := &Bootstrapper{
+		client:     c,
+		reconciler: &bootstrapReconciler{client: c},
+	}
 
 	var (
 		runManagerCalled bool
 		runManagerCtx    context.Context
 	)
 
+	c.On("Get", mock.Anything, mock.Anything,
+		mock.AnythingOfType("*v1alpha1.ClusterPackage"),
+		mock.Anything).
+		Return(nil)
 	c.On("Get", mock.Anything, mock.Anything,
 		mock.AnythingOfType("*v1.Deployment"),
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			depl := args.Get(2).(*appsv1.Deployment)
-			depl.Status.Conditions = []appsv1.DeploymentCondition{
-				{
-					Type:   appsv1.DeploymentAvailable,
-					Status: corev1.ConditionTrue,
-				},
-			}
+			setManagedAndAvailable(depl)
 		}).
 		Return(nil)
 
@@ -102,59 +101,3 @@ func TestBootstrapper_bootstrap(t *testing.T) {
 	assert.True(t, runManagerCalled)
 	assert.Equal(t, context.Canceled, runManagerCtx.Err())
 }
-
-func TestBootstrapper_isPKOAvailable(t *testing.T) {
-	t.Run("not found", func(t *testing.T) {
-		c := testutil.NewClient()
-
-		c.On("Get", mock.Anything, mock.Anything,
-			mock.AnythingOfType("*v1.Deployment"),
-			mock.Anything).
-			Return(errors.NewNotFound(schema.GroupResource{}, ""))
-
-		b := &Bootstrapper{client: c}
-		isPKOAvailable, err := b.isPKOAvailable(
-			context.Background())
-		require.NoError(t, err)
-		assert.False(t, isPKOAvailable)
-	})
-
-	t.Run("not available", func(t *testing.T) {
-		c := testutil.NewClient()
-
-		c.On("Get", mock.Anything, mock.Anything,
-			mock.AnythingOfType("*v1.Deployment"),
-			mock.Anything).
-			Return(nil)
-
-		b := &Bootstrapper{client: c}
-		isPKOAvailable, err := b.isPKOAvailable(
-			context.Background())
-		require.NoError(t, err)
-		assert.False(t, isPKOAvailable)
-	})
-
-	t.Run("available", func(t *testing.T) {
-		c := testutil.NewClient()
-
-		c.On("Get", mock.Anything, mock.Anything,
-			mock.AnythingOfType("*v1.Deployment"),
-			mock.Anything).
-			Run(func(args mock.Arguments) {
-				depl := args.Get(2).(*appsv1.Deployment)
-				depl.Status.Conditions = []appsv1.DeploymentCondition{
-					{
-						Type:   appsv1.DeploymentAvailable,
-						Status: corev1.ConditionTrue,
-					},
-				}
-			}).
-			Return(nil)
-
-		b := &Bootstrapper{client: c}
-		isPKOAvailable, err := b.isPKOAvailable(
-			context.Background())
-		require.NoError(t, err)
-		assert.True(t, isPKOAvailable)
-	})
-}
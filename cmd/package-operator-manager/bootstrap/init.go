@@ -33,6 +33,7 @@ type initializer struct {
 	// config
 	selfBootstrapImage string
 	selfConfig         string
+	identity           controllers.OperatorIdentity
 }
 
 func newInitializer(
@@ -43,6 +44,7 @@ func newInitializer(
 	// config
 	selfBootstrapImage string,
 	selfConfig string,
+	identity controllers.OperatorIdentity,
 ) *initializer {
 	return &initializer{
 		client:    client,
@@ -51,6 +53,7 @@ func newInitializer(
 
 		selfBootstrapImage: selfBootstrapImage,
 		selfConfig:         selfConfig,
+		identity:           identity,
 	}
 }
 
@@ -72,7 +75,8 @@ func (init *initializer) ensureClusterPackage(ctx context.Context) (
 ) {
 	pkoPackage := &corev1alpha1.ClusterPackage{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: packageOperatorClusterPackageName,
+			Name:   packageOperatorClusterPackageName,
+			Labels: init.identity.Labels(),
 		},
 		Spec: corev1alpha1.PackageSpec{
 			Image:  init.selfBootstrapImage,
@@ -121,7 +125,10 @@ func (init *initializer) crdsFromPackage(ctx context.Context) (
 	}
 
 	// Install CRDs or the manager won't start.
-	templateSpec := packagecontent.TemplateSpecFromPackage(packgeContent)
+	templateSpec, err := packagecontent.TemplateSpecFromPackage(packgeContent)
+	if err != nil {
+		return nil, fmt.Errorf("building template spec: %w", err)
+	}
 	return crdsFromTemplateSpec(templateSpec), nil
 }
 
@@ -136,7 +143,7 @@ func (init *initializer) ensureCRDs(ctx context.Context, crds []unstructured.Uns
 		if labels == nil {
 			labels = map[string]string{}
 		}
-		labels[controllers.DynamicCacheLabel] = "True"
+		labels[init.identity.DynamicCacheLabel()] = "True"
 		crd.SetLabels(labels)
 
 		log.Info("ensuring CRD", "name", crd.GetName())
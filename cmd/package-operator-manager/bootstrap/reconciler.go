@@ -0,0 +1,167 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers/objectdeployments"
+)
+
+// Drives the self-bootstrap until Package Operator is managing itself.
+//
+// PKO is part of the package it is reconciling, so when the package-operator
+// ClusterPackage is deleted, the PKO Deployment may be torn down before the
+// finalizers of the remaining objects have been removed.
+// The bootstrap manager is not part of the package and thus survives the teardown,
+// finishes it and re-creates the ClusterPackage afterwards.
+type bootstrapReconciler struct {
+	client        client.Client
+	ensurePackage func(ctx context.Context) (*corev1alpha1.ClusterPackage, error)
+	pkoNamespace  string
+	// Time after which a teardown of the package-operator ClusterPackage
+	// is considered stuck and finalizers are removed forcefully.
+	teardownTimeout time.Duration
+}
+
+// Reconcile returns true when the package-operator ClusterPackage
+// has rolled out an available PKO Deployment.
+func (r *bootstrapReconciler) Reconcile(ctx context.Context) (done bool, err error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	pkg := &corev1alpha1.ClusterPackage{}
+	err = r.client.Get(ctx, client.ObjectKey{Name: packageOperatorClusterPackageName}, pkg)
+	if errors.IsNotFound(err) {
+		log.Info("re-creating Package Operator ClusterPackage")
+		_, err := r.ensurePackage(ctx)
+		return false, err
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting Package Operator ClusterPackage: %w", err)
+	}
+
+	if !pkg.DeletionTimestamp.IsZero() {
+		if time.Since(pkg.DeletionTimestamp.Time) < r.teardownTimeout {
+			log.Info("waiting for Package Operator ClusterPackage teardown")
+			return false, nil
+		}
+		// PKO finishes the teardown itself, as long as it is running.
+		pkoGone, err := r.isPKOGone(ctx)
+		if err != nil {
+			return false, err
+		}
+		if !pkoGone {
+			log.Info("waiting for Package Operator ClusterPackage teardown, Package Operator Deployment is still present")
+			return false, nil
+		}
+		log.Info("Package Operator ClusterPackage teardown is stuck, removing finalizers")
+		return false, r.removeTeardownFinalizers(ctx, pkg)
+	}
+
+	return r.isPKOAvailable(ctx)
+}
+
+// Checks if the Package Operator Deployment is available and managed by PKO itself.
+// Deployments without ClusterObjectSet controller have been installed
+// from static manifests and have to be adopted.
+func (r *bootstrapReconciler) isPKOAvailable(ctx context.Context) (bool, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	deploy := &appsv1.Deployment{}
+	err := r.client.Get(ctx, client.ObjectKey{
+		Name:      packageOperatorDeploymentName,
+		Namespace: r.pkoNamespace,
+	}, deploy)
+	if errors.IsNotFound(err) {
+		// Deployment does not exist.
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if owner := metav1.GetControllerOf(deploy); owner == nil ||
+		owner.Kind != "ClusterObjectSet" ||
+		owner.APIVersion != corev1alpha1.GroupVersion.String() {
+		log.Info("Package Operator Deployment is not managed by a ClusterPackage, upgrading from static install")
+		return false, nil
+	}
+
+	for _, cond := range deploy.Status.Conditions {
+		if cond.Type == appsv1.DeploymentAvailable &&
+			cond.Status == corev1.ConditionTrue {
+			// Deployment is available -> nothing to do.
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Checks if the Package Operator Deployment has been deleted.
+func (r *bootstrapReconciler) isPKOGone(ctx context.Context) (bool, error) {
+	err := r.client.Get(ctx, client.ObjectKey{
+		Name:      packageOperatorDeploymentName,
+		Namespace: r.pkoNamespace,
+	}, &appsv1.Deployment{})
+	if errors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting Package Operator Deployment: %w", err)
+	}
+	return false, nil
+}
+
+// Removes finalizers from all objects of the package-operator ClusterPackage
+// that are being deleted, so the teardown can complete.
+func (r *bootstrapReconciler) removeTeardownFinalizers(
+	ctx context.Context, pkg *corev1alpha1.ClusterPackage,
+) error {
+	objectSets := &corev1alpha1.ClusterObjectSetList{}
+	if err := r.client.List(ctx, objectSets, client.MatchingLabels{
+		objectdeployments.ObjectSetObjectDeploymentLabel: pkg.Name,
+	}); err != nil {
+		return fmt.Errorf("listing Package Operator ClusterObjectSets: %w", err)
+	}
+	for i := range objectSets.Items {
+		if err := r.forceFinalize(ctx, "ClusterObjectSet", &objectSets.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	deploy := &corev1alpha1.ClusterObjectDeployment{}
+	err := r.client.Get(ctx, client.ObjectKey{Name: pkg.Name}, deploy)
+	switch {
+	case err == nil:
+		if err := r.forceFinalize(ctx, "ClusterObjectDeployment", deploy); err != nil {
+			return err
+		}
+	case !errors.IsNotFound(err):
+		return fmt.Errorf("getting Package Operator ClusterObjectDeployment: %w", err)
+	}
+	return r.forceFinalize(ctx, "ClusterPackage", pkg)
+}
+
+// Removes all finalizers from the given object, if it is being deleted.
+func (r *bootstrapReconciler) forceFinalize(ctx context.Context, kind string, obj client.Object) error {
+	if obj.GetDeletionTimestamp().IsZero() || len(obj.GetFinalizers()) == 0 {
+		return nil
+	}
+	logr.FromContextOrDiscard(ctx).Info("removing finalizers from stuck object",
+		"kind", kind, "name", obj.GetName(), "finalizers", obj.GetFinalizers())
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	obj.SetFinalizers(nil)
+	if err := r.client.Patch(ctx, obj, patch); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("removing finalizers from %s %s: %w", kind, obj.GetName(), err)
+	}
+	return nil
+}
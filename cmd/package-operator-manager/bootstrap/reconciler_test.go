@@ -0,0 +1,206 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func setManagedAndAvailable(depl *appsv1.Deployment) {
+	depl.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: corev1alpha1.GroupVersion.String(),
+		Kind:       "ClusterObjectSet",
+		Name:       "package-operator-1234",
+		Controller: pointer.Bool(true),
+	}}
+	depl.Status.Conditions = []appsv1.DeploymentCondition{
+		{
+			Type:   appsv1.DeploymentAvailable,
+			Status: corev1.ConditionTrue,
+		},
+	}
+}
+
+func TestBootstrapReconciler_packageNotFound(t *testing.T) {
+	c := testutil.NewClient()
+	c.On("Get", mock.Anything, mock.Anything,
+		mock.AnythingOfType("*v1alpha1.ClusterPackage"), mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+	var ensureCalled bool
+	r := &bootstrapReconciler{
+		client: c,
+		ensurePackage: func(ctx context.Context) (*corev1alpha1.ClusterPackage, error) {
+			ensureCalled = true
+			return &corev1alpha1.ClusterPackage{}, nil
+		},
+	}
+	done, err := r.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.False(t, done)
+	assert.True(t, ensureCalled)
+}
+
+func TestBootstrapReconciler_teardown(t *testing.T) {
+	const testTeardownTimeout = 30 * time.Minute
+
+	t.Run("in progress", func(t *testing.T) {
+		c := testutil.NewClient()
+		now := metav1.Now()
+		c.On("Get", mock.Anything, mock.Anything,
+			mock.AnythingOfType("*v1alpha1.ClusterPackage"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				pkg := args.Get(2).(*corev1alpha1.ClusterPackage)
+				pkg.DeletionTimestamp = &now
+				pkg.Finalizers = []string{"test"}
+			}).
+			Return(nil)
+
+		r := &bootstrapReconciler{client: c, teardownTimeout: testTeardownTimeout}
+		done, err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+		assert.False(t, done)
+		c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	deletionTimestamp := metav1.NewTime(time.Now().Add(-2 * testTeardownTimeout))
+
+	t.Run("stuck while PKO is running", func(t *testing.T) {
+		c := testutil.NewClient()
+		c.On("Get", mock.Anything, mock.Anything,
+			mock.AnythingOfType("*v1alpha1.ClusterPackage"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				pkg := args.Get(2).(*corev1alpha1.ClusterPackage)
+				pkg.DeletionTimestamp = &deletionTimestamp
+				pkg.Finalizers = []string{"test"}
+			}).
+			Return(nil)
+		c.On("Get", mock.Anything, mock.Anything,
+			mock.AnythingOfType("*v1.Deployment"), mock.Anything).
+			Return(nil)
+
+		r := &bootstrapReconciler{client: c, teardownTimeout: testTeardownTimeout}
+		done, err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+		assert.False(t, done)
+		c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("stuck", func(t *testing.T) {
+		c := testutil.NewClient()
+		c.On("Get", mock.Anything, mock.Anything,
+			mock.AnythingOfType("*v1alpha1.ClusterPackage"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				pkg := args.Get(2).(*corev1alpha1.ClusterPackage)
+				pkg.Name = packageOperatorClusterPackageName
+				pkg.DeletionTimestamp = &deletionTimestamp
+				pkg.Finalizers = []string{"test"}
+			}).
+			Return(nil)
+		c.On("Get", mock.Anything, mock.Anything,
+			mock.AnythingOfType("*v1.Deployment"), mock.Anything).
+			Return(errors.NewNotFound(schema.GroupResource{}, ""))
+		c.On("Get", mock.Anything, mock.Anything,
+			mock.AnythingOfType("*v1alpha1.ClusterObjectDeployment"), mock.Anything).
+			Return(errors.NewNotFound(schema.GroupResource{}, ""))
+		c.On("List", mock.Anything,
+			mock.AnythingOfType("*v1alpha1.ClusterObjectSetList"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				list := args.Get(1).(*corev1alpha1.ClusterObjectSetList)
+				list.Items = []corev1alpha1.ClusterObjectSet{
+					{ObjectMeta: metav1.ObjectMeta{
+						Name:              "package-operator-1",
+						DeletionTimestamp: &deletionTimestamp,
+						Finalizers:        []string{"package-operator.run/cached"},
+					}},
+					{ObjectMeta: metav1.ObjectMeta{
+						Name:       "package-operator-2",
+						Finalizers: []string{"package-operator.run/cached"},
+					}},
+				}
+			}).
+			Return(nil)
+
+		var patched []string
+		c.On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				obj := args.Get(1).(client.Object)
+				assert.Empty(t, obj.GetFinalizers())
+				patched = append(patched, obj.GetName())
+			}).
+			Return(nil)
+
+		r := &bootstrapReconciler{client: c, teardownTimeout: testTeardownTimeout}
+		done, err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.Equal(t, []string{"package-operator-1", "package-operator"}, patched)
+	})
+}
+
+func TestBootstrapReconciler_isPKOAvailable(t *testing.T) {
+	tests := []struct {
+		name      string
+		getErr    error
+		modify    func(depl *appsv1.Deployment)
+		available bool
+	}{
+		{
+			name:   "not found",
+			getErr: errors.NewNotFound(schema.GroupResource{}, ""),
+		},
+		{
+			name: "not available",
+			modify: func(depl *appsv1.Deployment) {
+				setManagedAndAvailable(depl)
+				depl.Status.Conditions = nil
+			},
+		},
+		{
+			name: "static install",
+			modify: func(depl *appsv1.Deployment) {
+				setManagedAndAvailable(depl)
+				depl.OwnerReferences = nil
+			},
+		},
+		{
+			name:      "available",
+			modify:    setManagedAndAvailable,
+			available: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			c := testutil.NewClient()
+			c.On("Get", mock.Anything, mock.Anything,
+				mock.AnythingOfType("*v1.Deployment"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					if test.modify != nil {
+						test.modify(args.Get(2).(*appsv1.Deployment))
+					}
+				}).
+				Return(test.getErr)
+
+			r := &bootstrapReconciler{client: c}
+			available, err := r.isPKOAvailable(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, test.available, available)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	"go.uber.org/dig"
 	batchv1 "k8s.io/api/batch/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -20,14 +21,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/config/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	pkoapis "package-operator.run/apis"
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/package-operator/internal/controllers"
 	hypershiftv1beta1 "package-operator.run/package-operator/internal/controllers/hostedclusters/hypershift/v1beta1"
 	"package-operator.run/package-operator/internal/dynamiccache"
 	"package-operator.run/package-operator/internal/environment"
 	"package-operator.run/package-operator/internal/metrics"
+	"package-operator.run/package-operator/internal/packages/packageimport"
 )
 
 // Returns a new pre-configured DI container.
@@ -35,9 +40,10 @@ func NewComponents() (*dig.Container, error) {
 	container := dig.New()
 	providers := []interface{}{
 		ProvideScheme, ProvideRestConfig, ProvideManager,
-		ProvideMetricsRecorder, ProvideDynamicCache,
+		ProvideMetricsRecorder, ProvideDynamicCache, ProvideReadiness,
 		ProvideUncachedClient, ProvideOptions, ProvideLogger,
-		ProvideRegistry, ProvideDiscoveryClient, ProvideEnvironmentManager,
+		ProvideRegistry, ProvidePackageImagePuller, ProvidePackageSourceLoader,
+		ProvideDiscoveryClient, ProvideEnvironmentManager, ProvideObjectMutators,
 
 		// -----------
 		// Controllers
@@ -53,6 +59,10 @@ func NewComponents() (*dig.Container, error) {
 		ProvidePackageController, ProvideClusterPackageController,
 		// ObjectTemplate
 		ProvideObjectTemplateController, ProvideClusterObjectTemplateController,
+		// PackageRepository
+		ProvidePackageRepositoryController,
+		// PackageFleet
+		ProvidePackageFleetController,
 
 		// HostedCluster
 		ProvideHostedClusterController,
@@ -62,6 +72,9 @@ func NewComponents() (*dig.Container, error) {
 			return nil, err
 		}
 	}
+	if err := container.Decorate(DecorateOptionsWithPackageOperatorConfig); err != nil {
+		return nil, err
+	}
 	return container, nil
 }
 
@@ -85,8 +98,18 @@ func ProvideScheme() (*runtime.Scheme, error) {
 	return scheme, nil
 }
 
-func ProvideRestConfig() (*rest.Config, error) {
-	return ctrl.GetConfig()
+func ProvideRestConfig(opts Options) (*rest.Config, error) {
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	if opts.KubeAPIQPS > 0 {
+		restConfig.QPS = float32(opts.KubeAPIQPS)
+	}
+	if opts.KubeAPIBurst > 0 {
+		restConfig.Burst = opts.KubeAPIBurst
+	}
+	return restConfig, nil
 }
 
 func ProvideManager(
@@ -94,24 +117,49 @@ func ProvideManager(
 	restConfig *rest.Config,
 	opts Options,
 ) (ctrl.Manager, error) {
+	groupKindConcurrency, err := groupKindConcurrency(
+		opts.MaxConcurrentReconciles, opts.ControllerConcurrency)
+	if err != nil {
+		return nil, err
+	}
+
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                     scheme,
 		MetricsBindAddress:         opts.MetricsAddr,
 		HealthProbeBindAddress:     opts.ProbeAddr,
-		Port:                       9443,
+		Port:                       opts.WebhookPort,
+		CertDir:                    opts.WebhookCertDir,
 		LeaderElectionResourceLock: "leases",
 		LeaderElection:             opts.EnableLeaderElection,
-		LeaderElectionID:           "8a4hp84a6s.package-operator-lock",
+		LeaderElectionID:           leaderElectionID(opts.OperatorIdentity, opts.Shard()),
+		LeaderElectionNamespace:    opts.LeaderElectionNamespace,
+		LeaseDuration:              &opts.LeaseDuration,
+		RenewDeadline:              &opts.RenewDeadline,
+		RetryPeriod:                &opts.RetryPeriod,
+		// Only safe, because the process exits right after the manager stopped.
+		LeaderElectionReleaseOnCancel: opts.ReleaseOnCancel,
+		GracefulShutdownTimeout:       &opts.GracefulShutdownTimeout,
+		Controller: v1alpha1.ControllerConfigurationSpec{
+			GroupKindConcurrency: groupKindConcurrency,
+		},
+		NewClient: newClientFunc(opts.ObserveOnly),
 		MapperProvider: func(c *rest.Config) (meta.RESTMapper, error) {
 			return apiutil.NewDynamicRESTMapper(c, apiutil.WithLazyDiscovery)
 		},
-		NewCache: cache.BuilderWithOptions(cache.Options{
+		ClientDisableCacheFor: uncachedClusterScopedObjects(opts),
+		NewCache: newCacheFunc(opts.CacheNamespaces(), cache.Options{
 			SelectorsByObject: cache.SelectorsByObject{
 				// We create Jobs to unpack package images.
 				// Limit caches to only contain Jobs that we create ourselves.
 				&batchv1.Job{}: {
 					Label: labels.SelectorFromSet(labels.Set{
-						controllers.DynamicCacheLabel: "True",
+						opts.Identity().DynamicCacheLabel(): "True",
+					}),
+				},
+				// Only remote phase heartbeats are read from Leases.
+				&coordinationv1.Lease{}: {
+					Label: labels.SelectorFromSet(labels.Set{
+						opts.Identity().DynamicCacheLabel(): "True",
 					}),
 				},
 			},
@@ -133,9 +181,71 @@ func ProvideManager(
 	if err := registerPPROF(mgr, opts.PPROFAddr); err != nil {
 		return nil, err
 	}
+
+	// Tracing
+	if err := registerTracing(mgr, opts.Tracing); err != nil {
+		return nil, err
+	}
+
+	// Conversion Webhook
+	if err := registerConversionWebhook(mgr, opts.ConversionWebhook); err != nil {
+		return nil, err
+	}
 	return mgr, nil
 }
 
+// In observe-only mode all controllers share a client,
+// that never persists changes to objects outside of the Package Operator API.
+func newClientFunc(observeOnly bool) cluster.NewClientFunc {
+	return func(
+		cache cache.Cache, config *rest.Config, options client.Options, uncachedObjects ...client.Object,
+	) (client.Client, error) {
+		c, err := cluster.DefaultNewClient(cache, config, options, uncachedObjects...)
+		if err != nil || !observeOnly {
+			return c, err
+		}
+		return controllers.NewObserveOnlyClient(c), nil
+	}
+}
+
+// Namespace-scoped managers only cache objects in the given namespaces.
+// Cluster-scoped objects, like the PackageOperatorConfig, are still cached cluster-wide,
+// unless excluded by uncachedClusterScopedObjects.
+func newCacheFunc(namespaces []string, opts cache.Options) cache.NewCacheFunc {
+	if len(namespaces) == 0 {
+		return cache.BuilderWithOptions(opts)
+	}
+	return func(config *rest.Config, inherited cache.Options) (cache.Cache, error) {
+		inherited.SelectorsByObject = opts.SelectorsByObject
+		return cache.MultiNamespacedCacheBuilder(namespaces)(config, inherited)
+	}
+}
+
+// Cluster-scoped objects read by namespace-scoped managers are not cached,
+// because namespace-scoped permissions do not allow to watch them.
+func uncachedClusterScopedObjects(opts Options) []client.Object {
+	if !opts.IsNamespaceScoped() {
+		return nil
+	}
+	return []client.Object{
+		&corev1alpha1.PackageOperatorConfig{},
+		&corev1alpha1.PhaseClass{},
+	}
+}
+
+// Separate installations of Package Operator must not compete for the same lease.
+// Each shard holds its own lease, so one replica per shard is active.
+func leaderElectionID(operatorIdentity string, shard controllers.Shard) string {
+	id := "8a4hp84a6s.package-operator-lock"
+	if shard.IsSharded() {
+		id = fmt.Sprintf("shard-%d.%s", shard.Index, id)
+	}
+	if len(operatorIdentity) == 0 {
+		return id
+	}
+	return operatorIdentity + "." + id
+}
+
 func ProvideMetricsRecorder() *metrics.Recorder {
 	recorder := metrics.NewRecorder()
 	recorder.Register()
@@ -145,6 +255,7 @@ func ProvideMetricsRecorder() *metrics.Recorder {
 func ProvideDynamicCache(
 	mgr ctrl.Manager,
 	recorder *metrics.Recorder,
+	opts Options,
 ) (*dynamiccache.Cache, error) {
 	dc := dynamiccache.NewCache(
 		mgr.GetConfig(), mgr.GetScheme(), mgr.GetRESTMapper(), recorder,
@@ -153,17 +264,22 @@ func ProvideDynamicCache(
 			// so we prevent our caches from exploding!
 			schema.GroupVersionKind{}: dynamiccache.Selector{
 				Label: labels.SelectorFromSet(labels.Set{
-					controllers.DynamicCacheLabel: "True",
+					opts.Identity().DynamicCacheLabel(): "True",
 				}),
 			},
-		})
+		},
+		controllers.DynamicCacheIndexers(opts.Identity()),
+		// Only watch objects within the namespace of the owning ObjectSet,
+		// when permissions are limited to the watched namespaces.
+		dynamiccache.OwnerNamespaceScoped(opts.IsNamespaceScoped()),
+	)
 	return dc, nil
 }
 
 type UncachedClient struct{ client.Client }
 
 func ProvideUncachedClient(
-	restConfig *rest.Config, scheme *runtime.Scheme,
+	restConfig *rest.Config, scheme *runtime.Scheme, opts Options,
 ) (UncachedClient, error) {
 	uncachedClient, err := client.New(
 		restConfig,
@@ -174,7 +290,18 @@ func ProvideUncachedClient(
 		return UncachedClient{},
 			fmt.Errorf("unable to set up uncached client: %w", err)
 	}
-	return UncachedClient{uncachedClient}, nil
+	return UncachedClient{newObserveOnlyUncachedClient(uncachedClient, opts)}, nil
+}
+
+// The uncached client creates unpack Pods for the PodPuller,
+// which are written in observe-only mode too, as Packages can't be observed without their content.
+func newObserveOnlyUncachedClient(c client.Client, opts Options) client.Client {
+	if !opts.ObserveOnly {
+		return c
+	}
+	return controllers.NewObserveOnlyClient(c, func(obj client.Object) bool {
+		return packageimport.IsUnpackPod(obj, opts.Namespace)
+	})
 }
 
 func ProvideDiscoveryClient(restConfig *rest.Config) (
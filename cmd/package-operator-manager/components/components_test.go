@@ -1,9 +1,20 @@
 package components
 
 import (
+	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/packages/packageimport"
+	"package-operator.run/package-operator/internal/testutil"
 )
 
 func TestNewComponents(t *testing.T) {
@@ -25,7 +36,44 @@ func TestProvideMetricsRecorder(_ *testing.T) {
 }
 
 func TestUncachedClient(t *testing.T) {
-	_, err := ProvideUncachedClient(nil, nil)
+	_, err := ProvideUncachedClient(nil, nil, Options{})
 	require.EqualError(t, err,
 		"unable to set up uncached client: must provide non-nil rest.Config to client.New")
 }
+
+func Test_newObserveOnlyUncachedClient_unpackPods(t *testing.T) {
+	scheme := testutil.NewTestSchemeWithCoreV1Alpha1()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	c := newObserveOnlyUncachedClient(fakeClient, Options{ObserveOnly: true, Namespace: "pko-system"})
+	ctx := context.Background()
+
+	// Unpack Pods are created in observe-only mode.
+	puller := packageimport.NewPodPuller(c, nil, "pko-system", "manager:v1")
+	_, err := puller.Pull(ctx, "quay.io/package-operator/test:v1")
+	require.ErrorIs(t, err, packageimport.ErrUnpackInProgress)
+	pods := &corev1.PodList{}
+	require.NoError(t, fakeClient.List(ctx, pods))
+	if assert.Len(t, pods.Items, 1) {
+		assert.True(t, packageimport.IsUnpackPod(&pods.Items[0], "pko-system"))
+	}
+
+	// Other Pods are not.
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "package-unpack-other",
+		Namespace: "test",
+		Labels:    map[string]string{packageimport.UnpackPodLabel: "True"},
+	}}
+	require.NoError(t, c.Create(ctx, pod))
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), &corev1.Pod{})
+	assert.True(t, errors.IsNotFound(err), "Pod must not be created")
+}
+
+func Test_leaderElectionID(t *testing.T) {
+	assert.Equal(t, "8a4hp84a6s.package-operator-lock",
+		leaderElectionID("", controllers.Shard{Count: 1}))
+	assert.Equal(t, "test.8a4hp84a6s.package-operator-lock",
+		leaderElectionID("test", controllers.Shard{}))
+	assert.Equal(t, "test.shard-2.8a4hp84a6s.package-operator-lock",
+		leaderElectionID("test", controllers.Shard{Index: 2, Count: 3}))
+}
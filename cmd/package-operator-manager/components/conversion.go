@@ -0,0 +1,62 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Path the conversion webhook is served at,
+// referenced by the conversion strategy of CustomResourceDefinitions.
+const conversionWebhookPath = "/convert"
+
+// Objects of APIs that are converted between versions by the conversion webhook.
+// All versions of these APIs need to convert from and to the v1alpha1 hub.
+var convertedObjects = []client.Object{
+	&corev1alpha1.ObjectSet{},
+	&corev1alpha1.ClusterObjectSet{},
+	&corev1alpha1.ObjectDeployment{},
+	&corev1alpha1.ClusterObjectDeployment{},
+}
+
+// Returns a handler converting objects between all API versions known to the scheme.
+// Errors if any version is missing its conversion to the hub.
+func newConversionWebhook(scheme *runtime.Scheme) (http.Handler, error) {
+	for _, obj := range convertedObjects {
+		if _, err := conversion.IsConvertible(scheme, obj); err != nil {
+			return nil, fmt.Errorf("checking conversion of %T: %w", obj, err)
+		}
+	}
+
+	wh := &conversion.Webhook{}
+	if err := wh.InjectScheme(scheme); err != nil {
+		return nil, err
+	}
+	return wh, nil
+}
+
+// Serves the conversion webhook with the serving certificate from the configured certificate directory.
+// Certificates are reloaded when they change on disk, so they can be rotated without restarts.
+func registerConversionWebhook(mgr ctrl.Manager, enabled bool) error {
+	if !enabled {
+		return nil
+	}
+
+	wh, err := newConversionWebhook(mgr.GetScheme())
+	if err != nil {
+		return fmt.Errorf("unable to set up conversion webhook: %w", err)
+	}
+
+	server := mgr.GetWebhookServer()
+	server.Register(conversionWebhookPath, wh)
+	if err := mgr.AddReadyzCheck("webhook", server.StartedChecker()); err != nil {
+		return fmt.Errorf("unable to set up webhook ready check: %w", err)
+	}
+	return nil
+}
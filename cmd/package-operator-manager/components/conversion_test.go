@@ -0,0 +1,36 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestNewConversionWebhook(t *testing.T) {
+	scheme, err := ProvideScheme()
+	require.NoError(t, err)
+
+	wh, err := newConversionWebhook(scheme)
+	require.NoError(t, err)
+	assert.NotNil(t, wh)
+}
+
+// Embeds the hub, so it would be a second hub for the same Kind.
+type unconvertibleObjectSet struct {
+	corev1alpha1.ObjectSet
+}
+
+func TestNewConversionWebhook_missingConversion(t *testing.T) {
+	scheme, err := ProvideScheme()
+	require.NoError(t, err)
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{
+		Group: corev1alpha1.GroupVersion.Group, Version: "v1alpha2", Kind: "ObjectSet",
+	}, &unconvertibleObjectSet{})
+
+	_, err = newConversionWebhook(scheme)
+	assert.Error(t, err)
+}
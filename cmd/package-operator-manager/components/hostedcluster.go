@@ -15,12 +15,12 @@ func ProvideHostedClusterController(
 	mgr ctrl.Manager, log logr.Logger,
 	opts Options,
 ) HostedClusterController {
-	return HostedClusterController{
-		hostedclusters.NewHostedClusterController(
-			mgr.GetClient(),
-			log.WithName("controllers").WithName("HostedCluster"),
-			mgr.GetScheme(),
-			opts.RemotePhasePackageImage,
-		),
-	}
+	c := hostedclusters.NewHostedClusterController(
+		mgr.GetClient(),
+		log.WithName("controllers").WithName("HostedCluster"),
+		mgr.GetScheme(),
+		opts.RemotePhasePackageImage,
+	)
+	c.SetOperatorIdentity(opts.Identity())
+	return HostedClusterController{c}
 }
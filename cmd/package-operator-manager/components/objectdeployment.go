@@ -10,30 +10,30 @@ import (
 // Type alias for dependency injector to differentiate
 // Cluster and non-cluster scoped *Generic<>Controllers.
 type (
-	ObjectDeploymentController        struct{ controller }
-	ClusterObjectDeploymentController struct{ controller }
+	ObjectDeploymentController        struct{ rateLimitedController }
+	ClusterObjectDeploymentController struct{ rateLimitedController }
 )
 
 func ProvideObjectDeploymentController(
-	mgr ctrl.Manager, log logr.Logger,
+	mgr ctrl.Manager, log logr.Logger, opts Options,
 ) ObjectDeploymentController {
-	return ObjectDeploymentController{
-		objectdeployments.NewObjectDeploymentController(
-			mgr.GetClient(),
-			log.WithName("controllers").WithName("ObjectDeployment"),
-			mgr.GetScheme(),
-		),
-	}
+	c := objectdeployments.NewObjectDeploymentController(
+		mgr.GetClient(),
+		log.WithName("controllers").WithName("ObjectDeployment"),
+		mgr.GetScheme(),
+	)
+	c.SetOperatorIdentity(opts.Identity())
+	return ObjectDeploymentController{c}
 }
 
 func ProvideClusterObjectDeploymentController(
-	mgr ctrl.Manager, log logr.Logger,
+	mgr ctrl.Manager, log logr.Logger, opts Options,
 ) ClusterObjectDeploymentController {
-	return ClusterObjectDeploymentController{
-		objectdeployments.NewClusterObjectDeploymentController(
-			mgr.GetClient(),
-			log.WithName("controllers").WithName("ClusterObjectDeployment"),
-			mgr.GetScheme(),
-		),
-	}
+	c := objectdeployments.NewClusterObjectDeploymentController(
+		mgr.GetClient(),
+		log.WithName("controllers").WithName("ClusterObjectDeployment"),
+		mgr.GetScheme(),
+	)
+	c.SetOperatorIdentity(opts.Identity())
+	return ClusterObjectDeploymentController{c}
 }
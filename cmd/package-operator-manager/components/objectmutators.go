@@ -0,0 +1,51 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"package-operator.run/package-operator/internal/controllers"
+)
+
+// ObjectMutators run on every object applied by ObjectSets and ObjectSetPhases.
+// Distributions embedding the manager may decorate this list with their own mutators.
+type ObjectMutators []controllers.ObjectMutator
+
+func ProvideObjectMutators(opts Options) (ObjectMutators, error) {
+	labels, err := parseKeyValues(opts.ObjectLabels)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object labels: %w", err)
+	}
+	annotations, err := parseKeyValues(opts.ObjectAnnotations)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object annotations: %w", err)
+	}
+
+	var mutators ObjectMutators
+	if len(labels) > 0 || len(annotations) > 0 {
+		mutators = append(mutators, controllers.NewMetadataMutator(labels, annotations))
+	}
+	return mutators, nil
+}
+
+// Parses a comma separated list of <key>=<value> pairs.
+func parseKeyValues(flag string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, pair := range strings.Split(flag, ",") {
+		if len(strings.TrimSpace(pair)) == 0 {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok {
+			return nil, fmt.Errorf("%q, expected <key>=<value>", pair)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("%q: %s", key, strings.Join(errs, ", "))
+		}
+		out[key] = strings.TrimSpace(value)
+	}
+	return out, nil
+}
@@ -0,0 +1,34 @@
+package components
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestProvideObjectMutators(t *testing.T) {
+	mutators, err := ProvideObjectMutators(Options{})
+	require.NoError(t, err)
+	assert.Empty(t, mutators)
+
+	mutators, err = ProvideObjectMutators(Options{
+		ObjectLabels:      "team=platform, env = prod",
+		ObjectAnnotations: "sidecar.example.com/inject=false",
+	})
+	require.NoError(t, err)
+	require.Len(t, mutators, 1)
+
+	obj := &unstructured.Unstructured{}
+	require.NoError(t, mutators[0].Mutate(context.Background(), nil, obj))
+	assert.Equal(t, map[string]string{"team": "platform", "env": "prod"}, obj.GetLabels())
+	assert.Equal(t, map[string]string{"sidecar.example.com/inject": "false"}, obj.GetAnnotations())
+
+	_, err = ProvideObjectMutators(Options{ObjectLabels: "team"})
+	assert.EqualError(t, err, `invalid object labels: "team", expected <key>=<value>`)
+
+	_, err = ProvideObjectMutators(Options{ObjectAnnotations: "not valid=true"})
+	assert.ErrorContains(t, err, `invalid object annotations: "not valid"`)
+}
@@ -2,18 +2,21 @@ package components
 
 import (
 	"github.com/go-logr/logr"
+	"k8s.io/client-go/discovery"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/controllers/objectsets"
 	"package-operator.run/package-operator/internal/dynamiccache"
 	"package-operator.run/package-operator/internal/metrics"
+	"package-operator.run/package-operator/internal/packages/packagedeploy"
 )
 
 // Type alias for dependency injector to differentiate
 // Cluster and non-cluster scoped *Generic<>Controllers.
 type (
-	ObjectSetController        struct{ controller }
-	ClusterObjectSetController struct{ controller }
+	ObjectSetController        struct{ controllerAndEnvSinker }
+	ClusterObjectSetController struct{ controllerAndEnvSinker }
 )
 
 func ProvideObjectSetController(
@@ -21,15 +24,30 @@ func ProvideObjectSetController(
 	dc *dynamiccache.Cache,
 	uncachedClient UncachedClient,
 	recorder *metrics.Recorder,
+	mutators ObjectMutators,
+	discoveryClient discovery.DiscoveryInterface,
+	imagePuller PackageImagePuller,
+	opts Options,
 ) ObjectSetController {
-	return ObjectSetController{
-		objectsets.NewObjectSetController(
-			mgr.GetClient(),
-			log.WithName("controllers").WithName("ObjectSet"),
-			mgr.GetScheme(), dc, uncachedClient, recorder,
-			mgr.GetRESTMapper(),
-		),
-	}
+	c := objectsets.NewObjectSetController(
+		mgr.GetClient(),
+		log.WithName("controllers").WithName("ObjectSet"),
+		mgr.GetScheme(), dc, uncachedClient, recorder,
+		mgr.GetRESTMapper(),
+		controllers.WithObjectMutators(mutators),
+		controllers.WithOperatorIdentity{OperatorIdentity: opts.Identity()},
+	)
+	c.SetArchiveCompaction(opts.ArchiveCompaction)
+	// Compacted ObjectSets are templated again from their package image, when reactivated.
+	objectLookup := controllers.NewObjectLookup(
+		mgr.GetClient(), uncachedClient, dc, mgr.GetRESTMapper(), opts.Identity())
+	c.SetRehydrator(packagedeploy.NewObjectSetRehydrator(
+		mgr.GetClient(), mgr.GetScheme(), imagePuller,
+		packagedeploy.NewPackageDeployer(mgr.GetClient(), mgr.GetScheme(), discoveryClient, objectLookup),
+	))
+	c.SetObserveOnly(opts.ObserveOnly)
+	c.SetShard(opts.Shard())
+	return ObjectSetController{c}
 }
 
 func ProvideClusterObjectSetController(
@@ -37,13 +55,28 @@ func ProvideClusterObjectSetController(
 	dc *dynamiccache.Cache,
 	uncachedClient UncachedClient,
 	recorder *metrics.Recorder,
+	mutators ObjectMutators,
+	discoveryClient discovery.DiscoveryInterface,
+	imagePuller PackageImagePuller,
+	opts Options,
 ) ClusterObjectSetController {
-	return ClusterObjectSetController{
-		objectsets.NewClusterObjectSetController(
-			mgr.GetClient(),
-			log.WithName("controllers").WithName("ObjectSet"),
-			mgr.GetScheme(), dc, uncachedClient, recorder,
-			mgr.GetRESTMapper(),
-		),
-	}
+	c := objectsets.NewClusterObjectSetController(
+		mgr.GetClient(),
+		log.WithName("controllers").WithName("ObjectSet"),
+		mgr.GetScheme(), dc, uncachedClient, recorder,
+		mgr.GetRESTMapper(),
+		controllers.WithObjectMutators(mutators),
+		controllers.WithOperatorIdentity{OperatorIdentity: opts.Identity()},
+	)
+	c.SetArchiveCompaction(opts.ArchiveCompaction)
+	// Compacted ObjectSets are templated again from their package image, when reactivated.
+	objectLookup := controllers.NewObjectLookup(
+		mgr.GetClient(), uncachedClient, dc, mgr.GetRESTMapper(), opts.Identity())
+	c.SetRehydrator(packagedeploy.NewClusterObjectSetRehydrator(
+		mgr.GetClient(), mgr.GetScheme(), imagePuller,
+		packagedeploy.NewClusterPackageDeployer(mgr.GetClient(), mgr.GetScheme(), discoveryClient, objectLookup),
+	))
+	c.SetObserveOnly(opts.ObserveOnly)
+	c.SetShard(opts.Shard())
+	return ClusterObjectSetController{c}
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/go-logr/logr"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/controllers/objectsetphases"
 	"package-operator.run/package-operator/internal/dynamiccache"
 )
@@ -11,8 +12,8 @@ import (
 // Type alias for dependency injector to differentiate
 // Cluster and non-cluster scoped *Generic<>Controllers.
 type (
-	ObjectSetPhaseController        struct{ controller }
-	ClusterObjectSetPhaseController struct{ controller }
+	ObjectSetPhaseController        struct{ rateLimitedController }
+	ClusterObjectSetPhaseController struct{ rateLimitedController }
 )
 
 const defaultObjectSetPhaseClass = "default"
@@ -21,28 +22,38 @@ func ProvideObjectSetPhaseController(
 	mgr ctrl.Manager, log logr.Logger,
 	dc *dynamiccache.Cache,
 	uncachedClient UncachedClient,
+	mutators ObjectMutators,
+	opts Options,
 ) ObjectSetPhaseController {
-	return ObjectSetPhaseController{
-		objectsetphases.NewSameClusterObjectSetPhaseController(
-			log.WithName("controllers").WithName("ObjectSetPhase"),
-			mgr.GetScheme(), dc, uncachedClient,
-			defaultObjectSetPhaseClass, mgr.GetClient(),
-			mgr.GetRESTMapper(),
-		),
-	}
+	c := objectsetphases.NewSameClusterObjectSetPhaseController(
+		log.WithName("controllers").WithName("ObjectSetPhase"),
+		mgr.GetScheme(), dc, uncachedClient,
+		defaultObjectSetPhaseClass, mgr.GetClient(),
+		mgr.GetRESTMapper(),
+		controllers.WithObjectMutators(mutators),
+		controllers.WithOperatorIdentity{OperatorIdentity: opts.Identity()},
+	)
+	c.SetObserveOnly(opts.ObserveOnly)
+	c.SetShard(opts.Shard())
+	return ObjectSetPhaseController{c}
 }
 
 func ProvideClusterObjectSetPhaseController(
 	mgr ctrl.Manager, log logr.Logger,
 	dc *dynamiccache.Cache,
 	uncachedClient UncachedClient,
+	mutators ObjectMutators,
+	opts Options,
 ) ClusterObjectSetPhaseController {
-	return ClusterObjectSetPhaseController{
-		objectsetphases.NewSameClusterClusterObjectSetPhaseController(
-			log.WithName("controllers").WithName("ClusterObjectSetPhase"),
-			mgr.GetScheme(), dc, uncachedClient,
-			defaultObjectSetPhaseClass, mgr.GetClient(),
-			mgr.GetRESTMapper(),
-		),
-	}
+	c := objectsetphases.NewSameClusterClusterObjectSetPhaseController(
+		log.WithName("controllers").WithName("ClusterObjectSetPhase"),
+		mgr.GetScheme(), dc, uncachedClient,
+		defaultObjectSetPhaseClass, mgr.GetClient(),
+		mgr.GetRESTMapper(),
+		controllers.WithObjectMutators(mutators),
+		controllers.WithOperatorIdentity{OperatorIdentity: opts.Identity()},
+	)
+	c.SetObserveOnly(opts.ObserveOnly)
+	c.SetShard(opts.Shard())
+	return ClusterObjectSetPhaseController{c}
 }
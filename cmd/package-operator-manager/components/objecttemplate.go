@@ -23,26 +23,28 @@ func ProvideObjectTemplateController(
 	mgr ctrl.Manager, log logr.Logger,
 	uncachedClient UncachedClient,
 	dc *dynamiccache.Cache,
+	opts Options,
 ) ObjectTemplateController {
-	return ObjectTemplateController{
-		objecttemplate.NewObjectTemplateController(
-			mgr.GetClient(), uncachedClient,
-			log.WithName("controllers").WithName("ObjectTemplate"),
-			dc, mgr.GetScheme(), mgr.GetRESTMapper(),
-		),
-	}
+	c := objecttemplate.NewObjectTemplateController(
+		mgr.GetClient(), uncachedClient,
+		log.WithName("controllers").WithName("ObjectTemplate"),
+		dc, mgr.GetScheme(), mgr.GetRESTMapper(),
+	)
+	c.SetOperatorIdentity(opts.Identity())
+	return ObjectTemplateController{c}
 }
 
 func ProvideClusterObjectTemplateController(
 	mgr ctrl.Manager, log logr.Logger,
 	uncachedClient UncachedClient,
 	dc *dynamiccache.Cache,
+	opts Options,
 ) ClusterObjectTemplateController {
-	return ClusterObjectTemplateController{
-		objecttemplate.NewClusterObjectTemplateController(
-			mgr.GetClient(), uncachedClient,
-			log.WithName("controllers").WithName("ClusterObjectTemplate"),
-			dc, mgr.GetScheme(), mgr.GetRESTMapper(),
-		),
-	}
+	c := objecttemplate.NewClusterObjectTemplateController(
+		mgr.GetClient(), uncachedClient,
+		log.WithName("controllers").WithName("ClusterObjectTemplate"),
+		dc, mgr.GetScheme(), mgr.GetRESTMapper(),
+	)
+	c.SetOperatorIdentity(opts.Identity())
+	return ClusterObjectTemplateController{c}
 }
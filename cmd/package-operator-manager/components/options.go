@@ -5,6 +5,12 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/tracing"
 )
 
 // Flags.
@@ -21,27 +27,180 @@ const (
 		" to load a package mounted at /package"
 	selfBootstrapFlagDescription = "(internal) bootstraps Package Operator" +
 		" with Package Operator using the given Package Operator Package Image"
+	selfBootstrapTeardownTimeoutFlagDescription = "(internal) Duration after which a stuck teardown" +
+		" of the package-operator ClusterPackage is finished by removing all finalizers," +
+		" once the Package Operator Deployment is gone."
 	remotePhasePackageImageFlagDescription = "Image pointing to a package operator remote phase package. " +
 		"This image is used with the HyperShift integration to spin up the remote-phase-manager for every HostedCluster"
 	registryHostOverrides = "List of registry host overrides to change during image pulling. e.g. quay.io=localhost:123,<original-host>=<new-host>"
 	packageHashModifier   = "An additional value used for the generation of a package's unpackedHash."
+	registryMirrors       = "List of registry mirrors to try before pulling package images from their source." +
+		" e.g. quay.io/package-operator=mirror.local/pko|mirror2.local/pko,<source-prefix>=<mirror-prefix>"
+	packagePlatform  = "Platform to select from multi-architecture package images, e.g. linux/arm64. Defaults to linux/amd64."
+	packageCacheSize = "Number of unpacked package images to cache by image digest, so Packages sharing an image only pull it once." +
+		" Set to 0 to disable caching."
+	operatorIdentityFlagDescription = "Identity of this Package Operator installation." +
+		" Only Package Operator objects labeled with package-operator.run/operator-identity=<identity> are reconciled," +
+		" unlabeled objects are reconciled by the installation without identity." +
+		" Namespaces the labels, annotations and field manager used on managed objects," +
+		" so multiple installations can run on the same cluster without adopting each other's objects."
+	archiveCompactionFlagDescription = "Strip phase objects of archived ObjectSets down to their identity to save etcd space." +
+		" Compacted ObjectSets templated from a package image are rehydrated from the same image and configuration" +
+		" when they leave the Archived state, e.g. to roll back to their revision."
+	observeOnlyFlagDescription = "Never create, patch or delete objects outside of the Package Operator API." +
+		" ObjectSets are reconciled as paused, reporting preflight violations and the changes they would apply in status." +
+		" Writes are sent as server-side dry-run instead, e.g. to evaluate adopting an existing cluster."
+	objectLabelsFlagDescription = "Labels added to every object applied by Package Operator," +
+		" unless set by the object itself. e.g. team=platform,<key>=<value>"
+	objectAnnotationsFlagDescription = "Annotations added to every object applied by Package Operator," +
+		" unless set by the object itself. e.g. sidecar.example.com/inject=false,<key>=<value>"
+	watchNamespacesFlagDescription = "Comma-separated list of namespaces to restrict all caches and watches to." +
+		" Cluster-scoped APIs like ClusterPackage and ClusterObjectSet are disabled," +
+		" so Package Operator only requires permissions within these namespaces. All namespaces are watched when empty."
+)
+
+// Sharding flags.
+const (
+	shardCountFlagDescription = "Number of manager replicas actively reconciling disjoint subsets of ObjectSets." +
+		" Each shard holds its own leader election lease."
+	shardIndexFlagDescription = "Index of the shard reconciled by this manager, between 0 and --shard-count - 1." +
+		" ObjectSets are assigned by the \"" + controllers.ShardLabel + "\" label or a hash of their namespace and name," +
+		" ObjectSetPhases are reconciled by the shard of their ObjectSet." +
+		" All other controllers only run on shard 0." +
+		" Defaults to PKO_SHARD_INDEX, which may also be set to the name of a StatefulSet Pod to use its ordinal."
+)
+
+// Leader election and shutdown flags.
+const (
+	leaderElectionNamespaceFlagDescription = "Namespace of the leader election lease. " +
+		"Defaults to the namespace the operator is running in."
+	leaseDurationFlagDescription   = "Duration that non-leader candidates will wait to force acquire leadership."
+	renewDeadlineFlagDescription   = "Duration that the acting leader will retry refreshing leadership before giving up."
+	retryPeriodFlagDescription     = "Duration the leader election clients should wait between tries of actions."
+	releaseOnCancelFlagDescription = "Release the leader election lease when the manager stops, " +
+		"so another replica can take over without waiting for the lease to expire."
+	gracefulShutdownTimeoutFlagDescription = "Duration given to controllers to stop before the manager exits."
+)
+
+// Throughput flags.
+const (
+	maxConcurrentReconcilesFlagDescription = "Number of objects each controller reconciles concurrently."
+	controllerConcurrencyFlagDescription   = "Number of concurrent reconciles per controller, overriding --max-concurrent-reconciles." +
+		" e.g. ObjectSet=10,Package=5,<Kind>=<number>"
+	kubeAPIQPSFlagDescription           = "Maximum queries per second to the Kubernetes API. Defaults to the controller-runtime default."
+	kubeAPIBurstFlagDescription         = "Maximum burst of queries to the Kubernetes API. Defaults to the controller-runtime default."
+	rateLimiterBaseDelayFlagDescription = "Delay before a controller retries a failed object," +
+		" doubled on every consecutive failure."
+	rateLimiterMaxDelayFlagDescription = "Maximum delay before a controller retries a failed object."
+	rateLimiterQPSFlagDescription      = "Number of objects each controller may process per second."
+	rateLimiterBurstFlagDescription    = "Number of objects each controller may process in a burst."
+)
+
+// Package unpack flags.
+const (
+	packageUnpackStrategyFlagDescription = "How package images are unpacked." +
+		" \"registry\" pulls images from within the manager," +
+		" \"pod\" runs Pods with the package image, so the node container runtime pulls images."
+	managerImageFlagDescription    = "Image of the package operator manager, used by unpack Pods."
+	unpackPodConfigFlagDescription = "YAML or JSON customizing unpack Pods, e.g. to run them on dedicated nodes." +
+		" Supports resources, nodeSelector, tolerations, runtimeClassName, securityContext and podSecurityContext."
+	localPackageDirFlagDescription = "Directory mounted into the manager containing packages referenced via .spec.source.localPath." +
+		" Local path sources are disabled when empty."
+	copyPackageFlagDescription = "(internal) copies package content from <source> to <destination> within unpack Pods"
+	dumpPackageFlagDescription = "(internal) prints package content at the given location as archive within unpack Pods"
 )
 
+// Webhook flags.
+const (
+	conversionWebhookFlagDescription = "Serve the conversion webhook translating stored objects between API versions."
+	webhookPortFlagDescription       = "The port the webhook server binds to."
+	webhookCertDirFlagDescription    = "The directory that contains the serving certificate (tls.crt) and key (tls.key)" +
+		" of the webhook server. Defaults to the controller-runtime default."
+)
+
+// Tracing flags.
+const (
+	tracingEndpointFlagDescription = "OTLP gRPC endpoint to export reconcile traces to, e.g. otel-collector:4317." +
+		" Tracing is disabled if empty."
+	tracingInsecureFlagDescription    = "Connect to the OTLP endpoint without transport security."
+	tracingSampleRatioFlagDescription = "Fraction of reconciles to trace, between 0 and 1."
+)
+
+const defaultTracingSampleRatio = 1.0
+
+const defaultWebhookPort = 9443
+
+// Package unpack strategies.
+const (
+	PackageUnpackStrategyRegistry = "registry"
+	PackageUnpackStrategyPod      = "pod"
+)
+
+const defaultPackageCacheSize = 64
+
+// Packages are unpacked concurrently by default, to not block on slow image pulls.
+const defaultControllerConcurrency = "Package=5,ClusterPackage=5"
+
+// Leader election and shutdown defaults, matching controller-runtime.
+const (
+	defaultLeaseDuration           = 15 * time.Second
+	defaultRenewDeadline           = 10 * time.Second
+	defaultRetryPeriod             = 2 * time.Second
+	defaultGracefulShutdownTimeout = 30 * time.Second
+)
+
+// The Package Operator teardown may take a while on large clusters,
+// finalizers are only removed forcefully as a last resort.
+const defaultSelfBootstrapTeardownTimeout = 30 * time.Minute
+
 type Options struct {
 	MetricsAddr             string
 	PPROFAddr               string
 	Namespace               string
 	EnableLeaderElection    bool
+	LeaderElectionNamespace string
+	LeaseDuration           time.Duration
+	RenewDeadline           time.Duration
+	RetryPeriod             time.Duration
+	ReleaseOnCancel         bool
+	GracefulShutdownTimeout time.Duration
+	MaxConcurrentReconciles int
+	ControllerConcurrency   string
+	KubeAPIQPS              float64
+	KubeAPIBurst            int
+	RateLimiter             controllers.RateLimiterConfig
+	Tracing                 tracing.Config
+	ConversionWebhook       bool
+	WebhookPort             int
+	WebhookCertDir          string
 	ProbeAddr               string
 	RemotePhasePackageImage string
 	RegistryHostOverrides   string
+	RegistryMirrors         string
+	PackagePlatform         string
 	PackageHashModifier     *int32
+	PackageCacheSize        int
+	PackageUnpackStrategy   string
+	UnpackPodConfig         string
+	ManagerImage            string
+	LocalPackageDir         string
+	ArchiveCompaction       bool
+	ObserveOnly             bool
+	ObjectLabels            string
+	ObjectAnnotations       string
+	WatchNamespaces         string
+	ShardCount              int
+	ShardIndex              int
+	OperatorIdentity        string
 
 	// sub commands
-	SelfBootstrap       string
-	SelfBootstrapConfig string
-	PrintVersion        bool
-	CopyTo              string
+	SelfBootstrap                string
+	SelfBootstrapConfig          string
+	SelfBootstrapTeardownTimeout time.Duration
+	PrintVersion                 bool
+	CopyTo                       string
+	CopyPackage                  string
+	DumpPackage                  string
 }
 
 func ProvideOptions() (opts Options, err error) {
@@ -61,18 +220,136 @@ func ProvideOptions() (opts Options, err error) {
 		&opts.EnableLeaderElection, "enable-leader-election",
 		false,
 		leaderElectionFlagDescription)
+	flag.StringVar(
+		&opts.LeaderElectionNamespace, "leader-election-namespace",
+		"",
+		leaderElectionNamespaceFlagDescription)
+	flag.DurationVar(
+		&opts.LeaseDuration, "leader-election-lease-duration",
+		defaultLeaseDuration,
+		leaseDurationFlagDescription)
+	flag.DurationVar(
+		&opts.RenewDeadline, "leader-election-renew-deadline",
+		defaultRenewDeadline,
+		renewDeadlineFlagDescription)
+	flag.DurationVar(
+		&opts.RetryPeriod, "leader-election-retry-period",
+		defaultRetryPeriod,
+		retryPeriodFlagDescription)
+	flag.BoolVar(
+		&opts.ReleaseOnCancel, "leader-election-release-on-cancel",
+		false,
+		releaseOnCancelFlagDescription)
+	flag.DurationVar(
+		&opts.GracefulShutdownTimeout, "graceful-shutdown-timeout",
+		defaultGracefulShutdownTimeout,
+		gracefulShutdownTimeoutFlagDescription)
+	flag.IntVar(
+		&opts.MaxConcurrentReconciles, "max-concurrent-reconciles",
+		1,
+		maxConcurrentReconcilesFlagDescription)
+	flag.StringVar(
+		&opts.ControllerConcurrency, "controller-concurrency",
+		defaultControllerConcurrency,
+		controllerConcurrencyFlagDescription)
+	flag.Float64Var(
+		&opts.KubeAPIQPS, "kube-api-qps",
+		0,
+		kubeAPIQPSFlagDescription)
+	flag.IntVar(
+		&opts.KubeAPIBurst, "kube-api-burst",
+		0,
+		kubeAPIBurstFlagDescription)
+	flag.DurationVar(
+		&opts.RateLimiter.BaseDelay, "rate-limiter-base-delay",
+		controllers.DefaultRateLimiterBaseDelay,
+		rateLimiterBaseDelayFlagDescription)
+	flag.DurationVar(
+		&opts.RateLimiter.MaxDelay, "rate-limiter-max-delay",
+		controllers.DefaultRateLimiterMaxDelay,
+		rateLimiterMaxDelayFlagDescription)
+	flag.Float64Var(
+		&opts.RateLimiter.QPS, "rate-limiter-qps",
+		controllers.DefaultRateLimiterQPS,
+		rateLimiterQPSFlagDescription)
+	flag.IntVar(
+		&opts.RateLimiter.Burst, "rate-limiter-burst",
+		controllers.DefaultRateLimiterBurst,
+		rateLimiterBurstFlagDescription)
+	flag.StringVar(
+		&opts.Tracing.Endpoint, "tracing-otlp-endpoint",
+		os.Getenv("PKO_TRACING_OTLP_ENDPOINT"),
+		tracingEndpointFlagDescription)
+	flag.BoolVar(
+		&opts.Tracing.Insecure, "tracing-otlp-insecure", false,
+		tracingInsecureFlagDescription)
+	flag.Float64Var(
+		&opts.Tracing.SampleRatio, "tracing-sample-ratio",
+		defaultTracingSampleRatio,
+		tracingSampleRatioFlagDescription)
+	flag.BoolVar(
+		&opts.ConversionWebhook, "enable-conversion-webhook", false,
+		conversionWebhookFlagDescription)
+	flag.IntVar(
+		&opts.WebhookPort, "webhook-port", defaultWebhookPort,
+		webhookPortFlagDescription)
+	flag.StringVar(
+		&opts.WebhookCertDir, "webhook-cert-dir", "",
+		webhookCertDirFlagDescription)
 	flag.StringVar(
 		&opts.ProbeAddr, "health-probe-bind-address", ":8081", probeAddrFlagDescription)
+	flag.BoolVar(
+		&opts.ArchiveCompaction, "archived-objectset-compaction", false,
+		archiveCompactionFlagDescription)
+	flag.BoolVar(
+		&opts.ObserveOnly, "observe-only", false,
+		observeOnlyFlagDescription)
+	flag.StringVar(
+		&opts.ObjectLabels, "object-labels",
+		os.Getenv("PKO_OBJECT_LABELS"),
+		objectLabelsFlagDescription)
+	flag.StringVar(
+		&opts.ObjectAnnotations, "object-annotations",
+		os.Getenv("PKO_OBJECT_ANNOTATIONS"),
+		objectAnnotationsFlagDescription)
+	flag.StringVar(
+		&opts.WatchNamespaces, "watch-namespaces",
+		os.Getenv("PKO_WATCH_NAMESPACES"),
+		watchNamespacesFlagDescription)
+	flag.IntVar(
+		&opts.ShardCount, "shard-count", 1,
+		shardCountFlagDescription)
+	shardIndex, err := shardIndexFromEnv()
+	if err != nil {
+		return Options{}, err
+	}
+	flag.IntVar(
+		&opts.ShardIndex, "shard-index", shardIndex,
+		shardIndexFlagDescription)
+	flag.StringVar(
+		&opts.OperatorIdentity, "operator-identity",
+		os.Getenv("PKO_OPERATOR_IDENTITY"),
+		operatorIdentityFlagDescription)
 	flag.BoolVar(
 		&opts.PrintVersion, "version", false,
 		versionFlagDescription)
 	flag.StringVar(
 		&opts.CopyTo, "copy-to", "",
 		copyToFlagDescription)
+	flag.StringVar(
+		&opts.CopyPackage, "copy-package", "",
+		copyPackageFlagDescription)
+	flag.StringVar(
+		&opts.DumpPackage, "dump-package", "",
+		dumpPackageFlagDescription)
 	flag.StringVar(
 		&opts.SelfBootstrap, "self-bootstrap", "", selfBootstrapFlagDescription)
 	flag.StringVar(
 		&opts.SelfBootstrapConfig, "self-bootstrap-config", os.Getenv("PKO_CONFIG"), "")
+	flag.DurationVar(
+		&opts.SelfBootstrapTeardownTimeout, "self-bootstrap-teardown-timeout",
+		defaultSelfBootstrapTeardownTimeout,
+		selfBootstrapTeardownTimeoutFlagDescription)
 	flag.StringVar(
 		&opts.RemotePhasePackageImage, "remote-phase-package-image",
 		os.Getenv("PKO_REMOTE_PHASE_PACKAGE_IMAGE"),
@@ -81,12 +358,47 @@ func ProvideOptions() (opts Options, err error) {
 		&opts.RegistryHostOverrides, "registry-host-overrides",
 		os.Getenv("PKO_REGISTRY_HOST_OVERRIDES"),
 		registryHostOverrides)
+	flag.StringVar(
+		&opts.RegistryMirrors, "registry-mirrors",
+		os.Getenv("PKO_REGISTRY_MIRRORS"),
+		registryMirrors)
+	flag.StringVar(
+		&opts.PackageUnpackStrategy, "package-unpack-strategy",
+		envOrDefault("PKO_PACKAGE_UNPACK_STRATEGY", PackageUnpackStrategyRegistry),
+		packageUnpackStrategyFlagDescription)
+	flag.StringVar(
+		&opts.UnpackPodConfig, "package-unpack-pod-config",
+		os.Getenv("PKO_PACKAGE_UNPACK_POD_CONFIG"),
+		unpackPodConfigFlagDescription)
+	flag.StringVar(
+		&opts.ManagerImage, "manager-image",
+		os.Getenv("PKO_IMAGE"),
+		managerImageFlagDescription)
+	flag.StringVar(
+		&opts.LocalPackageDir, "local-package-dir",
+		os.Getenv("PKO_LOCAL_PACKAGE_DIR"),
+		localPackageDirFlagDescription)
+	flag.StringVar(
+		&opts.PackagePlatform, "package-platform",
+		os.Getenv("PKO_PACKAGE_PLATFORM"),
+		packagePlatform)
 
 	packageHashModifierInt, err := envToInt("PKO_PACKAGE_HASH_MODIFIER")
 	if err != nil {
 		return Options{}, err
 	}
 
+	packageCacheSizeInt := defaultPackageCacheSize
+	if _, ok := os.LookupEnv("PKO_PACKAGE_CACHE_SIZE"); ok {
+		packageCacheSizeInt, err = envToInt("PKO_PACKAGE_CACHE_SIZE")
+		if err != nil {
+			return Options{}, err
+		}
+	}
+	flag.IntVar(
+		&opts.PackageCacheSize, "package-cache-size",
+		packageCacheSizeInt, packageCacheSize)
+
 	tmpPackageHashModifier := flag.Int(
 		"package-hash-modifier", packageHashModifierInt,
 		packageHashModifier)
@@ -97,9 +409,148 @@ func ProvideOptions() (opts Options, err error) {
 		opts.PackageHashModifier = &packageHashModifierInt32
 	}
 
+	if err := opts.Shard().Validate(); err != nil {
+		return Options{}, err
+	}
+	if err := opts.Identity().Validate(); err != nil {
+		return Options{}, err
+	}
+	if opts.IsNamespaceScoped() && len(opts.SelfBootstrap) > 0 {
+		return Options{}, fmt.Errorf(
+			"--watch-namespaces can not be used with --self-bootstrap, which requires cluster-scoped APIs")
+	}
+
 	return opts, nil
 }
 
+// RunsSubCommand returns true, if the binary runs one of its sub commands instead of the manager.
+func (opts Options) RunsSubCommand() bool {
+	return opts.PrintVersion || len(opts.CopyTo) > 0 ||
+		len(opts.CopyPackage) > 0 || len(opts.DumpPackage) > 0
+}
+
+// Shard returns the partition of ObjectSets reconciled by this manager.
+func (opts Options) Shard() controllers.Shard {
+	return controllers.Shard{Index: opts.ShardIndex, Count: opts.ShardCount}
+}
+
+// Identity returns the identity of this Package Operator installation.
+func (opts Options) Identity() controllers.OperatorIdentity {
+	return controllers.OperatorIdentity{Name: opts.OperatorIdentity}
+}
+
+// RunsUnshardedControllers returns true, if controllers not partitioned by shard run on this manager.
+// They only run on the first shard,
+// so objects are never reconciled by multiple replicas at once.
+func (opts Options) RunsUnshardedControllers() bool {
+	return opts.ShardIndex == 0
+}
+
+// IsNamespaceScoped returns true, if the manager is restricted to the namespaces given by --watch-namespaces.
+func (opts Options) IsNamespaceScoped() bool {
+	return len(parseNamespaces(opts.WatchNamespaces)) > 0
+}
+
+// CacheNamespaces returns the namespaces cached by a namespace-scoped manager,
+// including the namespace the operator is deployed into.
+func (opts Options) CacheNamespaces() []string {
+	namespaces := parseNamespaces(opts.WatchNamespaces)
+	if len(namespaces) == 0 || len(opts.Namespace) == 0 {
+		return namespaces
+	}
+	for _, ns := range namespaces {
+		if ns == opts.Namespace {
+			return namespaces
+		}
+	}
+	return append(namespaces, opts.Namespace)
+}
+
+// Parses a comma-separated list of namespaces, skipping empty entries.
+func parseNamespaces(flag string) []string {
+	var namespaces []string
+	for _, ns := range strings.Split(flag, ",") {
+		if ns = strings.TrimSpace(ns); len(ns) > 0 {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// Kinds reconciled by Package Operator controllers.
+var controllerKinds = []string{
+	"ObjectSet", "ClusterObjectSet",
+	"ObjectSetPhase", "ClusterObjectSetPhase",
+	"ObjectDeployment", "ClusterObjectDeployment",
+	"Package", "ClusterPackage",
+	"ObjectTemplate", "ClusterObjectTemplate",
+	"PackageRepository", "PackageFleet",
+}
+
+// Returns the number of concurrent reconciles by GroupKind of all controllers,
+// applying per-Kind overrides in the format "Kind=number,Kind=number" on top of the default.
+func groupKindConcurrency(defaultConcurrency int, overrides string) (map[string]int, error) {
+	concurrency := map[string]int{}
+	for _, kind := range controllerKinds {
+		concurrency[kind] = defaultConcurrency
+	}
+
+	for _, override := range strings.Split(overrides, ",") {
+		if len(strings.TrimSpace(override)) == 0 {
+			continue
+		}
+		kind, value, ok := strings.Cut(override, "=")
+		kind = strings.TrimSpace(kind)
+		if !ok {
+			return nil, fmt.Errorf("invalid controller concurrency %q, expected <Kind>=<number>", override)
+		}
+		if _, known := concurrency[kind]; !known {
+			return nil, fmt.Errorf("invalid controller concurrency %q, unknown Kind %q", override, kind)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid controller concurrency %q, expected a positive number", override)
+		}
+		concurrency[kind] = n
+	}
+
+	groupKindConcurrency := map[string]int{}
+	for kind, n := range concurrency {
+		groupKindConcurrency[corev1alpha1.GroupVersion.WithKind(kind).GroupKind().String()] = n
+	}
+	return groupKindConcurrency, nil
+}
+
+// Returns the value of the given environment variable or the default, if unset.
+func envOrDefault(env, def string) string {
+	if v, ok := os.LookupEnv(env); ok && len(v) > 0 {
+		return v
+	}
+	return def
+}
+
+// Parses PKO_SHARD_INDEX, either a shard index or the name of a StatefulSet Pod,
+// so every Pod of a StatefulSet reconciles the shard matching its ordinal.
+// Returns 0 in case the environment variable is unset.
+func shardIndexFromEnv() (int, error) {
+	v := os.Getenv("PKO_SHARD_INDEX")
+	if len(v) == 0 {
+		return 0, nil
+	}
+	if i, err := strconv.Atoi(v); err == nil {
+		return i, nil
+	}
+
+	// StatefulSet Pods are named <statefulset>-<ordinal>.
+	i := strings.LastIndex(v, "-")
+	ordinal, err := strconv.Atoi(v[i+1:])
+	if i < 0 || err != nil {
+		return 0, fmt.Errorf(
+			"unable to parse environment variable 'PKO_SHARD_INDEX' as integer or StatefulSet Pod name: %q", v)
+	}
+	return ordinal, nil
+}
+
 // Parses an environment variable string value to integer value.
 // Returns 0 in case the environment variable is unset.
 func envToInt(env string) (int, error) {
@@ -4,6 +4,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/tracing"
 )
 
 func TestProvideOptions(t *testing.T) {
@@ -11,7 +15,80 @@ func TestProvideOptions(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.Equal(t, Options{
-		MetricsAddr: ":8080",
-		ProbeAddr:   ":8081",
+		MetricsAddr:      ":8080",
+		ProbeAddr:        ":8081",
+		PackageCacheSize: defaultPackageCacheSize,
+
+		PackageUnpackStrategy: PackageUnpackStrategyRegistry,
+
+		LeaseDuration:           defaultLeaseDuration,
+		RenewDeadline:           defaultRenewDeadline,
+		RetryPeriod:             defaultRetryPeriod,
+		GracefulShutdownTimeout: defaultGracefulShutdownTimeout,
+
+		MaxConcurrentReconciles: 1,
+		ControllerConcurrency:   defaultControllerConcurrency,
+		RateLimiter: controllers.RateLimiterConfig{
+			BaseDelay: controllers.DefaultRateLimiterBaseDelay,
+			MaxDelay:  controllers.DefaultRateLimiterMaxDelay,
+			QPS:       controllers.DefaultRateLimiterQPS,
+			Burst:     controllers.DefaultRateLimiterBurst,
+		},
+		Tracing: tracing.Config{
+			SampleRatio: defaultTracingSampleRatio,
+		},
+		WebhookPort: defaultWebhookPort,
+		ShardCount:  1,
+
+		SelfBootstrapTeardownTimeout: defaultSelfBootstrapTeardownTimeout,
 	}, opts)
 }
+
+func TestOptions_CacheNamespaces(t *testing.T) {
+	assert.False(t, Options{WatchNamespaces: " , "}.IsNamespaceScoped())
+	assert.Empty(t, Options{Namespace: "pko"}.CacheNamespaces())
+
+	opts := Options{WatchNamespaces: "team-a, team-b,", Namespace: "pko"}
+	assert.True(t, opts.IsNamespaceScoped())
+	assert.Equal(t, []string{"team-a", "team-b", "pko"}, opts.CacheNamespaces())
+
+	opts.Namespace = "team-a"
+	assert.Equal(t, []string{"team-a", "team-b"}, opts.CacheNamespaces())
+}
+
+func Test_groupKindConcurrency(t *testing.T) {
+	concurrency, err := groupKindConcurrency(2, "Package=5, ObjectSet = 10,")
+	require.NoError(t, err)
+	assert.Len(t, concurrency, len(controllerKinds))
+	assert.Equal(t, 5, concurrency["Package.package-operator.run"])
+	assert.Equal(t, 10, concurrency["ObjectSet.package-operator.run"])
+	assert.Equal(t, 2, concurrency["ClusterObjectSet.package-operator.run"])
+
+	for _, invalid := range []string{"Package", "Banana=1", "Package=0", "Package=x"} {
+		_, err := groupKindConcurrency(1, invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func Test_shardIndexFromEnv(t *testing.T) {
+	tests := []struct {
+		env      string
+		expected int
+		err      bool
+	}{
+		{env: "", expected: 0},
+		{env: "2", expected: 2},
+		{env: "package-operator-manager-3", expected: 3},
+		{env: "package-operator-manager", err: true},
+	}
+	for _, test := range tests {
+		t.Setenv("PKO_SHARD_INDEX", test.env)
+		index, err := shardIndexFromEnv()
+		if test.err {
+			assert.Error(t, err, test.env)
+			continue
+		}
+		require.NoError(t, err, test.env)
+		assert.Equal(t, test.expected, index, test.env)
+	}
+}
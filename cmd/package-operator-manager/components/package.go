@@ -1,13 +1,21 @@
 package components
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/go-logr/logr"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"package-operator.run/package-operator/internal/controllers/packages"
+	"package-operator.run/package-operator/internal/dynamiccache"
 	"package-operator.run/package-operator/internal/metrics"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
 	"package-operator.run/package-operator/internal/packages/packageimport"
 )
 
@@ -22,9 +30,95 @@ type (
 	}
 )
 
-func ProvideRegistry(log logr.Logger, opts Options) *packageimport.Registry {
+func ProvideRegistry(
+	log logr.Logger, opts Options, uncachedClient UncachedClient,
+) (*packageimport.Registry, error) {
+	registryOpts := []packageimport.RegistryOption{
+		packageimport.WithMirrorSource{
+			MirrorSource: packageOperatorConfigMirrors{client: uncachedClient},
+		},
+	}
+
+	mirrors, err := packageimport.ParseRegistryMirrors(opts.RegistryMirrors)
+	if err != nil {
+		return nil, fmt.Errorf("parsing registry mirrors: %w", err)
+	}
+	if len(mirrors) > 0 {
+		log.WithName("Registry").Info("registry mirrors active", "mirrors", opts.RegistryMirrors)
+		registryOpts = append(registryOpts, packageimport.WithMirrors(mirrors))
+	}
+
+	if len(opts.PackagePlatform) > 0 {
+		platform, err := packageimport.ParsePlatform(opts.PackagePlatform)
+		if err != nil {
+			return nil, fmt.Errorf("parsing package platform: %w", err)
+		}
+		registryOpts = append(registryOpts, packageimport.WithPlatform{Platform: platform})
+	}
+
+	if opts.PackageCacheSize > 0 {
+		registryOpts = append(registryOpts, packageimport.WithCacheSize(opts.PackageCacheSize))
+	}
+
 	return packageimport.NewRegistry(
-		prepareRegistryHostOverrides(log, opts.RegistryHostOverrides))
+		prepareRegistryHostOverrides(log, opts.RegistryHostOverrides),
+		registryOpts...), nil
+}
+
+// PackageImagePuller provides the content of package images to Package controllers.
+type PackageImagePuller interface {
+	Pull(ctx context.Context, image string) (packagecontent.Files, error)
+}
+
+func ProvidePackageImagePuller(
+	log logr.Logger, opts Options,
+	registry *packageimport.Registry,
+	uncachedClient UncachedClient,
+	restConfig *rest.Config,
+) (PackageImagePuller, error) {
+	switch opts.PackageUnpackStrategy {
+	case PackageUnpackStrategyRegistry:
+		return registry, nil
+
+	case PackageUnpackStrategyPod:
+		if len(opts.ManagerImage) == 0 || len(opts.Namespace) == 0 {
+			return nil, fmt.Errorf(
+				"package unpack strategy %q requires --manager-image and --namespace", opts.PackageUnpackStrategy)
+		}
+		var pullerOpts []packageimport.PodPullerOption
+		if len(opts.UnpackPodConfig) > 0 {
+			unpackPodConfig, err := packageimport.ParseUnpackPodConfig(opts.UnpackPodConfig)
+			if err != nil {
+				return nil, err
+			}
+			pullerOpts = append(pullerOpts, packageimport.WithUnpackPodConfig(unpackPodConfig))
+		}
+		clientset, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("creating kubernetes clientset: %w", err)
+		}
+		log.WithName("Registry").Info("unpacking package images via Pods", "namespace", opts.Namespace)
+		return packageimport.NewPodPuller(
+			uncachedClient, clientset.CoreV1(), opts.Namespace, opts.ManagerImage, pullerOpts...), nil
+	}
+	return nil, fmt.Errorf("unknown package unpack strategy %q", opts.PackageUnpackStrategy)
+}
+
+// PackageSourceLoader provides the content of packages not sourced from images to Package controllers.
+type PackageSourceLoader interface {
+	LoadConfigMap(ctx context.Context, key client.ObjectKey) (packagecontent.Files, error)
+	LoadSecret(ctx context.Context, key client.ObjectKey) (packagecontent.Files, error)
+	LoadLocalPath(ctx context.Context, path string) (packagecontent.Files, error)
+}
+
+// Package sources are read uncached, to not cache all ConfigMaps and Secrets of the cluster.
+func ProvidePackageSourceLoader(
+	log logr.Logger, opts Options, uncachedClient UncachedClient,
+) PackageSourceLoader {
+	if len(opts.LocalPackageDir) > 0 {
+		log.WithName("SourceLoader").Info("local package directory active", "dir", opts.LocalPackageDir)
+	}
+	return packageimport.NewSourceLoader(uncachedClient, opts.LocalPackageDir)
 }
 
 func prepareRegistryHostOverrides(log logr.Logger, flag string) map[string]string {
@@ -47,32 +141,49 @@ func prepareRegistryHostOverrides(log logr.Logger, flag string) map[string]strin
 
 func ProvidePackageController(
 	mgr ctrl.Manager, log logr.Logger,
+	uncachedClient UncachedClient,
+	dc *dynamiccache.Cache,
+	discoveryClient discovery.DiscoveryInterface,
 	registry *packageimport.Registry,
+	imagePuller PackageImagePuller,
+	sourceLoader PackageSourceLoader,
 	recorder *metrics.Recorder,
 	opts Options,
 ) PackageController {
-	return PackageController{
-		packages.NewPackageController(
-			mgr.GetClient(),
-			log.WithName("controllers").WithName("Package"),
-			mgr.GetScheme(),
-			registry, recorder, opts.PackageHashModifier,
-		),
+	c := packages.NewPackageController(
+		mgr.GetClient(), uncachedClient,
+		log.WithName("controllers").WithName("Package"),
+		dc, mgr.GetScheme(), mgr.GetRESTMapper(), discoveryClient,
+		imagePuller, sourceLoader, recorder, opts.PackageHashModifier,
+		opts.Identity(),
+	)
+	c.SetImageDigestResolver(registry)
+	if opts.IsNamespaceScoped() {
+		// PackageRepositories are cluster-scoped.
+		c.DisablePackageRepositories()
 	}
+	return PackageController{c}
 }
 
 func ProvideClusterPackageController(
 	mgr ctrl.Manager, log logr.Logger,
+	uncachedClient UncachedClient,
+	dc *dynamiccache.Cache,
+	discoveryClient discovery.DiscoveryInterface,
 	registry *packageimport.Registry,
+	imagePuller PackageImagePuller,
+	sourceLoader PackageSourceLoader,
 	recorder *metrics.Recorder,
 	opts Options,
 ) ClusterPackageController {
-	return ClusterPackageController{
-		packages.NewClusterPackageController(
-			mgr.GetClient(),
-			log.WithName("controllers").WithName("ClusterPackage"),
-			mgr.GetScheme(),
-			registry, recorder, opts.PackageHashModifier,
-		),
-	}
+	c := packages.NewClusterPackageController(
+		mgr.GetClient(), uncachedClient,
+		log.WithName("controllers").WithName("ClusterPackage"),
+		dc, mgr.GetScheme(), mgr.GetRESTMapper(), discoveryClient,
+		imagePuller, sourceLoader, recorder, opts.PackageHashModifier,
+		opts.Identity(),
+	)
+	c.SetImageDigestResolver(registry)
+	c.SetInventoryNamespace(opts.Namespace)
+	return ClusterPackageController{c}
 }
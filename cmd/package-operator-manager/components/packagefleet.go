@@ -0,0 +1,23 @@
+package components
+
+import (
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"package-operator.run/package-operator/internal/controllers/packagefleets"
+)
+
+// Type alias for dependency injector.
+type PackageFleetController struct{ rateLimitedController }
+
+func ProvidePackageFleetController(
+	mgr ctrl.Manager, log logr.Logger, opts Options,
+) PackageFleetController {
+	c := packagefleets.NewPackageFleetController(
+		mgr.GetClient(),
+		log.WithName("controllers").WithName("PackageFleet"),
+		mgr.GetScheme(),
+	)
+	c.SetOperatorIdentity(opts.Identity())
+	return PackageFleetController{c}
+}
@@ -0,0 +1,107 @@
+package components
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/packages/packageimport"
+)
+
+// Environment variables setting the default of flags configurable via the PackageOperatorConfig.
+var flagEnvironmentVariables = map[string]string{
+	"package-cache-size": "PKO_PACKAGE_CACHE_SIZE",
+}
+
+// DecorateOptionsWithPackageOperatorConfig applies settings of the PackageOperatorConfig
+// that are only read when the manager starts.
+// Flags and environment variables set explicitly take precedence.
+func DecorateOptionsWithPackageOperatorConfig(opts Options, scheme *runtime.Scheme) (Options, error) {
+	if opts.RunsSubCommand() {
+		return opts, nil
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return Options{}, err
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return Options{}, fmt.Errorf("unable to set up client: %w", err)
+	}
+	config, err := controllers.GetPackageOperatorConfig(context.Background(), c)
+	if err != nil {
+		return Options{}, err
+	}
+	return applyPackageOperatorConfig(opts, config.Spec, isFlagSet), nil
+}
+
+func applyPackageOperatorConfig(
+	opts Options, spec corev1alpha1.PackageOperatorConfigSpec, isSet func(flag string) bool,
+) Options {
+	if spec.Concurrency != nil {
+		if spec.Concurrency.MaxConcurrentReconciles != nil && !isSet("max-concurrent-reconciles") {
+			opts.MaxConcurrentReconciles = int(*spec.Concurrency.MaxConcurrentReconciles)
+		}
+		if len(spec.Concurrency.Controllers) > 0 && !isSet("controller-concurrency") {
+			// Later entries override earlier ones, keeping defaults for all other kinds.
+			overrides := []string{opts.ControllerConcurrency}
+			for _, c := range spec.Concurrency.Controllers {
+				overrides = append(overrides, fmt.Sprintf("%s=%d", c.Kind, c.MaxConcurrentReconciles))
+			}
+			opts.ControllerConcurrency = strings.Join(overrides, ",")
+		}
+	}
+	if spec.PackageCacheSize != nil && !isSet("package-cache-size") {
+		opts.PackageCacheSize = int(*spec.PackageCacheSize)
+	}
+	if spec.Metrics != nil && len(spec.Metrics.BindAddress) > 0 && !isSet("metrics-addr") {
+		opts.MetricsAddr = spec.Metrics.BindAddress
+	}
+	return opts
+}
+
+// Returns true, if the given flag was set on the command line or via its environment variable.
+func isFlagSet(name string) bool {
+	if env, ok := flagEnvironmentVariables[name]; ok {
+		if _, ok := os.LookupEnv(env); ok {
+			return true
+		}
+	}
+	var set bool
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// Looks up the registry mirrors of the PackageOperatorConfig on every pull,
+// so they can be changed without restarting the manager.
+type packageOperatorConfigMirrors struct {
+	client client.Reader
+}
+
+func (m packageOperatorConfigMirrors) RegistryMirrors(ctx context.Context) ([]packageimport.RegistryMirror, error) {
+	config, err := controllers.GetPackageOperatorConfig(ctx, m.client)
+	if err != nil {
+		return nil, err
+	}
+	mirrors := make([]packageimport.RegistryMirror, len(config.Spec.RegistryMirrors))
+	for i, mirror := range config.Spec.RegistryMirrors {
+		mirrors[i].Source = strings.TrimSuffix(mirror.Source, "/")
+		for _, m := range mirror.Mirrors {
+			mirrors[i].Mirrors = append(mirrors[i].Mirrors, strings.TrimSuffix(m, "/"))
+		}
+	}
+	return mirrors, nil
+}
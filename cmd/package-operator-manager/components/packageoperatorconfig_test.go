@@ -0,0 +1,84 @@
+package components
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/packages/packageimport"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func Test_applyPackageOperatorConfig(t *testing.T) {
+	defaults := Options{
+		MetricsAddr:             ":8080",
+		MaxConcurrentReconciles: 1,
+		ControllerConcurrency:   defaultControllerConcurrency,
+		PackageCacheSize:        defaultPackageCacheSize,
+	}
+	spec := corev1alpha1.PackageOperatorConfigSpec{
+		Concurrency: &corev1alpha1.ConcurrencyConfig{
+			MaxConcurrentReconciles: pointer.Int32(2),
+			Controllers: []corev1alpha1.ControllerConcurrency{
+				{Kind: "ObjectSet", MaxConcurrentReconciles: 10},
+			},
+		},
+		PackageCacheSize: pointer.Int32(0),
+		Metrics:          &corev1alpha1.MetricsConfig{BindAddress: "0"},
+	}
+
+	opts := applyPackageOperatorConfig(defaults, spec, func(string) bool { return false })
+	assert.Equal(t, Options{
+		MetricsAddr:             "0",
+		MaxConcurrentReconciles: 2,
+		ControllerConcurrency:   defaultControllerConcurrency + ",ObjectSet=10",
+		PackageCacheSize:        0,
+	}, opts)
+
+	concurrency, err := groupKindConcurrency(opts.MaxConcurrentReconciles, opts.ControllerConcurrency)
+	require.NoError(t, err)
+	assert.Equal(t, 10, concurrency["ObjectSet.package-operator.run"])
+	assert.Equal(t, 5, concurrency["Package.package-operator.run"])
+	assert.Equal(t, 2, concurrency["ClusterObjectSet.package-operator.run"])
+
+	// explicitly set flags take precedence.
+	opts = applyPackageOperatorConfig(defaults, spec, func(string) bool { return true })
+	assert.Equal(t, defaults, opts)
+
+	// empty config keeps all options.
+	opts = applyPackageOperatorConfig(defaults, corev1alpha1.PackageOperatorConfigSpec{},
+		func(string) bool { return false })
+	assert.Equal(t, defaults, opts)
+}
+
+func TestOptions_RunsSubCommand(t *testing.T) {
+	assert.False(t, Options{}.RunsSubCommand())
+	assert.True(t, Options{PrintVersion: true}.RunsSubCommand())
+	assert.True(t, Options{CopyTo: "/bin/pko"}.RunsSubCommand())
+}
+
+func Test_packageOperatorConfigMirrors(t *testing.T) {
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, client.ObjectKey{Name: "cluster"},
+			mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			config := args.Get(2).(*corev1alpha1.PackageOperatorConfig)
+			config.Spec.RegistryMirrors = []corev1alpha1.RegistryMirror{
+				{Source: "quay.io/pko/", Mirrors: []string{"a.local/pko/", "b.local/pko"}},
+			}
+		}).
+		Return(nil)
+
+	mirrors, err := packageOperatorConfigMirrors{client: c}.RegistryMirrors(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []packageimport.RegistryMirror{
+		{Source: "quay.io/pko", Mirrors: []string{"a.local/pko", "b.local/pko"}},
+	}, mirrors)
+}
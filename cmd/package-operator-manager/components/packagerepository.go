@@ -0,0 +1,25 @@
+package components
+
+import (
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"package-operator.run/package-operator/internal/controllers/packagerepositories"
+	"package-operator.run/package-operator/internal/packages/packageimport"
+)
+
+// Type alias for dependency injector.
+type PackageRepositoryController struct{ rateLimitedController }
+
+func ProvidePackageRepositoryController(
+	mgr ctrl.Manager, log logr.Logger,
+	registry *packageimport.Registry,
+) PackageRepositoryController {
+	return PackageRepositoryController{
+		packagerepositories.NewPackageRepositoryController(
+			mgr.GetClient(),
+			log.WithName("controllers").WithName("PackageRepository"),
+			mgr.GetScheme(), registry,
+		),
+	}
+}
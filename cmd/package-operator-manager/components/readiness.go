@@ -0,0 +1,147 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/dynamiccache"
+)
+
+// Path on the metrics server reporting readiness details.
+const readinessDetailsPath = "/readyz/details"
+
+// Readiness reports the sync status of every controller as readyz check
+// and serves details about controllers and the dynamic cache
+// to debug performance issues in large installations.
+type Readiness struct {
+	checks       readyzCheckAdder
+	informers    informerForKindGetter
+	dynamicCache informerOwnersLister
+
+	controllersMux sync.RWMutex
+	controllers    []string
+}
+
+type readyzCheckAdder interface {
+	AddReadyzCheck(name string, check healthz.Checker) error
+}
+
+type informerForKindGetter interface {
+	GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (cache.Informer, error)
+}
+
+type informerOwnersLister interface {
+	InformerOwners() map[schema.GroupVersionKind]int
+}
+
+func newReadiness(
+	checks readyzCheckAdder,
+	informers informerForKindGetter,
+	dynamicCache informerOwnersLister,
+) *Readiness {
+	return &Readiness{
+		checks:       checks,
+		informers:    informers,
+		dynamicCache: dynamicCache,
+	}
+}
+
+func ProvideReadiness(
+	mgr ctrl.Manager, dc *dynamiccache.Cache,
+) (*Readiness, error) {
+	r := newReadiness(mgr, mgr.GetCache(), dc)
+	if err := mgr.AddMetricsExtraHandler(readinessDetailsPath, r); err != nil {
+		return nil, fmt.Errorf("unable to set up readiness details: %w", err)
+	}
+	return r, nil
+}
+
+// AddController adds a readyz check for the controller reconciling the given Kind,
+// which fails until the informer of this Kind has synced.
+func (r *Readiness) AddController(kind string) error {
+	r.controllersMux.Lock()
+	defer r.controllersMux.Unlock()
+
+	if err := r.checks.AddReadyzCheck("controller-"+kind, func(req *http.Request) error {
+		if !r.controllerSynced(req.Context(), kind) {
+			return fmt.Errorf("informer for %s not synced", kind)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("unable to set up ready check for %s: %w", kind, err)
+	}
+	r.controllers = append(r.controllers, kind)
+	return nil
+}
+
+func (r *Readiness) controllerSynced(ctx context.Context, kind string) bool {
+	// Report the current state instead of waiting for the informer to sync.
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	informer, err := r.informers.GetInformerForKind(
+		ctx, corev1alpha1.GroupVersion.WithKind(kind))
+	return err == nil && informer.HasSynced()
+}
+
+type readinessDetails struct {
+	Controllers  []controllerReadiness `json:"controllers"`
+	DynamicCache dynamicCacheReadiness `json:"dynamicCache"`
+}
+
+type controllerReadiness struct {
+	Name   string `json:"name"`
+	Synced bool   `json:"synced"`
+}
+
+type dynamicCacheReadiness struct {
+	// Total number of informers.
+	Informers int `json:"informers"`
+	// Number of owners watching by GroupVersionKind.
+	Owners map[string]int `json:"owners"`
+}
+
+func (r *Readiness) details(ctx context.Context) readinessDetails {
+	r.controllersMux.RLock()
+	defer r.controllersMux.RUnlock()
+
+	details := readinessDetails{
+		Controllers: make([]controllerReadiness, len(r.controllers)),
+		DynamicCache: dynamicCacheReadiness{
+			Owners: map[string]int{},
+		},
+	}
+	for i, kind := range r.controllers {
+		details.Controllers[i] = controllerReadiness{
+			Name:   kind,
+			Synced: r.controllerSynced(ctx, kind),
+		}
+	}
+	sort.Slice(details.Controllers, func(i, j int) bool {
+		return details.Controllers[i].Name < details.Controllers[j].Name
+	})
+
+	for gvk, owners := range r.dynamicCache.InformerOwners() {
+		details.DynamicCache.Owners[gvk.String()] = owners
+	}
+	details.DynamicCache.Informers = len(details.DynamicCache.Owners)
+	return details
+}
+
+// ServeHTTP implements http.Handler.
+func (r *Readiness) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(r.details(req.Context())); err != nil {
+		http.Error(resp, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,114 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestReadiness(t *testing.T) {
+	checks := &readyzCheckAdderMock{}
+	informers := &informerForKindGetterMock{}
+	dc := &informerOwnersListerMock{}
+	r := newReadiness(checks, informers, dc)
+
+	checks.
+		On("AddReadyzCheck", mock.Anything, mock.Anything).
+		Return(nil)
+	informers.
+		On("GetInformerForKind", mock.Anything, corev1alpha1.GroupVersion.WithKind("ObjectSet")).
+		Return(&informerMock{synced: true}, nil)
+	informers.
+		On("GetInformerForKind", mock.Anything, corev1alpha1.GroupVersion.WithKind("Package")).
+		Return(&informerMock{synced: false}, nil)
+	dc.
+		On("InformerOwners").
+		Return(map[schema.GroupVersionKind]int{
+			{Version: "v1", Kind: "ConfigMap"}: 3,
+		})
+
+	require.NoError(t, r.AddController("Package"))
+	require.NoError(t, r.AddController("ObjectSet"))
+
+	// Readyz checks.
+	checks.AssertCalled(t, "AddReadyzCheck", "controller-Package", mock.Anything)
+	checks.AssertCalled(t, "AddReadyzCheck", "controller-ObjectSet", mock.Anything)
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	for _, call := range checks.Calls {
+		check := call.Arguments.Get(1).(healthz.Checker)
+		switch call.Arguments.String(0) {
+		case "controller-Package":
+			assert.EqualError(t, check(req), "informer for Package not synced")
+		case "controller-ObjectSet":
+			assert.NoError(t, check(req))
+		}
+	}
+
+	// Details.
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, readinessDetailsPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var details readinessDetails
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &details))
+	assert.Equal(t, readinessDetails{
+		Controllers: []controllerReadiness{
+			{Name: "ObjectSet", Synced: true},
+			{Name: "Package", Synced: false},
+		},
+		DynamicCache: dynamicCacheReadiness{
+			Informers: 1,
+			Owners:    map[string]int{"/v1, Kind=ConfigMap": 3},
+		},
+	}, details)
+}
+
+type readyzCheckAdderMock struct {
+	mock.Mock
+}
+
+func (m *readyzCheckAdderMock) AddReadyzCheck(name string, check healthz.Checker) error {
+	args := m.Called(name, check)
+	return args.Error(0)
+}
+
+type informerForKindGetterMock struct {
+	mock.Mock
+}
+
+func (m *informerForKindGetterMock) GetInformerForKind(
+	ctx context.Context, gvk schema.GroupVersionKind,
+) (cache.Informer, error) {
+	args := m.Called(ctx, gvk)
+	informer, _ := args.Get(0).(cache.Informer)
+	return informer, args.Error(1)
+}
+
+type informerOwnersListerMock struct {
+	mock.Mock
+}
+
+func (m *informerOwnersListerMock) InformerOwners() map[schema.GroupVersionKind]int {
+	args := m.Called()
+	return args.Get(0).(map[schema.GroupVersionKind]int)
+}
+
+type informerMock struct {
+	cache.Informer
+	synced bool
+}
+
+func (m *informerMock) HasSynced() bool {
+	return m.synced
+}
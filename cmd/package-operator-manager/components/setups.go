@@ -3,23 +3,38 @@ package components
 import (
 	"fmt"
 
-	"package-operator.run/package-operator/internal/environment"
-
 	"go.uber.org/dig"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/environment"
 )
 
 type controllerSetup struct {
 	name       string
-	controller controller
+	controller rateLimitedController
+	// Reconciles cluster-scoped objects, disabled in namespace-scoped mode.
+	clusterScoped bool
 }
 
-func setupAll(mgr ctrl.Manager, controllers []controllerSetup) error {
-	for _, c := range controllers {
+func setupAll(
+	mgr ctrl.Manager, rateLimiter controllers.RateLimiterConfig,
+	readiness *Readiness, setups []controllerSetup,
+) error {
+	for _, c := range setups {
+		if rateLimiter != (controllers.RateLimiterConfig{}) {
+			c.controller.SetRateLimiter(rateLimiter.NewRateLimiter())
+		}
 		if err := c.controller.SetupWithManager(mgr); err != nil {
 			return fmt.Errorf(
 				"unable to create controller for %s: %w", c.name, err)
 		}
+		if readiness != nil {
+			if err := readiness.AddController(c.name); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -29,8 +44,14 @@ type controller interface {
 	SetupWithManager(mgr ctrl.Manager) error
 }
 
-type controllerAndEnvSinker interface {
+// interface implemented by controllers with a configurable workqueue rate limiter.
+type rateLimitedController interface {
 	controller
+	SetRateLimiter(rl ratelimiter.RateLimiter)
+}
+
+type controllerAndEnvSinker interface {
+	rateLimitedController
 	environment.Sinker
 }
 
@@ -52,6 +73,12 @@ type AllControllers struct {
 
 	ObjectTemplate        ObjectTemplateController
 	ClusterObjectTemplate ClusterObjectTemplateController
+
+	PackageRepository PackageRepositoryController
+	PackageFleet      PackageFleetController
+
+	Options   Options
+	Readiness *Readiness
 }
 
 func (ac AllControllers) List() []interface{} {
@@ -61,52 +88,92 @@ func (ac AllControllers) List() []interface{} {
 		ac.ObjectDeployment, ac.ClusterObjectDeployment,
 		ac.Package, ac.ClusterPackage,
 		ac.ObjectTemplate, ac.ClusterObjectTemplate,
+		ac.PackageRepository, ac.PackageFleet,
 	}
 }
 
 func (ac AllControllers) SetupWithManager(mgr ctrl.Manager) error {
-	return setupAll(mgr, []controllerSetup{
+	setups := []controllerSetup{
 		{
 			name:       "ObjectSet",
 			controller: ac.ObjectSet,
 		},
 		{
-			name:       "ClusterObjectSet",
-			controller: ac.ClusterObjectSet,
+			name:          "ClusterObjectSet",
+			controller:    ac.ClusterObjectSet,
+			clusterScoped: true,
 		},
 		{
 			name:       "ObjectSetPhase",
 			controller: ac.ObjectSetPhase,
 		},
 		{
-			name:       "ClusterObjectSetPhase",
-			controller: ac.ClusterObjectSetPhase,
+			name:          "ClusterObjectSetPhase",
+			controller:    ac.ClusterObjectSetPhase,
+			clusterScoped: true,
 		},
+	}
+	if ac.Options.RunsUnshardedControllers() {
+		setups = append(setups, ac.unshardedSetups()...)
+	}
+	if ac.Options.IsNamespaceScoped() {
+		setups = namespaceScopedSetups(setups)
+	}
+	return setupAll(mgr, ac.Options.RateLimiter, ac.Readiness, setups)
+}
+
+// Controllers not partitioned by shard.
+func (ac AllControllers) unshardedSetups() []controllerSetup {
+	return []controllerSetup{
 		{
 			name:       "ObjectDeployment",
 			controller: ac.ObjectDeployment,
 		},
 		{
-			name:       "ClusterObjectDeployment",
-			controller: ac.ClusterObjectDeployment,
+			name:          "ClusterObjectDeployment",
+			controller:    ac.ClusterObjectDeployment,
+			clusterScoped: true,
 		},
 		{
 			name:       "Package",
 			controller: ac.Package,
 		},
 		{
-			name:       "ClusterPackage",
-			controller: ac.ClusterPackage,
+			name:          "ClusterPackage",
+			controller:    ac.ClusterPackage,
+			clusterScoped: true,
 		},
 		{
 			name:       "ObjectTemplate",
 			controller: ac.ObjectTemplate,
 		},
 		{
-			name:       "ClusterObjectTemplate",
-			controller: ac.ClusterObjectTemplate,
+			name:          "ClusterObjectTemplate",
+			controller:    ac.ClusterObjectTemplate,
+			clusterScoped: true,
 		},
-	})
+		{
+			name:          "PackageRepository",
+			controller:    ac.PackageRepository,
+			clusterScoped: true,
+		},
+		{
+			name:          "PackageFleet",
+			controller:    ac.PackageFleet,
+			clusterScoped: true,
+		},
+	}
+}
+
+// Drops controllers of cluster-scoped objects.
+func namespaceScopedSetups(setups []controllerSetup) []controllerSetup {
+	var namespaced []controllerSetup
+	for _, s := range setups {
+		if !s.clusterScoped {
+			namespaced = append(namespaced, s)
+		}
+	}
+	return namespaced
 }
 
 // DI container to get only the controllers needed for self-bootstrap.
@@ -116,6 +183,9 @@ type BootstrapControllers struct {
 	ClusterPackage          ClusterPackageController
 	ClusterObjectDeployment ClusterObjectDeploymentController
 	ClusterObjectSet        ClusterObjectSetController
+
+	Options   Options
+	Readiness *Readiness
 }
 
 func (bc BootstrapControllers) List() []interface{} {
@@ -125,7 +195,7 @@ func (bc BootstrapControllers) List() []interface{} {
 }
 
 func (bc BootstrapControllers) SetupWithManager(mgr ctrl.Manager) error {
-	return setupAll(mgr, []controllerSetup{
+	return setupAll(mgr, bc.Options.RateLimiter, bc.Readiness, []controllerSetup{
 		{
 			name:       "ClusterObjectSet",
 			controller: bc.ClusterObjectSet,
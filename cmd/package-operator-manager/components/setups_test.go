@@ -8,8 +8,10 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
 )
 
 var errTest = errors.New("test")
@@ -21,19 +23,38 @@ func Test_setupAll(t *testing.T) {
 		cm.On("SetupWithManager", mock.Anything).
 			Return(nil)
 
-		err := setupAll(nil, []controllerSetup{
+		err := setupAll(nil, controllers.RateLimiterConfig{}, nil, []controllerSetup{
 			{name: "test", controller: cm},
 		})
 		require.NoError(t, err)
 	})
 
+	t.Run("rate limiter", func(t *testing.T) {
+		cm := &controllerMock{}
+
+		cm.On("SetRateLimiter", mock.Anything)
+		cm.On("SetupWithManager", mock.Anything).
+			Return(nil)
+
+		err := setupAll(nil, controllers.RateLimiterConfig{
+			BaseDelay: controllers.DefaultRateLimiterBaseDelay,
+			MaxDelay:  controllers.DefaultRateLimiterMaxDelay,
+			QPS:       controllers.DefaultRateLimiterQPS,
+			Burst:     controllers.DefaultRateLimiterBurst,
+		}, nil, []controllerSetup{
+			{name: "test", controller: cm},
+		})
+		require.NoError(t, err)
+		cm.AssertExpectations(t)
+	})
+
 	t.Run("error", func(t *testing.T) {
 		cm := &controllerMock{}
 
 		cm.On("SetupWithManager", mock.Anything).
 			Return(errTest)
 
-		err := setupAll(nil, []controllerSetup{
+		err := setupAll(nil, controllers.RateLimiterConfig{}, nil, []controllerSetup{
 			{name: "test", controller: cm},
 		})
 		require.EqualError(
@@ -51,16 +72,18 @@ func TestAllControllers(t *testing.T) {
 		return m
 	}
 	var (
-		os     = newMock()
-		cos    = newMock()
-		osp    = newMock()
-		cosp   = newMock()
-		od     = newMock()
-		cod    = newMock()
-		pkg    = newMock()
-		cpkg   = newMock()
-		otmpl  = newMock()
-		cotmpl = newMock()
+		os      = newMock()
+		cos     = newMock()
+		osp     = newMock()
+		cosp    = newMock()
+		od      = newMock()
+		cod     = newMock()
+		pkg     = newMock()
+		cpkg    = newMock()
+		otmpl   = newMock()
+		cotmpl  = newMock()
+		pkgrepo = newMock()
+		fleet   = newMock()
 	)
 	all := AllControllers{
 		ObjectSet:        ObjectSetController{os},
@@ -77,6 +100,80 @@ func TestAllControllers(t *testing.T) {
 
 		ObjectTemplate:        ObjectTemplateController{otmpl},
 		ClusterObjectTemplate: ClusterObjectTemplateController{cotmpl},
+
+		PackageRepository: PackageRepositoryController{pkgrepo},
+		PackageFleet:      PackageFleetController{fleet},
+	}
+	err := all.SetupWithManager(nil)
+	require.NoError(t, err)
+
+	for _, m := range mocks {
+		m.AssertExpectations(t)
+	}
+	assert.Len(t, all.List(), 12)
+}
+
+func TestAllControllers_shard(t *testing.T) {
+	var mocks []*controllerMock
+	sharded := func() *controllerMock {
+		m := &controllerMock{}
+		m.On("SetupWithManager", mock.Anything).
+			Return(nil)
+		mocks = append(mocks, m)
+		return m
+	}
+	all := AllControllers{
+		ObjectSet:             ObjectSetController{sharded()},
+		ClusterObjectSet:      ClusterObjectSetController{sharded()},
+		ObjectSetPhase:        ObjectSetPhaseController{sharded()},
+		ClusterObjectSetPhase: ClusterObjectSetPhaseController{sharded()},
+
+		// Not partitioned by shard, mocks panic when set up.
+		ObjectDeployment:        ObjectDeploymentController{&controllerMock{}},
+		ClusterObjectDeployment: ClusterObjectDeploymentController{&controllerMock{}},
+		Package:                 PackageController{&controllerMock{}},
+		ClusterPackage:          ClusterPackageController{&controllerMock{}},
+		ObjectTemplate:          ObjectTemplateController{&controllerMock{}},
+		ClusterObjectTemplate:   ClusterObjectTemplateController{&controllerMock{}},
+		PackageRepository:       PackageRepositoryController{&controllerMock{}},
+		PackageFleet:            PackageFleetController{&controllerMock{}},
+
+		Options: Options{ShardIndex: 1, ShardCount: 2},
+	}
+	err := all.SetupWithManager(nil)
+	require.NoError(t, err)
+
+	for _, m := range mocks {
+		m.AssertExpectations(t)
+	}
+}
+
+func TestAllControllers_namespaceScoped(t *testing.T) {
+	var mocks []*controllerMock
+	namespaced := func() *controllerMock {
+		m := &controllerMock{}
+		m.On("SetupWithManager", mock.Anything).
+			Return(nil)
+		mocks = append(mocks, m)
+		return m
+	}
+	all := AllControllers{
+		ObjectSet:        ObjectSetController{namespaced()},
+		ObjectSetPhase:   ObjectSetPhaseController{namespaced()},
+		ObjectDeployment: ObjectDeploymentController{namespaced()},
+		Package:          PackageController{namespaced()},
+		ObjectTemplate:   ObjectTemplateController{namespaced()},
+
+		// Cluster-scoped, mocks panic when set up.
+		ClusterObjectSet:        ClusterObjectSetController{&controllerMock{}},
+		ClusterObjectSetPhase:   ClusterObjectSetPhaseController{&controllerMock{}},
+		ClusterObjectDeployment: ClusterObjectDeploymentController{&controllerMock{}},
+		ClusterPackage:          ClusterPackageController{&controllerMock{}},
+		ClusterObjectTemplate:   ClusterObjectTemplateController{&controllerMock{}},
+		PackageRepository:       PackageRepositoryController{&controllerMock{}},
+		PackageFleet:            PackageFleetController{&controllerMock{}},
+
+		Options: Options{WatchNamespaces: "team-a"},
 	}
 	err := all.SetupWithManager(nil)
 	require.NoError(t, err)
@@ -84,7 +181,6 @@ func TestAllControllers(t *testing.T) {
 	for _, m := range mocks {
 		m.AssertExpectations(t)
 	}
-	assert.Len(t, all.List(), 10)
 }
 
 func TestBootstrapControllers(t *testing.T) {
@@ -124,6 +220,10 @@ func (m *controllerMock) SetupWithManager(mgr ctrl.Manager) error {
 	return args.Error(0)
 }
 
+func (m *controllerMock) SetRateLimiter(rl ratelimiter.RateLimiter) {
+	m.Called(rl)
+}
+
 func (m *controllerMock) SetEnvironment(env *manifestsv1alpha1.PackageEnvironment) {
 	m.Called(env)
 }
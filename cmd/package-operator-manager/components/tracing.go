@@ -0,0 +1,47 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"package-operator.run/package-operator/internal/tracing"
+)
+
+// Time given to export buffered spans when the manager stops.
+const tracingShutdownTimeout = 5 * time.Second
+
+// Flushes and stops span export when the manager stops.
+type tracingShutdown struct {
+	shutdown func(context.Context) error
+}
+
+func (t *tracingShutdown) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	return t.shutdown(shutdownCtx)
+}
+
+// Spans are exported from all replicas, not only the leader.
+func (t *tracingShutdown) NeedLeaderElection() bool {
+	return false
+}
+
+func registerTracing(mgr ctrl.Manager, cfg tracing.Config) error {
+	if len(cfg.Endpoint) == 0 {
+		return nil
+	}
+
+	shutdown, err := tracing.Setup(context.Background(), cfg)
+	if err != nil {
+		return fmt.Errorf("unable to set up tracing: %w", err)
+	}
+	if err := mgr.Add(&tracingShutdown{shutdown: shutdown}); err != nil {
+		return fmt.Errorf("unable to register tracing: %w", err)
+	}
+	return nil
+}
@@ -55,6 +55,20 @@ func run(opts components.Options) error {
 		return nil
 	}
 
+	if len(opts.CopyPackage) > 0 {
+		if err := runCopyPackage(context.Background(), opts.CopyPackage); err != nil {
+			return fmt.Errorf("unable to run copy-package: %w", err)
+		}
+		return nil
+	}
+
+	if len(opts.DumpPackage) > 0 {
+		if err := runDumpPackage(context.Background(), opts.DumpPackage); err != nil {
+			return fmt.Errorf("unable to run dump-package: %w", err)
+		}
+		return nil
+	}
+
 	ctx := ctrl.SetupSignalHandler()
 	if len(opts.SelfBootstrap) > 0 {
 		if err := di.Provide(bootstrap.NewBootstrapper); err != nil {
@@ -187,6 +201,16 @@ func (pkoMgr *packageOperatorManager) probeHyperShiftIntegration(
 ) error {
 	log := logr.FromContextOrDiscard(ctx)
 
+	if !pkoMgr.allControllers.Options.RunsUnshardedControllers() {
+		// HostedClusters are reconciled by the first shard.
+		return nil
+	}
+	if pkoMgr.allControllers.Options.IsNamespaceScoped() {
+		// HostedClusters are out of reach of namespace-scoped managers,
+		// as the integration deploys into namespaces of every HostedCluster.
+		return nil
+	}
+
 	// Probe for HyperShift API
 	_, err := pkoMgr.mgr.GetRESTMapper().
 		RESTMapping(hostedClusterGVK.GroupKind(), hostedClusterGVK.Version)
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"package-operator.run/package-operator/internal/packages/packageimport"
+)
+
+// Copies package content from the package image into a shared volume of the unpack Pod.
+// Expects <source dir>:<destination dir>.
+func runCopyPackage(ctx context.Context, srcDst string) error {
+	src, dst, ok := strings.Cut(srcDst, ":")
+	if !ok {
+		return fmt.Errorf("expected <source>:<destination>, got %q", srcDst)
+	}
+
+	files, err := packageimport.Folder(ctx, src)
+	if err != nil {
+		return fmt.Errorf("loading package content: %w", err)
+	}
+
+	for path, data := range files {
+		target := filepath.Join(dst, path)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("creating directory: %w", err)
+		}
+		if err := os.WriteFile(target, data, 0o644); err != nil { //nolint:gosec
+			return fmt.Errorf("writing file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Prints package content as archive, so the manager can read it from the unpack Pod logs.
+func runDumpPackage(ctx context.Context, src string) error {
+	files, err := packageimport.Folder(ctx, src)
+	if err != nil {
+		return fmt.Errorf("loading package content: %w", err)
+	}
+
+	// Fail instead of writing an archive that gets truncated by log rotation.
+	archive := &bytes.Buffer{}
+	if err := packageimport.WriteArchive(archive, files); err != nil {
+		return err
+	}
+	if archive.Len() > packageimport.MaxUnpackArchiveSize {
+		return fmt.Errorf("%w: archive of %d bytes exceeds %d bytes",
+			packageimport.ErrPackageTooLarge, archive.Len(), packageimport.MaxUnpackArchiveSize)
+	}
+	_, err = archive.WriteTo(os.Stdout)
+	return err
+}
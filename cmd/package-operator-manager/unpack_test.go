@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCopyPackage(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "manifest.yaml"), []byte("a"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "sub", "obj.yaml"), []byte("b"), 0o600))
+
+	require.NoError(t, runCopyPackage(context.Background(), src+":"+dst))
+
+	data, err := os.ReadFile(filepath.Join(dst, "sub", "obj.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "b", string(data))
+
+	assert.Error(t, runCopyPackage(context.Background(), src))
+}
@@ -16,7 +16,7 @@ import (
 )
 
 const (
-	logName = "validating webhooks"
+	logName = "admission webhooks"
 )
 
 var (
@@ -76,6 +76,16 @@ func main() {
 		),
 	},
 	)
+	wbh.Register("/mutate-object-set", &webhook.Admission{
+		Handler: webhooks.NewObjectSetDefaultingWebhookHandler(
+			log.Log.WithName(logName).WithName("ObjectSets"),
+		),
+	})
+	wbh.Register("/mutate-cluster-object-set", &webhook.Admission{
+		Handler: webhooks.NewClusterObjectSetDefaultingWebhookHandler(
+			log.Log.WithName(logName).WithName("ClusterObjectSets"),
+		),
+	})
 	wbh.Register("/validate-object-set-phase", &webhook.Admission{
 		Handler: webhooks.NewObjectSetPhaseWebhookHandler(
 			log.Log.WithName(logName).WithName("ObjectSetPhases"),
@@ -96,6 +106,19 @@ func main() {
 		),
 	})
 
+	wbh.Register("/validate-package", &webhook.Admission{
+		Handler: webhooks.NewPackageImageDigestWebhookHandler(
+			log.Log.WithName(logName).WithName("Packages"),
+			scheme, mgr.GetClient(),
+		),
+	})
+	wbh.Register("/validate-cluster-package", &webhook.Admission{
+		Handler: webhooks.NewClusterPackageImageDigestWebhookHandler(
+			log.Log.WithName(logName).WithName("ClusterPackages"),
+			scheme, mgr.GetClient(),
+		),
+	})
+
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
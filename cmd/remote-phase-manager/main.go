@@ -14,8 +14,10 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -27,6 +29,7 @@ import (
 	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/controllers/objectsetphases"
 	"package-operator.run/package-operator/internal/dynamiccache"
+	"package-operator.run/package-operator/internal/kubeconfig"
 	"package-operator.run/package-operator/internal/metrics"
 )
 
@@ -38,7 +41,11 @@ type opts struct {
 	probeAddr                   string
 	class                       string
 	targetClusterKubeconfigFile string
+	targetClusterQPS            float64
+	targetClusterBurst          int
+	heartbeatTimeout            time.Duration
 	printVersion                bool
+	operatorIdentity            controllers.OperatorIdentity
 }
 
 const (
@@ -51,6 +58,15 @@ const (
 	versionFlagDescription       = "print version information and exit."
 	classFlagDescription         = "class of the ObjectSetPhase to work on."
 	targetClusterFlagDescription = "Filepath for a kubeconfig for the target cluster."
+	targetClusterQPSDescription  = "Maximum queries per second to the target cluster, " +
+		"shared by all clients of this manager. Defaults to the client-go default."
+	targetClusterBurstDescription = "Maximum burst of queries to the target cluster, " +
+		"shared by all clients of this manager. Defaults to the client-go default."
+	heartbeatTimeoutDescription = "Time after which ObjectSetPhases are reported as RemoteUnreachable, " +
+		"when this manager can't reach the target cluster."
+	operatorIdentityDescription = "Identity of the Package Operator installation this manager belongs to. " +
+		"Only ObjectSetPhases labeled with this identity are reconciled. " +
+		"Namespaces the labels, annotations and field manager used on managed objects."
 )
 
 func main() {
@@ -61,8 +77,14 @@ func main() {
 	flag.BoolVar(&opts.enableLeaderElection, "enable-leader-election", false, leaderElectionFlagDescription)
 	flag.StringVar(&opts.probeAddr, "health-probe-bind-address", ":8081", probeAddrFlagDescription)
 	flag.StringVar(&opts.targetClusterKubeconfigFile, "target-cluster-kubeconfig-file", "", targetClusterFlagDescription)
+	flag.Float64Var(&opts.targetClusterQPS, "target-cluster-qps", 0, targetClusterQPSDescription)
+	flag.IntVar(&opts.targetClusterBurst, "target-cluster-burst", 0, targetClusterBurstDescription)
 	flag.StringVar(&opts.class, "class", "hosted-cluster", classFlagDescription)
+	flag.DurationVar(&opts.heartbeatTimeout, "heartbeat-timeout",
+		controllers.DefaultRemotePhaseHeartbeatTimeout, heartbeatTimeoutDescription)
 	flag.BoolVar(&opts.printVersion, "version", false, versionFlagDescription)
+	flag.StringVar(&opts.operatorIdentity.Name, "operator-identity",
+		os.Getenv("PKO_OPERATOR_IDENTITY"), operatorIdentityDescription)
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -94,6 +116,10 @@ func main() {
 }
 
 func run(log logr.Logger, scheme *runtime.Scheme, opts opts) error {
+	if err := opts.operatorIdentity.Validate(); err != nil {
+		return err
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Namespace:                  opts.namespace,
 		Scheme:                     scheme,
@@ -151,10 +177,16 @@ func run(log logr.Logger, scheme *runtime.Scheme, opts opts) error {
 		}
 	}
 
-	targetCfg, err := clientcmd.BuildConfigFromFlags("", opts.targetClusterKubeconfigFile)
+	// Follow credential rotations of the target cluster kubeconfig without restarting.
+	targetKubeconfig, err := kubeconfig.NewWatcher(opts.targetClusterKubeconfigFile)
 	if err != nil {
 		return fmt.Errorf("reading target cluster kubeconfig: %w", err)
 	}
+	if err := mgr.Add(targetKubeconfig); err != nil {
+		return fmt.Errorf("unable to add target cluster kubeconfig watcher: %w", err)
+	}
+	targetCfg := targetKubeconfig.Config()
+	setTargetClusterRateLimits(targetCfg, opts.targetClusterQPS, opts.targetClusterBurst)
 	targetMapper, err := apiutil.NewDynamicRESTMapper(targetCfg, apiutil.WithLazyDiscovery)
 	if err != nil {
 		return fmt.Errorf("creating target cluster rest mapper: %w", err)
@@ -178,10 +210,12 @@ func run(log logr.Logger, scheme *runtime.Scheme, opts opts) error {
 			// so we prevent our caches from exploding!
 			schema.GroupVersionKind{}: dynamiccache.Selector{
 				Label: labels.SelectorFromSet(labels.Set{
-					controllers.DynamicCacheLabel: "True",
+					opts.operatorIdentity.DynamicCacheLabel(): "True",
 				}),
 			},
-		})
+		},
+		controllers.DynamicCacheIndexers(opts.operatorIdentity),
+	)
 
 	// Create a client that does not cache resources cluster-wide.
 	uncachedClient, err := client.New(
@@ -192,11 +226,36 @@ func run(log logr.Logger, scheme *runtime.Scheme, opts opts) error {
 
 	managementClusterClient := mgr.GetClient()
 
+	// Heartbeat Leases are namespaced and live next to the ObjectSetPhases.
+	if len(opts.namespace) > 0 {
+		targetDiscovery, err := discovery.NewDiscoveryClientForConfig(targetCfg)
+		if err != nil {
+			return fmt.Errorf("creating target cluster discovery client: %w", err)
+		}
+		identity, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("getting hostname: %w", err)
+		}
+		if err := mgr.Add(controllers.NewRemotePhaseHeartbeat(
+			uncachedClient, opts.namespace, opts.class, identity,
+			func(context.Context) error {
+				// /version is readable anonymously, API groups require authentication.
+				_, err := targetDiscovery.ServerGroups()
+				return err
+			},
+			controllers.WithHeartbeatTimeout(opts.heartbeatTimeout),
+			controllers.WithHeartbeatOperatorIdentity(opts.operatorIdentity),
+		)); err != nil {
+			return fmt.Errorf("unable to add heartbeat: %w", err)
+		}
+	}
+
 	if err = objectsetphases.NewMultiClusterObjectSetPhaseController(
 		ctrl.Log.WithName("controllers").WithName("ObjectSetPhase"),
 		mgr.GetScheme(), dc, uncachedClient,
 		opts.class, managementClusterClient,
 		targetClient, targetMapper,
+		controllers.WithOperatorIdentity{OperatorIdentity: opts.operatorIdentity},
 	).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create controller for ObjectSetPhase: %w", err)
 	}
@@ -208,6 +267,7 @@ func run(log logr.Logger, scheme *runtime.Scheme, opts opts) error {
 			mgr.GetScheme(), dc, uncachedClient,
 			opts.class, managementClusterClient,
 			targetClient, targetMapper,
+			controllers.WithOperatorIdentity{OperatorIdentity: opts.operatorIdentity},
 		).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to create controller for ClusterObjectSetPhase: %w", err)
 		}
@@ -219,3 +279,20 @@ func run(log logr.Logger, scheme *runtime.Scheme, opts opts) error {
 	}
 	return nil
 }
+
+// Limits requests to the target cluster, so large rollouts don't overwhelm small hosted control planes.
+// All clients created from the config share one rate limiter,
+// because client-go would otherwise create a separate limiter per client.
+func setTargetClusterRateLimits(cfg *rest.Config, qps float64, burst int) {
+	if qps <= 0 && burst <= 0 {
+		return
+	}
+	cfg.QPS, cfg.Burst = rest.DefaultQPS, rest.DefaultBurst
+	if qps > 0 {
+		cfg.QPS = float32(qps)
+	}
+	if burst > 0 {
+		cfg.Burst = burst
+	}
+	cfg.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(cfg.QPS, cfg.Burst)
+}
@@ -35,6 +35,25 @@ spec:
           format: int32
         registryHostOverrides:
           type: string
+        registryMirrors:
+          description: Registry mirrors to try before pulling package images from their source.
+            e.g. quay.io/package-operator=mirror.local/pko|mirror2.local/pko
+          type: string
+        packagePlatform:
+          description: Platform to select from multi-architecture package images, e.g. linux/arm64.
+          type: string
+        packageCacheSize:
+          description: Number of unpacked package images to cache by image digest.
+            Set to 0 to disable caching.
+          type: integer
+          format: int32
+          minimum: 0
+        replicas:
+          description: Number of package operator manager replicas.
+            Only the leader is active, the other replicas take over when it fails.
+          type: integer
+          format: int32
+          minimum: 1
         namespace:
           description: Namespace to install package operator into.
           type: string
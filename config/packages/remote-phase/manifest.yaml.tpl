@@ -16,7 +16,35 @@ spec:
       kind:
         group: apps
         kind: Deployment
-  config: {}
+  config:
+    openAPIV3Schema:
+      properties:
+        class:
+          description: ObjectSetPhase class handled by this remote phase manager.
+            ObjectSets deploy phases to the target cluster by setting this class on the phase.
+          type: string
+        targetClusterKubeconfigSecret:
+          description: Secret in the Package namespace holding the kubeconfig of the target cluster.
+            Defaults to the kubeconfig provided by HyperShift for HostedClusters.
+          properties:
+            name:
+              description: Name of the Secret.
+              type: string
+            key:
+              description: Key of the kubeconfig within the Secret.
+              type: string
+          type: object
+        targetClusterQPS:
+          description: Maximum queries per second to the API server of the target cluster.
+            Shared by all requests of the remote phase manager to the target cluster.
+          type: number
+          minimum: 0
+        targetClusterBurst:
+          description: Maximum burst of queries to the API server of the target cluster.
+          type: integer
+          format: int32
+          minimum: 0
+      type: object
   phases:
   - name: rbac
   - name: deploy
@@ -32,3 +60,27 @@ test:
           name: test
           namespace: test-ns
     name: namespace-scope
+  - context:
+      package:
+        metadata:
+          annotations: null
+          labels: null
+          name: test
+          namespace: test-ns
+      config:
+        targetClusterQPS: 2.5
+        targetClusterBurst: 5
+    name: rate-limits
+  - context:
+      package:
+        metadata:
+          annotations: null
+          labels: null
+          name: test
+          namespace: test-ns
+      config:
+        class: edge
+        targetClusterKubeconfigSecret:
+          name: edge-kubeconfig
+          key: value
+    name: external-cluster
@@ -0,0 +1,70 @@
+# Sharded alternative to config/static-deployment/deployment.yaml.tpl.
+# Apply instead of the Deployment to run one manager per shard,
+# each actively reconciling a disjoint subset of ObjectSets and ObjectSetPhases.
+#
+# Every Pod derives its --shard-index from its StatefulSet ordinal via PKO_SHARD_INDEX.
+# Keep --shard-count equal to spec.replicas.
+# Shard 0 also runs all controllers not partitioned by shard, like the Package controllers.
+# Objects can be pinned to a shard with the "package-operator.run/shard" label.
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: package-operator-manager
+  namespace: package-operator-system
+  labels:
+    app.kubernetes.io/name: package-operator
+spec:
+  replicas: 3
+  serviceName: package-operator-manager
+  # Shards are independent, no need to wait for lower ordinals.
+  podManagementPolicy: Parallel
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: package-operator
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: package-operator
+    spec:
+      serviceAccountName: package-operator
+      containers:
+      - name: manager
+        image: quay.io/package-operator/package-operator-manager:latest
+        args:
+        - --enable-leader-election
+        - --shard-count=3
+        env:
+        - name: PKO_SHARD_INDEX
+          valueFrom:
+            fieldRef:
+              fieldPath: metadata.name
+        - name: PKO_NAMESPACE
+          valueFrom:
+            fieldRef:
+              fieldPath: metadata.namespace
+        - name: PKO_IMAGE
+          value: "quay.io/package-operator/package-operator-manager:latest"
+        - name: PKO_REMOTE_PHASE_PACKAGE_IMAGE
+          value: "quay.io/package-operator/remote-phase-package:latest"
+        ports:
+        - name: metrics
+          containerPort: 8080
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: 8081
+          initialDelaySeconds: 15
+          periodSeconds: 20
+        readinessProbe:
+          httpGet:
+            path: /readyz
+            port: 8081
+          initialDelaySeconds: 5
+          periodSeconds: 10
+        resources:
+          limits:
+            cpu: 100m
+            memory: 400Mi
+          requests:
+            cpu: 100m
+            memory: 300Mi
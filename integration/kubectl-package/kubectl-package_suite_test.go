@@ -133,9 +133,14 @@ func loadPackageImages(ctx context.Context, infos ...packageImageBuildInfo) erro
 			return fmt.Errorf("importing package from directory: %w", err)
 		}
 
+		image, err := packageexport.Image(files)
+		if err != nil {
+			return fmt.Errorf("building package image: %w", err)
+		}
+
 		tags := []string{info.Ref}
 
-		if err := packageexport.PushedImage(ctx, tags, files, crane.Insecure); err != nil {
+		if err := packageexport.PushedImage(ctx, tags, image, crane.Insecure); err != nil {
 			return fmt.Errorf("pushing package image: %w", err)
 		}
 	}
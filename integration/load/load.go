@@ -0,0 +1,159 @@
+// Package load creates many ObjectDeployments on a cluster running Package Operator
+// and reports how fast and at what cost Package Operator reconciles them.
+package load
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Service exposing the metrics endpoint of the package-operator-manager.
+const metricsServiceName = "package-operator-metrics"
+
+// Config of a load test run, read from the environment.
+type Config struct {
+	// Number of ObjectDeployments to create.
+	// PKO_LOAD_PACKAGES, the load test is skipped if unset.
+	Packages int
+	// Number of ConfigMaps in every ObjectDeployment.
+	// PKO_LOAD_OBJECTS, defaults to 10.
+	Objects int
+	// Number of ObjectDeployments created in parallel.
+	// PKO_LOAD_PARALLELISM, defaults to 20.
+	Parallelism int
+	// Time to wait for all ObjectDeployments to become Available.
+	// PKO_LOAD_TIMEOUT, defaults to 30m.
+	Timeout time.Duration
+	// Path to write the JSON report to.
+	// PKO_LOAD_REPORT, the report is only logged if unset.
+	ReportPath string
+	// Keeps the created objects on the cluster after the run.
+	// PKO_LOAD_KEEP.
+	Keep bool
+}
+
+// ConfigFromEnv reads the load test configuration from the environment.
+// Returns false, if PKO_LOAD_PACKAGES is not set.
+func ConfigFromEnv() (Config, bool, error) {
+	cfg := Config{
+		Objects:     10,
+		Parallelism: 20,
+		Timeout:     30 * time.Minute,
+		ReportPath:  os.Getenv("PKO_LOAD_REPORT"),
+		Keep:        len(os.Getenv("PKO_LOAD_KEEP")) > 0,
+	}
+
+	packages, ok := os.LookupEnv("PKO_LOAD_PACKAGES")
+	if !ok {
+		return cfg, false, nil
+	}
+	var err error
+	if cfg.Packages, err = strconv.Atoi(packages); err != nil {
+		return cfg, false, fmt.Errorf("parsing PKO_LOAD_PACKAGES: %w", err)
+	}
+	if v, ok := os.LookupEnv("PKO_LOAD_OBJECTS"); ok {
+		if cfg.Objects, err = strconv.Atoi(v); err != nil {
+			return cfg, false, fmt.Errorf("parsing PKO_LOAD_OBJECTS: %w", err)
+		}
+	}
+	if v, ok := os.LookupEnv("PKO_LOAD_PARALLELISM"); ok {
+		if cfg.Parallelism, err = strconv.Atoi(v); err != nil {
+			return cfg, false, fmt.Errorf("parsing PKO_LOAD_PARALLELISM: %w", err)
+		}
+	}
+	if v, ok := os.LookupEnv("PKO_LOAD_TIMEOUT"); ok {
+		if cfg.Timeout, err = time.ParseDuration(v); err != nil {
+			return cfg, false, fmt.Errorf("parsing PKO_LOAD_TIMEOUT: %w", err)
+		}
+	}
+	return cfg, true, nil
+}
+
+// Report summarizes a load test run.
+type Report struct {
+	Packages int `json:"packages"`
+	Objects  int `json:"objectsPerPackage"`
+	// Time from creating the first ObjectDeployment until all ObjectDeployments are Available.
+	Duration time.Duration `json:"duration"`
+	// ObjectDeployments becoming Available per second.
+	PackagesPerSecond float64 `json:"packagesPerSecond"`
+	// Requests Package Operator sent to the kube-apiserver during the run.
+	APIRequests float64 `json:"apiRequests"`
+	// Resident memory of Package Operator at the end of the run.
+	ResidentMemoryBytes float64 `json:"residentMemoryBytes"`
+	// Heap of Package Operator at the end of the run.
+	HeapAllocBytes float64 `json:"heapAllocBytes"`
+}
+
+func (r Report) String() string {
+	return fmt.Sprintf(
+		"%d packages with %d objects each available after %s (%.2f packages/s), "+
+			"%.0f API requests, %.0f MiB resident memory, %.0f MiB heap",
+		r.Packages, r.Objects, r.Duration.Round(time.Millisecond), r.PackagesPerSecond,
+		r.APIRequests, r.ResidentMemoryBytes/(1<<20), r.HeapAllocBytes/(1<<20))
+}
+
+// WriteFile writes the report as JSON to the given path.
+func (r Report) WriteFile(path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+// Metrics of Package Operator relevant to the report.
+type Metrics struct {
+	APIRequests         float64
+	ResidentMemoryBytes float64
+	HeapAllocBytes      float64
+}
+
+// ScrapeMetrics reads the metrics of Package Operator through the kube-apiserver service proxy.
+func ScrapeMetrics(ctx context.Context, cs kubernetes.Interface, namespace string) (Metrics, error) {
+	raw, err := cs.CoreV1().Services(namespace).
+		ProxyGet("http", metricsServiceName, "metrics", "/metrics", nil).
+		DoRaw(ctx)
+	if err != nil {
+		return Metrics{}, fmt.Errorf("scraping metrics: %w", err)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(raw))
+	if err != nil {
+		return Metrics{}, fmt.Errorf("parsing metrics: %w", err)
+	}
+	return Metrics{
+		APIRequests:         sum(families["rest_client_requests_total"]),
+		ResidentMemoryBytes: sum(families["process_resident_memory_bytes"]),
+		HeapAllocBytes:      sum(families["go_memstats_heap_alloc_bytes"]),
+	}, nil
+}
+
+// Sums all samples of a counter or gauge.
+func sum(family *dto.MetricFamily) float64 {
+	if family == nil {
+		return 0
+	}
+	var total float64
+	for _, m := range family.GetMetric() {
+		switch {
+		case m.GetCounter() != nil:
+			total += m.GetCounter().GetValue()
+		case m.GetGauge() != nil:
+			total += m.GetGauge().GetValue()
+		case m.GetUntyped() != nil:
+			total += m.GetUntyped().GetValue()
+		}
+	}
+	return total
+}
@@ -0,0 +1,207 @@
+package load
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pkoapis "package-operator.run/apis"
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+const loadLabel = "load.package-operator.run/run"
+
+// Runs against the cluster KUBECONFIG points at, e.g. the kind cluster of the dev environment:
+// PKO_LOAD_PACKAGES=1000 PKO_LOAD_OBJECTS=20 ./mage test:load
+//
+// ObjectDeployments drive the same ObjectSet machinery as Packages,
+// without requiring a package image for every package and object count.
+func TestLoad(t *testing.T) {
+	cfg, ok, err := ConfigFromEnv()
+	require.NoError(t, err)
+	if !ok {
+		t.Skip("PKO_LOAD_PACKAGES not set")
+	}
+
+	ctx := context.Background()
+	c, cs := newClients(t)
+	pkoNamespace := findPackageOperatorNamespace(ctx, t, c)
+
+	runID := time.Now().UTC().Format("20060102150405")
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "pko-load-" + runID,
+		Labels: map[string]string{loadLabel: runID},
+	}}
+	require.NoError(t, c.Create(ctx, ns))
+	if !cfg.Keep {
+		t.Cleanup(func() {
+			require.NoError(t, client.IgnoreNotFound(c.Delete(context.Background(), ns)))
+		})
+	}
+
+	before, err := ScrapeMetrics(ctx, cs, pkoNamespace)
+	require.NoError(t, err)
+
+	start := time.Now()
+	createObjectDeployments(ctx, t, c, cfg, ns.Name, runID)
+	waitForAvailable(ctx, t, c, cfg, ns.Name)
+	duration := time.Since(start)
+
+	after, err := ScrapeMetrics(ctx, cs, pkoNamespace)
+	require.NoError(t, err)
+
+	report := Report{
+		Packages:            cfg.Packages,
+		Objects:             cfg.Objects,
+		Duration:            duration,
+		PackagesPerSecond:   float64(cfg.Packages) / duration.Seconds(),
+		APIRequests:         after.APIRequests - before.APIRequests,
+		ResidentMemoryBytes: after.ResidentMemoryBytes,
+		HeapAllocBytes:      after.HeapAllocBytes,
+	}
+	t.Log(report.String())
+	if len(cfg.ReportPath) > 0 {
+		require.NoError(t, report.WriteFile(cfg.ReportPath))
+	}
+}
+
+func newClients(t *testing.T) (client.Client, kubernetes.Interface) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	addToSchemes := runtime.SchemeBuilder{
+		clientgoscheme.AddToScheme,
+		pkoapis.AddToScheme,
+	}
+	require.NoError(t, addToSchemes.AddToScheme(scheme))
+
+	restConfig, err := ctrl.GetConfig()
+	require.NoError(t, err)
+	// Don't let client side rate limiting skew the results.
+	restConfig.QPS = 500
+	restConfig.Burst = 1000
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	require.NoError(t, err)
+	cs, err := kubernetes.NewForConfig(restConfig)
+	require.NoError(t, err)
+	return c, cs
+}
+
+func findPackageOperatorNamespace(ctx context.Context, t *testing.T, c client.Client) string {
+	t.Helper()
+
+	deploymentList := &appsv1.DeploymentList{}
+	require.NoError(t, c.List(ctx, deploymentList))
+	for _, deployment := range deploymentList.Items {
+		if deployment.Name == "package-operator-manager" {
+			return deployment.Namespace
+		}
+	}
+	t.Fatal("no package-operator-manager deployment found on the cluster")
+	return ""
+}
+
+func createObjectDeployments(
+	ctx context.Context, t *testing.T, c client.Client,
+	cfg Config, namespace, runID string,
+) {
+	t.Helper()
+
+	var (
+		wg   sync.WaitGroup
+		mux  sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, cfg.Parallelism)
+	for i := 0; i < cfg.Packages; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+
+			od := objectDeployment(fmt.Sprintf("load-%d", i), namespace, runID, cfg.Objects)
+			if err := c.Create(ctx, od); err != nil {
+				mux.Lock()
+				errs = append(errs, err)
+				mux.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Empty(t, errs, "creating ObjectDeployments")
+}
+
+func waitForAvailable(
+	ctx context.Context, t *testing.T, c client.Client,
+	cfg Config, namespace string,
+) {
+	t.Helper()
+
+	var available int
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	err := wait.PollImmediateUntilWithContext(ctx, 2*time.Second,
+		func(ctx context.Context) (bool, error) {
+			list := &corev1alpha1.ObjectDeploymentList{}
+			if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+				return false, err
+			}
+			available = 0
+			for _, od := range list.Items {
+				if meta.IsStatusConditionTrue(od.Status.Conditions, corev1alpha1.ObjectDeploymentAvailable) {
+					available++
+				}
+			}
+			return available == cfg.Packages, nil
+		})
+	require.NoError(t, err, "%d of %d ObjectDeployments Available", available, cfg.Packages)
+}
+
+func objectDeployment(name, namespace, runID string, objects int) *corev1alpha1.ObjectDeployment {
+	labels := map[string]string{loadLabel: runID, "load.package-operator.run/name": name}
+
+	phaseObjects := make([]corev1alpha1.ObjectSetObject, objects)
+	for i := range phaseObjects {
+		cm := &unstructured.Unstructured{}
+		cm.SetAPIVersion("v1")
+		cm.SetKind("ConfigMap")
+		cm.SetName(fmt.Sprintf("%s-%d", name, i))
+		cm.SetLabels(map[string]string{loadLabel: runID})
+		cm.Object["data"] = map[string]interface{}{"index": fmt.Sprint(i)}
+		phaseObjects[i] = corev1alpha1.ObjectSetObject{Object: *cm}
+	}
+
+	return &corev1alpha1.ObjectDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: corev1alpha1.ObjectDeploymentSpec{
+			Selector: metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1alpha1.ObjectSetTemplate{
+				Metadata: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1alpha1.ObjectSetTemplateSpec{
+					Phases: []corev1alpha1.ObjectSetTemplatePhase{
+						{Name: "deploy", Objects: phaseObjects},
+					},
+				},
+			},
+		},
+	}
+}
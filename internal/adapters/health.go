@@ -0,0 +1,57 @@
+package adapters
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Summarizes the conditions of a package into its health.
+func packageHealth(conditions []metav1.Condition) *corev1alpha1.Health {
+	for _, condType := range []string{corev1alpha1.PackageInvalid, corev1alpha1.PackageUnsupported} {
+		if cond := meta.FindStatusCondition(conditions, condType); cond != nil && cond.Status == metav1.ConditionTrue {
+			return &corev1alpha1.Health{Status: corev1alpha1.HealthStatusDegraded, Message: cond.Message}
+		}
+	}
+
+	unpackCond := meta.FindStatusCondition(conditions, corev1alpha1.PackageUnpacked)
+	switch {
+	case unpackCond == nil:
+		return &corev1alpha1.Health{Status: corev1alpha1.HealthStatusProgressing, Message: "Unpacking package."}
+	case unpackCond.Reason == corev1alpha1.ReasonUnpackFailure:
+		return &corev1alpha1.Health{Status: corev1alpha1.HealthStatusDegraded, Message: unpackCond.Message}
+	case unpackCond.Status != metav1.ConditionTrue:
+		return &corev1alpha1.Health{Status: corev1alpha1.HealthStatusProgressing, Message: unpackCond.Message}
+	}
+	return availabilityHealth(conditions, corev1alpha1.PackageAvailable, corev1alpha1.PackageProgressing)
+}
+
+// Summarizes the conditions of an ObjectDeployment into its health.
+func objectDeploymentHealth(conditions []metav1.Condition) *corev1alpha1.Health {
+	if cond := meta.FindStatusCondition(conditions, corev1alpha1.ObjectDeploymentPaused); cond != nil &&
+		cond.Status == metav1.ConditionTrue {
+		return &corev1alpha1.Health{Status: corev1alpha1.HealthStatusSuspended, Message: cond.Message}
+	}
+	return availabilityHealth(
+		conditions, corev1alpha1.ObjectDeploymentAvailable, corev1alpha1.ObjectDeploymentProgressing)
+}
+
+// Objects rolling out a change are progressing, even when the previous revision is still available.
+// Objects without Available condition have not been reconciled yet.
+func availabilityHealth(conditions []metav1.Condition, availableType, progressingType string) *corev1alpha1.Health {
+	if cond := meta.FindStatusCondition(conditions, progressingType); cond != nil && cond.Status == metav1.ConditionTrue {
+		return &corev1alpha1.Health{Status: corev1alpha1.HealthStatusProgressing, Message: cond.Message}
+	}
+
+	availableCond := meta.FindStatusCondition(conditions, availableType)
+	switch {
+	case availableCond == nil:
+		return &corev1alpha1.Health{Status: corev1alpha1.HealthStatusProgressing}
+	case availableCond.Status == metav1.ConditionTrue:
+		return &corev1alpha1.Health{Status: corev1alpha1.HealthStatusHealthy, Message: availableCond.Message}
+	case availableCond.Status == metav1.ConditionFalse:
+		return &corev1alpha1.Health{Status: corev1alpha1.HealthStatusDegraded, Message: availableCond.Message}
+	}
+	return &corev1alpha1.Health{Status: corev1alpha1.HealthStatusProgressing, Message: availableCond.Message}
+}
@@ -0,0 +1,89 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func Test_packageHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+		expected   corev1alpha1.Health
+	}{
+		{
+			name: "Invalid",
+			conditions: []metav1.Condition{
+				{Type: corev1alpha1.PackageInvalid, Status: metav1.ConditionTrue, Message: "broken manifest"},
+			},
+			expected: corev1alpha1.Health{Status: corev1alpha1.HealthStatusDegraded, Message: "broken manifest"},
+		},
+		{
+			name:       "Unpacking",
+			conditions: []metav1.Condition{},
+			expected:   corev1alpha1.Health{Status: corev1alpha1.HealthStatusProgressing, Message: "Unpacking package."},
+		},
+		{
+			name: "UnpackFailure",
+			conditions: []metav1.Condition{
+				{
+					Type: corev1alpha1.PackageUnpacked, Status: metav1.ConditionFalse,
+					Reason: corev1alpha1.ReasonUnpackFailure, Message: "image not found",
+				},
+			},
+			expected: corev1alpha1.Health{Status: corev1alpha1.HealthStatusDegraded, Message: "image not found"},
+		},
+		{
+			name: "Progressing",
+			conditions: []metav1.Condition{
+				{Type: corev1alpha1.PackageUnpacked, Status: metav1.ConditionTrue},
+				{Type: corev1alpha1.PackageAvailable, Status: metav1.ConditionTrue},
+				{Type: corev1alpha1.PackageProgressing, Status: metav1.ConditionTrue, Message: "rolling out"},
+			},
+			expected: corev1alpha1.Health{Status: corev1alpha1.HealthStatusProgressing, Message: "rolling out"},
+		},
+		{
+			name: "Healthy",
+			conditions: []metav1.Condition{
+				{Type: corev1alpha1.PackageUnpacked, Status: metav1.ConditionTrue},
+				{Type: corev1alpha1.PackageAvailable, Status: metav1.ConditionTrue, Message: "available"},
+			},
+			expected: corev1alpha1.Health{Status: corev1alpha1.HealthStatusHealthy, Message: "available"},
+		},
+		{
+			name: "Degraded",
+			conditions: []metav1.Condition{
+				{Type: corev1alpha1.PackageUnpacked, Status: metav1.ConditionTrue},
+				{Type: corev1alpha1.PackageAvailable, Status: metav1.ConditionFalse, Message: "probe failed"},
+			},
+			expected: corev1alpha1.Health{Status: corev1alpha1.HealthStatusDegraded, Message: "probe failed"},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, &test.expected, packageHealth(test.conditions))
+		})
+	}
+}
+
+func Test_objectDeploymentHealth(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, &corev1alpha1.Health{
+		Status: corev1alpha1.HealthStatusSuspended, Message: "paused",
+	}, objectDeploymentHealth([]metav1.Condition{
+		{Type: corev1alpha1.ObjectDeploymentAvailable, Status: metav1.ConditionTrue},
+		{Type: corev1alpha1.ObjectDeploymentPaused, Status: metav1.ConditionTrue, Message: "paused"},
+	}))
+	assert.Equal(t, &corev1alpha1.Health{
+		Status: corev1alpha1.HealthStatusProgressing,
+	}, objectDeploymentHealth(nil))
+}
@@ -18,6 +18,8 @@ type ObjectDeploymentAccessor interface {
 	SetTemplateSpec(corev1alpha1.ObjectSetTemplateSpec)
 	GetTemplateSpec() corev1alpha1.ObjectSetTemplateSpec
 	GetRevisionHistoryLimit() *int32
+	GetObjectSetNaming() corev1alpha1.ObjectSetNamingStrategy
+	IsPaused() bool
 	SetStatusConditions(...metav1.Condition)
 	SetStatusCollisionCount(*int32)
 	GetStatusCollisionCount() *int32
@@ -27,6 +29,10 @@ type ObjectDeploymentAccessor interface {
 	SetSelector(labels map[string]string)
 	SetStatusRevision(r int64)
 	GetStatusRevision() int64
+	GetStatusMappedFields() map[string]string
+	SetStatusMappedFields(fields map[string]string)
+	GetStatusControllerOf() []corev1alpha1.ControlledObjectReference
+	SetStatusControllerOf(controllerOf []corev1alpha1.ControlledObjectReference)
 }
 
 type ObjectDeploymentFactory func(
@@ -72,6 +78,14 @@ func (a *ObjectDeployment) GetRevisionHistoryLimit() *int32 {
 	return a.Spec.RevisionHistoryLimit
 }
 
+func (a *ObjectDeployment) GetObjectSetNaming() corev1alpha1.ObjectSetNamingStrategy {
+	return a.Spec.ObjectSetNaming
+}
+
+func (a *ObjectDeployment) IsPaused() bool {
+	return a.Spec.Paused
+}
+
 func (a *ObjectDeployment) SetStatusCollisionCount(cc *int32) {
 	a.Status.CollisionCount = cc
 }
@@ -86,6 +100,7 @@ func (a *ObjectDeployment) ClientObject() client.Object {
 
 func (a *ObjectDeployment) UpdatePhase() {
 	a.Status.Phase = objectDeploymentPhase(a.Status.Conditions)
+	a.Status.Health = objectDeploymentHealth(a.Status.Conditions)
 }
 
 func (a *ObjectDeployment) GetConditions() *[]metav1.Condition {
@@ -139,6 +154,22 @@ func (a *ObjectDeployment) GetStatusRevision() int64 {
 	return a.Status.Revision
 }
 
+func (a *ObjectDeployment) GetStatusMappedFields() map[string]string {
+	return a.Status.MappedFields
+}
+
+func (a *ObjectDeployment) SetStatusMappedFields(fields map[string]string) {
+	a.Status.MappedFields = fields
+}
+
+func (a *ObjectDeployment) GetStatusControllerOf() []corev1alpha1.ControlledObjectReference {
+	return a.Status.ControllerOf
+}
+
+func (a *ObjectDeployment) SetStatusControllerOf(controllerOf []corev1alpha1.ControlledObjectReference) {
+	a.Status.ControllerOf = controllerOf
+}
+
 type ClusterObjectDeployment struct {
 	corev1alpha1.ClusterObjectDeployment
 }
@@ -147,6 +178,14 @@ func (a *ClusterObjectDeployment) GetRevisionHistoryLimit() *int32 {
 	return a.Spec.RevisionHistoryLimit
 }
 
+func (a *ClusterObjectDeployment) GetObjectSetNaming() corev1alpha1.ObjectSetNamingStrategy {
+	return a.Spec.ObjectSetNaming
+}
+
+func (a *ClusterObjectDeployment) IsPaused() bool {
+	return a.Spec.Paused
+}
+
 func (a *ClusterObjectDeployment) SetStatusCollisionCount(cc *int32) {
 	a.Status.CollisionCount = cc
 }
@@ -157,6 +196,7 @@ func (a *ClusterObjectDeployment) ClientObject() client.Object {
 
 func (a *ClusterObjectDeployment) UpdatePhase() {
 	a.Status.Phase = objectDeploymentPhase(a.Status.Conditions)
+	a.Status.Health = objectDeploymentHealth(a.Status.Conditions)
 }
 
 func (a *ClusterObjectDeployment) GetConditions() *[]metav1.Condition {
@@ -214,7 +254,27 @@ func (a *ClusterObjectDeployment) GetStatusRevision() int64 {
 	return a.Status.Revision
 }
 
+func (a *ClusterObjectDeployment) GetStatusMappedFields() map[string]string {
+	return a.Status.MappedFields
+}
+
+func (a *ClusterObjectDeployment) SetStatusMappedFields(fields map[string]string) {
+	a.Status.MappedFields = fields
+}
+
+func (a *ClusterObjectDeployment) GetStatusControllerOf() []corev1alpha1.ControlledObjectReference {
+	return a.Status.ControllerOf
+}
+
+func (a *ClusterObjectDeployment) SetStatusControllerOf(controllerOf []corev1alpha1.ControlledObjectReference) {
+	a.Status.ControllerOf = controllerOf
+}
+
 func objectDeploymentPhase(conditions []metav1.Condition) corev1alpha1.ObjectDeploymentPhase {
+	if meta.IsStatusConditionTrue(conditions, corev1alpha1.ObjectDeploymentPaused) {
+		return corev1alpha1.ObjectDeploymentPhasePaused
+	}
+
 	availableCond := meta.FindStatusCondition(conditions, corev1alpha1.ObjectDeploymentAvailable)
 
 	if availableCond != nil {
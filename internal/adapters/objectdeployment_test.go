@@ -123,6 +123,20 @@ func Test_objectDeploymentPhase(t *testing.T) {
 			},
 			expectedPhase: corev1alpha1.ObjectDeploymentPhaseProgressing,
 		},
+		{
+			name: "Paused",
+			conditions: []metav1.Condition{
+				{
+					Type:   corev1alpha1.ObjectDeploymentAvailable,
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:   corev1alpha1.ObjectDeploymentPaused,
+					Status: metav1.ConditionTrue,
+				},
+			},
+			expectedPhase: corev1alpha1.ObjectDeploymentPhasePaused,
+		},
 	}
 
 	for _, test := range tests {
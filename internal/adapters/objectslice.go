@@ -11,6 +11,8 @@ type ObjectSliceAccessor interface {
 	ClientObject() client.Object
 	GetObjects() []corev1alpha1.ObjectSetObject
 	SetObjects([]corev1alpha1.ObjectSetObject)
+	GetChecksum() string
+	SetChecksum(checksum string)
 }
 
 type ObjectSliceFactory func(
@@ -64,6 +66,14 @@ func (a *ObjectSlice) SetObjects(objects []corev1alpha1.ObjectSetObject) {
 	a.Objects = objects
 }
 
+func (a *ObjectSlice) GetChecksum() string {
+	return a.Checksum
+}
+
+func (a *ObjectSlice) SetChecksum(checksum string) {
+	a.Checksum = checksum
+}
+
 type ClusterObjectSlice struct {
 	corev1alpha1.ClusterObjectSlice
 }
@@ -79,3 +89,11 @@ func (a *ClusterObjectSlice) GetObjects() []corev1alpha1.ObjectSetObject {
 func (a *ClusterObjectSlice) SetObjects(objects []corev1alpha1.ObjectSetObject) {
 	a.Objects = objects
 }
+
+func (a *ClusterObjectSlice) GetChecksum() string {
+	return a.Checksum
+}
+
+func (a *ClusterObjectSlice) SetChecksum(checksum string) {
+	a.Checksum = checksum
+}
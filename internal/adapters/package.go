@@ -16,13 +16,27 @@ type GenericPackageAccessor interface {
 	UpdatePhase()
 	GetConditions() *[]metav1.Condition
 	GetImage() string
+	SetImage(image string)
+	SetConfig(config *runtime.RawExtension)
+	GetSource() corev1alpha1.PackageSource
+	GetUpgradePolicy() *corev1alpha1.PackageUpgradePolicy
+	GetPodTemplateMetadata() *corev1alpha1.PackagePodTemplateMetadata
+	GetAllowCriticalKinds() bool
+	GetPatches() []corev1alpha1.PackageObjectPatch
+	GetComponent() string
+	SetAvailableUpgrade(version string)
 	GetSpecHash(packageHashModifier *int32) string
 	GetUnpackedHash() string
 	SetUnpackedHash(hash string)
+	SetImageDigest(digest string)
 	setStatusPhase(phase corev1alpha1.PackageStatusPhase)
 	TemplateContext() manifestsv1alpha1.TemplateContext
 	SetStatusRevision(rev int64)
 	GetStatusRevision() int64
+	SetStatusMappedFields(fields map[string]string)
+	SetStatusObjects(objects []corev1alpha1.ControlledObjectReference)
+	GetStatusInventory() *corev1alpha1.PackageInventoryReference
+	SetStatusInventory(inventory *corev1alpha1.PackageInventoryReference)
 }
 
 type GenericPackageFactory func(scheme *runtime.Scheme) GenericPackageAccessor
@@ -73,14 +87,51 @@ func (a *GenericPackage) GetConditions() *[]metav1.Condition {
 
 func (a *GenericPackage) UpdatePhase() {
 	updatePackagePhase(a)
+	a.Status.Health = packageHealth(a.Status.Conditions)
 }
 
 func (a *GenericPackage) GetImage() string {
-	return a.Spec.Image
+	return packageSource(a.Spec).Image
+}
+
+func (a *GenericPackage) SetImage(image string) {
+	setPackageImage(&a.Spec, image)
+}
+
+func (a *GenericPackage) SetConfig(config *runtime.RawExtension) {
+	a.Spec.Config = config
+}
+
+func (a *GenericPackage) GetSource() corev1alpha1.PackageSource {
+	return packageSource(a.Spec)
+}
+
+func (a *GenericPackage) GetUpgradePolicy() *corev1alpha1.PackageUpgradePolicy {
+	return a.Spec.UpgradePolicy
+}
+
+func (a *GenericPackage) GetPodTemplateMetadata() *corev1alpha1.PackagePodTemplateMetadata {
+	return a.Spec.PodTemplateMetadata
+}
+
+func (a *GenericPackage) GetAllowCriticalKinds() bool {
+	return a.Spec.AllowCriticalKinds
+}
+
+func (a *GenericPackage) GetPatches() []corev1alpha1.PackageObjectPatch {
+	return a.Spec.Patches
+}
+
+func (a *GenericPackage) GetComponent() string {
+	return a.Spec.Component
+}
+
+func (a *GenericPackage) SetAvailableUpgrade(version string) {
+	a.Status.AvailableUpgrade = version
 }
 
 func (a *GenericPackage) GetSpecHash(packageHashModifier *int32) string {
-	return utils.ComputeSHA256Hash(a.Spec, packageHashModifier)
+	return packageSpecHash(a.Spec, packageHashModifier)
 }
 
 func (a *GenericPackage) SetUnpackedHash(hash string) {
@@ -91,6 +142,10 @@ func (a *GenericPackage) GetUnpackedHash() string {
 	return a.Status.UnpackedHash
 }
 
+func (a *GenericPackage) SetImageDigest(digest string) {
+	a.Status.ImageDigest = digest
+}
+
 func (a *GenericPackage) SetStatusRevision(rev int64) {
 	a.Status.Revision = rev
 }
@@ -112,6 +167,22 @@ func (a *GenericPackage) TemplateContext() manifestsv1alpha1.TemplateContext {
 	}
 }
 
+func (a *GenericPackage) SetStatusMappedFields(fields map[string]string) {
+	a.Status.MappedFields = fields
+}
+
+func (a *GenericPackage) SetStatusObjects(objects []corev1alpha1.ControlledObjectReference) {
+	a.Status.Objects = objects
+}
+
+func (a *GenericPackage) GetStatusInventory() *corev1alpha1.PackageInventoryReference {
+	return a.Status.Inventory
+}
+
+func (a *GenericPackage) SetStatusInventory(inventory *corev1alpha1.PackageInventoryReference) {
+	a.Status.Inventory = inventory
+}
+
 type GenericClusterPackage struct {
 	corev1alpha1.ClusterPackage
 }
@@ -126,14 +197,51 @@ func (a *GenericClusterPackage) GetConditions() *[]metav1.Condition {
 
 func (a *GenericClusterPackage) UpdatePhase() {
 	updatePackagePhase(a)
+	a.Status.Health = packageHealth(a.Status.Conditions)
 }
 
 func (a *GenericClusterPackage) GetImage() string {
-	return a.Spec.Image
+	return packageSource(a.Spec).Image
+}
+
+func (a *GenericClusterPackage) SetImage(image string) {
+	setPackageImage(&a.Spec, image)
+}
+
+func (a *GenericClusterPackage) SetConfig(config *runtime.RawExtension) {
+	a.Spec.Config = config
+}
+
+func (a *GenericClusterPackage) GetSource() corev1alpha1.PackageSource {
+	return packageSource(a.Spec)
+}
+
+func (a *GenericClusterPackage) GetUpgradePolicy() *corev1alpha1.PackageUpgradePolicy {
+	return a.Spec.UpgradePolicy
+}
+
+func (a *GenericClusterPackage) GetPodTemplateMetadata() *corev1alpha1.PackagePodTemplateMetadata {
+	return a.Spec.PodTemplateMetadata
+}
+
+func (a *GenericClusterPackage) GetAllowCriticalKinds() bool {
+	return a.Spec.AllowCriticalKinds
+}
+
+func (a *GenericClusterPackage) GetPatches() []corev1alpha1.PackageObjectPatch {
+	return a.Spec.Patches
+}
+
+func (a *GenericClusterPackage) GetComponent() string {
+	return a.Spec.Component
+}
+
+func (a *GenericClusterPackage) SetAvailableUpgrade(version string) {
+	a.Status.AvailableUpgrade = version
 }
 
 func (a *GenericClusterPackage) GetSpecHash(packageHashModifier *int32) string {
-	return utils.ComputeSHA256Hash(a.Spec, packageHashModifier)
+	return packageSpecHash(a.Spec, packageHashModifier)
 }
 
 func (a *GenericClusterPackage) SetStatusRevision(rev int64) {
@@ -165,11 +273,60 @@ func (a *GenericClusterPackage) GetUnpackedHash() string {
 	return a.Status.UnpackedHash
 }
 
+func (a *GenericClusterPackage) SetImageDigest(digest string) {
+	a.Status.ImageDigest = digest
+}
+
+func (a *GenericClusterPackage) SetStatusMappedFields(fields map[string]string) {
+	a.Status.MappedFields = fields
+}
+
+func (a *GenericClusterPackage) SetStatusObjects(objects []corev1alpha1.ControlledObjectReference) {
+	a.Status.Objects = objects
+}
+
+func (a *GenericClusterPackage) GetStatusInventory() *corev1alpha1.PackageInventoryReference {
+	return a.Status.Inventory
+}
+
+func (a *GenericClusterPackage) SetStatusInventory(inventory *corev1alpha1.PackageInventoryReference) {
+	a.Status.Inventory = inventory
+}
+
+// Returns the source of the package contents,
+// falling back to .spec.image when no explicit source is set.
+func packageSource(spec corev1alpha1.PackageSpec) corev1alpha1.PackageSource {
+	if spec.Source != nil {
+		return *spec.Source
+	}
+	return corev1alpha1.PackageSource{Image: spec.Image}
+}
+
+// Updates the image wherever the package specifies it.
+func setPackageImage(spec *corev1alpha1.PackageSpec, image string) {
+	if spec.Source != nil && len(spec.Source.Image) > 0 {
+		spec.Source.Image = image
+		return
+	}
+	spec.Image = image
+}
+
+// Hashes the parts of the spec that affect the unpacked package content,
+// so changing the upgrade policy doesn't trigger a new unpack.
+func packageSpecHash(spec corev1alpha1.PackageSpec, packageHashModifier *int32) string {
+	spec.UpgradePolicy = nil
+	return utils.ComputeSHA256Hash(spec, packageHashModifier)
+}
+
 func updatePackagePhase(pkg GenericPackageAccessor) {
 	if meta.IsStatusConditionTrue(*pkg.GetConditions(), corev1alpha1.PackageInvalid) {
 		pkg.setStatusPhase(corev1alpha1.PackagePhaseInvalid)
 		return
 	}
+	if meta.IsStatusConditionTrue(*pkg.GetConditions(), corev1alpha1.PackageUnsupported) {
+		pkg.setStatusPhase(corev1alpha1.PackagePhaseUnsupported)
+		return
+	}
 
 	unpackCond := meta.FindStatusCondition(*pkg.GetConditions(), corev1alpha1.PackageUnpacked)
 	if unpackCond == nil {
@@ -22,6 +22,18 @@ func TestGenericPackage(t *testing.T) {
 	p.Spec.Image = "test"
 	assert.Equal(t, p.Spec.Image, pkg.GetImage())
 
+	pkg.SetImage("test:v1.1.0")
+	assert.Equal(t, "test:v1.1.0", p.Spec.Image)
+
+	config := &runtime.RawExtension{Raw: []byte(`{"replicas":2}`)}
+	pkg.SetConfig(config)
+	assert.Same(t, config, p.Spec.Config)
+
+	p.Spec.UpgradePolicy = &corev1alpha1.PackageUpgradePolicy{Channel: "stable"}
+	assert.Same(t, p.Spec.UpgradePolicy, pkg.GetUpgradePolicy())
+	pkg.SetAvailableUpgrade("v1.2.0")
+	assert.Equal(t, "v1.2.0", p.Status.AvailableUpgrade)
+
 	pkg.SetUnpackedHash("123")
 	assert.Equal(t, "123", p.Status.UnpackedHash)
 	assert.Equal(t, "123", pkg.GetUnpackedHash())
@@ -43,6 +55,18 @@ func TestGenericClusterPackage(t *testing.T) {
 	p.Spec.Image = "test"
 	assert.Equal(t, p.Spec.Image, pkg.GetImage())
 
+	pkg.SetImage("test:v1.1.0")
+	assert.Equal(t, "test:v1.1.0", p.Spec.Image)
+
+	config := &runtime.RawExtension{Raw: []byte(`{"replicas":2}`)}
+	pkg.SetConfig(config)
+	assert.Same(t, config, p.Spec.Config)
+
+	p.Spec.UpgradePolicy = &corev1alpha1.PackageUpgradePolicy{Channel: "stable"}
+	assert.Same(t, p.Spec.UpgradePolicy, pkg.GetUpgradePolicy())
+	pkg.SetAvailableUpgrade("v1.2.0")
+	assert.Equal(t, "v1.2.0", p.Status.AvailableUpgrade)
+
 	pkg.SetUnpackedHash("123")
 	assert.Equal(t, "123", p.Status.UnpackedHash)
 	assert.Equal(t, "123", pkg.GetUnpackedHash())
@@ -52,6 +76,19 @@ func TestGenericClusterPackage(t *testing.T) {
 	assert.Same(t, p.Spec.Config, tc.Config)
 }
 
+func TestGenericPackage_GetSpecHash_ignoresUpgradePolicy(t *testing.T) {
+	pkg := NewGenericPackage(testScheme)
+	p := pkg.ClientObject().(*corev1alpha1.Package)
+	p.Spec.Image = "test:v1.0.0"
+	hash := pkg.GetSpecHash(nil)
+
+	p.Spec.UpgradePolicy = &corev1alpha1.PackageUpgradePolicy{Channel: "stable", Auto: true}
+	assert.Equal(t, hash, pkg.GetSpecHash(nil))
+
+	p.Spec.Image = "test:v1.1.0"
+	assert.NotEqual(t, hash, pkg.GetSpecHash(nil))
+}
+
 func Test_updatePackagePhase(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -146,3 +183,22 @@ func Test_templateContextObjectMetaFromObjectMeta(t *testing.T) {
 	assert.Equal(t, labels, tcom.Labels)
 	assert.Equal(t, annotations, tcom.Annotations)
 }
+
+func TestGenericPackage_source(t *testing.T) {
+	pkg := NewGenericPackage(testScheme)
+	p := pkg.ClientObject().(*corev1alpha1.Package)
+
+	p.Spec.Image = "test"
+	assert.Equal(t, corev1alpha1.PackageSource{Image: "test"}, pkg.GetSource())
+
+	p.Spec.Image = ""
+	p.Spec.Source = &corev1alpha1.PackageSource{Image: "test"}
+	assert.Equal(t, "test", pkg.GetImage())
+	pkg.SetImage("test:v1.1.0")
+	assert.Equal(t, "test:v1.1.0", p.Spec.Source.Image)
+	assert.Empty(t, p.Spec.Image)
+
+	p.Spec.Source = &corev1alpha1.PackageSource{LocalPath: "my-package"}
+	assert.Empty(t, pkg.GetImage())
+	assert.Equal(t, "my-package", pkg.GetSource().LocalPath)
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// OutputFormat selects how command results are printed.
+type OutputFormat string
+
+const (
+	// OutputFormatHuman prints human readable text.
+	OutputFormatHuman OutputFormat = ""
+	// OutputFormatJSON prints machine-readable JSON.
+	OutputFormatJSON OutputFormat = "json"
+	// OutputFormatYAML prints machine-readable YAML.
+	OutputFormatYAML OutputFormat = "yaml"
+)
+
+var ErrInvalidOutputFormat = errors.New("invalid output format")
+
+// ParseOutputFormat validates the given string as OutputFormat.
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch f := OutputFormat(strings.ToLower(s)); f {
+	case OutputFormatHuman, OutputFormatJSON, OutputFormatYAML:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidOutputFormat, s)
+	}
+}
+
+// IsStructured returns true for machine-readable formats.
+func (f OutputFormat) IsStructured() bool {
+	return f == OutputFormatJSON || f == OutputFormatYAML
+}
+
+// PrintStructured prints the given value as JSON or YAML.
+func (p *Printer) PrintStructured(format OutputFormat, v any) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch format {
+	case OutputFormatJSON:
+		data, err = json.MarshalIndent(v, "", "    ")
+		data = append(data, '\n')
+	case OutputFormatYAML:
+		data, err = yaml.Marshal(v)
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidOutputFormat, format)
+	}
+	if err != nil {
+		return fmt.Errorf("rendering %s output: %w", format, err)
+	}
+
+	return p.PrintfOut("%s", data)
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	t.Parallel()
+
+	for input, expected := range map[string]OutputFormat{
+		"":     OutputFormatHuman,
+		"json": OutputFormatJSON,
+		"YAML": OutputFormatYAML,
+	} {
+		f, err := ParseOutputFormat(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, f)
+	}
+
+	_, err := ParseOutputFormat("xml")
+	require.ErrorIs(t, err, ErrInvalidOutputFormat)
+}
+
+func TestPrinter_PrintStructured(t *testing.T) {
+	t.Parallel()
+
+	v := struct {
+		Name string `json:"name"`
+	}{Name: "test"}
+
+	for format, expected := range map[OutputFormat]string{
+		OutputFormatJSON: "{\n    \"name\": \"test\"\n}\n",
+		OutputFormatYAML: "name: test\n",
+	} {
+		var out bytes.Buffer
+
+		printer := NewPrinter(WithOut{Out: &out})
+		require.NoError(t, printer.PrintStructured(format, v))
+		assert.Equal(t, expected, out.String())
+	}
+
+	printer := NewPrinter(WithOut{Out: &bytes.Buffer{}})
+	require.ErrorIs(t, printer.PrintStructured(OutputFormatHuman, v), ErrInvalidOutputFormat)
+}
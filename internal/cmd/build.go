@@ -3,10 +3,13 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/crane"
 	"github.com/google/go-containerregistry/pkg/name"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"package-operator.run/apis/manifests/v1alpha1"
@@ -42,6 +45,7 @@ type Build struct {
 }
 
 type BuildConfig struct {
+	Clock    Clock
 	Log      logr.Logger
 	Resolver DigestResolver
 }
@@ -53,6 +57,10 @@ func (c *BuildConfig) Option(opts ...BuildOption) {
 }
 
 func (c *BuildConfig) Default() {
+	if c.Clock == nil {
+		c.Clock = &defaultClock{}
+	}
+
 	if c.Log.GetSink() == nil {
 		c.Log = logr.Discard()
 	}
@@ -73,7 +81,7 @@ func (b *Build) BuildFromSource(ctx context.Context, srcPath string, opts ...Bui
 
 	cfg.Option(opts...)
 
-	files, loaderOpts, err := getPackageFromPath(ctx, b.scheme, srcPath)
+	files, loaderOpts, err := getPackageFromPath(ctx, b.scheme, srcPath, "")
 	if err != nil {
 		return fmt.Errorf("load source from disk path %s: %w", srcPath, err)
 	}
@@ -91,22 +99,28 @@ func (b *Build) BuildFromSource(ctx context.Context, srcPath string, opts ...Bui
 		return fmt.Errorf("validating package: %w", err)
 	}
 
+	image, err := packageexport.Image(files, packageexport.WithAnnotations(b.imageAnnotations(cfg)))
+	if err != nil {
+		return fmt.Errorf("creating image: %w", err)
+	}
+
 	if cfg.OutputPath != "" {
 		b.cfg.Log.Info("writing tagged image to disk", "path", cfg.OutputPath)
 
-		if err := packageexport.File(cfg.OutputPath, cfg.Tags, files); err != nil {
+		if err := packageexport.File(cfg.OutputPath, cfg.Tags, image); err != nil {
 			return fmt.Errorf("exporting package to file: %w", err)
 		}
 	}
 
 	if cfg.Push {
-		var craneOpts []crane.Option
+		// Registry credentials are read from the local docker config.
+		craneOpts := []crane.Option{crane.WithAuthFromKeychain(authn.DefaultKeychain)}
 
 		if cfg.Insecure {
 			craneOpts = append(craneOpts, crane.Insecure)
 		}
 
-		if err := packageexport.PushedImage(ctx, cfg.Tags, files, craneOpts...); err != nil {
+		if err := packageexport.PushedImage(ctx, cfg.Tags, image, craneOpts...); err != nil {
 			return fmt.Errorf("exporting package to image: %w", err)
 		}
 	}
@@ -114,6 +128,24 @@ func (b *Build) BuildFromSource(ctx context.Context, srcPath string, opts ...Bui
 	return nil
 }
 
+// Returns the standard OCI annotations for the package image.
+func (b *Build) imageAnnotations(cfg BuildFromSourceConfig) map[string]string {
+	created := b.cfg.Clock.Now().Time
+	if cfg.Created != nil {
+		created = *cfg.Created
+	}
+	annotations := map[string]string{
+		ocispec.AnnotationCreated: created.UTC().Format(time.RFC3339),
+	}
+	if len(cfg.Source) > 0 {
+		annotations[ocispec.AnnotationSource] = cfg.Source
+	}
+	if len(cfg.Revision) > 0 {
+		annotations[ocispec.AnnotationRevision] = cfg.Revision
+	}
+	return annotations
+}
+
 func (b *Build) validatePackage(pkg *packagecontent.Package) error {
 	if pkg.PackageManifestLock == nil {
 		if len(pkg.PackageManifest.Spec.Images) > 0 {
@@ -182,6 +214,13 @@ type BuildFromSourceConfig struct {
 	OutputPath string
 	Tags       []string
 	Push       bool
+	// URL of the package source, recorded as image annotation.
+	Source string
+	// Source control revision of the package, recorded as image annotation.
+	Revision string
+	// Creation time recorded as image annotation instead of the current time,
+	// e.g. from SOURCE_DATE_EPOCH for reproducible builds.
+	Created *time.Time
 }
 
 func (c *BuildFromSourceConfig) Option(opts ...BuildFromSourceOption) {
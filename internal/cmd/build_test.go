@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/packages/packagecontent"
@@ -121,3 +124,37 @@ func pkg(manifestImages map[string]string, lockImages map[string]LockImageTestDa
 		PackageManifestLock: lock,
 	}
 }
+
+func TestBuild_imageAnnotations(t *testing.T) {
+	t.Parallel()
+
+	now := v1.NewTime(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	mClock := &clockMock{}
+	mClock.
+		On("Now").
+		Return(now)
+
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+
+	b := NewBuild(scheme, WithClock{Clock: mClock})
+
+	var cfg BuildFromSourceConfig
+	cfg.Option(WithSource("https://github.com/example/package"), WithRevision("0123abc"))
+
+	assert.Equal(t, map[string]string{
+		"org.opencontainers.image.created":  "2023-06-01T12:00:00Z",
+		"org.opencontainers.image.source":   "https://github.com/example/package",
+		"org.opencontainers.image.revision": "0123abc",
+	}, b.imageAnnotations(cfg))
+
+	assert.Equal(t, map[string]string{
+		"org.opencontainers.image.created": "2023-06-01T12:00:00Z",
+	}, b.imageAnnotations(BuildFromSourceConfig{}))
+
+	var reproducible BuildFromSourceConfig
+	reproducible.Option(WithCreated(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, map[string]string{
+		"org.opencontainers.image.created": "2020-01-01T00:00:00Z",
+	}, b.imageAnnotations(reproducible))
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -25,6 +26,9 @@ func NewScheme() (*runtime.Scheme, error) {
 	if err := manifestsv1alpha1.AddToScheme(scheme); err != nil {
 		return nil, err
 	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
 	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
 		return nil, err
 	}
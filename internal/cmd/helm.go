@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+)
+
+const (
+	// Helm stores every revision of a release in a Secret of this type.
+	helmReleaseSecretType = "helm.sh/release.v1"
+	// Label selecting objects of the generated ObjectDeployment,
+	// Helm charts commonly use the same label to identify the release.
+	helmInstanceLabel = "app.kubernetes.io/instance"
+	// Name of the single phase containing all objects of the release.
+	helmPhaseName = "helm-release"
+	// Annotation set by kubectl apply, which is not part of the desired state.
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+var ErrHelmReleaseNotFound = errors.New("helm release not found")
+
+// Helm compresses releases with gzip, if they are larger than a few bytes.
+var gzipMagicHeader = []byte{0x1f, 0x8b, 0x08}
+
+// AdoptHelmRelease generates an ObjectDeployment matching the current state of
+// all objects of the given Helm release and marks these objects for adoption,
+// so Package Operator can take them over without recreating them.
+func (c *Client) AdoptHelmRelease(
+	ctx context.Context, release string, opts ...AdoptHelmReleaseOption,
+) (client.Object, error) {
+	var cfg AdoptHelmReleaseConfig
+
+	cfg.Option(opts...)
+	cfg.Default(release)
+
+	manifest, err := c.getHelmReleaseManifest(ctx, release, cfg.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := parseHelmManifest(manifest, cfg.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	liveObjects := make([]*unstructured.Unstructured, 0, len(objects))
+	for i := range objects {
+		obj := &objects[i]
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := c.client.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+			return nil, fmt.Errorf("getting %s %s: %w", obj.GroupVersionKind().Kind, obj.GetName(), err)
+		}
+		liveObjects = append(liveObjects, live)
+	}
+
+	deployment := newHelmObjectDeployment(release, cfg.Name, cfg.Namespace, liveObjects)
+	if cfg.DryRun {
+		return deployment, nil
+	}
+
+	for _, live := range liveObjects {
+		if err := c.markForAdoption(ctx, live, cfg.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return deployment, nil
+}
+
+type AdoptHelmReleaseConfig struct {
+	Name      string
+	Namespace string
+	DryRun    bool
+}
+
+func (c *AdoptHelmReleaseConfig) Option(opts ...AdoptHelmReleaseOption) {
+	for _, opt := range opts {
+		opt.ConfigureAdoptHelmRelease(c)
+	}
+}
+
+func (c *AdoptHelmReleaseConfig) Default(release string) {
+	if c.Name == "" {
+		c.Name = release
+	}
+
+	if c.Namespace == "" {
+		c.Namespace = metav1.NamespaceDefault
+	}
+}
+
+type AdoptHelmReleaseOption interface {
+	ConfigureAdoptHelmRelease(*AdoptHelmReleaseConfig)
+}
+
+// Only the parts of a Helm release needed for adoption.
+type helmRelease struct {
+	Manifest string `json:"manifest"`
+	Version  int    `json:"version"`
+}
+
+// Returns the manifest of the latest deployed revision of the given release.
+func (c *Client) getHelmReleaseManifest(ctx context.Context, release, namespace string) (string, error) {
+	var secrets corev1.SecretList
+	if err := c.client.List(ctx, &secrets,
+		client.InNamespace(namespace),
+		client.MatchingLabels{
+			"owner":  "helm",
+			"name":   release,
+			"status": "deployed",
+		},
+	); err != nil {
+		return "", fmt.Errorf("listing helm release secrets: %w", err)
+	}
+
+	var latest *helmRelease
+	for _, secret := range secrets.Items {
+		if secret.Type != helmReleaseSecretType {
+			continue
+		}
+
+		rel, err := decodeHelmRelease(secret.Data["release"])
+		if err != nil {
+			return "", fmt.Errorf("decoding helm release secret %s: %w", secret.Name, err)
+		}
+		if latest == nil || rel.Version > latest.Version {
+			latest = rel
+		}
+	}
+	if latest == nil {
+		return "", fmt.Errorf("%w: %s in namespace %s", ErrHelmReleaseNotFound, release, namespace)
+	}
+
+	return latest.Manifest, nil
+}
+
+func decodeHelmRelease(data []byte) (*helmRelease, error) {
+	b, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding base64: %w", err)
+	}
+
+	if bytes.HasPrefix(b, gzipMagicHeader) {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("decompressing: %w", err)
+		}
+		defer r.Close()
+
+		if b, err = io.ReadAll(r); err != nil {
+			return nil, fmt.Errorf("decompressing: %w", err)
+		}
+	}
+
+	rel := &helmRelease{}
+	if err := json.Unmarshal(b, rel); err != nil {
+		return nil, fmt.Errorf("unmarshalling: %w", err)
+	}
+
+	return rel, nil
+}
+
+// Splits the multi document YAML manifest of a release into objects.
+// Helm installs objects without namespace into the namespace of the release.
+func parseHelmManifest(manifest, namespace string) ([]unstructured.Unstructured, error) {
+	var objects []unstructured.Unstructured
+
+	for i, yamlDocument := range bytes.Split([]byte("\n"+manifest), []byte("\n---\n")) {
+		obj := unstructured.Unstructured{}
+		if err := yaml.Unmarshal(yamlDocument, &obj.Object); err != nil {
+			return nil, fmt.Errorf("parsing helm manifest document %d: %w", i, err)
+		}
+
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// Labels objects, so they are visible to the Package Operator cache,
+// and annotates them to be adopted by the ObjectDeployment with the given name.
+func (c *Client) markForAdoption(ctx context.Context, obj *unstructured.Unstructured, name string) error {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]string{
+				controllers.DynamicCacheLabel: "True",
+			},
+			"annotations": map[string]string{
+				corev1alpha1.AdoptionAnnotation: name,
+			},
+		},
+	}
+
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("marshalling adoption patch: %w", err)
+	}
+
+	if err := c.client.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patchBytes)); err != nil {
+		return fmt.Errorf("marking %s %s for adoption: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	return nil
+}
+
+// Creates a ClusterObjectDeployment, if the release contains cluster-scoped objects
+// and an ObjectDeployment in the namespace of the release otherwise.
+func newHelmObjectDeployment(
+	release, name, namespace string, liveObjects []*unstructured.Unstructured,
+) client.Object {
+	var clusterScoped bool
+
+	phase := corev1alpha1.ObjectSetTemplatePhase{Name: helmPhaseName}
+	for _, live := range liveObjects {
+		if live.GetNamespace() == "" {
+			clusterScoped = true
+		}
+
+		phase.Objects = append(phase.Objects, corev1alpha1.ObjectSetObject{
+			Object: helmObjectTemplate(live),
+		})
+	}
+
+	selectorLabels := map[string]string{helmInstanceLabel: release}
+	spec := corev1alpha1.ObjectDeploymentSpec{
+		Selector: metav1.LabelSelector{MatchLabels: selectorLabels},
+		Template: corev1alpha1.ObjectSetTemplate{
+			Metadata: metav1.ObjectMeta{Labels: selectorLabels},
+			Spec: corev1alpha1.ObjectSetTemplateSpec{
+				Phases: []corev1alpha1.ObjectSetTemplatePhase{phase},
+			},
+		},
+	}
+
+	if clusterScoped {
+		return &corev1alpha1.ClusterObjectDeployment{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: corev1alpha1.GroupVersion.String(),
+				Kind:       "ClusterObjectDeployment",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1alpha1.ClusterObjectDeploymentSpec(spec),
+		}
+	}
+
+	return &corev1alpha1.ObjectDeployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1alpha1.GroupVersion.String(),
+			Kind:       "ObjectDeployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       spec,
+	}
+}
+
+// Strips fields populated by the API server or by adoption from the live object.
+func helmObjectTemplate(live *unstructured.Unstructured) unstructured.Unstructured {
+	obj := live.DeepCopy()
+
+	unstructured.RemoveNestedField(obj.Object, "status")
+	for _, f := range []string{
+		"uid", "resourceVersion", "generation", "creationTimestamp",
+		"managedFields", "ownerReferences", "selfLink",
+	} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", f)
+	}
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, lastAppliedConfigAnnotation)
+	delete(annotations, corev1alpha1.AdoptionAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+
+	labels := obj.GetLabels()
+	delete(labels, controllers.DynamicCacheLabel)
+	if len(labels) == 0 {
+		labels = nil
+	}
+	obj.SetLabels(labels)
+
+	return *obj
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+)
+
+const testHelmManifest = `---
+# Source: test/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-cm
+data:
+  key: value
+`
+
+func newHelmReleaseSecret(t *testing.T, name string, version int, manifest string) *corev1.Secret {
+	t.Helper()
+
+	releaseJSON, err := json.Marshal(helmRelease{Manifest: manifest, Version: version})
+	require.NoError(t, err)
+
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	_, err = w.Write(releaseJSON)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test-ns",
+			Labels: map[string]string{
+				"owner":  "helm",
+				"name":   "test",
+				"status": "deployed",
+			},
+		},
+		Type: helmReleaseSecretType,
+		Data: map[string][]byte{
+			"release": []byte(base64.StdEncoding.EncodeToString(compressed.Bytes())),
+		},
+	}
+}
+
+func TestClient_AdoptHelmRelease(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		ActualObjects []client.Object
+		Options       []AdoptHelmReleaseOption
+		Assertion     require.ErrorAssertionFunc
+		Marked        bool
+	}{
+		"release not found": {
+			Options:   []AdoptHelmReleaseOption{WithNamespace("test-ns")},
+			Assertion: require.Error,
+		},
+		"object not found": {
+			ActualObjects: []client.Object{
+				newHelmReleaseSecret(t, "sh.helm.release.v1.test.v1", 1, testHelmManifest),
+			},
+			Options:   []AdoptHelmReleaseOption{WithNamespace("test-ns")},
+			Assertion: require.Error,
+		},
+		"dry run": {
+			ActualObjects: []client.Object{
+				newHelmReleaseSecret(t, "sh.helm.release.v1.test.v1", 1, testHelmManifest),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cm", Namespace: "test-ns"},
+					Data:       map[string]string{"key": "value"},
+				},
+			},
+			Options:   []AdoptHelmReleaseOption{WithNamespace("test-ns"), WithDryRun(true)},
+			Assertion: require.NoError,
+		},
+		"latest revision": {
+			ActualObjects: []client.Object{
+				newHelmReleaseSecret(t, "sh.helm.release.v1.test.v1", 1, ""),
+				newHelmReleaseSecret(t, "sh.helm.release.v1.test.v2", 2, testHelmManifest),
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cm", Namespace: "test-ns"},
+					Data:       map[string]string{"key": "value"},
+				},
+			},
+			Options:   []AdoptHelmReleaseOption{WithNamespace("test-ns")},
+			Assertion: require.NoError,
+			Marked:    true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme, err := NewScheme()
+			require.NoError(t, err)
+
+			fakeClient := fake.
+				NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tc.ActualObjects...).
+				Build()
+
+			ctx := context.Background()
+			deployment, err := NewClient(fakeClient).AdoptHelmRelease(ctx, "test", tc.Options...)
+			tc.Assertion(t, err)
+			if err != nil {
+				return
+			}
+
+			require.IsType(t, &corev1alpha1.ObjectDeployment{}, deployment)
+			objectDeployment := deployment.(*corev1alpha1.ObjectDeployment)
+			assert.Equal(t, "test", objectDeployment.Name)
+			assert.Equal(t, "test-ns", objectDeployment.Namespace)
+			require.Len(t, objectDeployment.Spec.Template.Spec.Phases, 1)
+			objects := objectDeployment.Spec.Template.Spec.Phases[0].Objects
+			require.Len(t, objects, 1)
+			assert.Equal(t, "test-cm", objects[0].Object.GetName())
+			assert.Empty(t, objects[0].Object.GetResourceVersion())
+
+			cm := &corev1.ConfigMap{}
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "test-cm", Namespace: "test-ns"}, cm))
+			if tc.Marked {
+				assert.Equal(t, "True", cm.Labels[controllers.DynamicCacheLabel])
+				assert.Equal(t, "test", cm.Annotations[corev1alpha1.AdoptionAnnotation])
+			} else {
+				assert.NotContains(t, cm.Annotations, corev1alpha1.AdoptionAnnotation)
+			}
+		})
+	}
+}
+
+func TestParseHelmManifest(t *testing.T) {
+	t.Parallel()
+
+	manifest := testHelmManifest + `---
+# Source: test/templates/clusterrole.yaml
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: test-role
+  namespace: other
+`
+
+	objects, err := parseHelmManifest(manifest, "test-ns")
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	assert.Equal(t, "test-ns", objects[0].GetNamespace())
+	assert.Equal(t, "other", objects[1].GetNamespace())
+}
@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/go-logr/logr"
 )
 
 type WithClock struct{ Clock Clock }
 
+func (w WithClock) ConfigureBuild(c *BuildConfig) {
+	c.Clock = w.Clock
+}
+
 func (w WithClock) ConfigureUpdate(c *UpdateConfig) {
 	c.Clock = w.Clock
 }
@@ -16,6 +22,12 @@ func (w WithClusterScope) ConfigureRenderPackage(c *RenderPackageConfig) {
 	c.ClusterScope = bool(w)
 }
 
+type WithComponent string
+
+func (w WithComponent) ConfigureValidatePackage(c *ValidatePackageConfig) {
+	c.Component = string(w)
+}
+
 type WithConfigPath string
 
 func (w WithConfigPath) ConfigureRenderPackage(c *RenderPackageConfig) {
@@ -28,6 +40,13 @@ func (w WithConfigTestcase) ConfigureRenderPackage(c *RenderPackageConfig) {
 	c.ConfigTestcase = string(w)
 }
 
+type WithCreated time.Time
+
+func (w WithCreated) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
+	created := time.Time(w)
+	c.Created = &created
+}
+
 type WithDigestResolver struct{ Resolver DigestResolver }
 
 func (w WithDigestResolver) ConfigureBuild(c *BuildConfig) {
@@ -82,6 +101,10 @@ func (w WithInsecure) ConfigureValidatePackage(c *ValidatePackageConfig) {
 
 type WithNamespace string
 
+func (w WithNamespace) ConfigureAdoptHelmRelease(c *AdoptHelmReleaseConfig) {
+	c.Namespace = string(w)
+}
+
 func (w WithNamespace) ConfigureGetPackage(c *GetPackageConfig) {
 	c.Namespace = string(w)
 }
@@ -126,8 +149,38 @@ func (w WithRemoteReference) ConfigureValidatePackage(c *ValidatePackageConfig)
 	c.RemoteReference = string(w)
 }
 
+type WithRevision string
+
+func (w WithRevision) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
+	c.Revision = string(w)
+}
+
+type WithSource string
+
+func (w WithSource) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
+	c.Source = string(w)
+}
+
+type WithStrict bool
+
+func (w WithStrict) ConfigureValidatePackage(c *ValidatePackageConfig) {
+	c.Strict = bool(w)
+}
+
 type WithTags []string
 
 func (w WithTags) ConfigureBuildFromSource(c *BuildFromSourceConfig) {
 	c.Tags = append(c.Tags, w...)
 }
+
+type WithDeploymentName string
+
+func (w WithDeploymentName) ConfigureAdoptHelmRelease(c *AdoptHelmReleaseConfig) {
+	c.Name = string(w)
+}
+
+type WithDryRun bool
+
+func (w WithDryRun) ConfigureAdoptHelmRelease(c *AdoptHelmReleaseConfig) {
+	c.DryRun = bool(w)
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import "package-operator.run/package-operator/internal/version"
+
+// OutputAPIVersion versions the machine-readable results
+// printed by kubectl-package when structured output is requested.
+// Fields may be added within a version, but never renamed or removed.
+const OutputAPIVersion = "kubectl-package.package-operator.run/v1alpha1"
+
+// OutputTypeMeta identifies the schema of a machine-readable result.
+type OutputTypeMeta struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+func newOutputTypeMeta(kind string) OutputTypeMeta {
+	return OutputTypeMeta{
+		APIVersion: OutputAPIVersion,
+		Kind:       kind,
+	}
+}
+
+// ValidationResult is the machine-readable result of validating a package.
+type ValidationResult struct {
+	OutputTypeMeta `json:",inline"`
+	// Path or image reference of the validated package.
+	Target string `json:"target"`
+	// True if the package passed validation.
+	Valid bool `json:"valid"`
+	// Validation errors, empty if the package is valid.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// NewValidationResult creates a ValidationResult from the outcome of ValidatePackage.
+func NewValidationResult(target string, err error) ValidationResult {
+	res := ValidationResult{
+		OutputTypeMeta: newOutputTypeMeta("ValidationResult"),
+		Target:         target,
+		Valid:          err == nil,
+	}
+	if err != nil {
+		res.Errors = []string{err.Error()}
+	}
+
+	return res
+}
+
+// UpdateResult is the machine-readable result of updating a package lock file.
+type UpdateResult struct {
+	OutputTypeMeta `json:",inline"`
+	// Path of the lock file.
+	LockFile string `json:"lockFile"`
+	// True if the lock file was (re-)written.
+	Updated bool `json:"updated"`
+}
+
+// NewUpdateResult creates a new UpdateResult.
+func NewUpdateResult(lockFile string, updated bool) UpdateResult {
+	return UpdateResult{
+		OutputTypeMeta: newOutputTypeMeta("UpdateResult"),
+		LockFile:       lockFile,
+		Updated:        updated,
+	}
+}
+
+// VersionResult is the machine-readable build information of kubectl-package.
+type VersionResult struct {
+	OutputTypeMeta `json:",inline"`
+	// Application version.
+	Version string `json:"version,omitempty"`
+	// Go toolchain version used to build the application.
+	GoVersion string `json:"goVersion,omitempty"`
+	// Package path of the main package.
+	Path string `json:"path,omitempty"`
+	// Main module.
+	Module *VersionModule `json:"module,omitempty"`
+	// Module dependencies.
+	Deps []VersionModule `json:"deps,omitempty"`
+	// Build settings as key value pairs.
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+type VersionModule struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// NewVersionResult creates a VersionResult from the given build info.
+// Embedded build information is only included when embedded is true.
+func NewVersionResult(info version.Info, embedded bool) VersionResult {
+	res := VersionResult{
+		OutputTypeMeta: newOutputTypeMeta("VersionResult"),
+		Version:        info.ApplicationVersion,
+	}
+	if !embedded || info.BuildInfo == nil {
+		return res
+	}
+
+	res.GoVersion = info.GoVersion
+	res.Path = info.Path
+	res.Module = &VersionModule{Path: info.Main.Path, Version: info.Main.Version}
+	for _, dep := range info.Deps {
+		res.Deps = append(res.Deps, VersionModule{Path: dep.Path, Version: dep.Version})
+	}
+	res.Settings = map[string]string{}
+	for _, setting := range info.Settings {
+		res.Settings[setting.Key] = setting.Value
+	}
+
+	return res
+}
@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/disiqueira/gotree"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// RevisionTree is the machine-readable result of rendering
+// the revisions of a Package or ObjectDeployment from the cluster.
+type RevisionTree struct {
+	OutputTypeMeta `json:",inline"`
+	// Package owning the ObjectDeployment, empty when rendering an ObjectDeployment.
+	Package *RevisionTreeNode `json:"package,omitempty"`
+	// ObjectDeployment managing the ObjectSets, empty if not created yet.
+	ObjectDeployment *RevisionTreeNode `json:"objectDeployment,omitempty"`
+	// ObjectSets ordered by revision.
+	ObjectSets []RevisionTreeObjectSet `json:"objectSets"`
+}
+
+type RevisionTreeNode struct {
+	Kind       string             `json:"kind"`
+	Name       string             `json:"name"`
+	Namespace  string             `json:"namespace,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type RevisionTreeObjectSet struct {
+	RevisionTreeNode `json:",inline"`
+	Revision         int64               `json:"revision"`
+	Phases           []RevisionTreePhase `json:"phases"`
+}
+
+type RevisionTreePhase struct {
+	Name string `json:"name"`
+	// State of the phase as reported by the ObjectSet, empty if not reported yet.
+	State   corev1alpha1.ObjectSetRolloutPhaseState `json:"state,omitempty"`
+	Objects []RevisionTreeObject                    `json:"objects"`
+}
+
+type RevisionTreeObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	// Status of the object derived from the state and failed probes of its phase.
+	// One of Available, ProbeFailure, Pending or Failed, empty if unknown.
+	Status string `json:"status,omitempty"`
+	// Message of the failed probe.
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	RevisionTreeObjectStatusAvailable    = "Available"
+	RevisionTreeObjectStatusProbeFailure = "ProbeFailure"
+	RevisionTreeObjectStatusPending      = "Pending"
+	RevisionTreeObjectStatusFailed       = "Failed"
+)
+
+// PackageRevisionTree returns the ObjectDeployment and ObjectSets of the given Package.
+func (c *Client) PackageRevisionTree(ctx context.Context, name string, opts ...GetPackageOption) (*RevisionTree, error) {
+	pkg, err := c.GetPackage(ctx, name, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := newRevisionTree()
+	tree.Package = newRevisionTreeNode(pkg.obj)
+
+	// ObjectDeployments are named after their Package.
+	deploy, err := c.GetObjectDeployment(ctx, pkg.Name(), WithNamespace(pkg.Namespace()))
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return nil, err
+	default:
+		tree.ObjectDeployment = newRevisionTreeNode(deploy.obj)
+	}
+
+	sets, err := pkg.ObjectSets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree.addObjectSets(sets)
+
+	return tree, nil
+}
+
+// ObjectDeploymentRevisionTree returns the ObjectSets of the given ObjectDeployment.
+func (c *Client) ObjectDeploymentRevisionTree(
+	ctx context.Context, name string, opts ...GetObjectDeploymentOption,
+) (*RevisionTree, error) {
+	deploy, err := c.GetObjectDeployment(ctx, name, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := newRevisionTree()
+	tree.ObjectDeployment = newRevisionTreeNode(deploy.obj)
+
+	sets, err := deploy.ObjectSets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree.addObjectSets(sets)
+
+	return tree, nil
+}
+
+func newRevisionTree() *RevisionTree {
+	return &RevisionTree{
+		OutputTypeMeta: newOutputTypeMeta("RevisionTree"),
+		ObjectSets:     []RevisionTreeObjectSet{},
+	}
+}
+
+func newRevisionTreeNode(obj client.Object) *RevisionTreeNode {
+	var (
+		kind       string
+		conditions []metav1.Condition
+	)
+
+	switch o := obj.(type) {
+	case *corev1alpha1.Package:
+		kind, conditions = "Package", o.Status.Conditions
+	case *corev1alpha1.ClusterPackage:
+		kind, conditions = "ClusterPackage", o.Status.Conditions
+	case *corev1alpha1.ObjectDeployment:
+		kind, conditions = "ObjectDeployment", o.Status.Conditions
+	case *corev1alpha1.ClusterObjectDeployment:
+		kind, conditions = "ClusterObjectDeployment", o.Status.Conditions
+	case *corev1alpha1.ObjectSet:
+		kind, conditions = "ObjectSet", o.Status.Conditions
+	case *corev1alpha1.ClusterObjectSet:
+		kind, conditions = "ClusterObjectSet", o.Status.Conditions
+	}
+
+	return &RevisionTreeNode{
+		Kind:       kind,
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		Conditions: conditions,
+	}
+}
+
+func (t *RevisionTree) addObjectSets(sets ObjectSetList) {
+	sets.Sort()
+
+	for _, set := range sets {
+		treeSet := RevisionTreeObjectSet{
+			RevisionTreeNode: *newRevisionTreeNode(set.obj),
+			Revision:         set.Revision(),
+			Phases:           []RevisionTreePhase{},
+		}
+
+		phases, phaseStatuses := set.phases()
+		for _, phase := range phases {
+			treeSet.Phases = append(treeSet.Phases, newRevisionTreePhase(phase, phaseStatuses))
+		}
+
+		t.ObjectSets = append(t.ObjectSets, treeSet)
+	}
+}
+
+func (s *ObjectSet) phases() ([]corev1alpha1.ObjectSetTemplatePhase, []corev1alpha1.ObjectSetRolloutPhase) {
+	if cos, ok := s.obj.(*corev1alpha1.ClusterObjectSet); ok {
+		return cos.Spec.Phases, cos.Status.Phases
+	}
+
+	os := s.obj.(*corev1alpha1.ObjectSet)
+
+	return os.Spec.Phases, os.Status.Phases
+}
+
+func newRevisionTreePhase(
+	phase corev1alpha1.ObjectSetTemplatePhase, statuses []corev1alpha1.ObjectSetRolloutPhase,
+) RevisionTreePhase {
+	var status corev1alpha1.ObjectSetRolloutPhase
+	for _, s := range statuses {
+		if s.Name == phase.Name {
+			status = s
+		}
+	}
+
+	treePhase := RevisionTreePhase{
+		Name:    phase.Name,
+		State:   status.State,
+		Objects: []RevisionTreeObject{},
+	}
+
+	for _, obj := range phase.Objects {
+		treeObj := RevisionTreeObject{
+			APIVersion: obj.Object.GetAPIVersion(),
+			Kind:       obj.Object.GetKind(),
+			Name:       obj.Object.GetName(),
+			Namespace:  obj.Object.GetNamespace(),
+		}
+		treeObj.Status, treeObj.Message = revisionTreeObjectStatus(treeObj, status)
+		treePhase.Objects = append(treePhase.Objects, treeObj)
+	}
+
+	return treePhase
+}
+
+func revisionTreeObjectStatus(
+	obj RevisionTreeObject, phase corev1alpha1.ObjectSetRolloutPhase,
+) (status, message string) {
+	// Failed probes are prefixed with "<group> <kind> <namespace>/<name>: ".
+	gvk := schema.FromAPIVersionAndKind(obj.APIVersion, obj.Kind)
+	prefix := fmt.Sprintf("%s %s %s/%s: ", gvk.Group, gvk.Kind, obj.Namespace, obj.Name)
+	for _, probe := range phase.FailedProbes {
+		if msg, ok := strings.CutPrefix(probe, prefix); ok {
+			return RevisionTreeObjectStatusProbeFailure, msg
+		}
+	}
+
+	switch phase.State {
+	case corev1alpha1.ObjectSetRolloutPhaseStateAvailable,
+		corev1alpha1.ObjectSetRolloutPhaseStateProgressing:
+		return RevisionTreeObjectStatusAvailable, ""
+	case corev1alpha1.ObjectSetRolloutPhaseStatePending:
+		return RevisionTreeObjectStatusPending, ""
+	case corev1alpha1.ObjectSetRolloutPhaseStateFailed:
+		return RevisionTreeObjectStatusFailed, phase.Message
+	}
+
+	return "", ""
+}
+
+// String renders the RevisionTree in human readable form.
+func (t *RevisionTree) String() string {
+	return t.Render(false)
+}
+
+// Render renders the RevisionTree in human readable form,
+// optionally color coding conditions and states for terminals.
+func (t *RevisionTree) Render(color bool) string {
+	r := revisionTreeRenderer{color: color}
+
+	var (
+		root     gotree.Tree
+		setsNode gotree.Tree
+	)
+
+	if t.Package != nil {
+		root = gotree.New(r.node(*t.Package, ""))
+		setsNode = root
+	}
+
+	if t.ObjectDeployment != nil {
+		if root == nil {
+			root = gotree.New(r.node(*t.ObjectDeployment, ""))
+			setsNode = root
+		} else {
+			setsNode = root.Add(r.node(*t.ObjectDeployment, ""))
+		}
+	}
+
+	if root == nil {
+		root = gotree.New("")
+		setsNode = root
+	}
+
+	for _, set := range t.ObjectSets {
+		setNode := setsNode.Add(r.node(set.RevisionTreeNode, fmt.Sprintf(" revision %d", set.Revision)))
+
+		for _, phase := range set.Phases {
+			phaseNode := setNode.Add(strings.TrimSpace(fmt.Sprintf("Phase %s %s", phase.Name, r.state(string(phase.State)))))
+
+			for _, obj := range phase.Objects {
+				key := client.ObjectKey{Name: obj.Name, Namespace: obj.Namespace}
+				gvk := schema.FromAPIVersionAndKind(obj.APIVersion, obj.Kind)
+				line := strings.TrimSpace(fmt.Sprintf("%s %s %s", gvk, key, r.state(obj.Status)))
+				if len(obj.Message) > 0 {
+					line = fmt.Sprintf("%s: %s", line, obj.Message)
+				}
+				phaseNode.Add(line)
+			}
+		}
+	}
+
+	return root.Print()
+}
+
+// ANSI escape sequences used to color code the human readable tree.
+const (
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiReset  = "\x1b[0m"
+)
+
+type revisionTreeRenderer struct {
+	color bool
+}
+
+func (r revisionTreeRenderer) node(n RevisionTreeNode, suffix string) string {
+	key := client.ObjectKey{Name: n.Name, Namespace: n.Namespace}
+	if len(n.Conditions) == 0 {
+		return fmt.Sprintf("%s %s%s", n.Kind, key, suffix)
+	}
+
+	conds := make([]string, 0, len(n.Conditions))
+	for _, c := range n.Conditions {
+		conds = append(conds, r.condition(c))
+	}
+
+	return fmt.Sprintf("%s %s%s [%s]", n.Kind, key, suffix, strings.Join(conds, ", "))
+}
+
+func (r revisionTreeRenderer) condition(c metav1.Condition) string {
+	s := fmt.Sprintf("%s=%s", c.Type, c.Status)
+
+	switch c.Status {
+	case metav1.ConditionTrue:
+		return r.colorize(ansiGreen, s)
+	case metav1.ConditionFalse:
+		return r.colorize(ansiRed, s)
+	default:
+		return r.colorize(ansiYellow, s)
+	}
+}
+
+// Renders the state of phases and objects in parentheses.
+func (r revisionTreeRenderer) state(state string) string {
+	if len(state) == 0 {
+		return ""
+	}
+
+	s := fmt.Sprintf("(%s)", state)
+
+	switch state {
+	case RevisionTreeObjectStatusAvailable:
+		return r.colorize(ansiGreen, s)
+	case RevisionTreeObjectStatusProbeFailure, RevisionTreeObjectStatusFailed:
+		return r.colorize(ansiRed, s)
+	default:
+		return r.colorize(ansiYellow, s)
+	}
+}
+
+func (r revisionTreeRenderer) colorize(color, s string) string {
+	if !r.color {
+		return s
+	}
+
+	return color + s + ansiReset
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestClient_PackageRevisionTree_noObjectDeployment(t *testing.T) {
+	t.Parallel()
+
+	scheme, err := NewScheme()
+	require.NoError(t, err)
+
+	fakeClient := fake.
+		NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1alpha1.ClusterPackage{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		}).
+		Build()
+
+	tree, err := NewClient(fakeClient).PackageRevisionTree(context.Background(), "test")
+	require.NoError(t, err)
+
+	assert.Equal(t, "ClusterPackage", tree.Package.Kind)
+	assert.Nil(t, tree.ObjectDeployment)
+	assert.Empty(t, tree.ObjectSets)
+}
+
+func TestRevisionTree_Render(t *testing.T) {
+	t.Parallel()
+
+	tree := &RevisionTree{
+		ObjectDeployment: &RevisionTreeNode{
+			Kind: "ClusterObjectDeployment",
+			Name: "test",
+			Conditions: []metav1.Condition{
+				{Type: "Available", Status: metav1.ConditionTrue},
+				{Type: "Progressing", Status: metav1.ConditionFalse},
+			},
+		},
+		ObjectSets: []RevisionTreeObjectSet{{
+			RevisionTreeNode: RevisionTreeNode{Kind: "ClusterObjectSet", Name: "test-1"},
+			Revision:         1,
+			Phases: []RevisionTreePhase{{
+				Name:  "deploy",
+				State: corev1alpha1.ObjectSetRolloutPhaseStatePending,
+				Objects: []RevisionTreeObject{{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Name:       "test",
+					Status:     RevisionTreeObjectStatusPending,
+				}},
+			}},
+		}},
+	}
+
+	assert.Equal(t, `ClusterObjectDeployment /test [Available=True, Progressing=False]
+└── ClusterObjectSet /test-1 revision 1
+    └── Phase deploy (Pending)
+        └── /v1, Kind=ConfigMap /test (Pending)
+`, tree.String())
+
+	assert.Equal(t, "ClusterObjectDeployment /test "+
+		"[\x1b[32mAvailable=True\x1b[0m, \x1b[31mProgressing=False\x1b[0m]\n"+
+		"└── ClusterObjectSet /test-1 revision 1\n"+
+		"    └── Phase deploy \x1b[33m(Pending)\x1b[0m\n"+
+		"        └── /v1, Kind=ConfigMap /test \x1b[33m(Pending)\x1b[0m\n", tree.Render(true))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/disiqueira/gotree"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -60,7 +61,18 @@ type TreeOption interface {
 	ConfigureTree(*TreeConfig)
 }
 
+// RenderPackage renders the package at srcPath as human readable tree.
 func (t *Tree) RenderPackage(ctx context.Context, srcPath string, opts ...RenderPackageOption) (string, error) {
+	tree, err := t.RenderPackageTree(ctx, srcPath, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return tree.String(), nil
+}
+
+// RenderPackageTree renders the package at srcPath into a PackageTree.
+func (t *Tree) RenderPackageTree(ctx context.Context, srcPath string, opts ...RenderPackageOption) (*PackageTree, error) {
 	var cfg RenderPackageConfig
 
 	cfg.Option(opts...)
@@ -69,27 +81,27 @@ func (t *Tree) RenderPackage(ctx context.Context, srcPath string, opts ...Render
 
 	files, err := packageimport.Folder(ctx, srcPath)
 	if err != nil {
-		return "", fmt.Errorf("loading package contents from folder: %w", err)
+		return nil, fmt.Errorf("loading package contents from folder: %w", err)
 	}
 
 	pkg, err := packagecontent.PackageFromFiles(ctx, t.scheme, files)
 	if err != nil {
-		return "", fmt.Errorf("parsing package contents: %w", err)
+		return nil, fmt.Errorf("parsing package contents: %w", err)
 	}
 
 	tmplCtx := t.getTemplateContext(pkg, cfg)
 	tmplCfg, err := t.getConfig(pkg, cfg)
 	if err != nil {
-		return "", fmt.Errorf("getting config: %w", err)
+		return nil, fmt.Errorf("getting config: %w", err)
 	}
 
 	validationErrors, err := packageadmission.AdmitPackageConfiguration(
 		ctx, t.scheme, tmplCfg, pkg.PackageManifest, field.NewPath("spec", "config"))
 	if err != nil {
-		return "", fmt.Errorf("validate Package configuration: %w", err)
+		return nil, fmt.Errorf("validate Package configuration: %w", err)
 	}
 	if len(validationErrors) > 0 {
-		return "", validationErrors.ToAggregate()
+		return nil, validationErrors.ToAggregate()
 	}
 
 	tmplCtx.Config = tmplCfg
@@ -105,7 +117,7 @@ func (t *Tree) RenderPackage(ctx context.Context, srcPath string, opts ...Render
 
 	tt, err := packageloader.NewTemplateTransformer(tmplCtx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	l := packageloader.New(t.scheme, packageloader.WithDefaults,
@@ -115,21 +127,22 @@ func (t *Tree) RenderPackage(ctx context.Context, srcPath string, opts ...Render
 
 	packageContent, err := l.FromFiles(ctx, files)
 	if err != nil {
-		return "", fmt.Errorf("parsing package contents: %w", err)
+		return nil, fmt.Errorf("parsing package contents: %w", err)
 	}
 
-	pkgTree := newTreeFromSpec(
-		fmt.Sprintf("%s\n%s %s",
-			packageContent.PackageManifest.Name,
-			pkgPrefix, client.ObjectKey{
-				Name:      tmplCtx.Package.Name,
-				Namespace: tmplCtx.Package.Namespace,
-			},
-		),
-		packagecontent.TemplateSpecFromPackage(packageContent),
-	)
+	templateSpec, err := packagecontent.TemplateSpecFromPackage(packageContent)
+	if err != nil {
+		return nil, fmt.Errorf("building template spec: %w", err)
+	}
 
-	return pkgTree.Print(), nil
+	return newPackageTree(
+		packageContent.PackageManifest.Name,
+		pkgPrefix, client.ObjectKey{
+			Name:      tmplCtx.Package.Name,
+			Namespace: tmplCtx.Package.Namespace,
+		},
+		templateSpec,
+	), nil
 }
 
 func (t *Tree) getTemplateContext(pkg *packagecontent.Package, cfg RenderPackageConfig) packageloader.PackageFileTemplateContext {
@@ -207,30 +220,102 @@ func (t *Tree) getConfig(pkg *packagecontent.Package, cfg RenderPackageConfig) (
 	return config, nil
 }
 
-func newTreeFromSpec(header string, spec v1alpha1.ObjectSetTemplateSpec) gotree.Tree {
-	tree := gotree.New(header)
+// PackageTree is the machine-readable result of rendering a package tree.
+type PackageTree struct {
+	OutputTypeMeta `json:",inline"`
+	// Name of the PackageManifest.
+	PackageName string `json:"packageName"`
+	// Kind of the Package object, either Package or ClusterPackage.
+	PackageKind string `json:"packageKind"`
+	// Name of the Package object.
+	Name string `json:"name"`
+	// Namespace of the Package object.
+	Namespace string             `json:"namespace,omitempty"`
+	Phases    []PackageTreePhase `json:"phases"`
+}
+
+type PackageTreePhase struct {
+	Name    string              `json:"name"`
+	Objects []PackageTreeObject `json:"objects"`
+}
+
+type PackageTreeObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	// True if the object is only observed, but not reconciled by the package.
+	External bool `json:"external,omitempty"`
+}
+
+func newPackageTree(
+	packageName, packageKind string, key client.ObjectKey,
+	spec v1alpha1.ObjectSetTemplateSpec,
+) *PackageTree {
+	tree := &PackageTree{
+		OutputTypeMeta: newOutputTypeMeta("PackageTree"),
+		PackageName:    packageName,
+		PackageKind:    packageKind,
+		Name:           key.Name,
+		Namespace:      key.Namespace,
+		Phases:         []PackageTreePhase{},
+	}
 
 	for _, phase := range spec.Phases {
-		treePhase := tree.Add(fmt.Sprintf("Phase %s", phase.Name))
+		treePhase := PackageTreePhase{
+			Name:    phase.Name,
+			Objects: []PackageTreeObject{},
+		}
 
 		for _, obj := range phase.Objects {
-			treePhase.Add(
-				fmt.Sprintf("%s %s",
-					obj.Object.GroupVersionKind(),
-					client.ObjectKeyFromObject(&obj.Object)))
+			treePhase.Objects = append(treePhase.Objects, newPackageTreeObject(obj, false))
 		}
 
 		for _, obj := range phase.ExternalObjects {
-			treePhase.Add(
-				fmt.Sprintf("%s %s (EXTERNAL)",
-					obj.Object.GroupVersionKind(),
-					client.ObjectKeyFromObject(&obj.Object)))
+			treePhase.Objects = append(treePhase.Objects, newPackageTreeObject(obj, true))
 		}
+
+		tree.Phases = append(tree.Phases, treePhase)
 	}
 
 	return tree
 }
 
+func newPackageTreeObject(obj v1alpha1.ObjectSetObject, external bool) PackageTreeObject {
+	return PackageTreeObject{
+		APIVersion: obj.Object.GetAPIVersion(),
+		Kind:       obj.Object.GetKind(),
+		Name:       obj.Object.GetName(),
+		Namespace:  obj.Object.GetNamespace(),
+		External:   external,
+	}
+}
+
+// String renders the PackageTree in human readable form.
+func (t *PackageTree) String() string {
+	tree := gotree.New(fmt.Sprintf("%s\n%s %s",
+		t.PackageName, t.PackageKind, client.ObjectKey{
+			Name:      t.Name,
+			Namespace: t.Namespace,
+		}))
+
+	for _, phase := range t.Phases {
+		treePhase := tree.Add(fmt.Sprintf("Phase %s", phase.Name))
+
+		for _, obj := range phase.Objects {
+			key := client.ObjectKey{Name: obj.Name, Namespace: obj.Namespace}
+			gvk := schema.FromAPIVersionAndKind(obj.APIVersion, obj.Kind)
+			if obj.External {
+				treePhase.Add(fmt.Sprintf("%s %s (EXTERNAL)", gvk, key))
+				continue
+			}
+			treePhase.Add(fmt.Sprintf("%s %s", gvk, key))
+		}
+	}
+
+	return tree.Print()
+}
+
 type RenderPackageConfig struct {
 	ClusterScope   bool
 	ConfigPath     string
@@ -10,6 +10,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"package-operator.run/package-operator/internal/packages"
 	"package-operator.run/package-operator/internal/packages/packagecontent"
 	"package-operator.run/package-operator/internal/packages/packageimport"
 	"package-operator.run/package-operator/internal/packages/packageloader"
@@ -76,7 +77,7 @@ func (v *Validate) ValidatePackage(ctx context.Context, opts ...ValidatePackageO
 	if cfg.Path != "" {
 		var err error
 
-		filemap, extraOpts, err = getPackageFromPath(ctx, v.scheme, cfg.Path)
+		filemap, extraOpts, err = getPackageFromPath(ctx, v.scheme, cfg.Path, cfg.Component)
 		if err != nil {
 			return fmt.Errorf("getting package from path: %w", err)
 		}
@@ -89,7 +90,13 @@ func (v *Validate) ValidatePackage(ctx context.Context, opts ...ValidatePackageO
 		}
 	}
 
-	extraOpts = append(extraOpts, packageloader.WithDefaults)
+	extraOpts = append(extraOpts,
+		packageloader.WithDefaults,
+		packageloader.WithPackageAndFilesValidators(packageloader.NewTemplateSnapshotValidator(v.scheme)),
+	)
+	if cfg.Strict {
+		extraOpts = append(extraOpts, packageloader.WithStrictManifests)
+	}
 	if _, err := packageloader.New(v.scheme, extraOpts...).FromFiles(ctx, filemap); err != nil {
 		return fmt.Errorf("loading package from files: %w", err)
 	}
@@ -97,11 +104,20 @@ func (v *Validate) ValidatePackage(ctx context.Context, opts ...ValidatePackageO
 	return nil
 }
 
-func getPackageFromPath(ctx context.Context, scheme *runtime.Scheme, path string) (packagecontent.Files, []packageloader.Option, error) {
+func getPackageFromPath(
+	ctx context.Context, scheme *runtime.Scheme, path, component string,
+) (packagecontent.Files, []packageloader.Option, error) {
 	filemap, err := packageimport.Folder(ctx, path)
 	if err != nil {
 		return nil, nil, fmt.Errorf("importing package from folder: %w", err)
 	}
+	filemap, err = packageimport.SelectComponent(filemap, component)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(component) > 0 {
+		path = filepath.Join(path, packages.ComponentsFolder, component)
+	}
 
 	ttv := packageloader.NewTemplateTestValidator(scheme, filepath.Join(path, ".test-fixtures"))
 
@@ -117,6 +133,9 @@ func (v *Validate) getPackageFromRemoteRef(ctx context.Context, cfg ValidatePack
 	pullOpts := []packageimport.PullOption{
 		packageimport.WithInsecure(cfg.Insecure),
 	}
+	if len(cfg.Component) > 0 {
+		pullOpts = append(pullOpts, packageimport.WithComponent(cfg.Component))
+	}
 
 	filemap, err := v.cfg.Puller.Pull(ctx, ref.String(), pullOpts...)
 	if err != nil {
@@ -127,9 +146,12 @@ func (v *Validate) getPackageFromRemoteRef(ctx context.Context, cfg ValidatePack
 }
 
 type ValidatePackageConfig struct {
+	// Component of a multi-component package to validate.
+	Component       string
 	Insecure        bool
 	Path            string
 	RemoteReference string
+	Strict          bool
 }
 
 func (c *ValidatePackageConfig) Option(opts ...ValidatePackageOption) {
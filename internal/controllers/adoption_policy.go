@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// AdoptionPolicyChecker looks up which existing objects phases may take over.
+// A nil *AdoptionPolicyChecker always returns the "Annotated" policy.
+type AdoptionPolicyChecker struct {
+	client client.Reader
+}
+
+func NewAdoptionPolicyChecker(client client.Reader) *AdoptionPolicyChecker {
+	return &AdoptionPolicyChecker{client: client}
+}
+
+// AdoptionPolicy returns the adoption policy configured in the PackageOperatorConfig.
+func (c *AdoptionPolicyChecker) AdoptionPolicy(ctx context.Context) (corev1alpha1.AdoptionPolicy, error) {
+	if c == nil {
+		return corev1alpha1.AdoptionPolicyAnnotated, nil
+	}
+
+	config, err := GetPackageOperatorConfig(ctx, c.client)
+	if err != nil {
+		return "", err
+	}
+	if len(config.Spec.AdoptionPolicy) == 0 {
+		return corev1alpha1.AdoptionPolicyAnnotated, nil
+	}
+	return config.Spec.AdoptionPolicy, nil
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestAdoptionPolicyChecker_AdoptionPolicy(t *testing.T) {
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, client.ObjectKey{Name: "cluster"},
+			mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			config := args.Get(2).(*corev1alpha1.PackageOperatorConfig)
+			config.Spec.AdoptionPolicy = corev1alpha1.AdoptionPolicyUncontrolled
+		}).
+		Return(nil)
+
+	checker := NewAdoptionPolicyChecker(c)
+	policy, err := checker.AdoptionPolicy(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, corev1alpha1.AdoptionPolicyUncontrolled, policy)
+
+	var nilChecker *AdoptionPolicyChecker
+	policy, err = nilChecker.AdoptionPolicy(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, corev1alpha1.AdoptionPolicyAnnotated, policy)
+}
+
+func TestAdoptionPolicyChecker_AdoptionPolicy_noConfig(t *testing.T) {
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+	checker := NewAdoptionPolicyChecker(c)
+	policy, err := checker.AdoptionPolicy(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, corev1alpha1.AdoptionPolicyAnnotated, policy)
+}
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"bytes"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+// Returns the fields last applied to the object by the given field manager,
+// as recorded in the server-side apply managedFields of the object.
+// Returns nil if the object was never applied by the field manager.
+func lastAppliedFields(obj *unstructured.Unstructured, fieldOwner string) *fieldpath.Set {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager != fieldOwner ||
+			entry.Operation != metav1.ManagedFieldsOperationApply ||
+			len(entry.Subresource) > 0 ||
+			entry.FieldsV1 == nil {
+			continue
+		}
+
+		set := &fieldpath.Set{}
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			// Unparseable entries are treated as not present,
+			// we will just apply the object again.
+			return nil
+		}
+		return set
+	}
+	return nil
+}
+
+// Checks if fields previously applied by the given field manager are missing from the patch.
+// Server-side apply removes these fields from the object, when the patch is applied.
+// This three-way comparison catches removed fields, which DeepDerivative does not.
+func hasRemovedAppliedFields(currentObj, patch *unstructured.Unstructured, fieldOwner string) bool {
+	applied := lastAppliedFields(currentObj, fieldOwner)
+	if applied == nil {
+		return false
+	}
+
+	var removed bool
+	applied.Iterate(func(path fieldpath.Path) {
+		if removed || ignoredAppliedFieldPath(path) {
+			return
+		}
+		if !pathExists(patch.Object, path) {
+			removed = true
+		}
+	})
+	return removed
+}
+
+// Fields that are never part of the patch,
+// because they are handled separately.
+func ignoredAppliedFieldPath(path fieldpath.Path) bool {
+	if len(path) == 0 || path[0].FieldName == nil {
+		return false
+	}
+	switch *path[0].FieldName {
+	case "status":
+		return true
+	case "metadata":
+		return len(path) > 1 && path[1].FieldName != nil &&
+			*path[1].FieldName == "ownerReferences"
+	}
+	return false
+}
+
+// Checks if the given field path exists within the object.
+func pathExists(obj interface{}, path fieldpath.Path) bool {
+	current := obj
+	for _, pe := range path {
+		var ok bool
+		switch {
+		case pe.FieldName != nil:
+			m, isMap := current.(map[string]interface{})
+			if !isMap {
+				return false
+			}
+			current, ok = m[*pe.FieldName]
+
+		case pe.Key != nil:
+			current, ok = findListElement(current, func(elem interface{}) bool {
+				return listElementHasKey(elem, *pe.Key)
+			})
+
+		case pe.Value != nil:
+			current, ok = findListElement(current, func(elem interface{}) bool {
+				return value.Equals(value.NewValueInterface(elem), *pe.Value)
+			})
+
+		case pe.Index != nil:
+			l, isList := current.([]interface{})
+			if !isList || *pe.Index >= len(l) {
+				return false
+			}
+			current, ok = l[*pe.Index], true
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func findListElement(list interface{}, match func(elem interface{}) bool) (interface{}, bool) {
+	l, ok := list.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	for _, elem := range l {
+		if match(elem) {
+			return elem, true
+		}
+	}
+	return nil, false
+}
+
+// Checks if a list element matches the key of an associative list.
+// Key fields missing from the element are ignored,
+// because the recorded key includes values defaulted by the API server,
+// e.g. the protocol of container ports.
+func listElementHasKey(elem interface{}, key value.FieldList) bool {
+	m, ok := elem.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, field := range key {
+		v, ok := m[field.Name]
+		if !ok {
+			continue
+		}
+		if !value.Equals(value.NewValueInterface(v), field.Value) {
+			return false
+		}
+	}
+	return true
+}
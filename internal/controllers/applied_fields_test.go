@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_hasRemovedAppliedFields(t *testing.T) {
+	deployment := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{"app": "test"},
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(1),
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name": "test",
+								"args": []interface{}{"--a", "--b"},
+								"ports": []interface{}{
+									map[string]interface{}{"containerPort": int64(8080)},
+								},
+							},
+						},
+						"finalizers": []interface{}{"a", "b"},
+					},
+				},
+			},
+		}}
+	}
+	const appliedFields = `{
+		"f:metadata":{"f:labels":{"f:app":{}},"f:ownerReferences":{"k:{\"uid\":\"123\"}":{}}},
+		"f:spec":{"f:replicas":{},"f:template":{"f:spec":{
+			"f:containers":{"k:{\"name\":\"test\"}":{".":{},
+				"f:args":{},
+				"f:ports":{"k:{\"containerPort\":8080,\"protocol\":\"TCP\"}":{".":{}}}
+			}},
+			"f:finalizers":{"v:\"a\"":{},"v:\"b\"":{}}
+		}}},
+		"f:status":{"f:ready":{}}
+	}`
+
+	tests := []struct {
+		name     string
+		manager  string
+		modify   func(patch *unstructured.Unstructured)
+		expected bool
+	}{
+		{
+			name:     "unchanged",
+			manager:  FieldOwner,
+			modify:   func(patch *unstructured.Unstructured) {},
+			expected: false,
+		},
+		{
+			name:    "field added",
+			manager: FieldOwner,
+			modify: func(patch *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(patch.Object, "test", "spec", "serviceName")
+			},
+			expected: false,
+		},
+		{
+			name:    "field removed",
+			manager: FieldOwner,
+			modify: func(patch *unstructured.Unstructured) {
+				unstructured.RemoveNestedField(patch.Object, "spec", "replicas")
+			},
+			expected: true,
+		},
+		{
+			name:    "label removed",
+			manager: FieldOwner,
+			modify: func(patch *unstructured.Unstructured) {
+				patch.SetLabels(nil)
+			},
+			expected: true,
+		},
+		{
+			name:    "list element removed",
+			manager: FieldOwner,
+			modify: func(patch *unstructured.Unstructured) {
+				_ = unstructured.SetNestedSlice(patch.Object, []interface{}{}, "spec", "template", "spec", "containers")
+			},
+			expected: true,
+		},
+		{
+			name:    "set element removed",
+			manager: FieldOwner,
+			modify: func(patch *unstructured.Unstructured) {
+				_ = unstructured.SetNestedStringSlice(patch.Object, []string{"a"}, "spec", "template", "spec", "finalizers")
+			},
+			expected: true,
+		},
+		{
+			name:    "other manager",
+			manager: "kubectl",
+			modify: func(patch *unstructured.Unstructured) {
+				unstructured.RemoveNestedField(patch.Object, "spec", "replicas")
+			},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			currentObj := deployment()
+			currentObj.SetManagedFields([]metav1.ManagedFieldsEntry{
+				{
+					Manager:    test.manager,
+					Operation:  metav1.ManagedFieldsOperationApply,
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(appliedFields)},
+				},
+			})
+			patch := deployment()
+			test.modify(patch)
+
+			assert.Equal(t, test.expected, hasRemovedAppliedFields(currentObj, patch, FieldOwner))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// ApplyConflictRetention is how long conflicts with other field managers are reported,
+// after they were last observed.
+const ApplyConflictRetention = 10 * time.Minute
+
+// ApplyConflict describes fields of an object, that are managed by another field manager.
+type ApplyConflict struct {
+	// Field manager that changed the fields.
+	Manager string
+	// Paths of the conflicting fields.
+	Fields []string
+}
+
+// ConflictReportingPatcher is optionally implemented by Patchers,
+// to report the fields taken over from other field managers while patching.
+type ConflictReportingPatcher interface {
+	Patcher
+	PatchReportingConflicts(
+		ctx context.Context,
+		desiredObj, currentObj, updatedObj *unstructured.Unstructured,
+	) ([]ApplyConflict, error)
+}
+
+// PhaseObjectConflictRecorder is optionally implemented by PhaseObjectOwners,
+// to record fields taken over from other field managers while applying objects.
+type PhaseObjectConflictRecorder interface {
+	RecordObjectConflict(conflict corev1alpha1.ObjectSetObjectConflict)
+}
+
+// Matches the manager in messages of FieldManagerConflict causes, e.g.:
+// conflict with "kubectl-edit" using apps/v1.
+var conflictManagerRegexp = regexp.MustCompile(`^conflict with ("(?:[^"\\]|\\.)*")`)
+
+// Extracts the conflicts with other field managers
+// from the error of a server-side apply request without ForceOwnership.
+// Returns nil for all other errors.
+func applyConflicts(err error) []ApplyConflict {
+	var apiStatus apierrors.APIStatus
+	if !errors.As(err, &apiStatus) {
+		return nil
+	}
+	status := apiStatus.Status()
+	if status.Reason != metav1.StatusReasonConflict || status.Details == nil {
+		return nil
+	}
+
+	fieldsByManager := map[string][]string{}
+	for _, cause := range status.Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		var manager string
+		if m := conflictManagerRegexp.FindStringSubmatch(cause.Message); m != nil {
+			manager, _ = strconv.Unquote(m[1])
+		}
+		fieldsByManager[manager] = append(
+			fieldsByManager[manager], strings.TrimPrefix(cause.Field, "."))
+	}
+
+	conflicts := make([]ApplyConflict, 0, len(fieldsByManager))
+	for manager, fields := range fieldsByManager {
+		sort.Strings(fields)
+		conflicts = append(conflicts, ApplyConflict{Manager: manager, Fields: fields})
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].Manager < conflicts[j].Manager
+	})
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return conflicts
+}
+
+// RecordObjectConflict adds a conflict to the given list,
+// replacing previous observations for the same object and manager.
+func RecordObjectConflict(
+	conflicts []corev1alpha1.ObjectSetObjectConflict,
+	conflict corev1alpha1.ObjectSetObjectConflict,
+) []corev1alpha1.ObjectSetObjectConflict {
+	for i := range conflicts {
+		if conflicts[i].Object == conflict.Object &&
+			conflicts[i].Manager == conflict.Manager {
+			conflicts[i] = conflict
+			return conflicts
+		}
+	}
+	return append(conflicts, conflict)
+}
+
+// PruneObjectConflicts drops conflicts, that have not been observed within ApplyConflictRetention.
+func PruneObjectConflicts(
+	conflicts []corev1alpha1.ObjectSetObjectConflict, now time.Time,
+) []corev1alpha1.ObjectSetObjectConflict {
+	var retained []corev1alpha1.ObjectSetObjectConflict
+	for _, c := range conflicts {
+		if now.Sub(c.LastObservedTime.Time) < ApplyConflictRetention {
+			retained = append(retained, c)
+		}
+	}
+	return retained
+}
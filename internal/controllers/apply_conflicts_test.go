@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func newApplyConflictError(causes ...metav1.StatusCause) error {
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    409,
+		Reason:  metav1.StatusReasonConflict,
+		Details: &metav1.StatusDetails{Causes: causes},
+	}}
+}
+
+func Test_applyConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected []ApplyConflict
+	}{
+		{name: "no error"},
+		{name: "other error", err: errors.New("boom")},
+		{
+			name: "resourceVersion conflict",
+			err:  newApplyConflictError(),
+		},
+		{
+			name: "field manager conflicts",
+			err: newApplyConflictError(
+				metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kubectl-edit" using apps/v1`,
+					Field:   ".spec.template.spec.containers[name=\"app\"].image",
+				},
+				metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kubectl-edit" using apps/v1`,
+					Field:   ".spec.replicas",
+				},
+				metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "hpa-controller" with subresource "scale"`,
+					Field:   ".spec.replicas",
+				},
+			),
+			expected: []ApplyConflict{
+				{Manager: "hpa-controller", Fields: []string{"spec.replicas"}},
+				{Manager: "kubectl-edit", Fields: []string{
+					"spec.replicas", `spec.template.spec.containers[name="app"].image`,
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, applyConflicts(test.err))
+		})
+	}
+}
+
+func Test_defaultPatcher_patchObject_conflict(t *testing.T) {
+	clientMock := testutil.NewClient()
+	p := &defaultPatcher{writer: clientMock}
+
+	clientMock.
+		On("Patch", mock.Anything, mock.Anything, mock.Anything,
+			[]client.PatchOption{client.FieldOwner(FieldOwner)}).
+		Return(newApplyConflictError(metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl-edit"`,
+			Field:   ".data.key",
+		}))
+	clientMock.
+		On("Patch", mock.Anything, mock.Anything, mock.Anything,
+			[]client.PatchOption{client.FieldOwner(FieldOwner), client.ForceOwnership}).
+		Return(nil)
+
+	desiredObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{"key": "val"},
+	}}
+	currentObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{"key": "something else"},
+	}}
+
+	conflicts, err := p.PatchReportingConflicts(
+		context.Background(), desiredObj, currentObj, currentObj.DeepCopy())
+	require.NoError(t, err)
+	assert.Equal(t, []ApplyConflict{
+		{Manager: "kubectl-edit", Fields: []string{"data.key"}},
+	}, conflicts)
+	clientMock.AssertNumberOfCalls(t, "Patch", 2)
+}
+
+func TestRecordObjectConflict(t *testing.T) {
+	observed := metav1.NewTime(time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC))
+	obj := corev1alpha1.ControlledObjectReference{Kind: "ConfigMap", Name: "test"}
+
+	conflicts := RecordObjectConflict(nil, corev1alpha1.ObjectSetObjectConflict{
+		Object: obj, Manager: "kubectl-edit", Fields: []string{"data.a"},
+	})
+	conflicts = RecordObjectConflict(conflicts, corev1alpha1.ObjectSetObjectConflict{
+		Object: obj, Manager: "kubectl-edit", Fields: []string{"data.b"}, LastObservedTime: observed,
+	})
+	conflicts = RecordObjectConflict(conflicts, corev1alpha1.ObjectSetObjectConflict{
+		Object: obj, Manager: "other", LastObservedTime: metav1.NewTime(observed.Add(-time.Hour)),
+	})
+	if assert.Len(t, conflicts, 2) {
+		assert.Equal(t, []string{"data.b"}, conflicts[0].Fields)
+	}
+
+	retained := PruneObjectConflicts(conflicts, observed.Add(time.Minute))
+	if assert.Len(t, retained, 1) {
+		assert.Equal(t, "kubectl-edit", retained[0].Manager)
+	}
+}
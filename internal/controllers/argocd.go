@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Metadata used by ArgoCD to track resources of an Application and configure their sync.
+const (
+	ArgoCDInstanceLabel            = "app.kubernetes.io/instance"
+	ArgoCDTrackingIDAnnotation     = "argocd.argoproj.io/tracking-id"
+	ArgoCDCompareOptionsAnnotation = "argocd.argoproj.io/compare-options"
+	ArgoCDSyncOptionsAnnotation    = "argocd.argoproj.io/sync-options"
+	// Field manager of the ArgoCD application controller.
+	ArgoCDFieldManager = "argocd-controller"
+)
+
+// Adds tracking metadata of the ArgoCD Application named in the ArgoCDApplicationAnnotation of the owner.
+// Objects are tracked via label and annotation, to support all tracking methods of ArgoCD.
+// They are not part of the sources of the Application,
+// so ArgoCD has to ignore them when comparing and must never prune them.
+func setArgoCDTracking(owner client.Object, obj *unstructured.Unstructured) {
+	application := owner.GetAnnotations()[corev1alpha1.ArgoCDApplicationAnnotation]
+	if len(application) == 0 {
+		return
+	}
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[ArgoCDInstanceLabel] = application
+	obj.SetLabels(labels)
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	gvk := obj.GroupVersionKind()
+	annotations[ArgoCDTrackingIDAnnotation] = fmt.Sprintf("%s:%s/%s:%s/%s",
+		application, gvk.Group, gvk.Kind, obj.GetNamespace(), obj.GetName())
+	annotations[ArgoCDCompareOptionsAnnotation] = "IgnoreExtraneous"
+	annotations[ArgoCDSyncOptionsAnnotation] = "Prune=false"
+	obj.SetAnnotations(annotations)
+}
+
+// ArgoCDConflictError is returned when an object tracked by an ArgoCD Application
+// contains fields managed by ArgoCD, e.g. because the object is also part of the sources of the Application.
+// These fields are not taken over, because ArgoCD would just revert them on its next sync.
+type ArgoCDConflictError struct {
+	ObjectKey client.ObjectKey
+	Fields    []string
+}
+
+func (e ArgoCDConflictError) Error() string {
+	return fmt.Sprintf("%s: fields managed by ArgoCD: %s",
+		e.ObjectKey, strings.Join(e.Fields, ", "))
+}
+
+func (e ArgoCDConflictError) ErrorClass() ErrorClass {
+	return ErrorClassUserFixRequired
+}
+
+// Returns an ArgoCDConflictError, if an object tracked by ArgoCD conflicts with the ArgoCD field manager.
+func argoCDConflict(desiredObj *unstructured.Unstructured, conflicts []ApplyConflict) error {
+	if _, ok := desiredObj.GetAnnotations()[ArgoCDTrackingIDAnnotation]; !ok {
+		return nil
+	}
+	for _, c := range conflicts {
+		if c.Manager == ArgoCDFieldManager {
+			return ArgoCDConflictError{
+				ObjectKey: client.ObjectKeyFromObject(desiredObj),
+				Fields:    c.Fields,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func Test_setArgoCDTracking(t *testing.T) {
+	t.Parallel()
+
+	owner := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{corev1alpha1.ArgoCDApplicationAnnotation: "my-app"},
+		},
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	obj.SetName("test")
+	obj.SetNamespace("test-ns")
+	obj.SetLabels(map[string]string{"app": "test"})
+
+	setArgoCDTracking(owner, obj)
+	assert.Equal(t, map[string]string{
+		"app":               "test",
+		ArgoCDInstanceLabel: "my-app",
+	}, obj.GetLabels())
+	assert.Equal(t, map[string]string{
+		ArgoCDTrackingIDAnnotation:     "my-app:apps/Deployment:test-ns/test",
+		ArgoCDCompareOptionsAnnotation: "IgnoreExtraneous",
+		ArgoCDSyncOptionsAnnotation:    "Prune=false",
+	}, obj.GetAnnotations())
+
+	untracked := &unstructured.Unstructured{}
+	setArgoCDTracking(&corev1alpha1.ObjectSet{}, untracked)
+	assert.Empty(t, untracked.GetLabels())
+	assert.Empty(t, untracked.GetAnnotations())
+}
+
+func Test_defaultPatcher_patchObject_argoCDConflict(t *testing.T) {
+	t.Parallel()
+
+	clientMock := testutil.NewClient()
+	p := &defaultPatcher{writer: clientMock}
+
+	clientMock.
+		On("Patch", mock.Anything, mock.Anything, mock.Anything,
+			[]client.PatchOption{client.FieldOwner(FieldOwner)}).
+		Return(newApplyConflictError(metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "argocd-controller"`,
+			Field:   ".data.key",
+		}))
+
+	desiredObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{"key": "val"},
+	}}
+	desiredObj.SetName("test")
+	desiredObj.SetAnnotations(map[string]string{
+		ArgoCDTrackingIDAnnotation: "my-app:/ConfigMap:/test",
+	})
+	currentObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{"key": "something else"},
+	}}
+
+	_, err := p.PatchReportingConflicts(
+		context.Background(), desiredObj, currentObj, currentObj.DeepCopy())
+	assert.Equal(t, ArgoCDConflictError{
+		ObjectKey: client.ObjectKey{Name: "test"},
+		Fields:    []string{"data.key"},
+	}, err)
+	assert.Equal(t, ErrorClassUserFixRequired, ClassifyError(err))
+	clientMock.AssertNumberOfCalls(t, "Patch", 1)
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+)
+
+// CompactPhases strips all objects of the given phases down to their identity.
+// Archived ObjectSets don't reconcile objects anymore,
+// but newer revisions still need to know which objects they may adopt.
+// Compacted ObjectSets created from a package image are rehydrated
+// from the same image and configuration when they leave the Archived state.
+func CompactPhases(phases []corev1alpha1.ObjectSetTemplatePhase) []corev1alpha1.ObjectSetTemplatePhase {
+	compacted := make([]corev1alpha1.ObjectSetTemplatePhase, len(phases))
+	for i, phase := range phases {
+		compacted[i] = phase
+		compacted[i].Objects = compactObjects(phase.Objects)
+		compacted[i].ExternalObjects = compactObjects(phase.ExternalObjects)
+	}
+	return compacted
+}
+
+func compactObjects(objects []corev1alpha1.ObjectSetObject) []corev1alpha1.ObjectSetObject {
+	if objects == nil {
+		return nil
+	}
+
+	compacted := make([]corev1alpha1.ObjectSetObject, len(objects))
+	for i, obj := range objects {
+		stub := unstructured.Unstructured{}
+		stub.SetGroupVersionKind(obj.Object.GroupVersionKind())
+		stub.SetName(obj.Object.GetName())
+		stub.SetNamespace(obj.Object.GetNamespace())
+		compacted[i] = corev1alpha1.ObjectSetObject{Object: stub}
+	}
+	return compacted
+}
+
+// IsCompacted returns true, if the phase objects of the given ObjectSet have been compacted.
+func IsCompacted(objectSet client.Object) bool {
+	_, ok := objectSet.GetAnnotations()[corev1alpha1.ObjectSetCompactedAnnotation]
+	return ok
+}
+
+// IsRehydratable returns true, if the given ObjectSet was templated from a package image
+// and can be templated again after being compacted.
+func IsRehydratable(objectSet client.Object) bool {
+	return len(objectSet.GetAnnotations()[manifestsv1alpha1.PackageSourceImageAnnotation]) > 0 &&
+		len(objectSet.GetLabels()[manifestsv1alpha1.PackageInstanceLabel]) > 0
+}
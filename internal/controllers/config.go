@@ -3,6 +3,7 @@ package controllers
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/util/flowcontrol"
 )
 
@@ -43,3 +44,31 @@ func (c *BackoffConfig) Default() {
 func (c *BackoffConfig) GetBackoff() *flowcontrol.Backoff {
 	return flowcontrol.NewBackOff(*c.InitialBackoff, *c.MaxBackoff)
 }
+
+// PhaseReconcilerConfig holds replaceable dependencies of a PhaseReconciler.
+// Unset dependencies are defaulted.
+type PhaseReconcilerConfig struct {
+	AdoptionChecker AdoptionChecker
+	// AdoptionPolicy is consulted by the default AdoptionChecker,
+	// before refusing to adopt objects not owned by a previous revision.
+	// Optional, only objects marked for adoption are adopted without it.
+	AdoptionPolicy *AdoptionPolicyChecker
+	Patcher        Patcher
+	// RESTMapper is refreshed when CRDs of a phase become established.
+	// Optional, CRDs are only checked for the Established condition without it.
+	RESTMapper meta.RESTMapper
+	// ObjectMutators run in order on every object before it is applied.
+	ObjectMutators []ObjectMutator
+	// OperatorIdentity namespaces the cache label, revision annotation and field manager.
+	OperatorIdentity OperatorIdentity
+}
+
+func (c *PhaseReconcilerConfig) Option(opts ...PhaseReconcilerOption) {
+	for _, opt := range opts {
+		opt.ConfigurePhaseReconciler(c)
+	}
+}
+
+type PhaseReconcilerOption interface {
+	ConfigurePhaseReconciler(*PhaseReconcilerConfig)
+}
@@ -20,15 +20,35 @@ import (
 
 const (
 	// This label is set on all dynamic objects to limit caches.
+	// Namespaced by the operator identity, see OperatorIdentity.
 	DynamicCacheLabel = "package-operator.run/cache"
+	// Field manager used when applying objects.
+	// Namespaced by the operator identity, see OperatorIdentity.
+	FieldOwner = "package-operator"
 	// Common finalizer to free allocated caches when objects are deleted.
 	CachedFinalizer = "package-operator.run/cached"
 	// Records cause of change for history keeping.
 	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+	// Records the version of the Package Operator manager that created or last patched an object.
+	ManagerVersionAnnotation = "package-operator.run/manager-version"
 	// Causes PKO to skip ownership checks, used during self-bootstrap.
 	ForceAdoptionEnvironmentVariable = "PKO_FORCE_ADOPTION"
 )
 
+// Stores the given manager version in a well-known annotation on the given object.
+// Nothing is recorded for builds without version information.
+func SetManagerVersion(obj metav1.Object, managerVersion string) {
+	if len(managerVersion) == 0 {
+		return
+	}
+	a := obj.GetAnnotations()
+	if a == nil {
+		a = map[string]string{}
+	}
+	a[ManagerVersionAnnotation] = managerVersion
+	obj.SetAnnotations(a)
+}
+
 // Ensures the given finalizer is set and persisted on the given object.
 func EnsureFinalizer(
 	ctx context.Context, c client.Client,
@@ -134,6 +154,28 @@ func GetControllerOf(
 	return controllerOf, nil
 }
 
+// Returns the condition of the given type,
+// if it has been observed for the given generation, otherwise nil.
+// Conditions from an older generation may not reflect the latest spec.
+func FindCurrentStatusCondition(
+	conditions []metav1.Condition, conditionType string, generation int64,
+) *metav1.Condition {
+	cond := meta.FindStatusCondition(conditions, conditionType)
+	if cond == nil || cond.ObservedGeneration != generation {
+		return nil
+	}
+	return cond
+}
+
+// Returns true if the condition of the given type is True
+// and has been observed for the given generation.
+func IsCurrentStatusConditionTrue(
+	conditions []metav1.Condition, conditionType string, generation int64,
+) bool {
+	cond := FindCurrentStatusCondition(conditions, conditionType, generation)
+	return cond != nil && cond.Status == metav1.ConditionTrue
+}
+
 func IsMappedCondition(cond metav1.Condition) bool {
 	return strings.Contains(cond.Type, "/")
 }
@@ -173,8 +215,10 @@ func DeleteMappedConditions(_ context.Context, conditions *[]metav1.Condition) {
 }
 
 // AddDynamicCacheLabel ensures that the given object is labeled
-// for recognition by the dynamic cache.
-func AddDynamicCacheLabel(ctx context.Context, w client.Writer, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+// for recognition by the dynamic cache of the given operator identity.
+func AddDynamicCacheLabel(
+	ctx context.Context, w client.Writer, identity OperatorIdentity, obj *unstructured.Unstructured,
+) (*unstructured.Unstructured, error) {
 	updated := obj.DeepCopy()
 
 	labels := updated.GetLabels()
@@ -182,7 +226,7 @@ func AddDynamicCacheLabel(ctx context.Context, w client.Writer, obj *unstructure
 		labels = map[string]string{}
 	}
 
-	labels[DynamicCacheLabel] = "True" //nolint:goconst
+	labels[identity.DynamicCacheLabel()] = "True" //nolint:goconst
 	updated.SetLabels(labels)
 
 	if err := w.Patch(ctx, updated, client.MergeFrom(obj)); err != nil {
@@ -192,12 +236,14 @@ func AddDynamicCacheLabel(ctx context.Context, w client.Writer, obj *unstructure
 	return updated, nil
 }
 
-func RemoveDynamicCacheLabel(ctx context.Context, w client.Writer, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+func RemoveDynamicCacheLabel(
+	ctx context.Context, w client.Writer, identity OperatorIdentity, obj *unstructured.Unstructured,
+) (*unstructured.Unstructured, error) {
 	updated := obj.DeepCopy()
 
 	labels := updated.GetLabels()
 
-	delete(labels, DynamicCacheLabel)
+	delete(labels, identity.DynamicCacheLabel())
 	updated.SetLabels(labels)
 
 	if err := w.Patch(ctx, updated, client.MergeFrom(obj)); err != nil {
@@ -216,6 +216,19 @@ func TestDeleteMappedConditions(t *testing.T) {
 	}, conditions)
 }
 
+func TestSetManagerVersion(t *testing.T) {
+	t.Parallel()
+
+	object := &unstructured.Unstructured{}
+	SetManagerVersion(object, "")
+	assert.Nil(t, object.GetAnnotations())
+
+	SetManagerVersion(object, "v1.2.3")
+	assert.Equal(t, map[string]string{
+		ManagerVersionAnnotation: "v1.2.3",
+	}, object.GetAnnotations())
+}
+
 func TestAddDynamicCacheLabel(t *testing.T) {
 	t.Parallel()
 
@@ -235,7 +248,7 @@ func TestAddDynamicCacheLabel(t *testing.T) {
 		).
 		Return(nil)
 
-	updated, err := AddDynamicCacheLabel(context.Background(), c, object)
+	updated, err := AddDynamicCacheLabel(context.Background(), c, OperatorIdentity{}, object)
 	require.NoError(t, err)
 
 	assert.Equal(t, expectedLabels, updated.GetLabels())
@@ -266,8 +279,25 @@ func TestRemoveDynamicCacheLabel(t *testing.T) {
 		).
 		Return(nil)
 
-	updated, err := RemoveDynamicCacheLabel(context.Background(), c, object)
+	updated, err := RemoveDynamicCacheLabel(context.Background(), c, OperatorIdentity{}, object)
 	require.NoError(t, err)
 
 	assert.Equal(t, expectedLabels, updated.GetLabels())
 }
+
+func TestFindCurrentStatusCondition(t *testing.T) {
+	conditions := []metav1.Condition{
+		{
+			Type:               corev1alpha1.ObjectSetAvailable,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: 2,
+		},
+	}
+
+	assert.NotNil(t, FindCurrentStatusCondition(conditions, corev1alpha1.ObjectSetAvailable, 2))
+	assert.Nil(t, FindCurrentStatusCondition(conditions, corev1alpha1.ObjectSetAvailable, 3))
+	assert.Nil(t, FindCurrentStatusCondition(conditions, corev1alpha1.ObjectSetPaused, 2))
+
+	assert.True(t, IsCurrentStatusConditionTrue(conditions, corev1alpha1.ObjectSetAvailable, 2))
+	assert.False(t, IsCurrentStatusConditionTrue(conditions, corev1alpha1.ObjectSetAvailable, 3))
+}
@@ -1,12 +1,94 @@
 package controllers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/preflight"
+)
+
+// ErrorClass tells controllers and status writers how to handle a reconcile error.
+type ErrorClass string
+
+const (
+	// Transient errors, e.g. from the apiserver, that may go away when retrying.
+	ErrorClassRetryable ErrorClass = "Retryable"
+	// Errors that will not go away when retrying, e.g. revision collisions or failed hooks.
+	ErrorClassTerminal ErrorClass = "Terminal"
+	// Errors that require the user to change the objects, e.g. preflight violations.
+	ErrorClassUserFixRequired ErrorClass = "UserFixRequired"
 )
 
+// ClassifiedError is implemented by errors that know their ErrorClass.
+type ClassifiedError interface {
+	error
+	ErrorClass() ErrorClass
+}
+
+// ClassifyError returns the ErrorClass of the given error.
+// Errors not known to be permanent are retryable.
+// Returns an empty ErrorClass for nil.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ""
+	}
+
+	var (
+		classifiedErr ClassifiedError
+		preflightErr  *preflight.Error
+	)
+	switch {
+	case errors.As(err, &classifiedErr):
+		return classifiedErr.ErrorClass()
+	case errors.As(err, &preflightErr),
+		apierrors.IsInvalid(err),
+		apierrors.IsBadRequest(err):
+		return ErrorClassUserFixRequired
+	}
+	return ErrorClassRetryable
+}
+
+// ErrorCondition returns reason and message of the condition reporting a non-retryable error.
+func ErrorCondition(err error) (reason, message string) {
+	var preflightErr *preflight.Error
+	if errors.As(err, &preflightErr) {
+		return corev1alpha1.ReasonPreflightViolation, preflightErr.Error()
+	}
+	if ClassifyError(err) == ErrorClassTerminal {
+		return corev1alpha1.ReasonTerminalError, err.Error()
+	}
+	return corev1alpha1.ReasonUserFixRequired, err.Error()
+}
+
+// IsFatalPhaseError returns true for errors that can't be fixed by retrying to reconcile a phase.
+// Failed hooks are excluded, rolling back an atomic phase would delete their Job and run them again.
+func IsFatalPhaseError(err error) bool {
+	var hookFailedErr HookFailedError
+	if errors.As(err, &hookFailedErr) {
+		return false
+	}
+	class := ClassifyError(err)
+	return class == ErrorClassTerminal || class == ErrorClassUserFixRequired
+}
+
+// IsObjectApplyFailure returns true for errors of objects
+// that were rejected by the apiserver or an admission webhook,
+// or could not be applied within the apply timeout.
+func IsObjectApplyFailure(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsInvalid(err) ||
+		apierrors.IsBadRequest(err) ||
+		apierrors.IsForbidden(err) ||
+		apierrors.IsInternalError(err)
+}
+
 func IsExternalResourceNotFound(err error) bool {
 	var ctrlErr ControllerError
 
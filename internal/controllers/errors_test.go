@@ -7,6 +7,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/preflight"
 )
 
 func TestIsExternalResourceNotFound(t *testing.T) {
@@ -49,3 +54,74 @@ func TestPhaseReconcilerErrorInterfaces(t *testing.T) {
 	require.Implements(t, new(error), new(PhaseReconcilerError))
 	require.Implements(t, new(ControllerError), new(PhaseReconcilerError))
 }
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Error  error
+		Class  ErrorClass
+		Reason string
+	}{
+		"nil": {
+			Error: nil,
+			Class: "",
+		},
+		"io error": {
+			Error: io.EOF,
+			Class: ErrorClassRetryable,
+		},
+		"conflict": {
+			Error: apierrors.NewConflict(schema.GroupResource{}, "test", nil),
+			Class: ErrorClassRetryable,
+		},
+		"wrapped preflight error": {
+			Error: fmt.Errorf("wrapped: %w", &preflight.Error{
+				Violations: []preflight.Violation{{Position: "Deployment test", Error: "API not available"}},
+			}),
+			Class:  ErrorClassUserFixRequired,
+			Reason: corev1alpha1.ReasonPreflightViolation,
+		},
+		"invalid": {
+			Error:  apierrors.NewInvalid(schema.GroupKind{}, "test", nil),
+			Class:  ErrorClassUserFixRequired,
+			Reason: corev1alpha1.ReasonUserFixRequired,
+		},
+		"not owned by previous revision": {
+			Error:  ObjectNotOwnedByPreviousRevisionError{},
+			Class:  ErrorClassUserFixRequired,
+			Reason: corev1alpha1.ReasonUserFixRequired,
+		},
+		"wrapped revision collision": {
+			Error:  fmt.Errorf("wrapped: %w", RevisionCollisionError{}),
+			Class:  ErrorClassTerminal,
+			Reason: corev1alpha1.ReasonTerminalError,
+		},
+		"hook failed": {
+			Error:  HookFailedError{},
+			Class:  ErrorClassTerminal,
+			Reason: corev1alpha1.ReasonTerminalError,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Class, ClassifyError(tc.Error))
+			if len(tc.Reason) > 0 {
+				reason, message := ErrorCondition(tc.Error)
+				assert.Equal(t, tc.Reason, reason)
+				assert.NotEmpty(t, message)
+			}
+		})
+	}
+}
+
+func TestIsFatalPhaseError_hookFailed(t *testing.T) {
+	t.Parallel()
+
+	// Rolling back would run the failed hook again.
+	assert.False(t, IsFatalPhaseError(HookFailedError{}))
+	assert.True(t, IsFatalPhaseError(HookNotAJobError{}))
+}
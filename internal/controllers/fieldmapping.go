@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// PhaseFieldMapper is optionally implemented by PhaseObjectOwners,
+// to report fields of objects projected via FieldMappings.
+type PhaseFieldMapper interface {
+	SetStatusMappedField(destination, value string)
+}
+
+// Projects the fields selected by the given mappings from the object into the owner status.
+// Fields missing on the object are not reported.
+func mapFields(
+	owner PhaseObjectOwner,
+	fieldMappings []corev1alpha1.FieldMapping,
+	actualObject *unstructured.Unstructured,
+) error {
+	if len(fieldMappings) == 0 {
+		return nil
+	}
+	mapper, ok := owner.(PhaseFieldMapper)
+	if !ok {
+		return nil
+	}
+
+	for _, m := range fieldMappings {
+		value, found, err := FieldValue(actualObject, m.Source)
+		if err != nil {
+			return fmt.Errorf("mapping field %s: %w", m.Source, err)
+		}
+		if !found {
+			continue
+		}
+		mapper.SetStatusMappedField(m.Destination, value)
+	}
+	return nil
+}
+
+// FieldValue returns the value of the field selected by the given JSONPath expression.
+// Strings are returned as is, all other values are encoded as JSON.
+func FieldValue(obj *unstructured.Unstructured, path string) (value string, found bool, err error) {
+	jpString, err := RelaxedJSONPathExpression(path)
+	if err != nil {
+		return "", false, err
+	}
+
+	jp := jsonpath.New("field")
+	jp.AllowMissingKeys(true)
+	jp.EnableJSONOutput(true)
+	if err := jp.Parse(jpString); err != nil {
+		return "", false, err
+	}
+
+	var buf bytes.Buffer
+	if err := jp.Execute(&buf, obj.Object); err != nil {
+		return "", false, err
+	}
+	var results []interface{}
+	if err := json.Unmarshal(buf.Bytes(), &results); err != nil {
+		return "", false, err
+	}
+
+	var result interface{}
+	switch len(results) {
+	case 0:
+		return "", false, nil
+	case 1:
+		result = results[0]
+	default:
+		result = results
+	}
+	if s, ok := result.(string); ok {
+		return s, true, nil
+	}
+	j, err := json.Marshal(result)
+	if err != nil {
+		return "", false, err
+	}
+	return string(j), true, nil
+}
+
+var jsonRegexp = regexp.MustCompile(`^\{\.?([^{}]+)\}$|^\.?([^{}]+)$`)
+
+// RelaxedJSONPathExpression attempts to be flexible with JSONPath expressions, it accepts:
+//   - metadata.name (no leading '.' or curly braces '{...}'
+//   - {metadata.name} (no leading '.')
+//   - .metadata.name (no curly braces '{...}')
+//   - {.metadata.name} (complete expression)
+//
+// And transforms them all into a valid jsonpath expression:
+//
+//	{.metadata.name}
+//
+//nolint:goerr113
+func RelaxedJSONPathExpression(pathExpression string) (string, error) {
+	if len(pathExpression) == 0 {
+		return pathExpression, nil
+	}
+	submatches := jsonRegexp.FindStringSubmatch(pathExpression)
+	if submatches == nil {
+		return "", fmt.Errorf("unexpected path string, expected a 'name1.name2' or '.name1.name2' or '{name1.name2}' or '{.name1.name2}'")
+	}
+	if len(submatches) != 3 {
+		return "", fmt.Errorf("unexpected submatch list: %v", submatches)
+	}
+	var fieldSpec string
+	if len(submatches[1]) != 0 {
+		fieldSpec = submatches[1]
+	} else {
+		fieldSpec = submatches[2]
+	}
+	return fmt.Sprintf("{.%s}", fieldSpec), nil
+}
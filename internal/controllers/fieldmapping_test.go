@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+type fieldMappingOwnerMock struct {
+	phaseObjectOwnerMock
+	fields map[string]string
+}
+
+func (m *fieldMappingOwnerMock) SetStatusMappedField(destination, value string) {
+	if m.fields == nil {
+		m.fields = map[string]string{}
+	}
+	m.fields[destination] = value
+}
+
+func TestMapFields(t *testing.T) {
+	t.Parallel()
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"endpoint": "db.example.svc:5432",
+				"port":     int64(5432),
+			},
+		},
+	}
+	owner := &fieldMappingOwnerMock{}
+	err := mapFields(owner, []corev1alpha1.FieldMapping{
+		{Source: ".status.endpoint", Destination: "my-prefix/endpoint"},
+		{Source: "{.status.port}", Destination: "my-prefix/port"},
+		{Source: ".status.missing", Destination: "my-prefix/missing"},
+	}, obj)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"my-prefix/endpoint": "db.example.svc:5432",
+		"my-prefix/port":     "5432",
+	}, owner.fields)
+}
+
+func TestFieldValue(t *testing.T) {
+	t.Parallel()
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"ip": "10.0.0.1",
+				"addresses": []interface{}{
+					map[string]interface{}{"ip": "10.0.0.1"},
+					map[string]interface{}{"ip": "10.0.0.2"},
+				},
+				"ready": true,
+			},
+		},
+	}
+
+	tests := []struct {
+		path          string
+		expectedValue string
+		expectedFound bool
+	}{
+		{path: ".status.ip", expectedValue: "10.0.0.1", expectedFound: true},
+		{path: "status.ready", expectedValue: "true", expectedFound: true},
+		{path: ".status.addresses[*].ip", expectedValue: `["10.0.0.1","10.0.0.2"]`, expectedFound: true},
+		{path: ".status.addresses[0]", expectedValue: `{"ip":"10.0.0.1"}`, expectedFound: true},
+		{path: ".status.hostname"},
+		{path: ".spec.replicas"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.path, func(t *testing.T) {
+			t.Parallel()
+
+			value, found, err := FieldValue(obj, test.path)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedFound, found)
+			assert.Equal(t, test.expectedValue, value)
+		})
+	}
+}
+
+func TestFieldValue_invalid(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := FieldValue(&unstructured.Unstructured{}, "{{.status}}")
+	require.Error(t, err)
+}
@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var _ manager.Runnable = (*RemotePhaseHeartbeat)(nil)
+
+const (
+	// Default time after which a remote phase manager is considered unreachable,
+	// if it did not renew its heartbeat Lease.
+	DefaultRemotePhaseHeartbeatTimeout = 60 * time.Second
+	// Objects with remote phases are checked again after this interval,
+	// because expiring heartbeats don't cause any events.
+	RemotePhaseHeartbeatRecheckInterval = 30 * time.Second
+	// Annotation set on the heartbeat Lease while the target cluster
+	// rejects the credentials of the remote phase manager.
+	// Holds the error returned by the target cluster.
+	RemotePhaseHeartbeatUnauthorizedAnnotation = "package-operator.run/unauthorized"
+)
+
+// RemotePhaseHeartbeatLeaseName returns the name of the Lease
+// renewed by remote phase managers of the given ObjectSetPhase class.
+func RemotePhaseHeartbeatLeaseName(class string) string {
+	return "remote-phase-" + class + "-heartbeat"
+}
+
+// RemotePhaseHeartbeatExpired checks whether the given heartbeat Lease
+// has not been renewed within its lease duration.
+// Also returns the time the Lease expires at.
+func RemotePhaseHeartbeatExpired(
+	lease *coordinationv1.Lease, now time.Time,
+) (expired bool, expiresAt time.Time) {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true, now
+	}
+	expiresAt = lease.Spec.RenewTime.Add(
+		time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return !now.Before(expiresAt), expiresAt
+}
+
+// RemotePhaseHeartbeatUnauthorized returns the error reported by the remote phase manager,
+// if the target cluster rejected its credentials.
+func RemotePhaseHeartbeatUnauthorized(lease *coordinationv1.Lease) (msg string, unauthorized bool) {
+	msg, unauthorized = lease.Annotations[RemotePhaseHeartbeatUnauthorizedAnnotation]
+	return
+}
+
+// ConnectivityCheck returns an error, if the target cluster can't be reached.
+// Checks must authenticate against the target cluster,
+// so rejected credentials are reported, e.g. after a failed kubeconfig rotation.
+type ConnectivityCheck func(ctx context.Context) error
+
+// RemotePhaseHeartbeat renews a Lease next to the ObjectSetPhases
+// of a remote phase manager, as long as the target cluster is reachable.
+// Parent ObjectSets report their remote phases as unreachable,
+// when the Lease is not renewed within the heartbeat timeout.
+type RemotePhaseHeartbeat struct {
+	client   client.Client
+	lease    client.ObjectKey
+	identity string
+	check    ConnectivityCheck
+	interval time.Duration
+	timeout  time.Duration
+	clock    clock.PassiveClock
+	// Labels the Lease for the cache of the Package Operator installation.
+	operatorIdentity OperatorIdentity
+}
+
+type RemotePhaseHeartbeatOption func(h *RemotePhaseHeartbeat)
+
+// WithHeartbeatTimeout sets the time after which the remote phase manager
+// is considered unreachable. Heartbeats are sent every quarter of the timeout.
+func WithHeartbeatTimeout(timeout time.Duration) RemotePhaseHeartbeatOption {
+	return func(h *RemotePhaseHeartbeat) {
+		h.timeout = timeout
+	}
+}
+
+// WithHeartbeatOperatorIdentity labels the Lease for the cache
+// of the Package Operator installation with the given identity.
+func WithHeartbeatOperatorIdentity(identity OperatorIdentity) RemotePhaseHeartbeatOption {
+	return func(h *RemotePhaseHeartbeat) {
+		h.operatorIdentity = identity
+	}
+}
+
+func NewRemotePhaseHeartbeat(
+	c client.Client, namespace, class, identity string,
+	check ConnectivityCheck, opts ...RemotePhaseHeartbeatOption,
+) *RemotePhaseHeartbeat {
+	h := &RemotePhaseHeartbeat{
+		client: c,
+		lease: client.ObjectKey{
+			Name:      RemotePhaseHeartbeatLeaseName(class),
+			Namespace: namespace,
+		},
+		identity: identity,
+		check:    check,
+		timeout:  DefaultRemotePhaseHeartbeatTimeout,
+		clock:    clock.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.interval = h.timeout / 4
+	return h
+}
+
+// Start sends heartbeats until the context is cancelled.
+func (h *RemotePhaseHeartbeat) Start(ctx context.Context) error {
+	log := logr.FromContextOrDiscard(ctx).WithValues("lease", h.lease)
+
+	t := time.NewTicker(h.interval)
+	defer t.Stop()
+	for {
+		if err := h.beat(ctx); err != nil {
+			log.Error(err, "sending heartbeat")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+// beat renews the heartbeat Lease, if the target cluster is reachable.
+// When the target cluster rejects the credentials of the remote phase manager,
+// the Lease is still renewed, but annotated with the error,
+// so parents can tell broken credentials from an unreachable cluster.
+func (h *RemotePhaseHeartbeat) beat(ctx context.Context) error {
+	checkErr := h.check(ctx)
+	if checkErr != nil && !errors.IsUnauthorized(checkErr) {
+		return fmt.Errorf("target cluster unreachable: %w", checkErr)
+	}
+
+	now := metav1.NewMicroTime(h.clock.Now())
+	leaseDurationSeconds := int32(h.timeout.Seconds())
+
+	lease := &coordinationv1.Lease{}
+	err := h.client.Get(ctx, h.lease, lease)
+	if errors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      h.lease.Name,
+				Namespace: h.lease.Namespace,
+				Labels: map[string]string{
+					h.operatorIdentity.DynamicCacheLabel(): "True",
+				},
+				Annotations: unauthorizedAnnotations(nil, checkErr),
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &h.identity,
+				LeaseDurationSeconds: &leaseDurationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		if err := h.client.Create(ctx, lease); err != nil {
+			return fmt.Errorf("creating heartbeat Lease: %w", err)
+		}
+		return unauthorizedError(checkErr)
+	}
+	if err != nil {
+		return fmt.Errorf("getting heartbeat Lease: %w", err)
+	}
+
+	lease.Spec.HolderIdentity = &h.identity
+	lease.Spec.LeaseDurationSeconds = &leaseDurationSeconds
+	lease.Spec.RenewTime = &now
+	lease.Annotations = unauthorizedAnnotations(lease.Annotations, checkErr)
+	if err := h.client.Update(ctx, lease); err != nil {
+		return fmt.Errorf("renewing heartbeat Lease: %w", err)
+	}
+	return unauthorizedError(checkErr)
+}
+
+// Sets or removes the unauthorized annotation, depending on the result of the connectivity check.
+func unauthorizedAnnotations(annotations map[string]string, checkErr error) map[string]string {
+	if checkErr == nil {
+		delete(annotations, RemotePhaseHeartbeatUnauthorizedAnnotation)
+		return annotations
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[RemotePhaseHeartbeatUnauthorizedAnnotation] = checkErr.Error()
+	return annotations
+}
+
+func unauthorizedError(checkErr error) error {
+	if checkErr == nil {
+		return nil
+	}
+	return fmt.Errorf("target cluster rejected credentials: %w", checkErr)
+}
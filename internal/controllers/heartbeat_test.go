@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRemotePhaseHeartbeat(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+	clock := clocktesting.NewFakePassiveClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	var checkErr error
+	h := NewRemotePhaseHeartbeat(
+		c, "test-ns", "hosted-cluster", "test-pod",
+		func(context.Context) error { return checkErr },
+		WithHeartbeatTimeout(2*time.Minute))
+	h.clock = clock
+	assert.Equal(t, 30*time.Second, h.interval)
+
+	ctx := context.Background()
+	key := client.ObjectKey{Name: "remote-phase-hosted-cluster-heartbeat", Namespace: "test-ns"}
+
+	// creates Lease
+	require.NoError(t, h.beat(ctx))
+	lease := &coordinationv1.Lease{}
+	require.NoError(t, c.Get(ctx, key, lease))
+	assert.Equal(t, "True", lease.Labels[DynamicCacheLabel])
+	assert.Equal(t, "test-pod", *lease.Spec.HolderIdentity)
+	assert.Equal(t, int32(120), *lease.Spec.LeaseDurationSeconds)
+	assert.True(t, lease.Spec.RenewTime.Time.Equal(clock.Now()))
+
+	// renews Lease
+	clock.SetTime(clock.Now().Add(time.Minute))
+	require.NoError(t, h.beat(ctx))
+	require.NoError(t, c.Get(ctx, key, lease))
+	assert.True(t, lease.Spec.RenewTime.Time.Equal(clock.Now()))
+
+	// target cluster unreachable
+	checkErr = errors.New("connection refused")
+	clock.SetTime(clock.Now().Add(time.Minute))
+	require.EqualError(t, h.beat(ctx), "target cluster unreachable: connection refused")
+	require.NoError(t, c.Get(ctx, key, lease))
+	assert.True(t, lease.Spec.RenewTime.Time.Equal(clock.Now().Add(-time.Minute)))
+
+	// credentials rejected by target cluster
+	checkErr = apierrors.NewUnauthorized("token expired")
+	require.EqualError(t, h.beat(ctx), "target cluster rejected credentials: token expired")
+	require.NoError(t, c.Get(ctx, key, lease))
+	assert.True(t, lease.Spec.RenewTime.Time.Equal(clock.Now()))
+	msg, unauthorized := RemotePhaseHeartbeatUnauthorized(lease)
+	assert.True(t, unauthorized)
+	assert.Equal(t, "token expired", msg)
+
+	// credentials rotated
+	checkErr = nil
+	clock.SetTime(clock.Now().Add(time.Minute))
+	require.NoError(t, h.beat(ctx))
+	require.NoError(t, c.Get(ctx, key, lease))
+	_, unauthorized = RemotePhaseHeartbeatUnauthorized(lease)
+	assert.False(t, unauthorized)
+}
+
+func TestRemotePhaseHeartbeatExpired(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	renewTime := metav1.NewMicroTime(now.Add(-time.Minute))
+
+	tests := []struct {
+		name              string
+		spec              coordinationv1.LeaseSpec
+		expectedExpired   bool
+		expectedExpiresAt time.Time
+	}{
+		{
+			name: "not renewed",
+			spec: coordinationv1.LeaseSpec{
+				LeaseDurationSeconds: pointer.Int32(60),
+			},
+			expectedExpired:   true,
+			expectedExpiresAt: now,
+		},
+		{
+			name: "expired",
+			spec: coordinationv1.LeaseSpec{
+				RenewTime:            &renewTime,
+				LeaseDurationSeconds: pointer.Int32(30),
+			},
+			expectedExpired:   true,
+			expectedExpiresAt: now.Add(-30 * time.Second),
+		},
+		{
+			name: "valid",
+			spec: coordinationv1.LeaseSpec{
+				RenewTime:            &renewTime,
+				LeaseDurationSeconds: pointer.Int32(90),
+			},
+			expectedExpiresAt: now.Add(30 * time.Second),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			expired, expiresAt := RemotePhaseHeartbeatExpired(
+				&coordinationv1.Lease{Spec: test.spec}, now)
+			assert.Equal(t, test.expectedExpired, expired)
+			assert.True(t, test.expectedExpiresAt.Equal(expiresAt))
+		})
+	}
+}
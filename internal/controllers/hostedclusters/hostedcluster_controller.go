@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/controllers/hostedclusters/hypershift/v1beta1"
 	"package-operator.run/package-operator/internal/ownerhandling"
 )
@@ -26,6 +27,8 @@ type HostedClusterController struct {
 	scheme                  *runtime.Scheme
 	remotePhasePackageImage string
 	ownerStrategy           ownerStrategy
+	// Labels remote phase Packages to be reconciled by this installation.
+	identity controllers.OperatorIdentity
 }
 
 type ownerStrategy interface {
@@ -52,6 +55,12 @@ func NewHostedClusterController(
 	return controller
 }
 
+// SetOperatorIdentity labels remote phase Packages with the given identity,
+// so they are reconciled by the same Package Operator installation.
+func (c *HostedClusterController) SetOperatorIdentity(identity controllers.OperatorIdentity) {
+	c.identity = identity
+}
+
 func (c *HostedClusterController) Reconcile(
 	ctx context.Context, req ctrl.Request,
 ) (ctrl.Result, error) {
@@ -107,6 +116,7 @@ func (c *HostedClusterController) desiredPackage(cluster *v1beta1.HostedCluster)
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "remote-phase",
 			Namespace: hostedClusterNamespace(cluster),
+			Labels:    c.identity.Labels(),
 		},
 		Spec: corev1alpha1.PackageSpec{
 			Image: c.remotePhasePackageImage,
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// OperatorIdentityLabel assigns Package Operator objects to the installation
+// started with the same --operator-identity.
+// Objects without this label are reconciled by the installation without identity.
+// Packages, ObjectDeployments and ObjectSets propagate this label to the objects they create.
+const OperatorIdentityLabel = "package-operator.run/operator-identity"
+
+// OperatorIdentity tells multiple Package Operator installations on the same cluster apart.
+// Each installation only reconciles objects labeled with its identity
+// and namespaces the labels, annotations and field manager used on managed objects,
+// so installations don't cache or adopt each other's objects.
+// The zero value is the identity of an installation without --operator-identity.
+type OperatorIdentity struct {
+	Name string
+}
+
+// Validate returns an error if the name is not a DNS-1123 label.
+func (i OperatorIdentity) Validate() error {
+	if len(i.Name) == 0 {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(i.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid operator identity %q: %s", i.Name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// Owns returns true if the given Package Operator object is reconciled by this installation.
+func (i OperatorIdentity) Owns(obj client.Object) bool {
+	return obj.GetLabels()[OperatorIdentityLabel] == i.Name
+}
+
+// Predicate filters events for objects not owned by this installation.
+func (i OperatorIdentity) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(i.Owns)
+}
+
+// Labels returns the labels to set on Package Operator objects created by this installation,
+// so they are reconciled by the same installation.
+func (i OperatorIdentity) Labels() map[string]string {
+	if len(i.Name) == 0 {
+		return nil
+	}
+	return map[string]string{OperatorIdentityLabel: i.Name}
+}
+
+// DynamicCacheLabel returns the label set on all dynamic objects to limit caches.
+func (i OperatorIdentity) DynamicCacheLabel() string {
+	if len(i.Name) == 0 {
+		return DynamicCacheLabel
+	}
+	return i.Name + "." + DynamicCacheLabel
+}
+
+// RevisionAnnotation returns the annotation holding the revision of the owner of an object.
+func (i OperatorIdentity) RevisionAnnotation() string {
+	if len(i.Name) == 0 {
+		return revisionAnnotation
+	}
+	return i.Name + "." + revisionAnnotation
+}
+
+// FieldOwner returns the field manager used when applying objects.
+func (i OperatorIdentity) FieldOwner() string {
+	if len(i.Name) == 0 {
+		return FieldOwner
+	}
+	return FieldOwner + "-" + i.Name
+}
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestOperatorIdentity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		identity := OperatorIdentity{}
+		require.NoError(t, identity.Validate())
+		assert.Equal(t, "package-operator.run/cache", identity.DynamicCacheLabel())
+		assert.Equal(t, "package-operator.run/revision", identity.RevisionAnnotation())
+		assert.Equal(t, "package-operator", identity.FieldOwner())
+		assert.Nil(t, identity.Labels())
+	})
+
+	t.Run("named", func(t *testing.T) {
+		t.Parallel()
+
+		identity := OperatorIdentity{Name: "tenant-a"}
+		require.NoError(t, identity.Validate())
+		assert.Equal(t, "tenant-a.package-operator.run/cache", identity.DynamicCacheLabel())
+		assert.Equal(t, "tenant-a.package-operator.run/revision", identity.RevisionAnnotation())
+		assert.Equal(t, "package-operator-tenant-a", identity.FieldOwner())
+		assert.Equal(t, map[string]string{OperatorIdentityLabel: "tenant-a"}, identity.Labels())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		require.Error(t, OperatorIdentity{Name: "Not_A_Label"}.Validate())
+	})
+}
+
+func TestOperatorIdentity_Owns(t *testing.T) {
+	t.Parallel()
+
+	newObjectSet := func(labels map[string]string) *corev1alpha1.ObjectSet {
+		return &corev1alpha1.ObjectSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "test", Namespace: "test", Labels: labels,
+		}}
+	}
+	unlabeled := newObjectSet(nil)
+	tenantA := newObjectSet(map[string]string{OperatorIdentityLabel: "tenant-a"})
+	tenantB := newObjectSet(map[string]string{OperatorIdentityLabel: "tenant-b"})
+
+	// Unlabeled objects belong to the installation without identity.
+	assert.True(t, OperatorIdentity{}.Owns(unlabeled))
+	assert.False(t, OperatorIdentity{}.Owns(tenantA))
+
+	identity := OperatorIdentity{Name: "tenant-a"}
+	assert.False(t, identity.Owns(unlabeled))
+	assert.True(t, identity.Owns(tenantA))
+	assert.False(t, identity.Owns(tenantB))
+}
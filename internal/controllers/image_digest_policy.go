@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ImageDigestPolicyChecker determines whether the PackageOperatorConfig
+// requires package images to be referenced by digest.
+// A nil *ImageDigestPolicyChecker never requires digests.
+type ImageDigestPolicyChecker struct {
+	client client.Reader
+}
+
+func NewImageDigestPolicyChecker(client client.Reader) *ImageDigestPolicyChecker {
+	return &ImageDigestPolicyChecker{client: client}
+}
+
+// IsDigestRequired returns true when package images have to be referenced by digest.
+func (c *ImageDigestPolicyChecker) IsDigestRequired(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	config, err := GetPackageOperatorConfig(ctx, c.client)
+	if err != nil {
+		return false, err
+	}
+	return config.Spec.RequireImageDigests, nil
+}
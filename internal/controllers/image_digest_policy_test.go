@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestImageDigestPolicyChecker_IsDigestRequired(t *testing.T) {
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, client.ObjectKey{Name: "cluster"},
+			mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			config := args.Get(2).(*corev1alpha1.PackageOperatorConfig)
+			config.Spec.RequireImageDigests = true
+		}).
+		Return(nil)
+
+	checker := NewImageDigestPolicyChecker(c)
+	required, err := checker.IsDigestRequired(context.Background())
+	require.NoError(t, err)
+	assert.True(t, required)
+
+	var nilChecker *ImageDigestPolicyChecker
+	required, err = nilChecker.IsDigestRequired(context.Background())
+	require.NoError(t, err)
+	assert.False(t, required)
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+)
+
+// Objects in maintenance mode are checked again after this interval,
+// because changes to the PackageOperatorConfig are not watched.
+const MaintenanceModeRequeueInterval = 30 * time.Second
+
+// MaintenanceModeChecker determines whether Package Operator
+// must refrain from changing objects on behalf of an object.
+// A nil *MaintenanceModeChecker never reports maintenance mode.
+type MaintenanceModeChecker struct {
+	client client.Reader
+}
+
+func NewMaintenanceModeChecker(client client.Reader) *MaintenanceModeChecker {
+	return &MaintenanceModeChecker{client: client}
+}
+
+// IsInMaintenance returns true when the given object is in maintenance mode.
+// The maintenance mode annotation on the Package owning the object
+// takes precedence over the global PackageOperatorConfig.
+func (c *MaintenanceModeChecker) IsInMaintenance(
+	ctx context.Context, obj client.Object,
+) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	override, ok, err := c.packageOverride(ctx, obj)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		return override, nil
+	}
+
+	config, err := GetPackageOperatorConfig(ctx, c.client)
+	if err != nil {
+		return false, err
+	}
+	return config.Spec.MaintenanceMode, nil
+}
+
+// GetPackageOperatorConfig returns the PackageOperatorConfig honored by Package Operator
+// or an empty config, if it does not exist or is not readable.
+func GetPackageOperatorConfig(
+	ctx context.Context, c client.Reader,
+) (*corev1alpha1.PackageOperatorConfig, error) {
+	config := &corev1alpha1.PackageOperatorConfig{}
+	err := c.Get(ctx, client.ObjectKey{
+		Name: corev1alpha1.PackageOperatorConfigName,
+	}, config)
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return &corev1alpha1.PackageOperatorConfig{}, nil
+	}
+	if errors.IsForbidden(err) {
+		// The cluster-scoped config is not readable when running namespace-scoped.
+		return &corev1alpha1.PackageOperatorConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting PackageOperatorConfig: %w", err)
+	}
+	return config, nil
+}
+
+// Looks up the maintenance mode annotation of the Package
+// that the given object is or belongs to.
+func (c *MaintenanceModeChecker) packageOverride(
+	ctx context.Context, obj client.Object,
+) (override, ok bool, err error) {
+	var pkg client.Object
+	switch obj.(type) {
+	case *corev1alpha1.Package, *corev1alpha1.ClusterPackage:
+		pkg = obj
+
+	default:
+		instance := obj.GetLabels()[manifestsv1alpha1.PackageInstanceLabel]
+		if len(instance) == 0 {
+			return false, false, nil
+		}
+
+		if len(obj.GetNamespace()) > 0 {
+			pkg = &corev1alpha1.Package{}
+		} else {
+			pkg = &corev1alpha1.ClusterPackage{}
+		}
+		err := c.client.Get(ctx, client.ObjectKey{
+			Name:      instance,
+			Namespace: obj.GetNamespace(),
+		}, pkg)
+		if errors.IsNotFound(err) {
+			return false, false, nil
+		}
+		if err != nil {
+			return false, false, fmt.Errorf("getting Package: %w", err)
+		}
+	}
+
+	v, found := pkg.GetAnnotations()[corev1alpha1.MaintenanceModeAnnotation]
+	if !found {
+		return false, false, nil
+	}
+	override, err = strconv.ParseBool(v)
+	if err != nil {
+		// Ignore invalid values.
+		return false, false, nil //nolint:nilerr
+	}
+	return override, true, nil
+}
@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestMaintenanceModeChecker_IsInMaintenance(t *testing.T) {
+	tests := []struct {
+		name               string
+		globalMaintenance  bool
+		packageAnnotations map[string]string
+		expected           bool
+	}{
+		{
+			name: "not in maintenance",
+		},
+		{
+			name:              "global maintenance",
+			globalMaintenance: true,
+			expected:          true,
+		},
+		{
+			name: "package maintenance",
+			packageAnnotations: map[string]string{
+				corev1alpha1.MaintenanceModeAnnotation: "true",
+			},
+			expected: true,
+		},
+		{
+			name:              "package exempt from global maintenance",
+			globalMaintenance: true,
+			packageAnnotations: map[string]string{
+				corev1alpha1.MaintenanceModeAnnotation: "false",
+			},
+		},
+		{
+			name:              "invalid package override",
+			globalMaintenance: true,
+			packageAnnotations: map[string]string{
+				corev1alpha1.MaintenanceModeAnnotation: "banana",
+			},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			c := testutil.NewClient()
+			c.
+				On("Get", mock.Anything, client.ObjectKey{Name: "cluster"},
+					mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					config := args.Get(2).(*corev1alpha1.PackageOperatorConfig)
+					config.Spec.MaintenanceMode = test.globalMaintenance
+				}).
+				Return(nil)
+			c.
+				On("Get", mock.Anything, client.ObjectKey{Name: "my-pkg", Namespace: "test"},
+					mock.AnythingOfType("*v1alpha1.Package"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					pkg := args.Get(2).(*corev1alpha1.Package)
+					pkg.Annotations = test.packageAnnotations
+				}).
+				Return(nil)
+
+			objectSet := &corev1alpha1.ObjectSet{}
+			objectSet.Namespace = "test"
+			objectSet.Labels = map[string]string{
+				manifestsv1alpha1.PackageInstanceLabel: "my-pkg",
+			}
+
+			checker := NewMaintenanceModeChecker(c)
+			inMaintenance, err := checker.IsInMaintenance(context.Background(), objectSet)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, inMaintenance)
+		})
+	}
+}
+
+func TestMaintenanceModeChecker_IsInMaintenance_package(t *testing.T) {
+	c := testutil.NewClient()
+	pkg := &corev1alpha1.ClusterPackage{}
+	pkg.Annotations = map[string]string{
+		corev1alpha1.MaintenanceModeAnnotation: "true",
+	}
+
+	checker := NewMaintenanceModeChecker(c)
+	inMaintenance, err := checker.IsInMaintenance(context.Background(), pkg)
+	require.NoError(t, err)
+	assert.True(t, inMaintenance)
+	c.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMaintenanceModeChecker_IsInMaintenance_noConfig(t *testing.T) {
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+	checker := NewMaintenanceModeChecker(c)
+	inMaintenance, err := checker.IsInMaintenance(
+		context.Background(), &corev1alpha1.ObjectSet{})
+	require.NoError(t, err)
+	assert.False(t, inMaintenance)
+
+	var nilChecker *MaintenanceModeChecker
+	inMaintenance, err = nilChecker.IsInMaintenance(
+		context.Background(), &corev1alpha1.ObjectSet{})
+	require.NoError(t, err)
+	assert.False(t, inMaintenance)
+}
+
+func TestMaintenanceModeChecker_IsInMaintenance_configForbidden(t *testing.T) {
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewForbidden(schema.GroupResource{}, "", nil))
+
+	checker := NewMaintenanceModeChecker(c)
+	inMaintenance, err := checker.IsInMaintenance(
+		context.Background(), &corev1alpha1.ObjectSet{})
+	require.NoError(t, err)
+	assert.False(t, inMaintenance)
+}
@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/dynamiccache"
 	"package-operator.run/package-operator/internal/testutil"
 	"package-operator.run/package-operator/internal/testutil/ownerhandlingmocks"
 )
@@ -60,11 +61,19 @@ type dynamicCacheMock struct {
 
 func (c *dynamicCacheMock) Watch(
 	ctx context.Context, owner client.Object, obj runtime.Object,
+	_ ...dynamiccache.WatchOption,
 ) error {
 	args := c.Called(ctx, owner, obj)
 	return args.Error(0)
 }
 
+func (c *dynamicCacheMock) ListByIndex(
+	ctx context.Context, field, value string,
+) ([]unstructured.Unstructured, error) {
+	args := c.Called(ctx, field, value)
+	return args.Get(0).([]unstructured.Unstructured), args.Error(1)
+}
+
 type adoptionCheckerMock struct {
 	mock.Mock
 }
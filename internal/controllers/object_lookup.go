@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"package-operator.run/package-operator/internal/preflight"
+	"package-operator.run/package-operator/internal/transform"
+)
+
+type lookupChecker interface {
+	Check(ctx context.Context, owner, obj client.Object) ([]preflight.Violation, error)
+}
+
+// ObjectLookup reads objects of the cluster on behalf of an owner,
+// e.g. for the lookup template function.
+// Objects are read through the dynamic cache and watched for the owner,
+// so the owner is reconciled again when they change.
+// The owner gets the CachedFinalizer, to free the cache when it is deleted.
+type ObjectLookup struct {
+	client         client.Client
+	uncachedClient client.Reader
+	dynamicCache   PhaseCache
+	checker        lookupChecker
+	identity       OperatorIdentity
+}
+
+// NewObjectLookup returns an ObjectLookup,
+// preventing namespaced owners from reading objects outside of their namespace.
+// Looked up objects are labeled for the dynamic cache of the given operator identity.
+func NewObjectLookup(
+	c client.Client, uncachedClient client.Reader,
+	dynamicCache PhaseCache, restMapper meta.RESTMapper,
+	identity OperatorIdentity,
+) *ObjectLookup {
+	return &ObjectLookup{
+		client:         c,
+		uncachedClient: uncachedClient,
+		dynamicCache:   dynamicCache,
+		checker: preflight.List{
+			preflight.NewEmptyNamespaceNoDefault(restMapper),
+			preflight.NewNamespaceEscalation(restMapper),
+		},
+		identity: identity,
+	}
+}
+
+// Lookup reads the object with the GroupVersionKind, name and namespace of the given object into it.
+// The namespace defaults to the namespace of the owner.
+// Returns false, if the object or its API does not exist.
+func (l *ObjectLookup) Lookup(
+	ctx context.Context, owner client.Object, obj *unstructured.Unstructured,
+) (found bool, err error) {
+	if len(obj.GetNamespace()) == 0 {
+		obj.SetNamespace(owner.GetNamespace())
+	}
+
+	violations, err := l.checker.Check(ctx, owner, obj)
+	if err != nil {
+		return false, err
+	}
+	if len(violations) > 0 {
+		return false, &preflight.Error{Violations: violations}
+	}
+
+	if err := EnsureCachedFinalizer(ctx, l.client, owner); err != nil {
+		return false, err
+	}
+	if err := l.dynamicCache.Watch(ctx, owner, obj); meta.IsNoMatchError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("watching looked up object: %w", err)
+	}
+
+	key := client.ObjectKeyFromObject(obj)
+	err = l.dynamicCache.Get(ctx, key, obj)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.IsNotFound(err) {
+		return false, fmt.Errorf("getting %s %s: %w", obj.GetKind(), key, err)
+	}
+
+	// The object might not be labeled for the cache to pick up,
+	// fallback to an uncached read to discover.
+	if err := l.uncachedClient.Get(ctx, key, obj); errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting %s %s from uncachedClient: %w", obj.GetKind(), key, err)
+	}
+
+	// Label object to ensure it is part of our cache and we get events to reconcile.
+	updatedObj, err := AddDynamicCacheLabel(ctx, l.client, l.identity, obj)
+	if err != nil {
+		return false, fmt.Errorf("patching looked up object for cache: %w", err)
+	}
+	obj.Object = updatedObj.Object
+	return true, nil
+}
+
+// LookupFunc returns a transform.LookupFunc reading objects on behalf of the given owner.
+func (l *ObjectLookup) LookupFunc(ctx context.Context, owner client.Object) transform.LookupFunc {
+	return func(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(apiVersion)
+		obj.SetKind(kind)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+
+		found, err := l.Lookup(ctx, owner, obj)
+		if err != nil {
+			return nil, fmt.Errorf("lookup %s %s: %w", kind, name, err)
+		}
+		if !found {
+			return map[string]interface{}{}, nil
+		}
+		return obj.Object, nil
+	}
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"package-operator.run/package-operator/internal/preflight"
+	"package-operator.run/package-operator/internal/testutil"
+	"package-operator.run/package-operator/internal/testutil/dynamiccachemocks"
+)
+
+func TestObjectLookup(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	uncachedClient := testutil.NewClient()
+	dc := &dynamiccachemocks.DynamicCacheMock{}
+	l := &ObjectLookup{
+		client:         c,
+		uncachedClient: uncachedClient,
+		dynamicCache:   dc,
+		checker:        preflight.List{},
+	}
+
+	c.
+		On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	dc.
+		On("Watch", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	dc.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+	uncachedClient.
+		On("Get", mock.Anything, client.ObjectKey{Name: "config", Namespace: "test"}, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			obj := args.Get(2).(*unstructured.Unstructured)
+			obj.Object["data"] = map[string]interface{}{"key": "value"}
+		}).
+		Return(nil)
+	uncachedClient.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "owner", Namespace: "test",
+	}}
+	lookup := l.LookupFunc(context.Background(), owner)
+
+	obj, err := lookup("v1", "ConfigMap", "", "config")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"key": "value"}, obj["data"])
+	assert.Equal(t, "True", obj["metadata"].(map[string]interface{})["labels"].(map[string]interface{})[DynamicCacheLabel])
+	assert.Contains(t, owner.GetFinalizers(), CachedFinalizer)
+
+	obj, err = lookup("v1", "ConfigMap", "", "missing")
+	require.NoError(t, err)
+	assert.Empty(t, obj)
+}
+
+func TestObjectLookup_violation(t *testing.T) {
+	t.Parallel()
+
+	l := &ObjectLookup{
+		checker: preflight.CheckerFn(func(
+			_ context.Context, _, _ client.Object,
+		) ([]preflight.Violation, error) {
+			return []preflight.Violation{{Error: "Must stay within the same namespace."}}, nil
+		}),
+	}
+
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "owner", Namespace: "test",
+	}}
+	_, err := l.LookupFunc(context.Background(), owner)("v1", "ConfigMap", "other", "config")
+	var preflightErr *preflight.Error
+	require.ErrorAs(t, err, &preflightErr)
+}
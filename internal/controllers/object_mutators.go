@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ObjectMutator modifies objects before a PhaseReconciler applies them,
+// so distributions can inject labels, sidecar annotations or required fields
+// into every object without a separate admission webhook round trip.
+// Mutators run in the order they are configured, after the object has been defaulted
+// and before preflight checks, so mutated objects are checked too.
+// Mutations must be deterministic, otherwise objects are patched on every reconcile.
+type ObjectMutator interface {
+	Mutate(ctx context.Context, owner PhaseObjectOwner, obj *unstructured.Unstructured) error
+}
+
+// ObjectMutatorFunc adapts a plain function to the ObjectMutator interface.
+type ObjectMutatorFunc func(ctx context.Context, owner PhaseObjectOwner, obj *unstructured.Unstructured) error
+
+func (fn ObjectMutatorFunc) Mutate(
+	ctx context.Context, owner PhaseObjectOwner, obj *unstructured.Unstructured,
+) error {
+	return fn(ctx, owner, obj)
+}
+
+// NewMetadataMutator returns an ObjectMutator adding the given labels and annotations to all objects.
+// Labels and annotations already set by the object itself take precedence.
+func NewMetadataMutator(labels, annotations map[string]string) ObjectMutator {
+	return ObjectMutatorFunc(func(_ context.Context, _ PhaseObjectOwner, obj *unstructured.Unstructured) error {
+		if len(labels) > 0 {
+			obj.SetLabels(mergeKeysFrom(copyStringMap(labels), obj.GetLabels()))
+		}
+		if len(annotations) > 0 {
+			obj.SetAnnotations(mergeKeysFrom(copyStringMap(annotations), obj.GetAnnotations()))
+		}
+		return nil
+	})
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
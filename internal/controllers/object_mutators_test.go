@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewMetadataMutator(t *testing.T) {
+	t.Parallel()
+
+	m := NewMetadataMutator(
+		map[string]string{"team": "platform", "app": "default"},
+		map[string]string{"sidecar.example.com/inject": "false"},
+	)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetLabels(map[string]string{"app": "test"})
+	require.NoError(t, m.Mutate(context.Background(), nil, obj))
+
+	// labels of the object take precedence
+	assert.Equal(t, map[string]string{"team": "platform", "app": "test"}, obj.GetLabels())
+	assert.Equal(t, map[string]string{"sidecar.example.com/inject": "false"}, obj.GetAnnotations())
+}
@@ -9,6 +9,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/utils"
 )
 
@@ -27,8 +28,12 @@ type genericObjectSet interface {
 	GetGeneration() int64
 	IsStatusPaused() bool
 	SetPaused()
+	SetActive()
 	IsSpecPaused() bool
 	IsAvailable() bool
+	HasSurvivedSuccessDelay() bool
+	GetStatusMappedFields() map[string]string
+	GetStatusControllerOf() []corev1alpha1.ControlledObjectReference
 }
 
 type genericObjectSetFactory func(
@@ -122,16 +127,28 @@ func (a *GenericObjectSet) IsStatusPaused() bool {
 }
 
 func (a *GenericObjectSet) IsAvailable() bool {
-	return meta.IsStatusConditionTrue(
+	return controllers.IsCurrentStatusConditionTrue(
 		a.Status.Conditions,
 		corev1alpha1.ObjectSetAvailable,
+		a.Generation,
 	)
 }
 
+// HasSurvivedSuccessDelay returns true when the ObjectSet has no success delay,
+// or stayed Available for the whole delay and reported Succeeded.
+func (a *GenericObjectSet) HasSurvivedSuccessDelay() bool {
+	return a.Spec.SuccessDelaySeconds == 0 ||
+		meta.IsStatusConditionTrue(a.Status.Conditions, corev1alpha1.ObjectSetSucceeded)
+}
+
 func (a *GenericObjectSet) SetPaused() {
 	a.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStatePaused
 }
 
+func (a *GenericObjectSet) SetActive() {
+	a.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStateActive
+}
+
 func (a *GenericObjectSet) IsSpecPaused() bool {
 	return a.Spec.LifecycleState == corev1alpha1.ObjectSetLifecycleStatePaused
 }
@@ -201,6 +218,14 @@ func (a *GenericObjectSet) GetObjects() ([]objectIdentifier, error) {
 	return result, nil
 }
 
+func (a *GenericObjectSet) GetStatusMappedFields() map[string]string {
+	return a.Status.MappedFields
+}
+
+func (a *GenericObjectSet) GetStatusControllerOf() []corev1alpha1.ControlledObjectReference {
+	return a.Status.ControllerOf
+}
+
 type GenericClusterObjectSet struct {
 	corev1alpha1.ClusterObjectSet
 }
@@ -238,12 +263,20 @@ func (a *GenericClusterObjectSet) GetConditions() []metav1.Condition {
 }
 
 func (a *GenericClusterObjectSet) IsAvailable() bool {
-	return meta.IsStatusConditionTrue(
+	return controllers.IsCurrentStatusConditionTrue(
 		a.Status.Conditions,
 		corev1alpha1.ObjectSetAvailable,
+		a.Generation,
 	)
 }
 
+// HasSurvivedSuccessDelay returns true when the ObjectSet has no success delay,
+// or stayed Available for the whole delay and reported Succeeded.
+func (a *GenericClusterObjectSet) HasSurvivedSuccessDelay() bool {
+	return a.Spec.SuccessDelaySeconds == 0 ||
+		meta.IsStatusConditionTrue(a.Status.Conditions, corev1alpha1.ObjectSetSucceeded)
+}
+
 func (a *GenericClusterObjectSet) GetPhases() []corev1alpha1.ObjectSetTemplatePhase {
 	return a.Spec.Phases
 }
@@ -271,6 +304,10 @@ func (a *GenericClusterObjectSet) SetPaused() {
 	a.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStatePaused
 }
 
+func (a *GenericClusterObjectSet) SetActive() {
+	a.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStateActive
+}
+
 func (a *GenericClusterObjectSet) IsSpecPaused() bool {
 	return a.Spec.LifecycleState == corev1alpha1.ObjectSetLifecycleStatePaused
 }
@@ -322,6 +359,14 @@ func (a *GenericClusterObjectSet) GetObjects() ([]objectIdentifier, error) {
 	return result, nil
 }
 
+func (a *GenericClusterObjectSet) GetStatusMappedFields() map[string]string {
+	return a.Status.MappedFields
+}
+
+func (a *GenericClusterObjectSet) GetStatusControllerOf() []corev1alpha1.ControlledObjectReference {
+	return a.Status.ControllerOf
+}
+
 type objectSetsByRevisionAscending []genericObjectSet
 
 func (a objectSetsByRevisionAscending) Len() int      { return len(a) }
@@ -160,6 +160,20 @@ func TestGenericObjectSet_GetActivelyReconciledObjects(t *testing.T) {
 	}
 }
 
+func TestGenericObjectSet_HasSurvivedSuccessDelay(t *testing.T) {
+	objectSet := &GenericObjectSet{}
+	assert.True(t, objectSet.HasSurvivedSuccessDelay())
+
+	objectSet.Spec.SuccessDelaySeconds = 60
+	assert.False(t, objectSet.HasSurvivedSuccessDelay())
+
+	objectSet.Status.Conditions = []metav1.Condition{{
+		Type:   corev1alpha1.ObjectSetSucceeded,
+		Status: metav1.ConditionTrue,
+	}}
+	assert.True(t, objectSet.HasSurvivedSuccessDelay())
+}
+
 func cmTemplate(name string, namespace string, t require.TestingT) client.Object {
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
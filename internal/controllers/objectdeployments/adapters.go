@@ -14,6 +14,8 @@ type objectDeploymentAccessor interface {
 	GetSelector() metav1.LabelSelector
 	GetObjectSetTemplate() corev1alpha1.ObjectSetTemplate
 	GetRevisionHistoryLimit() *int32
+	GetObjectSetNaming() corev1alpha1.ObjectSetNamingStrategy
+	IsPaused() bool
 	SetStatusConditions(...metav1.Condition)
 	SetStatusCollisionCount(*int32)
 	GetStatusCollisionCount() *int32
@@ -21,4 +23,6 @@ type objectDeploymentAccessor interface {
 	GetGeneration() int64
 	SetStatusTemplateHash(templateHash string)
 	SetStatusRevision(r int64)
+	SetStatusMappedFields(fields map[string]string)
+	SetStatusControllerOf(controllerOf []corev1alpha1.ControlledObjectReference)
 }
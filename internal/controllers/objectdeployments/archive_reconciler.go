@@ -78,9 +78,9 @@ func (a *archiveReconciler) objectSetsToBeArchived(
 		currentLatestRevision := allObjectSets[j]
 
 		// Case 1:
-		// currentRevision is "Available",
+		// currentRevision is "Available" and stayed Available for its success delay,
 		// so all previous revisions can be archived.
-		if currentLatestRevision.IsAvailable() {
+		if currentLatestRevision.IsAvailable() && currentLatestRevision.HasSurvivedSuccessDelay() {
 			prevRevisionsToArchive, err := a.archiveAllLaterRevisions(ctx, currentLatestRevision, allObjectSets[:j])
 			if err != nil {
 				return []genericObjectSet{}, err
@@ -67,6 +67,7 @@ func Test_ArchivalReconciler(t *testing.T) {
 		arch1.On("IsArchived").Return(false)
 		arch2.On("IsArchived").Return(false)
 		latestAvailable.On("IsAvailable").Return(true)
+		latestAvailable.On("HasSurvivedSuccessDelay").Return(true)
 		prevs := []genericObjectSet{
 			arch1,
 			arch2,
@@ -94,6 +95,37 @@ func Test_ArchivalReconciler(t *testing.T) {
 			testPauseAndArchivalWhenLatestIsAvailable(t, true)
 		})
 
+	t.Run("Doesnt archive previous revisions while the latest revision is within its success delay", func(t *testing.T) {
+		objectDeployment := &genericObjectDeploymentMock{}
+		revisionLimit := int32(10)
+		objectDeployment.On("GetRevisionHistoryLimit").Return(&revisionLimit)
+
+		client := testutil.NewClient()
+		client.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		latest := makeObjectSetMock(2, "", makeControllerOfObjects("a"), makeObjects("a"), nil, false, false, false, true)
+		latest.ExpectedCalls = removeCall(latest.ExpectedCalls, "HasSurvivedSuccessDelay")
+		latest.On("HasSurvivedSuccessDelay").Return(false)
+		// Previous revision still shares objects with the latest one.
+		prev := makeObjectSetMock(1, "", makeControllerOfObjects("a"), makeObjects("a"), nil, false, false, false, true)
+
+		r := archiveReconciler{client: client}
+		res, err := r.Reconcile(context.Background(), latest, []genericObjectSet{prev}, objectDeployment)
+		require.NoError(t, err)
+		assert.True(t, res.IsZero(), "unexpected requeue")
+
+		client.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		prev.AssertNotCalled(t, "SetPaused")
+		prev.AssertNotCalled(t, "SetArchived")
+
+		// Once the delay passed, previous revisions are paused for archival.
+		latest.ExpectedCalls = removeCall(latest.ExpectedCalls, "HasSurvivedSuccessDelay")
+		latest.On("HasSurvivedSuccessDelay").Return(true)
+		_, err = r.Reconcile(context.Background(), latest, []genericObjectSet{prev}, objectDeployment)
+		require.NoError(t, err)
+		prev.AssertCalled(t, "SetPaused")
+	})
+
 	t.Run("archives intermediate revision/s if they are not available and dont actively reconcile anything present in later revisions",
 		func(t *testing.T) {
 			testPauseAndArchivalIntermediateRevisions(t, false)
@@ -500,8 +532,19 @@ func makeObjectSetMock(
 	mock.On("IsStatusPaused").Return(isStatusPaused)
 	mock.On("IsSpecPaused").Return(isSpecPaused)
 	mock.On("IsAvailable").Return(isAvailable)
+	mock.On("HasSurvivedSuccessDelay").Return(true)
 	mock.On("IsArchived").Return(isArchived)
 	mock.On("SetPaused").Return()
 	mock.On("SetArchived").Return()
 	return mock
 }
+
+func removeCall(calls []*mock.Call, method string) []*mock.Call {
+	out := make([]*mock.Call, 0, len(calls))
+	for _, c := range calls {
+		if c.Method != method {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -50,11 +50,32 @@ func (o *genericObjectSetMock) SetPaused() {
 	o.Called()
 }
 
+func (o *genericObjectSetMock) SetActive() {
+	o.Called()
+}
+
 func (o *genericObjectSetMock) IsAvailable() bool {
 	args := o.Called()
 	return args.Bool(0)
 }
 
+func (o *genericObjectSetMock) HasSurvivedSuccessDelay() bool {
+	args := o.Called()
+	return args.Bool(0)
+}
+
+func (o *genericObjectSetMock) GetStatusMappedFields() map[string]string {
+	args := o.Called()
+	fields, _ := args.Get(0).(map[string]string)
+	return fields
+}
+
+func (o *genericObjectSetMock) GetStatusControllerOf() []corev1alpha1.ControlledObjectReference {
+	args := o.Called()
+	controllerOf, _ := args.Get(0).([]corev1alpha1.ControlledObjectReference)
+	return controllerOf
+}
+
 func (o *genericObjectSetMock) GetConditions() []metav1.Condition {
 	args := o.Called()
 	return args.Get(0).([]metav1.Condition)
@@ -106,6 +127,14 @@ func (o *genericObjectDeploymentMock) SetStatusRevision(r int64) {
 	o.Called(r)
 }
 
+func (o *genericObjectDeploymentMock) SetStatusMappedFields(fields map[string]string) {
+	o.Called(fields)
+}
+
+func (o *genericObjectDeploymentMock) SetStatusControllerOf(controllerOf []corev1alpha1.ControlledObjectReference) {
+	o.Called(controllerOf)
+}
+
 func (o *genericObjectDeploymentMock) ClientObject() client.Object {
 	args := o.Called()
 	return args.Get(0).(client.Object)
@@ -147,6 +176,16 @@ func (o *genericObjectDeploymentMock) GetRevisionHistoryLimit() *int32 {
 	return args.Get(0).(*int32)
 }
 
+func (o *genericObjectDeploymentMock) GetObjectSetNaming() corev1alpha1.ObjectSetNamingStrategy {
+	args := o.Called()
+	return args.Get(0).(corev1alpha1.ObjectSetNamingStrategy)
+}
+
+func (o *genericObjectDeploymentMock) IsPaused() bool {
+	args := o.Called()
+	return args.Bool(0)
+}
+
 func (o *genericObjectDeploymentMock) GetStatusCollisionCount() *int32 {
 	args := o.Called()
 	res, _ := args.Get(0).(*int32)
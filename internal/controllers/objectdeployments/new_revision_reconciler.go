@@ -12,12 +12,17 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
 )
 
 type newRevisionReconciler struct {
 	client       client.Client
 	newObjectSet genericObjectSetFactory
 	scheme       *runtime.Scheme
+	// Version of the running manager, recorded on new ObjectSets.
+	managerVersion string
 }
 
 func (r *newRevisionReconciler) Reconcile(ctx context.Context,
@@ -91,7 +96,7 @@ func (r *newRevisionReconciler) newObjectSetFromDeployment(
 	deploymentClientObj := objectDeployment.ClientObject()
 	newObjectSet := r.newObjectSet(r.scheme)
 	newObjectSetClientObj := newObjectSet.ClientObject()
-	newObjectSetClientObj.SetName(deploymentClientObj.GetName() + "-" + objectDeployment.GetStatusTemplateHash())
+	newObjectSetClientObj.SetName(newObjectSetName(objectDeployment, prevObjectSets))
 	newObjectSetClientObj.SetNamespace(deploymentClientObj.GetNamespace())
 	newObjectSetClientObj.SetAnnotations(deploymentClientObj.GetAnnotations())
 	newObjectSetClientObj.SetLabels(objectDeployment.GetObjectSetTemplate().Metadata.Labels)
@@ -104,11 +109,16 @@ func (r *newRevisionReconciler) newObjectSetFromDeployment(
 		newObjectSetClientObj.SetLabels(map[string]string{})
 	}
 	newObjectSetClientObj.GetLabels()[ObjectSetObjectDeploymentLabel] = objectDeployment.ClientObject().GetName()
+	if identity, ok := deploymentClientObj.GetLabels()[controllers.OperatorIdentityLabel]; ok {
+		// Reconciled by the same Package Operator installation as the ObjectDeployment.
+		newObjectSetClientObj.GetLabels()[controllers.OperatorIdentityLabel] = identity
+	}
 
 	if newObjectSetClientObj.GetAnnotations() == nil {
 		newObjectSetClientObj.SetAnnotations(map[string]string{})
 	}
 	newObjectSetClientObj.GetAnnotations()[ObjectSetHashAnnotation] = objectDeployment.GetStatusTemplateHash()
+	controllers.SetManagerVersion(newObjectSetClientObj, r.managerVersion)
 
 	if err := controllerutil.SetControllerReference(
 		deploymentClientObj, newObjectSetClientObj, r.scheme); err != nil {
@@ -116,3 +126,29 @@ func (r *newRevisionReconciler) newObjectSetFromDeployment(
 	}
 	return newObjectSet, nil
 }
+
+// Returns the name of the next ObjectSet according to the naming strategy of the ObjectDeployment.
+func newObjectSetName(
+	objectDeployment objectDeploymentAccessor,
+	prevObjectSets []genericObjectSet,
+) string {
+	deploymentName := objectDeployment.ClientObject().GetName()
+	if objectDeployment.GetObjectSetNaming() != corev1alpha1.ObjectSetNamingRevision {
+		// The template hash already includes the collision count.
+		return deploymentName + "-" + objectDeployment.GetStatusTemplateHash()
+	}
+
+	// The ObjectSet controller assigns the next revision
+	// after the latest previous revision, so we can predict it here.
+	var latestRevision int64
+	for _, prev := range prevObjectSets {
+		if prev.GetRevision() > latestRevision {
+			latestRevision = prev.GetRevision()
+		}
+	}
+	name := fmt.Sprintf("%s-%d", deploymentName, latestRevision+1)
+	if collisionCount := objectDeployment.GetStatusCollisionCount(); collisionCount != nil && *collisionCount > 0 {
+		name = fmt.Sprintf("%s-%d", name, *collisionCount)
+	}
+	return name
+}
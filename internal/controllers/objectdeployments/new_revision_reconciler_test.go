@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -226,6 +227,68 @@ func Test_newRevisionReconciler_createsObjectSet(t *testing.T) {
 	}
 }
 
+func Test_newObjectSetName(t *testing.T) {
+	t.Parallel()
+
+	prevObjectSets := []genericObjectSet{
+		&GenericObjectSet{makeObjectSet("test-1", "test", 1, "xyz", false, true, true)},
+		&GenericObjectSet{makeObjectSet("test-3", "test", 3, "abc", true, true, false)},
+		&GenericObjectSet{makeObjectSet("test-2", "test", 2, "pqr", false, true, true)},
+	}
+
+	tests := []struct {
+		name           string
+		naming         corev1alpha1.ObjectSetNamingStrategy
+		collisionCount *int32
+		prev           []genericObjectSet
+		expected       string
+	}{
+		{
+			name:     "default",
+			prev:     prevObjectSets,
+			expected: "test-h4sh",
+		},
+		{
+			name:     "template hash",
+			naming:   corev1alpha1.ObjectSetNamingTemplateHash,
+			prev:     prevObjectSets,
+			expected: "test-h4sh",
+		},
+		{
+			name:     "first revision",
+			naming:   corev1alpha1.ObjectSetNamingRevision,
+			expected: "test-1",
+		},
+		{
+			name:     "next revision",
+			naming:   corev1alpha1.ObjectSetNamingRevision,
+			prev:     prevObjectSets,
+			expected: "test-4",
+		},
+		{
+			name:           "next revision after collision",
+			naming:         corev1alpha1.ObjectSetNamingRevision,
+			collisionCount: pointer.Int32(2),
+			prev:           prevObjectSets,
+			expected:       "test-4-2",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			objectDeployment := &adapters.ObjectDeployment{}
+			objectDeployment.Name = "test"
+			objectDeployment.Spec.ObjectSetNaming = test.naming
+			objectDeployment.Status.TemplateHash = "h4sh"
+			objectDeployment.Status.CollisionCount = test.collisionCount
+
+			assert.Equal(t, test.expected, newObjectSetName(objectDeployment, test.prev))
+		})
+	}
+}
+
 func requireObject(t *testing.T,
 	obj *corev1alpha1.ObjectSet,
 	expectedHash string,
@@ -10,14 +10,22 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/package-operator/internal/adapters"
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/version"
 )
 
 const (
 	ObjectSetHashAnnotation = "package-operator.run/hash"
+	// Marks ObjectSets paused via .spec.paused of their ObjectDeployment,
+	// so only these are resumed again, when the ObjectDeployment is unpaused.
+	ObjectSetPausedByDeploymentAnnotation = "package-operator.run/paused-by-deployment"
 	// Used to filter ObjectSets by their owning ObjectDeployment.
 	ObjectSetObjectDeploymentLabel = "package-operator.run/object-deployment"
 )
@@ -36,6 +44,10 @@ type GenericObjectDeploymentController struct {
 	newObjectSet        genericObjectSetFactory
 	newObjectSetList    genericObjectSetListFactory
 	reconciler          []reconciler
+	rateLimiter         ratelimiter.RateLimiter
+	statusWriter        *controllers.StatusWriter
+	// Only ObjectDeployments labeled with this identity are reconciled.
+	identity controllers.OperatorIdentity
 }
 
 func newGenericObjectDeploymentController(
@@ -55,6 +67,7 @@ func newGenericObjectDeploymentController(
 		newObjectDeployment: newObjectDeployment,
 		newObjectSet:        newObjectSet,
 		newObjectSetList:    newObjectSetList,
+		statusWriter:        controllers.NewStatusWriter(c),
 	}
 	controller.reconciler = []reconciler{
 		&hashReconciler{
@@ -65,14 +78,16 @@ func newGenericObjectDeploymentController(
 			listObjectSetsForDeployment: controller.listObjectSetsByRevision,
 			reconcilers: []objectSetSubReconciler{
 				&newRevisionReconciler{
-					client:       c,
-					newObjectSet: newObjectSet,
-					scheme:       scheme,
+					client:         c,
+					newObjectSet:   newObjectSet,
+					scheme:         scheme,
+					managerVersion: version.Get().ApplicationVersion,
 				},
 				&archiveReconciler{
 					client: c,
 				},
 			},
+			maintenance: controllers.NewMaintenanceModeChecker(c),
 		},
 	}
 
@@ -120,6 +135,11 @@ func (od *GenericObjectDeploymentController) Reconcile(
 		// Ignore not found errors on delete
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	if !od.identity.Owns(objectDeployment.ClientObject()) {
+		// Reconciled by another Package Operator installation.
+		return ctrl.Result{}, nil
+	}
+	original := objectDeployment.ClientObject().DeepCopyObject().(client.Object)
 
 	var (
 		res ctrl.Result
@@ -135,7 +155,21 @@ func (od *GenericObjectDeploymentController) Reconcile(
 		return res, err
 	}
 	objectDeployment.UpdatePhase()
-	return res, od.client.Status().Update(ctx, objectDeployment.ClientObject())
+	res, err = od.statusWriter.Update(ctx, original, objectDeployment.ClientObject(), res)
+	if err != nil {
+		return res, fmt.Errorf("updating ObjectDeployment status: %w", err)
+	}
+	return res, nil
+}
+
+// SetRateLimiter sets the workqueue rate limiter used when the controller is set up.
+func (od *GenericObjectDeploymentController) SetRateLimiter(rl ratelimiter.RateLimiter) {
+	od.rateLimiter = rl
+}
+
+// SetOperatorIdentity limits reconciliation to ObjectDeployments labeled with the given identity.
+func (od *GenericObjectDeploymentController) SetOperatorIdentity(identity controllers.OperatorIdentity) {
+	od.identity = identity
 }
 
 func (od *GenericObjectDeploymentController) SetupWithManager(mgr ctrl.Manager) error {
@@ -143,7 +177,8 @@ func (od *GenericObjectDeploymentController) SetupWithManager(mgr ctrl.Manager)
 	objectSet := od.newObjectSet(od.scheme).ClientObject()
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(objectDeployment).
+		WithOptions(controller.Options{RateLimiter: od.rateLimiter}).
+		For(objectDeployment, builder.WithPredicates(od.identity.Predicate())).
 		Owns(objectSet).
 		Complete(od)
 }
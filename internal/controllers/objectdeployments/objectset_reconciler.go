@@ -3,6 +3,7 @@ package objectdeployments
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,6 +18,7 @@ type objectSetReconciler struct {
 	client                      client.Client
 	listObjectSetsForDeployment listObjectSetsForDeploymentFn
 	reconcilers                 []objectSetSubReconciler
+	maintenance                 *controllers.MaintenanceModeChecker
 }
 
 type objectSetSubReconciler interface {
@@ -63,6 +65,47 @@ func (o *objectSetReconciler) Reconcile(ctx context.Context, objectDeployment ob
 		prevObjectSets = objectSets
 	}
 
+	inMaintenance, err := o.maintenance.IsInMaintenance(ctx, objectDeployment.ClientObject())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if inMaintenance {
+		// Don't create or archive ObjectSets, only report status.
+		o.setObjectDeploymentStatus(ctx, currentObjectSet, prevObjectSets, objectDeployment)
+		return ctrl.Result{RequeueAfter: controllers.MaintenanceModeRequeueInterval}, nil
+	}
+
+	if objectDeployment.IsPaused() {
+		// Don't create or archive ObjectSets and stop reconciling objects, only report status.
+		if err := o.pauseObjectSets(ctx, objectSets); err != nil {
+			return ctrl.Result{}, err
+		}
+		o.setObjectDeploymentStatus(ctx, currentObjectSet, prevObjectSets, objectDeployment)
+		objectDeployment.SetStatusConditions(
+			metav1.Condition{
+				Type:               corev1alpha1.ObjectDeploymentPaused,
+				Status:             metav1.ConditionTrue,
+				Reason:             corev1alpha1.ReasonPaused,
+				Message:            "ObjectDeployment is paused.",
+				ObservedGeneration: objectDeployment.GetGeneration(),
+			},
+			newProgressingCondition(
+				metav1.ConditionUnknown,
+				progressingReasonPaused,
+				"ObjectDeployment is paused, new revisions are not rolled out.",
+				objectDeployment.GetGeneration(),
+			),
+		)
+		return ctrl.Result{}, nil
+	}
+	if err := o.resumeObjectSets(ctx, objectSets); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := o.syncArgoCDApplication(ctx, currentObjectSet, objectDeployment); err != nil {
+		return ctrl.Result{}, err
+	}
+	meta.RemoveStatusCondition(objectDeployment.GetConditions(), corev1alpha1.ObjectDeploymentPaused)
+
 	var (
 		res              ctrl.Result
 		subReconcilerErr error
@@ -82,6 +125,78 @@ func (o *objectSetReconciler) Reconcile(ctx context.Context, objectDeployment ob
 	return ctrl.Result{}, nil
 }
 
+// Pauses all active ObjectSets of a paused ObjectDeployment.
+// ObjectSets that are already paused, e.g. before being archived, are left alone.
+func (o *objectSetReconciler) pauseObjectSets(ctx context.Context, objectSets []genericObjectSet) error {
+	for _, objectSet := range objectSets {
+		if objectSet.IsArchived() || objectSet.IsSpecPaused() {
+			continue
+		}
+		obj := objectSet.ClientObject()
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[ObjectSetPausedByDeploymentAnnotation] = "True"
+		obj.SetAnnotations(annotations)
+		objectSet.SetPaused()
+		if err := o.client.Update(ctx, obj); err != nil {
+			return fmt.Errorf("pausing ObjectSet: %w", err)
+		}
+	}
+	return nil
+}
+
+// Resumes ObjectSets that were paused by pauseObjectSets.
+func (o *objectSetReconciler) resumeObjectSets(ctx context.Context, objectSets []genericObjectSet) error {
+	for _, objectSet := range objectSets {
+		obj := objectSet.ClientObject()
+		annotations := obj.GetAnnotations()
+		if _, ok := annotations[ObjectSetPausedByDeploymentAnnotation]; !ok {
+			continue
+		}
+		delete(annotations, ObjectSetPausedByDeploymentAnnotation)
+		obj.SetAnnotations(annotations)
+		if objectSet.IsSpecPaused() {
+			objectSet.SetActive()
+		}
+		if err := o.client.Update(ctx, obj); err != nil {
+			return fmt.Errorf("resuming ObjectSet: %w", err)
+		}
+	}
+	return nil
+}
+
+// Propagates the ArgoCDApplicationAnnotation to the current ObjectSet,
+// which only inherits annotations of the ObjectDeployment when it is created.
+func (o *objectSetReconciler) syncArgoCDApplication(
+	ctx context.Context, currentObjectSet genericObjectSet, objectDeployment objectDeploymentAccessor,
+) error {
+	if currentObjectSet == nil {
+		return nil
+	}
+	desired, desiredOK := objectDeployment.ClientObject().GetAnnotations()[corev1alpha1.ArgoCDApplicationAnnotation]
+
+	obj := currentObjectSet.ClientObject()
+	annotations := obj.GetAnnotations()
+	if current, ok := annotations[corev1alpha1.ArgoCDApplicationAnnotation]; ok == desiredOK && current == desired {
+		return nil
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if desiredOK {
+		annotations[corev1alpha1.ArgoCDApplicationAnnotation] = desired
+	} else {
+		delete(annotations, corev1alpha1.ArgoCDApplicationAnnotation)
+	}
+	obj.SetAnnotations(annotations)
+	if err := o.client.Update(ctx, obj); err != nil {
+		return fmt.Errorf("updating ArgoCD application of ObjectSet: %w", err)
+	}
+	return nil
+}
+
 // Does current objectset exist?
 // N -> ObjectDeployment Progressing = True / Is a previous objectset available?
 // __Y -> ObjectDeployment Available = True
@@ -100,6 +215,8 @@ func (o *objectSetReconciler) setObjectDeploymentStatus(ctx context.Context,
 	prevObjectSets []genericObjectSet,
 	objectDeployment objectDeploymentAccessor,
 ) {
+	objectDeployment.SetStatusControllerOf(controlledObjects(currentObjectSet, prevObjectSets))
+
 	if currentObjectSet == nil {
 		objectDeployment.SetStatusConditions(
 			newProgressingCondition(
@@ -119,28 +236,34 @@ func (o *objectSetReconciler) setObjectDeploymentStatus(ctx context.Context,
 	objectDeployment.SetStatusRevision(currentObjectSet.GetRevision())
 
 	// map conditions
-	// -> copy mapped status conditions
+	// -> copy mapped status conditions and fields
 	controllers.DeleteMappedConditions(ctx, objectDeployment.GetConditions())
 	controllers.MapConditions(
 		ctx,
 		currentObjectSet.ClientObject().GetGeneration(), currentObjectSet.GetConditions(),
 		objectDeployment.ClientObject().GetGeneration(), objectDeployment.GetConditions(),
 	)
+	objectDeployment.SetStatusMappedFields(currentObjectSet.GetStatusMappedFields())
 
 	if !meta.IsStatusConditionTrue(currentObjectSet.GetConditions(), corev1alpha1.ObjectSetSucceeded) {
 		var conds []metav1.Condition
 
 		msg := "Latest Revision Status Unknown"
 
-		availableCond := meta.FindStatusCondition(currentObjectSet.GetConditions(), corev1alpha1.ObjectSetAvailable)
-		if availableCond != nil {
-			if availableCond.Status == metav1.ConditionFalse {
-				conds = append(conds, conditionFromPreviousObjectSets(objectDeployment.GetGeneration(), prevObjectSets...))
-
-				msg = "Latest Revision is Unavailable: " + availableCond.Message
-			} else {
-				msg = "Latest Revision is Available: pending success delay period"
-			}
+		availableCond := controllers.FindCurrentStatusCondition(
+			currentObjectSet.GetConditions(), corev1alpha1.ObjectSetAvailable,
+			currentObjectSet.GetGeneration())
+		switch {
+		case availableCond == nil:
+			// Latest revision has not reported yet,
+			// don't keep Available from an older generation around.
+			conds = append(conds, conditionFromPreviousObjectSets(objectDeployment.GetGeneration(), prevObjectSets...))
+		case availableCond.Status == metav1.ConditionFalse:
+			conds = append(conds, conditionFromPreviousObjectSets(objectDeployment.GetGeneration(), prevObjectSets...))
+
+			msg = "Latest Revision is Unavailable: " + availableCond.Message
+		default:
+			msg = "Latest Revision is Available: pending success delay period"
 		}
 
 		conds = append(conds, newProgressingCondition(
@@ -182,6 +305,46 @@ func (o *objectSetReconciler) setObjectDeploymentStatus(ctx context.Context,
 	)
 }
 
+// Returns the objects controlled by the given ObjectSets that are not archived,
+// sorted and without duplicates, as objects may be handed over between revisions.
+func controlledObjects(
+	currentObjectSet genericObjectSet, prevObjectSets []genericObjectSet,
+) []corev1alpha1.ControlledObjectReference {
+	objectSets := prevObjectSets
+	if currentObjectSet != nil {
+		objectSets = append([]genericObjectSet{currentObjectSet}, prevObjectSets...)
+	}
+
+	seen := map[corev1alpha1.ControlledObjectReference]struct{}{}
+	var refs []corev1alpha1.ControlledObjectReference
+	for _, objectSet := range objectSets {
+		if objectSet.IsArchived() {
+			continue
+		}
+		for _, ref := range objectSet.GetStatusControllerOf() {
+			if _, ok := seen[ref]; ok {
+				continue
+			}
+			seen[ref] = struct{}{}
+			refs = append(refs, ref)
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		a, b := refs[i], refs[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return refs
+}
+
 func conditionFromPreviousObjectSets(generation int64, prevObjectSets ...genericObjectSet) metav1.Condition {
 	found, rev := findAvailableRevision(prevObjectSets...)
 	if !found {
@@ -203,17 +366,7 @@ func conditionFromPreviousObjectSets(generation int64, prevObjectSets ...generic
 
 func findAvailableRevision(objectSets ...genericObjectSet) (bool, string) {
 	for _, os := range objectSets {
-		availableCond := meta.FindStatusCondition(os.GetConditions(), corev1alpha1.ObjectSetAvailable)
-		if availableCond == nil {
-			continue
-		}
-
-		var (
-			available = availableCond.Status == metav1.ConditionTrue
-			currGen   = availableCond.ObservedGeneration == os.ClientObject().GetGeneration()
-		)
-
-		if available && currGen {
+		if os.IsAvailable() {
 			return true, os.ClientObject().GetName()
 		}
 	}
@@ -238,8 +391,8 @@ func (r availableReason) String() string {
 }
 
 const (
-	availableReasonAvailable        availableReason = "Available"
-	availableReasonObjectSetUnready availableReason = "ObjectSetUnready"
+	availableReasonAvailable        availableReason = corev1alpha1.ReasonAvailable
+	availableReasonObjectSetUnready availableReason = corev1alpha1.ReasonObjectSetUnready
 )
 
 func newProgressingCondition(status metav1.ConditionStatus, reason progressingReason, msg string, generation int64) metav1.Condition {
@@ -259,7 +412,8 @@ func (r progressingReason) String() string {
 }
 
 const (
-	progressingReasonIdle                    progressingReason = "Idle"
-	progressingReasonLatestRevPendingSuccess progressingReason = "LatestRevisionPendingSuccess"
-	progressingReasonProgressing             progressingReason = "Progressing"
+	progressingReasonIdle                    progressingReason = corev1alpha1.ReasonIdle
+	progressingReasonLatestRevPendingSuccess progressingReason = corev1alpha1.ReasonLatestRevisionPendingSuccess
+	progressingReasonProgressing             progressingReason = corev1alpha1.ReasonProgressing
+	progressingReasonPaused                  progressingReason = corev1alpha1.ReasonPaused
 )
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -24,6 +25,7 @@ func Test_ObjectSetReconciler(t *testing.T) {
 		revisions               []corev1alpha1.ObjectSet
 		deploymentGeneration    int64
 		deploymentHash          string
+		deploymentPaused        bool
 		expectedCurrentRevision string
 		expectedPrevRevisions   []string
 		expectedConditions      map[string]metav1.ConditionStatus
@@ -81,6 +83,24 @@ func Test_ObjectSetReconciler(t *testing.T) {
 				corev1alpha1.ObjectDeploymentProgressing: metav1.ConditionTrue,
 			},
 		},
+		{
+			name:   "paused",
+			client: testutil.NewClient(),
+			revisions: []corev1alpha1.ObjectSet{
+				makeObjectSet("rev1", "test", 1, "xyz", false, true, true),
+				makeObjectSet("rev2", "test", 2, "abc", true, true, false),
+			},
+			deploymentGeneration:    2,
+			deploymentHash:          "hhh",
+			deploymentPaused:        true,
+			expectedCurrentRevision: "",
+			expectedPrevRevisions:   []string{"rev1", "rev2"},
+			expectedConditions: map[string]metav1.ConditionStatus{
+				corev1alpha1.ObjectDeploymentAvailable:   metav1.ConditionTrue,
+				corev1alpha1.ObjectDeploymentProgressing: metav1.ConditionUnknown,
+				corev1alpha1.ObjectDeploymentPaused:      metav1.ConditionTrue,
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -124,6 +144,7 @@ func Test_ObjectSetReconciler(t *testing.T) {
 				"test",
 				testCase.deploymentGeneration,
 				testCase.deploymentHash,
+				testCase.deploymentPaused,
 				&existingConditions,
 			)
 
@@ -138,6 +159,7 @@ func Test_ObjectSetReconciler(t *testing.T) {
 				objectList := args.Get(1).(*corev1alpha1.ObjectSetList)
 				objectList.Items = revisions
 			}).Return(nil)
+			client.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 			// Invoke reconciler
 			res, err := r.Reconcile(context.Background(), objectDeploymentmock)
@@ -145,33 +167,22 @@ func Test_ObjectSetReconciler(t *testing.T) {
 			require.NoError(t, err, "unexpected error")
 			require.True(t, res.IsZero(), "unexpected requeue")
 
-			// Assert that the subreconcilers are called with the
-			// correct args
-			mockedSubreconciler.AssertCalled(
-				t,
-				"Reconcile",
-				mock.Anything,
-				mock.MatchedBy(func(item interface{}) bool {
-					if len(testCase.expectedCurrentRevision) == 0 {
-						return item == nil
-					}
-					obj := item.(*GenericObjectSet)
-					return obj.Name == testCase.expectedCurrentRevision
-				}),
-				mock.MatchedBy(func(obj interface{}) bool {
-					objs := obj.([]genericObjectSet)
-					if len(objs) != len(testCase.expectedPrevRevisions) {
-						return false
-					}
-					for _, item := range objs {
-						if !slices.Contains(testCase.expectedPrevRevisions, item.ClientObject().GetName()) {
-							return false
-						}
-					}
-					return true
-				}),
-				mock.Anything,
-			)
+			if testCase.deploymentPaused {
+				// Only the active ObjectSet is paused, archived ObjectSets stay untouched.
+				mockedSubreconciler.AssertNotCalled(t, "Reconcile",
+					mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				client.AssertNumberOfCalls(t, "Update", 1)
+				client.AssertCalled(t, "Update", mock.Anything,
+					mock.MatchedBy(func(obj *corev1alpha1.ObjectSet) bool {
+						return obj.Name == "rev2" &&
+							obj.Spec.LifecycleState == corev1alpha1.ObjectSetLifecycleStatePaused &&
+							obj.Annotations[ObjectSetPausedByDeploymentAnnotation] == "True"
+					}), mock.Anything)
+			} else {
+				client.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				assertSubReconcilerCalled(t, mockedSubreconciler,
+					testCase.expectedCurrentRevision, testCase.expectedPrevRevisions)
+			}
 
 			// Assert that the status is correctly set
 
@@ -184,9 +195,63 @@ func Test_ObjectSetReconciler(t *testing.T) {
 	}
 }
 
+func Test_controlledObjects(t *testing.T) {
+	deploy := corev1alpha1.ControlledObjectReference{Group: "apps", Kind: "Deployment", Name: "test", Namespace: "test"}
+	cm := corev1alpha1.ControlledObjectReference{Kind: "ConfigMap", Name: "test", Namespace: "test"}
+	old := corev1alpha1.ControlledObjectReference{Kind: "Secret", Name: "test", Namespace: "test"}
+
+	current := makeObjectSet("rev3", "test", 3, "abc", true, true, false)
+	current.Status.ControllerOf = []corev1alpha1.ControlledObjectReference{deploy}
+	prev := makeObjectSet("rev2", "test", 2, "pqr", true, true, false)
+	prev.Status.ControllerOf = []corev1alpha1.ControlledObjectReference{deploy, cm}
+	archived := makeObjectSet("rev1", "test", 1, "xyz", false, true, true)
+	archived.Status.ControllerOf = []corev1alpha1.ControlledObjectReference{old}
+
+	refs := controlledObjects(&GenericObjectSet{current}, []genericObjectSet{
+		&GenericObjectSet{archived}, &GenericObjectSet{prev},
+	})
+	assert.Equal(t, []corev1alpha1.ControlledObjectReference{cm, deploy}, refs)
+
+	assert.Empty(t, controlledObjects(nil, nil))
+}
+
+// Asserts that the subreconcilers are called with the correct args.
+func assertSubReconcilerCalled(
+	t *testing.T, mockedSubreconciler *objectSetSubReconcilerMock,
+	expectedCurrentRevision string, expectedPrevRevisions []string,
+) {
+	t.Helper()
+	mockedSubreconciler.AssertCalled(
+		t,
+		"Reconcile",
+		mock.Anything,
+		mock.MatchedBy(func(item interface{}) bool {
+			if len(expectedCurrentRevision) == 0 {
+				return item == nil
+			}
+			obj := item.(*GenericObjectSet)
+			return obj.Name == expectedCurrentRevision
+		}),
+		mock.MatchedBy(func(obj interface{}) bool {
+			objs := obj.([]genericObjectSet)
+			if len(objs) != len(expectedPrevRevisions) {
+				return false
+			}
+			for _, item := range objs {
+				if !slices.Contains(expectedPrevRevisions, item.ClientObject().GetName()) {
+					return false
+				}
+			}
+			return true
+		}),
+		mock.Anything,
+	)
+}
+
 func makeObjectDeploymentMock(name string, namespace string,
 	generation int64,
 	templateHash string,
+	paused bool,
 	initialConditions *[]metav1.Condition,
 ) *genericObjectDeploymentMock {
 	res := &genericObjectDeploymentMock{}
@@ -211,11 +276,14 @@ func makeObjectDeploymentMock(name string, namespace string,
 		},
 	}
 	res.On("SetStatusRevision", mock.Anything).Return()
+	res.On("SetStatusMappedFields", mock.Anything).Return()
+	res.On("SetStatusControllerOf", mock.Anything).Return()
 	res.On("GetSelector").Return(labelSelector)
 	res.On("GetGeneration").Return(generation)
 	res.On("GetStatusTemplateHash").Return(templateHash)
 	res.On("GetConditions").Return(initialConditions)
 	res.On("GetName").Return(name)
+	res.On("IsPaused").Return(paused)
 	res.On("SetStatusConditions", mock.Anything).Run(func(args mock.Arguments) {
 		conds := args.Get(0).([]metav1.Condition)
 
@@ -11,6 +11,7 @@ type genericObjectSet interface {
 	ClientObject() client.Object
 	GetPrevious() []corev1alpha1.PreviousRevisionReference
 	GetRemotePhases() []corev1alpha1.RemotePhaseReference
+	GetPhases() []corev1alpha1.ObjectSetTemplatePhase
 }
 
 type genericObjectSetFactory func(
@@ -64,6 +65,10 @@ func (a *GenericObjectSet) GetRemotePhases() []corev1alpha1.RemotePhaseReference
 	return a.Status.RemotePhases
 }
 
+func (a *GenericObjectSet) GetPhases() []corev1alpha1.ObjectSetTemplatePhase {
+	return a.Spec.Phases
+}
+
 type GenericClusterObjectSet struct {
 	corev1alpha1.ClusterObjectSet
 }
@@ -79,3 +84,7 @@ func (a *GenericClusterObjectSet) GetPrevious() []corev1alpha1.PreviousRevisionR
 func (a *GenericClusterObjectSet) GetRemotePhases() []corev1alpha1.RemotePhaseReference {
 	return a.Status.RemotePhases
 }
+
+func (a *GenericClusterObjectSet) GetPhases() []corev1alpha1.ObjectSetTemplatePhase {
+	return a.Spec.Phases
+}
@@ -19,6 +19,9 @@ type genericObjectSetPhase interface {
 	GetGeneration() int64
 	IsPaused() bool
 	SetStatusControllerOf([]corev1alpha1.ControlledObjectReference)
+	SetStatusFailedProbes([]string)
+	SetStatusMappedFields(map[string]string)
+	SetStatusMappedField(destination, value string)
 }
 
 var (
@@ -78,7 +81,9 @@ func (a *GenericObjectSetPhase) GetPrevious() []corev1alpha1.PreviousRevisionRef
 
 func (a *GenericObjectSetPhase) GetPhase() corev1alpha1.ObjectSetTemplatePhase {
 	return corev1alpha1.ObjectSetTemplatePhase{
-		Objects: a.Spec.Objects,
+		Objects:     a.Spec.Objects,
+		Atomic:      a.Spec.Atomic,
+		ApplyPolicy: a.Spec.ApplyPolicy,
 	}
 }
 
@@ -102,6 +107,21 @@ func (a *GenericObjectSetPhase) SetStatusControllerOf(controllerOf []corev1alpha
 	a.Status.ControllerOf = controllerOf
 }
 
+func (a *GenericObjectSetPhase) SetStatusFailedProbes(failedProbes []string) {
+	a.Status.FailedProbes = failedProbes
+}
+
+func (a *GenericObjectSetPhase) SetStatusMappedFields(fields map[string]string) {
+	a.Status.MappedFields = fields
+}
+
+func (a *GenericObjectSetPhase) SetStatusMappedField(destination, value string) {
+	if a.Status.MappedFields == nil {
+		a.Status.MappedFields = map[string]string{}
+	}
+	a.Status.MappedFields[destination] = value
+}
+
 type GenericClusterObjectSetPhase struct {
 	corev1alpha1.ClusterObjectSetPhase
 }
@@ -124,7 +144,9 @@ func (a *GenericClusterObjectSetPhase) GetPrevious() []corev1alpha1.PreviousRevi
 
 func (a *GenericClusterObjectSetPhase) GetPhase() corev1alpha1.ObjectSetTemplatePhase {
 	return corev1alpha1.ObjectSetTemplatePhase{
-		Objects: a.Spec.Objects,
+		Objects:     a.Spec.Objects,
+		Atomic:      a.Spec.Atomic,
+		ApplyPolicy: a.Spec.ApplyPolicy,
 	}
 }
 
@@ -147,3 +169,18 @@ func (a *GenericClusterObjectSetPhase) IsPaused() bool {
 func (a *GenericClusterObjectSetPhase) SetStatusControllerOf(controllerOf []corev1alpha1.ControlledObjectReference) {
 	a.Status.ControllerOf = controllerOf
 }
+
+func (a *GenericClusterObjectSetPhase) SetStatusFailedProbes(failedProbes []string) {
+	a.Status.FailedProbes = failedProbes
+}
+
+func (a *GenericClusterObjectSetPhase) SetStatusMappedFields(fields map[string]string) {
+	a.Status.MappedFields = fields
+}
+
+func (a *GenericClusterObjectSetPhase) SetStatusMappedField(destination, value string) {
+	if a.Status.MappedFields == nil {
+		a.Status.MappedFields = map[string]string{}
+	}
+	a.Status.MappedFields[destination] = value
+}
@@ -7,19 +7,25 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/dynamiccache"
 	"package-operator.run/package-operator/internal/ownerhandling"
 	"package-operator.run/package-operator/internal/preflight"
+	"package-operator.run/package-operator/internal/tracing"
 )
 
 type reconciler interface {
@@ -30,7 +36,10 @@ type dynamicCache interface {
 	client.Reader
 	Source() source.Source
 	Free(ctx context.Context, obj client.Object) error
-	Watch(ctx context.Context, owner client.Object, obj runtime.Object) error
+	Watch(
+		ctx context.Context, owner client.Object, obj runtime.Object,
+		opts ...dynamiccache.WatchOption,
+	) error
 }
 
 type ownerStrategy interface {
@@ -68,6 +77,16 @@ type GenericObjectSetPhaseController struct {
 	dynamicCache    dynamicCache
 	ownerStrategy   ownerStrategy
 	teardownHandler teardownHandler
+	maintenance     *controllers.MaintenanceModeChecker
+	orphanMode      *controllers.OrphanModeChecker
+	rateLimiter     ratelimiter.RateLimiter
+	statusWriter    *controllers.StatusWriter
+	// Reconciles ObjectSetPhases as paused and orphans their objects on teardown.
+	observeOnly bool
+	// Partition of ObjectSetPhases reconciled by this manager replica.
+	shard controllers.Shard
+	// Only ObjectSetPhases labeled with this identity are reconciled.
+	identity controllers.OperatorIdentity
 
 	reconciler []reconciler
 }
@@ -80,7 +99,11 @@ func NewMultiClusterObjectSetPhaseController(
 	client client.Client, // client to get and update ObjectSetPhases (management cluster).
 	targetWriter client.Writer, // client to patch objects with (hosted cluster).
 	targetRESTMapper meta.RESTMapper,
+	opts ...controllers.PhaseReconcilerOption,
 ) *GenericObjectSetPhaseController {
+	var cfg controllers.PhaseReconcilerConfig
+	cfg.Option(opts...)
+
 	return NewGenericObjectSetPhaseController(
 		newGenericObjectSetPhase,
 		newGenericObjectSet,
@@ -89,8 +112,13 @@ func NewMultiClusterObjectSetPhaseController(
 		class, client, targetWriter,
 		preflight.List{
 			preflight.NewAPIExistence(targetRESTMapper),
-			preflight.NewDryRun(targetWriter),
+			preflight.NewCriticalKinds(),
+			preflight.NewPolicy(client),
+			preflight.NewDryRun(targetWriter, cfg.OperatorIdentity.FieldOwner()),
 		},
+		append([]controllers.PhaseReconcilerOption{
+			controllers.WithRESTMapper{RESTMapper: targetRESTMapper},
+		}, opts...)...,
 	)
 }
 
@@ -102,7 +130,11 @@ func NewMultiClusterClusterObjectSetPhaseController(
 	client client.Client, // client to get and update ObjectSetPhases (management cluster).
 	targetWriter client.Writer, // client to patch objects with (hosted cluster).
 	targetRESTMapper meta.RESTMapper,
+	opts ...controllers.PhaseReconcilerOption,
 ) *GenericObjectSetPhaseController {
+	var cfg controllers.PhaseReconcilerConfig
+	cfg.Option(opts...)
+
 	return NewGenericObjectSetPhaseController(
 		newGenericClusterObjectSetPhase,
 		newGenericClusterObjectSet,
@@ -111,8 +143,13 @@ func NewMultiClusterClusterObjectSetPhaseController(
 		class, client, targetWriter,
 		preflight.List{
 			preflight.NewAPIExistence(targetRESTMapper),
-			preflight.NewDryRun(targetWriter),
+			preflight.NewCriticalKinds(),
+			preflight.NewPolicy(client),
+			preflight.NewDryRun(targetWriter, cfg.OperatorIdentity.FieldOwner()),
 		},
+		append([]controllers.PhaseReconcilerOption{
+			controllers.WithRESTMapper{RESTMapper: targetRESTMapper},
+		}, opts...)...,
 	)
 }
 
@@ -123,7 +160,11 @@ func NewSameClusterObjectSetPhaseController(
 	class string,
 	client client.Client, // client to get and update ObjectSetPhases.
 	restMapper meta.RESTMapper,
+	opts ...controllers.PhaseReconcilerOption,
 ) *GenericObjectSetPhaseController {
+	var cfg controllers.PhaseReconcilerConfig
+	cfg.Option(opts...)
+
 	return NewGenericObjectSetPhaseController(
 		newGenericObjectSetPhase,
 		newGenericObjectSet,
@@ -132,9 +173,14 @@ func NewSameClusterObjectSetPhaseController(
 		class, client, client,
 		preflight.List{
 			preflight.NewAPIExistence(restMapper),
+			preflight.NewCriticalKinds(),
+			preflight.NewPolicy(client),
 			preflight.NewNamespaceEscalation(restMapper),
-			preflight.NewDryRun(client),
+			preflight.NewDryRun(client, cfg.OperatorIdentity.FieldOwner()),
 		},
+		append([]controllers.PhaseReconcilerOption{
+			controllers.WithRESTMapper{RESTMapper: restMapper},
+		}, opts...)...,
 	)
 }
 
@@ -145,7 +191,11 @@ func NewSameClusterClusterObjectSetPhaseController(
 	class string,
 	client client.Client, // client to get and update ObjectSetPhases.
 	restMapper meta.RESTMapper,
+	opts ...controllers.PhaseReconcilerOption,
 ) *GenericObjectSetPhaseController {
+	var cfg controllers.PhaseReconcilerConfig
+	cfg.Option(opts...)
+
 	return NewGenericObjectSetPhaseController(
 		newGenericClusterObjectSetPhase,
 		newGenericClusterObjectSet,
@@ -154,8 +204,13 @@ func NewSameClusterClusterObjectSetPhaseController(
 		class, client, client,
 		preflight.List{
 			preflight.NewAPIExistence(restMapper),
-			preflight.NewDryRun(client),
+			preflight.NewCriticalKinds(),
+			preflight.NewPolicy(client),
+			preflight.NewDryRun(client, cfg.OperatorIdentity.FieldOwner()),
 		},
+		append([]controllers.PhaseReconcilerOption{
+			controllers.WithRESTMapper{RESTMapper: restMapper},
+		}, opts...)...,
 	)
 }
 
@@ -170,7 +225,11 @@ func NewGenericObjectSetPhaseController(
 	client client.Client, // client to get and update ObjectSetPhases.
 	targetWriter client.Writer, // client to patch objects with.
 	preflightChecker preflightChecker,
+	opts ...controllers.PhaseReconcilerOption,
 ) *GenericObjectSetPhaseController {
+	var cfg controllers.PhaseReconcilerConfig
+	cfg.Option(opts...)
+
 	controller := &GenericObjectSetPhaseController{
 		newObjectSetPhase: newObjectSetPhase,
 
@@ -181,12 +240,22 @@ func NewGenericObjectSetPhaseController(
 		client:        client,
 		dynamicCache:  dynamicCache,
 		ownerStrategy: ownerStrategy,
+		maintenance:   controllers.NewMaintenanceModeChecker(client),
+		orphanMode:    controllers.NewOrphanModeChecker(client),
+		statusWriter:  controllers.NewStatusWriter(client),
+		identity:      cfg.OperatorIdentity,
 	}
 
 	phaseReconciler := newObjectSetPhaseReconciler(
-		scheme,
+		scheme, dynamicCache,
 		controllers.NewPhaseReconciler(
-			scheme, targetWriter, dynamicCache, uncachedClient, ownerStrategy, preflightChecker),
+			scheme, targetWriter, dynamicCache, uncachedClient, ownerStrategy, preflightChecker,
+			append([]controllers.PhaseReconcilerOption{
+				controllers.WithAdoptionPolicy{
+					AdoptionPolicyChecker: controllers.NewAdoptionPolicyChecker(client),
+				},
+			}, opts...)...,
+		),
 		controllers.NewPreviousRevisionLookup(
 			scheme, func(s *runtime.Scheme) controllers.PreviousObjectSet {
 				return newObjectSet(s)
@@ -195,6 +264,11 @@ func NewGenericObjectSetPhaseController(
 	)
 	controller.teardownHandler = phaseReconciler
 	controller.reconciler = []reconciler{
+		&parentValidationReconciler{
+			scheme:       scheme,
+			client:       client,
+			newObjectSet: newObjectSet,
+		},
 		phaseReconciler,
 	}
 
@@ -203,11 +277,14 @@ func NewGenericObjectSetPhaseController(
 
 func (c *GenericObjectSetPhaseController) Reconcile(
 	ctx context.Context, req ctrl.Request,
-) (ctrl.Result, error) {
+) (res ctrl.Result, err error) {
 	log := c.log.WithValues("ObjectSetPhase", req.String())
 	defer log.Info("reconciled")
 	ctx = logr.NewContext(ctx, log)
 
+	ctx, span := tracing.Start(ctx, "Reconcile ObjectSetPhase", tracing.RequestAttributes(req)...)
+	defer func() { tracing.End(span, err) }()
+
 	defer log.Info("reconciled")
 
 	objectSetPhase := c.newObjectSetPhase(c.scheme)
@@ -215,27 +292,54 @@ func (c *GenericObjectSetPhaseController) Reconcile(
 		ctx, req.NamespacedName, objectSetPhase.ClientObject()); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	original := objectSetPhase.ClientObject().DeepCopyObject().(client.Object)
 
 	if objectSetPhase.GetClass() != c.class {
 		return ctrl.Result{}, nil
 	}
+	if !c.identity.Owns(objectSetPhase.ClientObject()) {
+		// Reconciled by another Package Operator installation.
+		return ctrl.Result{}, nil
+	}
+	if !c.shard.OwnsDependent(objectSetPhase.ClientObject()) {
+		// Reconciled by another manager replica.
+		return ctrl.Result{}, nil
+	}
+
+	inMaintenance, err := c.maintenance.IsInMaintenance(ctx, objectSetPhase.ClientObject())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 
 	if !objectSetPhase.ClientObject().GetDeletionTimestamp().IsZero() {
+		if inMaintenance {
+			// Teardown has to wait until maintenance is over.
+			return ctrl.Result{RequeueAfter: controllers.MaintenanceModeRequeueInterval}, nil
+		}
+		orphaning, err := c.orphanMode.IsOrphaning(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		// Objects have not been changed in observe-only mode, so leave them as they are.
+		if orphaning || c.observeOnly {
+			// Leave all objects on the cluster, e.g. when Package Operator is uninstalled.
+			objectSetPhase = &orphanObjectSetPhase{genericObjectSetPhase: objectSetPhase}
+		}
 		if err := c.handleDeletionAndArchival(ctx, objectSetPhase); err != nil {
 			return ctrl.Result{}, err
 		}
 
-		return ctrl.Result{}, c.updateStatus(ctx, objectSetPhase)
+		return c.updateStatus(ctx, original, objectSetPhase, ctrl.Result{})
 	}
 
 	if err := controllers.EnsureCachedFinalizer(ctx, c.client, objectSetPhase.ClientObject()); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	var (
-		res ctrl.Result
-		err error
-	)
+	if inMaintenance || c.observeOnly {
+		// Reconcile like a paused ObjectSetPhase, so only status is reported.
+		objectSetPhase = &maintenanceObjectSetPhase{genericObjectSetPhase: objectSetPhase}
+	}
 	for _, r := range c.reconciler {
 		res, err = r.Reconcile(ctx, objectSetPhase)
 		if err != nil || !res.IsZero() {
@@ -244,11 +348,49 @@ func (c *GenericObjectSetPhaseController) Reconcile(
 	}
 
 	if err != nil {
-		return c.updateStatusError(ctx, objectSetPhase, err)
+		return c.updateStatusError(ctx, original, objectSetPhase, err)
 	}
 
 	c.reportPausedCondition(ctx, objectSetPhase)
-	return res, c.updateStatus(ctx, objectSetPhase)
+	if inMaintenance {
+		meta.SetStatusCondition(objectSetPhase.GetConditions(), metav1.Condition{
+			Type:               corev1alpha1.ObjectSetPhasePaused,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: objectSetPhase.GetGeneration(),
+			Reason:             corev1alpha1.ReasonMaintenanceMode,
+			Message:            "Package Operator is in maintenance mode.",
+		})
+		if res.IsZero() {
+			res.RequeueAfter = controllers.MaintenanceModeRequeueInterval
+		}
+	} else if c.observeOnly {
+		meta.SetStatusCondition(objectSetPhase.GetConditions(), metav1.Condition{
+			Type:               corev1alpha1.ObjectSetPhasePaused,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: objectSetPhase.GetGeneration(),
+			Reason:             corev1alpha1.ReasonObserveOnly,
+			Message:            "Package Operator is in observe-only mode.",
+		})
+	}
+	return c.updateStatus(ctx, original, objectSetPhase, res)
+}
+
+// Treats the ObjectSetPhase as paused while in maintenance or observe-only mode.
+type maintenanceObjectSetPhase struct {
+	genericObjectSetPhase
+}
+
+func (a *maintenanceObjectSetPhase) IsPaused() bool {
+	return true
+}
+
+// Orphans all objects of the ObjectSetPhase on teardown.
+type orphanObjectSetPhase struct {
+	genericObjectSetPhase
+}
+
+func (a *orphanObjectSetPhase) GetPhase() corev1alpha1.ObjectSetTemplatePhase {
+	return controllers.OrphanPhase(a.genericObjectSetPhase.GetPhase())
 }
 
 func (c *GenericObjectSetPhaseController) reportPausedCondition(_ context.Context, objectSetPhase genericObjectSetPhase) {
@@ -257,7 +399,7 @@ func (c *GenericObjectSetPhaseController) reportPausedCondition(_ context.Contex
 			Type:               corev1alpha1.ObjectSetPhasePaused,
 			Status:             metav1.ConditionTrue,
 			ObservedGeneration: objectSetPhase.GetGeneration(),
-			Reason:             "Paused",
+			Reason:             corev1alpha1.ReasonPaused,
 			Message:            "Lifecycle state set to paused.",
 		})
 	} else {
@@ -266,31 +408,66 @@ func (c *GenericObjectSetPhaseController) reportPausedCondition(_ context.Contex
 }
 
 func (c *GenericObjectSetPhaseController) updateStatusError(
-	ctx context.Context, objectSetPhase genericObjectSetPhase,
+	ctx context.Context, original client.Object, objectSetPhase genericObjectSetPhase,
 	reconcileErr error,
 ) (ctrl.Result, error) {
-	var preflightError *preflight.Error
-	if errors.As(reconcileErr, &preflightError) {
+	if controllers.ClassifyError(reconcileErr) != controllers.ErrorClassRetryable {
+		// Retrying won't help, report and wait for the ObjectSetPhase or its objects to change.
+		reason, message := controllers.ErrorCondition(reconcileErr)
+		meta.SetStatusCondition(objectSetPhase.GetConditions(), metav1.Condition{
+			Type:               corev1alpha1.ObjectSetPhaseAvailable,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: objectSetPhase.GetGeneration(),
+			Reason:             reason,
+			Message:            message,
+		})
+		return c.updateStatus(ctx, original, objectSetPhase, ctrl.Result{})
+	}
+
+	var parentMismatchError *ParentMismatchError
+	if errors.As(reconcileErr, &parentMismatchError) {
+		meta.SetStatusCondition(objectSetPhase.GetConditions(), metav1.Condition{
+			Type:               corev1alpha1.ObjectSetPhaseInvalid,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: objectSetPhase.GetGeneration(),
+			Reason:             parentMismatchError.Reason,
+			Message:            parentMismatchError.Error(),
+		})
+		// Also report via Available, so the parent ObjectSet surfaces the issue.
 		meta.SetStatusCondition(objectSetPhase.GetConditions(), metav1.Condition{
 			Type:               corev1alpha1.ObjectSetPhaseAvailable,
 			Status:             metav1.ConditionFalse,
 			ObservedGeneration: objectSetPhase.GetGeneration(),
-			Reason:             "PreflightError",
-			Message:            preflightError.Error(),
+			Reason:             corev1alpha1.ReasonInvalid,
+			Message:            parentMismatchError.Error(),
 		})
-		return ctrl.Result{}, c.updateStatus(ctx, objectSetPhase)
+		return c.updateStatus(ctx, original, objectSetPhase, ctrl.Result{})
+	}
+
+	if apierrors.IsUnauthorized(reconcileErr) {
+		// Credentials may be rotated at any time,
+		// so report and check again without erroring.
+		meta.SetStatusCondition(objectSetPhase.GetConditions(), metav1.Condition{
+			Type:               corev1alpha1.ObjectSetPhaseAvailable,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: objectSetPhase.GetGeneration(),
+			Reason:             corev1alpha1.ReasonUnauthorized,
+			Message:            fmt.Sprintf("Invalid credentials: %s", reconcileErr.Error()),
+		})
+		return c.updateStatus(ctx, original, objectSetPhase, ctrl.Result{RequeueAfter: 30 * time.Second})
 	}
 
 	return ctrl.Result{RequeueAfter: 30 * time.Second}, reconcileErr
 }
 
 func (c *GenericObjectSetPhaseController) updateStatus(
-	ctx context.Context, objectSetPhase genericObjectSetPhase,
-) error {
-	if err := c.client.Status().Update(ctx, objectSetPhase.ClientObject()); err != nil {
-		return fmt.Errorf("updating ObjectSetPhase status: %w", err)
+	ctx context.Context, original client.Object, objectSetPhase genericObjectSetPhase, res ctrl.Result,
+) (ctrl.Result, error) {
+	res, err := c.statusWriter.Update(ctx, original, objectSetPhase.ClientObject(), res)
+	if err != nil {
+		return res, fmt.Errorf("updating ObjectSetPhase status: %w", err)
 	}
-	return nil
+	return res, nil
 }
 
 func (c *GenericObjectSetPhaseController) handleDeletionAndArchival(
@@ -317,13 +494,30 @@ func (c *GenericObjectSetPhaseController) handleDeletionAndArchival(
 	return controllers.FreeCacheAndRemoveFinalizer(ctx, c.client, objectSetPhase.ClientObject(), c.dynamicCache)
 }
 
+// SetRateLimiter sets the workqueue rate limiter used when the controller is set up.
+func (c *GenericObjectSetPhaseController) SetRateLimiter(rl ratelimiter.RateLimiter) {
+	c.rateLimiter = rl
+}
+
+// SetObserveOnly reconciles all ObjectSetPhases as paused,
+// so changes to objects are only reported, and orphans their objects on teardown.
+func (c *GenericObjectSetPhaseController) SetObserveOnly(enabled bool) {
+	c.observeOnly = enabled
+}
+
+// SetShard limits reconciliation to ObjectSetPhases of ObjectSets owned by the given shard.
+func (c *GenericObjectSetPhaseController) SetShard(shard controllers.Shard) {
+	c.shard = shard
+}
+
 func (c *GenericObjectSetPhaseController) SetupWithManager(
 	mgr ctrl.Manager,
 ) error {
 	objectSetPhase := c.newObjectSetPhase(c.scheme).ClientObject()
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(objectSetPhase).
+		WithOptions(controller.Options{RateLimiter: c.rateLimiter}).
+		For(objectSetPhase, builder.WithPredicates(c.identity.Predicate())).
 		Watches(c.dynamicCache.Source(), c.ownerStrategy.EnqueueRequestForOwner(objectSetPhase, false)).
 		Complete(c)
 }
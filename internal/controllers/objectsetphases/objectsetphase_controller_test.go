@@ -21,6 +21,7 @@ import (
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/dynamiccache"
 	"package-operator.run/package-operator/internal/ownerhandling"
 	"package-operator.run/package-operator/internal/preflight"
 	"package-operator.run/package-operator/internal/testutil"
@@ -32,6 +33,7 @@ type dynamicCacheMock struct {
 
 func (c *dynamicCacheMock) Watch(
 	ctx context.Context, owner client.Object, obj runtime.Object,
+	_ ...dynamiccache.WatchOption,
 ) error {
 	args := c.Called(ctx, owner, obj)
 	return args.Error(0)
@@ -81,6 +83,7 @@ func newControllerAndMocks() (*GenericObjectSetPhaseController, *testutil.CtrlCl
 		client:        c,
 		dynamicCache:  dc,
 		ownerStrategy: ownerhandling.NewNative(scheme),
+		statusWriter:  controllers.NewStatusWriter(c),
 	}
 
 	pr := &objectSetPhaseReconcilerMock{}
@@ -170,13 +173,15 @@ func TestGenericObjectSetPhaseController_Reconcile(t *testing.T) {
 				pr.AssertCalled(t, "Teardown", mock.Anything, mock.Anything)
 				pr.AssertNotCalled(t, "Reconcile", mock.Anything, mock.Anything)
 				dc.AssertCalled(t, "Free", mock.Anything, mock.Anything)
-				c.StatusMock.AssertCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				// Status did not change.
+				c.StatusMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 				return
 			}
 
 			pr.AssertNotCalled(t, "Teardown", mock.Anything, mock.Anything)
 			pr.AssertCalled(t, "Reconcile", mock.Anything, mock.Anything)
-			c.StatusMock.AssertCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			// Status did not change.
+			c.StatusMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 		})
 	}
 }
@@ -275,7 +280,7 @@ func TestGenericObjectSetPhaseController_updateStatusError(t *testing.T) {
 
 		c := &GenericObjectSetPhaseController{}
 		ctx := context.Background()
-		res, err := c.updateStatusError(ctx, objectSetPhase, errTest)
+		res, err := c.updateStatusError(ctx, objectSetPhase.DeepCopy(), objectSetPhase, errTest)
 		assert.False(t, res.IsZero())
 		assert.EqualError(t, err, "explosion")
 	})
@@ -287,7 +292,32 @@ func TestGenericObjectSetPhaseController_updateStatusError(t *testing.T) {
 
 		client := testutil.NewClient()
 		c := &GenericObjectSetPhaseController{
-			client: client,
+			client:       client,
+			statusWriter: controllers.NewStatusWriter(client),
+		}
+
+		client.StatusMock.
+			On("Update", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+
+		ctx := context.Background()
+		res, err := c.updateStatusError(
+			ctx, objectSetPhase.DeepCopy(), objectSetPhase, &preflight.Error{})
+		require.True(t, res.IsZero())
+		require.NoError(t, err)
+
+		client.StatusMock.AssertExpectations(t)
+	})
+
+	t.Run("reports parent mismatch", func(t *testing.T) {
+		objectSetPhase := &GenericObjectSetPhase{
+			ObjectSetPhase: corev1alpha1.ObjectSetPhase{},
+		}
+
+		client := testutil.NewClient()
+		c := &GenericObjectSetPhaseController{
+			client:       client,
+			statusWriter: controllers.NewStatusWriter(client),
 		}
 
 		client.StatusMock.
@@ -296,10 +326,48 @@ func TestGenericObjectSetPhaseController_updateStatusError(t *testing.T) {
 
 		ctx := context.Background()
 		res, err := c.updateStatusError(
-			ctx, objectSetPhase, &preflight.Error{})
+			ctx, objectSetPhase.DeepCopy(), objectSetPhase, &ParentMismatchError{Reason: "PhaseNotFound", Message: "not found"})
 		require.True(t, res.IsZero())
 		require.NoError(t, err)
 
+		invalidCond := meta.FindStatusCondition(
+			objectSetPhase.Status.Conditions, corev1alpha1.ObjectSetPhaseInvalid)
+		if assert.NotNil(t, invalidCond) {
+			assert.Equal(t, metav1.ConditionTrue, invalidCond.Status)
+			assert.Equal(t, "PhaseNotFound", invalidCond.Reason)
+		}
+		assert.True(t, meta.IsStatusConditionFalse(
+			objectSetPhase.Status.Conditions, corev1alpha1.ObjectSetPhaseAvailable))
+		client.StatusMock.AssertExpectations(t)
+	})
+
+	t.Run("reports invalid credentials", func(t *testing.T) {
+		objectSetPhase := &GenericObjectSetPhase{
+			ObjectSetPhase: corev1alpha1.ObjectSetPhase{},
+		}
+
+		client := testutil.NewClient()
+		c := &GenericObjectSetPhaseController{
+			client:       client,
+			statusWriter: controllers.NewStatusWriter(client),
+		}
+
+		client.StatusMock.
+			On("Update", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+
+		ctx := context.Background()
+		res, err := c.updateStatusError(
+			ctx, objectSetPhase.DeepCopy(), objectSetPhase, errors.NewUnauthorized("token expired"))
+		assert.False(t, res.IsZero())
+		require.NoError(t, err)
+
+		availableCond := meta.FindStatusCondition(
+			objectSetPhase.Status.Conditions, corev1alpha1.ObjectSetPhaseAvailable)
+		if assert.NotNil(t, availableCond) {
+			assert.Equal(t, metav1.ConditionFalse, availableCond.Status)
+			assert.Equal(t, "Unauthorized", availableCond.Reason)
+		}
 		client.StatusMock.AssertExpectations(t)
 	})
 }
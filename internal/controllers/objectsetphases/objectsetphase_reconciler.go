@@ -19,14 +19,17 @@ import (
 // objectSetPhaseReconciler reconciles objects within a phase.
 type objectSetPhaseReconciler struct {
 	scheme                  *runtime.Scheme
+	dynamicCache            controllers.PhaseCache
 	phaseReconciler         phaseReconciler
 	lookupPreviousRevisions lookupPreviousRevisions
 	ownerStrategy           ownerStrategy
 	backoff                 *flowcontrol.Backoff
+	failureTracker          *probing.FailureTracker
 }
 
 func newObjectSetPhaseReconciler(
 	scheme *runtime.Scheme,
+	dynamicCache controllers.PhaseCache,
 	phaseReconciler phaseReconciler,
 	lookupPreviousRevisions lookupPreviousRevisions,
 	ownerStrategy ownerStrategy,
@@ -39,10 +42,12 @@ func newObjectSetPhaseReconciler(
 
 	return &objectSetPhaseReconciler{
 		scheme:                  scheme,
+		dynamicCache:            dynamicCache,
 		phaseReconciler:         phaseReconciler,
 		lookupPreviousRevisions: lookupPreviousRevisions,
 		ownerStrategy:           ownerStrategy,
 		backoff:                 cfg.GetBackoff(),
+		failureTracker:          probing.NewFailureTracker(),
 	}
 }
 
@@ -69,6 +74,7 @@ func (r *objectSetPhaseReconciler) Reconcile(
 	defer r.backoff.GC()
 
 	controllers.DeleteMappedConditions(ctx, objectSetPhase.GetConditions())
+	objectSetPhase.SetStatusMappedFields(nil)
 
 	previous, err := r.lookupPreviousRevisions(ctx, objectSetPhase)
 	if err != nil {
@@ -76,7 +82,14 @@ func (r *objectSetPhaseReconciler) Reconcile(
 	}
 
 	probe, err := probing.Parse(
-		ctx, objectSetPhase.GetAvailabilityProbes())
+		ctx, objectSetPhase.GetAvailabilityProbes(),
+		probing.WithObjectLister{
+			ObjectLister: controllers.NewProbeObjectLister(r.dynamicCache, objectSetPhase.ClientObject()),
+		},
+		probing.WithFailureTracker{
+			FailureTracker: r.failureTracker,
+			Owner:          objectSetPhase.ClientObject().GetUID(),
+		})
 	if err != nil {
 		return res, fmt.Errorf("parsing probes: %w", err)
 	}
@@ -99,35 +112,45 @@ func (r *objectSetPhaseReconciler) Reconcile(
 		return res, fmt.Errorf("reporting active objects: %w", err)
 	}
 
+	objectSetPhase.SetStatusFailedProbes(probingResult.FailedProbes)
 	if !probingResult.IsZero() {
 		meta.SetStatusCondition(
 			objectSetPhase.GetConditions(), metav1.Condition{
 				Type:               corev1alpha1.ObjectSetAvailable,
 				Status:             metav1.ConditionFalse,
-				Reason:             "ProbeFailure",
+				Reason:             corev1alpha1.ReasonProbeFailure,
 				Message:            probingResult.StringWithoutPhase(),
 				ObservedGeneration: objectSetPhase.ClientObject().GetGeneration(),
 			})
 
+		// Objects may not emit events when becoming available,
+		// so follow the suggestion of the probes when to check again.
+		res.RequeueAfter = probingResult.RecheckAfter
 		return res, nil
 	}
 
 	meta.SetStatusCondition(objectSetPhase.GetConditions(), metav1.Condition{
 		Type:               corev1alpha1.ObjectSetPhaseAvailable,
 		Status:             metav1.ConditionTrue,
-		Reason:             "Available",
+		Reason:             corev1alpha1.ReasonAvailable,
 		Message:            "Object is available and passes all probes.",
 		ObservedGeneration: objectSetPhase.ClientObject().GetGeneration(),
 	})
 
-	return ctrl.Result{}, nil
+	// Probes tolerating failures have to check objects again.
+	return ctrl.Result{RequeueAfter: probingResult.RecheckAfter}, nil
 }
 
 func (r *objectSetPhaseReconciler) Teardown(
 	ctx context.Context, objectSetPhase genericObjectSetPhase,
 ) (cleanupDone bool, err error) {
-	return r.phaseReconciler.TeardownPhase(
+	cleanupDone, err = r.phaseReconciler.TeardownPhase(
 		ctx, objectSetPhase, objectSetPhase.GetPhase())
+	if cleanupDone {
+		// Probes are no longer needed once torn down.
+		r.failureTracker.Forget(objectSetPhase.ClientObject().GetUID())
+	}
+	return cleanupDone, err
 }
 
 // Sets .status.activeObjects to all objects actively reconciled and controlled by this Phase.
@@ -43,8 +43,9 @@ func TestPhaseReconciler_Reconcile(t *testing.T) {
 	}
 
 	tests := []struct {
-		name      string
-		condition metav1.Condition
+		name         string
+		condition    metav1.Condition
+		failedProbes []string
 	}{
 		{
 			name: "probe failed",
@@ -52,6 +53,7 @@ func TestPhaseReconciler_Reconcile(t *testing.T) {
 				Status: metav1.ConditionFalse,
 				Reason: "ProbeFailure",
 			},
+			failedProbes: []string{"apps Deployment test/test: not ready"},
 		},
 		{
 			name: "probe passed",
@@ -67,12 +69,15 @@ func TestPhaseReconciler_Reconcile(t *testing.T) {
 			objectSetPhase.ClientObject().SetName("testPhaseOwner")
 			m := &phaseReconcilerMock{}
 			ownerStrategy := &ownerhandlingmocks.OwnerStrategyMock{}
-			r := newObjectSetPhaseReconciler(testScheme, m, lookup, ownerStrategy)
+			r := newObjectSetPhaseReconciler(testScheme, nil, m, lookup, ownerStrategy)
 
 			if test.condition.Reason == "ProbeFailure" {
 				m.
 					On("ReconcilePhase", mock.Anything, objectSetPhase, objectSetPhase.GetPhase(), mock.Anything, previousList).
-					Return([]client.Object{}, controllers.ProbingResult{PhaseName: "this"}, nil).
+					Return([]client.Object{}, controllers.ProbingResult{
+						PhaseName:    "this",
+						FailedProbes: test.failedProbes,
+					}, nil).
 					Once()
 			} else {
 				m.
@@ -91,6 +96,8 @@ func TestPhaseReconciler_Reconcile(t *testing.T) {
 			assert.Equal(t, corev1alpha1.ObjectSetPhaseAvailable, cond.Type)
 			assert.Equal(t, test.condition.Status, cond.Status)
 			assert.Equal(t, test.condition.Reason, cond.Reason)
+			assert.Equal(t, test.failedProbes,
+				objectSetPhase.(*GenericObjectSetPhase).Status.FailedProbes)
 		})
 	}
 }
@@ -108,7 +115,7 @@ func TestPhaseReconciler_ReconcileBackoff(t *testing.T) {
 	objectSetPhase.ClientObject().SetName("testPhaseOwner")
 	m := &phaseReconcilerMock{}
 	ownerStrategy := &ownerhandlingmocks.OwnerStrategyMock{}
-	r := newObjectSetPhaseReconciler(testScheme, m, lookup, ownerStrategy)
+	r := newObjectSetPhaseReconciler(testScheme, nil, m, lookup, ownerStrategy)
 
 	m.
 		On("ReconcilePhase", mock.Anything, objectSetPhase, objectSetPhase.GetPhase(), mock.Anything, previousList).
@@ -132,7 +139,7 @@ func TestPhaseReconciler_Teardown(t *testing.T) {
 	ownerStrategy := &ownerhandlingmocks.OwnerStrategyMock{}
 	m := &phaseReconcilerMock{}
 	m.On("TeardownPhase", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
-	r := newObjectSetPhaseReconciler(testScheme, m, lookup, ownerStrategy)
+	r := newObjectSetPhaseReconciler(testScheme, nil, m, lookup, ownerStrategy)
 	_, err := r.Teardown(context.Background(), objectSetPhase)
 	assert.NoError(t, err)
 	m.AssertCalled(t, "TeardownPhase", mock.Anything, mock.Anything, mock.Anything)
@@ -0,0 +1,118 @@
+package objectsetphases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// ParentMismatchError is returned when an ObjectSetPhase
+// does not match any phase declared by its parent ObjectSet.
+type ParentMismatchError struct {
+	Reason  string
+	Message string
+}
+
+func (e *ParentMismatchError) Error() string {
+	return e.Message
+}
+
+// parentValidationReconciler ensures that the ObjectSetPhase
+// belongs to a phase declared by its parent ObjectSet,
+// before any objects of the phase are reconciled.
+type parentValidationReconciler struct {
+	scheme       *runtime.Scheme
+	client       client.Reader
+	newObjectSet genericObjectSetFactory
+}
+
+func (r *parentValidationReconciler) Reconcile(
+	ctx context.Context, objectSetPhase genericObjectSetPhase,
+) (res ctrl.Result, err error) {
+	obj := objectSetPhase.ClientObject()
+	ownerRef := metav1.GetControllerOf(obj)
+	if ownerRef == nil {
+		return res, &ParentMismatchError{
+			Reason:  corev1alpha1.ReasonMissingParent,
+			Message: "ObjectSetPhase is not controlled by an ObjectSet",
+		}
+	}
+
+	objectSet := r.newObjectSet(r.scheme)
+	if err := r.client.Get(ctx, client.ObjectKey{
+		Name:      ownerRef.Name,
+		Namespace: obj.GetNamespace(),
+	}, objectSet.ClientObject()); errors.IsNotFound(err) {
+		return res, &ParentMismatchError{
+			Reason:  corev1alpha1.ReasonMissingParent,
+			Message: fmt.Sprintf("parent %s %q not found", ownerRef.Kind, ownerRef.Name),
+		}
+	} else if err != nil {
+		return res, fmt.Errorf("getting parent ObjectSet: %w", err)
+	}
+	if objectSet.ClientObject().GetUID() != ownerRef.UID {
+		return res, &ParentMismatchError{
+			Reason:  corev1alpha1.ReasonMissingParent,
+			Message: fmt.Sprintf("parent %s %q was recreated", ownerRef.Kind, ownerRef.Name),
+		}
+	}
+
+	if err := validateParentPhase(objectSet, objectSetPhase); err != nil {
+		return res, err
+	}
+	meta.RemoveStatusCondition(objectSetPhase.GetConditions(), corev1alpha1.ObjectSetPhaseInvalid)
+	return res, nil
+}
+
+// Checks that exactly one phase of the parent ObjectSet
+// maps to the given ObjectSetPhase and that their classes match.
+func validateParentPhase(
+	objectSet genericObjectSet, objectSetPhase genericObjectSetPhase,
+) error {
+	parentName := objectSet.ClientObject().GetName()
+	phaseName, ok := strings.CutPrefix(
+		objectSetPhase.ClientObject().GetName(), parentName+"-")
+	if !ok || len(phaseName) == 0 {
+		return &ParentMismatchError{
+			Reason: corev1alpha1.ReasonPhaseNotFound,
+			Message: fmt.Sprintf("name %q does not follow the <parent>-<phase> pattern of parent %q",
+				objectSetPhase.ClientObject().GetName(), parentName),
+		}
+	}
+
+	var matches []corev1alpha1.ObjectSetTemplatePhase
+	for _, phase := range objectSet.GetPhases() {
+		if phase.Name == phaseName {
+			matches = append(matches, phase)
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		return &ParentMismatchError{
+			Reason:  corev1alpha1.ReasonPhaseNotFound,
+			Message: fmt.Sprintf("phase %q is not declared by parent %q", phaseName, parentName),
+		}
+	case len(matches) > 1:
+		return &ParentMismatchError{
+			Reason:  corev1alpha1.ReasonDuplicatePhase,
+			Message: fmt.Sprintf("phase %q is declared %d times by parent %q", phaseName, len(matches), parentName),
+		}
+	case matches[0].Class != objectSetPhase.GetClass():
+		return &ParentMismatchError{
+			Reason: corev1alpha1.ReasonClassMismatch,
+			Message: fmt.Sprintf("class %q does not match class %q of phase %q in parent %q",
+				objectSetPhase.GetClass(), matches[0].Class, phaseName, parentName),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package objectsetphases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestParentValidationReconciler(t *testing.T) {
+	scheme := testutil.NewTestSchemeWithCoreV1Alpha1()
+
+	newObjectSetPhase := func() *GenericObjectSetPhase {
+		objectSetPhase := &GenericObjectSetPhase{}
+		objectSetPhase.Name = "my-set-phase-1"
+		objectSetPhase.Namespace = "test"
+		objectSetPhase.Labels = map[string]string{
+			corev1alpha1.ObjectSetPhaseClassLabel: "remote",
+		}
+		objectSetPhase.OwnerReferences = []metav1.OwnerReference{{
+			Kind:       "ObjectSet",
+			Name:       "my-set",
+			UID:        types.UID("1234"),
+			Controller: pointer.Bool(true),
+		}}
+		return objectSetPhase
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		c := testutil.NewClient()
+		r := &parentValidationReconciler{
+			scheme: scheme, client: c, newObjectSet: newGenericObjectSet,
+		}
+		c.
+			On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.ObjectSet"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				objectSet := args.Get(2).(*corev1alpha1.ObjectSet)
+				objectSet.Name = "my-set"
+				objectSet.UID = types.UID("1234")
+				objectSet.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
+					{Name: "phase-1", Class: "remote"},
+				}
+			}).
+			Return(nil)
+
+		objectSetPhase := newObjectSetPhase()
+		meta.SetStatusCondition(&objectSetPhase.Status.Conditions, metav1.Condition{
+			Type:   corev1alpha1.ObjectSetPhaseInvalid,
+			Status: metav1.ConditionTrue,
+		})
+		_, err := r.Reconcile(context.Background(), objectSetPhase)
+		require.NoError(t, err)
+		assert.Nil(t, meta.FindStatusCondition(
+			objectSetPhase.Status.Conditions, corev1alpha1.ObjectSetPhaseInvalid))
+	})
+
+	t.Run("parent not found", func(t *testing.T) {
+		c := testutil.NewClient()
+		r := &parentValidationReconciler{
+			scheme: scheme, client: c, newObjectSet: newGenericObjectSet,
+		}
+		c.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+		_, err := r.Reconcile(context.Background(), newObjectSetPhase())
+		var mismatchErr *ParentMismatchError
+		require.ErrorAs(t, err, &mismatchErr)
+		assert.Equal(t, "MissingParent", mismatchErr.Reason)
+	})
+
+	t.Run("not controlled", func(t *testing.T) {
+		r := &parentValidationReconciler{
+			scheme: scheme, client: testutil.NewClient(), newObjectSet: newGenericObjectSet,
+		}
+		objectSetPhase := newObjectSetPhase()
+		objectSetPhase.OwnerReferences = nil
+
+		_, err := r.Reconcile(context.Background(), objectSetPhase)
+		var mismatchErr *ParentMismatchError
+		require.ErrorAs(t, err, &mismatchErr)
+		assert.Equal(t, "MissingParent", mismatchErr.Reason)
+	})
+}
+
+func Test_validateParentPhase(t *testing.T) {
+	tests := []struct {
+		name           string
+		objectSetPhase string
+		phases         []corev1alpha1.ObjectSetTemplatePhase
+		expectedReason string
+	}{
+		{
+			name:           "valid",
+			objectSetPhase: "my-set-phase-1",
+			phases: []corev1alpha1.ObjectSetTemplatePhase{
+				{Name: "phase-0"},
+				{Name: "phase-1", Class: "remote"},
+			},
+		},
+		{
+			name:           "name without parent prefix",
+			objectSetPhase: "other-phase-1",
+			phases: []corev1alpha1.ObjectSetTemplatePhase{
+				{Name: "phase-1", Class: "remote"},
+			},
+			expectedReason: "PhaseNotFound",
+		},
+		{
+			name:           "phase not declared",
+			objectSetPhase: "my-set-phase-2",
+			phases: []corev1alpha1.ObjectSetTemplatePhase{
+				{Name: "phase-1", Class: "remote"},
+			},
+			expectedReason: "PhaseNotFound",
+		},
+		{
+			name:           "duplicate phase",
+			objectSetPhase: "my-set-phase-1",
+			phases: []corev1alpha1.ObjectSetTemplatePhase{
+				{Name: "phase-1", Class: "remote"},
+				{Name: "phase-1", Class: "remote"},
+			},
+			expectedReason: "DuplicatePhase",
+		},
+		{
+			name:           "class mismatch",
+			objectSetPhase: "my-set-phase-1",
+			phases: []corev1alpha1.ObjectSetTemplatePhase{
+				{Name: "phase-1", Class: "other"},
+			},
+			expectedReason: "ClassMismatch",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			objectSet := &GenericObjectSet{}
+			objectSet.Name = "my-set"
+			objectSet.Spec.Phases = test.phases
+
+			objectSetPhase := &GenericObjectSetPhase{}
+			objectSetPhase.Name = test.objectSetPhase
+			objectSetPhase.Labels = map[string]string{
+				corev1alpha1.ObjectSetPhaseClassLabel: "remote",
+			}
+
+			err := validateParentPhase(objectSet, objectSetPhase)
+			if len(test.expectedReason) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var mismatchErr *ParentMismatchError
+			require.ErrorAs(t, err, &mismatchErr)
+			assert.Equal(t, test.expectedReason, mismatchErr.Reason)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
 )
 
 type genericObjectSet interface {
@@ -20,12 +21,26 @@ type genericObjectSet interface {
 	SetPhases(phases []corev1alpha1.ObjectSetTemplatePhase)
 	GetAvailabilityProbes() []corev1alpha1.ObjectSetProbe
 	GetSuccessDelaySeconds() int32
+	GetAllowCriticalKinds() bool
 	SetRevision(revision int64)
 	GetRevision() int64
 	GetGeneration() int64
 	GetRemotePhases() []corev1alpha1.RemotePhaseReference
 	SetRemotePhases([]corev1alpha1.RemotePhaseReference)
 	SetStatusControllerOf([]corev1alpha1.ControlledObjectReference)
+	GetStatusControllerOf() []corev1alpha1.ControlledObjectReference
+	GetStatusRollout() *corev1alpha1.ObjectSetRolloutStatus
+	SetStatusRollout(*corev1alpha1.ObjectSetRolloutStatus)
+	GetStatusPhases() []corev1alpha1.ObjectSetRolloutPhase
+	SetStatusPhases([]corev1alpha1.ObjectSetRolloutPhase)
+	SetStatusDiff([]corev1alpha1.ObjectSetObjectDiff)
+	SetStatusManagerVersion(managerVersion string)
+	RecordObjectDiff(corev1alpha1.ObjectSetObjectDiff)
+	GetStatusConflicts() []corev1alpha1.ObjectSetObjectConflict
+	SetStatusConflicts([]corev1alpha1.ObjectSetObjectConflict)
+	RecordObjectConflict(corev1alpha1.ObjectSetObjectConflict)
+	SetStatusMappedFields(map[string]string)
+	SetStatusMappedField(destination, value string)
 }
 
 type genericObjectSetFactory func(
@@ -107,6 +122,10 @@ func (a *GenericObjectSet) GetSuccessDelaySeconds() int32 {
 	return a.Spec.SuccessDelaySeconds
 }
 
+func (a *GenericObjectSet) GetAllowCriticalKinds() bool {
+	return a.Spec.AllowCriticalKinds
+}
+
 func (a *GenericObjectSet) SetRevision(revision int64) {
 	a.Status.Revision = revision
 }
@@ -131,6 +150,61 @@ func (a *GenericObjectSet) SetStatusControllerOf(controllerOf []corev1alpha1.Con
 	a.Status.ControllerOf = controllerOf
 }
 
+func (a *GenericObjectSet) GetStatusControllerOf() []corev1alpha1.ControlledObjectReference {
+	return a.Status.ControllerOf
+}
+
+func (a *GenericObjectSet) SetStatusDiff(diff []corev1alpha1.ObjectSetObjectDiff) {
+	a.Status.Diff = diff
+}
+
+func (a *GenericObjectSet) SetStatusManagerVersion(managerVersion string) {
+	a.Status.ManagerVersion = managerVersion
+}
+
+func (a *GenericObjectSet) RecordObjectDiff(diff corev1alpha1.ObjectSetObjectDiff) {
+	a.Status.Diff = append(a.Status.Diff, diff)
+}
+
+func (a *GenericObjectSet) GetStatusConflicts() []corev1alpha1.ObjectSetObjectConflict {
+	return a.Status.Conflicts
+}
+
+func (a *GenericObjectSet) SetStatusConflicts(conflicts []corev1alpha1.ObjectSetObjectConflict) {
+	a.Status.Conflicts = conflicts
+}
+
+func (a *GenericObjectSet) RecordObjectConflict(conflict corev1alpha1.ObjectSetObjectConflict) {
+	a.Status.Conflicts = controllers.RecordObjectConflict(a.Status.Conflicts, conflict)
+}
+
+func (a *GenericObjectSet) GetStatusRollout() *corev1alpha1.ObjectSetRolloutStatus {
+	return a.Status.Rollout
+}
+
+func (a *GenericObjectSet) SetStatusRollout(rollout *corev1alpha1.ObjectSetRolloutStatus) {
+	a.Status.Rollout = rollout
+}
+
+func (a *GenericObjectSet) GetStatusPhases() []corev1alpha1.ObjectSetRolloutPhase {
+	return a.Status.Phases
+}
+
+func (a *GenericObjectSet) SetStatusPhases(phases []corev1alpha1.ObjectSetRolloutPhase) {
+	a.Status.Phases = phases
+}
+
+func (a *GenericObjectSet) SetStatusMappedFields(fields map[string]string) {
+	a.Status.MappedFields = fields
+}
+
+func (a *GenericObjectSet) SetStatusMappedField(destination, value string) {
+	if a.Status.MappedFields == nil {
+		a.Status.MappedFields = map[string]string{}
+	}
+	a.Status.MappedFields[destination] = value
+}
+
 type GenericClusterObjectSet struct {
 	corev1alpha1.ClusterObjectSet
 }
@@ -175,6 +249,10 @@ func (a *GenericClusterObjectSet) GetSuccessDelaySeconds() int32 {
 	return a.Spec.SuccessDelaySeconds
 }
 
+func (a *GenericClusterObjectSet) GetAllowCriticalKinds() bool {
+	return a.Spec.AllowCriticalKinds
+}
+
 func (a *GenericClusterObjectSet) SetRevision(revision int64) {
 	a.Status.Revision = revision
 }
@@ -199,6 +277,61 @@ func (a *GenericClusterObjectSet) SetStatusControllerOf(controllerOf []corev1alp
 	a.Status.ControllerOf = controllerOf
 }
 
+func (a *GenericClusterObjectSet) GetStatusControllerOf() []corev1alpha1.ControlledObjectReference {
+	return a.Status.ControllerOf
+}
+
+func (a *GenericClusterObjectSet) SetStatusDiff(diff []corev1alpha1.ObjectSetObjectDiff) {
+	a.Status.Diff = diff
+}
+
+func (a *GenericClusterObjectSet) SetStatusManagerVersion(managerVersion string) {
+	a.Status.ManagerVersion = managerVersion
+}
+
+func (a *GenericClusterObjectSet) RecordObjectDiff(diff corev1alpha1.ObjectSetObjectDiff) {
+	a.Status.Diff = append(a.Status.Diff, diff)
+}
+
+func (a *GenericClusterObjectSet) GetStatusConflicts() []corev1alpha1.ObjectSetObjectConflict {
+	return a.Status.Conflicts
+}
+
+func (a *GenericClusterObjectSet) SetStatusConflicts(conflicts []corev1alpha1.ObjectSetObjectConflict) {
+	a.Status.Conflicts = conflicts
+}
+
+func (a *GenericClusterObjectSet) RecordObjectConflict(conflict corev1alpha1.ObjectSetObjectConflict) {
+	a.Status.Conflicts = controllers.RecordObjectConflict(a.Status.Conflicts, conflict)
+}
+
+func (a *GenericClusterObjectSet) GetStatusRollout() *corev1alpha1.ObjectSetRolloutStatus {
+	return a.Status.Rollout
+}
+
+func (a *GenericClusterObjectSet) SetStatusRollout(rollout *corev1alpha1.ObjectSetRolloutStatus) {
+	a.Status.Rollout = rollout
+}
+
+func (a *GenericClusterObjectSet) GetStatusPhases() []corev1alpha1.ObjectSetRolloutPhase {
+	return a.Status.Phases
+}
+
+func (a *GenericClusterObjectSet) SetStatusPhases(phases []corev1alpha1.ObjectSetRolloutPhase) {
+	a.Status.Phases = phases
+}
+
+func (a *GenericClusterObjectSet) SetStatusMappedFields(fields map[string]string) {
+	a.Status.MappedFields = fields
+}
+
+func (a *GenericClusterObjectSet) SetStatusMappedField(destination, value string) {
+	if a.Status.MappedFields == nil {
+		a.Status.MappedFields = map[string]string{}
+	}
+	a.Status.MappedFields[destination] = value
+}
+
 func objectSetStatusPhase(conditions []metav1.Condition) corev1alpha1.ObjectSetStatusPhase {
 	if meta.IsStatusConditionTrue(
 		conditions,
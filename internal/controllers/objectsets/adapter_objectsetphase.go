@@ -11,13 +11,17 @@ import (
 type genericObjectSetPhase interface {
 	ClientObject() client.Object
 	GetConditions() []metav1.Condition
+	SetConditions(conditions []metav1.Condition)
 	IsPaused() bool
 	SetPhase(phase corev1alpha1.ObjectSetTemplatePhase)
 	SetPaused(paused bool)
 	SetAvailabilityProbes([]corev1alpha1.ObjectSetProbe)
+	SetAllowCriticalKinds(allow bool)
 	SetRevision(revision int64)
 	SetPrevious([]corev1alpha1.PreviousRevisionReference)
 	GetStatusControllerOf() []corev1alpha1.ControlledObjectReference
+	GetStatusFailedProbes() []string
+	GetStatusMappedFields() map[string]string
 }
 
 type genericObjectSetPhaseFactory func(
@@ -67,6 +71,10 @@ func (a *GenericObjectSetPhase) GetConditions() []metav1.Condition {
 	return a.Status.Conditions
 }
 
+func (a *GenericObjectSetPhase) SetConditions(conditions []metav1.Condition) {
+	a.Status.Conditions = conditions
+}
+
 func (a *GenericObjectSetPhase) SetPaused(paused bool) {
 	a.Spec.Paused = paused
 }
@@ -75,6 +83,10 @@ func (a *GenericObjectSetPhase) SetAvailabilityProbes(probes []corev1alpha1.Obje
 	a.Spec.AvailabilityProbes = probes
 }
 
+func (a *GenericObjectSetPhase) SetAllowCriticalKinds(allow bool) {
+	a.Spec.AllowCriticalKinds = allow
+}
+
 func (a *GenericObjectSetPhase) IsPaused() bool {
 	return a.Spec.Paused
 }
@@ -85,6 +97,8 @@ func (a *GenericObjectSetPhase) SetPhase(phase corev1alpha1.ObjectSetTemplatePha
 	}
 	a.Labels[corev1alpha1.ObjectSetPhaseClassLabel] = phase.Class
 	a.Spec.Objects = phase.Objects
+	a.Spec.Atomic = phase.Atomic
+	a.Spec.ApplyPolicy = phase.ApplyPolicy
 }
 
 func (a *GenericObjectSetPhase) SetRevision(revision int64) {
@@ -99,6 +113,14 @@ func (a *GenericObjectSetPhase) GetStatusControllerOf() []corev1alpha1.Controlle
 	return a.Status.ControllerOf
 }
 
+func (a *GenericObjectSetPhase) GetStatusFailedProbes() []string {
+	return a.Status.FailedProbes
+}
+
+func (a *GenericObjectSetPhase) GetStatusMappedFields() map[string]string {
+	return a.Status.MappedFields
+}
+
 type GenericClusterObjectSetPhase struct {
 	corev1alpha1.ClusterObjectSetPhase
 }
@@ -111,6 +133,10 @@ func (a *GenericClusterObjectSetPhase) GetConditions() []metav1.Condition {
 	return a.Status.Conditions
 }
 
+func (a *GenericClusterObjectSetPhase) SetConditions(conditions []metav1.Condition) {
+	a.Status.Conditions = conditions
+}
+
 func (a *GenericClusterObjectSetPhase) SetPaused(paused bool) {
 	a.Spec.Paused = paused
 }
@@ -123,12 +149,18 @@ func (a *GenericClusterObjectSetPhase) SetAvailabilityProbes(probes []corev1alph
 	a.Spec.AvailabilityProbes = probes
 }
 
+func (a *GenericClusterObjectSetPhase) SetAllowCriticalKinds(allow bool) {
+	a.Spec.AllowCriticalKinds = allow
+}
+
 func (a *GenericClusterObjectSetPhase) SetPhase(phase corev1alpha1.ObjectSetTemplatePhase) {
 	if a.Labels == nil {
 		a.Labels = map[string]string{}
 	}
 	a.Labels[corev1alpha1.ObjectSetPhaseClassLabel] = phase.Class
 	a.Spec.Objects = phase.Objects
+	a.Spec.Atomic = phase.Atomic
+	a.Spec.ApplyPolicy = phase.ApplyPolicy
 }
 
 func (a *GenericClusterObjectSetPhase) SetRevision(revision int64) {
@@ -142,3 +174,11 @@ func (a *GenericClusterObjectSetPhase) SetPrevious(previous []corev1alpha1.Previ
 func (a *GenericClusterObjectSetPhase) GetStatusControllerOf() []corev1alpha1.ControlledObjectReference {
 	return a.Status.ControllerOf
 }
+
+func (a *GenericClusterObjectSetPhase) GetStatusFailedProbes() []string {
+	return a.Status.FailedProbes
+}
+
+func (a *GenericClusterObjectSetPhase) GetStatusMappedFields() map[string]string {
+	return a.Status.MappedFields
+}
@@ -19,6 +19,8 @@ func TestGenericObjectSetPhase(t *testing.T) {
 		{},
 	}
 	assert.Equal(t, objectSet.Status.Conditions, objectSet.GetConditions())
+	objectSet.SetConditions(nil)
+	assert.Empty(t, objectSet.Status.Conditions)
 
 	objectSet.SetPaused(true)
 	assert.True(t, objectSet.IsPaused())
@@ -63,6 +65,8 @@ func TestGenericClusterObjectSetPhase(t *testing.T) {
 		{},
 	}
 	assert.Equal(t, objectSet.Status.Conditions, objectSet.GetConditions())
+	objectSet.SetConditions(nil)
+	assert.Empty(t, objectSet.Status.Conditions)
 
 	objectSet.SetPaused(true)
 	assert.True(t, objectSet.IsPaused())
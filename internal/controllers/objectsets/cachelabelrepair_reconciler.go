@@ -0,0 +1,144 @@
+package objectsets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/dynamiccache"
+)
+
+const (
+	// ObjectSets are checked for objects missing the dynamic cache label
+	// at least this often, because removing the label hides the object from the cache.
+	cacheLabelRepairInterval = 10 * time.Minute
+	// Gives the dynamic cache time to pick up repaired objects,
+	// before phases are reconciled again.
+	cacheLabelRepairRequeueDelay = 2 * time.Second
+)
+
+type cacheLabelRepairRecorder interface {
+	RecordDynamicCacheLabelRepairs(count int)
+}
+
+// cacheLabelRepairReconciler re-adds the dynamic cache label to objects
+// controlled by the ObjectSet, e.g. when it was stripped by a `kubectl apply`.
+// Without the label, objects are invisible to the dynamic cache
+// and phase reconciliation would try to create them again.
+type cacheLabelRepairReconciler struct {
+	writer         client.Writer
+	dynamicCache   client.Reader
+	uncachedClient client.Reader
+	recorder       cacheLabelRepairRecorder
+	identity       controllers.OperatorIdentity
+}
+
+func (r *cacheLabelRepairReconciler) Reconcile(
+	ctx context.Context, objectSet genericObjectSet,
+) (res ctrl.Result, err error) {
+	if objectSet.IsPaused() {
+		// Objects of paused ObjectSets must not be changed.
+		return res, nil
+	}
+
+	log := logr.FromContextOrDiscard(ctx)
+
+	gvks := controlledObjectGVKs(objectSet)
+	var repaired int
+	for _, ref := range objectSet.GetStatusControllerOf() {
+		gvk, ok := gvks[ref]
+		if !ok {
+			// Object is no longer part of this revision.
+			continue
+		}
+
+		labeled, err := r.repair(ctx, gvk, ref)
+		if err != nil {
+			return res, err
+		}
+		if labeled {
+			repaired++
+			log.Info("repaired missing dynamic cache label",
+				"object", client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace},
+				"kind", ref.Kind)
+		}
+	}
+
+	if repaired == 0 {
+		return res, nil
+	}
+	log.Info("repaired objects missing the dynamic cache label", "count", repaired)
+	if r.recorder != nil {
+		r.recorder.RecordDynamicCacheLabelRepairs(repaired)
+	}
+	res.RequeueAfter = cacheLabelRepairRequeueDelay
+	return res, nil
+}
+
+// repair labels the referenced object, if it exists on the cluster
+// but is missing from the dynamic cache.
+func (r *cacheLabelRepairReconciler) repair(
+	ctx context.Context, gvk schema.GroupVersionKind,
+	ref corev1alpha1.ControlledObjectReference,
+) (repaired bool, err error) {
+	key := client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	err = r.dynamicCache.Get(ctx, key, obj)
+	var notStartedErr *dynamiccache.CacheNotStartedError
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.As(err, &notStartedErr):
+		// Kind is not watched yet, nothing to repair.
+		return false, nil
+	case !k8serrors.IsNotFound(err):
+		return false, fmt.Errorf("getting %s from cache: %w", gvk.Kind, err)
+	}
+
+	err = r.uncachedClient.Get(ctx, key, obj)
+	if k8serrors.IsNotFound(err) {
+		// Really gone, will be recreated.
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting %s: %w", gvk.Kind, err)
+	}
+	if obj.GetLabels()[r.identity.DynamicCacheLabel()] == "True" {
+		// Cache has not caught up yet.
+		return false, nil
+	}
+
+	if _, err := controllers.AddDynamicCacheLabel(ctx, r.writer, r.identity, obj); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Returns the GroupVersionKind of all objects in local phases of the ObjectSet.
+func controlledObjectGVKs(
+	objectSet genericObjectSet,
+) map[corev1alpha1.ControlledObjectReference]schema.GroupVersionKind {
+	gvks := map[corev1alpha1.ControlledObjectReference]schema.GroupVersionKind{}
+	for _, phase := range objectSet.GetPhases() {
+		if len(phase.Class) > 0 {
+			// Objects of remote phases are managed elsewhere.
+			continue
+		}
+		for _, phaseObject := range phase.Objects {
+			gvks[controlledObjectReference(objectSet, phaseObject)] = phaseObject.Object.GroupVersionKind()
+		}
+	}
+	return gvks
+}
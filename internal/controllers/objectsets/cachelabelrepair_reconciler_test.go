@@ -0,0 +1,147 @@
+package objectsets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/dynamiccache"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+type cacheLabelRepairRecorderMock struct {
+	repairs int
+}
+
+func (m *cacheLabelRepairRecorderMock) RecordDynamicCacheLabelRepairs(count int) {
+	m.repairs += count
+}
+
+func Test_cacheLabelRepairReconciler(t *testing.T) {
+	newPhaseObject := func(kind, name string) corev1alpha1.ObjectSetObject {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind(kind)
+		obj.SetName(name)
+		return corev1alpha1.ObjectSetObject{Object: obj}
+	}
+	controlledRef := func(kind, name string) corev1alpha1.ControlledObjectReference {
+		return corev1alpha1.ControlledObjectReference{
+			Kind:      kind,
+			Name:      name,
+			Namespace: "test",
+		}
+	}
+
+	newObjectSet := func(lifecycleState corev1alpha1.ObjectSetLifecycleState) *GenericObjectSet {
+		return &GenericObjectSet{corev1alpha1.ObjectSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "current", Namespace: "test"},
+			Spec: corev1alpha1.ObjectSetSpec{
+				LifecycleState: lifecycleState,
+				ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+					Phases: []corev1alpha1.ObjectSetTemplatePhase{
+						{
+							Name: "phase",
+							Objects: []corev1alpha1.ObjectSetObject{
+								newPhaseObject("ConfigMap", "labeled"),
+								newPhaseObject("ConfigMap", "stripped"),
+								newPhaseObject("ConfigMap", "missing"),
+								newPhaseObject("Secret", "unwatched"),
+							},
+						},
+					},
+				},
+			},
+			Status: corev1alpha1.ObjectSetStatus{
+				ControllerOf: []corev1alpha1.ControlledObjectReference{
+					controlledRef("ConfigMap", "labeled"),
+					controlledRef("ConfigMap", "stripped"),
+					controlledRef("ConfigMap", "missing"),
+					controlledRef("Secret", "unwatched"),
+					controlledRef("ConfigMap", "released"),
+				},
+			},
+		}}
+	}
+
+	newClient := func() client.Client {
+		return fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: "labeled", Namespace: "test",
+				Labels: map[string]string{controllers.DynamicCacheLabel: "True"},
+			}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: "stripped", Namespace: "test",
+				Labels: map[string]string{"app": "test"},
+			}},
+		).Build()
+	}
+
+	newCache := func() *testutil.CtrlClient {
+		cache := testutil.NewClient()
+		cache.
+			On("Get", mock.Anything, client.ObjectKey{Name: "labeled", Namespace: "test"}, mock.Anything, mock.Anything).
+			Return(nil)
+		cache.
+			On("Get", mock.Anything, client.ObjectKey{Name: "unwatched", Namespace: "test"}, mock.Anything, mock.Anything).
+			Return(&dynamiccache.CacheNotStartedError{})
+		cache.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(k8serrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, ""))
+		return cache
+	}
+
+	t.Run("re-adds missing labels", func(t *testing.T) {
+		c := newClient()
+		recorder := &cacheLabelRepairRecorderMock{}
+		r := &cacheLabelRepairReconciler{
+			writer:         c,
+			dynamicCache:   newCache(),
+			uncachedClient: c,
+			recorder:       recorder,
+		}
+
+		ctx := context.Background()
+		res, err := r.Reconcile(ctx, newObjectSet(corev1alpha1.ObjectSetLifecycleStateActive))
+		require.NoError(t, err)
+		assert.Equal(t, cacheLabelRepairRequeueDelay, res.RequeueAfter)
+		assert.Equal(t, 1, recorder.repairs)
+
+		cm := &corev1.ConfigMap{}
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "stripped", Namespace: "test"}, cm))
+		assert.Equal(t, map[string]string{
+			"app":                         "test",
+			controllers.DynamicCacheLabel: "True",
+		}, cm.Labels)
+	})
+
+	t.Run("leaves paused ObjectSets alone", func(t *testing.T) {
+		c := newClient()
+		cache := newCache()
+		r := &cacheLabelRepairReconciler{
+			writer:         c,
+			dynamicCache:   cache,
+			uncachedClient: c,
+		}
+
+		ctx := context.Background()
+		res, err := r.Reconcile(ctx, newObjectSet(corev1alpha1.ObjectSetLifecycleStatePaused))
+		require.NoError(t, err)
+		assert.True(t, res.IsZero(), "unexpected requeue")
+
+		cache.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -6,24 +6,33 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/adapters"
 	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/dynamiccache"
+	"package-operator.run/package-operator/internal/environment"
 	"package-operator.run/package-operator/internal/metrics"
 	"package-operator.run/package-operator/internal/ownerhandling"
 	"package-operator.run/package-operator/internal/preflight"
+	"package-operator.run/package-operator/internal/tracing"
+	"package-operator.run/package-operator/internal/version"
 )
 
 // Generic reconciler for both ObjectSet and ClusterObjectSet objects.
@@ -37,19 +46,54 @@ type GenericObjectSetController struct {
 	reconciler []reconciler
 
 	recorder        metricsRecorder
+	statusWriter    *controllers.StatusWriter
 	dynamicCache    dynamicCache
 	teardownHandler teardownHandler
+	maintenance     *controllers.MaintenanceModeChecker
+	orphanMode      *controllers.OrphanModeChecker
+	rateLimiter     ratelimiter.RateLimiter
+	// Version of the running manager, reported in status.
+	managerVersion string
+	// Strips phase objects from archived ObjectSets.
+	archiveCompaction bool
+	// Restores phase objects of compacted ObjectSets leaving the Archived state.
+	rehydrator rehydrator
+	// Reconciles ObjectSets as paused and orphans their objects on teardown.
+	observeOnly bool
+	// Partition of ObjectSets reconciled by this manager replica.
+	shard controllers.Shard
+	// Only ObjectSets labeled with this identity are reconciled.
+	identity controllers.OperatorIdentity
+	// Delegates phases with a class to their handlers.
+	phaseClasses *phaseClassRouter
 }
 
 type reconciler interface {
 	Reconcile(ctx context.Context, objectSet genericObjectSet) (ctrl.Result, error)
 }
 
+var (
+	errRehydrationDisabled      = errors.New("no rehydrator configured")
+	errRehydratedObjectsChanged = errors.New("package image templates objects different from the compacted ObjectSet")
+)
+
+// Restores phase objects of compacted ObjectSets.
+type rehydrator interface {
+	environment.Sinker
+	Rehydrate(ctx context.Context, objectSet client.Object) ([]corev1alpha1.ObjectSetTemplatePhase, error)
+}
+
 type dynamicCache interface {
 	client.Reader
 	Source() source.Source
 	Free(ctx context.Context, obj client.Object) error
-	Watch(ctx context.Context, owner client.Object, obj runtime.Object) error
+	Watch(
+		ctx context.Context, owner client.Object, obj runtime.Object,
+		opts ...dynamiccache.WatchOption,
+	) error
+	ListByIndex(
+		ctx context.Context, field, value string,
+	) ([]unstructured.Unstructured, error)
 }
 
 type teardownHandler interface {
@@ -60,6 +104,7 @@ type teardownHandler interface {
 
 type metricsRecorder interface {
 	RecordObjectSetMetrics(objectSet metrics.GenericObjectSet)
+	RecordDynamicCacheLabelRepairs(count int)
 }
 
 func NewObjectSetController(
@@ -67,13 +112,14 @@ func NewObjectSetController(
 	scheme *runtime.Scheme,
 	dw dynamicCache, uc client.Reader,
 	r metricsRecorder, restMapper meta.RESTMapper,
+	opts ...controllers.PhaseReconcilerOption,
 ) *GenericObjectSetController {
 	return newGenericObjectSetController(
 		newGenericObjectSet,
 		newGenericObjectSetPhase,
 		adapters.NewObjectSlice,
 		c, log, scheme, dw, uc, r,
-		restMapper,
+		restMapper, opts...,
 	)
 }
 
@@ -82,13 +128,14 @@ func NewClusterObjectSetController(
 	scheme *runtime.Scheme,
 	dw dynamicCache, uc client.Reader,
 	r metricsRecorder, restMapper meta.RESTMapper,
+	opts ...controllers.PhaseReconcilerOption,
 ) *GenericObjectSetController {
 	return newGenericObjectSetController(
 		newGenericClusterObjectSet,
 		newGenericClusterObjectSetPhase,
 		adapters.NewClusterObjectSlice,
 		c, log, scheme, dw, uc, r,
-		restMapper,
+		restMapper, opts...,
 	)
 }
 
@@ -100,7 +147,11 @@ func newGenericObjectSetController(
 	scheme *runtime.Scheme,
 	dynamicCache dynamicCache, uncachedClient client.Reader,
 	recorder metricsRecorder, restMapper meta.RESTMapper,
+	opts ...controllers.PhaseReconcilerOption,
 ) *GenericObjectSetController {
+	var cfg controllers.PhaseReconcilerConfig
+	cfg.Option(opts...)
+
 	controller := &GenericObjectSetController{
 		newObjectSet:      newObjectSet,
 		newObjectSetPhase: newObjectSetPhase,
@@ -110,10 +161,21 @@ func newGenericObjectSetController(
 		scheme:       scheme,
 		dynamicCache: dynamicCache,
 		recorder:     recorder,
+		statusWriter: controllers.NewStatusWriter(client),
+		maintenance:  controllers.NewMaintenanceModeChecker(client),
+		orphanMode:   controllers.NewOrphanModeChecker(client),
+		identity:     cfg.OperatorIdentity,
+
+		managerVersion: version.Get().ApplicationVersion,
 	}
 
+	controller.phaseClasses = newPhaseClassRouter(
+		client, scheme,
+		newObjectSetRemotePhaseReconciler(
+			client, scheme, newObjectSetPhase),
+	)
 	phasesReconciler := newObjectSetPhasesReconciler(
-		scheme,
+		scheme, dynamicCache,
 		controllers.NewPhaseReconciler(
 			scheme, client,
 			dynamicCache,
@@ -121,12 +183,19 @@ func newGenericObjectSetController(
 			ownerhandling.NewNative(scheme),
 			preflight.List{
 				preflight.NewAPIExistence(restMapper),
+				preflight.NewCriticalKinds(),
+				preflight.NewPolicy(client),
 				preflight.NewNamespaceEscalation(restMapper),
-				preflight.NewDryRun(client),
+				preflight.NewDryRun(client, cfg.OperatorIdentity.FieldOwner()),
 			},
+			append([]controllers.PhaseReconcilerOption{
+				controllers.WithRESTMapper{RESTMapper: restMapper},
+				controllers.WithAdoptionPolicy{
+					AdoptionPolicyChecker: controllers.NewAdoptionPolicyChecker(client),
+				},
+			}, opts...)...,
 		),
-		newObjectSetRemotePhaseReconciler(
-			client, scheme, newObjectSetPhase),
+		controller.phaseClasses,
 		controllers.NewPreviousRevisionLookup(
 			scheme, func(s *runtime.Scheme) controllers.PreviousObjectSet {
 				return newObjectSet(s)
@@ -142,18 +211,74 @@ func newGenericObjectSetController(
 			newObjectSet: newObjectSet,
 		},
 		newObjectSliceLoadReconciler(scheme, client, newObjectSlice),
+		&cacheLabelRepairReconciler{
+			writer:         client,
+			dynamicCache:   dynamicCache,
+			uncachedClient: uncachedClient,
+			recorder:       recorder,
+			identity:       cfg.OperatorIdentity,
+		},
 		phasesReconciler,
+		&orphanCleanupReconciler{
+			scheme:       scheme,
+			client:       client,
+			dynamicCache: dynamicCache,
+			newObjectSet: newObjectSet,
+		},
 	}
 
 	return controller
 }
 
+// SetRateLimiter sets the workqueue rate limiter used when the controller is set up.
+func (c *GenericObjectSetController) SetRateLimiter(rl ratelimiter.RateLimiter) {
+	c.rateLimiter = rl
+}
+
+// SetArchiveCompaction enables stripping phase objects from archived ObjectSets.
+func (c *GenericObjectSetController) SetArchiveCompaction(enabled bool) {
+	c.archiveCompaction = enabled
+}
+
+// SetRehydrator enables rehydrating compacted ObjectSets leaving the Archived state,
+// e.g. to roll back to their revision.
+func (c *GenericObjectSetController) SetRehydrator(r rehydrator) {
+	c.rehydrator = r
+}
+
+// SetEnvironment passes the environment to the rehydrator, to template packages like the Package controller.
+func (c *GenericObjectSetController) SetEnvironment(env *manifestsv1alpha1.PackageEnvironment) {
+	if c.rehydrator != nil {
+		c.rehydrator.SetEnvironment(env)
+	}
+}
+
+// SetObserveOnly reconciles all ObjectSets as paused,
+// so changes to objects are only reported, and orphans their objects on teardown.
+func (c *GenericObjectSetController) SetObserveOnly(enabled bool) {
+	c.observeOnly = enabled
+}
+
+// SetShard limits reconciliation to ObjectSets owned by the given shard.
+func (c *GenericObjectSetController) SetShard(shard controllers.Shard) {
+	c.shard = shard
+}
+
+// RegisterPhaseHandler registers a handler executing phases in-process.
+// The handler is used for phases of the given class, if no PhaseClass of that name exists,
+// and for PhaseClasses referencing it via the InProcess handler.
+func (c *GenericObjectSetController) RegisterPhaseHandler(name string, handler PhaseHandler) {
+	c.phaseClasses.Register(name, handler)
+}
+
 func (c *GenericObjectSetController) SetupWithManager(mgr ctrl.Manager) error {
 	objectSet := c.newObjectSet(c.scheme).ClientObject()
 	objectSetPhase := c.newObjectSetPhase(c.scheme).ClientObject()
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(objectSet, builder.WithPredicates(&predicate.GenerationChangedPredicate{})).
+		WithOptions(controller.Options{RateLimiter: c.rateLimiter}).
+		For(objectSet, builder.WithPredicates(
+			&predicate.GenerationChangedPredicate{}, c.identity.Predicate())).
 		Owns(objectSetPhase).
 		Watches(c.dynamicCache.Source(), &handler.EnqueueRequestForOwner{
 			OwnerType:    objectSet,
@@ -175,11 +300,23 @@ func (c *GenericObjectSetController) Reconcile(
 	defer log.Info("reconciled")
 	ctx = logr.NewContext(ctx, log)
 
+	ctx, span := tracing.Start(ctx, "Reconcile ObjectSet", tracing.RequestAttributes(req)...)
+	defer func() { tracing.End(span, err) }()
+
 	objectSet := c.newObjectSet(c.scheme)
 	if err := c.client.Get(
 		ctx, req.NamespacedName, objectSet.ClientObject()); err != nil {
 		return res, client.IgnoreNotFound(err)
 	}
+	if !c.identity.Owns(objectSet.ClientObject()) {
+		// Reconciled by another Package Operator installation.
+		return res, nil
+	}
+	if !c.shard.Owns(objectSet.ClientObject()) {
+		// Reconciled by another manager replica.
+		return res, nil
+	}
+	original := objectSet.ClientObject().DeepCopyObject().(client.Object)
 	defer func() {
 		if err != nil {
 			return
@@ -189,13 +326,45 @@ func (c *GenericObjectSetController) Reconcile(
 		}
 	}()
 
+	if controllers.IsCompacted(objectSet.ClientObject()) && !objectSet.IsArchived() &&
+		objectSet.ClientObject().GetDeletionTimestamp().IsZero() {
+		// Leaving the Archived state, e.g. to roll back to this revision.
+		return res, c.rehydrate(ctx, original, objectSet)
+	}
+
 	if meta.IsStatusConditionTrue(*objectSet.GetConditions(), corev1alpha1.ObjectSetArchived) {
-		// We don't want to touch this object anymore.
+		// We don't want to touch this object anymore,
+		// except for removing object contents that are no longer needed.
+		if c.archiveCompaction {
+			return res, c.compactArchived(ctx, objectSet)
+		}
 		return res, nil
 	}
 
+	inMaintenance, err := c.maintenance.IsInMaintenance(ctx, objectSet.ClientObject())
+	if err != nil {
+		return res, err
+	}
+
 	if !objectSet.ClientObject().GetDeletionTimestamp().IsZero() ||
 		objectSet.IsArchived() {
+		if inMaintenance {
+			// Teardown has to wait until maintenance is over.
+			return ctrl.Result{RequeueAfter: controllers.MaintenanceModeRequeueInterval}, nil
+		}
+		if c.observeOnly {
+			// Objects have not been changed, so leave them as they are.
+			objectSet = &orphanObjectSet{genericObjectSet: objectSet}
+		} else if !objectSet.ClientObject().GetDeletionTimestamp().IsZero() {
+			orphaning, err := c.orphanMode.IsOrphaning(ctx)
+			if err != nil {
+				return res, err
+			}
+			if orphaning {
+				// Leave all objects on the cluster, e.g. when Package Operator is uninstalled.
+				objectSet = &orphanObjectSet{genericObjectSet: objectSet}
+			}
+		}
 		if err := c.handleDeletionAndArchival(ctx, objectSet); err != nil {
 			return res, err
 		}
@@ -206,13 +375,17 @@ func (c *GenericObjectSetController) Reconcile(
 			return res, nil
 		}
 
-		return res, c.updateStatus(ctx, objectSet)
+		return c.updateStatus(ctx, original, objectSet, res)
 	}
 
 	if err := controllers.EnsureCachedFinalizer(ctx, c.client, objectSet.ClientObject()); err != nil {
 		return res, err
 	}
 
+	if inMaintenance || c.observeOnly {
+		// Reconcile like a paused ObjectSet, so only status is reported.
+		objectSet = &maintenanceObjectSet{genericObjectSet: objectSet}
+	}
 	for _, r := range c.reconciler {
 		res, err = r.Reconcile(ctx, objectSet)
 		if err != nil || !res.IsZero() {
@@ -220,39 +393,170 @@ func (c *GenericObjectSetController) Reconcile(
 		}
 	}
 	if err != nil {
-		return res, c.updateStatusError(ctx, objectSet, err)
+		return c.updateStatusError(ctx, original, objectSet, err)
 	}
 
 	if err := c.reportPausedCondition(ctx, objectSet); err != nil {
 		return res, fmt.Errorf("getting paused status: %w", err)
 	}
+	if inMaintenance {
+		reportPausedReason(objectSet,
+			corev1alpha1.ReasonMaintenanceMode, "Package Operator is in maintenance mode.")
+		if res.IsZero() {
+			res.RequeueAfter = controllers.MaintenanceModeRequeueInterval
+		}
+	} else {
+		if c.observeOnly {
+			reportPausedReason(objectSet,
+				corev1alpha1.ReasonObserveOnly, "Package Operator is in observe-only mode.")
+		}
+		if len(c.managerVersion) > 0 {
+			objectSet.SetStatusManagerVersion(c.managerVersion)
+		}
+	}
+	if res.IsZero() && len(objectSet.GetRemotePhases()) > 0 {
+		// Check again, if remote phase managers are still sending heartbeats.
+		res.RequeueAfter = controllers.RemotePhaseHeartbeatRecheckInterval
+	}
+	if res.IsZero() {
+		// Periodically check for objects missing the dynamic cache label.
+		res.RequeueAfter = cacheLabelRepairInterval
+	}
 
-	return res, c.updateStatus(ctx, objectSet)
+	return c.updateStatus(ctx, original, objectSet, res)
 }
 
-func (c *GenericObjectSetController) updateStatusError(ctx context.Context, objectSet genericObjectSet,
-	reconcileErr error,
+// Strips phase objects of an archived ObjectSet down to their identity,
+// so archived revisions don't keep consuming etcd space for their full object specs.
+func (c *GenericObjectSetController) compactArchived(
+	ctx context.Context, objectSet genericObjectSet,
+) error {
+	obj := objectSet.ClientObject()
+	if controllers.IsCompacted(obj) {
+		return nil
+	}
+
+	objectSet.SetPhases(controllers.CompactPhases(objectSet.GetPhases()))
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[corev1alpha1.ObjectSetCompactedAnnotation] = "True"
+	obj.SetAnnotations(annotations)
+
+	if err := c.client.Update(ctx, obj); err != nil {
+		return fmt.Errorf("compacting archived ObjectSet: %w", err)
+	}
+	return nil
+}
+
+// Restores phase objects of a compacted ObjectSet by templating its package image again.
+func (c *GenericObjectSetController) rehydrate(
+	ctx context.Context, original client.Object, objectSet genericObjectSet,
 ) error {
-	var preflightError *preflight.Error
-	if errors.As(reconcileErr, &preflightError) {
+	err := errRehydrationDisabled
+	var phases []corev1alpha1.ObjectSetTemplatePhase
+	if c.rehydrator != nil {
+		phases, err = c.rehydrator.Rehydrate(ctx, objectSet.ClientObject())
+	}
+	if err == nil && !equality.Semantic.DeepEqual(controllers.CompactPhases(phases), objectSet.GetPhases()) {
+		err = errRehydratedObjectsChanged
+	}
+	if err != nil {
 		meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
-			Type:               corev1alpha1.ObjectSetAvailable,
-			Status:             metav1.ConditionFalse,
+			Type:               corev1alpha1.ObjectSetArchived,
+			Status:             metav1.ConditionTrue,
+			Reason:             corev1alpha1.ReasonArchived,
+			Message:            fmt.Sprintf("Rehydration failed: %v", err),
 			ObservedGeneration: objectSet.GetGeneration(),
-			Reason:             "PreflightError",
-			Message:            preflightError.Error(),
 		})
-		return c.updateStatus(ctx, objectSet)
+		if _, updateErr := c.updateStatus(ctx, original, objectSet, ctrl.Result{}); updateErr != nil {
+			return updateErr
+		}
+		return fmt.Errorf("rehydrating compacted ObjectSet: %w", err)
 	}
-	return reconcileErr
+
+	// Reconcile like before archival from now on.
+	// Rehydration is retried by the next reconcile, if the spec update below fails.
+	obj := objectSet.ClientObject()
+	meta.RemoveStatusCondition(objectSet.GetConditions(), corev1alpha1.ObjectSetArchived)
+	if err := c.client.Status().Update(ctx, obj); err != nil {
+		return fmt.Errorf("removing Archived condition: %w", err)
+	}
+
+	objectSet.SetPhases(phases)
+	annotations := obj.GetAnnotations()
+	delete(annotations, corev1alpha1.ObjectSetCompactedAnnotation)
+	obj.SetAnnotations(annotations)
+	if err := c.client.Update(ctx, obj); err != nil {
+		return fmt.Errorf("rehydrating compacted ObjectSet: %w", err)
+	}
+	return nil
+}
+
+// Treats the ObjectSet as paused while in maintenance or observe-only mode.
+type maintenanceObjectSet struct {
+	genericObjectSet
 }
 
-func (c *GenericObjectSetController) updateStatus(ctx context.Context, objectSet genericObjectSet) error {
+func (a *maintenanceObjectSet) IsPaused() bool {
+	return true
+}
+
+// Orphans all objects of the ObjectSet on teardown.
+type orphanObjectSet struct {
+	genericObjectSet
+}
+
+func (a *orphanObjectSet) GetPhases() []corev1alpha1.ObjectSetTemplatePhase {
+	phases := a.genericObjectSet.GetPhases()
+	orphanPhases := make([]corev1alpha1.ObjectSetTemplatePhase, len(phases))
+	for i := range phases {
+		orphanPhases[i] = controllers.OrphanPhase(phases[i])
+	}
+	return orphanPhases
+}
+
+// Explains why the ObjectSet is reported as Paused.
+func reportPausedReason(objectSet genericObjectSet, reason, message string) {
+	pausedCond := meta.FindStatusCondition(
+		*objectSet.GetConditions(), corev1alpha1.ObjectSetPaused)
+	if pausedCond == nil || pausedCond.Status != metav1.ConditionTrue {
+		return
+	}
+	pausedCond.Reason = reason
+	pausedCond.Message = message
+}
+
+func (c *GenericObjectSetController) updateStatusError(
+	ctx context.Context, original client.Object, objectSet genericObjectSet,
+	reconcileErr error,
+) (ctrl.Result, error) {
+	if controllers.ClassifyError(reconcileErr) == controllers.ErrorClassRetryable {
+		return ctrl.Result{}, reconcileErr
+	}
+
+	// Retrying won't help, report and wait for the ObjectSet or its objects to change.
+	reason, message := controllers.ErrorCondition(reconcileErr)
+	meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
+		Type:               corev1alpha1.ObjectSetAvailable,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: objectSet.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+	return c.updateStatus(ctx, original, objectSet, ctrl.Result{})
+}
+
+func (c *GenericObjectSetController) updateStatus(
+	ctx context.Context, original client.Object, objectSet genericObjectSet, res ctrl.Result,
+) (ctrl.Result, error) {
 	objectSet.UpdateStatusPhase()
-	if err := c.client.Status().Update(ctx, objectSet.ClientObject()); err != nil {
-		return fmt.Errorf("updating ObjectSet status: %w", err)
+	res, err := c.statusWriter.Update(ctx, original, objectSet.ClientObject(), res)
+	if err != nil {
+		return res, fmt.Errorf("updating ObjectSet status: %w", err)
 	}
-	return nil
+	return res, nil
 }
 
 func (c *GenericObjectSetController) reportPausedCondition(ctx context.Context, objectSet genericObjectSet) error {
@@ -276,7 +580,7 @@ func (c *GenericObjectSetController) reportPausedCondition(ctx context.Context,
 			Type:               corev1alpha1.ObjectSetPaused,
 			Status:             metav1.ConditionUnknown,
 			ObservedGeneration: objectSet.GetGeneration(),
-			Reason:             "PartiallyPaused",
+			Reason:             corev1alpha1.ReasonPartiallyPaused,
 			Message:            "Waiting for ObjectSetPhases.",
 		})
 
@@ -286,7 +590,7 @@ func (c *GenericObjectSetController) reportPausedCondition(ctx context.Context,
 			Type:               corev1alpha1.ObjectSetPaused,
 			Status:             metav1.ConditionTrue,
 			ObservedGeneration: objectSet.GetGeneration(),
-			Reason:             "Paused",
+			Reason:             corev1alpha1.ReasonPaused,
 			Message:            "Lifecycle state set to paused.",
 		})
 
@@ -345,7 +649,7 @@ func (c *GenericObjectSetController) handleDeletionAndArchival(
 			meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
 				Type:               corev1alpha1.ObjectSetArchived,
 				Status:             metav1.ConditionFalse,
-				Reason:             "ArchivalInProgress",
+				Reason:             corev1alpha1.ReasonArchivalInProgress,
 				Message:            "Object teardown in progress.",
 				ObservedGeneration: objectSet.GetGeneration(),
 			})
@@ -365,7 +669,7 @@ func (c *GenericObjectSetController) handleDeletionAndArchival(
 		meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
 			Type:               corev1alpha1.ObjectSetArchived,
 			Status:             metav1.ConditionTrue,
-			Reason:             "Archived",
+			Reason:             corev1alpha1.ReasonArchived,
 			ObservedGeneration: objectSet.GetGeneration(),
 		})
 		objectSet.SetStatusControllerOf(nil) // we are no longer controlling anything.
@@ -3,6 +3,7 @@ package objectsets
 import (
 	"context"
 	goerrors "errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -10,11 +11,15 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/preflight"
 	"package-operator.run/package-operator/internal/testutil"
@@ -52,9 +57,26 @@ func (r *revisionReconcilerMock) Reconcile(
 	return args.Get(0).(ctrl.Result), args.Error(1)
 }
 
+type rehydratorMock struct {
+	mock.Mock
+}
+
+func (r *rehydratorMock) Rehydrate(
+	ctx context.Context, objectSet client.Object,
+) ([]corev1alpha1.ObjectSetTemplatePhase, error) {
+	args := r.Called(ctx, objectSet)
+	phases, _ := args.Get(0).([]corev1alpha1.ObjectSetTemplatePhase)
+	return phases, args.Error(1)
+}
+
+func (r *rehydratorMock) SetEnvironment(env *manifestsv1alpha1.PackageEnvironment) {
+	r.Called(env)
+}
+
 func TestGenericObjectSetController_Reconcile(t *testing.T) {
 	tests := []struct {
 		name                   string
+		expectedResult         ctrl.Result
 		getObjectSetPhaseError error
 		deletionTimestamp      *metav1.Time
 		condition              metav1.Condition
@@ -80,7 +102,8 @@ func TestGenericObjectSetController_Reconcile(t *testing.T) {
 			deletionTimestamp: &metav1.Time{Time: time.Now()},
 		},
 		{
-			name: "run all the way through",
+			name:           "run all the way through",
+			expectedResult: ctrl.Result{RequeueAfter: cacheLabelRepairInterval},
 		},
 	}
 	for _, test := range tests {
@@ -125,7 +148,7 @@ func TestGenericObjectSetController_Reconcile(t *testing.T) {
 				Return(test.getObjectSetPhaseError)
 
 			res, err := controller.Reconcile(context.Background(), ctrl.Request{})
-			assert.Empty(t, res)
+			assert.Equal(t, test.expectedResult, res)
 			assert.NoError(t, err)
 
 			if test.getObjectSetPhaseError != nil || test.condition.Type == corev1alpha1.ObjectSetArchived {
@@ -155,6 +178,205 @@ func TestGenericObjectSetController_Reconcile(t *testing.T) {
 	}
 }
 
+func TestOrphanObjectSet(t *testing.T) {
+	t.Parallel()
+
+	objectSet := &GenericObjectSet{}
+	objectSet.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
+		{Name: "a", Objects: []corev1alpha1.ObjectSetObject{{}}},
+		{Name: "b", Objects: []corev1alpha1.ObjectSetObject{{}, {}}},
+	}
+
+	phases := (&orphanObjectSet{genericObjectSet: objectSet}).GetPhases()
+	if assert.Len(t, phases, 2) {
+		for _, phase := range phases {
+			for _, obj := range phase.Objects {
+				assert.Equal(t, corev1alpha1.ObjectSetObjectDeletionPolicyOrphan, obj.DeletionPolicy)
+			}
+		}
+	}
+	assert.Empty(t, objectSet.Spec.Phases[0].Objects[0].DeletionPolicy)
+}
+
+func TestGenericObjectSetController_compactArchived(t *testing.T) {
+	controller, c, _, _, _ := newControllerAndMocks()
+	controller.SetArchiveCompaction(true)
+
+	cm := unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetName("cm")
+	cm.SetLabels(map[string]string{"app": "test"})
+	require.NoError(t, unstructured.SetNestedField(cm.Object, "value", "data", "key"))
+
+	objectSet := GenericObjectSet{}
+	objectSet.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStateArchived
+	objectSet.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
+		{Name: "a", Objects: []corev1alpha1.ObjectSetObject{{Object: cm}}},
+	}
+	objectSet.Status.Conditions = []metav1.Condition{{
+		Type:   corev1alpha1.ObjectSetArchived,
+		Status: metav1.ConditionTrue,
+	}}
+
+	c.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			arg := args.Get(2).(*corev1alpha1.ObjectSet)
+			objectSet.DeepCopyInto(arg)
+		}).
+		Return(nil)
+	var updated *corev1alpha1.ObjectSet
+	c.On("Update", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			updated = args.Get(1).(*corev1alpha1.ObjectSet)
+		}).
+		Return(nil)
+
+	_, err := controller.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, "True", updated.Annotations[corev1alpha1.ObjectSetCompactedAnnotation])
+	compactedObj := updated.Spec.Phases[0].Objects[0].Object
+	assert.Equal(t, "cm", compactedObj.GetName())
+	assert.Equal(t, "ConfigMap", compactedObj.GetKind())
+	assert.Empty(t, compactedObj.GetLabels())
+	assert.NotContains(t, compactedObj.Object, "data")
+
+	// Already compacted ObjectSets are left alone.
+	objectSet.Annotations = updated.Annotations
+	_, err = controller.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+	c.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestGenericObjectSetController_rehydrate(t *testing.T) {
+	cm := unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetName("cm")
+	require.NoError(t, unstructured.SetNestedField(cm.Object, "value", "data", "key"))
+	phases := []corev1alpha1.ObjectSetTemplatePhase{
+		{Name: "a", Objects: []corev1alpha1.ObjectSetObject{{Object: cm}}},
+	}
+
+	// Compacted ObjectSet requested to leave the Archived state.
+	objectSet := GenericObjectSet{}
+	objectSet.Annotations = map[string]string{corev1alpha1.ObjectSetCompactedAnnotation: "True"}
+	objectSet.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStateActive
+	objectSet.Spec.Phases = controllers.CompactPhases(phases)
+	objectSet.Status.Conditions = []metav1.Condition{{
+		Type:   corev1alpha1.ObjectSetArchived,
+		Status: metav1.ConditionTrue,
+	}}
+
+	t.Run("restores phase objects", func(t *testing.T) {
+		controller, c, _, _, _ := newControllerAndMocks()
+		r := &rehydratorMock{}
+		controller.SetRehydrator(r)
+
+		c.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				objectSet.DeepCopyInto(args.Get(2).(*corev1alpha1.ObjectSet))
+			}).
+			Return(nil)
+		var updated *corev1alpha1.ObjectSet
+		c.On("Update", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				updated = args.Get(1).(*corev1alpha1.ObjectSet).DeepCopy()
+			}).
+			Return(nil)
+		var status *corev1alpha1.ObjectSet
+		c.StatusMock.On("Update", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				status = args.Get(1).(*corev1alpha1.ObjectSet).DeepCopy()
+			}).
+			Return(nil)
+		r.On("Rehydrate", mock.Anything, mock.Anything).Return(phases, nil)
+
+		_, err := controller.Reconcile(context.Background(), ctrl.Request{})
+		require.NoError(t, err)
+		require.NotNil(t, updated)
+		assert.NotContains(t, updated.Annotations, corev1alpha1.ObjectSetCompactedAnnotation)
+		assert.Equal(t, phases, updated.Spec.Phases)
+		// Reconciled like before archival from now on.
+		require.NotNil(t, status)
+		assert.Nil(t, meta.FindStatusCondition(status.Status.Conditions, corev1alpha1.ObjectSetArchived))
+	})
+
+	t.Run("rejects different objects", func(t *testing.T) {
+		controller, c, _, _, _ := newControllerAndMocks()
+		r := &rehydratorMock{}
+		controller.SetRehydrator(r)
+
+		c.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				objectSet.DeepCopyInto(args.Get(2).(*corev1alpha1.ObjectSet))
+			}).
+			Return(nil)
+		var status *corev1alpha1.ObjectSet
+		c.StatusMock.On("Update", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				status = args.Get(1).(*corev1alpha1.ObjectSet)
+			}).
+			Return(nil)
+		renamed := []corev1alpha1.ObjectSetTemplatePhase{*phases[0].DeepCopy()}
+		renamed[0].Objects[0].Object.SetName("other")
+		r.On("Rehydrate", mock.Anything, mock.Anything).Return(renamed, nil)
+
+		_, err := controller.Reconcile(context.Background(), ctrl.Request{})
+		require.ErrorIs(t, err, errRehydratedObjectsChanged)
+		c.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		require.NotNil(t, status)
+		archived := meta.FindStatusCondition(status.Status.Conditions, corev1alpha1.ObjectSetArchived)
+		if assert.NotNil(t, archived) {
+			assert.Equal(t, metav1.ConditionTrue, archived.Status)
+			assert.Contains(t, archived.Message, "Rehydration failed")
+		}
+	})
+}
+
+func TestGenericObjectSetController_Reconcile_otherShard(t *testing.T) {
+	controller, c, _, _, _ := newControllerAndMocks()
+	controller.SetShard(controllers.Shard{Index: 0, Count: 2})
+
+	objectSet := GenericObjectSet{}
+	objectSet.Labels = map[string]string{controllers.ShardLabel: "1"}
+
+	c.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			arg := args.Get(2).(*corev1alpha1.ObjectSet)
+			objectSet.DeepCopyInto(arg)
+		}).
+		Return(nil)
+
+	res, err := controller.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	c.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGenericObjectSetController_Reconcile_otherOperatorIdentity(t *testing.T) {
+	controller, c, _, _, _ := newControllerAndMocks()
+	controller.identity = controllers.OperatorIdentity{Name: "tenant-a"}
+
+	objectSet := GenericObjectSet{}
+	objectSet.Labels = map[string]string{controllers.OperatorIdentityLabel: "tenant-b"}
+
+	c.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			arg := args.Get(2).(*corev1alpha1.ObjectSet)
+			objectSet.DeepCopyInto(arg)
+		}).
+		Return(nil)
+
+	res, err := controller.Reconcile(context.Background(), ctrl.Request{})
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	c.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestGenericObjectSetController_areRemotePhasesPaused_AllPhasesFound(t *testing.T) {
 	pausedCond := metav1.Condition{
 		Type:   corev1alpha1.ObjectSetPaused,
@@ -397,7 +619,7 @@ func TestGenericObjectSetController_updateStatusError(t *testing.T) {
 
 		c, _, _, _, _ := newControllerAndMocks()
 		ctx := context.Background()
-		err := c.updateStatusError(ctx, objectSet, errTest)
+		_, err := c.updateStatusError(ctx, objectSet.DeepCopy(), objectSet, errTest)
 		assert.EqualError(t, err, "explosion")
 	})
 
@@ -413,10 +635,37 @@ func TestGenericObjectSetController_updateStatusError(t *testing.T) {
 			Return(nil)
 
 		ctx := context.Background()
-		err := c.updateStatusError(
-			ctx, objectSet, &preflight.Error{})
+		_, err := c.updateStatusError(
+			ctx, objectSet.DeepCopy(), objectSet, &preflight.Error{})
+		require.NoError(t, err)
+
+		client.StatusMock.AssertExpectations(t)
+	})
+
+	t.Run("reports terminal error without requeue", func(t *testing.T) {
+		objectSet := &GenericObjectSet{
+			ObjectSet: corev1alpha1.ObjectSet{},
+		}
+
+		c, client, _, _, _ := newControllerAndMocks()
+
+		client.StatusMock.
+			On("Update", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+
+		ctx := context.Background()
+		res, err := c.updateStatusError(
+			ctx, objectSet.DeepCopy(), objectSet,
+			fmt.Errorf("phase: %w", controllers.RevisionCollisionError{}))
 		require.NoError(t, err)
+		assert.True(t, res.IsZero())
 
+		availableCond := meta.FindStatusCondition(
+			objectSet.Status.Conditions, corev1alpha1.ObjectSetAvailable)
+		if assert.NotNil(t, availableCond) {
+			assert.Equal(t, metav1.ConditionFalse, availableCond.Status)
+			assert.Equal(t, corev1alpha1.ReasonTerminalError, availableCond.Reason)
+		}
 		client.StatusMock.AssertExpectations(t)
 	})
 }
@@ -439,6 +688,7 @@ func newControllerAndMocks() (
 		log:               ctrl.Log.WithName("controllers"),
 		scheme:            scheme,
 		dynamicCache:      dc,
+		statusWriter:      controllers.NewStatusWriter(c),
 	}
 	pr := &objectSetPhasesReconcilerMock{}
 
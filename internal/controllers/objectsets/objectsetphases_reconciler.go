@@ -3,6 +3,7 @@ package objectsets
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -23,11 +24,13 @@ import (
 type objectSetPhasesReconciler struct {
 	cfg                     objectSetPhasesReconcilerConfig
 	scheme                  *runtime.Scheme
+	dynamicCache            controllers.PhaseCache
 	phaseReconciler         phaseReconciler
 	remotePhase             remotePhaseReconciler
 	lookupPreviousRevisions lookupPreviousRevisions
 	ownerStrategy           ownerStrategy
 	backoff                 *flowcontrol.Backoff
+	failureTracker          *probing.FailureTracker
 }
 
 type ownerStrategy interface {
@@ -37,6 +40,7 @@ type ownerStrategy interface {
 
 func newObjectSetPhasesReconciler(
 	scheme *runtime.Scheme,
+	dynamicCache controllers.PhaseCache,
 	phaseReconciler phaseReconciler,
 	remotePhase remotePhaseReconciler,
 	lookupPreviousRevisions lookupPreviousRevisions,
@@ -50,14 +54,17 @@ func newObjectSetPhasesReconciler(
 	return &objectSetPhasesReconciler{
 		cfg:                     cfg,
 		scheme:                  scheme,
+		dynamicCache:            dynamicCache,
 		phaseReconciler:         phaseReconciler,
 		remotePhase:             remotePhase,
 		lookupPreviousRevisions: lookupPreviousRevisions,
 		ownerStrategy:           ownerhandling.NewNative(scheme),
 		backoff:                 cfg.GetBackoff(),
+		failureTracker:          probing.NewFailureTracker(),
 	}
 }
 
+// Reconciles phases with a class, which are not executed by the local phaseReconciler.
 type remotePhaseReconciler interface {
 	Reconcile(
 		ctx context.Context, objectSet genericObjectSet,
@@ -92,8 +99,18 @@ func (r *objectSetPhasesReconciler) Reconcile(
 	defer r.backoff.GC()
 
 	controllers.DeleteMappedConditions(ctx, objectSet.GetConditions())
+	// Reported again by the remote phase reconciler.
+	meta.RemoveStatusCondition(objectSet.GetConditions(), corev1alpha1.ObjectSetRemoteUnreachable)
+	objectSet.SetStatusMappedFields(nil)
+	// Diff is recomputed by the PhaseReconciler while paused.
+	objectSet.SetStatusDiff(nil)
+	// Conflicts are recorded again by the PhaseReconciler, while other field managers keep changing objects.
+	if conflicts := objectSet.GetStatusConflicts(); len(conflicts) > 0 {
+		objectSet.SetStatusConflicts(controllers.PruneObjectConflicts(conflicts, r.cfg.Clock.Now()))
+	}
 
 	controllerOf, probingResult, err := r.reconcile(ctx, objectSet)
+	r.updateConflictCondition(objectSet)
 	if controllers.IsExternalResourceNotFound(err) {
 		id := string(objectSet.ClientObject().GetUID())
 
@@ -112,7 +129,7 @@ func (r *objectSetPhasesReconciler) Reconcile(
 		meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
 			Type:               corev1alpha1.ObjectSetInTransition,
 			Status:             metav1.ConditionTrue,
-			Reason:             "InTransition",
+			Reason:             corev1alpha1.ReasonInTransition,
 			Message:            "ObjectSet is still rolling out or is being replaced by a newer version.",
 			ObservedGeneration: objectSet.ClientObject().GetGeneration(),
 		})
@@ -124,38 +141,53 @@ func (r *objectSetPhasesReconciler) Reconcile(
 		meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
 			Type:               corev1alpha1.ObjectSetAvailable,
 			Status:             metav1.ConditionFalse,
-			Reason:             "ProbeFailure",
+			Reason:             corev1alpha1.ReasonProbeFailure,
 			Message:            probingResult.String(),
 			ObservedGeneration: objectSet.ClientObject().GetGeneration(),
 		})
 
+		// Objects may not emit events when becoming available,
+		// so follow the suggestion of the probes when to check again.
+		res.RequeueAfter = probingResult.RecheckAfter
 		return res, nil
 	}
 
 	meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
 		Type:               corev1alpha1.ObjectSetAvailable,
 		Status:             metav1.ConditionTrue,
-		Reason:             "Available",
+		Reason:             corev1alpha1.ReasonAvailable,
 		Message:            "Object is available and passes all probes.",
 		ObservedGeneration: objectSet.ClientObject().GetGeneration(),
 	})
+	// Probes tolerating failures have to check objects again.
+	res.RequeueAfter = probingResult.RecheckAfter
 
-	if r.hasSurvivedDelay(objectSet) && !meta.IsStatusConditionTrue(
-		*objectSet.GetConditions(), corev1alpha1.ObjectSetSucceeded) &&
+	if meta.IsStatusConditionTrue(*objectSet.GetConditions(), corev1alpha1.ObjectSetSucceeded) ||
 		// we don't want to record Succeeded during transition,
 		// because the object may become Available due to external
 		// (e.g. other ObjectSets) involvement.
-		!inTransition {
-		// Remember that this rollout worked!
-		meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
-			Type:               corev1alpha1.ObjectSetSucceeded,
-			Status:             metav1.ConditionTrue,
-			Reason:             "RolloutSuccess",
-			Message:            "ObjectSet rolled out all objects successfully and was Available at least once.",
-			ObservedGeneration: objectSet.ClientObject().GetGeneration(),
-		})
+		inTransition {
+		return
+	}
+	if !r.hasSurvivedDelay(objectSet) {
+		// Probes have to stay green for the whole success delay,
+		// failing probes reset the LastTransitionTime of the Available condition.
+		// Check again once the delay has passed, as objects may not emit events in the meantime.
+		if remaining := r.successDelayRemaining(objectSet); res.RequeueAfter == 0 || remaining < res.RequeueAfter {
+			res.RequeueAfter = remaining
+		}
+		return
 	}
 
+	// Remember that this rollout worked!
+	meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
+		Type:               corev1alpha1.ObjectSetSucceeded,
+		Status:             metav1.ConditionTrue,
+		Reason:             corev1alpha1.ReasonRolloutSuccess,
+		Message:            "ObjectSet rolled out all objects successfully and was Available at least once.",
+		ObservedGeneration: objectSet.ClientObject().GetGeneration(),
+	})
+
 	return
 }
 
@@ -168,16 +200,31 @@ func (r *objectSetPhasesReconciler) reconcile(
 	}
 
 	probe, err := probing.Parse(
-		ctx, objectSet.GetAvailabilityProbes())
+		ctx, objectSet.GetAvailabilityProbes(),
+		probing.WithObjectLister{
+			ObjectLister: controllers.NewProbeObjectLister(r.dynamicCache, objectSet.ClientObject()),
+		},
+		probing.WithFailureTracker{
+			FailureTracker: r.failureTracker,
+			Owner:          objectSet.ClientObject().GetUID(),
+		},
+		probing.WithClock{Clock: r.cfg.Clock})
 	if err != nil {
 		return nil, controllers.ProbingResult{}, fmt.Errorf("parsing probes: %w", err)
 	}
 
-	var controllerOfAll []corev1alpha1.ControlledObjectReference
-	for _, phase := range objectSet.GetPhases() {
+	var (
+		controllerOfAll []corev1alpha1.ControlledObjectReference
+		recheckAfter    time.Duration
+	)
+	for i, phase := range objectSet.GetPhases() {
 		controllerOf, probingResult, err := r.reconcilePhase(
 			ctx, objectSet, phase, probe, previous)
 		if err != nil {
+			r.updatePhasesStatus(objectSet, i, corev1alpha1.ObjectSetRolloutPhase{
+				State:   corev1alpha1.ObjectSetRolloutPhaseStateFailed,
+				Message: err.Error(),
+			})
 			return nil, controllers.ProbingResult{}, err
 		}
 
@@ -186,11 +233,110 @@ func (r *objectSetPhasesReconciler) reconcile(
 
 		if !probingResult.IsZero() {
 			// break on first failing probe
+			r.updateRolloutStatus(objectSet, i)
+			r.updatePhasesStatus(objectSet, i, corev1alpha1.ObjectSetRolloutPhase{
+				State:        corev1alpha1.ObjectSetRolloutPhaseStateProgressing,
+				FailedProbes: probingResult.FailedProbes,
+			})
 			return controllerOfAll, probingResult, nil
 		}
+		if probingResult.RecheckAfter > 0 && (recheckAfter == 0 || probingResult.RecheckAfter < recheckAfter) {
+			recheckAfter = probingResult.RecheckAfter
+		}
+	}
+
+	r.updateRolloutStatus(objectSet, len(objectSet.GetPhases()))
+	r.updatePhasesStatus(objectSet, len(objectSet.GetPhases()), corev1alpha1.ObjectSetRolloutPhase{})
+	return controllerOfAll, controllers.ProbingResult{RecheckAfter: recheckAfter}, nil
+}
+
+// Reports fields taken over from other field managers via the ApplyConflict condition.
+func (r *objectSetPhasesReconciler) updateConflictCondition(objectSet genericObjectSet) {
+	conflicts := objectSet.GetStatusConflicts()
+	if len(conflicts) == 0 {
+		meta.RemoveStatusCondition(objectSet.GetConditions(), corev1alpha1.ObjectSetApplyConflict)
+		return
+	}
+
+	msgs := make([]string, len(conflicts))
+	for i, c := range conflicts {
+		obj := c.Object.Name
+		if len(c.Object.Namespace) > 0 {
+			obj = c.Object.Namespace + "/" + obj
+		}
+		msgs[i] = fmt.Sprintf("%s %s: %q changed %s",
+			c.Object.Kind, obj, c.Manager, strings.Join(c.Fields, ", "))
+	}
+	meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
+		Type:               corev1alpha1.ObjectSetApplyConflict,
+		Status:             metav1.ConditionTrue,
+		Reason:             corev1alpha1.ReasonFieldManagerConflict,
+		Message:            "Took over fields from other field managers: " + strings.Join(msgs, "; "),
+		ObservedGeneration: objectSet.ClientObject().GetGeneration(),
+	})
+}
+
+// Records the state of every phase in status.
+// Phases before the current phase are available and phases after it are pending.
+// The time a phase first became available is retained.
+func (r *objectSetPhasesReconciler) updatePhasesStatus(
+	objectSet genericObjectSet, current int,
+	currentStatus corev1alpha1.ObjectSetRolloutPhase,
+) {
+	availableAt := map[string]*metav1.Time{}
+	for _, status := range objectSet.GetStatusPhases() {
+		availableAt[status.Name] = status.AvailableAt
+	}
+
+	now := metav1.NewTime(r.cfg.Clock.Now())
+	phases := objectSet.GetPhases()
+	statuses := make([]corev1alpha1.ObjectSetRolloutPhase, len(phases))
+	for i, phase := range phases {
+		status := corev1alpha1.ObjectSetRolloutPhase{
+			State: corev1alpha1.ObjectSetRolloutPhaseStatePending,
+		}
+		switch {
+		case i < current:
+			status.State = corev1alpha1.ObjectSetRolloutPhaseStateAvailable
+			if availableAt[phase.Name] == nil {
+				availableAt[phase.Name] = &now
+			}
+		case i == current:
+			status = currentStatus
+		}
+		status.Name = phase.Name
+		status.AvailableAt = availableAt[phase.Name]
+		statuses[i] = status
+	}
+	objectSet.SetStatusPhases(statuses)
+}
+
+// Records rollout progress in status.
+// The number of completed phases never decreases,
+// so watchers can render a consistent progress indicator.
+func (r *objectSetPhasesReconciler) updateRolloutStatus(
+	objectSet genericObjectSet, phasesCompleted int,
+) {
+	phases := objectSet.GetPhases()
+
+	rollout := objectSet.GetStatusRollout()
+	if rollout == nil {
+		now := metav1.NewTime(r.cfg.Clock.Now())
+		rollout = &corev1alpha1.ObjectSetRolloutStatus{StartedAt: &now}
+	}
+	rollout.TotalPhases = int32(len(phases))
+	if int32(phasesCompleted) > rollout.PhasesCompleted {
+		rollout.PhasesCompleted = int32(phasesCompleted)
+	}
+	if rollout.PhasesCompleted > rollout.TotalPhases {
+		rollout.PhasesCompleted = rollout.TotalPhases
 	}
 
-	return controllerOfAll, controllers.ProbingResult{}, nil
+	rollout.CurrentPhase = ""
+	if rollout.PhasesCompleted < rollout.TotalPhases {
+		rollout.CurrentPhase = phases[rollout.PhasesCompleted].Name
+	}
+	objectSet.SetStatusRollout(rollout)
 }
 
 func (r *objectSetPhasesReconciler) reconcilePhase(
@@ -237,6 +383,13 @@ func (r *objectSetPhasesReconciler) Teardown(
 	phases := objectSet.GetPhases()
 	reverse(phases) // teardown in reverse order
 
+	// Probes are no longer needed once torn down.
+	defer func() {
+		if cleanupDone {
+			r.failureTracker.Forget(objectSet.ClientObject().GetUID())
+		}
+	}()
+
 	for _, phase := range phases {
 		if cleanupDone, err := r.teardownPhase(ctx, objectSet, phase); err != nil {
 			return false, fmt.Errorf("error archiving phase: %w", err)
@@ -282,17 +435,7 @@ func (r *objectSetPhasesReconciler) isObjectSetInTransition(
 
 	for _, phase := range objectSet.GetPhases() {
 		for _, obj := range phase.Objects {
-			gvk := obj.Object.GroupVersionKind()
-			ns := obj.Object.GetNamespace()
-			if len(ns) == 0 {
-				ns = objectSet.ClientObject().GetNamespace()
-			}
-			ref := corev1alpha1.ControlledObjectReference{
-				Kind:      gvk.Kind,
-				Group:     gvk.Group,
-				Name:      obj.Object.GetName(),
-				Namespace: ns,
-			}
+			ref := controlledObjectReference(objectSet, obj)
 			if _, isControlledByThisInstance := controlledIndex[ref]; !isControlledByThisInstance {
 				// This object is not yet reconciled by this instance or has been taken somewhere else.
 				return true
@@ -309,14 +452,23 @@ func (r *objectSetPhasesReconciler) hasSurvivedDelay(objectSet genericObjectSet)
 	}
 
 	var (
-		available   = availCond.Status == metav1.ConditionTrue
-		noDelay     = objectSet.GetSuccessDelaySeconds() == 0
-		delayTarget = availCond.LastTransitionTime.Add(time.Duration(objectSet.GetSuccessDelaySeconds() * int32(time.Second)))
+		available = availCond.Status == metav1.ConditionTrue
+		noDelay   = objectSet.GetSuccessDelaySeconds() == 0
 	)
 
 	// noDelay avoids false negative for edgecase where objectSet
 	// is available on first pass, but no delay is set
-	return available && (noDelay || r.cfg.Clock.Now().After(delayTarget))
+	return available && (noDelay || r.successDelayRemaining(objectSet) <= 0)
+}
+
+// Returns how long the ObjectSet still has to stay Available, before it is marked as Succeeded.
+func (r *objectSetPhasesReconciler) successDelayRemaining(objectSet genericObjectSet) time.Duration {
+	availCond := meta.FindStatusCondition(*objectSet.GetConditions(), corev1alpha1.ObjectSetAvailable)
+	if availCond == nil {
+		return 0
+	}
+	delay := time.Duration(objectSet.GetSuccessDelaySeconds()) * time.Second
+	return availCond.LastTransitionTime.Add(delay).Sub(r.cfg.Clock.Now())
 }
 
 type objectSetPhasesReconcilerConfig struct {
@@ -2,6 +2,7 @@ package objectsets
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -48,7 +50,7 @@ func TestObjectSetPhasesReconciler_Reconcile(t *testing.T) {
 	lookup := func(_ context.Context, _ controllers.PreviousOwner) ([]controllers.PreviousObjectSet, error) {
 		return []controllers.PreviousObjectSet{}, nil
 	}
-	r := newObjectSetPhasesReconciler(testScheme, pr, remotePr, lookup)
+	r := newObjectSetPhasesReconciler(testScheme, nil, pr, remotePr, lookup)
 
 	phase1 := corev1alpha1.ObjectSetTemplatePhase{
 		Name: "phase1",
@@ -96,7 +98,7 @@ func TestPhaseReconciler_ReconcileBackoff(t *testing.T) {
 	lookup := func(_ context.Context, _ controllers.PreviousOwner) ([]controllers.PreviousObjectSet, error) {
 		return []controllers.PreviousObjectSet{}, nil
 	}
-	r := newObjectSetPhasesReconciler(testScheme, pr, remotePr, lookup)
+	r := newObjectSetPhasesReconciler(testScheme, nil, pr, remotePr, lookup)
 
 	os := &GenericObjectSet{}
 	os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
@@ -106,7 +108,7 @@ func TestPhaseReconciler_ReconcileBackoff(t *testing.T) {
 	}
 
 	pr.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return([]client.Object{}, controllers.ProbingResult{}, controllers.NewExternalResourceNotFoundError(nil))
+		Return([]client.Object{}, controllers.ProbingResult{}, controllers.NewExternalResourceNotFoundError(&unstructured.Unstructured{}))
 	remotePr.On("Reconcile", mock.Anything, mock.Anything, mock.Anything).
 		Return([]corev1alpha1.ControlledObjectReference{}, controllers.ProbingResult{}, nil)
 
@@ -139,7 +141,7 @@ func TestObjectSetPhasesReconciler_Teardown(t *testing.T) {
 			lookup := func(_ context.Context, _ controllers.PreviousOwner) ([]controllers.PreviousObjectSet, error) {
 				return []controllers.PreviousObjectSet{}, nil
 			}
-			r := newObjectSetPhasesReconciler(testScheme, pr, remotePr, lookup)
+			r := newObjectSetPhasesReconciler(testScheme, nil, pr, remotePr, lookup)
 
 			phase1 := corev1alpha1.ObjectSetTemplatePhase{
 				Name: "phase1",
@@ -175,6 +177,7 @@ func TestObjectSetPhasesReconciler_SuccessDelay(t *testing.T) {
 		ObjectSet                 genericObjectSet
 		TimeSinceAvailable        time.Duration
 		ExpectedConditionStatuses map[string]metav1.ConditionStatus
+		ExpectedRequeueAfter      time.Duration
 	}{
 		"success delay default": {
 			ObjectSet: &GenericObjectSet{
@@ -214,6 +217,28 @@ func TestObjectSetPhasesReconciler_SuccessDelay(t *testing.T) {
 			ExpectedConditionStatuses: map[string]metav1.ConditionStatus{
 				corev1alpha1.ObjectSetAvailable: metav1.ConditionTrue,
 			},
+			ExpectedRequeueAfter: 1 * time.Second,
+		},
+		"success delay 300s/time since available 60s": {
+			ObjectSet: &GenericObjectSet{
+				ObjectSet: corev1alpha1.ObjectSet{
+					Spec: corev1alpha1.ObjectSetSpec{
+						ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+							Phases: []corev1alpha1.ObjectSetTemplatePhase{
+								{
+									Name: "phase-1",
+								},
+							},
+							SuccessDelaySeconds: 300,
+						},
+					},
+				},
+			},
+			TimeSinceAvailable: 60 * time.Second,
+			ExpectedConditionStatuses: map[string]metav1.ConditionStatus{
+				corev1alpha1.ObjectSetAvailable: metav1.ConditionTrue,
+			},
+			ExpectedRequeueAfter: 240 * time.Second,
 		},
 		"success delay 1s/time since available 2s": {
 			ObjectSet: &GenericObjectSet{
@@ -252,13 +277,14 @@ func TestObjectSetPhasesReconciler_SuccessDelay(t *testing.T) {
 				Return([]corev1alpha1.ControlledObjectReference{}, controllers.ProbingResult{}, nil)
 
 			rec := newObjectSetPhasesReconciler(
-				testScheme, prm, rprm, lookup,
+				testScheme, nil, prm, rprm, lookup,
 				withClock{
 					Clock: cm,
 				},
 			)
-			_, err := rec.Reconcile(context.Background(), tc.ObjectSet)
+			res, err := rec.Reconcile(context.Background(), tc.ObjectSet)
 			require.NoError(t, err)
+			assert.InDelta(t, tc.ExpectedRequeueAfter, res.RequeueAfter, float64(time.Second))
 
 			require.Equal(t, len(tc.ExpectedConditionStatuses), len(*tc.ObjectSet.GetConditions()), tc.ObjectSet.GetConditions())
 
@@ -278,3 +304,214 @@ func (m *clockMock) Now() time.Time {
 
 	return args.Get(0).(time.Time)
 }
+
+func TestObjectSetPhasesReconciler_RolloutStatus(t *testing.T) {
+	startedAt := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+	cm := &clockMock{}
+	cm.On("Now").Return(startedAt)
+
+	pr := &phaseReconcilerMock{}
+	remotePr := &remotePhaseReconcilerMock{}
+	lookup := func(_ context.Context, _ controllers.PreviousOwner) ([]controllers.PreviousObjectSet, error) {
+		return []controllers.PreviousObjectSet{}, nil
+	}
+	r := newObjectSetPhasesReconciler(testScheme, nil, pr, remotePr, lookup, withClock{Clock: cm})
+
+	os := &GenericObjectSet{}
+	os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
+		{Name: "phase1"}, {Name: "phase2"},
+	}
+
+	failing := controllers.ProbingResult{PhaseName: "phase2"}
+	results := []controllers.ProbingResult{
+		// 1st reconcile: phase2 is not available.
+		{}, failing,
+		// 2nd reconcile: everything is available.
+		{}, {},
+		// 3rd reconcile: phase2 became unavailable again.
+		{}, failing,
+	}
+	for _, res := range results {
+		pr.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return([]client.Object{}, res, nil).Once()
+	}
+
+	expectedStartedAt := metav1.NewTime(startedAt)
+	ctx := context.Background()
+
+	_, err := r.Reconcile(ctx, os)
+	require.NoError(t, err)
+	assert.Equal(t, &corev1alpha1.ObjectSetRolloutStatus{
+		CurrentPhase:    "phase2",
+		PhasesCompleted: 1,
+		TotalPhases:     2,
+		StartedAt:       &expectedStartedAt,
+	}, os.Status.Rollout)
+
+	completed := &corev1alpha1.ObjectSetRolloutStatus{
+		PhasesCompleted: 2,
+		TotalPhases:     2,
+		StartedAt:       &expectedStartedAt,
+	}
+	_, err = r.Reconcile(ctx, os)
+	require.NoError(t, err)
+	assert.Equal(t, completed, os.Status.Rollout)
+
+	_, err = r.Reconcile(ctx, os)
+	require.NoError(t, err)
+	assert.Equal(t, completed, os.Status.Rollout)
+}
+
+func TestObjectSetPhasesReconciler_PhasesStatus(t *testing.T) {
+	firstAvailable := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+	cm := &clockMock{}
+	cm.On("Now").Return(firstAvailable).Twice()
+	cm.On("Now").Return(firstAvailable.Add(time.Minute))
+
+	pr := &phaseReconcilerMock{}
+	remotePr := &remotePhaseReconcilerMock{}
+	lookup := func(_ context.Context, _ controllers.PreviousOwner) ([]controllers.PreviousObjectSet, error) {
+		return []controllers.PreviousObjectSet{}, nil
+	}
+	r := newObjectSetPhasesReconciler(testScheme, nil, pr, remotePr, lookup, withClock{Clock: cm})
+
+	os := &GenericObjectSet{}
+	os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
+		{Name: "phase1"}, {Name: "phase2"}, {Name: "phase3"},
+	}
+
+	failing := controllers.ProbingResult{
+		PhaseName:    "phase2",
+		FailedProbes: []string{"Deployment test: not available"},
+	}
+	pr.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]client.Object{}, controllers.ProbingResult{}, nil).Once()
+	pr.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]client.Object{}, failing, nil).Once()
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, os)
+	require.NoError(t, err)
+
+	phase1AvailableAt := metav1.NewTime(firstAvailable)
+	assert.Equal(t, []corev1alpha1.ObjectSetRolloutPhase{
+		{
+			Name:        "phase1",
+			State:       corev1alpha1.ObjectSetRolloutPhaseStateAvailable,
+			AvailableAt: &phase1AvailableAt,
+		},
+		{
+			Name:         "phase2",
+			State:        corev1alpha1.ObjectSetRolloutPhaseStateProgressing,
+			FailedProbes: []string{"Deployment test: not available"},
+		},
+		{
+			Name:  "phase3",
+			State: corev1alpha1.ObjectSetRolloutPhaseStatePending,
+		},
+	}, os.Status.Phases)
+
+	pr.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]client.Object{}, controllers.ProbingResult{}, nil).Twice()
+	pr.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]client.Object{}, controllers.ProbingResult{}, errors.New("boom")).Once()
+
+	_, err = r.Reconcile(ctx, os)
+	require.Error(t, err)
+
+	phase2AvailableAt := metav1.NewTime(firstAvailable.Add(time.Minute))
+	assert.Equal(t, []corev1alpha1.ObjectSetRolloutPhase{
+		{
+			Name:        "phase1",
+			State:       corev1alpha1.ObjectSetRolloutPhaseStateAvailable,
+			AvailableAt: &phase1AvailableAt,
+		},
+		{
+			Name:        "phase2",
+			State:       corev1alpha1.ObjectSetRolloutPhaseStateAvailable,
+			AvailableAt: &phase2AvailableAt,
+		},
+		{
+			Name:    "phase3",
+			State:   corev1alpha1.ObjectSetRolloutPhaseStateFailed,
+			Message: "boom",
+		},
+	}, os.Status.Phases)
+}
+
+func TestObjectSetPhasesReconciler_RecheckAfter(t *testing.T) {
+	pr := &phaseReconcilerMock{}
+	remotePr := &remotePhaseReconcilerMock{}
+	lookup := func(_ context.Context, _ controllers.PreviousOwner) ([]controllers.PreviousObjectSet, error) {
+		return []controllers.PreviousObjectSet{}, nil
+	}
+	r := newObjectSetPhasesReconciler(testScheme, nil, pr, remotePr, lookup)
+
+	os := &GenericObjectSet{}
+	os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{{Name: "phase1"}}
+
+	pr.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]client.Object{}, controllers.ProbingResult{
+			PhaseName:    "phase1",
+			FailedProbes: []string{"batch Job /test: not completed"},
+			RecheckAfter: 10 * time.Minute,
+		}, nil)
+
+	res, err := r.Reconcile(context.Background(), os)
+	require.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: 10 * time.Minute}, res)
+	assert.False(t, meta.IsStatusConditionTrue(os.Status.Conditions, corev1alpha1.ObjectSetAvailable))
+}
+
+func TestObjectSetPhasesReconciler_ApplyConflicts(t *testing.T) {
+	now := time.Date(2023, time.June, 1, 12, 0, 0, 0, time.UTC)
+	cm := &clockMock{}
+	cm.On("Now").Return(now)
+
+	pr := &phaseReconcilerMock{}
+	remotePr := &remotePhaseReconcilerMock{}
+	lookup := func(_ context.Context, _ controllers.PreviousOwner) ([]controllers.PreviousObjectSet, error) {
+		return []controllers.PreviousObjectSet{}, nil
+	}
+	r := newObjectSetPhasesReconciler(testScheme, nil, pr, remotePr, lookup, withClock{Clock: cm})
+
+	os := &GenericObjectSet{}
+	os.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{{Name: "phase1"}}
+	os.Status.Conflicts = []corev1alpha1.ObjectSetObjectConflict{
+		{
+			Object:           corev1alpha1.ControlledObjectReference{Kind: "Deployment", Group: "apps", Name: "test", Namespace: "test-ns"},
+			Manager:          "kubectl-edit",
+			Fields:           []string{"spec.replicas"},
+			LastObservedTime: metav1.NewTime(now.Add(-time.Minute)),
+		},
+		{
+			Object:           corev1alpha1.ControlledObjectReference{Kind: "ConfigMap", Name: "old", Namespace: "test-ns"},
+			Manager:          "kubectl-edit",
+			LastObservedTime: metav1.NewTime(now.Add(-controllers.ApplyConflictRetention)),
+		},
+	}
+
+	pr.On("ReconcilePhase", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]client.Object{}, controllers.ProbingResult{}, nil)
+
+	ctx := context.Background()
+	_, err := r.Reconcile(ctx, os)
+	require.NoError(t, err)
+	if assert.Len(t, os.Status.Conflicts, 1) {
+		assert.Equal(t, "test", os.Status.Conflicts[0].Object.Name)
+	}
+	cond := meta.FindStatusCondition(os.Status.Conditions, corev1alpha1.ObjectSetApplyConflict)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, corev1alpha1.ReasonFieldManagerConflict, cond.Reason)
+		assert.Equal(t,
+			`Took over fields from other field managers: Deployment test-ns/test: "kubectl-edit" changed spec.replicas`,
+			cond.Message)
+	}
+
+	os.Status.Conflicts[0].LastObservedTime = metav1.NewTime(now.Add(-time.Hour))
+	_, err = r.Reconcile(ctx, os)
+	require.NoError(t, err)
+	assert.Empty(t, os.Status.Conflicts)
+	assert.Nil(t, meta.FindStatusCondition(os.Status.Conditions, corev1alpha1.ObjectSetApplyConflict))
+}
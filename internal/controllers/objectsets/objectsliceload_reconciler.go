@@ -11,6 +11,7 @@ import (
 
 	"package-operator.run/package-operator/internal/adapters"
 	"package-operator.run/package-operator/internal/ownerhandling"
+	"package-operator.run/package-operator/internal/utils"
 )
 
 // objectSliceLoadReconciler loads ObjectSlices to inline all objects into the ObjectSet again.
@@ -60,9 +61,44 @@ func (r *objectSliceLoadReconciler) Reconcile(
 				}
 			}
 
+			if err := verifySliceChecksum(objSlice); err != nil {
+				return res, err
+			}
+
 			phase.Objects = append(phase.Objects, objSlice.GetObjects()...)
 		}
 	}
 	objectSet.SetPhases(phases)
 	return
 }
+
+// Slices created before checksums were introduced are not verified.
+func verifySliceChecksum(objSlice adapters.ObjectSliceAccessor) error {
+	expected := objSlice.GetChecksum()
+	if len(expected) == 0 {
+		return nil
+	}
+	actual, err := utils.ComputeObjectsChecksum(objSlice.GetObjects())
+	if err != nil {
+		return fmt.Errorf("computing ObjectSlice checksum: %w", err)
+	}
+	if actual != expected {
+		return &ObjectSliceChecksumMismatchError{
+			Slice:    client.ObjectKeyFromObject(objSlice.ClientObject()),
+			Expected: expected,
+			Actual:   actual,
+		}
+	}
+	return nil
+}
+
+// ObjectSliceChecksumMismatchError is returned when the objects of an ObjectSlice
+// don't match the checksum recorded when the slice was created.
+type ObjectSliceChecksumMismatchError struct {
+	Slice            client.ObjectKey
+	Expected, Actual string
+}
+
+func (e *ObjectSliceChecksumMismatchError) Error() string {
+	return fmt.Sprintf("ObjectSlice %s checksum mismatch: expected %s, got %s", e.Slice, e.Expected, e.Actual)
+}
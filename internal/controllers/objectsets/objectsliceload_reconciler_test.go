@@ -16,6 +16,7 @@ import (
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/package-operator/internal/adapters"
 	"package-operator.run/package-operator/internal/testutil"
+	"package-operator.run/package-operator/internal/utils"
 )
 
 func TestObjectSliceLoadReconciler(t *testing.T) {
@@ -102,3 +103,91 @@ func TestObjectSliceLoadReconciler(t *testing.T) {
 		object1, object2,
 	}, objectSet.Spec.Phases[0].Objects)
 }
+
+func TestObjectSliceLoadReconciler_checksum(t *testing.T) {
+	t.Parallel()
+
+	object := corev1alpha1.ObjectSetObject{
+		Object: unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name":   "o-1",
+					"labels": map[string]interface{}{"replicas": int64(3)},
+				},
+			},
+		},
+	}
+	checksum, err := utils.ComputeObjectsChecksum([]corev1alpha1.ObjectSetObject{object})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		checksum string
+		objects  []corev1alpha1.ObjectSetObject
+		err      bool
+	}{
+		{
+			name:     "valid",
+			checksum: checksum,
+			objects:  []corev1alpha1.ObjectSetObject{object},
+		},
+		{
+			name:    "no checksum",
+			objects: []corev1alpha1.ObjectSetObject{object},
+		},
+		{
+			name:     "truncated",
+			checksum: checksum,
+			objects:  []corev1alpha1.ObjectSetObject{},
+			err:      true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			r := newObjectSliceLoadReconciler(testScheme, c, adapters.NewObjectSlice)
+
+			objectSet := &GenericObjectSet{
+				ObjectSet: corev1alpha1.ObjectSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns"},
+					Spec: corev1alpha1.ObjectSetSpec{
+						ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+							Phases: []corev1alpha1.ObjectSetTemplatePhase{
+								{Slices: []string{"slice-1"}},
+							},
+						},
+					},
+				},
+			}
+			c.
+				On("Get", mock.Anything, mock.Anything,
+					mock.AnythingOfType("*v1alpha1.ObjectSlice"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					slice := args.Get(2).(*corev1alpha1.ObjectSlice)
+					slice.Name = "slice-1"
+					slice.Namespace = "test-ns"
+					slice.Objects = test.objects
+					slice.Checksum = test.checksum
+				}).
+				Return(nil)
+			c.
+				On("Update", mock.Anything, mock.AnythingOfType("*v1alpha1.ObjectSlice"), mock.Anything).
+				Return(nil)
+
+			_, err := r.Reconcile(context.Background(), objectSet)
+			if !test.err {
+				require.NoError(t, err)
+				assert.Equal(t, test.objects, objectSet.Spec.Phases[0].Objects)
+				return
+			}
+
+			var mismatchErr *ObjectSliceChecksumMismatchError
+			require.ErrorAs(t, err, &mismatchErr)
+			assert.Equal(t, "slice-1", mismatchErr.Slice.Name)
+			assert.Empty(t, objectSet.Spec.Phases[0].Objects)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package objectsets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+)
+
+const orphanCleanupRequeueDelay = 10 * time.Second
+
+// orphanCleanupReconciler deletes objects still controlled by previous revisions,
+// that are no longer part of this revision.
+// Without it, these objects are only removed when the previous revision is archived.
+type orphanCleanupReconciler struct {
+	scheme       *runtime.Scheme
+	newObjectSet genericObjectSetFactory
+	client       client.Client
+	dynamicCache dynamicCache
+}
+
+func (r *orphanCleanupReconciler) Reconcile(
+	ctx context.Context, objectSet genericObjectSet,
+) (res ctrl.Result, err error) {
+	if objectSet.IsPaused() || !controllers.IsCurrentStatusConditionTrue(
+		*objectSet.GetConditions(), corev1alpha1.ObjectSetAvailable, objectSet.GetGeneration()) {
+		// Only cleanup after this revision has taken over.
+		return
+	}
+
+	desired := map[corev1alpha1.ControlledObjectReference]struct{}{}
+	for _, phase := range objectSet.GetPhases() {
+		for _, phaseObject := range phase.Objects {
+			desired[controlledObjectReference(objectSet, phaseObject)] = struct{}{}
+		}
+	}
+
+	for _, prev := range objectSet.GetPrevious() {
+		prevObjectSet := r.newObjectSet(r.scheme)
+		key := client.ObjectKey{
+			Name:      prev.Name,
+			Namespace: objectSet.ClientObject().GetNamespace(),
+		}
+		if err := r.client.Get(ctx, key, prevObjectSet.ClientObject()); errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return res, fmt.Errorf("getting previous revision: %w", err)
+		}
+
+		if prevObjectSet.IsArchived() {
+			// Archival tears down all remaining objects.
+			continue
+		}
+		if !meta.IsStatusConditionTrue(
+			*prevObjectSet.GetConditions(), corev1alpha1.ObjectSetPaused) {
+			// Previous revision is still reconciling and would recreate deleted objects.
+			if prevObjectSet.IsPaused() {
+				// Check again when pausing is reported,
+				// this delay is needed, because we are not watching previous revisions.
+				res.RequeueAfter = orphanCleanupRequeueDelay
+			}
+			continue
+		}
+
+		if err := r.cleanupOrphans(ctx, prevObjectSet, desired); err != nil {
+			return res, err
+		}
+	}
+	return
+}
+
+func (r *orphanCleanupReconciler) cleanupOrphans(
+	ctx context.Context, prevObjectSet genericObjectSet,
+	desired map[corev1alpha1.ControlledObjectReference]struct{},
+) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	prevObjects := map[corev1alpha1.ControlledObjectReference]corev1alpha1.ObjectSetObject{}
+	for _, phase := range prevObjectSet.GetPhases() {
+		if len(phase.Class) > 0 {
+			// Objects of remote phases are managed elsewhere.
+			continue
+		}
+		for _, phaseObject := range phase.Objects {
+			prevObjects[controlledObjectReference(prevObjectSet, phaseObject)] = phaseObject
+		}
+	}
+
+	// Only look at the objects actually owned by the previous revision,
+	// instead of its possibly stale status.controllerOf.
+	owned, err := controllers.ListObjectsForRevision(
+		ctx, r.dynamicCache, prevObjectSet.ClientObject(), prevObjectSet.GetRevision())
+	if err != nil {
+		return fmt.Errorf("listing objects of previous revision: %w", err)
+	}
+
+	for i := range owned {
+		obj := &owned[i]
+		if controllerRef := metav1.GetControllerOf(obj); controllerRef == nil ||
+			controllerRef.UID != prevObjectSet.ClientObject().GetUID() {
+			// Not controlled by the previous revision anymore.
+			continue
+		}
+
+		ref := corev1alpha1.ControlledObjectReference{
+			Kind:      obj.GetKind(),
+			Group:     obj.GroupVersionKind().Group,
+			Name:      obj.GetName(),
+			Namespace: obj.GetNamespace(),
+		}
+		if _, ok := desired[ref]; ok {
+			continue
+		}
+		phaseObject, ok := prevObjects[ref]
+		if !ok {
+			continue
+		}
+		switch phaseObject.DeletionPolicy {
+		case corev1alpha1.ObjectSetObjectDeletionPolicyOrphan:
+			continue
+		case corev1alpha1.ObjectSetObjectDeletionPolicyScaleDown:
+			// Scaled down and kept for fast rollbacks when the previous revision is archived.
+			continue
+		}
+
+		// The cache may lag behind, only delete the exact object version checked above.
+		uid, resourceVersion := obj.GetUID(), obj.GetResourceVersion()
+		if err := r.client.Delete(ctx, obj, client.Preconditions{
+			UID: &uid, ResourceVersion: &resourceVersion,
+		}); err != nil && !errors.IsNotFound(err) && !errors.IsConflict(err) {
+			return fmt.Errorf("deleting orphaned object: %w", err)
+		}
+		log.Info("deleted object no longer part of the latest revision",
+			"object", client.ObjectKeyFromObject(obj), "kind", ref.Kind,
+			"previousRevision", prevObjectSet.ClientObject().GetName())
+	}
+	return nil
+}
+
+func controlledObjectReference(
+	objectSet genericObjectSet, phaseObject corev1alpha1.ObjectSetObject,
+) corev1alpha1.ControlledObjectReference {
+	obj := &phaseObject.Object
+	if substituted, err := controllers.SubstituteOwnerMetadata(objectSet.ClientObject(), obj); err == nil {
+		obj = substituted
+	}
+	namespace := obj.GetNamespace()
+	if len(namespace) == 0 {
+		namespace = objectSet.ClientObject().GetNamespace()
+	}
+	return corev1alpha1.ControlledObjectReference{
+		Kind:      obj.GetKind(),
+		Group:     obj.GroupVersionKind().Group,
+		Name:      obj.GetName(),
+		Namespace: namespace,
+	}
+}
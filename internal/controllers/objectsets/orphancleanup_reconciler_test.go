@@ -0,0 +1,206 @@
+package objectsets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func Test_orphanCleanupReconciler(t *testing.T) {
+	newPhaseObject := func(name string) corev1alpha1.ObjectSetObject {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetName(name)
+		return corev1alpha1.ObjectSetObject{Object: obj}
+	}
+	// Live object in the dynamic cache, as listed by the revision index.
+	newLiveObject := func(phaseObject corev1alpha1.ObjectSetObject, controllerUID types.UID) unstructured.Unstructured {
+		obj := *phaseObject.Object.DeepCopy()
+		obj.SetNamespace("test")
+		obj.SetUID(types.UID(obj.GetName() + "-uid"))
+		obj.SetResourceVersion("1")
+		obj.SetOwnerReferences([]metav1.OwnerReference{{UID: controllerUID, Controller: pointer.Bool(true)}})
+		return obj
+	}
+
+	orphanObject := newPhaseObject("orphan-policy")
+	orphanObject.DeletionPolicy = corev1alpha1.ObjectSetObjectDeletionPolicyOrphan
+	scaleDownObject := newPhaseObject("scale-down-policy")
+	scaleDownObject.Object.SetAPIVersion("apps/v1")
+	scaleDownObject.Object.SetKind("Deployment")
+	scaleDownObject.DeletionPolicy = corev1alpha1.ObjectSetObjectDeletionPolicyScaleDown
+
+	prev := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "prev", Namespace: "test", UID: "prev-uid"},
+		Spec: corev1alpha1.ObjectSetSpec{
+			LifecycleState: corev1alpha1.ObjectSetLifecycleStatePaused,
+			ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+				Phases: []corev1alpha1.ObjectSetTemplatePhase{
+					{
+						Name: "phase",
+						Objects: []corev1alpha1.ObjectSetObject{
+							newPhaseObject("kept"),
+							newPhaseObject("dropped"),
+							newPhaseObject("adopted"),
+							orphanObject,
+							scaleDownObject,
+						},
+					},
+				},
+			},
+		},
+		Status: corev1alpha1.ObjectSetStatus{
+			Conditions: []metav1.Condition{
+				{Type: corev1alpha1.ObjectSetPaused, Status: metav1.ConditionTrue},
+			},
+			Revision: 1,
+		},
+	}
+
+	newObjectSet := func(available bool) *GenericObjectSet {
+		status := metav1.ConditionFalse
+		if available {
+			status = metav1.ConditionTrue
+		}
+		return &GenericObjectSet{corev1alpha1.ObjectSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "current", Namespace: "test", Generation: 1},
+			Spec: corev1alpha1.ObjectSetSpec{
+				Previous: []corev1alpha1.PreviousRevisionReference{{Name: "prev"}},
+				ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+					Phases: []corev1alpha1.ObjectSetTemplatePhase{
+						{
+							Name:    "phase",
+							Objects: []corev1alpha1.ObjectSetObject{newPhaseObject("kept")},
+						},
+					},
+				},
+			},
+			Status: corev1alpha1.ObjectSetStatus{
+				Conditions: []metav1.Condition{
+					{Type: corev1alpha1.ObjectSetAvailable, Status: status, ObservedGeneration: 1},
+				},
+			},
+		}}
+	}
+
+	t.Run("deletes objects dropped by the latest revision", func(t *testing.T) {
+		testClient := testutil.NewClient()
+		dc := &dynamicCacheMock{}
+		r := &orphanCleanupReconciler{
+			scheme:       testScheme,
+			newObjectSet: newGenericObjectSet,
+			client:       testClient,
+			dynamicCache: dc,
+		}
+
+		testClient.
+			On("Get", mock.Anything, client.ObjectKey{Name: "prev", Namespace: "test"}, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				out := args.Get(2).(*corev1alpha1.ObjectSet)
+				*out = *prev
+			}).
+			Return(nil)
+		dc.
+			On("ListByIndex", mock.Anything, controllers.RevisionIndex, "1").
+			Return([]unstructured.Unstructured{
+				newLiveObject(newPhaseObject("kept"), "prev-uid"),
+				newLiveObject(newPhaseObject("dropped"), "prev-uid"),
+				// Not part of the previous revision.
+				newLiveObject(newPhaseObject("unknown"), "prev-uid"),
+				// Revision 1 of another ObjectDeployment.
+				newLiveObject(newPhaseObject("other-owner"), "other-uid"),
+				newLiveObject(orphanObject, "prev-uid"),
+				newLiveObject(scaleDownObject, "prev-uid"),
+			}, nil)
+		testClient.
+			On("Delete", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+
+		ctx := context.Background()
+		res, err := r.Reconcile(ctx, newObjectSet(true))
+		require.NoError(t, err)
+		assert.True(t, res.IsZero(), "unexpected requeue")
+
+		droppedUID, resourceVersion := types.UID("dropped-uid"), "1"
+		testClient.AssertNumberOfCalls(t, "Delete", 1)
+		testClient.AssertCalled(t, "Delete", mock.Anything, mock.MatchedBy(func(obj *unstructured.Unstructured) bool {
+			return obj.GetName() == "dropped" && obj.GetNamespace() == "test"
+		}), []client.DeleteOption{client.Preconditions{UID: &droppedUID, ResourceVersion: &resourceVersion}})
+	})
+
+	t.Run("waits for availability", func(t *testing.T) {
+		testClient := testutil.NewClient()
+		r := &orphanCleanupReconciler{
+			scheme:       testScheme,
+			newObjectSet: newGenericObjectSet,
+			client:       testClient,
+		}
+
+		ctx := context.Background()
+		res, err := r.Reconcile(ctx, newObjectSet(false))
+		require.NoError(t, err)
+		assert.True(t, res.IsZero(), "unexpected requeue")
+
+		testClient.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		testClient.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("waits for previous revision to pause", func(t *testing.T) {
+		testClient := testutil.NewClient()
+		r := &orphanCleanupReconciler{
+			scheme:       testScheme,
+			newObjectSet: newGenericObjectSet,
+			client:       testClient,
+		}
+
+		pausing := prev.DeepCopy()
+		pausing.Status.Conditions = nil
+		testClient.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				out := args.Get(2).(*corev1alpha1.ObjectSet)
+				*out = *pausing
+			}).
+			Return(nil)
+
+		ctx := context.Background()
+		res, err := r.Reconcile(ctx, newObjectSet(true))
+		require.NoError(t, err)
+		assert.Equal(t, orphanCleanupRequeueDelay, res.RequeueAfter)
+
+		testClient.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func Test_controlledObjectReference_ownerMetadata(t *testing.T) {
+	objectSet := &GenericObjectSet{corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-ns",
+			Labels:    map[string]string{"tenant": "acme"},
+		},
+	}}
+	obj := unstructured.Unstructured{}
+	obj.SetKind("ConfigMap")
+	obj.SetName("cm-${metadata.labels['tenant']}")
+
+	ref := controlledObjectReference(objectSet, corev1alpha1.ObjectSetObject{Object: obj})
+	assert.Equal(t, corev1alpha1.ControlledObjectReference{
+		Kind:      "ConfigMap",
+		Name:      "cm-acme",
+		Namespace: "test-ns",
+	}, ref)
+}
@@ -0,0 +1,179 @@
+package objectsets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+)
+
+// PhaseHandler executes ObjectSet phases of a class.
+// Register implementations via GenericObjectSetController.RegisterPhaseHandler
+// to execute phases in-process, e.g. to apply infrastructure via Terraform.
+type PhaseHandler interface {
+	// Ensures the objects of the phase and reports their state.
+	ReconcilePhase(ctx context.Context, req PhaseRequest) (PhaseResult, error)
+	// Removes the objects of the phase.
+	// Called repeatedly until cleanupDone is true.
+	TeardownPhase(ctx context.Context, req PhaseRequest) (cleanupDone bool, err error)
+}
+
+// PhaseRequest describes the phase to execute.
+type PhaseRequest struct {
+	// ObjectSet or ClusterObjectSet owning the phase.
+	ObjectSet client.Object
+	Phase     corev1alpha1.ObjectSetTemplatePhase
+	// Revision of the ObjectSet.
+	Revision int64
+	// Objects must not be changed while paused.
+	Paused bool
+}
+
+// PhaseResult reports the state of an executed phase.
+type PhaseResult struct {
+	// Objects actively controlled by the phase.
+	ControllerOf []corev1alpha1.ControlledObjectReference
+	// The phase is not Available, as long as any probe fails.
+	FailedProbes []string
+}
+
+// ErrPhaseHandlerNotRegistered is returned, when a PhaseClass references an InProcess handler
+// that was not registered with the manager.
+var ErrPhaseHandlerNotRegistered = errors.New("phase handler not registered")
+
+// phaseClassRouter delegates phases with a class to the handler configured by their PhaseClass.
+// Classes without PhaseClass are executed by handlers registered for the class name
+// and fall back to remote phase managers.
+type phaseClassRouter struct {
+	client  client.Reader
+	remote  remotePhaseReconciler
+	webhook func(webhook *corev1alpha1.PhaseClassWebhook) (PhaseHandler, error)
+
+	handlersMux sync.RWMutex
+	handlers    map[string]PhaseHandler
+}
+
+var _ remotePhaseReconciler = (*phaseClassRouter)(nil)
+
+func newPhaseClassRouter(
+	client client.Reader, scheme *runtime.Scheme, remote remotePhaseReconciler,
+) *phaseClassRouter {
+	return &phaseClassRouter{
+		client: client,
+		remote: remote,
+		webhook: func(webhook *corev1alpha1.PhaseClassWebhook) (PhaseHandler, error) {
+			return newWebhookPhaseHandler(scheme, webhook)
+		},
+		handlers: map[string]PhaseHandler{},
+	}
+}
+
+func (r *phaseClassRouter) Register(name string, handler PhaseHandler) {
+	r.handlersMux.Lock()
+	defer r.handlersMux.Unlock()
+	r.handlers[name] = handler
+}
+
+func (r *phaseClassRouter) Reconcile(
+	ctx context.Context, objectSet genericObjectSet,
+	phase corev1alpha1.ObjectSetTemplatePhase,
+) ([]corev1alpha1.ControlledObjectReference, controllers.ProbingResult, error) {
+	handler, err := r.handlerFor(ctx, phase.Class)
+	if err != nil {
+		return nil, controllers.ProbingResult{}, err
+	}
+	if handler == nil {
+		return r.remote.Reconcile(ctx, objectSet, phase)
+	}
+
+	res, err := handler.ReconcilePhase(ctx, r.request(objectSet, phase))
+	if err != nil {
+		return nil, controllers.ProbingResult{}, fmt.Errorf("phase class %q: %w", phase.Class, err)
+	}
+	if len(res.FailedProbes) > 0 {
+		return res.ControllerOf, controllers.ProbingResult{
+			PhaseName:    phase.Name,
+			FailedProbes: res.FailedProbes,
+		}, nil
+	}
+	return res.ControllerOf, controllers.ProbingResult{}, nil
+}
+
+func (r *phaseClassRouter) Teardown(
+	ctx context.Context, objectSet genericObjectSet,
+	phase corev1alpha1.ObjectSetTemplatePhase,
+) (cleanupDone bool, err error) {
+	handler, err := r.handlerFor(ctx, phase.Class)
+	if err != nil {
+		return false, err
+	}
+	if handler == nil {
+		return r.remote.Teardown(ctx, objectSet, phase)
+	}
+
+	cleanupDone, err = handler.TeardownPhase(ctx, r.request(objectSet, phase))
+	if err != nil {
+		return false, fmt.Errorf("phase class %q: %w", phase.Class, err)
+	}
+	return cleanupDone, nil
+}
+
+func (r *phaseClassRouter) request(
+	objectSet genericObjectSet, phase corev1alpha1.ObjectSetTemplatePhase,
+) PhaseRequest {
+	return PhaseRequest{
+		ObjectSet: objectSet.ClientObject(),
+		Phase:     phase,
+		Revision:  objectSet.GetRevision(),
+		Paused:    objectSet.IsPaused(),
+	}
+}
+
+// Returns the handler for the given class or nil, if the phase is delegated to a remote phase manager.
+func (r *phaseClassRouter) handlerFor(ctx context.Context, class string) (PhaseHandler, error) {
+	phaseClass := &corev1alpha1.PhaseClass{}
+	err := r.client.Get(ctx, client.ObjectKey{Name: class}, phaseClass)
+	switch {
+	case k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) || k8serrors.IsForbidden(err):
+		// No PhaseClass or not readable when running namespace-scoped,
+		// so the class name itself may reference a handler.
+		return r.registered(class), nil
+	case err != nil:
+		return nil, fmt.Errorf("getting PhaseClass: %w", err)
+	}
+
+	switch phaseClass.Spec.Handler {
+	case corev1alpha1.PhaseClassHandlerInProcess:
+		name := class
+		if phaseClass.Spec.InProcess != nil && len(phaseClass.Spec.InProcess.Name) > 0 {
+			name = phaseClass.Spec.InProcess.Name
+		}
+		if handler := r.registered(name); handler != nil {
+			return handler, nil
+		}
+		return nil, fmt.Errorf("%w: %q", ErrPhaseHandlerNotRegistered, name)
+
+	case corev1alpha1.PhaseClassHandlerWebhook:
+		if phaseClass.Spec.Webhook == nil {
+			return nil, fmt.Errorf("PhaseClass %q: .spec.webhook is required for the Webhook handler", class)
+		}
+		return r.webhook(phaseClass.Spec.Webhook)
+
+	default:
+		return nil, nil
+	}
+}
+
+func (r *phaseClassRouter) registered(name string) PhaseHandler {
+	r.handlersMux.RLock()
+	defer r.handlersMux.RUnlock()
+	return r.handlers[name]
+}
@@ -0,0 +1,210 @@
+package objectsets
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+type phaseHandlerMock struct {
+	mock.Mock
+}
+
+func (m *phaseHandlerMock) ReconcilePhase(ctx context.Context, req PhaseRequest) (PhaseResult, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(PhaseResult), args.Error(1)
+}
+
+func (m *phaseHandlerMock) TeardownPhase(ctx context.Context, req PhaseRequest) (bool, error) {
+	args := m.Called(ctx, req)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestPhaseClassRouter(t *testing.T) {
+	phase := corev1alpha1.ObjectSetTemplatePhase{Name: "infra", Class: "terraform"}
+	controlled := []corev1alpha1.ControlledObjectReference{{Kind: "Bucket", Name: "test"}}
+
+	tests := []struct {
+		name string
+		// nil, if no PhaseClass exists.
+		phaseClass *corev1alpha1.PhaseClassSpec
+		// Error getting the PhaseClass, if none exists.
+		getErr   error
+		register string
+		remote   bool
+		webhook  bool
+		err      error
+	}{
+		{
+			name:   "no PhaseClass",
+			remote: true,
+		},
+		{
+			name:     "no PhaseClass, registered for class",
+			register: "terraform",
+		},
+		{
+			name:     "PhaseClass forbidden",
+			getErr:   errors.NewForbidden(schema.GroupResource{}, "", nil),
+			register: "terraform",
+		},
+		{
+			name:       "RemotePhaseManager",
+			phaseClass: &corev1alpha1.PhaseClassSpec{Handler: corev1alpha1.PhaseClassHandlerRemotePhaseManager},
+			register:   "terraform",
+			remote:     true,
+		},
+		{
+			name: "InProcess",
+			phaseClass: &corev1alpha1.PhaseClassSpec{
+				Handler:   corev1alpha1.PhaseClassHandlerInProcess,
+				InProcess: &corev1alpha1.PhaseClassInProcess{Name: "tf"},
+			},
+			register: "tf",
+		},
+		{
+			name:       "InProcess not registered",
+			phaseClass: &corev1alpha1.PhaseClassSpec{Handler: corev1alpha1.PhaseClassHandlerInProcess},
+			err:        ErrPhaseHandlerNotRegistered,
+		},
+		{
+			name: "Webhook",
+			phaseClass: &corev1alpha1.PhaseClassSpec{
+				Handler: corev1alpha1.PhaseClassHandlerWebhook,
+				Webhook: &corev1alpha1.PhaseClassWebhook{URL: "https://example.com"},
+			},
+			webhook: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			c := testutil.NewClient()
+			if test.phaseClass == nil {
+				getErr := test.getErr
+				if getErr == nil {
+					getErr = errors.NewNotFound(schema.GroupResource{}, "")
+				}
+				c.On("Get", mock.Anything, mock.Anything,
+					mock.AnythingOfType("*v1alpha1.PhaseClass"), mock.Anything).
+					Return(getErr)
+			} else {
+				c.On("Get", mock.Anything, mock.Anything,
+					mock.AnythingOfType("*v1alpha1.PhaseClass"), mock.Anything).
+					Run(func(args mock.Arguments) {
+						args.Get(2).(*corev1alpha1.PhaseClass).Spec = *test.phaseClass
+					}).
+					Return(nil)
+			}
+
+			remote := &remotePhaseReconcilerMock{}
+			remote.
+				On("Reconcile", mock.Anything, mock.Anything, mock.Anything).
+				Return([]corev1alpha1.ControlledObjectReference{}, controllers.ProbingResult{}, nil)
+
+			handler := &phaseHandlerMock{}
+			handler.
+				On("ReconcilePhase", mock.Anything, mock.Anything).
+				Return(PhaseResult{ControllerOf: controlled, FailedProbes: []string{"not ready"}}, nil)
+
+			r := newPhaseClassRouter(c, testScheme, remote)
+			r.webhook = func(*corev1alpha1.PhaseClassWebhook) (PhaseHandler, error) {
+				return handler, nil
+			}
+			if len(test.register) > 0 {
+				r.Register(test.register, handler)
+			}
+
+			objectSet := newGenericObjectSet(testScheme)
+			controllerOf, probingResult, err := r.Reconcile(context.Background(), objectSet, phase)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			if test.remote {
+				remote.AssertCalled(t, "Reconcile", mock.Anything, objectSet, phase)
+				handler.AssertNotCalled(t, "ReconcilePhase", mock.Anything, mock.Anything)
+				return
+			}
+			remote.AssertNotCalled(t, "Reconcile", mock.Anything, mock.Anything, mock.Anything)
+			handler.AssertCalled(t, "ReconcilePhase", mock.Anything, PhaseRequest{
+				ObjectSet: objectSet.ClientObject(),
+				Phase:     phase,
+			})
+			assert.Equal(t, controlled, controllerOf)
+			assert.Equal(t, controllers.ProbingResult{
+				PhaseName:    "infra",
+				FailedProbes: []string{"not ready"},
+			}, probingResult)
+		})
+	}
+}
+
+func TestWebhookPhaseHandler(t *testing.T) {
+	var received PhaseWebhookRequest
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&received)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		res := PhaseWebhookResponse{CleanupDone: true}
+		if received.Operation == PhaseWebhookOperationReconcile {
+			res = PhaseWebhookResponse{FailedProbes: []string{"bucket not ready"}}
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	defer srv.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	h, err := newWebhookPhaseHandler(testScheme, &corev1alpha1.PhaseClassWebhook{
+		URL:      srv.URL,
+		CABundle: caBundle,
+	})
+	require.NoError(t, err)
+
+	objectSet := newGenericObjectSet(testScheme)
+	objectSet.ClientObject().SetName("test")
+	objectSet.ClientObject().SetNamespace("test-ns")
+	req := PhaseRequest{
+		ObjectSet: objectSet.ClientObject(),
+		Phase:     corev1alpha1.ObjectSetTemplatePhase{Name: "infra", Class: "terraform"},
+		Revision:  3,
+	}
+
+	ctx := context.Background()
+	res, err := h.ReconcilePhase(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bucket not ready"}, res.FailedProbes)
+	assert.Equal(t, PhaseWebhookObjectSet{
+		APIVersion: corev1alpha1.GroupVersion.String(),
+		Kind:       "ObjectSet",
+		Name:       "test",
+		Namespace:  "test-ns",
+		Revision:   3,
+	}, received.ObjectSet)
+	assert.Equal(t, "infra", received.Phase.Name)
+
+	cleanupDone, err := h.TeardownPhase(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, cleanupDone)
+	assert.Equal(t, PhaseWebhookOperationTeardown, received.Operation)
+
+	_, err = newWebhookPhaseHandler(testScheme, &corev1alpha1.PhaseClassWebhook{URL: "http://example.com"})
+	assert.ErrorIs(t, err, errPhaseWebhookHTTPS)
+}
@@ -0,0 +1,187 @@
+package objectsets
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// PhaseWebhookOperation is the operation requested from a phase webhook.
+type PhaseWebhookOperation string
+
+const (
+	PhaseWebhookOperationReconcile PhaseWebhookOperation = "Reconcile"
+	PhaseWebhookOperationTeardown  PhaseWebhookOperation = "Teardown"
+)
+
+// PhaseWebhookRequest is POSTed as JSON to the webhook of a PhaseClass.
+type PhaseWebhookRequest struct {
+	Operation PhaseWebhookOperation `json:"operation"`
+	// ObjectSet or ClusterObjectSet owning the phase.
+	ObjectSet PhaseWebhookObjectSet               `json:"objectSet"`
+	Phase     corev1alpha1.ObjectSetTemplatePhase `json:"phase"`
+}
+
+// PhaseWebhookObjectSet identifies the ObjectSet owning a phase.
+type PhaseWebhookObjectSet struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Name       string    `json:"name"`
+	Namespace  string    `json:"namespace,omitempty"`
+	UID        types.UID `json:"uid"`
+	Revision   int64     `json:"revision"`
+	Paused     bool      `json:"paused,omitempty"`
+}
+
+// PhaseWebhookResponse is expected as JSON response from the webhook of a PhaseClass.
+type PhaseWebhookResponse struct {
+	// Objects actively controlled by the phase.
+	ControllerOf []corev1alpha1.ControlledObjectReference `json:"controllerOf,omitempty"`
+	// The phase is not Available, as long as any probe fails.
+	FailedProbes []string `json:"failedProbes,omitempty"`
+	// Reported for Teardown requests, Teardown is requested again until true.
+	CleanupDone bool `json:"cleanupDone,omitempty"`
+	// Error executing the operation.
+	Error string `json:"error,omitempty"`
+}
+
+const (
+	defaultPhaseWebhookTimeout = 10 * time.Second
+	// Limits the size of responses read from phase webhooks.
+	maxPhaseWebhookResponseBytes = 1 << 20
+)
+
+var errPhaseWebhookHTTPS = errors.New("phase webhook URL must use https")
+
+// webhookPhaseHandler executes phases by calling the webhook of a PhaseClass.
+type webhookPhaseHandler struct {
+	scheme *runtime.Scheme
+	url    string
+	client *http.Client
+}
+
+func newWebhookPhaseHandler(
+	scheme *runtime.Scheme, webhook *corev1alpha1.PhaseClassWebhook,
+) (PhaseHandler, error) {
+	u, err := url.Parse(webhook.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing phase webhook URL: %w", err)
+	}
+	if u.Scheme != "https" {
+		return nil, errPhaseWebhookHTTPS
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(webhook.CABundle) > 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(webhook.CABundle) {
+			return nil, fmt.Errorf("phase webhook caBundle contains no PEM encoded certificates")
+		}
+	}
+
+	timeout := defaultPhaseWebhookTimeout
+	if webhook.TimeoutSeconds > 0 {
+		timeout = time.Duration(webhook.TimeoutSeconds) * time.Second
+	}
+
+	return &webhookPhaseHandler{
+		scheme: scheme,
+		url:    u.String(),
+		client: &http.Client{
+			Timeout: timeout,
+			// Handlers are created for every call,
+			// so connections must not be kept around.
+			Transport: &http.Transport{
+				TLSClientConfig:   tlsConfig,
+				DisableKeepAlives: true,
+			},
+		},
+	}, nil
+}
+
+func (h *webhookPhaseHandler) ReconcilePhase(ctx context.Context, req PhaseRequest) (PhaseResult, error) {
+	res, err := h.call(ctx, PhaseWebhookOperationReconcile, req)
+	if err != nil {
+		return PhaseResult{}, err
+	}
+	return PhaseResult{
+		ControllerOf: res.ControllerOf,
+		FailedProbes: res.FailedProbes,
+	}, nil
+}
+
+func (h *webhookPhaseHandler) TeardownPhase(ctx context.Context, req PhaseRequest) (cleanupDone bool, err error) {
+	res, err := h.call(ctx, PhaseWebhookOperationTeardown, req)
+	if err != nil {
+		return false, err
+	}
+	return res.CleanupDone, nil
+}
+
+func (h *webhookPhaseHandler) call(
+	ctx context.Context, op PhaseWebhookOperation, req PhaseRequest,
+) (*PhaseWebhookResponse, error) {
+	gvk, err := apiutil.GVKForObject(req.ObjectSet, h.scheme)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(PhaseWebhookRequest{
+		Operation: op,
+		ObjectSet: PhaseWebhookObjectSet{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       req.ObjectSet.GetName(),
+			Namespace:  req.ObjectSet.GetNamespace(),
+			UID:        req.ObjectSet.GetUID(),
+			Revision:   req.Revision,
+			Paused:     req.Paused,
+		},
+		Phase: req.Phase,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding phase webhook request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpRes, err := h.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("calling phase webhook: %w", err)
+	}
+	defer httpRes.Body.Close()
+
+	resBody, err := io.ReadAll(io.LimitReader(httpRes.Body, maxPhaseWebhookResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("reading phase webhook response: %w", err)
+	}
+	if httpRes.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("phase webhook responded with %s", httpRes.Status)
+	}
+
+	res := &PhaseWebhookResponse{}
+	if err := json.Unmarshal(resBody, res); err != nil {
+		return nil, fmt.Errorf("decoding phase webhook response: %w", err)
+	}
+	if len(res.Error) > 0 {
+		return nil, fmt.Errorf("phase webhook: %s", res.Error)
+	}
+	return res, nil
+}
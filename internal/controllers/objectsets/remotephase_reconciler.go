@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -25,6 +27,7 @@ type objectSetRemotePhaseReconciler struct {
 	client            client.Client
 	scheme            *runtime.Scheme
 	newObjectSetPhase genericObjectSetPhaseFactory
+	clock             clock
 }
 
 func newObjectSetRemotePhaseReconciler(
@@ -36,10 +39,14 @@ func newObjectSetRemotePhaseReconciler(
 		client:            client,
 		scheme:            scheme,
 		newObjectSetPhase: newObjectSetPhase,
+		clock:             defaultClock{},
 	}
 }
 
-const noStatusProbeFailure = "no status reported"
+const (
+	noStatusProbeFailure          = "no status reported"
+	remoteUnreachableProbeFailure = "remote phase manager unreachable"
+)
 
 // Teardown just ensures the remote ObjectSetPhase object
 // has been deleted from the cluster.
@@ -128,41 +135,49 @@ func (r *objectSetRemotePhaseReconciler) Reconcile(
 	remotes := objectSet.GetRemotePhases()
 	objectSet.SetRemotePhases(addRemoteObjectSetPhase(remotes, ref))
 
-	// Pause/Unpause
-	if currentObjectSetPhase.IsPaused() != desiredObjectSetPhase.IsPaused() {
-		current := currentObjectSetPhase.ClientObject()
-		patch := map[string]interface{}{
-			"metadata": map[string]interface{}{
-				"resourceVersion": current.GetResourceVersion(),
-			},
-			"spec": map[string]interface{}{
-				"paused": desiredObjectSetPhase.IsPaused(),
-			},
-		}
+	// Pause/Unpause and follow the ObjectSet to its shard.
+	if patch := objectSetPhasePatch(currentObjectSetPhase, desiredObjectSetPhase); patch != nil {
 		patchJSON, err := json.Marshal(patch)
 		if err != nil {
 			panic(err)
 		}
 		if err := r.client.Patch(
-			ctx, current, client.RawPatch(types.MergePatchType, patchJSON)); err != nil {
+			ctx, currentObjectSetPhase.ClientObject(), client.RawPatch(types.MergePatchType, patchJSON)); err != nil {
 			return nil, controllers.ProbingResult{}, fmt.Errorf("patching ObjectSetPhase: %w", err)
 		}
 	}
 
 	// ObjectSetPhase already exists
-	// -> copy mapped status conditions
+	// -> copy mapped status conditions and fields
 	controllers.MapConditions(
 		ctx,
 		currentObjectSetPhase.ClientObject().GetGeneration(), currentObjectSetPhase.GetConditions(),
 		objectSet.ClientObject().GetGeneration(), objectSet.GetConditions(),
 	)
+	for destination, value := range currentObjectSetPhase.GetStatusMappedFields() {
+		objectSet.SetStatusMappedField(destination, value)
+	}
+	activeObjects := currentObjectSetPhase.GetStatusControllerOf()
+
+	// -> check heartbeat, reported status may be outdated
+	unreachable, err := r.reconcileHeartbeat(ctx, objectSet, currentObjectSetPhase, phase.Class)
+	if err != nil {
+		return nil, controllers.ProbingResult{}, err
+	}
+	if unreachable {
+		return activeObjects, controllers.ProbingResult{
+			PhaseName: phase.Name,
+			FailedProbes: []string{
+				remoteUnreachableProbeFailure,
+			},
+		}, nil
+	}
 
 	// -> check status
 	availableCond := meta.FindStatusCondition(
 		currentObjectSetPhase.GetConditions(),
 		corev1alpha1.ObjectSetAvailable,
 	)
-	activeObjects := currentObjectSetPhase.GetStatusControllerOf()
 	if availableCond == nil ||
 		availableCond.ObservedGeneration !=
 			currentObjectSetPhase.ClientObject().GetGeneration() {
@@ -180,15 +195,117 @@ func (r *objectSetRemotePhaseReconciler) Reconcile(
 	}
 
 	// Remote Phase is not Available!
-	// Reports its message as failed probe output.
+	// Reports detailed probe failures, if the remote phase provides them
+	// and falls back to the condition message otherwise.
+	failedProbes := currentObjectSetPhase.GetStatusFailedProbes()
+	if len(failedProbes) == 0 {
+		failedProbes = []string{availableCond.Message}
+	}
 	return activeObjects, controllers.ProbingResult{
-		PhaseName: phase.Name,
-		FailedProbes: []string{
-			availableCond.Message,
-		},
+		PhaseName:    phase.Name,
+		FailedProbes: failedProbes,
 	}, nil
 }
 
+// Reports the ObjectSetPhase and its parent ObjectSet as RemoteUnreachable,
+// when the remote phase manager stopped renewing its heartbeat Lease
+// or reports that the target cluster rejects its credentials.
+// Remote phase managers not sending heartbeats are assumed to be reachable.
+func (r *objectSetRemotePhaseReconciler) reconcileHeartbeat(
+	ctx context.Context, objectSet genericObjectSet,
+	objectSetPhase genericObjectSetPhase, class string,
+) (unreachable bool, err error) {
+	namespace := objectSet.ClientObject().GetNamespace()
+	if len(namespace) == 0 {
+		// Heartbeat Leases are namespaced.
+		return false, nil
+	}
+
+	lease := &coordinationv1.Lease{}
+	err = r.client.Get(ctx, client.ObjectKey{
+		Name:      controllers.RemotePhaseHeartbeatLeaseName(class),
+		Namespace: namespace,
+	}, lease)
+	if errors.IsNotFound(err) {
+		return false, r.updateRemoteUnreachableCondition(ctx, objectSetPhase, nil)
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting heartbeat Lease: %w", err)
+	}
+
+	expired, _ := controllers.RemotePhaseHeartbeatExpired(lease, r.clock.Now())
+	if expired {
+		lastHeartbeat := "never"
+		if lease.Spec.RenewTime != nil {
+			lastHeartbeat = lease.Spec.RenewTime.UTC().Format(time.RFC3339)
+		}
+		return true, r.reportRemoteUnreachable(ctx, objectSet, objectSetPhase,
+			corev1alpha1.ReasonHeartbeatExpired, fmt.Sprintf(
+				"Remote phase manager for class %q did not report since %s.", class, lastHeartbeat))
+	}
+
+	if unauthorizedMsg, unauthorized := controllers.RemotePhaseHeartbeatUnauthorized(lease); unauthorized {
+		return true, r.reportRemoteUnreachable(ctx, objectSet, objectSetPhase,
+			corev1alpha1.ReasonUnauthorized, fmt.Sprintf(
+				"Remote phase manager for class %q is not authorized by the target cluster: %s", class, unauthorizedMsg))
+	}
+
+	return false, r.updateRemoteUnreachableCondition(ctx, objectSetPhase, nil)
+}
+
+// Sets the RemoteUnreachable condition on the ObjectSetPhase and its parent ObjectSet.
+func (r *objectSetRemotePhaseReconciler) reportRemoteUnreachable(
+	ctx context.Context, objectSet genericObjectSet,
+	objectSetPhase genericObjectSetPhase, reason, msg string,
+) error {
+	if err := r.updateRemoteUnreachableCondition(ctx, objectSetPhase, &metav1.Condition{
+		Type:               corev1alpha1.ObjectSetPhaseRemoteUnreachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: objectSetPhase.ClientObject().GetGeneration(),
+	}); err != nil {
+		return err
+	}
+	meta.SetStatusCondition(objectSet.GetConditions(), metav1.Condition{
+		Type:               corev1alpha1.ObjectSetRemoteUnreachable,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            fmt.Sprintf("Phase %q: %s", objectSetPhase.ClientObject().GetName(), msg),
+		ObservedGeneration: objectSet.ClientObject().GetGeneration(),
+	})
+	return nil
+}
+
+// Sets or removes the RemoteUnreachable condition of the ObjectSetPhase.
+// The remote phase manager can't report this condition itself.
+func (r *objectSetRemotePhaseReconciler) updateRemoteUnreachableCondition(
+	ctx context.Context, objectSetPhase genericObjectSetPhase, cond *metav1.Condition,
+) error {
+	conditions := objectSetPhase.GetConditions()
+	existing := meta.FindStatusCondition(
+		conditions, corev1alpha1.ObjectSetPhaseRemoteUnreachable)
+	switch {
+	case cond == nil && existing == nil:
+		return nil
+	case cond == nil:
+		meta.RemoveStatusCondition(&conditions, corev1alpha1.ObjectSetPhaseRemoteUnreachable)
+	case existing != nil && existing.Status == cond.Status &&
+		existing.Reason == cond.Reason &&
+		existing.Message == cond.Message &&
+		existing.ObservedGeneration == cond.ObservedGeneration:
+		return nil
+	default:
+		meta.SetStatusCondition(&conditions, *cond)
+	}
+	objectSetPhase.SetConditions(conditions)
+
+	if err := r.client.Status().Update(ctx, objectSetPhase.ClientObject()); err != nil {
+		return fmt.Errorf("updating ObjectSetPhase status: %w", err)
+	}
+	return nil
+}
+
 func (r *objectSetRemotePhaseReconciler) desiredObjectSetPhase(
 	objectSet genericObjectSet,
 	phase corev1alpha1.ObjectSetTemplatePhase,
@@ -204,6 +321,7 @@ func (r *objectSetRemotePhaseReconciler) desiredObjectSetPhase(
 
 	desiredObjectSetPhase.SetPhase(phase)
 	desiredObjectSetPhase.SetAvailabilityProbes(objectSet.GetAvailabilityProbes())
+	desiredObjectSetPhase.SetAllowCriticalKinds(objectSet.GetAllowCriticalKinds())
 	desiredObjectSetPhase.SetRevision(objectSet.GetRevision())
 	desiredObjectSetPhase.SetPrevious(objectSet.GetPrevious())
 	if objectSet.IsPaused() {
@@ -218,6 +336,43 @@ func (r *objectSetRemotePhaseReconciler) desiredObjectSetPhase(
 	return desiredObjectSetPhase, nil
 }
 
+// Returns a merge patch updating the paused state and the shard label of the current ObjectSetPhase,
+// or nil if it already matches the desired ObjectSetPhase.
+func objectSetPhasePatch(current, desired genericObjectSetPhase) map[string]interface{} {
+	currentObj, desiredObj := current.ClientObject(), desired.ClientObject()
+	metadata := map[string]interface{}{
+		"resourceVersion": currentObj.GetResourceVersion(),
+	}
+	patch := map[string]interface{}{"metadata": metadata}
+
+	var changed bool
+	if current.IsPaused() != desired.IsPaused() {
+		patch["spec"] = map[string]interface{}{
+			"paused": desired.IsPaused(),
+		}
+		changed = true
+	}
+
+	currentShard, currentHasShard := currentObj.GetLabels()[controllers.ShardLabel]
+	desiredShard, desiredHasShard := desiredObj.GetLabels()[controllers.ShardLabel]
+	if currentShard != desiredShard || currentHasShard != desiredHasShard {
+		// null removes the label.
+		var shard interface{}
+		if desiredHasShard {
+			shard = desiredShard
+		}
+		metadata["labels"] = map[string]interface{}{
+			controllers.ShardLabel: shard,
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return patch
+}
+
 func objectSetPhaseName(
 	objectSet genericObjectSet,
 	phase corev1alpha1.ObjectSetTemplatePhase,
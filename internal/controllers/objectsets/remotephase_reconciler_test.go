@@ -3,17 +3,22 @@ package objectsets
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/testutil"
 )
 
@@ -156,6 +161,75 @@ func TestObjectSetRemotePhaseReconciler_desiredObjectSetPhase(
 	assert.Equal(t, objectSet.Namespace, objectSetPhase.Namespace)
 }
 
+func Test_objectSetPhasePatch(t *testing.T) {
+	t.Parallel()
+
+	newPhase := func(paused bool, labels map[string]string) genericObjectSetPhase {
+		phase := newGenericObjectSetPhase(testScheme)
+		phase.ClientObject().SetLabels(labels)
+		phase.ClientObject().SetResourceVersion("1")
+		phase.SetPaused(paused)
+		return phase
+	}
+
+	tests := []struct {
+		name     string
+		current  genericObjectSetPhase
+		desired  genericObjectSetPhase
+		expected map[string]interface{}
+	}{
+		{
+			name:    "up-to-date",
+			current: newPhase(false, map[string]string{controllers.ShardLabel: "1"}),
+			desired: newPhase(false, map[string]string{controllers.ShardLabel: "1"}),
+		},
+		{
+			name:    "pause",
+			current: newPhase(false, nil),
+			desired: newPhase(true, nil),
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{"resourceVersion": "1"},
+				"spec":     map[string]interface{}{"paused": true},
+			},
+		},
+		{
+			name:    "shard changed",
+			current: newPhase(false, map[string]string{controllers.ShardLabel: "1"}),
+			desired: newPhase(false, map[string]string{controllers.ShardLabel: "2"}),
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"resourceVersion": "1",
+					"labels":          map[string]interface{}{controllers.ShardLabel: "2"},
+				},
+			},
+		},
+		{
+			name:    "shard removed",
+			current: newPhase(false, map[string]string{controllers.ShardLabel: "1"}),
+			desired: newPhase(false, nil),
+			expected: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"resourceVersion": "1",
+					"labels":          map[string]interface{}{controllers.ShardLabel: nil},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			patch := objectSetPhasePatch(test.current, test.desired)
+			if test.expected == nil {
+				assert.Nil(t, patch)
+				return
+			}
+			assert.Equal(t, test.expected, patch)
+		})
+	}
+}
+
 func TestObjectSetRemotePhaseReconciler_TeardownNamespaceDeletion_ObjectSet(t *testing.T) {
 	ctx := context.Background()
 	c := testutil.NewClient()
@@ -263,3 +337,193 @@ func TestObjectSetRemotePhaseReconciler_TeardownNamespaceDeletion_ClusterObjectS
 	c.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
 	c.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1.Namespace"), mock.Anything)
 }
+
+func TestObjectSetRemotePhaseReconciler_Reconcile_FailedProbes(t *testing.T) {
+	tests := []struct {
+		name                 string
+		failedProbes         []string
+		expectedFailedProbes []string
+	}{
+		{
+			name: "detailed failed probes",
+			failedProbes: []string{
+				"apps Deployment my-namespace/a: not ready",
+				"apps Deployment my-namespace/b: not ready",
+			},
+			expectedFailedProbes: []string{
+				"apps Deployment my-namespace/a: not ready",
+				"apps Deployment my-namespace/b: not ready",
+			},
+		},
+		{
+			name:                 "condition message fallback",
+			expectedFailedProbes: []string{"something is not ready"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clientMock := testutil.NewClient()
+			r := newObjectSetRemotePhaseReconciler(clientMock, testScheme, newGenericObjectSetPhase)
+
+			genObjectSet := newGenericObjectSet(testScheme)
+			objectSet := genObjectSet.ClientObject().(*corev1alpha1.ObjectSet)
+			objectSet.Name = "my-stuff"
+			objectSet.Namespace = "my-namespace"
+
+			phase := corev1alpha1.ObjectSetTemplatePhase{
+				Name:  "phase-1",
+				Class: "remote",
+			}
+
+			clientMock.
+				On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.ObjectSetPhase"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					osp := args.Get(2).(*corev1alpha1.ObjectSetPhase)
+					osp.Status.Conditions = []metav1.Condition{{
+						Type:    corev1alpha1.ObjectSetAvailable,
+						Status:  metav1.ConditionFalse,
+						Message: "something is not ready",
+					}}
+					osp.Status.FailedProbes = test.failedProbes
+				}).
+				Return(nil)
+			clientMock.
+				On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1.Lease"), mock.Anything).
+				Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+			_, probingResult, err := r.Reconcile(context.Background(), genObjectSet, phase)
+			require.NoError(t, err)
+			assert.Equal(t, "phase-1", probingResult.PhaseName)
+			assert.Equal(t, test.expectedFailedProbes, probingResult.FailedProbes)
+		})
+	}
+}
+
+func TestObjectSetRemotePhaseReconciler_Reconcile_Heartbeat(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	leaseDurationSeconds := int32(60)
+
+	tests := []struct {
+		name                 string
+		renewTime            time.Time
+		annotations          map[string]string
+		existingConditions   []metav1.Condition
+		expectUnreachable    bool
+		expectReason         string
+		expectStatusUpdate   bool
+		expectPhaseCondition bool
+	}{
+		{
+			name:              "expired",
+			renewTime:         now.Add(-2 * time.Minute),
+			expectUnreachable: true,
+			expectReason:      corev1alpha1.ReasonHeartbeatExpired,
+			// Condition reported on the ObjectSetPhase.
+			expectStatusUpdate:   true,
+			expectPhaseCondition: true,
+		},
+		{
+			name:      "renewed",
+			renewTime: now.Add(-10 * time.Second),
+		},
+		{
+			name:      "unauthorized",
+			renewTime: now.Add(-10 * time.Second),
+			annotations: map[string]string{
+				controllers.RemotePhaseHeartbeatUnauthorizedAnnotation: "Unauthorized",
+			},
+			expectUnreachable: true,
+			expectReason:      corev1alpha1.ReasonUnauthorized,
+			// Condition reported on the ObjectSetPhase.
+			expectStatusUpdate:   true,
+			expectPhaseCondition: true,
+		},
+		{
+			name:      "renewed again",
+			renewTime: now.Add(-10 * time.Second),
+			existingConditions: []metav1.Condition{{
+				Type:   corev1alpha1.ObjectSetPhaseRemoteUnreachable,
+				Status: metav1.ConditionTrue,
+			}},
+			// Condition removed from the ObjectSetPhase.
+			expectStatusUpdate: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			clientMock := testutil.NewClient()
+			r := newObjectSetRemotePhaseReconciler(clientMock, testScheme, newGenericObjectSetPhase)
+			cm := &clockMock{}
+			cm.On("Now").Return(now)
+			r.clock = cm
+
+			genObjectSet := newGenericObjectSet(testScheme)
+			objectSet := genObjectSet.ClientObject().(*corev1alpha1.ObjectSet)
+			objectSet.Name = "my-stuff"
+			objectSet.Namespace = "my-namespace"
+
+			phase := corev1alpha1.ObjectSetTemplatePhase{
+				Name:  "phase-1",
+				Class: "remote",
+			}
+
+			clientMock.
+				On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.ObjectSetPhase"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					osp := args.Get(2).(*corev1alpha1.ObjectSetPhase)
+					osp.Status.Conditions = append([]metav1.Condition{{
+						Type:   corev1alpha1.ObjectSetAvailable,
+						Status: metav1.ConditionTrue,
+					}}, test.existingConditions...)
+				}).
+				Return(nil)
+			clientMock.
+				On("Get", mock.Anything, client.ObjectKey{
+					Name:      "remote-phase-remote-heartbeat",
+					Namespace: "my-namespace",
+				}, mock.AnythingOfType("*v1.Lease"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					lease := args.Get(2).(*coordinationv1.Lease)
+					lease.Annotations = test.annotations
+					renewTime := metav1.NewMicroTime(test.renewTime)
+					lease.Spec.RenewTime = &renewTime
+					lease.Spec.LeaseDurationSeconds = &leaseDurationSeconds
+				}).
+				Return(nil)
+			var updatedPhase *corev1alpha1.ObjectSetPhase
+			clientMock.StatusMock.
+				On("Update", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					updatedPhase = args.Get(1).(*corev1alpha1.ObjectSetPhase)
+				}).
+				Return(nil)
+
+			_, probingResult, err := r.Reconcile(context.Background(), genObjectSet, phase)
+			require.NoError(t, err)
+
+			if test.expectUnreachable {
+				assert.Equal(t, []string{remoteUnreachableProbeFailure}, probingResult.FailedProbes)
+				cond := meta.FindStatusCondition(
+					objectSet.Status.Conditions, corev1alpha1.ObjectSetRemoteUnreachable)
+				require.NotNil(t, cond)
+				assert.Equal(t, metav1.ConditionTrue, cond.Status)
+				assert.Equal(t, test.expectReason, cond.Reason)
+			} else {
+				assert.True(t, probingResult.IsZero())
+				assert.Nil(t, meta.FindStatusCondition(
+					objectSet.Status.Conditions, corev1alpha1.ObjectSetRemoteUnreachable))
+			}
+
+			if !test.expectStatusUpdate {
+				clientMock.StatusMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NotNil(t, updatedPhase)
+			assert.Equal(t, test.expectPhaseCondition, meta.IsStatusConditionTrue(
+				updatedPhase.Status.Conditions, corev1alpha1.ObjectSetPhaseRemoteUnreachable))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package objecttemplate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestCRDValidation_pruneOnMissingRequiresOptional(t *testing.T) {
+	t.Parallel()
+
+	for _, crd := range []string{
+		"package-operator.run_objecttemplates.yaml",
+		"package-operator.run_clusterobjecttemplates.yaml",
+	} {
+		crd := crd
+		t.Run(crd, func(t *testing.T) {
+			t.Parallel()
+
+			validate := testutil.NewCRDValidator(t, filepath.Join("..", "..", "..", "config", "crds", crd))
+
+			tests := []struct {
+				name   string
+				source map[string]interface{}
+				valid  bool
+			}{
+				{name: "required", source: map[string]interface{}{}, valid: true},
+				{name: "optional", source: map[string]interface{}{"optional": true}, valid: true},
+				{
+					name:   "optional with pruneOnMissing",
+					source: map[string]interface{}{"optional": true, "pruneOnMissing": true},
+					valid:  true,
+				},
+				{name: "pruneOnMissing without optional", source: map[string]interface{}{"pruneOnMissing": true}},
+				{
+					name:   "pruneOnMissing with optional false",
+					source: map[string]interface{}{"optional": false, "pruneOnMissing": true},
+				},
+			}
+			for _, test := range tests {
+				test := test
+				t.Run(test.name, func(t *testing.T) {
+					t.Parallel()
+
+					source := map[string]interface{}{
+						"apiVersion": "v1",
+						"kind":       "ConfigMap",
+						"name":       "config",
+						"items":      []interface{}{},
+					}
+					for k, v := range test.source {
+						source[k] = v
+					}
+					errs := validate(map[string]interface{}{
+						"spec": map[string]interface{}{
+							"template": "",
+							"sources":  []interface{}{source},
+						},
+					})
+					if test.valid {
+						assert.Empty(t, errs)
+						return
+					}
+					if assert.Len(t, errs, 1) {
+						assert.Contains(t, errs[0].Error(), "pruneOnMissing requires optional")
+					}
+				})
+			}
+		})
+	}
+}
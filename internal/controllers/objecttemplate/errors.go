@@ -1,12 +1,16 @@
 package objecttemplate
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ErrEmptySourceName is returned when a templated source name renders to an empty string.
+var ErrEmptySourceName = errors.New("source name template rendered empty")
+
 type JSONPathFormatError struct {
 	Path string
 }
@@ -26,6 +30,18 @@ func (e *SourceError) Error() string {
 		client.ObjectKeyFromObject(e.Source), e.Err)
 }
 
+// ObjectCollisionError is returned when the templated object
+// already exists and is controlled by another object.
+type ObjectCollisionError struct {
+	Object client.Object
+}
+
+func (e *ObjectCollisionError) Error() string {
+	return fmt.Sprintf("refusing adoption, %s %s is controlled by another object",
+		e.Object.GetObjectKind().GroupVersionKind().Kind,
+		client.ObjectKeyFromObject(e.Object))
+}
+
 type SourceKeyNotFoundError struct {
 	Key string
 }
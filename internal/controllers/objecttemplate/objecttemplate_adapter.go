@@ -13,8 +13,12 @@ type genericObjectTemplate interface {
 	ClientObject() client.Object
 	GetTemplate() string
 	GetSources() []corev1alpha1.ObjectTemplateSource
+	GetExternalSources() []corev1alpha1.ObjectTemplateExternalSource
+	GetConditionMappings() []corev1alpha1.ConditionMapping
 	GetConditions() *[]metav1.Condition
 	GetGeneration() int64
+	GetStatusTemplatedObject() *corev1alpha1.ObjectTemplateObjectReference
+	SetStatusTemplatedObject(ref *corev1alpha1.ObjectTemplateObjectReference)
 	UpdatePhase()
 }
 
@@ -64,6 +68,14 @@ func (t *GenericObjectTemplate) GetSources() []corev1alpha1.ObjectTemplateSource
 	return t.Spec.Sources
 }
 
+func (t *GenericObjectTemplate) GetExternalSources() []corev1alpha1.ObjectTemplateExternalSource {
+	return t.Spec.ExternalSources
+}
+
+func (t *GenericObjectTemplate) GetConditionMappings() []corev1alpha1.ConditionMapping {
+	return t.Spec.ConditionMappings
+}
+
 func (t *GenericObjectTemplate) GetConditions() *[]metav1.Condition {
 	return &t.Status.Conditions
 }
@@ -72,6 +84,14 @@ func (t *GenericObjectTemplate) GetGeneration() int64 {
 	return t.Generation
 }
 
+func (t *GenericObjectTemplate) GetStatusTemplatedObject() *corev1alpha1.ObjectTemplateObjectReference {
+	return t.Status.TemplatedObject
+}
+
+func (t *GenericObjectTemplate) SetStatusTemplatedObject(ref *corev1alpha1.ObjectTemplateObjectReference) {
+	t.Status.TemplatedObject = ref
+}
+
 func (t *GenericObjectTemplate) UpdatePhase() {
 	t.Status.Phase = getObjectTemplatePhase(t)
 }
@@ -88,6 +108,14 @@ func (t *GenericClusterObjectTemplate) GetSources() []corev1alpha1.ObjectTemplat
 	return t.Spec.Sources
 }
 
+func (t *GenericClusterObjectTemplate) GetExternalSources() []corev1alpha1.ObjectTemplateExternalSource {
+	return t.Spec.ExternalSources
+}
+
+func (t *GenericClusterObjectTemplate) GetConditionMappings() []corev1alpha1.ConditionMapping {
+	return t.Spec.ConditionMappings
+}
+
 func (t *GenericClusterObjectTemplate) GetConditions() *[]metav1.Condition {
 	return &t.Status.Conditions
 }
@@ -96,6 +124,14 @@ func (t *GenericClusterObjectTemplate) ClientObject() client.Object {
 	return &t.ClusterObjectTemplate
 }
 
+func (t *GenericClusterObjectTemplate) GetStatusTemplatedObject() *corev1alpha1.ObjectTemplateObjectReference {
+	return t.Status.TemplatedObject
+}
+
+func (t *GenericClusterObjectTemplate) SetStatusTemplatedObject(ref *corev1alpha1.ObjectTemplateObjectReference) {
+	t.Status.TemplatedObject = ref
+}
+
 func (t *GenericClusterObjectTemplate) UpdatePhase() {
 	t.Status.Phase = getObjectTemplatePhase(t)
 }
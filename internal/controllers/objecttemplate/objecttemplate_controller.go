@@ -3,6 +3,7 @@ package objecttemplate
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/environment"
@@ -12,22 +13,30 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 
 	"package-operator.run/package-operator/internal/preflight"
+	"package-operator.run/package-operator/internal/tracing"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/dynamiccache"
+	"package-operator.run/package-operator/internal/externalsecrets"
 )
 
 type dynamicCache interface {
 	client.Reader
 	Source() source.Source
 	Free(ctx context.Context, obj client.Object) error
-	Watch(ctx context.Context, owner client.Object, obj runtime.Object) error
+	Watch(
+		ctx context.Context, owner client.Object, obj runtime.Object,
+		opts ...dynamiccache.WatchOption,
+	) error
 	OwnersForGKV(gvk schema.GroupVersionKind) []dynamiccache.OwnerReference
 }
 
@@ -35,6 +44,34 @@ type reconciler interface {
 	Reconcile(ctx context.Context, pkg genericObjectTemplate) (ctrl.Result, error)
 }
 
+// Reconciler extends the reconciliation of ObjectTemplates and ClusterObjectTemplates.
+// The given object is either a *corev1alpha1.ObjectTemplate or a *corev1alpha1.ClusterObjectTemplate.
+// Changes to the status are persisted after all reconcilers succeeded.
+type Reconciler interface {
+	Reconcile(ctx context.Context, objectTemplate client.Object) (ctrl.Result, error)
+}
+
+// Priority of the built-in reconciler rendering and applying the template.
+// Reconcilers registered with a lower priority run before the template is applied,
+// reconcilers with a higher priority run afterwards.
+const TemplateReconcilerPriority = 0
+
+type prioritizedReconciler struct {
+	priority   int
+	reconciler reconciler
+}
+
+// Adapts an exported Reconciler to the internal reconciler interface.
+type registeredReconciler struct {
+	Reconciler
+}
+
+func (r registeredReconciler) Reconcile(
+	ctx context.Context, objectTemplate genericObjectTemplate,
+) (ctrl.Result, error) {
+	return r.Reconciler.Reconcile(ctx, objectTemplate.ClientObject())
+}
+
 type preflightChecker interface {
 	Check(
 		ctx context.Context, owner, obj client.Object,
@@ -52,6 +89,12 @@ type GenericObjectTemplateController struct {
 	dynamicCache       dynamicCache
 	templateReconciler *templateReconciler
 	reconciler         []reconciler
+	registered         []prioritizedReconciler
+	rateLimiter        ratelimiter.RateLimiter
+	maintenance        *controllers.MaintenanceModeChecker
+	statusWriter       *controllers.StatusWriter
+	// Only ObjectTemplates labeled with this identity are reconciled.
+	identity controllers.OperatorIdentity
 }
 
 func NewObjectTemplateController(
@@ -97,24 +140,58 @@ func newGenericObjectTemplateController(
 			preflight.NewAPIExistence(restMapper),
 			preflight.NewEmptyNamespaceNoDefault(restMapper),
 			preflight.NewNamespaceEscalation(restMapper),
-		}),
+		}, controllers.NewObjectTemplateSourcePolicyChecker(client), externalsecrets.NewRegistry(uncachedClient)),
+		maintenance:  controllers.NewMaintenanceModeChecker(client),
+		statusWriter: controllers.NewStatusWriter(client),
 	}
-	controller.reconciler = []reconciler{controller.templateReconciler}
+	controller.registered = []prioritizedReconciler{
+		{priority: TemplateReconcilerPriority, reconciler: controller.templateReconciler},
+	}
+	controller.sortReconcilers()
 	return controller
 }
 
+// RegisterReconciler adds a Reconciler to the reconciler chain.
+// Reconcilers run in ascending order of priority,
+// reconcilers with equal priority run in order of registration.
+// Reconcilers have to be registered before the controller is started.
+func (c *GenericObjectTemplateController) RegisterReconciler(priority int, r Reconciler) {
+	c.registered = append(c.registered, prioritizedReconciler{
+		priority: priority, reconciler: registeredReconciler{r},
+	})
+	c.sortReconcilers()
+}
+
+func (c *GenericObjectTemplateController) sortReconcilers() {
+	sort.SliceStable(c.registered, func(i, j int) bool {
+		return c.registered[i].priority < c.registered[j].priority
+	})
+	c.reconciler = make([]reconciler, len(c.registered))
+	for i, r := range c.registered {
+		c.reconciler[i] = r.reconciler
+	}
+}
+
 func (c *GenericObjectTemplateController) Reconcile(
 	ctx context.Context, req ctrl.Request,
-) (ctrl.Result, error) {
+) (res ctrl.Result, err error) {
 	log := c.log.WithValues("ObjectTemplate", req.String())
 	defer log.Info("reconciled")
 	ctx = logr.NewContext(ctx, log)
 
+	ctx, span := tracing.Start(ctx, "Reconcile ObjectTemplate", tracing.RequestAttributes(req)...)
+	defer func() { tracing.End(span, err) }()
+
 	objectTemplate := c.newObjectTemplate(c.scheme)
 	if err := c.client.Get(
 		ctx, req.NamespacedName, objectTemplate.ClientObject()); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	if !c.identity.Owns(objectTemplate.ClientObject()) {
+		// Reconciled by another Package Operator installation.
+		return ctrl.Result{}, nil
+	}
+	original := objectTemplate.ClientObject().DeepCopyObject().(client.Object)
 
 	if !objectTemplate.ClientObject().GetDeletionTimestamp().IsZero() {
 		if err := controllers.FreeCacheAndRemoveFinalizer(
@@ -124,14 +201,19 @@ func (c *GenericObjectTemplateController) Reconcile(
 		return ctrl.Result{}, nil
 	}
 
+	inMaintenance, err := c.maintenance.IsInMaintenance(ctx, objectTemplate.ClientObject())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if inMaintenance {
+		// Templated objects must not be changed.
+		return ctrl.Result{RequeueAfter: controllers.MaintenanceModeRequeueInterval}, nil
+	}
+
 	if err := controllers.EnsureCachedFinalizer(ctx, c.client, objectTemplate.ClientObject()); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	var (
-		res ctrl.Result
-		err error
-	)
 	for _, r := range c.reconciler {
 		res, err = r.Reconcile(ctx, objectTemplate)
 		if err != nil || !res.IsZero() {
@@ -141,28 +223,44 @@ func (c *GenericObjectTemplateController) Reconcile(
 	if err != nil {
 		return res, err
 	}
-	return res, c.updateStatus(ctx, objectTemplate)
+	return c.updateStatus(ctx, original, objectTemplate, res)
 }
 
-func (c *GenericObjectTemplateController) updateStatus(ctx context.Context, objectTemplate genericObjectTemplate) error {
+func (c *GenericObjectTemplateController) updateStatus(
+	ctx context.Context, original client.Object, objectTemplate genericObjectTemplate, res ctrl.Result,
+) (ctrl.Result, error) {
 	objectTemplate.UpdatePhase()
-	if err := c.client.Status().Update(ctx, objectTemplate.ClientObject()); err != nil {
-		return fmt.Errorf("updating ObjectTemplate status: %w", err)
+	res, err := c.statusWriter.Update(ctx, original, objectTemplate.ClientObject(), res)
+	if err != nil {
+		return res, fmt.Errorf("updating ObjectTemplate status: %w", err)
 	}
-	return nil
+	return res, nil
 }
 
 func (c *GenericObjectTemplateController) SetEnvironment(env *manifestsv1alpha1.PackageEnvironment) {
 	c.templateReconciler.SetEnvironment(env)
 }
 
+// SetRateLimiter sets the workqueue rate limiter used when the controller is set up.
+func (c *GenericObjectTemplateController) SetRateLimiter(rl ratelimiter.RateLimiter) {
+	c.rateLimiter = rl
+}
+
+// SetOperatorIdentity limits reconciliation to ObjectTemplates labeled with the given identity
+// and labels source objects for the dynamic cache of this identity.
+func (c *GenericObjectTemplateController) SetOperatorIdentity(identity controllers.OperatorIdentity) {
+	c.identity = identity
+	c.templateReconciler.identity = identity
+}
+
 func (c *GenericObjectTemplateController) SetupWithManager(
 	mgr ctrl.Manager,
 ) error {
 	objectTemplate := c.newObjectTemplate(c.scheme).ClientObject()
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(objectTemplate).
+		WithOptions(controller.Options{RateLimiter: c.rateLimiter}).
+		For(objectTemplate, builder.WithPredicates(c.identity.Predicate())).
 		Watches(c.dynamicCache.Source(), &dynamiccache.EnqueueWatchingObjects{
 			WatcherRefGetter: c.dynamicCache,
 			WatcherType:      objectTemplate,
@@ -8,12 +8,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/testutil"
 	"package-operator.run/package-operator/internal/testutil/dynamiccachemocks"
 	"package-operator.run/package-operator/internal/testutil/restmappermock"
@@ -40,6 +43,9 @@ func TestObjectTemplateController_Reconcile(t *testing.T) {
 	c.
 		On("Get", mock.Anything, objectKey, mock.AnythingOfType("*v1alpha1.ObjectTemplate"), mock.Anything).
 		Return(nil)
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
 	c.
 		On("Patch", mock.Anything, mock.AnythingOfType("*v1alpha1.ObjectTemplate"), mock.Anything, mock.Anything).
 		Return(nil)
@@ -57,6 +63,69 @@ func TestObjectTemplateController_Reconcile(t *testing.T) {
 	dc.AssertExpectations(t)
 }
 
+func TestObjectTemplateController_Reconcile_unchangedStatus(t *testing.T) {
+	c := testutil.NewClient()
+	uncachedClient := testutil.NewClient()
+	log := testr.New(t)
+	dc := &dynamiccachemocks.DynamicCacheMock{}
+	rm := &restmappermock.RestMapperMock{}
+	controller := NewObjectTemplateController(c, uncachedClient, log, dc, testScheme, rm)
+	controller.reconciler = nil // we are testing reconcilers on their own
+
+	objectKey := client.ObjectKey{Name: "test", Namespace: "testns"}
+	c.
+		On("Get", mock.Anything, objectKey, mock.AnythingOfType("*v1alpha1.ObjectTemplate"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			objectTemplate := args.Get(2).(*corev1alpha1.ObjectTemplate)
+			objectTemplate.Finalizers = []string{controllers.CachedFinalizer}
+			objectTemplate.Status.Phase = getObjectTemplatePhase(&GenericObjectTemplate{*objectTemplate})
+		}).
+		Return(nil)
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+	ctx := context.Background()
+	res, err := controller.Reconcile(ctx, reconcile.Request{
+		NamespacedName: objectKey,
+	})
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+
+	c.StatusMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestObjectTemplateController_Reconcile_maintenanceMode(t *testing.T) {
+	c := testutil.NewClient()
+	uncachedClient := testutil.NewClient()
+	log := testr.New(t)
+	dc := &dynamiccachemocks.DynamicCacheMock{}
+	rm := &restmappermock.RestMapperMock{}
+	controller := NewObjectTemplateController(c, uncachedClient, log, dc, testScheme, rm)
+
+	objectKey := client.ObjectKey{Name: "test", Namespace: "testns"}
+	c.
+		On("Get", mock.Anything, objectKey, mock.AnythingOfType("*v1alpha1.ObjectTemplate"), mock.Anything).
+		Return(nil)
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			config := args.Get(2).(*corev1alpha1.PackageOperatorConfig)
+			config.Spec.MaintenanceMode = true
+		}).
+		Return(nil)
+
+	ctx := context.Background()
+	res, err := controller.Reconcile(ctx, reconcile.Request{
+		NamespacedName: objectKey,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, controllers.MaintenanceModeRequeueInterval, res.RequeueAfter)
+
+	c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	c.StatusMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestObjectTemplateController_Reconcile_deletion(t *testing.T) {
 	c := testutil.NewClient()
 	uncachedClient := testutil.NewClient()
@@ -93,3 +162,41 @@ func TestObjectTemplateController_Reconcile_deletion(t *testing.T) {
 
 	dc.AssertExpectations(t)
 }
+
+type recordingReconciler struct {
+	name  string
+	order *[]string
+}
+
+func (r *recordingReconciler) Reconcile(
+	_ context.Context, _ client.Object,
+) (reconcile.Result, error) {
+	*r.order = append(*r.order, r.name)
+	return reconcile.Result{}, nil
+}
+
+func TestObjectTemplateController_RegisterReconciler(t *testing.T) {
+	c := testutil.NewClient()
+	uncachedClient := testutil.NewClient()
+	log := testr.New(t)
+	dc := &dynamiccachemocks.DynamicCacheMock{}
+	rm := &restmappermock.RestMapperMock{}
+	controller := NewObjectTemplateController(c, uncachedClient, log, dc, testScheme, rm)
+
+	var order []string
+	controller.RegisterReconciler(10, &recordingReconciler{name: "after", order: &order})
+	controller.RegisterReconciler(-10, &recordingReconciler{name: "before", order: &order})
+	controller.RegisterReconciler(-10, &recordingReconciler{name: "before2", order: &order})
+
+	if assert.Len(t, controller.reconciler, 4) {
+		assert.Same(t, controller.templateReconciler, controller.reconciler[2])
+	}
+
+	// Drop the templateReconciler to check the order of the registered reconcilers.
+	controller.reconciler = append(controller.reconciler[:2], controller.reconciler[3:]...)
+	for _, r := range controller.reconciler {
+		_, err := r.Reconcile(context.Background(), &GenericObjectTemplate{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"before", "before2", "after"}, order)
+}
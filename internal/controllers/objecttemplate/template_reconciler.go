@@ -3,10 +3,11 @@ package objecttemplate
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	goerrors "errors"
 	"fmt"
-	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,12 +28,31 @@ import (
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/externalsecrets"
 	"package-operator.run/package-operator/internal/preflight"
+	"package-operator.run/package-operator/internal/tracing"
+	"package-operator.run/package-operator/internal/transform"
 )
 
 // Requeue every 30s to check if input sources exist now.
 var defaultMissingResourceRetryInterval = 30 * time.Second
 
+// Requeue every 10s to check if previously templated objects are gone,
+// as these objects might not be watched anymore.
+var defaultTeardownRetryInterval = 10 * time.Second
+
+// Requeue every 5m to refresh values from external sources,
+// unless the SecretProvider configures a different interval.
+var defaultExternalSourceRefreshInterval = 5 * time.Minute
+
+var secretProviderGVK = corev1alpha1.GroupVersion.WithKind("SecretProvider")
+
+type secretProviders interface {
+	GetSecret(
+		ctx context.Context, sp *corev1alpha1.SecretProvider, path, version string,
+	) (map[string]interface{}, error)
+}
+
 type templateReconciler struct {
 	environment.Sink
 	scheme           *runtime.Scheme
@@ -40,6 +60,9 @@ type templateReconciler struct {
 	uncachedClient   client.Reader
 	dynamicCache     dynamicCache
 	preflightChecker preflightChecker
+	sourcePolicy     *controllers.ObjectTemplateSourcePolicyChecker
+	secretProviders  secretProviders
+	identity         controllers.OperatorIdentity
 }
 
 func newTemplateReconciler(
@@ -48,6 +71,8 @@ func newTemplateReconciler(
 	uncachedClient client.Reader,
 	dynamicCache dynamicCache,
 	preflightChecker preflightChecker,
+	sourcePolicy *controllers.ObjectTemplateSourcePolicyChecker,
+	secretProviders secretProviders,
 ) *templateReconciler {
 	return &templateReconciler{
 		scheme:           scheme,
@@ -55,6 +80,8 @@ func newTemplateReconciler(
 		uncachedClient:   uncachedClient,
 		dynamicCache:     dynamicCache,
 		preflightChecker: preflightChecker,
+		sourcePolicy:     sourcePolicy,
+		secretProviders:  secretProviders,
 	}
 }
 
@@ -66,13 +93,17 @@ func (r *templateReconciler) Reconcile(
 	}()
 
 	sourcesConfig := map[string]interface{}{}
-	retryLater, err := r.getValuesFromSources(ctx, objectTemplate, sourcesConfig)
+	sourcesResult, err := r.getValuesFromSources(ctx, objectTemplate, sourcesConfig)
 	if err != nil {
 		return res, fmt.Errorf("retrieving values from sources: %w", err)
 	}
-	if retryLater {
+	if sourcesResult.retryLater {
 		res.RequeueAfter = defaultMissingResourceRetryInterval
 	}
+	if sourcesResult.refreshAfter > 0 &&
+		(res.RequeueAfter == 0 || res.RequeueAfter > sourcesResult.refreshAfter) {
+		res.RequeueAfter = sourcesResult.refreshAfter
+	}
 
 	obj := &unstructured.Unstructured{
 		Object: map[string]interface{}{},
@@ -86,6 +117,29 @@ func (r *templateReconciler) Reconcile(
 		return res, fmt.Errorf("watching new child: %w", err)
 	}
 
+	teardownDone, err := r.teardownPreviousObject(ctx, objectTemplate, obj)
+	if err != nil {
+		return res, fmt.Errorf("tearing down previously templated object: %w", err)
+	}
+	if !teardownDone &&
+		(res.RequeueAfter == 0 || res.RequeueAfter > defaultTeardownRetryInterval) {
+		res.RequeueAfter = defaultTeardownRetryInterval
+	}
+
+	if sourcesResult.prune {
+		if err := r.pruneTemplatedObject(ctx, objectTemplate, obj); err != nil {
+			return res, fmt.Errorf("pruning templated object: %w", err)
+		}
+		if teardownDone {
+			objectTemplate.SetStatusTemplatedObject(nil)
+		}
+		return res, nil
+	}
+	if teardownDone {
+		ref := templatedObjectReference(obj)
+		objectTemplate.SetStatusTemplatedObject(&ref)
+	}
+
 	existingObj := &unstructured.Unstructured{}
 	existingObj.SetGroupVersionKind(obj.GroupVersionKind())
 	if err := r.dynamicCache.Get(ctx, client.ObjectKeyFromObject(obj), existingObj); errors.IsNotFound(err) {
@@ -96,11 +150,21 @@ func (r *templateReconciler) Reconcile(
 	} else if err != nil {
 		return res, fmt.Errorf("getting existing object: %w", err)
 	}
+	if metav1.GetControllerOf(existingObj) != nil &&
+		!metav1.IsControlledBy(existingObj, objectTemplate.ClientObject()) {
+		return res, &ObjectCollisionError{Object: existingObj}
+	}
 	if err := updateStatusConditionsFromOwnedObject(ctx, objectTemplate, existingObj); err != nil {
 		return res, fmt.Errorf("updating status conditions from owned object: %w", err)
 	}
 
 	obj.SetOwnerReferences(existingObj.GetOwnerReferences())
+	if metav1.GetControllerOf(existingObj) == nil {
+		// Adopt objects that existed before the template.
+		if err := controllerutil.SetControllerReference(objectTemplate.ClientObject(), obj, r.scheme); err != nil {
+			return res, fmt.Errorf("setting owner reference: %w", err)
+		}
+	}
 	obj.SetLabels(labels.Merge(existingObj.GetLabels(), obj.GetLabels()))
 	obj.SetAnnotations(labels.Merge(existingObj.GetAnnotations(), obj.GetAnnotations()))
 
@@ -124,41 +188,237 @@ func (r *templateReconciler) handleCreation(ctx context.Context, owner, object c
 	return nil
 }
 
+// Deletes the templated object, because an optional source
+// with pruneOnMissing is not available.
+func (r *templateReconciler) pruneTemplatedObject(
+	ctx context.Context, objectTemplate genericObjectTemplate, obj *unstructured.Unstructured,
+) error {
+	existingObj := &unstructured.Unstructured{}
+	existingObj.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := r.dynamicCache.Get(ctx, client.ObjectKeyFromObject(obj), existingObj); errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting existing object: %w", err)
+	}
+
+	if !metav1.IsControlledBy(existingObj, objectTemplate.ClientObject()) {
+		// Not created by us, leave it alone.
+		return nil
+	}
+
+	if err := r.client.Delete(ctx, existingObj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting templated object: %w", err)
+	}
+	return nil
+}
+
+// Deletes the object previously created from the template,
+// if the template now renders a different object.
+// Returns true when no previous object is left.
+func (r *templateReconciler) teardownPreviousObject(
+	ctx context.Context, objectTemplate genericObjectTemplate, obj *unstructured.Unstructured,
+) (done bool, err error) {
+	prev := objectTemplate.GetStatusTemplatedObject()
+	if prev == nil || *prev == templatedObjectReference(obj) {
+		meta.RemoveStatusCondition(objectTemplate.GetConditions(), corev1alpha1.ObjectTemplateTeardownPending)
+		return true, nil
+	}
+
+	prevObj := &unstructured.Unstructured{}
+	prevObj.SetAPIVersion(prev.APIVersion)
+	prevObj.SetKind(prev.Kind)
+	err = r.uncachedClient.Get(ctx, client.ObjectKey{
+		Name: prev.Name, Namespace: prev.Namespace,
+	}, prevObj)
+	switch {
+	case errors.IsNotFound(err) || meta.IsNoMatchError(err):
+		prevObj = nil
+	case err != nil:
+		return false, fmt.Errorf("getting previous object: %w", err)
+	case !metav1.IsControlledBy(prevObj, objectTemplate.ClientObject()):
+		// Not created by us, leave it alone.
+		prevObj = nil
+	}
+	if prevObj == nil {
+		meta.RemoveStatusCondition(objectTemplate.GetConditions(), corev1alpha1.ObjectTemplateTeardownPending)
+		return true, nil
+	}
+
+	if prevObj.GetDeletionTimestamp().IsZero() {
+		if err := r.client.Delete(ctx, prevObj); client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("deleting previous object: %w", err)
+		}
+		logr.FromContextOrDiscard(ctx).Info("deleting object no longer produced by template",
+			"kind", prev.Kind, "object", client.ObjectKeyFromObject(prevObj))
+	}
+
+	meta.SetStatusCondition(objectTemplate.GetConditions(), metav1.Condition{
+		Type:               corev1alpha1.ObjectTemplateTeardownPending,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: objectTemplate.GetGeneration(),
+		Reason:             corev1alpha1.ReasonDeleting,
+		Message: fmt.Sprintf("Waiting for %s %s to be deleted.",
+			prev.Kind, client.ObjectKeyFromObject(prevObj)),
+	})
+	return false, nil
+}
+
+func templatedObjectReference(obj *unstructured.Unstructured) corev1alpha1.ObjectTemplateObjectReference {
+	return corev1alpha1.ObjectTemplateObjectReference{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+	}
+}
+
+type sourcesResult struct {
+	// At least one optional source was not found.
+	retryLater bool
+	// At least one missing optional source requests
+	// the templated object to be deleted.
+	prune bool
+	// Shortest refresh interval of all external sources,
+	// zero when there are no external sources.
+	refreshAfter time.Duration
+}
+
 func (r *templateReconciler) getValuesFromSources(
 	ctx context.Context, objectTemplate genericObjectTemplate,
 	sourcesConfig map[string]interface{},
-) (retryLater bool, err error) {
+) (res sourcesResult, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	for _, src := range objectTemplate.GetSources() {
 		sourceObj, found, err := r.getSourceObject(ctx, objectTemplate.ClientObject(), src)
 		if err != nil {
-			return false, err
+			return res, err
 		}
 		if !found {
 			log.Info(fmt.Sprintf("optional source not found, retry in %s", defaultMissingResourceRetryInterval),
-				"source", fmt.Sprintf("%s %s/%s", src.Kind, src.Namespace, src.Name))
-			retryLater = true
+				"source", fmt.Sprintf("%s %s/%s", src.Kind, sourceObj.GetNamespace(), sourceObj.GetName()))
+			res.retryLater = true
+			if src.PruneOnMissing {
+				res.prune = true
+			}
+			if err := copySourceItemDefaults(src.Items, sourcesConfig); err != nil {
+				return res, &SourceError{Source: sourceObj, Err: err}
+			}
 			continue
 		}
 		if err := copySourceItems(src.Items, sourceObj, sourcesConfig); err != nil {
-			return false, &SourceError{Source: sourceObj, Err: err}
+			return res, &SourceError{Source: sourceObj, Err: err}
 		}
 	}
-	return retryLater, nil
+
+	for _, src := range objectTemplate.GetExternalSources() {
+		providerObj, data, refreshInterval, err := r.getExternalSource(ctx, objectTemplate.ClientObject(), src)
+		if err != nil {
+			return res, err
+		}
+		if res.refreshAfter == 0 || res.refreshAfter > refreshInterval {
+			res.refreshAfter = refreshInterval
+		}
+		if data == nil {
+			log.Info(fmt.Sprintf("optional external source not found, retry in %s", defaultMissingResourceRetryInterval),
+				"provider", client.ObjectKeyFromObject(providerObj).String(), "path", src.Path)
+			res.retryLater = true
+			if err := copySourceItemDefaults(src.Items, sourcesConfig); err != nil {
+				return res, &SourceError{Source: providerObj, Err: err}
+			}
+			continue
+		}
+		if err := copySourceItems(src.Items, &unstructured.Unstructured{Object: data}, sourcesConfig); err != nil {
+			return res, &SourceError{Source: providerObj, Err: fmt.Errorf("path %s: %w", src.Path, err)}
+		}
+	}
+	return res, nil
+}
+
+// Reads the secret of an external source from the external secret manager of its SecretProvider.
+// Returns nil data when an optional SecretProvider or secret is not found.
+func (r *templateReconciler) getExternalSource(
+	ctx context.Context, objectTemplate client.Object,
+	src corev1alpha1.ObjectTemplateExternalSource,
+) (providerObj *unstructured.Unstructured, data map[string]interface{}, refreshInterval time.Duration, err error) {
+	providerObj = &unstructured.Unstructured{}
+	providerObj.SetGroupVersionKind(secretProviderGVK)
+	providerObj.SetName(src.Provider)
+	providerObj.SetNamespace(src.Namespace)
+	refreshInterval = defaultExternalSourceRefreshInterval
+
+	// SecretProviders hand out credentials for external secrets,
+	// so they are subject to the same checks as sources.
+	violations, err := preflight.List{
+		r.preflightChecker, r.sourcePolicy,
+	}.Check(ctx, objectTemplate, providerObj)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if len(violations) > 0 {
+		return nil, nil, 0, &SourceError{Source: providerObj, Err: &preflight.Error{Violations: violations}}
+	}
+
+	if len(providerObj.GetNamespace()) == 0 {
+		providerObj.SetNamespace(objectTemplate.GetNamespace())
+	}
+
+	key := client.ObjectKeyFromObject(providerObj)
+	provider := &corev1alpha1.SecretProvider{}
+	if err := r.uncachedClient.Get(ctx, key, provider); errors.IsNotFound(err) {
+		if src.Optional {
+			return providerObj, nil, refreshInterval, nil
+		}
+		return nil, nil, 0, &SourceError{Source: providerObj, Err: err}
+	} else if err != nil {
+		return nil, nil, 0, fmt.Errorf("getting SecretProvider %s in namespace %s: %w", key.Name, key.Namespace, err)
+	}
+	if provider.Spec.RefreshInterval != nil && provider.Spec.RefreshInterval.Duration > 0 {
+		refreshInterval = provider.Spec.RefreshInterval.Duration
+	}
+
+	data, err = r.secretProviders.GetSecret(ctx, provider, src.Path, src.Version)
+	switch {
+	case goerrors.Is(err, externalsecrets.ErrSecretNotFound) && src.Optional:
+		return providerObj, nil, refreshInterval, nil
+	case goerrors.Is(err, externalsecrets.ErrSecretNotFound):
+		return nil, nil, 0, &SourceError{Source: providerObj, Err: fmt.Errorf("path %s: %w", src.Path, err)}
+	case err != nil:
+		// External secret managers might be temporarily unavailable, retry with backoff.
+		return nil, nil, 0, fmt.Errorf("reading external source %s: %w", src.Path, err)
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return providerObj, data, refreshInterval, nil
 }
 
 func (r *templateReconciler) getSourceObject(
 	ctx context.Context, objectTemplate client.Object,
 	src corev1alpha1.ObjectTemplateSource,
 ) (sourceObj *unstructured.Unstructured, found bool, err error) {
+	name, err := renderSourceField(objectTemplate, src.Name)
+	if err != nil {
+		return nil, false, fmt.Errorf("rendering source name: %w", err)
+	}
+	namespace, err := renderSourceField(objectTemplate, src.Namespace)
+	if err != nil {
+		return nil, false, fmt.Errorf("rendering source namespace: %w", err)
+	}
+
 	sourceObj = &unstructured.Unstructured{}
-	sourceObj.SetName(src.Name)
+	sourceObj.SetName(name)
 	sourceObj.SetKind(src.Kind)
 	sourceObj.SetAPIVersion(src.APIVersion)
-	sourceObj.SetNamespace(src.Namespace)
+	sourceObj.SetNamespace(namespace)
+	if len(name) == 0 && len(src.Name) > 0 {
+		return nil, false, &SourceError{Source: sourceObj, Err: ErrEmptySourceName}
+	}
 
-	// Ensure we are staying within the same namespace.
-	violations, err := r.preflightChecker.Check(ctx, objectTemplate, sourceObj)
+	// Ensure we are staying within the same namespace
+	// and ClusterObjectTemplates only read sources allowed by policy.
+	violations, err := preflight.List{
+		r.preflightChecker, r.sourcePolicy,
+	}.Check(ctx, objectTemplate, sourceObj)
 	if err != nil {
 		return nil, false, err
 	}
@@ -185,11 +445,11 @@ func (r *templateReconciler) getSourceObject(
 			return nil, false, err
 		}
 		if !found {
-			return nil, false, nil
+			return sourceObj, false, nil
 		}
 
 		// Update object to ensure it is part of our cache and we get events to reconcile.
-		updatedSourceObj, err := controllers.AddDynamicCacheLabel(ctx, r.client, sourceObj)
+		updatedSourceObj, err := controllers.AddDynamicCacheLabel(ctx, r.client, r.identity, sourceObj)
 		if err != nil {
 			return nil, false, fmt.Errorf("patching source object for cache: %w", err)
 		}
@@ -200,6 +460,28 @@ func (r *templateReconciler) getSourceObject(
 	return sourceObj, true, nil
 }
 
+// Returns the LookupFunc for the lookup template function.
+// Looked up objects are read like optional sources,
+// so they are subject to the same preflight checks and source policies.
+func (r *templateReconciler) lookupFunc(ctx context.Context, objectTemplate client.Object) transform.LookupFunc {
+	return func(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+		obj, found, err := r.getSourceObject(ctx, objectTemplate, corev1alpha1.ObjectTemplateSource{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Namespace:  namespace,
+			Name:       name,
+			Optional:   true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return map[string]interface{}{}, nil
+		}
+		return obj.Object, nil
+	}
+}
+
 func (r *templateReconciler) lookupUncached(ctx context.Context, src corev1alpha1.ObjectTemplateSource, key client.ObjectKey, obj client.Object) (found bool, err error) {
 	if err := r.uncachedClient.Get(ctx, key, obj); errors.IsNotFound(err) {
 		if src.Optional {
@@ -230,7 +512,7 @@ func copySourceItem(
 	sourceObj *unstructured.Unstructured,
 	sourcesConfig map[string]interface{},
 ) error {
-	jpString, err := RelaxedJSONPathExpression(item.Key)
+	jpString, err := controllers.RelaxedJSONPathExpression(item.Key)
 	if err != nil {
 		return err
 	}
@@ -253,12 +535,97 @@ func copySourceItem(
 		value = vslice[0]
 	}
 
-	if string(item.Destination[0]) != "." {
-		return &JSONPathFormatError{Path: item.Destination}
+	value, err = convertSourceItemValue(item, value)
+	if err != nil {
+		return fmt.Errorf("converting %s: %w", item.Key, err)
 	}
-	trimmedDestination := strings.TrimPrefix(item.Destination, ".")
+
+	return setDestination(item.Destination, value, sourcesConfig)
+}
+
+// Decodes and parses the source value as configured by the item.
+func convertSourceItemValue(
+	item corev1alpha1.ObjectTemplateSourceItem, value interface{},
+) (interface{}, error) {
+	if !item.DecodeBase64 && len(item.Parse) == 0 {
+		return value, nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected string value, got %T", value)
+	}
+
+	if item.DecodeBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(str)
+		if err != nil {
+			return nil, fmt.Errorf("decoding base64: %w", err)
+		}
+		str = string(decoded)
+	}
+
+	switch item.Parse {
+	case "":
+		return str, nil
+	case corev1alpha1.ObjectTemplateSourceItemParseJSON:
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(str), &parsed); err != nil {
+			return nil, fmt.Errorf("parsing json: %w", err)
+		}
+		return parsed, nil
+	case corev1alpha1.ObjectTemplateSourceItemParseYAML:
+		var parsed interface{}
+		if err := yaml.Unmarshal([]byte(str), &parsed); err != nil {
+			return nil, fmt.Errorf("parsing yaml: %w", err)
+		}
+		return parsed, nil
+	case corev1alpha1.ObjectTemplateSourceItemParseInt:
+		parsed, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing int: %w", err)
+		}
+		return parsed, nil
+	case corev1alpha1.ObjectTemplateSourceItemParseBool:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(str))
+		if err != nil {
+			return nil, fmt.Errorf("parsing bool: %w", err)
+		}
+		return parsed, nil
+	}
+	return nil, fmt.Errorf("unknown parse format %q", item.Parse)
+}
+
+// Stores default values of the given items, used when an optional source is not found.
+func copySourceItemDefaults(
+	src []corev1alpha1.ObjectTemplateSourceItem,
+	sourcesConfig map[string]interface{},
+) error {
+	for _, item := range src {
+		if item.Default == nil {
+			continue
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(item.Default.Raw, &value); err != nil {
+			return fmt.Errorf("unmarshalling default for %s: %w", item.Destination, err)
+		}
+		if err := setDestination(item.Destination, value, sourcesConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setDestination(
+	destination string, value interface{},
+	sourcesConfig map[string]interface{},
+) error {
+	if string(destination[0]) != "." {
+		return &JSONPathFormatError{Path: destination}
+	}
+	trimmedDestination := strings.TrimPrefix(destination, ".")
 	if err := unstructured.SetNestedField(sourcesConfig, value, strings.Split(trimmedDestination, ".")...); err != nil {
-		return fmt.Errorf("setting nested field at %s: %w", item.Destination, err)
+		return fmt.Errorf("setting nested field at %s: %w", destination, err)
 	}
 
 	return nil
@@ -267,7 +634,10 @@ func copySourceItem(
 func (r *templateReconciler) templateObject(
 	ctx context.Context, sourcesConfig map[string]interface{},
 	objectTemplate genericObjectTemplate, object client.Object,
-) error {
+) (err error) {
+	ctx, span := tracing.Start(ctx, "RenderTemplate")
+	defer func() { tracing.End(span, err) }()
+
 	env, err := r.getEnvironment()
 	if err != nil {
 		return fmt.Errorf("getting environment: %w", err)
@@ -276,7 +646,8 @@ func (r *templateReconciler) templateObject(
 		Config:      sourcesConfig,
 		Environment: env,
 	}
-	transformer, err := NewTemplateTransformer(templateContext)
+	transformer, err := NewTemplateTransformer(
+		templateContext, r.lookupFunc(ctx, objectTemplate.ClientObject()))
 	if err != nil {
 		return fmt.Errorf("creating transformer: %w", err)
 	}
@@ -300,7 +671,11 @@ func (r *templateReconciler) templateObject(
 		object.SetNamespace(objectTemplate.ClientObject().GetNamespace())
 	}
 
-	object.SetLabels(labels.Merge(object.GetLabels(), map[string]string{controllers.DynamicCacheLabel: "True"}))
+	object.SetLabels(labels.Merge(object.GetLabels(), map[string]string{r.identity.DynamicCacheLabel(): "True"}))
+	if identity, ok := objectTemplate.ClientObject().GetLabels()[controllers.OperatorIdentityLabel]; ok {
+		// Templated Packages are reconciled by the same Package Operator installation as the template.
+		object.SetLabels(labels.Merge(object.GetLabels(), map[string]string{controllers.OperatorIdentityLabel: identity}))
+	}
 
 	return nil
 }
@@ -319,50 +694,67 @@ func (r *templateReconciler) getEnvironment() (map[string]interface{}, error) {
 	return env, nil
 }
 
-func updateStatusConditionsFromOwnedObject(_ context.Context, objectTemplate genericObjectTemplate, existingObj *unstructured.Unstructured) error {
+// Mirrors conditions of the templated object into the ObjectTemplate status,
+// renaming them as specified by the condition mappings of the ObjectTemplate.
+func updateStatusConditionsFromOwnedObject(
+	_ context.Context, objectTemplate genericObjectTemplate, existingObj *unstructured.Unstructured,
+) error {
 	statusObservedGeneration, ok, err := unstructured.NestedInt64(existingObj.Object, "status", "observedGeneration")
 	if err != nil {
 		return fmt.Errorf("getting status observedGeneration: %w", err)
 	}
-	if ok &&
-		statusObservedGeneration != objectTemplate.
-			ClientObject().GetGeneration() {
+	if ok && statusObservedGeneration != existingObj.GetGeneration() {
 		// all .status is outdated
 		return nil
 	}
 
-	objectConds, found, err := unstructured.NestedSlice(existingObj.Object, "status", "conditions")
+	rawConditions, found, err := unstructured.NestedFieldNoCopy(existingObj.Object, "status", "conditions")
 	if err != nil {
 		return fmt.Errorf("getting conditions from object: %w", err)
 	}
-
 	if !found {
 		return nil
 	}
-	for _, cond := range objectConds {
-		condMap, ok := cond.(map[string]interface{})
-		if !ok {
-			return errors.NewBadRequest("malformed condition")
-		}
+	j, err := json.Marshal(rawConditions)
+	if err != nil {
+		return fmt.Errorf("marshalling conditions: %w", err)
+	}
+	var objectConditions []metav1.Condition
+	if err := json.Unmarshal(j, &objectConditions); err != nil {
+		return errors.NewBadRequest("malformed condition")
+	}
 
-		condObservedGeneration, _, err := unstructured.NestedInt64(condMap, "observedGeneration")
-		if err != nil {
-			return fmt.Errorf("getting status observedGeneration: %w", err)
+	// Maps from object condition type to ObjectTemplate condition type.
+	var conditionTypeMap map[string]string
+	if mappings := objectTemplate.GetConditionMappings(); len(mappings) > 0 {
+		conditionTypeMap = map[string]string{}
+		for _, m := range mappings {
+			conditionTypeMap[m.SourceType] = m.DestinationType
 		}
-
-		if existingObj.GetGeneration() != condObservedGeneration {
+	}
+	for _, cond := range objectConditions {
+		if cond.ObservedGeneration != 0 &&
+			cond.ObservedGeneration != existingObj.GetGeneration() {
 			// condition is out of date, don't copy it over
 			continue
 		}
 
-		newCond := metav1.Condition{
-			Type:               condMap["type"].(string),
-			Status:             metav1.ConditionStatus(condMap["status"].(string)),
-			ObservedGeneration: objectTemplate.ClientObject().GetGeneration(),
-			Reason:             condMap["reason"].(string),
-			Message:            condMap["message"].(string),
+		destType := cond.Type
+		if conditionTypeMap != nil {
+			var ok bool
+			if destType, ok = conditionTypeMap[cond.Type]; !ok {
+				// condition not mapped
+				continue
+			}
 		}
-		meta.SetStatusCondition(objectTemplate.GetConditions(), newCond)
+
+		meta.SetStatusCondition(objectTemplate.GetConditions(), metav1.Condition{
+			Type:               destType,
+			Status:             cond.Status,
+			ObservedGeneration: objectTemplate.ClientObject().GetGeneration(),
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+		})
 	}
 	return nil
 }
@@ -374,7 +766,7 @@ func setObjectTemplateConditionBasedOnError(objectTemplate genericObjectTemplate
 			Type:               corev1alpha1.ObjectTemplateInvalid,
 			Status:             metav1.ConditionTrue,
 			ObservedGeneration: objectTemplate.GetGeneration(),
-			Reason:             "SourceError",
+			Reason:             corev1alpha1.ReasonSourceError,
 			Message:            sourceError.Error(),
 		})
 		return nil // don't retry error
@@ -385,47 +777,26 @@ func setObjectTemplateConditionBasedOnError(objectTemplate genericObjectTemplate
 			Type:               corev1alpha1.ObjectTemplateInvalid,
 			Status:             metav1.ConditionTrue,
 			ObservedGeneration: objectTemplate.GetGeneration(),
-			Reason:             "TemplateError",
+			Reason:             corev1alpha1.ReasonTemplateError,
 			Message:            templateError.Error(),
 		})
 		return nil // don't retry error
 	}
 
+	var collisionError *ObjectCollisionError
+	if goerrors.As(err, &collisionError) {
+		meta.SetStatusCondition(objectTemplate.GetConditions(), metav1.Condition{
+			Type:               corev1alpha1.ObjectTemplateInvalid,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: objectTemplate.GetGeneration(),
+			Reason:             corev1alpha1.ReasonObjectCollision,
+			Message:            collisionError.Error(),
+		})
+		return nil // don't retry error, changes to the object trigger a reconcile
+	}
+
 	if err == nil {
 		meta.RemoveStatusCondition(objectTemplate.GetConditions(), corev1alpha1.ObjectTemplateInvalid)
 	}
 	return err
 }
-
-var jsonRegexp = regexp.MustCompile(`^\{\.?([^{}]+)\}$|^\.?([^{}]+)$`)
-
-// RelaxedJSONPathExpression attempts to be flexible with JSONPath expressions, it accepts:
-//   - metadata.name (no leading '.' or curly braces '{...}'
-//   - {metadata.name} (no leading '.')
-//   - .metadata.name (no curly braces '{...}')
-//   - {.metadata.name} (complete expression)
-//
-// And transforms them all into a valid jsonpath expression:
-//
-//	{.metadata.name}
-//
-//nolint:goerr113
-func RelaxedJSONPathExpression(pathExpression string) (string, error) {
-	if len(pathExpression) == 0 {
-		return pathExpression, nil
-	}
-	submatches := jsonRegexp.FindStringSubmatch(pathExpression)
-	if submatches == nil {
-		return "", fmt.Errorf("unexpected path string, expected a 'name1.name2' or '.name1.name2' or '{name1.name2}' or '{.name1.name2}'")
-	}
-	if len(submatches) != 3 {
-		return "", fmt.Errorf("unexpected submatch list: %v", submatches)
-	}
-	var fieldSpec string
-	if len(submatches[1]) != 0 {
-		fieldSpec = submatches[1]
-	} else {
-		fieldSpec = submatches[2]
-	}
-	return fmt.Sprintf("{.%s}", fieldSpec), nil
-}
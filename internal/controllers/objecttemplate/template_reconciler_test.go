@@ -6,11 +6,14 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -18,7 +21,9 @@ import (
 	"sigs.k8s.io/yaml"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/externalsecrets"
 	"package-operator.run/package-operator/internal/preflight"
 	"package-operator.run/package-operator/internal/testutil"
 	"package-operator.run/package-operator/internal/testutil/dynamiccachemocks"
@@ -91,6 +96,83 @@ func Test_templateReconciler_getSourceObject_stopAtViolation(t *testing.T) {
 	require.EqualError(t, err, "for source ConfigMap default/test: here: aaaaaaah!")
 }
 
+func Test_templateReconciler_getSourceObject_templatedReference(t *testing.T) {
+	dynamicCache := &dynamiccachemocks.DynamicCacheMock{}
+	dynamicCache.
+		On("Watch", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	dynamicCache.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	r := &templateReconciler{
+		dynamicCache:     dynamicCache,
+		preflightChecker: preflight.List{},
+	}
+
+	objectTemplate := &corev1alpha1.ClusterObjectTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			Labels: map[string]string{
+				"tenant": "banana",
+			},
+			Annotations: map[string]string{
+				"example.com/source": "tenant-config",
+			},
+		},
+	}
+
+	ctx := context.Background()
+	srcObj, found, err := r.getSourceObject(
+		ctx, objectTemplate, corev1alpha1.ObjectTemplateSource{
+			Kind:      "ConfigMap",
+			Name:      `{{index .metadata.annotations "example.com/source"}}`,
+			Namespace: "{{.metadata.labels.tenant}}-system",
+		})
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "tenant-config", srcObj.GetName())
+	assert.Equal(t, "banana-system", srcObj.GetNamespace())
+}
+
+func Test_renderSourceField(t *testing.T) {
+	objectTemplate := &corev1alpha1.ClusterObjectTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+			Labels: map[string]string{
+				"tenant": "banana",
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		field    string
+		expected string
+		err      bool
+	}{
+		{name: "static", field: "static-name", expected: "static-name"},
+		{name: "label", field: "{{.metadata.labels.tenant}}", expected: "banana"},
+		{name: "name", field: "{{.metadata.name}}-src", expected: "test-src"},
+		{name: "missing", field: "{{.metadata.labels.missing}}", err: true},
+		{name: "invalid", field: "{{.metadata", err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			res, err := renderSourceField(objectTemplate, test.field)
+			if test.err {
+				var tErr *TemplateError
+				require.ErrorAs(t, err, &tErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
 func Test_copySourceItems(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -205,6 +287,300 @@ func Test_copySourceItems_nonJSONPath_destination(t *testing.T) {
 	require.EqualError(t, err, "path banana must be a JSONPath with a leading dot")
 }
 
+func Test_copySourceItems_convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		item     corev1alpha1.ObjectTemplateSourceItem
+		expected interface{}
+		err      string
+	}{
+		{
+			name:     "decode base64",
+			value:    "aGVsbG8=",
+			item:     corev1alpha1.ObjectTemplateSourceItem{DecodeBase64: true},
+			expected: "hello",
+		},
+		{
+			name:  "decode base64 and parse json",
+			value: "eyJhIjogWzEsIDJdfQ==", // {"a": [1, 2]}
+			item: corev1alpha1.ObjectTemplateSourceItem{
+				DecodeBase64: true,
+				Parse:        corev1alpha1.ObjectTemplateSourceItemParseJSON,
+			},
+			expected: map[string]interface{}{"a": []interface{}{float64(1), float64(2)}},
+		},
+		{
+			name:     "parse yaml",
+			value:    "a:\n  b: c\n",
+			item:     corev1alpha1.ObjectTemplateSourceItem{Parse: corev1alpha1.ObjectTemplateSourceItemParseYAML},
+			expected: map[string]interface{}{"a": map[string]interface{}{"b": "c"}},
+		},
+		{
+			name:     "parse int",
+			value:    "42",
+			item:     corev1alpha1.ObjectTemplateSourceItem{Parse: corev1alpha1.ObjectTemplateSourceItemParseInt},
+			expected: int64(42),
+		},
+		{
+			name:     "parse bool",
+			value:    "true",
+			item:     corev1alpha1.ObjectTemplateSourceItem{Parse: corev1alpha1.ObjectTemplateSourceItemParseBool},
+			expected: true,
+		},
+		{
+			name:  "invalid base64",
+			value: "%%%",
+			item:  corev1alpha1.ObjectTemplateSourceItem{DecodeBase64: true},
+			err:   "converting .data.something: decoding base64: illegal base64 data at input byte 0",
+		},
+		{
+			name:  "invalid int",
+			value: "banana",
+			item:  corev1alpha1.ObjectTemplateSourceItem{Parse: corev1alpha1.ObjectTemplateSourceItemParseInt},
+			err:   `converting .data.something: parsing int: strconv.ParseInt: parsing "banana": invalid syntax`,
+		},
+		{
+			name:  "not a string",
+			value: map[string]interface{}{},
+			item:  corev1alpha1.ObjectTemplateSourceItem{Parse: corev1alpha1.ObjectTemplateSourceItemParseJSON},
+			err:   "converting .data.something: expected string value, got map[string]interface {}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sourceObj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"data": map[string]interface{}{
+						"something": test.value,
+					},
+				},
+			}
+			sourcesConfig := map[string]interface{}{}
+			item := test.item
+			item.Key = ".data.something"
+			item.Destination = ".banana"
+
+			err := copySourceItems(
+				[]corev1alpha1.ObjectTemplateSourceItem{item}, sourceObj, sourcesConfig)
+			if len(test.err) > 0 {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"banana": test.expected}, sourcesConfig)
+		})
+	}
+}
+
+func Test_copySourceItemDefaults(t *testing.T) {
+	sourcesConfig := map[string]interface{}{}
+	items := []corev1alpha1.ObjectTemplateSourceItem{
+		{Key: ".data.something", Destination: ".banana"},
+		{
+			Key: ".data.other", Destination: ".apple.color",
+			Default: &apiextensionsv1.JSON{Raw: []byte(`"red"`)},
+		},
+		{
+			Key: ".data.list", Destination: ".cherries",
+			Default: &apiextensionsv1.JSON{Raw: []byte(`[1,2]`)},
+		},
+	}
+	err := copySourceItemDefaults(items, sourcesConfig)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"apple": map[string]interface{}{
+			"color": "red",
+		},
+		"cherries": []interface{}{float64(1), float64(2)},
+	}, sourcesConfig)
+}
+
+func Test_templateReconciler_getValuesFromSources_missingOptional(t *testing.T) {
+	client := testutil.NewClient()
+	uncachedClient := testutil.NewClient()
+	dynamicCache := &dynamiccachemocks.DynamicCacheMock{}
+
+	dynamicCache.
+		On("Watch", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	dynamicCache.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+	uncachedClient.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+	r := &templateReconciler{
+		client:           client,
+		uncachedClient:   uncachedClient,
+		dynamicCache:     dynamicCache,
+		preflightChecker: preflight.List{},
+	}
+
+	objectTemplate := &GenericObjectTemplate{
+		ObjectTemplate: corev1alpha1.ObjectTemplate{
+			Spec: corev1alpha1.ObjectTemplateSpec{
+				Sources: []corev1alpha1.ObjectTemplateSource{
+					{
+						APIVersion: "v1", Kind: "ConfigMap", Name: "test",
+						Optional: true, PruneOnMissing: true,
+						Items: []corev1alpha1.ObjectTemplateSourceItem{
+							{
+								Key: ".data.something", Destination: ".banana",
+								Default: &apiextensionsv1.JSON{Raw: []byte(`"yellow"`)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	sourcesConfig := map[string]interface{}{}
+	res, err := r.getValuesFromSources(context.Background(), objectTemplate, sourcesConfig)
+	require.NoError(t, err)
+	assert.True(t, res.retryLater)
+	assert.True(t, res.prune)
+	assert.Equal(t, map[string]interface{}{"banana": "yellow"}, sourcesConfig)
+}
+
+func Test_templateReconciler_pruneTemplatedObject(t *testing.T) {
+	client := testutil.NewClient()
+	dynamicCache := &dynamiccachemocks.DynamicCacheMock{}
+
+	objectTemplate := &GenericObjectTemplate{
+		ObjectTemplate: corev1alpha1.ObjectTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test", Namespace: "test", UID: "1234",
+			},
+		},
+	}
+	isController := true
+	dynamicCache.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			obj := args.Get(2).(*unstructured.Unstructured)
+			obj.SetOwnerReferences([]metav1.OwnerReference{
+				{UID: "1234", Controller: &isController},
+			})
+		}).
+		Return(nil)
+	client.
+		On("Delete", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	r := &templateReconciler{
+		client:       client,
+		dynamicCache: dynamicCache,
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName("templated")
+	err := r.pruneTemplatedObject(context.Background(), objectTemplate, obj)
+	require.NoError(t, err)
+	client.AssertCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_templateReconciler_teardownPreviousObject(t *testing.T) {
+	newObjectTemplate := func(prev *corev1alpha1.ObjectTemplateObjectReference) *GenericClusterObjectTemplate {
+		objectTemplate := &GenericClusterObjectTemplate{}
+		objectTemplate.Name = "test"
+		objectTemplate.UID = "1234"
+		objectTemplate.Status.TemplatedObject = prev
+		return objectTemplate
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName("templated")
+	obj.SetNamespace("new-ns")
+
+	t.Run("same object", func(t *testing.T) {
+		r := &templateReconciler{}
+		objectTemplate := newObjectTemplate(&corev1alpha1.ObjectTemplateObjectReference{
+			APIVersion: "v1", Kind: "ConfigMap", Name: "templated", Namespace: "new-ns",
+		})
+
+		done, err := r.teardownPreviousObject(context.Background(), objectTemplate, obj)
+		require.NoError(t, err)
+		assert.True(t, done)
+	})
+
+	t.Run("previous object gone", func(t *testing.T) {
+		uncachedClient := testutil.NewClient()
+		uncachedClient.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.NewNotFound(schema.GroupResource{}, ""))
+		r := &templateReconciler{uncachedClient: uncachedClient}
+		objectTemplate := newObjectTemplate(&corev1alpha1.ObjectTemplateObjectReference{
+			APIVersion: "v1", Kind: "ConfigMap", Name: "templated", Namespace: "old-ns",
+		})
+		meta.SetStatusCondition(objectTemplate.GetConditions(), metav1.Condition{
+			Type:   corev1alpha1.ObjectTemplateTeardownPending,
+			Status: metav1.ConditionTrue,
+		})
+
+		done, err := r.teardownPreviousObject(context.Background(), objectTemplate, obj)
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Nil(t, meta.FindStatusCondition(
+			*objectTemplate.GetConditions(), corev1alpha1.ObjectTemplateTeardownPending))
+	})
+
+	t.Run("deletes previous object", func(t *testing.T) {
+		c := testutil.NewClient()
+		uncachedClient := testutil.NewClient()
+		isController := true
+		uncachedClient.
+			On("Get", mock.Anything, client.ObjectKey{Name: "templated", Namespace: "old-ns"},
+				mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				obj := args.Get(2).(*unstructured.Unstructured)
+				obj.SetName("templated")
+				obj.SetNamespace("old-ns")
+				obj.SetOwnerReferences([]metav1.OwnerReference{
+					{UID: "1234", Controller: &isController},
+				})
+			}).
+			Return(nil)
+		c.
+			On("Delete", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		r := &templateReconciler{client: c, uncachedClient: uncachedClient}
+		objectTemplate := newObjectTemplate(&corev1alpha1.ObjectTemplateObjectReference{
+			APIVersion: "v1", Kind: "ConfigMap", Name: "templated", Namespace: "old-ns",
+		})
+
+		done, err := r.teardownPreviousObject(context.Background(), objectTemplate, obj)
+		require.NoError(t, err)
+		assert.False(t, done)
+		c.AssertCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+		assert.True(t, meta.IsStatusConditionTrue(
+			*objectTemplate.GetConditions(), corev1alpha1.ObjectTemplateTeardownPending))
+	})
+
+	t.Run("previous object not controlled", func(t *testing.T) {
+		c := testutil.NewClient()
+		uncachedClient := testutil.NewClient()
+		uncachedClient.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		r := &templateReconciler{client: c, uncachedClient: uncachedClient}
+		objectTemplate := newObjectTemplate(&corev1alpha1.ObjectTemplateObjectReference{
+			APIVersion: "v1", Kind: "ConfigMap", Name: "templated", Namespace: "old-ns",
+		})
+
+		done, err := r.teardownPreviousObject(context.Background(), objectTemplate, obj)
+		require.NoError(t, err)
+		assert.True(t, done)
+		c.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 func Test_templateReconciler_templateObject(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -260,6 +636,99 @@ func Test_templateReconciler_templateObject(t *testing.T) {
 	}
 }
 
+func Test_templateReconciler_templateObject_environment(t *testing.T) {
+	r := &templateReconciler{
+		preflightChecker: preflight.List{},
+	}
+	r.SetEnvironment(&manifestsv1alpha1.PackageEnvironment{
+		Kubernetes: manifestsv1alpha1.PackageEnvironmentKubernetes{Version: "v1.27.3"},
+		OpenShift:  &manifestsv1alpha1.PackageEnvironmentOpenShift{Version: "4.13.2"},
+		Proxy:      &manifestsv1alpha1.PackageEnvironmentProxy{HTTPProxy: "http://proxy:3128"},
+		HyperShift: &manifestsv1alpha1.PackageEnvironmentHyperShift{ManagementCluster: true},
+	})
+
+	template, err := os.ReadFile(filepath.Join("testdata", "package_template_environment.yaml"))
+	require.NoError(t, err)
+
+	objectTemplate := GenericObjectTemplate{
+		ObjectTemplate: corev1alpha1.ObjectTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: corev1alpha1.ObjectTemplateSpec{
+				Template: string(template),
+			},
+		},
+	}
+
+	pkg := &corev1alpha1.Package{}
+	err = r.templateObject(context.Background(), map[string]interface{}{}, &objectTemplate, pkg)
+	require.NoError(t, err)
+
+	config := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal(pkg.Spec.Config.Raw, &config))
+	assert.Equal(t, map[string]interface{}{
+		"kubernetesVersion": "v1.27.3",
+		"platform":          "OpenShift",
+		"httpProxy":         "http://proxy:3128",
+		"hyperShift":        true,
+	}, config)
+}
+
+func Test_templateReconciler_templateObject_lookup(t *testing.T) {
+	dynamicCache := &dynamiccachemocks.DynamicCacheMock{}
+	uncachedClient := testutil.NewClient()
+	dynamicCache.
+		On("Watch", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	dynamicCache.
+		On("Get", mock.Anything, client.ObjectKey{Name: "gp3"}, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			obj := args.Get(2).(*unstructured.Unstructured)
+			obj.SetAnnotations(map[string]string{"provisioner": "ebs.csi.aws.com"})
+		}).
+		Return(nil)
+	dynamicCache.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+	uncachedClient.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+	r := &templateReconciler{
+		uncachedClient:   uncachedClient,
+		dynamicCache:     dynamicCache,
+		preflightChecker: preflight.List{},
+	}
+
+	objectTemplate := GenericClusterObjectTemplate{
+		ClusterObjectTemplate: corev1alpha1.ClusterObjectTemplate{
+			Spec: corev1alpha1.ObjectTemplateSpec{
+				Template: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+  namespace: default
+data:
+  gp3: {{ (lookup "storage.k8s.io/v1" "StorageClass" "" "gp3").metadata.annotations.provisioner }}
+  gp2: {{ if lookup "storage.k8s.io/v1" "StorageClass" "" "gp2" }}found{{ else }}missing{{ end }}
+`,
+			},
+		},
+	}
+
+	obj := &unstructured.Unstructured{}
+	err := r.templateObject(context.Background(), map[string]interface{}{}, &objectTemplate, obj)
+	require.NoError(t, err)
+
+	data, _, err := unstructured.NestedStringMap(obj.Object, "data")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"gp3": "ebs.csi.aws.com",
+		"gp2": "missing",
+	}, data)
+}
+
 func Test_updateStatusConditionsFromOwnedObject(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -361,6 +830,89 @@ func Test_updateStatusConditionsFromOwnedObject(t *testing.T) {
 	}
 }
 
+func Test_updateStatusConditionsFromOwnedObject_mappings(t *testing.T) {
+	objectTemplate := &GenericObjectTemplate{
+		ObjectTemplate: corev1alpha1.ObjectTemplate{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec: corev1alpha1.ObjectTemplateSpec{
+				ConditionMappings: []corev1alpha1.ConditionMapping{
+					{SourceType: "Available", DestinationType: "my-prefix/DeploymentAvailable"},
+				},
+			},
+		},
+	}
+	// Deployment conditions don't report an observedGeneration.
+	deploy := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"generation": int64(7),
+			},
+			"status": map[string]interface{}{
+				"observedGeneration": int64(7),
+				"conditions": []interface{}{
+					map[string]interface{}{
+						"type":   "Available",
+						"status": "True",
+						"reason": "MinimumReplicasAvailable",
+					},
+					map[string]interface{}{
+						"type":   "Progressing",
+						"status": "True",
+					},
+				},
+			},
+		},
+	}
+
+	err := updateStatusConditionsFromOwnedObject(context.Background(), objectTemplate, deploy)
+	require.NoError(t, err)
+	conds := *objectTemplate.GetConditions()
+	if assert.Len(t, conds, 1) {
+		assert.Equal(t, "my-prefix/DeploymentAvailable", conds[0].Type)
+		assert.Equal(t, metav1.ConditionTrue, conds[0].Status)
+		assert.Equal(t, "MinimumReplicasAvailable", conds[0].Reason)
+		assert.Equal(t, int64(2), conds[0].ObservedGeneration)
+	}
+}
+
+func Test_templateReconciler_objectCollision(t *testing.T) {
+	r, _, _, dc := newControllerAndMocks(t)
+
+	dc.
+		On("Watch", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	dc.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			obj := args.Get(2).(*unstructured.Unstructured)
+			obj.SetName("test")
+			controller := true
+			obj.SetOwnerReferences([]metav1.OwnerReference{
+				{Name: "other", UID: "other-uid", Controller: &controller},
+			})
+		}).
+		Return(nil)
+
+	objectTemplate := &GenericObjectTemplate{
+		ObjectTemplate: corev1alpha1.ObjectTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test-uid"},
+			Spec: corev1alpha1.ObjectTemplateSpec{
+				Template: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n",
+			},
+		},
+	}
+
+	_, err := r.Reconcile(context.Background(), objectTemplate)
+	require.NoError(t, err)
+	cond := meta.FindStatusCondition(*objectTemplate.GetConditions(), corev1alpha1.ObjectTemplateInvalid)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, corev1alpha1.ReasonObjectCollision, cond.Reason)
+		assert.Equal(t, "refusing adoption, ConfigMap /test is controlled by another object", cond.Message)
+	}
+}
+
 func Test_templateReconcilerReconcile(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -528,3 +1080,89 @@ func Test_setObjectTemplateConditionBasedOnError(t *testing.T) {
 		})
 	}
 }
+
+type secretProvidersFn func(
+	ctx context.Context, sp *corev1alpha1.SecretProvider, path, version string,
+) (map[string]interface{}, error)
+
+func (fn secretProvidersFn) GetSecret(
+	ctx context.Context, sp *corev1alpha1.SecretProvider, path, version string,
+) (map[string]interface{}, error) {
+	return fn(ctx, sp, path, version)
+}
+
+func Test_templateReconciler_getValuesFromSources_externalSources(t *testing.T) {
+	uncachedClient := testutil.NewClient()
+	uncachedClient.
+		On("Get", mock.Anything, client.ObjectKey{Name: "vault", Namespace: "test"},
+			mock.AnythingOfType("*v1alpha1.SecretProvider"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			sp := args.Get(2).(*corev1alpha1.SecretProvider)
+			sp.Spec.Type = corev1alpha1.SecretProviderTypeVault
+			sp.Spec.RefreshInterval = &metav1.Duration{Duration: time.Minute}
+		}).
+		Return(nil)
+
+	r := &templateReconciler{
+		uncachedClient:   uncachedClient,
+		preflightChecker: preflight.List{},
+		secretProviders: secretProvidersFn(func(
+			_ context.Context, sp *corev1alpha1.SecretProvider, path, version string,
+		) (map[string]interface{}, error) {
+			assert.Equal(t, corev1alpha1.SecretProviderTypeVault, sp.Spec.Type)
+			assert.Equal(t, "2", version)
+			if path == "team-a/database" {
+				return map[string]interface{}{"password": "hunter2"}, nil
+			}
+			return nil, externalsecrets.ErrSecretNotFound
+		}),
+	}
+
+	newObjectTemplate := func(path string, optional bool) *GenericObjectTemplate {
+		return &GenericObjectTemplate{
+			ObjectTemplate: corev1alpha1.ObjectTemplate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+				Spec: corev1alpha1.ObjectTemplateSpec{
+					ExternalSources: []corev1alpha1.ObjectTemplateExternalSource{
+						{
+							Provider: "vault", Path: path, Version: "2", Optional: optional,
+							Items: []corev1alpha1.ObjectTemplateSourceItem{
+								{
+									Key: ".password", Destination: ".password",
+									Default: &apiextensionsv1.JSON{Raw: []byte(`"default"`)},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("found", func(t *testing.T) {
+		sourcesConfig := map[string]interface{}{}
+		res, err := r.getValuesFromSources(
+			context.Background(), newObjectTemplate("team-a/database", false), sourcesConfig)
+		require.NoError(t, err)
+		assert.False(t, res.retryLater)
+		assert.Equal(t, time.Minute, res.refreshAfter)
+		assert.Equal(t, map[string]interface{}{"password": "hunter2"}, sourcesConfig)
+	})
+
+	t.Run("missing optional", func(t *testing.T) {
+		sourcesConfig := map[string]interface{}{}
+		res, err := r.getValuesFromSources(
+			context.Background(), newObjectTemplate("team-a/missing", true), sourcesConfig)
+		require.NoError(t, err)
+		assert.True(t, res.retryLater)
+		assert.Equal(t, map[string]interface{}{"password": "default"}, sourcesConfig)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := r.getValuesFromSources(
+			context.Background(), newObjectTemplate("team-a/missing", false), map[string]interface{}{})
+		var sourceErr *SourceError
+		require.ErrorAs(t, err, &sourceErr)
+		assert.EqualError(t, err, "for source SecretProvider test/vault: path team-a/missing: secret not found")
+	})
+}
@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"package-operator.run/package-operator/internal/transform"
 )
@@ -14,10 +17,13 @@ type TemplateContext struct {
 }
 
 type TemplateTransformer struct {
-	tctx map[string]interface{}
+	tctx   map[string]interface{}
+	lookup transform.LookupFunc
 }
 
-func NewTemplateTransformer(tmplCtx TemplateContext) (*TemplateTransformer, error) {
+// NewTemplateTransformer returns a TemplateTransformer,
+// reading objects for the lookup template function via the given LookupFunc.
+func NewTemplateTransformer(tmplCtx TemplateContext, lookup transform.LookupFunc) (*TemplateTransformer, error) {
 	p, err := json.Marshal(tmplCtx)
 	if err != nil {
 		return nil, err
@@ -28,11 +34,11 @@ func NewTemplateTransformer(tmplCtx TemplateContext) (*TemplateTransformer, erro
 		return nil, err
 	}
 
-	return &TemplateTransformer{actualCtx}, nil
+	return &TemplateTransformer{tctx: actualCtx, lookup: lookup}, nil
 }
 
 func (t *TemplateTransformer) transform(_ context.Context, content []byte) ([]byte, error) {
-	template, err := transform.TemplateWithSprigFuncs(string(content))
+	template, err := transform.TemplateWithSprigFuncs(string(content), transform.LookupFuncs(t.lookup))
 	if err != nil {
 		return nil, &TemplateError{Err: err}
 	}
@@ -43,3 +49,31 @@ func (t *TemplateTransformer) transform(_ context.Context, content []byte) ([]by
 	}
 	return doc.Bytes(), nil
 }
+
+// Renders a source name or namespace field.
+// Only metadata of the ObjectTemplate itself is available in the template context:
+// {{.metadata.name}}, {{.metadata.namespace}}, {{.metadata.labels}} and {{.metadata.annotations}}.
+func renderSourceField(objectTemplate client.Object, field string) (string, error) {
+	if !strings.Contains(field, "{{") {
+		return field, nil
+	}
+
+	template, err := transform.TemplateWithSprigFuncs(field)
+	if err != nil {
+		return "", &TemplateError{Err: err}
+	}
+
+	tctx := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":        objectTemplate.GetName(),
+			"namespace":   objectTemplate.GetNamespace(),
+			"labels":      objectTemplate.GetLabels(),
+			"annotations": objectTemplate.GetAnnotations(),
+		},
+	}
+	var doc bytes.Buffer
+	if err := template.Execute(&doc, tctx); err != nil {
+		return "", &TemplateError{Err: err}
+	}
+	return strings.TrimSpace(doc.String()), nil
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// NewObserveOnlyClient wraps the given client for observe-only mode.
+// Creates, updates, patches and deletes of objects outside of the Package Operator API group
+// are sent as server-side dry-run, so they pass validation and admission, but are never persisted.
+// Package Operator's own objects and status are still written,
+// so desired state is rendered down to ObjectSets, which report the changes they would apply.
+// Objects matched by one of the given exemptions are written too.
+func NewObserveOnlyClient(c client.Client, exemptions ...func(obj client.Object) bool) client.Client {
+	return &observeOnlyClient{Client: c, exemptions: exemptions}
+}
+
+type observeOnlyClient struct {
+	client.Client
+	exemptions []func(obj client.Object) bool
+}
+
+func (c *observeOnlyClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if !c.isWritable(obj) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *observeOnlyClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if !c.isWritable(obj) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *observeOnlyClient) Patch(
+	ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption,
+) error {
+	if !c.isWritable(obj) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *observeOnlyClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if !c.isWritable(obj) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *observeOnlyClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if !c.isWritable(obj) {
+		opts = append(opts, client.DryRunAll)
+	}
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *observeOnlyClient) isWritable(obj client.Object) bool {
+	for _, exempt := range c.exemptions {
+		if exempt(obj) {
+			return true
+		}
+	}
+	return c.isPackageOperatorObject(obj)
+}
+
+// Objects of unknown kind are treated as foreign, so they are never written.
+func (c *observeOnlyClient) isPackageOperatorObject(obj client.Object) bool {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return false
+	}
+	return gvk.Group == corev1alpha1.GroupVersion.Group
+}
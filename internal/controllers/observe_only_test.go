@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestObserveOnlyClient(t *testing.T) {
+	t.Parallel()
+
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "test"},
+		Data:       map[string]string{"a": "1"},
+	}
+	scheme := testutil.NewTestSchemeWithCoreV1Alpha1()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	c := NewObserveOnlyClient(fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(existing).
+		Build())
+	ctx := context.Background()
+
+	// Objects outside of the Package Operator API are not persisted.
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "test"}}
+	require.NoError(t, c.Create(ctx, cm))
+	err := c.Get(ctx, client.ObjectKeyFromObject(cm), &corev1.ConfigMap{})
+	assert.True(t, errors.IsNotFound(err), "ConfigMap must not be created")
+
+	patched := existing.DeepCopy()
+	patched.Data = map[string]string{"a": "2"}
+	require.NoError(t, c.Patch(ctx, patched, client.MergeFrom(existing)))
+	require.NoError(t, c.Delete(ctx, existing))
+	current := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(existing), current))
+	assert.Equal(t, map[string]string{"a": "1"}, current.Data)
+
+	// Package Operator's own objects are written.
+	objectSet := &corev1alpha1.ObjectSet{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+	require.NoError(t, c.Create(ctx, objectSet))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(objectSet), &corev1alpha1.ObjectSet{}))
+}
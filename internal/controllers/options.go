@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
 )
 
 type WithInitialBackoff time.Duration
@@ -19,3 +21,49 @@ func (w WithMaxBackoff) ConfigureBackoff(c *BackoffConfig) {
 
 	c.MaxBackoff = &val
 }
+
+// WithAdoptionChecker replaces the default AdoptionChecker of a PhaseReconciler.
+type WithAdoptionChecker struct{ AdoptionChecker }
+
+func (w WithAdoptionChecker) ConfigurePhaseReconciler(c *PhaseReconcilerConfig) {
+	c.AdoptionChecker = w.AdoptionChecker
+}
+
+// WithAdoptionPolicy makes the default AdoptionChecker of a PhaseReconciler
+// honor the adoption policy of the PackageOperatorConfig.
+type WithAdoptionPolicy struct{ *AdoptionPolicyChecker }
+
+func (w WithAdoptionPolicy) ConfigurePhaseReconciler(c *PhaseReconcilerConfig) {
+	c.AdoptionPolicy = w.AdoptionPolicyChecker
+}
+
+// WithPatcher replaces the default Patcher of a PhaseReconciler.
+type WithPatcher struct{ Patcher }
+
+func (w WithPatcher) ConfigurePhaseReconciler(c *PhaseReconcilerConfig) {
+	c.Patcher = w.Patcher
+}
+
+// WithRESTMapper sets the RESTMapper a PhaseReconciler waits on for CRDs to become discoverable.
+type WithRESTMapper struct{ meta.RESTMapper }
+
+func (w WithRESTMapper) ConfigurePhaseReconciler(c *PhaseReconcilerConfig) {
+	c.RESTMapper = w.RESTMapper
+}
+
+// WithObjectMutators adds ObjectMutators to a PhaseReconciler,
+// running after all previously configured mutators.
+type WithObjectMutators []ObjectMutator
+
+func (w WithObjectMutators) ConfigurePhaseReconciler(c *PhaseReconcilerConfig) {
+	c.ObjectMutators = append(c.ObjectMutators, w...)
+}
+
+// WithOperatorIdentity makes a PhaseReconciler label, annotate and apply objects
+// as the given Package Operator installation.
+// ObjectSet and ObjectSetPhase controllers also only reconcile objects labeled with the identity.
+type WithOperatorIdentity struct{ OperatorIdentity }
+
+func (w WithOperatorIdentity) ConfigurePhaseReconciler(c *PhaseReconcilerConfig) {
+	c.OperatorIdentity = w.OperatorIdentity
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// OrphanModeChecker determines whether Package Operator
+// must orphan all objects on teardown instead of deleting them.
+// A nil *OrphanModeChecker never reports orphan mode.
+type OrphanModeChecker struct {
+	client client.Reader
+}
+
+func NewOrphanModeChecker(client client.Reader) *OrphanModeChecker {
+	return &OrphanModeChecker{client: client}
+}
+
+// IsOrphaning returns true when the PackageOperatorConfig
+// overrides the deletion policy of all objects with "Orphan".
+func (c *OrphanModeChecker) IsOrphaning(ctx context.Context) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	config, err := GetPackageOperatorConfig(ctx, c.client)
+	if err != nil {
+		return false, err
+	}
+	return config.Spec.DeletionPolicy == corev1alpha1.ObjectSetObjectDeletionPolicyOrphan, nil
+}
+
+// OrphanPhase returns a copy of the given phase with the deletion policy of all objects set to "Orphan".
+func OrphanPhase(phase corev1alpha1.ObjectSetTemplatePhase) corev1alpha1.ObjectSetTemplatePhase {
+	phase = *phase.DeepCopy()
+	for i := range phase.Objects {
+		phase.Objects[i].DeletionPolicy = corev1alpha1.ObjectSetObjectDeletionPolicyOrphan
+	}
+	return phase
+}
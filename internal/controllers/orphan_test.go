@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestOrphanModeChecker_IsOrphaning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		deletionPolicy corev1alpha1.ObjectSetObjectDeletionPolicy
+		expected       bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:           "delete",
+			deletionPolicy: corev1alpha1.ObjectSetObjectDeletionPolicyDelete,
+		},
+		{
+			name:           "orphan",
+			deletionPolicy: corev1alpha1.ObjectSetObjectDeletionPolicyOrphan,
+			expected:       true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			c := testutil.NewClient()
+			c.
+				On("Get", mock.Anything, client.ObjectKey{Name: "cluster"},
+					mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					config := args.Get(2).(*corev1alpha1.PackageOperatorConfig)
+					config.Spec.DeletionPolicy = test.deletionPolicy
+				}).
+				Return(nil)
+
+			orphaning, err := NewOrphanModeChecker(c).IsOrphaning(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, orphaning)
+		})
+	}
+}
+
+func TestOrphanModeChecker_IsOrphaning_noConfig(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, mock.Anything,
+			mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, "cluster"))
+
+	orphaning, err := NewOrphanModeChecker(c).IsOrphaning(context.Background())
+	require.NoError(t, err)
+	assert.False(t, orphaning)
+
+	var nilChecker *OrphanModeChecker
+	orphaning, err = nilChecker.IsOrphaning(context.Background())
+	require.NoError(t, err)
+	assert.False(t, orphaning)
+}
+
+func TestOrphanPhase(t *testing.T) {
+	t.Parallel()
+
+	phase := corev1alpha1.ObjectSetTemplatePhase{
+		Name: "test",
+		Objects: []corev1alpha1.ObjectSetObject{
+			{},
+			{DeletionPolicy: corev1alpha1.ObjectSetObjectDeletionPolicyDelete},
+		},
+	}
+
+	orphanPhase := OrphanPhase(phase)
+	for _, obj := range orphanPhase.Objects {
+		assert.Equal(t, corev1alpha1.ObjectSetObjectDeletionPolicyOrphan, obj.DeletionPolicy)
+	}
+	// Input is not modified.
+	assert.Empty(t, phase.Objects[0].DeletionPolicy)
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Matches references to the metadata of the owner within string values of phase objects, e.g.:
+// ${metadata.name}, ${metadata.namespace}, ${metadata.labels['tenant']} or ${metadata.annotations["team"]}.
+var ownerMetadataRefRegexp = regexp.MustCompile(
+	`\$\{metadata\.(name|namespace|(labels|annotations)\[(?:'([^']*)'|"([^"]*)")\])\}`)
+
+// OwnerMetadataReferenceError is returned, when a phase object references
+// a label or annotation that is not set on the owner.
+type OwnerMetadataReferenceError struct {
+	Reference string
+}
+
+func (e OwnerMetadataReferenceError) Error() string {
+	return fmt.Sprintf("%s: not set on owner", e.Reference)
+}
+
+// ErrorClass implements ClassifiedError.
+func (e OwnerMetadataReferenceError) ErrorClass() ErrorClass {
+	return ErrorClassUserFixRequired
+}
+
+// SubstituteOwnerMetadata returns the object with all ${metadata...} references
+// in string values resolved against the metadata of the owner.
+// ObjectSets inherit the labels of their ObjectDeployment template,
+// so a single static package can stamp per-tenant names.
+// The given object is not modified and returned as is, if it contains no references.
+func SubstituteOwnerMetadata(
+	owner metav1.Object, obj *unstructured.Unstructured,
+) (*unstructured.Unstructured, error) {
+	substituted, changed, err := substituteOwnerMetadata(owner, obj.Object)
+	if err != nil || !changed {
+		return obj, err
+	}
+	return &unstructured.Unstructured{
+		Object: substituted.(map[string]interface{}),
+	}, nil
+}
+
+// Copy-on-write walk over the object,
+// maps and slices are only copied when they contain substituted values.
+func substituteOwnerMetadata(owner metav1.Object, v interface{}) (interface{}, bool, error) {
+	switch v := v.(type) {
+	case string:
+		return substituteOwnerMetadataString(owner, v)
+
+	case map[string]interface{}:
+		var out map[string]interface{}
+		for key, value := range v {
+			newValue, changed, err := substituteOwnerMetadata(owner, value)
+			if err != nil {
+				return nil, false, err
+			}
+			if !changed {
+				continue
+			}
+			if out == nil {
+				out = make(map[string]interface{}, len(v))
+				for k, val := range v {
+					out[k] = val
+				}
+			}
+			out[key] = newValue
+		}
+		if out == nil {
+			return v, false, nil
+		}
+		return out, true, nil
+
+	case []interface{}:
+		var out []interface{}
+		for i, value := range v {
+			newValue, changed, err := substituteOwnerMetadata(owner, value)
+			if err != nil {
+				return nil, false, err
+			}
+			if !changed {
+				continue
+			}
+			if out == nil {
+				out = make([]interface{}, len(v))
+				copy(out, v)
+			}
+			out[i] = newValue
+		}
+		if out == nil {
+			return v, false, nil
+		}
+		return out, true, nil
+	}
+	return v, false, nil
+}
+
+func substituteOwnerMetadataString(owner metav1.Object, s string) (string, bool, error) {
+	if !strings.Contains(s, "${metadata.") {
+		return s, false, nil
+	}
+
+	var err error
+	out := ownerMetadataRefRegexp.ReplaceAllStringFunc(s, func(ref string) string {
+		m := ownerMetadataRefRegexp.FindStringSubmatch(ref)
+		key := m[3] + m[4]
+
+		var (
+			value string
+			ok    bool
+		)
+		switch {
+		case m[1] == "name":
+			value, ok = owner.GetName(), true
+		case m[1] == "namespace":
+			value, ok = owner.GetNamespace(), true
+		case m[2] == "labels":
+			value, ok = owner.GetLabels()[key]
+		case m[2] == "annotations":
+			value, ok = owner.GetAnnotations()[key]
+		}
+		if !ok && err == nil {
+			err = OwnerMetadataReferenceError{Reference: ref}
+		}
+		return value
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return out, out != s, nil
+}
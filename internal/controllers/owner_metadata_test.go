@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSubstituteOwnerMetadata(t *testing.T) {
+	t.Parallel()
+
+	owner := &metav1.ObjectMeta{
+		Name:        "test-1234",
+		Namespace:   "test",
+		Labels:      map[string]string{"tenant": "acme"},
+		Annotations: map[string]string{"team": "blue"},
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "db-${metadata.labels['tenant']}",
+		},
+		"data": map[string]interface{}{
+			"owner":  "${metadata.namespace}/${metadata.name}",
+			"team":   `${metadata.annotations["team"]}`,
+			"script": "echo ${HOME}",
+		},
+		"list": []interface{}{"${metadata.labels['tenant']}", int64(1)},
+	}}
+
+	substituted, err := SubstituteOwnerMetadata(owner, obj)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "db-acme",
+		},
+		"data": map[string]interface{}{
+			"owner":  "test/test-1234",
+			"team":   "blue",
+			"script": "echo ${HOME}",
+		},
+		"list": []interface{}{"acme", int64(1)},
+	}, substituted.Object)
+
+	// Original object is not modified.
+	assert.Equal(t, "db-${metadata.labels['tenant']}", obj.GetName())
+}
+
+func TestSubstituteOwnerMetadata_noReferences(t *testing.T) {
+	t.Parallel()
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "test"},
+	}}
+	substituted, err := SubstituteOwnerMetadata(&metav1.ObjectMeta{}, obj)
+	require.NoError(t, err)
+	assert.Same(t, obj, substituted)
+}
+
+func TestSubstituteOwnerMetadata_missingLabel(t *testing.T) {
+	t.Parallel()
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "db-${metadata.labels['tenant']}"},
+	}}
+	_, err := SubstituteOwnerMetadata(&metav1.ObjectMeta{}, obj)
+	require.EqualError(t, err, "${metadata.labels['tenant']}: not set on owner")
+	assert.Equal(t, ErrorClassUserFixRequired, ClassifyError(err))
+}
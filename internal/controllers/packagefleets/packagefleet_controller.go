@@ -0,0 +1,342 @@
+package packagefleets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/tracing"
+)
+
+// Maximum number of failed namespaces listed in the Available condition.
+const maxReportedFailures = 5
+
+// PackageFleetController creates a Package in every namespace selected by a PackageFleet
+// and aggregates the status of these Packages.
+type PackageFleetController struct {
+	client      client.Client
+	log         logr.Logger
+	scheme      *runtime.Scheme
+	rateLimiter ratelimiter.RateLimiter
+	// Only PackageFleets labeled with this identity are reconciled.
+	identity controllers.OperatorIdentity
+}
+
+func NewPackageFleetController(
+	c client.Client, log logr.Logger, scheme *runtime.Scheme,
+) *PackageFleetController {
+	return &PackageFleetController{
+		client: c,
+		log:    log,
+		scheme: scheme,
+	}
+}
+
+// SetRateLimiter sets the workqueue rate limiter used when the controller is set up.
+func (c *PackageFleetController) SetRateLimiter(rl ratelimiter.RateLimiter) {
+	c.rateLimiter = rl
+}
+
+// SetOperatorIdentity limits reconciliation to PackageFleets labeled with the given identity.
+func (c *PackageFleetController) SetOperatorIdentity(identity controllers.OperatorIdentity) {
+	c.identity = identity
+}
+
+func (c *PackageFleetController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{RateLimiter: c.rateLimiter}).
+		For(&corev1alpha1.PackageFleet{}, builder.WithPredicates(c.identity.Predicate())).
+		Owns(&corev1alpha1.Package{}).
+		Watches(&source.Kind{Type: &corev1.Namespace{}},
+			handler.EnqueueRequestsFromMapFunc(c.fleetsForNamespace)).
+		Complete(c)
+}
+
+// Enqueues all PackageFleets when a namespace changes,
+// because namespaces may start or stop matching the selector of any fleet.
+func (c *PackageFleetController) fleetsForNamespace(_ client.Object) []reconcile.Request {
+	fleetList := &corev1alpha1.PackageFleetList{}
+	if err := c.client.List(context.Background(), fleetList); err != nil {
+		c.log.Error(err, "listing PackageFleets")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(fleetList.Items))
+	for i := range fleetList.Items {
+		if !c.identity.Owns(&fleetList.Items[i]) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&fleetList.Items[i]),
+		})
+	}
+	return requests
+}
+
+func (c *PackageFleetController) Reconcile(
+	ctx context.Context, req ctrl.Request,
+) (res ctrl.Result, err error) {
+	log := c.log.WithValues("PackageFleet", req.String())
+	defer log.Info("reconciled")
+	ctx = logr.NewContext(ctx, log)
+
+	ctx, span := tracing.Start(ctx, "Reconcile PackageFleet", tracing.RequestAttributes(req)...)
+	defer func() { tracing.End(span, err) }()
+
+	fleet := &corev1alpha1.PackageFleet{}
+	if err := c.client.Get(ctx, req.NamespacedName, fleet); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+	if !c.identity.Owns(fleet) {
+		// Reconciled by another Package Operator installation.
+		return res, nil
+	}
+	if !fleet.DeletionTimestamp.IsZero() {
+		// Packages are garbage collected via owner references.
+		return res, nil
+	}
+
+	targets, err := c.targetNamespaces(ctx, fleet)
+	if err != nil {
+		return res, err
+	}
+	states, err := c.reconcilePackages(ctx, fleet, targets)
+	if err != nil {
+		return res, err
+	}
+
+	setStatus(fleet, states)
+	if err := c.client.Status().Update(ctx, fleet); err != nil {
+		return res, fmt.Errorf("updating PackageFleet status: %w", err)
+	}
+	return res, nil
+}
+
+// Returns the names of all namespaces selected by the fleet, that are not terminating.
+func (c *PackageFleetController) targetNamespaces(
+	ctx context.Context, fleet *corev1alpha1.PackageFleet,
+) (map[string]struct{}, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&fleet.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("parsing namespace selector: %w", err)
+	}
+	namespaceList := &corev1.NamespaceList{}
+	if err := c.client.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("listing Namespaces: %w", err)
+	}
+
+	targets := map[string]struct{}{}
+	for _, ns := range namespaceList.Items {
+		if ns.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		targets[ns.Name] = struct{}{}
+	}
+	return targets, nil
+}
+
+// Rollout state of the Package in a target namespace.
+type targetState struct {
+	namespace string
+	health    corev1alpha1.HealthStatus
+	message   string
+}
+
+// Creates, updates and deletes the Packages of the fleet,
+// so there is one Package in every target namespace.
+// Returns the rollout state of the Package in every target namespace.
+func (c *PackageFleetController) reconcilePackages(
+	ctx context.Context, fleet *corev1alpha1.PackageFleet, targets map[string]struct{},
+) ([]targetState, error) {
+	existingList := &corev1alpha1.PackageList{}
+	if err := c.client.List(ctx, existingList, client.MatchingLabels{
+		corev1alpha1.PackageFleetLabel: fleet.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("listing Packages: %w", err)
+	}
+
+	existing := map[string]*corev1alpha1.Package{}
+	for i := range existingList.Items {
+		pkg := &existingList.Items[i]
+		if _, ok := targets[pkg.Namespace]; !ok {
+			if err := c.client.Delete(ctx, pkg); client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("deleting Package: %w", err)
+			}
+			continue
+		}
+		existing[pkg.Namespace] = pkg
+	}
+
+	states := make([]targetState, 0, len(targets))
+	for namespace := range targets {
+		desired, err := c.desiredPackage(fleet, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		pkg, ok := existing[namespace]
+		if !ok {
+			err := c.client.Create(ctx, desired)
+			switch {
+			case errors.IsAlreadyExists(err):
+				states = append(states, targetState{
+					namespace: namespace,
+					health:    corev1alpha1.HealthStatusDegraded,
+					message:   "Package already exists and is not part of this fleet.",
+				})
+			case err != nil:
+				return nil, fmt.Errorf("creating Package: %w", err)
+			default:
+				states = append(states, targetState{
+					namespace: namespace,
+					health:    corev1alpha1.HealthStatusProgressing,
+				})
+			}
+			continue
+		}
+
+		if !packageUpToDate(pkg, desired) {
+			pkg.Labels = desired.Labels
+			pkg.Annotations = desired.Annotations
+			pkg.Spec = desired.Spec
+			if err := c.client.Update(ctx, pkg); err != nil {
+				return nil, fmt.Errorf("updating Package: %w", err)
+			}
+			states = append(states, targetState{
+				namespace: namespace,
+				health:    corev1alpha1.HealthStatusProgressing,
+			})
+			continue
+		}
+		states = append(states, packageState(pkg))
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].namespace < states[j].namespace
+	})
+	return states, nil
+}
+
+func (c *PackageFleetController) desiredPackage(
+	fleet *corev1alpha1.PackageFleet, namespace string,
+) (*corev1alpha1.Package, error) {
+	tmpl := fleet.Spec.Template.DeepCopy()
+	labels := tmpl.Metadata.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[corev1alpha1.PackageFleetLabel] = fleet.Name
+	if identity, ok := fleet.Labels[controllers.OperatorIdentityLabel]; ok {
+		// Reconciled by the same Package Operator installation as the fleet.
+		labels[controllers.OperatorIdentityLabel] = identity
+	}
+
+	pkg := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fleet.Name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: tmpl.Metadata.Annotations,
+		},
+		Spec: tmpl.Spec,
+	}
+	if err := controllerutil.SetControllerReference(fleet, pkg, c.scheme); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+func packageUpToDate(pkg, desired *corev1alpha1.Package) bool {
+	return equality.Semantic.DeepEqual(pkg.Labels, desired.Labels) &&
+		equality.Semantic.DeepEqual(pkg.Annotations, desired.Annotations) &&
+		equality.Semantic.DeepEqual(pkg.Spec, desired.Spec)
+}
+
+// Packages that did not yet report on their latest spec are still progressing.
+func packageState(pkg *corev1alpha1.Package) targetState {
+	state := targetState{
+		namespace: pkg.Namespace,
+		health:    corev1alpha1.HealthStatusProgressing,
+	}
+	availableCond := meta.FindStatusCondition(pkg.Status.Conditions, corev1alpha1.PackageAvailable)
+	if pkg.Status.Health == nil || availableCond == nil ||
+		availableCond.ObservedGeneration != pkg.Generation {
+		return state
+	}
+	state.health = pkg.Status.Health.Status
+	state.message = pkg.Status.Health.Message
+	return state
+}
+
+func setStatus(fleet *corev1alpha1.PackageFleet, states []targetState) {
+	var ready, progressing, failed int32
+	var failures []string
+	for _, s := range states {
+		switch s.health {
+		case corev1alpha1.HealthStatusHealthy:
+			ready++
+		case corev1alpha1.HealthStatusDegraded:
+			failed++
+			if len(failures) < maxReportedFailures {
+				failures = append(failures, fmt.Sprintf("%s: %s", s.namespace, s.message))
+			}
+		default:
+			progressing++
+		}
+	}
+	fleet.Status.TargetCount = int32(len(states))
+	fleet.Status.ReadyCount = ready
+	fleet.Status.ProgressingCount = progressing
+	fleet.Status.FailedCount = failed
+
+	availableCond := metav1.Condition{
+		Type:               corev1alpha1.PackageFleetAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             corev1alpha1.ReasonAvailable,
+		Message:            "Packages in all target namespaces are available.",
+		ObservedGeneration: fleet.Generation,
+	}
+	if ready < fleet.Status.TargetCount {
+		availableCond.Status = metav1.ConditionFalse
+		availableCond.Reason = corev1alpha1.ReasonPackagesUnavailable
+		availableCond.Message = fmt.Sprintf(
+			"%d of %d Packages are available.", ready, fleet.Status.TargetCount)
+		if len(failures) > 0 {
+			availableCond.Message += " Failed: " + strings.Join(failures, ", ")
+		}
+	}
+	meta.SetStatusCondition(&fleet.Status.Conditions, availableCond)
+
+	progressingCond := metav1.Condition{
+		Type:               corev1alpha1.PackageFleetProgressing,
+		Status:             metav1.ConditionFalse,
+		Reason:             corev1alpha1.ReasonIdle,
+		Message:            "No Packages are rolling out.",
+		ObservedGeneration: fleet.Generation,
+	}
+	if progressing > 0 {
+		progressingCond.Status = metav1.ConditionTrue
+		progressingCond.Reason = corev1alpha1.ReasonProgressing
+		progressingCond.Message = fmt.Sprintf("%d Packages are rolling out.", progressing)
+	}
+	meta.SetStatusCondition(&fleet.Status.Conditions, progressingCond)
+}
@@ -0,0 +1,183 @@
+package packagefleets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestPackageFleetController_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, corev1alpha1.AddToScheme(scheme))
+
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	tenant := map[string]string{"tenant": "true"}
+	terminating := namespace("terminating", tenant)
+	terminating.Status.Phase = corev1.NamespaceTerminating
+
+	fleet := &corev1alpha1.PackageFleet{
+		ObjectMeta: metav1.ObjectMeta{Name: "monitoring", Generation: 1},
+		Spec: corev1alpha1.PackageFleetSpec{
+			NamespaceSelector: metav1.LabelSelector{MatchLabels: tenant},
+			Template: corev1alpha1.PackageTemplate{
+				Metadata: metav1.ObjectMeta{Labels: map[string]string{"team": "observability"}},
+				Spec:     corev1alpha1.PackageSpec{Image: "quay.io/example/monitoring:v1"},
+			},
+		},
+	}
+	fleetLabels := map[string]string{
+		"team":                         "observability",
+		corev1alpha1.PackageFleetLabel: "monitoring",
+	}
+	readyPkg := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "monitoring", Namespace: "ready", Generation: 1, Labels: fleetLabels,
+		},
+		Spec: fleet.Spec.Template.Spec,
+		Status: corev1alpha1.PackageStatus{
+			Conditions: []metav1.Condition{{
+				Type:               corev1alpha1.PackageAvailable,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 1,
+			}},
+			Health: &corev1alpha1.Health{Status: corev1alpha1.HealthStatusHealthy},
+		},
+	}
+	stalePkg := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "monitoring", Namespace: "unselected", Labels: fleetLabels,
+		},
+	}
+	foreignPkg := &corev1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "monitoring", Namespace: "taken"},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			namespace("new", tenant), namespace("ready", tenant), namespace("taken", tenant),
+			namespace("unselected", nil), terminating,
+			fleet, readyPkg, stalePkg, foreignPkg,
+		).
+		Build()
+	controller := NewPackageFleetController(c, testr.New(t), scheme)
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(fleet)}
+	_, err := controller.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	// Package created in new target namespace.
+	created := &corev1alpha1.Package{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "monitoring", Namespace: "new"}, created))
+	assert.Equal(t, fleetLabels, created.Labels)
+	assert.Equal(t, "quay.io/example/monitoring:v1", created.Spec.Image)
+	require.Len(t, created.OwnerReferences, 1)
+	assert.Equal(t, "PackageFleet", created.OwnerReferences[0].Kind)
+
+	// Package removed from namespace no longer selected.
+	err = c.Get(ctx, client.ObjectKeyFromObject(stalePkg), &corev1alpha1.Package{})
+	assert.True(t, errors.IsNotFound(err))
+
+	// No Package in terminating namespace.
+	err = c.Get(ctx, client.ObjectKey{Name: "monitoring", Namespace: "terminating"}, &corev1alpha1.Package{})
+	assert.True(t, errors.IsNotFound(err))
+
+	require.NoError(t, c.Get(ctx, req.NamespacedName, fleet))
+	assert.Equal(t, int32(3), fleet.Status.TargetCount)
+	assert.Equal(t, int32(1), fleet.Status.ReadyCount)
+	assert.Equal(t, int32(1), fleet.Status.ProgressingCount)
+	assert.Equal(t, int32(1), fleet.Status.FailedCount)
+
+	availableCond := meta.FindStatusCondition(fleet.Status.Conditions, corev1alpha1.PackageFleetAvailable)
+	require.NotNil(t, availableCond)
+	assert.Equal(t, metav1.ConditionFalse, availableCond.Status)
+	assert.Equal(t, corev1alpha1.ReasonPackagesUnavailable, availableCond.Reason)
+	assert.Equal(t, "1 of 3 Packages are available. "+
+		"Failed: taken: Package already exists and is not part of this fleet.", availableCond.Message)
+	assert.True(t, meta.IsStatusConditionTrue(fleet.Status.Conditions, corev1alpha1.PackageFleetProgressing))
+
+	// Template changes are rolled out to all Packages.
+	fleet.Spec.Template.Spec.Image = "quay.io/example/monitoring:v2"
+	require.NoError(t, c.Update(ctx, fleet))
+	_, err = controller.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(readyPkg), readyPkg))
+	assert.Equal(t, "quay.io/example/monitoring:v2", readyPkg.Spec.Image)
+	require.NoError(t, c.Get(ctx, req.NamespacedName, fleet))
+	assert.Equal(t, int32(0), fleet.Status.ReadyCount)
+	assert.Equal(t, int32(2), fleet.Status.ProgressingCount)
+}
+
+func Test_packageState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		pkg      *corev1alpha1.Package
+		expected corev1alpha1.HealthStatus
+	}{
+		{
+			name:     "not reconciled",
+			pkg:      &corev1alpha1.Package{},
+			expected: corev1alpha1.HealthStatusProgressing,
+		},
+		{
+			name: "outdated status",
+			pkg: &corev1alpha1.Package{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: corev1alpha1.PackageStatus{
+					Conditions: []metav1.Condition{{
+						Type:               corev1alpha1.PackageAvailable,
+						Status:             metav1.ConditionTrue,
+						ObservedGeneration: 1,
+					}},
+					Health: &corev1alpha1.Health{Status: corev1alpha1.HealthStatusHealthy},
+				},
+			},
+			expected: corev1alpha1.HealthStatusProgressing,
+		},
+		{
+			name: "degraded",
+			pkg: &corev1alpha1.Package{
+				ObjectMeta: metav1.ObjectMeta{Generation: 1},
+				Status: corev1alpha1.PackageStatus{
+					Conditions: []metav1.Condition{{
+						Type:               corev1alpha1.PackageAvailable,
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: 1,
+					}},
+					Health: &corev1alpha1.Health{Status: corev1alpha1.HealthStatusDegraded},
+				},
+			},
+			expected: corev1alpha1.HealthStatusDegraded,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, packageState(test.pkg).health)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package packagerepositories
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestCRDValidation_repositoriesOrCatalogRequired(t *testing.T) {
+	t.Parallel()
+
+	validate := testutil.NewCRDValidator(t,
+		filepath.Join("..", "..", "..", "config", "crds", "package-operator.run_packagerepositories.yaml"))
+
+	tests := []struct {
+		name  string
+		spec  map[string]interface{}
+		valid bool
+	}{
+		{
+			name:  "repositories",
+			spec:  map[string]interface{}{"repositories": []interface{}{"quay.io/example/package"}},
+			valid: true,
+		},
+		{
+			name:  "catalog",
+			spec:  map[string]interface{}{"catalog": "quay.io/example/catalog:latest"},
+			valid: true,
+		},
+		{name: "empty", spec: map[string]interface{}{}},
+		{
+			name: "empty repositories and catalog",
+			spec: map[string]interface{}{"repositories": []interface{}{}, "catalog": ""},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := validate(map[string]interface{}{"spec": test.spec})
+			if test.valid {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.Contains(t, errs[0].Error(), "at least one of repositories or catalog is required")
+			}
+		})
+	}
+}
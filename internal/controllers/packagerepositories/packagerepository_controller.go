@@ -0,0 +1,367 @@
+package packagerepositories
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"sigs.k8s.io/yaml"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/package-operator/internal/packages"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+	"package-operator.run/package-operator/internal/tracing"
+)
+
+// Interval in which available versions are refreshed, if not specified.
+const defaultRefreshInterval = time.Hour
+
+type packageRegistry interface {
+	ListTags(ctx context.Context, repository string) ([]string, error)
+	Digest(ctx context.Context, image string) (string, error)
+	Pull(ctx context.Context, image string) (packagecontent.Files, error)
+}
+
+// PackageRepositoryController lists package versions available in OCI repositories,
+// either listed directly or via a catalog image.
+type PackageRepositoryController struct {
+	client      client.Client
+	log         logr.Logger
+	scheme      *runtime.Scheme
+	registry    packageRegistry
+	clock       clock.Clock
+	rateLimiter ratelimiter.RateLimiter
+}
+
+func NewPackageRepositoryController(
+	c client.Client, log logr.Logger,
+	scheme *runtime.Scheme, registry packageRegistry,
+) *PackageRepositoryController {
+	return &PackageRepositoryController{
+		client:   c,
+		log:      log,
+		scheme:   scheme,
+		registry: registry,
+		clock:    clock.RealClock{},
+	}
+}
+
+// SetRateLimiter sets the workqueue rate limiter used when the controller is set up.
+func (c *PackageRepositoryController) SetRateLimiter(rl ratelimiter.RateLimiter) {
+	c.rateLimiter = rl
+}
+
+func (c *PackageRepositoryController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{RateLimiter: c.rateLimiter}).
+		For(&corev1alpha1.PackageRepository{}).
+		Owns(&corev1alpha1.AvailablePackage{}).
+		Complete(c)
+}
+
+func (c *PackageRepositoryController) Reconcile(
+	ctx context.Context, req ctrl.Request,
+) (res ctrl.Result, err error) {
+	log := c.log.WithValues("PackageRepository", req.String())
+	defer log.Info("reconciled")
+	ctx = logr.NewContext(ctx, log)
+
+	ctx, span := tracing.Start(ctx, "Reconcile PackageRepository", tracing.RequestAttributes(req)...)
+	defer func() { tracing.End(span, err) }()
+
+	repo := &corev1alpha1.PackageRepository{}
+	if err := c.client.Get(ctx, req.NamespacedName, repo); err != nil {
+		return res, client.IgnoreNotFound(err)
+	}
+	if !repo.DeletionTimestamp.IsZero() {
+		// AvailablePackages are garbage collected via owner references.
+		return res, nil
+	}
+
+	refreshInterval := defaultRefreshInterval
+	if repo.Spec.RefreshInterval != nil {
+		refreshInterval = repo.Spec.RefreshInterval.Duration
+	}
+	if next, ok := c.nextSync(repo, refreshInterval); ok {
+		// Versions are still fresh, only make sure AvailablePackages match.
+		if err := c.reconcileAvailablePackages(ctx, repo); err != nil {
+			return res, err
+		}
+		return ctrl.Result{RequeueAfter: next.Sub(c.clock.Now())}, nil
+	}
+
+	packages, listErr := c.listPackages(ctx, repo.Spec)
+	if listErr != nil {
+		meta.SetStatusCondition(&repo.Status.Conditions, metav1.Condition{
+			Type:               corev1alpha1.PackageRepositorySynced,
+			Status:             metav1.ConditionFalse,
+			Reason:             corev1alpha1.ReasonListFailed,
+			Message:            listErr.Error(),
+			ObservedGeneration: repo.Generation,
+		})
+	} else {
+		meta.SetStatusCondition(&repo.Status.Conditions, metav1.Condition{
+			Type:               corev1alpha1.PackageRepositorySynced,
+			Status:             metav1.ConditionTrue,
+			Reason:             corev1alpha1.ReasonSynced,
+			Message:            "All repositories have been listed.",
+			ObservedGeneration: repo.Generation,
+		})
+	}
+	if packages == nil {
+		packages = []corev1alpha1.RepositoryPackage{}
+	}
+
+	now := metav1.NewTime(c.clock.Now())
+	repo.Status.Packages = packages
+	repo.Status.PackageCount = len(packages)
+	repo.Status.LastSyncTime = &now
+	if err := c.client.Status().Update(ctx, repo); err != nil {
+		return res, fmt.Errorf("updating PackageRepository status: %w", err)
+	}
+
+	if err := c.reconcileAvailablePackages(ctx, repo); err != nil {
+		return res, err
+	}
+	if listErr != nil {
+		// Retry with backoff.
+		return res, listErr
+	}
+	return ctrl.Result{RequeueAfter: refreshInterval}, nil
+}
+
+// Returns when the PackageRepository has to be synced next,
+// if the last successful sync is still within the refresh interval.
+func (c *PackageRepositoryController) nextSync(
+	repo *corev1alpha1.PackageRepository, refreshInterval time.Duration,
+) (time.Time, bool) {
+	synced := meta.FindStatusCondition(repo.Status.Conditions, corev1alpha1.PackageRepositorySynced)
+	if repo.Status.LastSyncTime == nil || synced == nil ||
+		synced.Status != metav1.ConditionTrue ||
+		synced.ObservedGeneration != repo.Generation {
+		return time.Time{}, false
+	}
+	next := repo.Status.LastSyncTime.Add(refreshInterval)
+	return next, c.clock.Now().Before(next)
+}
+
+// Lists the versions of all packages, keeping packages that could be listed if others fail.
+func (c *PackageRepositoryController) listPackages(
+	ctx context.Context, spec corev1alpha1.PackageRepositorySpec,
+) ([]corev1alpha1.RepositoryPackage, error) {
+	channels, err := parseChannels(spec.Channels)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		packages []corev1alpha1.RepositoryPackage
+		errs     []error
+	)
+	repositories := spec.Repositories
+	if len(spec.Catalog) > 0 {
+		catalogRepositories, err := c.catalogRepositories(ctx, spec.Catalog)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("loading catalog %s: %w", spec.Catalog, err))
+		}
+		repositories = mergeRepositories(repositories, catalogRepositories)
+	}
+
+	for _, repository := range repositories {
+		tags, err := c.registry.ListTags(ctx, repository)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("listing tags of %s: %w", repository, err))
+			continue
+		}
+
+		versions := sortedVersions(tags)
+		pkg := corev1alpha1.RepositoryPackage{
+			Name:       path.Base(repository),
+			Repository: repository,
+			Versions:   make([]string, len(versions)),
+		}
+		for i, v := range versions {
+			pkg.Versions[i] = v.Original()
+		}
+		if len(versions) > 0 {
+			pkg.LatestVersion = pkg.Versions[0]
+		}
+		for _, ch := range channels {
+			pkgCh := ch.latest(versions)
+			if len(pkgCh.LatestVersion) > 0 {
+				image := repository + ":" + pkgCh.LatestVersion
+				if pkgCh.LatestDigest, err = c.registry.Digest(ctx, image); err != nil {
+					errs = append(errs, fmt.Errorf("resolving digest of %s: %w", image, err))
+				}
+			}
+			pkg.Channels = append(pkg.Channels, pkgCh)
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, errors.NewAggregate(errs)
+}
+
+// Returns the repositories listed in the PackageCatalog of the given catalog image.
+func (c *PackageRepositoryController) catalogRepositories(ctx context.Context, image string) ([]string, error) {
+	files, err := c.registry.Pull(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := files[packages.PackageCatalogFile]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s not found", errInvalidCatalog, packages.PackageCatalogFile)
+	}
+
+	catalog := &manifestsv1alpha1.PackageCatalog{}
+	if err := yaml.Unmarshal(data, catalog); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidCatalog, err)
+	}
+	if gvk := catalog.GroupVersionKind(); gvk.GroupKind() != packages.PackageCatalogGroupKind ||
+		gvk.Version != manifestsv1alpha1.GroupVersion.Version {
+		return nil, fmt.Errorf("%w: unknown GroupVersionKind %s", errInvalidCatalog, gvk)
+	}
+	return catalog.Spec.Repositories, nil
+}
+
+// errInvalidCatalog is returned when a catalog image does not contain a valid PackageCatalog.
+var errInvalidCatalog = goerrors.New("invalid catalog")
+
+// Appends repositories not already present, keeping the order of both lists.
+func mergeRepositories(repositories, additional []string) []string {
+	seen := map[string]struct{}{}
+	merged := make([]string, 0, len(repositories)+len(additional))
+	for _, r := range append(append([]string{}, repositories...), additional...) {
+		if _, ok := seen[r]; ok {
+			continue
+		}
+		seen[r] = struct{}{}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// Returns all tags following semantic versioning, latest first.
+func sortedVersions(tags []string) []*semver.Version {
+	var versions []*semver.Version
+	for _, tag := range tags {
+		v, err := semver.NewVersion(tag)
+		if err != nil {
+			// Not a version, e.g. "latest" or a digest tag.
+			continue
+		}
+		versions = append(versions, v)
+	}
+	sort.Sort(sort.Reverse(semver.Collection(versions)))
+	return versions
+}
+
+type channel struct {
+	name        string
+	constraints *semver.Constraints
+}
+
+func parseChannels(specs []corev1alpha1.PackageRepositoryChannel) ([]channel, error) {
+	channels := make([]channel, len(specs))
+	for i, spec := range specs {
+		constraints, err := semver.NewConstraint(spec.Versions)
+		if err != nil {
+			return nil, fmt.Errorf("parsing versions of channel %s: %w", spec.Name, err)
+		}
+		channels[i] = channel{name: spec.Name, constraints: constraints}
+	}
+	return channels, nil
+}
+
+// Returns the latest of the given versions, sorted latest first, that is part of the channel.
+func (ch channel) latest(versions []*semver.Version) corev1alpha1.RepositoryPackageChannel {
+	out := corev1alpha1.RepositoryPackageChannel{Name: ch.name}
+	for _, v := range versions {
+		if ch.constraints.Check(v) {
+			out.LatestVersion = v.Original()
+			break
+		}
+	}
+	return out
+}
+
+func (c *PackageRepositoryController) reconcileAvailablePackages(
+	ctx context.Context, repo *corev1alpha1.PackageRepository,
+) error {
+	existingList := &corev1alpha1.AvailablePackageList{}
+	if err := c.client.List(ctx, existingList, client.MatchingLabels{
+		corev1alpha1.PackageRepositoryLabel: repo.Name,
+	}); err != nil {
+		return fmt.Errorf("listing AvailablePackages: %w", err)
+	}
+
+	desired := map[string]corev1alpha1.RepositoryPackage{}
+	if repo.Spec.CreateAvailablePackages {
+		for _, pkg := range repo.Status.Packages {
+			desired[availablePackageName(repo, pkg)] = pkg
+		}
+	}
+
+	existing := map[string]*corev1alpha1.AvailablePackage{}
+	for i := range existingList.Items {
+		ap := &existingList.Items[i]
+		if _, ok := desired[ap.Name]; !ok {
+			if err := c.client.Delete(ctx, ap); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("deleting AvailablePackage: %w", err)
+			}
+			continue
+		}
+		existing[ap.Name] = ap
+	}
+
+	for name, pkg := range desired {
+		if ap, ok := existing[name]; ok {
+			if equality.Semantic.DeepEqual(ap.Package, pkg) {
+				continue
+			}
+			ap.Package = pkg
+			if err := c.client.Update(ctx, ap); err != nil {
+				return fmt.Errorf("updating AvailablePackage: %w", err)
+			}
+			continue
+		}
+
+		ap := &corev1alpha1.AvailablePackage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					corev1alpha1.PackageRepositoryLabel: repo.Name,
+				},
+			},
+			Package: pkg,
+		}
+		if err := controllerutil.SetControllerReference(repo, ap, c.scheme); err != nil {
+			return err
+		}
+		if err := c.client.Create(ctx, ap); err != nil {
+			return fmt.Errorf("creating AvailablePackage: %w", err)
+		}
+	}
+	return nil
+}
+
+func availablePackageName(repo *corev1alpha1.PackageRepository, pkg corev1alpha1.RepositoryPackage) string {
+	return repo.Name + "." + strings.ToLower(pkg.Name)
+}
@@ -0,0 +1,286 @@
+package packagerepositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+type registryMock struct {
+	mock.Mock
+}
+
+func (m *registryMock) ListTags(ctx context.Context, repository string) ([]string, error) {
+	args := m.Called(ctx, repository)
+	tags, _ := args.Get(0).([]string)
+	return tags, args.Error(1)
+}
+
+func (m *registryMock) Digest(ctx context.Context, image string) (string, error) {
+	args := m.Called(ctx, image)
+	return args.String(0), args.Error(1)
+}
+
+func (m *registryMock) Pull(ctx context.Context, image string) (packagecontent.Files, error) {
+	args := m.Called(ctx, image)
+	files, _ := args.Get(0).(packagecontent.Files)
+	return files, args.Error(1)
+}
+
+func newTestController(t *testing.T) (
+	*PackageRepositoryController, *testutil.CtrlClient, *registryMock, *clocktesting.FakeClock,
+) {
+	t.Helper()
+	c := testutil.NewClient()
+	reg := &registryMock{}
+	clk := clocktesting.NewFakeClock(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	prc := NewPackageRepositoryController(c, testr.New(t), testutil.NewTestSchemeWithCoreV1Alpha1(), reg)
+	prc.clock = clk
+	return prc, c, reg, clk
+}
+
+func mockGetRepository(c *testutil.CtrlClient, repo *corev1alpha1.PackageRepository) {
+	c.On("Get", mock.Anything, mock.Anything,
+		mock.AnythingOfType("*v1alpha1.PackageRepository"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			repo.DeepCopyInto(args.Get(2).(*corev1alpha1.PackageRepository))
+		}).
+		Return(nil)
+}
+
+func TestPackageRepositoryController_Reconcile(t *testing.T) {
+	t.Parallel()
+	prc, c, reg, _ := newTestController(t)
+
+	repo := &corev1alpha1.PackageRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 2},
+		Spec: corev1alpha1.PackageRepositorySpec{
+			Repositories:            []string{"quay.io/example/test-stub"},
+			CreateAvailablePackages: true,
+			Channels: []corev1alpha1.PackageRepositoryChannel{
+				{Name: "stable", Versions: "< 1.10.0"},
+				{Name: "candidate", Versions: ">= 1.0.0-0"},
+			},
+		},
+	}
+	mockGetRepository(c, repo)
+	reg.On("ListTags", mock.Anything, "quay.io/example/test-stub").
+		Return([]string{"latest", "v1.0.0", "v1.10.0", "v1.2.0", "v1.11.0-rc.1", "sha256-abc"}, nil)
+	reg.On("Digest", mock.Anything, "quay.io/example/test-stub:v1.2.0").Return("sha256:120", nil)
+	reg.On("Digest", mock.Anything, "quay.io/example/test-stub:v1.11.0-rc.1").Return("sha256:111", nil)
+
+	var status *corev1alpha1.PackageRepository
+	c.StatusMock.On("Update", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			status = args.Get(1).(*corev1alpha1.PackageRepository)
+		}).
+		Return(nil)
+	c.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	var created *corev1alpha1.AvailablePackage
+	c.On("Create", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*corev1alpha1.AvailablePackage)
+		}).
+		Return(nil)
+
+	res, err := prc.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: client.ObjectKey{Name: "test"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, defaultRefreshInterval, res.RequeueAfter)
+
+	require.NotNil(t, status)
+	expectedPkg := corev1alpha1.RepositoryPackage{
+		Name:          "test-stub",
+		Repository:    "quay.io/example/test-stub",
+		Versions:      []string{"v1.11.0-rc.1", "v1.10.0", "v1.2.0", "v1.0.0"},
+		LatestVersion: "v1.11.0-rc.1",
+		Channels: []corev1alpha1.RepositoryPackageChannel{
+			{Name: "stable", LatestVersion: "v1.2.0", LatestDigest: "sha256:120"},
+			{Name: "candidate", LatestVersion: "v1.11.0-rc.1", LatestDigest: "sha256:111"},
+		},
+	}
+	assert.Equal(t, []corev1alpha1.RepositoryPackage{expectedPkg}, status.Status.Packages)
+	assert.Equal(t, 1, status.Status.PackageCount)
+	assert.NotNil(t, status.Status.LastSyncTime)
+	cond := meta.FindStatusCondition(status.Status.Conditions, corev1alpha1.PackageRepositorySynced)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, int64(2), cond.ObservedGeneration)
+
+	require.NotNil(t, created)
+	assert.Equal(t, "test.test-stub", created.Name)
+	assert.Equal(t, "test", created.Labels[corev1alpha1.PackageRepositoryLabel])
+	assert.Equal(t, expectedPkg, created.Package)
+	require.Len(t, created.OwnerReferences, 1)
+	assert.Equal(t, "PackageRepository", created.OwnerReferences[0].Kind)
+}
+
+func TestPackageRepositoryController_Reconcile_listError(t *testing.T) {
+	t.Parallel()
+	prc, c, reg, _ := newTestController(t)
+
+	repo := &corev1alpha1.PackageRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: corev1alpha1.PackageRepositorySpec{
+			Repositories: []string{"quay.io/example/broken", "quay.io/example/ok"},
+		},
+	}
+	mockGetRepository(c, repo)
+	reg.On("ListTags", mock.Anything, "quay.io/example/broken").
+		Return(nil, errors.New("unauthorized"))
+	reg.On("ListTags", mock.Anything, "quay.io/example/ok").
+		Return([]string{"v1.0.0"}, nil)
+
+	var status *corev1alpha1.PackageRepository
+	c.StatusMock.On("Update", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			status = args.Get(1).(*corev1alpha1.PackageRepository)
+		}).
+		Return(nil)
+	c.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := prc.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: client.ObjectKey{Name: "test"},
+	})
+	require.ErrorContains(t, err, "unauthorized")
+
+	require.NotNil(t, status)
+	if assert.Len(t, status.Status.Packages, 1) {
+		assert.Equal(t, "ok", status.Status.Packages[0].Name)
+	}
+	assert.True(t, meta.IsStatusConditionFalse(
+		status.Status.Conditions, corev1alpha1.PackageRepositorySynced))
+	c.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPackageRepositoryController_Reconcile_fresh(t *testing.T) {
+	t.Parallel()
+	prc, c, reg, clk := newTestController(t)
+
+	lastSync := metav1.NewTime(clk.Now().Add(-10 * time.Minute))
+	repo := &corev1alpha1.PackageRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 1},
+		Spec: corev1alpha1.PackageRepositorySpec{
+			Repositories: []string{"quay.io/example/test-stub"},
+		},
+		Status: corev1alpha1.PackageRepositoryStatus{
+			Conditions: []metav1.Condition{{
+				Type:               corev1alpha1.PackageRepositorySynced,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 1,
+			}},
+			LastSyncTime: &lastSync,
+		},
+	}
+	mockGetRepository(c, repo)
+
+	stale := corev1alpha1.AvailablePackage{
+		ObjectMeta: metav1.ObjectMeta{Name: "test.removed"},
+	}
+	c.On("List", mock.Anything, mock.AnythingOfType("*v1alpha1.AvailablePackageList"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*corev1alpha1.AvailablePackageList)
+			list.Items = []corev1alpha1.AvailablePackage{stale}
+		}).
+		Return(nil)
+	c.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	res, err := prc.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: client.ObjectKey{Name: "test"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 50*time.Minute, res.RequeueAfter)
+
+	reg.AssertNotCalled(t, "ListTags", mock.Anything, mock.Anything)
+	c.StatusMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	c.AssertCalled(t, "Delete", mock.Anything, &stale, mock.Anything)
+}
+
+func TestPackageRepositoryController_Reconcile_catalog(t *testing.T) {
+	t.Parallel()
+	prc, c, reg, _ := newTestController(t)
+
+	repo := &corev1alpha1.PackageRepository{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: corev1alpha1.PackageRepositorySpec{
+			Repositories: []string{"quay.io/example/test-stub"},
+			Catalog:      "quay.io/example/catalog:latest",
+		},
+	}
+	mockGetRepository(c, repo)
+	reg.On("Pull", mock.Anything, "quay.io/example/catalog:latest").
+		Return(packagecontent.Files{
+			"catalog.yaml": []byte(`apiVersion: manifests.package-operator.run/v1alpha1
+kind: PackageCatalog
+spec:
+  repositories:
+  - quay.io/example/test-stub
+  - quay.io/example/other
+`),
+		}, nil)
+	reg.On("ListTags", mock.Anything, "quay.io/example/test-stub").
+		Return([]string{"v1.0.0"}, nil)
+	reg.On("ListTags", mock.Anything, "quay.io/example/other").
+		Return([]string{"v2.0.0"}, nil)
+
+	var status *corev1alpha1.PackageRepository
+	c.StatusMock.On("Update", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			status = args.Get(1).(*corev1alpha1.PackageRepository)
+		}).
+		Return(nil)
+	c.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := prc.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: client.ObjectKey{Name: "test"},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, status)
+	assert.Equal(t, []corev1alpha1.RepositoryPackage{
+		{
+			Name: "test-stub", Repository: "quay.io/example/test-stub",
+			Versions: []string{"v1.0.0"}, LatestVersion: "v1.0.0",
+		},
+		{
+			Name: "other", Repository: "quay.io/example/other",
+			Versions: []string{"v2.0.0"}, LatestVersion: "v2.0.0",
+		},
+	}, status.Status.Packages)
+	reg.AssertNumberOfCalls(t, "ListTags", 2)
+}
+
+func TestPackageRepositoryController_catalogRepositories_invalid(t *testing.T) {
+	t.Parallel()
+	prc, _, reg, _ := newTestController(t)
+
+	reg.On("Pull", mock.Anything, "quay.io/example/missing").
+		Return(packagecontent.Files{}, nil)
+	reg.On("Pull", mock.Anything, "quay.io/example/wrong-kind").
+		Return(packagecontent.Files{
+			"catalog.yaml": []byte("apiVersion: manifests.package-operator.run/v1alpha1\nkind: PackageManifest\n"),
+		}, nil)
+
+	ctx := context.Background()
+	_, err := prc.catalogRepositories(ctx, "quay.io/example/missing")
+	assert.ErrorIs(t, err, errInvalidCatalog)
+	_, err = prc.catalogRepositories(ctx, "quay.io/example/wrong-kind")
+	assert.ErrorIs(t, err, errInvalidCatalog)
+}
@@ -0,0 +1,130 @@
+package packages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/adapters"
+	"package-operator.run/package-operator/internal/controllers"
+)
+
+// Packages managing more objects list them in an inventory ConfigMap,
+// to keep the size of the Package object in check.
+const maxStatusObjects = 100
+
+// Reports the objects managed by a package, as listed by its ObjectDeployment.
+type inventoryReconciler struct {
+	client              client.Client
+	scheme              *runtime.Scheme
+	newObjectDeployment adapters.ObjectDeploymentFactory
+	// Namespace of the inventory ConfigMaps of ClusterPackages.
+	// ClusterPackages always list their objects in status, if empty.
+	namespace string
+	// Field manager of inventory ConfigMaps.
+	identity controllers.OperatorIdentity
+}
+
+func (r *inventoryReconciler) Reconcile(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+) (ctrl.Result, error) {
+	objDep := r.newObjectDeployment(r.scheme)
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(pkg.ClientObject()), objDep.ClientObject()); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	objects := objDep.GetStatusControllerOf()
+
+	key, ok := r.inventoryKey(pkg)
+	if len(objects) <= maxStatusObjects || !ok {
+		if err := r.deleteInventory(ctx, pkg); err != nil {
+			return ctrl.Result{}, err
+		}
+		pkg.SetStatusObjects(objects)
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.applyInventory(ctx, pkg, key, objects); err != nil {
+		return ctrl.Result{}, err
+	}
+	pkg.SetStatusObjects(nil)
+	pkg.SetStatusInventory(&corev1alpha1.PackageInventoryReference{
+		Name:        key.Name,
+		Namespace:   key.Namespace,
+		ObjectCount: int32(len(objects)),
+	})
+	return ctrl.Result{}, nil
+}
+
+// Returns the key of the inventory ConfigMap of the package
+// and false when no namespace is configured for ClusterPackages.
+func (r *inventoryReconciler) inventoryKey(pkg adapters.GenericPackageAccessor) (client.ObjectKey, bool) {
+	obj := pkg.ClientObject()
+	if len(obj.GetNamespace()) > 0 {
+		return client.ObjectKey{
+			Name: obj.GetName() + ".package-inventory", Namespace: obj.GetNamespace(),
+		}, true
+	}
+	return client.ObjectKey{
+		Name: obj.GetName() + ".clusterpackage-inventory", Namespace: r.namespace,
+	}, len(r.namespace) > 0
+}
+
+func (r *inventoryReconciler) applyInventory(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+	key client.ObjectKey, objects []corev1alpha1.ControlledObjectReference,
+) error {
+	j, err := json.Marshal(objects)
+	if err != nil {
+		return fmt.Errorf("marshalling inventory: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Data: map[string]string{
+			corev1alpha1.PackageInventoryObjectsKey: string(j),
+		},
+	}
+	if err := controllerutil.SetControllerReference(pkg.ClientObject(), cm, r.scheme); err != nil {
+		return fmt.Errorf("setting owner reference: %w", err)
+	}
+	// Server-side apply, to not cache all ConfigMaps of the cluster.
+	if err := r.client.Patch(ctx, cm, client.Apply,
+		client.FieldOwner(r.identity.FieldOwner()), client.ForceOwnership); err != nil {
+		return fmt.Errorf("applying inventory ConfigMap: %w", err)
+	}
+	return nil
+}
+
+// Deletes the inventory ConfigMap, when the objects of the package fit into its status again.
+func (r *inventoryReconciler) deleteInventory(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+) error {
+	inventory := pkg.GetStatusInventory()
+	if inventory == nil {
+		return nil
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      inventory.Name,
+			Namespace: inventory.Namespace,
+		},
+	}
+	if err := r.client.Delete(ctx, cm); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting inventory ConfigMap: %w", err)
+	}
+	pkg.SetStatusInventory(nil)
+	return nil
+}
@@ -0,0 +1,122 @@
+package packages
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/adapters"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestInventoryReconciler(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			objDep := args.Get(2).(*corev1alpha1.ObjectDeployment)
+			objDep.Status.ControllerOf = []corev1alpha1.ControlledObjectReference{
+				{Kind: "ConfigMap", Name: "test", Namespace: "test"},
+			}
+		}).
+		Return(nil)
+	c.
+		On("Delete", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	r := &inventoryReconciler{
+		client:              c,
+		scheme:              testutil.NewTestSchemeWithCoreV1Alpha1(),
+		newObjectDeployment: adapters.NewObjectDeployment,
+	}
+
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Status: corev1alpha1.PackageStatus{
+				Inventory: &corev1alpha1.PackageInventoryReference{
+					Name: "test.package-inventory", Namespace: "test", ObjectCount: 101,
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	res, err := r.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+
+	assert.Equal(t, []corev1alpha1.ControlledObjectReference{
+		{Kind: "ConfigMap", Name: "test", Namespace: "test"},
+	}, pkg.Status.Objects)
+	assert.Nil(t, pkg.Status.Inventory)
+	c.AssertCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInventoryReconciler_inventoryConfigMap(t *testing.T) {
+	t.Parallel()
+
+	objects := make([]corev1alpha1.ControlledObjectReference, maxStatusObjects+1)
+	for i := range objects {
+		objects[i] = corev1alpha1.ControlledObjectReference{
+			Kind: "ConfigMap", Name: fmt.Sprintf("test-%d", i), Namespace: "test",
+		}
+	}
+
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			objDep := args.Get(2).(*corev1alpha1.ClusterObjectDeployment)
+			objDep.Status.ControllerOf = objects
+		}).
+		Return(nil)
+	c.
+		On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	r := &inventoryReconciler{
+		client:              c,
+		scheme:              testutil.NewTestSchemeWithCoreV1Alpha1(),
+		newObjectDeployment: adapters.NewClusterObjectDeployment,
+		namespace:           "package-operator-system",
+	}
+
+	pkg := &adapters.GenericClusterPackage{
+		ClusterPackage: corev1alpha1.ClusterPackage{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Status: corev1alpha1.PackageStatus{
+				Objects: objects[:1],
+			},
+		},
+	}
+
+	ctx := context.Background()
+	res, err := r.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+
+	assert.Nil(t, pkg.Status.Objects)
+	assert.Equal(t, &corev1alpha1.PackageInventoryReference{
+		Name:        "test.clusterpackage-inventory",
+		Namespace:   "package-operator-system",
+		ObjectCount: maxStatusObjects + 1,
+	}, pkg.Status.Inventory)
+
+	cm := c.Calls[1].Arguments.Get(1).(*corev1.ConfigMap)
+	assert.Equal(t, "test.clusterpackage-inventory", cm.Name)
+	assert.Equal(t, "package-operator-system", cm.Namespace)
+	assert.Contains(t, cm.Data[corev1alpha1.PackageInventoryObjectsKey], `"name":"test-100"`)
+	if assert.Len(t, cm.OwnerReferences, 1) {
+		assert.Equal(t, "ClusterPackage", cm.OwnerReferences[0].Kind)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,16 +26,28 @@ func (r *objectDeploymentStatusReconciler) Reconcile(ctx context.Context, packag
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	objDepAvailableCondition := meta.FindStatusCondition(*objDep.GetConditions(), corev1alpha1.ObjectDeploymentAvailable)
-	if objDepAvailableCondition != nil && objDepAvailableCondition.ObservedGeneration == objDep.ClientObject().GetGeneration() {
+	objDepAvailableCondition := controllers.FindCurrentStatusCondition(
+		*objDep.GetConditions(), corev1alpha1.ObjectDeploymentAvailable, objDep.ClientObject().GetGeneration())
+	if objDepAvailableCondition != nil {
 		packageAvailableCond := objDepAvailableCondition.DeepCopy()
 		packageAvailableCond.ObservedGeneration = packageObj.ClientObject().GetGeneration()
 
 		meta.SetStatusCondition(packageObj.GetConditions(), *packageAvailableCond)
+	} else if meta.FindStatusCondition(*packageObj.GetConditions(), corev1alpha1.PackageAvailable) != nil {
+		// ObjectDeployment has not yet reported on its latest generation,
+		// so Available from an older generation would be stale.
+		meta.SetStatusCondition(packageObj.GetConditions(), metav1.Condition{
+			Type:               corev1alpha1.PackageAvailable,
+			Status:             metav1.ConditionUnknown,
+			Reason:             corev1alpha1.ReasonPending,
+			Message:            "Waiting for ObjectDeployment to report on its latest generation.",
+			ObservedGeneration: packageObj.ClientObject().GetGeneration(),
+		})
 	}
 
-	objDepProgressingCondition := meta.FindStatusCondition(*objDep.GetConditions(), corev1alpha1.ObjectDeploymentProgressing)
-	if objDepProgressingCondition != nil && objDepProgressingCondition.ObservedGeneration == objDep.ClientObject().GetGeneration() {
+	objDepProgressingCondition := controllers.FindCurrentStatusCondition(
+		*objDep.GetConditions(), corev1alpha1.ObjectDeploymentProgressing, objDep.ClientObject().GetGeneration())
+	if objDepProgressingCondition != nil {
 		packageProgressingCond := objDepProgressingCondition.DeepCopy()
 		packageProgressingCond.ObservedGeneration = packageObj.ClientObject().GetGeneration()
 
@@ -47,6 +60,7 @@ func (r *objectDeploymentStatusReconciler) Reconcile(ctx context.Context, packag
 		objDep.ClientObject().GetGeneration(), *objDep.GetConditions(),
 		packageObj.ClientObject().GetGeneration(), packageObj.GetConditions(),
 	)
+	packageObj.SetStatusMappedFields(objDep.GetStatusMappedFields())
 
 	packageObj.SetStatusRevision(objDep.GetStatusRevision())
 
@@ -0,0 +1,91 @@
+package packages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/adapters"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestObjectDeploymentStatusReconciler(t *testing.T) {
+	tests := []struct {
+		name                string
+		objDepGeneration    int64
+		objDepObservedGen   int64
+		expectedAvailStatus metav1.ConditionStatus
+	}{
+		{
+			name:                "current",
+			objDepGeneration:    2,
+			objDepObservedGen:   2,
+			expectedAvailStatus: metav1.ConditionTrue,
+		},
+		{
+			name:                "stale",
+			objDepGeneration:    2,
+			objDepObservedGen:   1,
+			expectedAvailStatus: metav1.ConditionUnknown,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			c := testutil.NewClient()
+			c.
+				On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					objDep := args.Get(2).(*corev1alpha1.ObjectDeployment)
+					objDep.Generation = test.objDepGeneration
+					objDep.Status.Conditions = []metav1.Condition{
+						{
+							Type:               corev1alpha1.ObjectDeploymentAvailable,
+							Status:             metav1.ConditionTrue,
+							ObservedGeneration: test.objDepObservedGen,
+						},
+					}
+				}).
+				Return(nil)
+
+			r := &objectDeploymentStatusReconciler{
+				client:              c,
+				scheme:              testutil.NewTestSchemeWithCoreV1Alpha1(),
+				newObjectDeployment: adapters.NewObjectDeployment,
+			}
+
+			pkg := &adapters.GenericPackage{
+				Package: corev1alpha1.Package{
+					ObjectMeta: metav1.ObjectMeta{Generation: 5},
+					Status: corev1alpha1.PackageStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:               corev1alpha1.PackageAvailable,
+								Status:             metav1.ConditionTrue,
+								ObservedGeneration: 4,
+							},
+						},
+					},
+				},
+			}
+
+			ctx := context.Background()
+			res, err := r.Reconcile(ctx, pkg)
+			require.NoError(t, err)
+			assert.True(t, res.IsZero())
+
+			cond := meta.FindStatusCondition(*pkg.GetConditions(), corev1alpha1.PackageAvailable)
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, test.expectedAvailStatus, cond.Status)
+				assert.Equal(t, int64(5), cond.ObservedGeneration)
+			}
+		})
+	}
+}
@@ -6,17 +6,28 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/adapters"
 	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/dynamiccache"
 	"package-operator.run/package-operator/internal/environment"
 	"package-operator.run/package-operator/internal/metrics"
 	"package-operator.run/package-operator/internal/packages/packagedeploy"
+	"package-operator.run/package-operator/internal/tracing"
 )
 
 const loaderJobFinalizer = "package-operator.run/loader-job"
@@ -32,67 +43,132 @@ type metricsRecorder interface {
 	RecordPackageLoadMetric(pkg metrics.GenericPackage, d time.Duration)
 }
 
+// Discovers APIs served by the cluster to check package constraints.
+type discoveryClient interface {
+	ServerGroups() (*metav1.APIGroupList, error)
+	ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error)
+}
+
+// Watches objects read by the lookup template function.
+type dynamicCache interface {
+	client.Reader
+	Source() source.Source
+	Free(ctx context.Context, obj client.Object) error
+	Watch(
+		ctx context.Context, owner client.Object, obj runtime.Object,
+		opts ...dynamiccache.WatchOption,
+	) error
+	OwnersForGKV(gvk schema.GroupVersionKind) []dynamiccache.OwnerReference
+}
+
 // Generic reconciler for both Package and ClusterPackage objects.
 type GenericPackageController struct {
 	newPackage          adapters.GenericPackageFactory
+	newPackageList      adapters.GenericPackageListFactory
 	newObjectDeployment adapters.ObjectDeploymentFactory
 
-	recorder         metricsRecorder
-	client           client.Client
-	log              logr.Logger
-	scheme           *runtime.Scheme
-	reconciler       []reconciler
-	unpackReconciler *unpackReconciler
+	recorder            metricsRecorder
+	client              client.Client
+	dynamicCache        dynamicCache
+	log                 logr.Logger
+	scheme              *runtime.Scheme
+	reconciler          []reconciler
+	unpackReconciler    *unpackReconciler
+	upgradeReconciler   *upgradeReconciler
+	inventoryReconciler *inventoryReconciler
+	statusWriter        *controllers.StatusWriter
+	maintenance         *controllers.MaintenanceModeChecker
+	rateLimiter         ratelimiter.RateLimiter
+	// Only Packages labeled with this identity are reconciled.
+	identity controllers.OperatorIdentity
 }
 
 func NewPackageController(
-	c client.Client, log logr.Logger,
+	c, uncachedClient client.Client, log logr.Logger,
+	dynamicCache dynamicCache,
 	scheme *runtime.Scheme,
+	restMapper meta.RESTMapper,
+	discoveryClient discoveryClient,
 	imagePuller imagePuller,
+	sourceLoader sourceLoader,
 	metricsRecorder metricsRecorder,
 	packageHashModifier *int32,
+	identity controllers.OperatorIdentity,
 ) *GenericPackageController {
+	objectLookup := controllers.NewObjectLookup(c, uncachedClient, dynamicCache, restMapper, identity)
 	return newGenericPackageController(
-		adapters.NewGenericPackage, adapters.NewObjectDeployment,
-		c, log, scheme, imagePuller, packagedeploy.NewPackageDeployer(c, scheme),
-		metricsRecorder, packageHashModifier,
+		adapters.NewGenericPackage, adapters.NewGenericPackageList, adapters.NewObjectDeployment,
+		c, log, dynamicCache, scheme, imagePuller, sourceLoader,
+		packagedeploy.NewPackageDeployer(c, scheme, discoveryClient, objectLookup),
+		metricsRecorder, packageHashModifier, identity,
 	)
 }
 
 func NewClusterPackageController(
-	c client.Client, log logr.Logger,
+	c, uncachedClient client.Client, log logr.Logger,
+	dynamicCache dynamicCache,
 	scheme *runtime.Scheme,
+	restMapper meta.RESTMapper,
+	discoveryClient discoveryClient,
 	imagePuller imagePuller,
+	sourceLoader sourceLoader,
 	metricsRecorder metricsRecorder,
 	packageHashModifier *int32,
+	identity controllers.OperatorIdentity,
 ) *GenericPackageController {
+	objectLookup := controllers.NewObjectLookup(c, uncachedClient, dynamicCache, restMapper, identity)
 	return newGenericPackageController(
-		adapters.NewGenericClusterPackage, adapters.NewClusterObjectDeployment,
-		c, log, scheme, imagePuller, packagedeploy.NewClusterPackageDeployer(c, scheme),
-		metricsRecorder, packageHashModifier,
+		adapters.NewGenericClusterPackage, adapters.NewGenericClusterPackageList, adapters.NewClusterObjectDeployment,
+		c, log, dynamicCache, scheme, imagePuller, sourceLoader,
+		packagedeploy.NewClusterPackageDeployer(c, scheme, discoveryClient, objectLookup),
+		metricsRecorder, packageHashModifier, identity,
 	)
 }
 
 func newGenericPackageController(
 	newPackage adapters.GenericPackageFactory,
+	newPackageList adapters.GenericPackageListFactory,
 	newObjectDeployment adapters.ObjectDeploymentFactory,
 	client client.Client, log logr.Logger,
+	dynamicCache dynamicCache,
 	scheme *runtime.Scheme,
 	imagePuller imagePuller,
+	sourceLoader sourceLoader,
 	packageDeployer packageDeployer,
 	metricsRecorder metricsRecorder,
 	packageHashModifier *int32,
+	identity controllers.OperatorIdentity,
 ) *GenericPackageController {
 	controller := &GenericPackageController{
 		newPackage:          newPackage,
+		newPackageList:      newPackageList,
 		newObjectDeployment: newObjectDeployment,
 		recorder:            metricsRecorder,
 		client:              client,
+		dynamicCache:        dynamicCache,
 		log:                 log,
 		scheme:              scheme,
+		identity:            identity,
+		// Unpack results are not computed again on every reconcile,
+		// so status updates are only skipped when unchanged, but never deferred.
+		statusWriter: controllers.NewStatusWriter(client, controllers.WithStatusCoalesceInterval(0)),
 		unpackReconciler: newUnpackReconciler(
-			imagePuller, packageDeployer, metricsRecorder, packageHashModifier),
+			imagePuller, sourceLoader, packageDeployer, metricsRecorder, packageHashModifier),
+		upgradeReconciler: &upgradeReconciler{
+			client:              client,
+			scheme:              scheme,
+			newPackageList:      newPackageList,
+			packageHashModifier: packageHashModifier,
+		},
+		inventoryReconciler: &inventoryReconciler{
+			client:              client,
+			scheme:              scheme,
+			newObjectDeployment: newObjectDeployment,
+			identity:            identity,
+		},
+		maintenance: controllers.NewMaintenanceModeChecker(client),
 	}
+	controller.unpackReconciler.digestPolicy = controllers.NewImageDigestPolicyChecker(client)
 
 	controller.reconciler = []reconciler{
 		controller.unpackReconciler,
@@ -101,24 +177,80 @@ func newGenericPackageController(
 			scheme:              scheme,
 			newObjectDeployment: newObjectDeployment,
 		},
+		controller.inventoryReconciler,
+		controller.upgradeReconciler,
 	}
 
 	return controller
 }
 
+// SetImageDigestResolver enables reporting the digests of package images referenced by tag.
+func (c *GenericPackageController) SetImageDigestResolver(resolver imageDigestResolver) {
+	c.unpackReconciler.digestResolver = resolver
+}
+
 func (c *GenericPackageController) SetEnvironment(env *manifestsv1alpha1.PackageEnvironment) {
 	c.unpackReconciler.SetEnvironment(env)
 }
 
+// DisablePackageRepositories stops following PackageRepository channels for upgrades,
+// e.g. when the cluster-scoped PackageRepositories are not readable.
+func (c *GenericPackageController) DisablePackageRepositories() {
+	c.upgradeReconciler.disabled = true
+}
+
+// SetInventoryNamespace sets the namespace of inventory ConfigMaps,
+// listing the objects of ClusterPackages that manage too many objects to list them in status.
+func (c *GenericPackageController) SetInventoryNamespace(namespace string) {
+	c.inventoryReconciler.namespace = namespace
+}
+
+// SetRateLimiter sets the workqueue rate limiter used when the controller is set up.
+func (c *GenericPackageController) SetRateLimiter(rl ratelimiter.RateLimiter) {
+	c.rateLimiter = rl
+}
+
 func (c *GenericPackageController) SetupWithManager(mgr ctrl.Manager) error {
 	pkg := c.newPackage(c.scheme).ClientObject()
 	objDep := c.newObjectDeployment(c.scheme).ClientObject()
 
-	return ctrl.NewControllerManagedBy(mgr).
-		WithOptions(controller.Options{MaxConcurrentReconciles: 5}).
-		For(pkg).
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{RateLimiter: c.rateLimiter}).
+		For(pkg, builder.WithPredicates(c.identity.Predicate())).
 		Owns(objDep).
-		Complete(c)
+		Watches(c.dynamicCache.Source(), &dynamiccache.EnqueueWatchingObjects{
+			WatcherRefGetter: c.dynamicCache,
+			WatcherType:      pkg,
+		})
+	if !c.upgradeReconciler.disabled {
+		b = b.Watches(
+			&source.Kind{Type: &corev1alpha1.PackageRepository{}},
+			handler.EnqueueRequestsFromMapFunc(c.enqueuePackagesWithUpgradePolicy),
+		)
+	}
+	return b.Complete(c)
+}
+
+// Enqueues all packages following a channel, when available versions change.
+func (c *GenericPackageController) enqueuePackagesWithUpgradePolicy(
+	_ client.Object,
+) []reconcile.Request {
+	pkgList := c.newPackageList(c.scheme)
+	if err := c.client.List(context.Background(), pkgList.ClientObjectList()); err != nil {
+		c.log.Error(err, "listing packages to check for upgrades")
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for _, pkg := range pkgList.GetItems() {
+		if pkg.GetUpgradePolicy() == nil || !c.identity.Owns(pkg.ClientObject()) {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(pkg.ClientObject()),
+		})
+	}
+	return reqs
 }
 
 func (c *GenericPackageController) Reconcile(
@@ -128,11 +260,19 @@ func (c *GenericPackageController) Reconcile(
 	defer log.Info("reconciled")
 	ctx = logr.NewContext(ctx, log)
 
+	ctx, span := tracing.Start(ctx, "Reconcile Package", tracing.RequestAttributes(req)...)
+	defer func() { tracing.End(span, err) }()
+
 	pkg := c.newPackage(c.scheme)
 	if err := c.client.Get(
 		ctx, req.NamespacedName, pkg.ClientObject()); err != nil {
 		return res, client.IgnoreNotFound(err)
 	}
+	if !c.identity.Owns(pkg.ClientObject()) {
+		// Reconciled by another Package Operator installation.
+		return res, nil
+	}
+	original := pkg.ClientObject().DeepCopyObject().(client.Object)
 	defer func() {
 		if err != nil {
 			return
@@ -150,7 +290,21 @@ func (c *GenericPackageController) Reconcile(
 		return res, nil
 	}
 
+	inMaintenance, err := c.maintenance.IsInMaintenance(ctx, pkgClientObject)
+	if err != nil {
+		return res, err
+	}
+
 	for _, r := range c.reconciler {
+		if _, ok := r.(*unpackReconciler); ok && inMaintenance {
+			// Don't deploy new package contents, only report status.
+			log.Info("skipping unpack, Package is in maintenance mode")
+			continue
+		}
+		if _, ok := r.(*upgradeReconciler); ok && inMaintenance {
+			log.Info("skipping upgrade, Package is in maintenance mode")
+			continue
+		}
 		res, err = r.Reconcile(ctx, pkg)
 		if err != nil || !res.IsZero() {
 			break
@@ -159,21 +313,36 @@ func (c *GenericPackageController) Reconcile(
 	if err != nil {
 		return res, err
 	}
+	if inMaintenance && res.IsZero() {
+		res.RequeueAfter = controllers.MaintenanceModeRequeueInterval
+	}
+	if res.IsZero() && len(pkg.GetSource().Image) == 0 {
+		res.RequeueAfter = packageSourceRecheckInterval
+	}
 
-	return res, c.updateStatus(ctx, pkg)
+	return c.updateStatus(ctx, original, pkg, res)
 }
 
-func (c *GenericPackageController) updateStatus(ctx context.Context, pkg adapters.GenericPackageAccessor) error {
+func (c *GenericPackageController) updateStatus(
+	ctx context.Context, original client.Object, pkg adapters.GenericPackageAccessor, res ctrl.Result,
+) (ctrl.Result, error) {
 	pkg.UpdatePhase()
-	if err := c.client.Status().Update(ctx, pkg.ClientObject()); err != nil {
-		return fmt.Errorf("updating Package status: %w", err)
+	res, err := c.statusWriter.Update(ctx, original, pkg.ClientObject(), res)
+	if err != nil {
+		return res, fmt.Errorf("updating Package status: %w", err)
 	}
-	return nil
+	return res, nil
 }
 
 func (c *GenericPackageController) handleDeletion(
 	ctx context.Context, pkg adapters.GenericPackageAccessor,
 ) error {
+	// Free watches of objects read by the lookup template function.
+	if err := controllers.FreeCacheAndRemoveFinalizer(
+		ctx, c.client, pkg.ClientObject(), c.dynamicCache); err != nil {
+		return err
+	}
+
 	// Remove finalizer from previous versions of PKO.
 	if err := controllers.RemoveFinalizer(
 		ctx, c.client, pkg.ClientObject(), loaderJobFinalizer); err != nil {
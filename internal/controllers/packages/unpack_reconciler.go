@@ -2,14 +2,17 @@ package packages
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/flowcontrol"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
@@ -18,6 +21,17 @@ import (
 	"package-operator.run/package-operator/internal/environment"
 	"package-operator.run/package-operator/internal/metrics"
 	"package-operator.run/package-operator/internal/packages/packagecontent"
+	"package-operator.run/package-operator/internal/packages/packageimport"
+	"package-operator.run/package-operator/internal/tracing"
+	"package-operator.run/package-operator/internal/utils"
+)
+
+const (
+	// Interval to check on package images being unpacked by the node container runtime.
+	unpackInProgressRequeueInterval = 5 * time.Second
+	// Interval to re-read packages loaded from ConfigMaps, Secrets or local directories,
+	// as changes to their contents don't trigger reconciles.
+	packageSourceRecheckInterval = time.Minute
 )
 
 // Loads/unpack and templates packages into an ObjectDeployment.
@@ -25,8 +39,13 @@ type unpackReconciler struct {
 	environment.Sink
 
 	imagePuller         imagePuller
+	sourceLoader        sourceLoader
 	packageDeployer     packageDeployer
 	packageLoadRecorder packageLoadRecorder
+	// Resolves the digests of package images referenced by tag, to report them in status.
+	// Only digests of images referenced by digest are reported, if nil.
+	digestResolver imageDigestResolver
+	digestPolicy   *controllers.ImageDigestPolicyChecker
 
 	backoff             *flowcontrol.Backoff
 	packageHashModifier *int32
@@ -39,6 +58,7 @@ type packageLoadRecorder interface {
 
 func newUnpackReconciler(
 	imagePuller imagePuller,
+	sourceLoader sourceLoader,
 	packageDeployer packageDeployer,
 	packageLoadRecorder packageLoadRecorder,
 	packageHashModifier *int32,
@@ -51,6 +71,7 @@ func newUnpackReconciler(
 
 	return &unpackReconciler{
 		imagePuller:         imagePuller,
+		sourceLoader:        sourceLoader,
 		packageDeployer:     packageDeployer,
 		packageLoadRecorder: packageLoadRecorder,
 		backoff:             cfg.GetBackoff(),
@@ -63,6 +84,17 @@ type imagePuller interface {
 		packagecontent.Files, error)
 }
 
+type imageDigestResolver interface {
+	Digest(ctx context.Context, image string) (string, error)
+}
+
+// Loads package contents from sources other than images.
+type sourceLoader interface {
+	LoadConfigMap(ctx context.Context, key client.ObjectKey) (packagecontent.Files, error)
+	LoadSecret(ctx context.Context, key client.ObjectKey) (packagecontent.Files, error)
+	LoadLocalPath(ctx context.Context, path string) (packagecontent.Files, error)
+}
+
 type packageDeployer interface {
 	Load(
 		ctx context.Context, pkg adapters.GenericPackageAccessor,
@@ -76,53 +108,194 @@ func (r *unpackReconciler) Reconcile(
 	// run back off garbage collection to prevent stale data building up.
 	defer r.backoff.GC()
 
+	if source := pkg.GetSource(); len(source.Image) == 0 {
+		return r.reconcileSource(ctx, pkg, source)
+	}
+
 	specHash := pkg.GetSpecHash(r.packageHashModifier)
 	if pkg.GetUnpackedHash() == specHash {
 		// We have already unpacked this package \o/
 		return res, nil
 	}
 
-	pullStart := time.Now()
-	log := logr.FromContextOrDiscard(ctx)
-	files, err := r.imagePuller.Pull(ctx, pkg.GetImage())
+	ctx, span := tracing.Start(ctx, "Unpack", tracing.ImageKey.String(pkg.GetImage()))
+	defer func() { tracing.End(span, err) }()
+
+	digest, err := r.imageDigest(ctx, pkg.GetImage())
 	if err != nil {
+		return r.unpackFailed(ctx, pkg, err, "checking image digest"), nil
+	}
+
+	pullStart := time.Now()
+	files, err := r.pull(ctx, pkg.GetImage())
+	if errors.Is(err, packageimport.ErrUnpackInProgress) {
 		meta.SetStatusCondition(
 			pkg.GetConditions(), metav1.Condition{
 				Type:               corev1alpha1.PackageUnpacked,
 				Status:             metav1.ConditionFalse,
-				Reason:             "ImagePullBackOff",
-				Message:            err.Error(),
+				Reason:             corev1alpha1.ReasonUnpacking,
+				Message:            "Waiting for the package image to be unpacked.",
 				ObservedGeneration: pkg.ClientObject().GetGeneration(),
 			})
-		backoffID := string(pkg.ClientObject().GetUID())
-		r.backoff.Next(backoffID, r.backoff.Clock.Now())
-		backoff := r.backoff.Get(backoffID)
-		log.Error(err, "pulling image", "backoff", backoff)
+		return ctrl.Result{RequeueAfter: unpackInProgressRequeueInterval}, nil
+	}
+	if err != nil {
+		return r.unpackFailed(ctx, pkg, err, "pulling image"), nil
+	}
+
+	if err := r.load(ctx, pkg, files); err != nil {
+		return res, fmt.Errorf("deploying package: %w", err)
+	}
+	r.unpacked(pkg, specHash, time.Since(pullStart))
+	pkg.SetImageDigest(digest)
+
+	return
+}
+
+var errImageDigestRequired = errors.New(
+	"the PackageOperatorConfig requires package images to be referenced by digest")
+
+// Returns the digest of the given image.
+// Images referenced by tag are rejected, if the PackageOperatorConfig requires digests.
+// Digests of tags that can't be resolved are not reported, as the image may still be pullable.
+func (r *unpackReconciler) imageDigest(ctx context.Context, image string) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", fmt.Errorf("parsing image: %w", err)
+	}
+	if digest, ok := ref.(name.Digest); ok {
+		return digest.DigestStr(), nil
+	}
+
+	required, err := r.digestPolicy.IsDigestRequired(ctx)
+	if err != nil {
+		return "", err
+	}
+	if required {
+		return "", errImageDigestRequired
+	}
+
+	if r.digestResolver == nil {
+		return "", nil
+	}
+	digest, err := r.digestResolver.Digest(ctx, image)
+	if err != nil {
+		logr.FromContextOrDiscard(ctx).Info("resolving image digest", "image", image, "error", err.Error())
+		return "", nil
+	}
+	return digest, nil
+}
+
+// Packages not sourced from an image are loaded on every reconcile,
+// but only deployed again when the loaded contents changed.
+func (r *unpackReconciler) reconcileSource(
+	ctx context.Context, pkg adapters.GenericPackageAccessor, source corev1alpha1.PackageSource,
+) (res ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "LoadSource")
+	defer func() { tracing.End(span, err) }()
 
-		return ctrl.Result{
-			RequeueAfter: backoff,
-		}, nil
+	loadStart := time.Now()
+	files, err := r.loadSource(ctx, pkg, source)
+	if err != nil {
+		return r.unpackFailed(ctx, pkg, err, "loading package source"), nil
 	}
 
-	if err := r.packageDeployer.Load(ctx, pkg, files, *r.GetEnvironment()); err != nil {
+	contentHash := utils.ComputeSHA256Hash(
+		[]interface{}{pkg.GetSpecHash(r.packageHashModifier), files}, nil)
+	if pkg.GetUnpackedHash() == contentHash {
+		return res, nil
+	}
+
+	if err := r.load(ctx, pkg, files); err != nil {
 		return res, fmt.Errorf("deploying package: %w", err)
 	}
+	r.unpacked(pkg, contentHash, time.Since(loadStart))
+
+	return res, nil
+}
+
+func (r *unpackReconciler) loadSource(
+	ctx context.Context, pkg adapters.GenericPackageAccessor, source corev1alpha1.PackageSource,
+) (packagecontent.Files, error) {
+	switch {
+	case source.ConfigMap != nil && source.Secret == nil && len(source.LocalPath) == 0:
+		key, err := sourceObjectKey(pkg, source.ConfigMap)
+		if err != nil {
+			return nil, err
+		}
+		return r.sourceLoader.LoadConfigMap(ctx, key)
+
+	case source.Secret != nil && source.ConfigMap == nil && len(source.LocalPath) == 0:
+		key, err := sourceObjectKey(pkg, source.Secret)
+		if err != nil {
+			return nil, err
+		}
+		return r.sourceLoader.LoadSecret(ctx, key)
+
+	case len(source.LocalPath) > 0 && source.ConfigMap == nil && source.Secret == nil:
+		return r.sourceLoader.LoadLocalPath(ctx, source.LocalPath)
+	}
+	return nil, errInvalidPackageSource
+}
+
+var errInvalidPackageSource = errors.New(
+	"exactly one of .spec.image, .spec.source.image, .spec.source.configMap, " +
+		".spec.source.secret or .spec.source.localPath has to be set")
+
+// Packages may only reference objects in their own namespace,
+// ClusterPackages have to specify the namespace.
+func sourceObjectKey(
+	pkg adapters.GenericPackageAccessor, ref *corev1alpha1.PackageSourceObjectReference,
+) (client.ObjectKey, error) {
+	pkgNamespace := pkg.ClientObject().GetNamespace()
+	switch {
+	case len(pkgNamespace) == 0 && len(ref.Namespace) == 0:
+		return client.ObjectKey{}, fmt.Errorf("package source %s requires a namespace", ref.Name)
+	case len(pkgNamespace) > 0 && len(ref.Namespace) > 0 && ref.Namespace != pkgNamespace:
+		return client.ObjectKey{}, fmt.Errorf(
+			"package source %s/%s must be in the namespace of the Package", ref.Namespace, ref.Name)
+	case len(pkgNamespace) > 0:
+		return client.ObjectKey{Name: ref.Name, Namespace: pkgNamespace}, nil
+	}
+	return client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, nil
+}
 
+func (r *unpackReconciler) unpackFailed(
+	ctx context.Context, pkg adapters.GenericPackageAccessor, err error, msg string,
+) ctrl.Result {
+	meta.SetStatusCondition(
+		pkg.GetConditions(), metav1.Condition{
+			Type:               corev1alpha1.PackageUnpacked,
+			Status:             metav1.ConditionFalse,
+			Reason:             corev1alpha1.ReasonUnpackFailure,
+			Message:            err.Error(),
+			ObservedGeneration: pkg.ClientObject().GetGeneration(),
+		})
+	backoffID := string(pkg.ClientObject().GetUID())
+	r.backoff.Next(backoffID, r.backoff.Clock.Now())
+	backoff := r.backoff.Get(backoffID)
+	logr.FromContextOrDiscard(ctx).Error(err, msg, "backoff", backoff)
+
+	return ctrl.Result{
+		RequeueAfter: backoff,
+	}
+}
+
+func (r *unpackReconciler) unpacked(
+	pkg adapters.GenericPackageAccessor, hash string, d time.Duration,
+) {
 	if r.packageLoadRecorder != nil {
-		r.packageLoadRecorder.RecordPackageLoadMetric(
-			pkg, time.Since(pullStart))
+		r.packageLoadRecorder.RecordPackageLoadMetric(pkg, d)
 	}
-	pkg.SetUnpackedHash(specHash)
+	pkg.SetUnpackedHash(hash)
 	meta.SetStatusCondition(
 		pkg.GetConditions(), metav1.Condition{
 			Type:               corev1alpha1.PackageUnpacked,
 			Status:             metav1.ConditionTrue,
-			Reason:             "UnpackSuccess",
+			Reason:             corev1alpha1.ReasonUnpackSuccess,
 			Message:            "Unpack job succeeded",
 			ObservedGeneration: pkg.ClientObject().GetGeneration(),
 		})
-
-	return
 }
 
 type unpackReconcilerConfig struct {
@@ -142,3 +315,25 @@ func (c *unpackReconcilerConfig) Default() {
 type unpackReconcilerOption interface {
 	ConfigureUnpackReconciler(c *unpackReconcilerConfig)
 }
+
+func (r *unpackReconciler) pull(ctx context.Context, image string) (files packagecontent.Files, err error) {
+	ctx, span := tracing.Start(ctx, "PullImage", tracing.ImageKey.String(image))
+	defer func() {
+		if errors.Is(err, packageimport.ErrUnpackInProgress) {
+			span.End()
+			return
+		}
+		tracing.End(span, err)
+	}()
+
+	return r.imagePuller.Pull(ctx, image)
+}
+
+func (r *unpackReconciler) load(
+	ctx context.Context, pkg adapters.GenericPackageAccessor, files packagecontent.Files,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "LoadPackage")
+	defer func() { tracing.End(span, err) }()
+
+	return r.packageDeployer.Load(ctx, pkg, files, *r.GetEnvironment())
+}
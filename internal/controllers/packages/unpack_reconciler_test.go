@@ -9,18 +9,22 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/adapters"
 	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/packages/packagecontent"
+	"package-operator.run/package-operator/internal/packages/packageimport"
+	"package-operator.run/package-operator/internal/testutil"
 )
 
 func TestUnpackReconciler(t *testing.T) {
 	ipm := &imagePullerMock{}
 	pd := &packageDeployerMock{}
-	ur := newUnpackReconciler(ipm, pd, nil, nil)
+	ur := newUnpackReconciler(ipm, nil, pd, nil, nil)
 
 	const image = "test123:latest"
 
@@ -58,7 +62,7 @@ func TestUnpackReconciler(t *testing.T) {
 func TestUnpackReconciler_noop(t *testing.T) {
 	ipm := &imagePullerMock{}
 	pd := &packageDeployerMock{}
-	ur := newUnpackReconciler(ipm, pd, nil, nil)
+	ur := newUnpackReconciler(ipm, nil, pd, nil, nil)
 
 	const image = "test123:latest"
 
@@ -81,7 +85,7 @@ var errTest = errors.New("test error")
 func TestUnpackReconciler_pullBackoff(t *testing.T) {
 	ipm := &imagePullerMock{}
 	pd := &packageDeployerMock{}
-	ur := newUnpackReconciler(ipm, pd, nil, nil)
+	ur := newUnpackReconciler(ipm, nil, pd, nil, nil)
 
 	const image = "test123:latest"
 
@@ -108,6 +112,220 @@ func TestUnpackReconciler_pullBackoff(t *testing.T) {
 			corev1alpha1.PackageUnpacked))
 }
 
+func TestUnpackReconciler_unpackInProgress(t *testing.T) {
+	ipm := &imagePullerMock{}
+	pd := &packageDeployerMock{}
+	ur := newUnpackReconciler(ipm, nil, pd, nil, nil)
+
+	ipm.
+		On("Pull", mock.Anything, mock.Anything).
+		Return(packagecontent.Files(nil), packageimport.ErrUnpackInProgress)
+
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			Spec: corev1alpha1.PackageSpec{
+				Image: "test123:latest",
+			},
+		},
+	}
+
+	ctx := context.Background()
+	res, err := ur.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	assert.Equal(t, unpackInProgressRequeueInterval, res.RequeueAfter)
+
+	cond := meta.FindStatusCondition(*pkg.GetConditions(), corev1alpha1.PackageUnpacked)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, "Unpacking", cond.Reason)
+	}
+}
+
+func TestUnpackReconciler_configMapSource(t *testing.T) {
+	slm := &sourceLoaderMock{}
+	pd := &packageDeployerMock{}
+	ur := newUnpackReconciler(nil, slm, pd, nil, nil)
+
+	f := packagecontent.Files{"manifest.yaml": []byte("manifest")}
+	slm.
+		On("LoadConfigMap", mock.Anything, client.ObjectKey{Name: "pkg-content", Namespace: "test"}).
+		Return(f, nil)
+	pd.
+		On("Load", mock.Anything, mock.Anything, f, mock.Anything).
+		Return(nil)
+
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+			Spec: corev1alpha1.PackageSpec{
+				Source: &corev1alpha1.PackageSource{
+					ConfigMap: &corev1alpha1.PackageSourceObjectReference{Name: "pkg-content"},
+				},
+			},
+		},
+	}
+	ur.SetEnvironment(&manifestsv1alpha1.PackageEnvironment{})
+
+	ctx := context.Background()
+	res, err := ur.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	assert.True(t,
+		meta.IsStatusConditionTrue(*pkg.GetConditions(),
+			corev1alpha1.PackageUnpacked))
+	assert.NotEmpty(t, pkg.GetUnpackedHash())
+
+	// Unchanged contents are not deployed again.
+	_, err = ur.Reconcile(ctx, pkg)
+	require.NoError(t, err)
+	pd.AssertNumberOfCalls(t, "Load", 1)
+}
+
+func TestUnpackReconciler_invalidSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		source    corev1alpha1.PackageSource
+		errMsg    string
+	}{
+		{
+			name: "multiple sources",
+			source: corev1alpha1.PackageSource{
+				ConfigMap: &corev1alpha1.PackageSourceObjectReference{Name: "pkg-content"},
+				LocalPath: "pkg",
+			},
+			errMsg: errInvalidPackageSource.Error(),
+		},
+		{
+			name:      "other namespace",
+			namespace: "test",
+			source: corev1alpha1.PackageSource{
+				Secret: &corev1alpha1.PackageSourceObjectReference{Name: "pkg-content", Namespace: "other"},
+			},
+			errMsg: "package source other/pkg-content must be in the namespace of the Package",
+		},
+		{
+			name: "cluster package without namespace",
+			source: corev1alpha1.PackageSource{
+				Secret: &corev1alpha1.PackageSourceObjectReference{Name: "pkg-content"},
+			},
+			errMsg: "package source pkg-content requires a namespace",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			ur := newUnpackReconciler(nil, &sourceLoaderMock{}, &packageDeployerMock{}, nil, nil)
+
+			pkg := &adapters.GenericPackage{
+				Package: corev1alpha1.Package{
+					ObjectMeta: metav1.ObjectMeta{Namespace: test.namespace},
+					Spec:       corev1alpha1.PackageSpec{Source: &test.source},
+				},
+			}
+
+			res, err := ur.Reconcile(context.Background(), pkg)
+			require.NoError(t, err)
+			assert.Equal(t, controllers.DefaultInitialBackoff, res.RequeueAfter)
+
+			cond := meta.FindStatusCondition(*pkg.GetConditions(), corev1alpha1.PackageUnpacked)
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, metav1.ConditionFalse, cond.Status)
+				assert.Equal(t, test.errMsg, cond.Message)
+			}
+		})
+	}
+}
+
+func TestUnpackReconciler_imageDigest(t *testing.T) {
+	const digest = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	tests := []struct {
+		name           string
+		image          string
+		expectedDigest string
+	}{
+		{
+			name:           "digest",
+			image:          "quay.io/example/test@" + digest,
+			expectedDigest: digest,
+		},
+		{
+			name:           "tag",
+			image:          "quay.io/example/test:v1.0.0",
+			expectedDigest: "sha256:123",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			ipm := &imagePullerMock{}
+			pd := &packageDeployerMock{}
+			dr := &imageDigestResolverMock{}
+			ur := newUnpackReconciler(ipm, nil, pd, nil, nil)
+			ur.digestResolver = dr
+
+			ipm.On("Pull", mock.Anything, test.image).Return(packagecontent.Files{}, nil)
+			pd.On("Load", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			dr.On("Digest", mock.Anything, "quay.io/example/test:v1.0.0").Return("sha256:123", nil)
+
+			pkg := &adapters.GenericPackage{
+				Package: corev1alpha1.Package{
+					Spec: corev1alpha1.PackageSpec{Image: test.image},
+				},
+			}
+			ur.SetEnvironment(&manifestsv1alpha1.PackageEnvironment{})
+			res, err := ur.Reconcile(context.Background(), pkg)
+			require.NoError(t, err)
+			assert.True(t, res.IsZero())
+			assert.Equal(t, test.expectedDigest, pkg.Status.ImageDigest)
+		})
+	}
+}
+
+func TestUnpackReconciler_imageDigestRequired(t *testing.T) {
+	ipm := &imagePullerMock{}
+	pd := &packageDeployerMock{}
+	c := testutil.NewClient()
+	ur := newUnpackReconciler(ipm, nil, pd, nil, nil)
+	ur.digestPolicy = controllers.NewImageDigestPolicyChecker(c)
+
+	c.On("Get", mock.Anything, mock.Anything,
+		mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			config := args.Get(2).(*corev1alpha1.PackageOperatorConfig)
+			config.Spec.RequireImageDigests = true
+		}).
+		Return(nil)
+
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			Spec: corev1alpha1.PackageSpec{Image: "quay.io/example/test:v1.0.0"},
+		},
+	}
+	res, err := ur.Reconcile(context.Background(), pkg)
+	require.NoError(t, err)
+	assert.Equal(t, controllers.DefaultInitialBackoff, res.RequeueAfter)
+
+	cond := meta.FindStatusCondition(*pkg.GetConditions(), corev1alpha1.PackageUnpacked)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, errImageDigestRequired.Error(), cond.Message)
+	}
+	ipm.AssertNotCalled(t, "Pull", mock.Anything, mock.Anything)
+}
+
+type imageDigestResolverMock struct {
+	mock.Mock
+}
+
+func (m *imageDigestResolverMock) Digest(ctx context.Context, image string) (string, error) {
+	args := m.Called(ctx, image)
+	return args.String(0), args.Error(1)
+}
+
 type imagePullerMock struct {
 	mock.Mock
 }
@@ -119,6 +337,31 @@ func (m *imagePullerMock) Pull(
 	return args.Get(0).(packagecontent.Files), args.Error(1)
 }
 
+type sourceLoaderMock struct {
+	mock.Mock
+}
+
+func (m *sourceLoaderMock) LoadConfigMap(
+	ctx context.Context, key client.ObjectKey,
+) (packagecontent.Files, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(packagecontent.Files), args.Error(1)
+}
+
+func (m *sourceLoaderMock) LoadSecret(
+	ctx context.Context, key client.ObjectKey,
+) (packagecontent.Files, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(packagecontent.Files), args.Error(1)
+}
+
+func (m *sourceLoaderMock) LoadLocalPath(
+	ctx context.Context, path string,
+) (packagecontent.Files, error) {
+	args := m.Called(ctx, path)
+	return args.Get(0).(packagecontent.Files), args.Error(1)
+}
+
 type packageDeployerMock struct {
 	mock.Mock
 }
@@ -0,0 +1,253 @@
+package packages
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/adapters"
+	"package-operator.run/package-operator/internal/controllers"
+)
+
+// Packages waiting for other packages of their channel to roll out, are checked again after this duration.
+const upgradePacingInterval = 30 * time.Second
+
+// Reports and applies new versions of a package
+// published to the PackageRepository channel of its upgrade policy.
+type upgradeReconciler struct {
+	client              client.Client
+	scheme              *runtime.Scheme
+	newPackageList      adapters.GenericPackageListFactory
+	packageHashModifier *int32
+	// Ignores upgrade policies, if PackageRepositories are not available.
+	disabled bool
+
+	// Serializes upgrade decisions of channels with maxUnavailable,
+	// so concurrent reconciles can't exceed the budget together.
+	upgradeLock sync.Mutex
+	// Generations of Packages upgraded by this reconciler, until they are observed in the cache.
+	// Protected by upgradeLock.
+	inFlight map[types.UID]int64
+}
+
+func (r *upgradeReconciler) Reconcile(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+) (ctrl.Result, error) {
+	policy := pkg.GetUpgradePolicy()
+	if policy == nil || r.disabled {
+		pkg.SetAvailableUpgrade("")
+		return ctrl.Result{}, nil
+	}
+
+	ref, err := name.ParseReference(pkg.GetImage())
+	if err != nil {
+		// Invalid image references are reported when unpacking.
+		return ctrl.Result{}, nil
+	}
+	repository := ref.Context().Name()
+
+	latest, err := r.latestChannelRelease(ctx, repository, policy.Channel)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	current := imageVersion(ref)
+	if latest == nil || current != nil && !latest.version.GreaterThan(current) {
+		pkg.SetAvailableUpgrade("")
+		return ctrl.Result{}, nil
+	}
+	pkg.SetAvailableUpgrade(latest.version.Original())
+
+	if !policy.Auto || current == nil || !r.isRolledOut(pkg) {
+		// Only upgrade from a known version after the current version rolled out successfully.
+		return ctrl.Result{}, nil
+	}
+
+	if latest.maxUnavailable != nil {
+		r.upgradeLock.Lock()
+		defer r.upgradeLock.Unlock()
+
+		ok, err := r.withinMaxUnavailable(ctx, repository, policy.Channel, latest.maxUnavailable)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !ok {
+			logr.FromContextOrDiscard(ctx).Info("waiting for other packages of the channel to roll out",
+				"channel", policy.Channel)
+			return ctrl.Result{RequeueAfter: upgradePacingInterval}, nil
+		}
+	}
+
+	image := repository + ":" + latest.version.Original()
+	if len(latest.digest) > 0 {
+		image += "@" + latest.digest
+	}
+	logr.FromContextOrDiscard(ctx).Info("upgrading package", "from", pkg.GetImage(), "to", image)
+	pkg.SetImage(image)
+	if err := r.client.Update(ctx, pkg.ClientObject()); err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating Package image: %w", err)
+	}
+	if latest.maxUnavailable != nil {
+		r.recordInFlight(pkg.ClientObject())
+	}
+	return ctrl.Result{}, nil
+}
+
+// Remembers an upgrade until the cache reports the updated generation.
+// Must be called with upgradeLock held.
+func (r *upgradeReconciler) recordInFlight(obj client.Object) {
+	if r.inFlight == nil {
+		r.inFlight = map[types.UID]int64{}
+	}
+	r.inFlight[obj.GetUID()] = obj.GetGeneration()
+}
+
+// Returns true when the package was upgraded, but the cache still reports an older generation.
+// Must be called with upgradeLock held.
+func (r *upgradeReconciler) isInFlight(obj client.Object) bool {
+	generation, ok := r.inFlight[obj.GetUID()]
+	if !ok {
+		return false
+	}
+	if obj.GetGeneration() >= generation {
+		// Cache is up-to-date, the rollout is tracked by the package conditions.
+		delete(r.inFlight, obj.GetUID())
+		return false
+	}
+	return true
+}
+
+// Latest version of a package published to a PackageRepository channel.
+type channelRelease struct {
+	version        *semver.Version
+	digest         string
+	maxUnavailable *intstr.IntOrString
+}
+
+// Returns the latest version of the given repository in the channel of any PackageRepository.
+// Returns nil, if no PackageRepository lists a version in this channel.
+func (r *upgradeReconciler) latestChannelRelease(
+	ctx context.Context, repository, channel string,
+) (*channelRelease, error) {
+	repoList := &corev1alpha1.PackageRepositoryList{}
+	if err := r.client.List(ctx, repoList); err != nil {
+		return nil, fmt.Errorf("listing PackageRepositories: %w", err)
+	}
+
+	var latest *channelRelease
+	for _, repo := range repoList.Items {
+		for _, pkg := range repo.Status.Packages {
+			pkgRepository, err := name.NewRepository(pkg.Repository)
+			if err != nil || pkgRepository.Name() != repository {
+				continue
+			}
+			for _, ch := range pkg.Channels {
+				if ch.Name != channel || len(ch.LatestVersion) == 0 {
+					continue
+				}
+				v, err := semver.NewVersion(ch.LatestVersion)
+				if err != nil {
+					continue
+				}
+				if latest == nil || v.GreaterThan(latest.version) {
+					latest = &channelRelease{
+						version:        v,
+						digest:         ch.LatestDigest,
+						maxUnavailable: channelMaxUnavailable(repo.Spec.Channels, channel),
+					}
+				}
+			}
+		}
+	}
+	return latest, nil
+}
+
+func channelMaxUnavailable(
+	channels []corev1alpha1.PackageRepositoryChannel, channel string,
+) *intstr.IntOrString {
+	for _, ch := range channels {
+		if ch.Name == channel {
+			return ch.MaxUnavailable
+		}
+	}
+	return nil
+}
+
+// Returns true when fewer packages of the same kind, following the given repository and channel,
+// are unavailable or still rolling out, than allowed by maxUnavailable.
+// Must be called with upgradeLock held.
+func (r *upgradeReconciler) withinMaxUnavailable(
+	ctx context.Context, repository, channel string, maxUnavailable *intstr.IntOrString,
+) (bool, error) {
+	pkgList := r.newPackageList(r.scheme)
+	if err := r.client.List(ctx, pkgList.ClientObjectList()); err != nil {
+		return false, fmt.Errorf("listing packages: %w", err)
+	}
+
+	var total, unavailable int
+	for _, pkg := range pkgList.GetItems() {
+		policy := pkg.GetUpgradePolicy()
+		if policy == nil || policy.Channel != channel {
+			continue
+		}
+		ref, err := name.ParseReference(pkg.GetImage())
+		if err != nil || ref.Context().Name() != repository {
+			continue
+		}
+		total++
+		if !r.isRolledOut(pkg) || r.isInFlight(pkg.ClientObject()) {
+			unavailable++
+		}
+	}
+
+	// Round up, so percentages always allow at least one package to upgrade.
+	limit, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, total, true)
+	if err != nil {
+		return false, fmt.Errorf("invalid maxUnavailable of channel %s: %w", channel, err)
+	}
+	return unavailable < limit, nil
+}
+
+// Returns the version of the image tag or nil, if the image is not tagged with a semantic version.
+// Images pinned to a digest report the version of their tag, e.g. "repo:v1.0.0@sha256:...".
+func imageVersion(ref name.Reference) *semver.Version {
+	var tagStr string
+	switch r := ref.(type) {
+	case name.Tag:
+		tagStr = r.TagStr()
+	case name.Digest:
+		base, _, _ := strings.Cut(r.String(), "@")
+		tag, err := name.NewTag(base)
+		if err != nil {
+			return nil
+		}
+		tagStr = tag.TagStr()
+	default:
+		return nil
+	}
+	v, err := semver.NewVersion(tagStr)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// Returns true when the current spec of the package is unpacked and Available.
+func (r *upgradeReconciler) isRolledOut(pkg adapters.GenericPackageAccessor) bool {
+	generation := pkg.ClientObject().GetGeneration()
+	conds := *pkg.GetConditions()
+	return pkg.GetUnpackedHash() == pkg.GetSpecHash(r.packageHashModifier) &&
+		controllers.IsCurrentStatusConditionTrue(conds, corev1alpha1.PackageAvailable, generation) &&
+		!controllers.IsCurrentStatusConditionTrue(conds, corev1alpha1.PackageProgressing, generation)
+}
@@ -0,0 +1,405 @@
+package packages
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/adapters"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestUpgradeReconciler(t *testing.T) {
+	t.Parallel()
+
+	repos := []corev1alpha1.PackageRepository{
+		{
+			Status: corev1alpha1.PackageRepositoryStatus{
+				Packages: []corev1alpha1.RepositoryPackage{
+					{
+						Repository: "quay.io/example/other",
+						Channels: []corev1alpha1.RepositoryPackageChannel{
+							{Name: "stable", LatestVersion: "v9.0.0"},
+						},
+					},
+					{
+						Repository: "quay.io/example/test-stub",
+						Channels: []corev1alpha1.RepositoryPackageChannel{
+							{Name: "stable", LatestVersion: "v1.2.0"},
+							{Name: "candidate", LatestVersion: "v1.3.0-rc.1"},
+						},
+					},
+					{
+						Repository: "quay.io/example/pinned",
+						Channels: []corev1alpha1.RepositoryPackageChannel{
+							{Name: "stable", LatestVersion: "v2.0.0", LatestDigest: testDigest},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		image             string
+		policy            *corev1alpha1.PackageUpgradePolicy
+		rolledOut         bool
+		expectedImage     string
+		expectedAvailable string
+	}{
+		{
+			name:          "no policy",
+			image:         "quay.io/example/test-stub:v1.0.0",
+			expectedImage: "quay.io/example/test-stub:v1.0.0",
+		},
+		{
+			name:              "report only",
+			image:             "quay.io/example/test-stub:v1.0.0",
+			policy:            &corev1alpha1.PackageUpgradePolicy{Channel: "stable"},
+			rolledOut:         true,
+			expectedImage:     "quay.io/example/test-stub:v1.0.0",
+			expectedAvailable: "v1.2.0",
+		},
+		{
+			name:              "auto",
+			image:             "quay.io/example/test-stub:v1.0.0",
+			policy:            &corev1alpha1.PackageUpgradePolicy{Channel: "candidate", Auto: true},
+			rolledOut:         true,
+			expectedImage:     "quay.io/example/test-stub:v1.3.0-rc.1",
+			expectedAvailable: "v1.3.0-rc.1",
+		},
+		{
+			name:              "auto waits for rollout",
+			image:             "quay.io/example/test-stub:v1.0.0",
+			policy:            &corev1alpha1.PackageUpgradePolicy{Channel: "stable", Auto: true},
+			expectedImage:     "quay.io/example/test-stub:v1.0.0",
+			expectedAvailable: "v1.2.0",
+		},
+		{
+			name:          "up to date",
+			image:         "quay.io/example/test-stub:v1.2.0",
+			policy:        &corev1alpha1.PackageUpgradePolicy{Channel: "stable", Auto: true},
+			rolledOut:     true,
+			expectedImage: "quay.io/example/test-stub:v1.2.0",
+		},
+		{
+			name:              "digest is not upgraded",
+			image:             "quay.io/example/test-stub@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			policy:            &corev1alpha1.PackageUpgradePolicy{Channel: "stable", Auto: true},
+			rolledOut:         true,
+			expectedImage:     "quay.io/example/test-stub@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			expectedAvailable: "v1.2.0",
+		},
+		{
+			name:              "auto pins digest",
+			image:             "quay.io/example/pinned:v1.0.0",
+			policy:            &corev1alpha1.PackageUpgradePolicy{Channel: "stable", Auto: true},
+			rolledOut:         true,
+			expectedImage:     "quay.io/example/pinned:v2.0.0@" + testDigest,
+			expectedAvailable: "v2.0.0",
+		},
+		{
+			name:              "pinned digest is upgraded",
+			image:             "quay.io/example/pinned:v1.0.0@sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			policy:            &corev1alpha1.PackageUpgradePolicy{Channel: "stable", Auto: true},
+			rolledOut:         true,
+			expectedImage:     "quay.io/example/pinned:v2.0.0@" + testDigest,
+			expectedAvailable: "v2.0.0",
+		},
+		{
+			name:          "pinned digest up to date",
+			image:         "quay.io/example/pinned:v2.0.0@" + testDigest,
+			policy:        &corev1alpha1.PackageUpgradePolicy{Channel: "stable", Auto: true},
+			rolledOut:     true,
+			expectedImage: "quay.io/example/pinned:v2.0.0@" + testDigest,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			c.On("List", mock.Anything, mock.AnythingOfType("*v1alpha1.PackageRepositoryList"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*corev1alpha1.PackageRepositoryList).Items = repos
+				}).
+				Return(nil)
+			c.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			pkg := &adapters.GenericPackage{
+				Package: corev1alpha1.Package{
+					ObjectMeta: metav1.ObjectMeta{Generation: 3},
+					Spec: corev1alpha1.PackageSpec{
+						Image:         test.image,
+						UpgradePolicy: test.policy,
+					},
+					Status: corev1alpha1.PackageStatus{
+						AvailableUpgrade: "stale",
+					},
+				},
+			}
+			if test.rolledOut {
+				pkg.Status.UnpackedHash = pkg.GetSpecHash(nil)
+				pkg.Status.Conditions = []metav1.Condition{{
+					Type:               corev1alpha1.PackageAvailable,
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 3,
+				}}
+			}
+
+			r := &upgradeReconciler{client: c}
+			res, err := r.Reconcile(context.Background(), pkg)
+			require.NoError(t, err)
+			assert.True(t, res.IsZero())
+
+			assert.Equal(t, test.expectedImage, pkg.Spec.Image)
+			assert.Equal(t, test.expectedAvailable, pkg.Status.AvailableUpgrade)
+			if test.expectedImage != test.image {
+				c.AssertCalled(t, "Update", mock.Anything, &pkg.Package, mock.Anything)
+			} else {
+				c.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+const testDigest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+
+func TestUpgradeReconciler_maxUnavailable(t *testing.T) {
+	t.Parallel()
+
+	maxUnavailable := intstr.FromString("50%")
+	repos := []corev1alpha1.PackageRepository{
+		{
+			Spec: corev1alpha1.PackageRepositorySpec{
+				Channels: []corev1alpha1.PackageRepositoryChannel{
+					{Name: "stable", Versions: ">= 1.0.0", MaxUnavailable: &maxUnavailable},
+				},
+			},
+			Status: corev1alpha1.PackageRepositoryStatus{
+				Packages: []corev1alpha1.RepositoryPackage{
+					{
+						Repository: "quay.io/example/test-stub",
+						Channels: []corev1alpha1.RepositoryPackageChannel{
+							{Name: "stable", LatestVersion: "v1.2.0"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	newPkg := func(name, image string, rolledOut bool) *adapters.GenericPackage {
+		pkg := &adapters.GenericPackage{
+			Package: corev1alpha1.Package{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Generation: 1},
+				Spec: corev1alpha1.PackageSpec{
+					Image:         image,
+					UpgradePolicy: &corev1alpha1.PackageUpgradePolicy{Channel: "stable", Auto: true},
+				},
+			},
+		}
+		if rolledOut {
+			pkg.Status.UnpackedHash = pkg.GetSpecHash(nil)
+			pkg.Status.Conditions = []metav1.Condition{{
+				Type:               corev1alpha1.PackageAvailable,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 1,
+			}}
+		}
+		return pkg
+	}
+
+	tests := []struct {
+		name            string
+		others          []*adapters.GenericPackage
+		expectedUpgrade bool
+	}{
+		{
+			name: "within budget",
+			others: []*adapters.GenericPackage{
+				newPkg("a", "quay.io/example/test-stub:v1.2.0", false),
+				newPkg("b", "quay.io/example/test-stub:v1.0.0", true),
+				newPkg("c", "quay.io/example/test-stub:v1.0.0", true),
+			},
+			expectedUpgrade: true,
+		},
+		{
+			name: "budget exhausted",
+			others: []*adapters.GenericPackage{
+				newPkg("a", "quay.io/example/test-stub:v1.2.0", false),
+				newPkg("b", "quay.io/example/test-stub:v1.2.0", false),
+				newPkg("c", "quay.io/example/test-stub:v1.0.0", true),
+			},
+		},
+		{
+			name: "other repositories are ignored",
+			others: []*adapters.GenericPackage{
+				newPkg("a", "quay.io/example/other:v1.0.0", false),
+				newPkg("b", "quay.io/example/other:v1.0.0", false),
+				newPkg("c", "quay.io/example/other:v1.0.0", false),
+			},
+			expectedUpgrade: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg := newPkg("test", "quay.io/example/test-stub:v1.0.0", true)
+			items := []corev1alpha1.Package{pkg.Package}
+			for _, other := range test.others {
+				items = append(items, other.Package)
+			}
+
+			c := testutil.NewClient()
+			c.On("List", mock.Anything, mock.AnythingOfType("*v1alpha1.PackageRepositoryList"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*corev1alpha1.PackageRepositoryList).Items = repos
+				}).
+				Return(nil)
+			c.On("List", mock.Anything, mock.AnythingOfType("*v1alpha1.PackageList"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*corev1alpha1.PackageList).Items = items
+				}).
+				Return(nil)
+			c.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			r := &upgradeReconciler{
+				client:         c,
+				scheme:         testutil.NewTestSchemeWithCoreV1Alpha1(),
+				newPackageList: adapters.NewGenericPackageList,
+			}
+			res, err := r.Reconcile(context.Background(), pkg)
+			require.NoError(t, err)
+
+			if test.expectedUpgrade {
+				assert.True(t, res.IsZero())
+				assert.Equal(t, "quay.io/example/test-stub:v1.2.0", pkg.Spec.Image)
+				c.AssertCalled(t, "Update", mock.Anything, &pkg.Package, mock.Anything)
+			} else {
+				assert.Equal(t, upgradePacingInterval, res.RequeueAfter)
+				assert.Equal(t, "quay.io/example/test-stub:v1.0.0", pkg.Spec.Image)
+				c.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUpgradeReconciler_maxUnavailable_staleCache(t *testing.T) {
+	t.Parallel()
+
+	maxUnavailable := intstr.FromInt(1)
+	repos := []corev1alpha1.PackageRepository{
+		{
+			Spec: corev1alpha1.PackageRepositorySpec{
+				Channels: []corev1alpha1.PackageRepositoryChannel{
+					{Name: "stable", Versions: ">= 1.0.0", MaxUnavailable: &maxUnavailable},
+				},
+			},
+			Status: corev1alpha1.PackageRepositoryStatus{
+				Packages: []corev1alpha1.RepositoryPackage{
+					{
+						Repository: "quay.io/example/test-stub",
+						Channels: []corev1alpha1.RepositoryPackageChannel{
+							{Name: "stable", LatestVersion: "v1.2.0"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	newPkg := func(name string) *adapters.GenericPackage {
+		pkg := &adapters.GenericPackage{
+			Package: corev1alpha1.Package{
+				ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name), Generation: 1},
+				Spec: corev1alpha1.PackageSpec{
+					Image:         "quay.io/example/test-stub:v1.0.0",
+					UpgradePolicy: &corev1alpha1.PackageUpgradePolicy{Channel: "stable", Auto: true},
+				},
+			},
+		}
+		pkg.Status.UnpackedHash = pkg.GetSpecHash(nil)
+		pkg.Status.Conditions = []metav1.Condition{{
+			Type:               corev1alpha1.PackageAvailable,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: 1,
+		}}
+		return pkg
+	}
+	a, b := newPkg("a"), newPkg("b")
+	// The cache has not observed any upgrade yet.
+	cached := []corev1alpha1.Package{a.Package, b.Package}
+
+	c := testutil.NewClient()
+	c.On("List", mock.Anything, mock.AnythingOfType("*v1alpha1.PackageRepositoryList"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*corev1alpha1.PackageRepositoryList).Items = repos
+		}).
+		Return(nil)
+	c.On("List", mock.Anything, mock.AnythingOfType("*v1alpha1.PackageList"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*corev1alpha1.PackageList).Items = cached
+		}).
+		Return(nil)
+	c.On("Update", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			obj := args.Get(1).(*corev1alpha1.Package)
+			obj.Generation++
+		}).
+		Return(nil)
+
+	r := &upgradeReconciler{
+		client:         c,
+		scheme:         testutil.NewTestSchemeWithCoreV1Alpha1(),
+		newPackageList: adapters.NewGenericPackageList,
+	}
+	ctx := context.Background()
+
+	res, err := r.Reconcile(ctx, a)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	assert.Equal(t, "quay.io/example/test-stub:v1.2.0", a.Spec.Image)
+
+	// The upgrade of a is still in flight.
+	res, err = r.Reconcile(ctx, b)
+	require.NoError(t, err)
+	assert.Equal(t, upgradePacingInterval, res.RequeueAfter)
+	assert.Equal(t, "quay.io/example/test-stub:v1.0.0", b.Spec.Image)
+	c.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestUpgradeReconciler_disabled(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			Spec: corev1alpha1.PackageSpec{
+				Image:         "quay.io/example/test-stub:v1.0.0",
+				UpgradePolicy: &corev1alpha1.PackageUpgradePolicy{Channel: "stable", Auto: true},
+			},
+			Status: corev1alpha1.PackageStatus{
+				AvailableUpgrade: "stale",
+			},
+		},
+	}
+
+	r := &upgradeReconciler{client: c, disabled: true}
+	res, err := r.Reconcile(context.Background(), pkg)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	assert.Empty(t, pkg.Status.AvailableUpgrade)
+	c.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+}
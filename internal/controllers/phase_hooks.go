@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+var jobGroupKind = schema.GroupKind{Group: "batch", Kind: "Job"}
+
+// HookNotAJobError is returned for hook objects that are not batch/v1 Jobs.
+type HookNotAJobError struct {
+	CommonObjectPhaseError
+}
+
+func (e HookNotAJobError) Error() string {
+	return fmt.Sprintf("hook %s %s must be a batch/v1 Job", e.ObjectGVK, e.ObjectKey)
+}
+
+// ErrorClass implements ClassifiedError.
+func (e HookNotAJobError) ErrorClass() ErrorClass {
+	return ErrorClassUserFixRequired
+}
+
+// HookFailedError is returned, when the Job of a hook failed after exhausting its backoffLimit.
+type HookFailedError struct {
+	CommonObjectPhaseError
+	Reason, Message string
+}
+
+func (e HookFailedError) Error() string {
+	return fmt.Sprintf("hook Job %s failed: %s: %s", e.ObjectKey, e.Reason, e.Message)
+}
+
+// ErrorClass implements ClassifiedError.
+// Jobs report failure only after exhausting their backoffLimit, so retrying won't help.
+func (e HookFailedError) ErrorClass() ErrorClass {
+	return ErrorClassTerminal
+}
+
+// Reconciles the hook objects of a phase.
+// Returns done, when the Jobs of all hooks of the given type completed for the revision of the owner.
+func (r *PhaseReconciler) reconcileHooks(
+	ctx context.Context, owner PhaseObjectOwner,
+	phase corev1alpha1.ObjectSetTemplatePhase,
+	desiredObjects []unstructured.Unstructured,
+	previous []PreviousObjectSet,
+	hook corev1alpha1.ObjectSetObjectHook,
+	rec *recordingProbe,
+) (actualObjects []client.Object, done bool, err error) {
+	done = true
+	for i, phaseObject := range phase.Objects {
+		if phaseObject.Hook != hook {
+			continue
+		}
+		desiredObj := &desiredObjects[i]
+
+		var actualObj *unstructured.Unstructured
+		if owner.IsPaused() {
+			// Just report the state of the hook.
+			actualObj, err = r.reconcilePhaseObject(ctx, owner, phaseObject, desiredObj, previous, nil)
+		} else {
+			actualObj, err = r.reconcileHook(ctx, owner, desiredObj, previous)
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", phaseObject, err)
+		}
+		if actualObj == nil {
+			// Not created while paused or previous revision is still being replaced.
+			rec.recordFailure(desiredObj, "hook has not started", 0)
+			done = false
+			continue
+		}
+		actualObjects = append(actualObjects, actualObj)
+
+		completed, err := hookCompleted(actualObj)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", phaseObject, err)
+		}
+		if !completed {
+			rec.recordFailure(actualObj, "hook has not completed", 0)
+			done = false
+		}
+	}
+	return actualObjects, done, nil
+}
+
+// Ensures the hook Job for the revision of the owner exists.
+// Returns nil, while the Job of a previous revision is being replaced.
+func (r *PhaseReconciler) reconcileHook(
+	ctx context.Context, owner PhaseObjectOwner,
+	desiredObj *unstructured.Unstructured, previous []PreviousObjectSet,
+) (*unstructured.Unstructured, error) {
+	if desiredObj.GroupVersionKind().GroupKind() != jobGroupKind {
+		return nil, HookNotAJobError{
+			CommonObjectPhaseError: CommonObjectPhaseError{
+				OwnerKey:  client.ObjectKeyFromObject(owner.ClientObject()),
+				OwnerGVK:  owner.ClientObject().GetObjectKind().GroupVersionKind(),
+				ObjectKey: client.ObjectKeyFromObject(desiredObj),
+				ObjectGVK: desiredObj.GroupVersionKind(),
+			},
+		}
+	}
+
+	if err := r.ownerStrategy.SetControllerReference(owner.ClientObject(), desiredObj); err != nil {
+		return nil, err
+	}
+	if err := r.dynamicCache.Watch(ctx, owner.ClientObject(), desiredObj); err != nil {
+		return nil, fmt.Errorf("watching new resource: %w", err)
+	}
+
+	currentObj := desiredObj.DeepCopy()
+	err := r.dynamicCache.Get(ctx, client.ObjectKeyFromObject(desiredObj), currentObj)
+	if errors.IsNotFound(err) {
+		if err := r.writer.Create(ctx, desiredObj); err != nil {
+			return nil, fmt.Errorf("creating: %w", err)
+		}
+		return desiredObj, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting %s: %w", desiredObj.GroupVersionKind(), err)
+	}
+
+	if r.ownerStrategy.IsController(owner.ClientObject(), currentObj) {
+		return currentObj, nil
+	}
+
+	needsAdoption, err := r.adoptionChecker.Check(ctx, owner, currentObj, previous)
+	if err != nil {
+		return nil, err
+	}
+	if !needsAdoption {
+		// Hook of a newer revision, nothing left to do for this revision.
+		return currentObj, nil
+	}
+	if !currentObj.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	// Jobs are immutable and run only once,
+	// so the hook Job of the previous revision is replaced to run the hook again.
+	log := logr.FromContextOrDiscard(ctx)
+	log.Info("replacing hook Job of previous revision",
+		"ObjectKey", client.ObjectKeyFromObject(currentObj))
+	if err := r.writer.Delete(ctx, currentObj,
+		client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("deleting hook Job of previous revision: %w", err)
+	}
+	return nil, nil
+}
+
+// Checks the status conditions of a hook Job.
+// Jobs report the Failed condition only after exhausting their backoffLimit.
+func hookCompleted(job *unstructured.Unstructured) (bool, error) {
+	conditions, _, err := unstructured.NestedSlice(job.Object, "status", "conditions")
+	if err != nil {
+		return false, fmt.Errorf("reading Job conditions: %w", err)
+	}
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["status"] != string(metav1.ConditionTrue) {
+			continue
+		}
+		switch cond["type"] {
+		case "Complete":
+			return true, nil
+		case "Failed":
+			reason, _ := cond["reason"].(string)
+			message, _ := cond["message"].(string)
+			return false, HookFailedError{
+				CommonObjectPhaseError: CommonObjectPhaseError{
+					ObjectKey: client.ObjectKeyFromObject(job),
+					ObjectGVK: job.GroupVersionKind(),
+				},
+				Reason:  reason,
+				Message: message,
+			}
+		}
+	}
+	return false, nil
+}
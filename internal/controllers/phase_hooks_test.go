@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/ownerhandling"
+	"package-operator.run/package-operator/internal/preflight"
+	"package-operator.run/package-operator/internal/probing"
+	"package-operator.run/package-operator/internal/testutil/faultinjection"
+)
+
+func TestPhaseReconciler_ReconcilePhase_hooks(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	pcm := &preflightCheckerMock{}
+	pcm.
+		On("Check", mock.Anything, mock.Anything, mock.Anything).
+		Return([]preflight.Violation{}, nil)
+	ownerStrategy := ownerhandling.NewNative(scheme)
+	pr := NewPhaseReconciler(
+		scheme, c, faultinjection.Watchless(c), c,
+		ownerStrategy, pcm)
+
+	newOwner := func(revision int64) (*phaseObjectOwnerMock, client.Object) {
+		name := fmt.Sprintf("rev%d", revision)
+		ownerObj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "test", UID: types.UID(name + "-uid"),
+		}}
+		owner := &phaseObjectOwnerMock{}
+		owner.On("ClientObject").Return(ownerObj)
+		owner.On("GetRevision").Return(revision)
+		owner.On("IsPaused").Return(false)
+		return owner, ownerObj
+	}
+	newObject := func(kind, name string, hook corev1alpha1.ObjectSetObjectHook) corev1alpha1.ObjectSetObject {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		if kind == "Job" {
+			obj.SetAPIVersion("batch/v1")
+		}
+		obj.SetKind(kind)
+		obj.SetName(name)
+		return corev1alpha1.ObjectSetObject{Object: obj, Hook: hook}
+	}
+	newPhase := func() corev1alpha1.ObjectSetTemplatePhase {
+		return corev1alpha1.ObjectSetTemplatePhase{
+			Name: "deploy",
+			Objects: []corev1alpha1.ObjectSetObject{
+				newObject("Job", "notify", corev1alpha1.ObjectSetObjectHookPost),
+				newObject("ConfigMap", "config", ""),
+				newObject("Job", "migrate", corev1alpha1.ObjectSetObjectHookPre),
+			},
+		}
+	}
+	setJobCondition := func(name, condType string) {
+		job := &batchv1.Job{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: name, Namespace: "test"}, job))
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:   batchv1.JobConditionType(condType),
+			Status: corev1.ConditionTrue,
+			Reason: "BackoffLimitExceeded",
+		}}
+		require.NoError(t, c.Update(context.Background(), job))
+	}
+	exists := func(obj client.Object, name string) bool {
+		err := c.Get(context.Background(), client.ObjectKey{Name: name, Namespace: "test"}, obj)
+		return err == nil
+	}
+
+	ctx := context.Background()
+	probe, err := probing.ParseProbes(ctx, nil)
+	require.NoError(t, err)
+	owner, ownerObj := newOwner(1)
+
+	// Pre hook has to complete first.
+	actual, res, err := pr.ReconcilePhase(ctx, owner, newPhase(), probe, nil)
+	require.NoError(t, err)
+	assert.Len(t, actual, 1)
+	assert.False(t, res.IsZero())
+	assert.False(t, exists(&corev1.ConfigMap{}, "config"))
+
+	// Post hook runs once the rest of the phase is available.
+	setJobCondition("migrate", "Complete")
+	actual, res, err = pr.ReconcilePhase(ctx, owner, newPhase(), probe, nil)
+	require.NoError(t, err)
+	assert.Len(t, actual, 3)
+	assert.False(t, res.IsZero())
+	assert.True(t, exists(&corev1.ConfigMap{}, "config"))
+	assert.True(t, exists(&batchv1.Job{}, "notify"))
+
+	setJobCondition("notify", "Complete")
+	_, res, err = pr.ReconcilePhase(ctx, owner, newPhase(), probe, nil)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+
+	// Hooks run again for the next revision.
+	nextOwner, nextOwnerObj := newOwner(2)
+	previous := []PreviousObjectSet{newPreviousObjectSetMockWithoutRemotes(ownerObj)}
+	_, res, err = pr.ReconcilePhase(ctx, nextOwner, newPhase(), probe, previous)
+	require.NoError(t, err)
+	assert.False(t, res.IsZero())
+	assert.False(t, exists(&batchv1.Job{}, "migrate"), "hook Job of previous revision must be replaced")
+
+	_, _, err = pr.ReconcilePhase(ctx, nextOwner, newPhase(), probe, previous)
+	require.NoError(t, err)
+	job := &batchv1.Job{}
+	require.True(t, exists(job, "migrate"))
+	assert.True(t, ownerStrategy.IsController(nextOwnerObj, job))
+
+	// Failed hooks fail the phase.
+	setJobCondition("migrate", "Failed")
+	_, _, err = pr.ReconcilePhase(ctx, nextOwner, newPhase(), probe, previous)
+	var hookErr HookFailedError
+	if assert.True(t, errors.As(err, &hookErr), "expected HookFailedError, got: %v", err) {
+		assert.Equal(t, "BackoffLimitExceeded", hookErr.Reason)
+	}
+}
+
+func TestPhaseReconciler_ReconcilePhase_hookNotAJob(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	pcm := &preflightCheckerMock{}
+	pcm.
+		On("Check", mock.Anything, mock.Anything, mock.Anything).
+		Return([]preflight.Violation{}, nil)
+	pr := NewPhaseReconciler(
+		testScheme, c, faultinjection.Watchless(c), c,
+		ownerhandling.NewNative(testScheme), pcm)
+
+	owner := &phaseObjectOwnerMock{}
+	owner.On("ClientObject").Return(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "owner", Namespace: "test", UID: "owner-uid",
+	}})
+	owner.On("GetRevision").Return(int64(1))
+	owner.On("IsPaused").Return(false)
+
+	obj := unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName("test")
+
+	ctx := context.Background()
+	probe, err := probing.ParseProbes(ctx, nil)
+	require.NoError(t, err)
+	_, _, err = pr.ReconcilePhase(ctx, owner, corev1alpha1.ObjectSetTemplatePhase{
+		Name: "test",
+		Objects: []corev1alpha1.ObjectSetObject{
+			{Object: obj, Hook: corev1alpha1.ObjectSetObjectHookPre},
+		},
+	}, probe, nil)
+	var notAJob HookNotAJobError
+	assert.True(t, errors.As(err, &notAJob), "expected HookNotAJobError, got: %v", err)
+}
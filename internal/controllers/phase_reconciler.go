@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/codes"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -22,8 +25,11 @@ import (
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/package-operator/internal/dynamiccache"
 	"package-operator.run/package-operator/internal/preflight"
 	"package-operator.run/package-operator/internal/probing"
+	"package-operator.run/package-operator/internal/tracing"
+	"package-operator.run/package-operator/internal/version"
 )
 
 // PhaseReconciler reconciles objects within a ObjectSet phase.
@@ -32,12 +38,17 @@ type PhaseReconciler struct {
 	// just specify a writer, because we don't want to ever read from another source than
 	// the dynamic cache that is managed to hold the objects we are reconciling.
 	writer           client.Writer
-	dynamicCache     dynamicCache
+	dynamicCache     PhaseCache
 	uncachedClient   client.Reader
 	ownerStrategy    ownerStrategy
-	adoptionChecker  adoptionChecker
-	patcher          patcher
+	adoptionChecker  AdoptionChecker
+	patcher          Patcher
 	preflightChecker preflightChecker
+	restMapper       meta.RESTMapper
+	mutators         []ObjectMutator
+	identity         OperatorIdentity
+	// Version of the running manager, recorded on all objects.
+	managerVersion string
 }
 
 type ownerStrategy interface {
@@ -49,27 +60,79 @@ type ownerStrategy interface {
 	OwnerPatch(owner metav1.Object) ([]byte, error)
 }
 
-type adoptionChecker interface {
+// AdoptionChecker decides whether an existing object is taken over by the owner of a phase.
+// Check is called for every existing object in a phase, that is about to be reconciled.
+// Returning true makes the owner the new controller of the object.
+// Returning false keeps the current controller, e.g. when the owner already is the controller
+// or the object belongs to a newer revision.
+// Returning an error, like ObjectNotOwnedByPreviousRevisionError, stops reconciliation of the phase.
+// Implementations must not modify the given object.
+type AdoptionChecker interface {
 	Check(
 		ctx context.Context, owner PhaseObjectOwner, obj client.Object,
 		previous []PreviousObjectSet,
 	) (needsAdoption bool, err error)
 }
 
-type patcher interface {
+// Patcher brings an existing object into the desired state.
+// desiredObj is the object as specified in the phase, currentObj the object as found on the cluster
+// and updatedObj a deep copy of currentObj with owner references already updated.
+// Implementations must persist the changes via the API and update updatedObj with the result.
+// currentObj and desiredObj must not be modified.
+type Patcher interface {
 	Patch(
 		ctx context.Context,
 		desiredObj, currentObj, updatedObj *unstructured.Unstructured,
 	) error
 }
 
-type dynamicCache interface {
+// PhaseCache is a watch-backed reader for objects in a phase target.
+// Watch must be called for an object type before reading it.
+type PhaseCache interface {
 	client.Reader
 	Watch(
 		ctx context.Context, owner client.Object, obj runtime.Object,
+		opts ...dynamiccache.WatchOption,
 	) error
 }
 
+// PhaseActuator abstracts the target that phase objects are actuated against.
+// The default implementation talks to a Kubernetes API server,
+// but phase classes may implement it against other backends.
+// Implementations should pass RunPhaseActuatorConformance in phaseactuatortest.
+type PhaseActuator interface {
+	// Writer persists object changes in the phase target.
+	Writer() client.Writer
+	// Cache reads objects from the phase target via watches.
+	Cache() PhaseCache
+	// UncachedReader reads objects directly from the phase target,
+	// to find objects that are not yet part of the cache.
+	UncachedReader() client.Reader
+}
+
+// NewKubernetesPhaseActuator returns a PhaseActuator for a Kubernetes API server.
+func NewKubernetesPhaseActuator(
+	writer client.Writer,
+	dynamicCache PhaseCache,
+	uncachedClient client.Reader,
+) PhaseActuator {
+	return &kubernetesPhaseActuator{
+		writer:         writer,
+		dynamicCache:   dynamicCache,
+		uncachedClient: uncachedClient,
+	}
+}
+
+type kubernetesPhaseActuator struct {
+	writer         client.Writer
+	dynamicCache   PhaseCache
+	uncachedClient client.Reader
+}
+
+func (a *kubernetesPhaseActuator) Writer() client.Writer         { return a.writer }
+func (a *kubernetesPhaseActuator) Cache() PhaseCache             { return a.dynamicCache }
+func (a *kubernetesPhaseActuator) UncachedReader() client.Reader { return a.uncachedClient }
+
 type preflightChecker interface {
 	Check(
 		ctx context.Context, owner, obj client.Object,
@@ -79,20 +142,56 @@ type preflightChecker interface {
 func NewPhaseReconciler(
 	scheme *runtime.Scheme,
 	writer client.Writer,
-	dynamicCache dynamicCache,
+	dynamicCache PhaseCache,
 	uncachedClient client.Reader,
 	ownerStrategy ownerStrategy,
 	preflightChecker preflightChecker,
+	opts ...PhaseReconcilerOption,
+) *PhaseReconciler {
+	return NewPhaseReconcilerWithActuator(
+		scheme,
+		NewKubernetesPhaseActuator(writer, dynamicCache, uncachedClient),
+		ownerStrategy, preflightChecker, opts...,
+	)
+}
+
+// NewPhaseReconcilerWithActuator creates a PhaseReconciler actuating objects against the given PhaseActuator.
+func NewPhaseReconcilerWithActuator(
+	scheme *runtime.Scheme,
+	actuator PhaseActuator,
+	ownerStrategy ownerStrategy,
+	preflightChecker preflightChecker,
+	opts ...PhaseReconcilerOption,
 ) *PhaseReconciler {
+	writer := actuator.Writer()
+
+	var cfg PhaseReconcilerConfig
+	cfg.Option(opts...)
+	if cfg.AdoptionChecker == nil {
+		cfg.AdoptionChecker = &defaultAdoptionChecker{
+			scheme:        scheme,
+			ownerStrategy: ownerStrategy,
+			policy:        cfg.AdoptionPolicy,
+			identity:      cfg.OperatorIdentity,
+		}
+	}
+	if cfg.Patcher == nil {
+		cfg.Patcher = NewDefaultPatcher(writer, cfg.OperatorIdentity)
+	}
+
 	return &PhaseReconciler{
 		scheme:           scheme,
 		writer:           writer,
-		dynamicCache:     dynamicCache,
-		uncachedClient:   uncachedClient,
+		dynamicCache:     actuator.Cache(),
+		uncachedClient:   actuator.UncachedReader(),
 		ownerStrategy:    ownerStrategy,
-		adoptionChecker:  &defaultAdoptionChecker{ownerStrategy: ownerStrategy, scheme: scheme},
-		patcher:          &defaultPatcher{writer: writer},
+		adoptionChecker:  cfg.AdoptionChecker,
+		patcher:          cfg.Patcher,
 		preflightChecker: preflightChecker,
+		restMapper:       cfg.RESTMapper,
+		mutators:         cfg.ObjectMutators,
+		identity:         cfg.OperatorIdentity,
+		managerVersion:   version.Get().ApplicationVersion,
 	}
 }
 
@@ -103,6 +202,12 @@ type PhaseObjectOwner interface {
 	IsPaused() bool
 }
 
+// PhaseObjectDiffRecorder is optionally implemented by PhaseObjectOwners,
+// to record the changes that would be applied to objects while paused.
+type PhaseObjectDiffRecorder interface {
+	RecordObjectDiff(diff corev1alpha1.ObjectSetObjectDiff)
+}
+
 func newRecordingProbe(name string, probe probing.Prober) recordingProbe {
 	return recordingProbe{
 		name:  name,
@@ -111,37 +216,74 @@ func newRecordingProbe(name string, probe probing.Prober) recordingProbe {
 }
 
 type recordingProbe struct {
-	name     string
-	probe    probing.Prober
-	failures []string
+	name         string
+	probe        probing.Prober
+	failures     []string
+	recheckAfter time.Duration
 }
 
-func (p *recordingProbe) Probe(obj *unstructured.Unstructured) {
-	ok, msg := p.probe.Probe(obj)
+func (p *recordingProbe) Probe(ctx context.Context, obj *unstructured.Unstructured) {
+	_, span := tracing.Start(ctx, "Probe", tracing.ObjectAttributes(obj)...)
+	defer span.End()
+
+	ok, msg, recheckAfter := probing.ProbeWithRecheck(p.probe, obj)
 	if ok {
+		// Objects with tolerated failures have to be probed again.
+		p.recheck(recheckAfter)
 		return
 	}
+	span.SetStatus(codes.Error, msg)
+	p.recordFailure(obj, msg, recheckAfter)
+}
+
+// RecordApplyFailure reports an object that failed to apply like an object failing its probes,
+// so the remaining objects of the phase can still be reconciled.
+func (p *recordingProbe) RecordApplyFailure(
+	obj *unstructured.Unstructured, err error, retryAfter time.Duration,
+) {
+	p.recordFailure(obj, fmt.Sprintf("apply failed: %v", err), retryAfter)
+}
+
+func (p *recordingProbe) recordFailure(
+	obj *unstructured.Unstructured, msg string, recheckAfter time.Duration,
+) {
+	p.recheck(recheckAfter)
 
 	gvk := obj.GroupVersionKind()
+	if source := obj.GetAnnotations()[corev1alpha1.ObjectSourceFileAnnotation]; len(source) > 0 {
+		msg = fmt.Sprintf("%s (from %s)", msg, source)
+	}
 	msg = fmt.Sprintf("%s %s %s/%s: %s", gvk.Group, gvk.Kind, obj.GetNamespace(), obj.GetName(), msg)
 
 	p.failures = append(p.failures, msg)
 }
 
+// Remembers the shortest suggested recheck interval.
+func (p *recordingProbe) recheck(recheckAfter time.Duration) {
+	if recheckAfter > 0 && (p.recheckAfter == 0 || recheckAfter < p.recheckAfter) {
+		p.recheckAfter = recheckAfter
+	}
+}
+
 func (p *recordingProbe) Result() ProbingResult {
 	if len(p.failures) == 0 {
-		return ProbingResult{}
+		return ProbingResult{RecheckAfter: p.recheckAfter}
 	}
 
 	return ProbingResult{
 		PhaseName:    p.name,
 		FailedProbes: p.failures,
+		RecheckAfter: p.recheckAfter,
 	}
 }
 
 type ProbingResult struct {
 	PhaseName    string
 	FailedProbes []string
+	// Interval after which objects should be probed again, as suggested by the probes.
+	// Also set without failures, while probes tolerate failures of objects.
+	// 0, if no probe made a suggestion.
+	RecheckAfter time.Duration
 }
 
 func (e *ProbingResult) IsZero() bool {
@@ -165,6 +307,9 @@ func (r *PhaseReconciler) ReconcilePhase(
 	phase corev1alpha1.ObjectSetTemplatePhase,
 	probe probing.Prober, previous []PreviousObjectSet,
 ) (actualObjects []client.Object, res ProbingResult, err error) {
+	ctx, span := tracing.Start(ctx, "ReconcilePhase", tracing.PhaseKey.String(phase.Name))
+	defer func() { tracing.End(span, err) }()
+
 	desiredObjects := make([]unstructured.Unstructured, len(phase.Objects))
 	for i, phaseObject := range phase.Objects {
 		desired, err := r.desiredObject(ctx, owner, phaseObject)
@@ -174,28 +319,58 @@ func (r *PhaseReconciler) ReconcilePhase(
 		desiredObjects[i] = *desired
 	}
 
-	violations, err := preflight.CheckAllInPhase(
-		ctx, r.preflightChecker, owner.ClientObject(), phase, desiredObjects)
-	if err != nil {
-		return nil, res, err
+	if phase.Atomic && !owner.IsPaused() {
+		defer func() {
+			if !IsFatalPhaseError(err) {
+				return
+			}
+			if rollbackErr := r.rollbackPhase(ctx, owner, desiredObjects); rollbackErr != nil {
+				err = fmt.Errorf("%w, rolling back phase: %v", err, rollbackErr)
+			}
+		}()
 	}
-	if len(violations) > 0 {
-		return nil, res, &preflight.Error{
-			Violations: violations,
-		}
+
+	if err := r.preflightPhase(ctx, owner, phase, desiredObjects); err != nil {
+		return nil, res, err
 	}
 
 	rec := newRecordingProbe(phase.Name, probe)
 
+	// Pre hooks have to complete, before the other objects of the phase are applied.
+	actualObjects, preHooksDone, err := r.reconcileHooks(
+		ctx, owner, phase, desiredObjects, previous, corev1alpha1.ObjectSetObjectHookPre, &rec)
+	if err != nil {
+		return nil, res, err
+	}
+	if !preHooksDone {
+		return actualObjects, rec.Result(), nil
+	}
+
 	for i, phaseObject := range phase.Objects {
+		if len(phaseObject.Hook) > 0 {
+			continue
+		}
 		desiredObj := &desiredObjects[i]
-		actualObj, err := r.reconcilePhaseObject(ctx, owner, phaseObject, desiredObj, previous)
+		applyPolicy := objectApplyPolicy(phase, phaseObject)
+		actualObj, err := r.reconcilePhaseObject(ctx, owner, phaseObject, desiredObj, previous, applyPolicy)
+		if applyPolicy != nil && IsObjectApplyFailure(err) {
+			// Don't let a single object block the whole phase,
+			// report it as failed and continue with the next object.
+			rec.RecordApplyFailure(desiredObj, err, applyRetryBackoff(applyPolicy))
+			continue
+		}
 		if err != nil {
 			return nil, res, fmt.Errorf("%s: %w", phaseObject, err)
 		}
+		if actualObj == nil {
+			// Object does not exist yet and is not created while paused.
+			rec.recordFailure(desiredObj, "object not found", 0)
+			continue
+		}
 		actualObjects = append(actualObjects, actualObj)
 
-		rec.Probe(actualObj)
+		rec.Probe(ctx, actualObj)
+		r.probeCRD(&rec, actualObj)
 	}
 
 	for _, obj := range phase.ExternalObjects {
@@ -204,12 +379,144 @@ func (r *PhaseReconciler) ReconcilePhase(
 			return nil, res, fmt.Errorf("%s: %w", obj, err)
 		}
 
-		rec.Probe(observedObj)
+		rec.Probe(ctx, observedObj)
 	}
 
+	if probingResult := rec.Result(); !probingResult.IsZero() {
+		return actualObjects, probingResult, nil
+	}
+
+	// Post hooks run once all other objects of the phase are available.
+	postHookObjects, _, err := r.reconcileHooks(
+		ctx, owner, phase, desiredObjects, previous, corev1alpha1.ObjectSetObjectHookPost, &rec)
+	if err != nil {
+		return nil, res, err
+	}
+	actualObjects = append(actualObjects, postHookObjects...)
+
 	return actualObjects, rec.Result(), nil
 }
 
+var (
+	crdGroupKind = schema.GroupKind{
+		Group: "apiextensions.k8s.io",
+		Kind:  "CustomResourceDefinition",
+	}
+	crdEstablishedProbe = probing.NewConditionProbe("Established", "True")
+)
+
+// Time to wait before checking again, if an established CRD is not yet discoverable.
+const crdDiscoveryRecheckInterval = 5 * time.Second
+
+// probeCRD holds back later phases until CustomResourceDefinitions of this phase are established
+// and known to the RESTMapper, so instances of these CRDs can be created.
+func (r *PhaseReconciler) probeCRD(rec *recordingProbe, obj *unstructured.Unstructured) {
+	if obj.GroupVersionKind().GroupKind() != crdGroupKind {
+		return
+	}
+	if ok, msg := crdEstablishedProbe.Probe(obj); !ok {
+		rec.recordFailure(obj, msg, 0)
+		return
+	}
+	if r.restMapper == nil {
+		return
+	}
+
+	group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+	_, err := r.restMapper.RESTMapping(schema.GroupKind{Group: group, Kind: kind})
+	if err == nil {
+		return
+	}
+	if meta.IsNoMatchError(err) {
+		if rm, ok := r.restMapper.(meta.ResettableRESTMapper); ok {
+			rm.Reset()
+		}
+	}
+	rec.recordFailure(obj, fmt.Sprintf("not yet discoverable: %v", err), crdDiscoveryRecheckInterval)
+}
+
+// Time to wait before retrying objects that failed to apply, if not specified.
+const defaultApplyRetryBackoff = 10 * time.Second
+
+// Returns the apply policy of the object, falling back to the policy of the phase.
+func objectApplyPolicy(
+	phase corev1alpha1.ObjectSetTemplatePhase, phaseObject corev1alpha1.ObjectSetObject,
+) *corev1alpha1.ObjectSetApplyPolicy {
+	if phaseObject.ApplyPolicy != nil {
+		return phaseObject.ApplyPolicy
+	}
+	return phase.ApplyPolicy
+}
+
+func applyRetryBackoff(policy *corev1alpha1.ObjectSetApplyPolicy) time.Duration {
+	if policy.RetryBackoff == nil {
+		return defaultApplyRetryBackoff
+	}
+	return policy.RetryBackoff.Duration
+}
+
+// Deletes objects of the phase that were created by the owner,
+// so a failing atomic phase does not stay partially applied.
+// Objects adopted from previous revisions are kept.
+func (r *PhaseReconciler) rollbackPhase(
+	ctx context.Context, owner PhaseObjectOwner,
+	desiredObjects []unstructured.Unstructured,
+) error {
+	log := logr.FromContextOrDiscard(ctx)
+	ownerObj := owner.ClientObject()
+	ownerCreation := ownerObj.GetCreationTimestamp()
+
+	for i := range desiredObjects {
+		obj := desiredObjects[i].DeepCopy()
+		// Not every object might be watched yet, when rolling back.
+		err := r.uncachedClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("getting %s: %w", obj.GroupVersionKind(), err)
+		}
+
+		if !r.ownerStrategy.IsController(ownerObj, obj) {
+			continue
+		}
+		if creation := obj.GetCreationTimestamp(); creation.Before(&ownerCreation) {
+			// Object existed before the owner and was adopted.
+			continue
+		}
+
+		log.Info("rolling back object of atomic phase",
+			"ObjectKey", client.ObjectKeyFromObject(obj),
+			"ObjectGVK", obj.GroupVersionKind())
+		if err := r.writer.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting %s: %w", obj.GroupVersionKind(), err)
+		}
+	}
+	return nil
+}
+
+func (r *PhaseReconciler) preflightPhase(
+	ctx context.Context, owner PhaseObjectOwner,
+	phase corev1alpha1.ObjectSetTemplatePhase,
+	desiredObjects []unstructured.Unstructured,
+) (err error) {
+	ctx, span := tracing.Start(ctx, "Preflight", tracing.PhaseKey.String(phase.Name))
+	defer func() { tracing.End(span, err) }()
+
+	violations, err := preflight.CheckAllInPhase(
+		ctx, r.preflightChecker, owner.ClientObject(), phase, desiredObjects)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &preflight.Error{
+			Violations: violations,
+		}
+	}
+	return nil
+}
+
 func (r *PhaseReconciler) observeExternalObject(
 	ctx context.Context,
 	owner PhaseObjectOwner,
@@ -242,7 +549,7 @@ func (r *PhaseReconciler) observeExternalObject(
 		}
 
 		// Update object to ensure it is part of our cache and we get events to reconcile.
-		if observed, err = AddDynamicCacheLabel(ctx, r.writer, observed); err != nil {
+		if observed, err = AddDynamicCacheLabel(ctx, r.writer, r.identity, observed); err != nil {
 			return nil, fmt.Errorf("adding cache label: %w", err)
 		}
 	} else if err != nil {
@@ -265,6 +572,9 @@ func (r *PhaseReconciler) observeExternalObject(
 	if err := mapConditions(ctx, owner, extObj.ConditionMappings, observed); err != nil {
 		return nil, err
 	}
+	if err := mapFields(owner, extObj.FieldMappings, observed); err != nil {
+		return nil, err
+	}
 
 	return observed, nil
 }
@@ -336,8 +646,9 @@ func (r *PhaseReconciler) teardownPhaseObject(
 		return false, fmt.Errorf("getting object for teardown: %w", err)
 	}
 
-	if !r.ownerStrategy.IsController(owner.ClientObject(), currentObj) {
-		// this object is owned by someone else
+	if !r.ownerStrategy.IsController(owner.ClientObject(), currentObj) ||
+		phaseObject.DeletionPolicy == corev1alpha1.ObjectSetObjectDeletionPolicyOrphan {
+		// this object is owned by someone else or should be orphaned,
 		// so we don't have to delete it for cleanup,
 		// but we still want to remove ourselves as owner.
 		r.ownerStrategy.RemoveOwner(owner.ClientObject(), currentObj)
@@ -347,7 +658,21 @@ func (r *PhaseReconciler) teardownPhaseObject(
 		return true, nil
 	}
 
-	err = r.writer.Delete(ctx, currentObj)
+	if shouldScaleDown(owner, phaseObject, currentObj) {
+		// Kept on the cluster owned by the archived revision,
+		// so the object is garbage collected together with the revision.
+		if err := r.scaleDown(ctx, currentObj); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	var deleteOpts []client.DeleteOption
+	if len(phaseObject.Hook) > 0 {
+		// Jobs orphan their Pods by default.
+		deleteOpts = append(deleteOpts, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	}
+	err = r.writer.Delete(ctx, currentObj, deleteOpts...)
 	if err != nil && errors.IsNotFound(err) {
 		return true, nil
 	}
@@ -389,7 +714,7 @@ func (r *PhaseReconciler) teardownExternalObject(
 			return false, fmt.Errorf("retrieving external object: %w", err)
 		}
 
-		if _, err = RemoveDynamicCacheLabel(ctx, r.writer, observed); err != nil {
+		if _, err = RemoveDynamicCacheLabel(ctx, r.writer, r.identity, observed); err != nil {
 			return false, fmt.Errorf("removing cache label: %w", err)
 		}
 	} else if err != nil {
@@ -409,7 +734,11 @@ func (r *PhaseReconciler) reconcilePhaseObject(
 	phaseObject corev1alpha1.ObjectSetObject,
 	desiredObj *unstructured.Unstructured,
 	previous []PreviousObjectSet,
+	applyPolicy *corev1alpha1.ObjectSetApplyPolicy,
 ) (actualObj *unstructured.Unstructured, err error) {
+	ctx, span := tracing.Start(ctx, "ApplyObject", tracing.ObjectAttributes(desiredObj)...)
+	defer func() { tracing.End(span, err) }()
+
 	// Set owner reference
 	if err := r.ownerStrategy.SetControllerReference(owner.ClientObject(), desiredObj); err != nil {
 		return nil, err
@@ -423,23 +752,140 @@ func (r *PhaseReconciler) reconcilePhaseObject(
 
 	if owner.IsPaused() {
 		actualObj = desiredObj.DeepCopy()
-		if err := r.dynamicCache.Get(ctx, client.ObjectKeyFromObject(desiredObj), actualObj); err != nil {
+		err := r.dynamicCache.Get(ctx, client.ObjectKeyFromObject(desiredObj), actualObj)
+		if errors.IsNotFound(err) {
+			// Objects not created by Package Operator are missing the cache label,
+			// e.g. when observing an existing cluster.
+			err = r.uncachedClient.Get(ctx, client.ObjectKeyFromObject(desiredObj), actualObj)
+		}
+		if recorder, ok := owner.(PhaseObjectDiffRecorder); ok {
+			if errors.IsNotFound(err) {
+				recorder.RecordObjectDiff(corev1alpha1.ObjectSetObjectDiff{
+					Object: objectReference(desiredObj),
+					Action: corev1alpha1.ObjectSetObjectDiffActionCreate,
+				})
+				return nil, nil
+			}
+			if err == nil && !phaseObject.CreateOnly {
+				if err := r.recordObjectDiff(ctx, recorder, desiredObj, actualObj); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err != nil {
 			return nil, fmt.Errorf("looking up object while paused: %w", err)
 		}
 		return actualObj, nil
 	}
 
-	if actualObj, err = r.reconcileObject(ctx, owner, desiredObj, previous); err != nil {
+	applyCtx := ctx
+	if applyPolicy != nil && applyPolicy.Timeout != nil {
+		var cancel context.CancelFunc
+		applyCtx, cancel = context.WithTimeout(ctx, applyPolicy.Timeout.Duration)
+		defer cancel()
+	}
+	if actualObj, err = r.reconcileObject(applyCtx, owner, desiredObj, previous, phaseObject.CreateOnly); err != nil {
 		return nil, err
 	}
 
 	if err = mapConditions(ctx, owner, phaseObject.ConditionMappings, actualObj); err != nil {
 		return nil, err
 	}
+	if err = mapFields(owner, phaseObject.FieldMappings, actualObj); err != nil {
+		return nil, err
+	}
 
 	return actualObj, nil
 }
 
+// Records the fields a server-side dry-run apply of the desired object would change.
+func (r *PhaseReconciler) recordObjectDiff(
+	ctx context.Context, recorder PhaseObjectDiffRecorder,
+	desiredObj, actualObj *unstructured.Unstructured,
+) error {
+	patch := applyPatchObject(desiredObj.DeepCopy(), actualObj)
+	patch.SetResourceVersion(actualObj.GetResourceVersion())
+	objectPatch, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("creating patch: %w", err)
+	}
+
+	dryRunObj := actualObj.DeepCopy()
+	if err := r.writer.Patch(ctx, dryRunObj, client.RawPatch(
+		types.ApplyPatchType, objectPatch),
+		client.FieldOwner(r.identity.FieldOwner()),
+		client.ForceOwnership,
+		client.DryRunAll,
+	); err != nil {
+		return fmt.Errorf("dry-run patching object: %w", err)
+	}
+
+	fields := diffFields(actualObj.Object, dryRunObj.Object, "")
+	if len(fields) == 0 {
+		return nil
+	}
+	sort.Strings(fields)
+	recorder.RecordObjectDiff(corev1alpha1.ObjectSetObjectDiff{
+		Object: objectReference(actualObj),
+		Action: corev1alpha1.ObjectSetObjectDiffActionUpdate,
+		Fields: fields,
+	})
+	return nil
+}
+
+// Fields managed by the API server, that are not part of a diff.
+var diffIgnoredFields = map[string]struct{}{
+	"status":                     {},
+	"metadata.managedFields":     {},
+	"metadata.resourceVersion":   {},
+	"metadata.generation":        {},
+	"metadata.creationTimestamp": {},
+	"metadata.uid":               {},
+}
+
+// Returns the paths of all fields that differ between a and b.
+// Lists are compared as a whole.
+func diffFields(a, b map[string]interface{}, prefix string) []string {
+	var fields []string
+	keys := map[string]struct{}{}
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	for k := range keys {
+		path := k
+		if len(prefix) > 0 {
+			path = prefix + "." + k
+		}
+		if _, ignored := diffIgnoredFields[path]; ignored {
+			continue
+		}
+
+		aMap, aIsMap := a[k].(map[string]interface{})
+		bMap, bIsMap := b[k].(map[string]interface{})
+		if aIsMap && bIsMap {
+			fields = append(fields, diffFields(aMap, bMap, path)...)
+			continue
+		}
+		if !equality.Semantic.DeepEqual(a[k], b[k]) {
+			fields = append(fields, path)
+		}
+	}
+	return fields
+}
+
+func objectReference(obj *unstructured.Unstructured) corev1alpha1.ControlledObjectReference {
+	gvk := obj.GroupVersionKind()
+	return corev1alpha1.ControlledObjectReference{
+		Kind:      gvk.Kind,
+		Group:     gvk.Group,
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}
+}
+
 func mapConditions(
 	_ context.Context, owner PhaseObjectOwner,
 	conditionMappings []corev1alpha1.ConditionMapping,
@@ -499,10 +945,13 @@ func mapConditions(
 // Builds an object as specified in a phase.
 // Includes system labels, namespace and owner reference.
 func (r *PhaseReconciler) desiredObject(
-	_ context.Context, owner PhaseObjectOwner,
+	ctx context.Context, owner PhaseObjectOwner,
 	phaseObject corev1alpha1.ObjectSetObject,
 ) (desiredObj *unstructured.Unstructured, err error) {
-	desiredObj = &phaseObject.Object
+	desiredObj, err = SubstituteOwnerMetadata(owner.ClientObject(), &phaseObject.Object)
+	if err != nil {
+		return nil, fmt.Errorf("substituting owner metadata: %w", err)
+	}
 
 	// Default namespace to the owners namespace
 	if len(desiredObj.GetNamespace()) == 0 {
@@ -515,7 +964,7 @@ func (r *PhaseReconciler) desiredObject(
 	if labels == nil {
 		labels = map[string]string{}
 	}
-	labels[DynamicCacheLabel] = "True"
+	labels[r.identity.DynamicCacheLabel()] = "True"
 
 	if ownerLabels := owner.ClientObject().GetLabels(); ownerLabels != nil {
 		if pkgLabel, ok := ownerLabels[manifestsv1alpha1.PackageLabel]; ok {
@@ -527,8 +976,16 @@ func (r *PhaseReconciler) desiredObject(
 	}
 
 	desiredObj.SetLabels(labels)
+	setArgoCDTracking(owner.ClientObject(), desiredObj)
+
+	setObjectRevision(r.identity, desiredObj, owner.GetRevision())
+	SetManagerVersion(desiredObj, r.managerVersion)
 
-	setObjectRevision(desiredObj, owner.GetRevision())
+	for _, mutator := range r.mutators {
+		if err := mutator.Mutate(ctx, owner, desiredObj); err != nil {
+			return nil, fmt.Errorf("mutating object: %w", err)
+		}
+	}
 
 	return desiredObj, nil
 }
@@ -549,6 +1006,12 @@ func (e ObjectNotOwnedByPreviousRevisionError) Error() string {
 	return fmt.Sprintf("refusing adoption, object %s %s not owned by previous revision", e.ObjectGVK, e.ObjectKey)
 }
 
+// ErrorClass implements ClassifiedError.
+// The object has to be removed or its owner declared as previous revision.
+func (e ObjectNotOwnedByPreviousRevisionError) ErrorClass() ErrorClass {
+	return ErrorClassUserFixRequired
+}
+
 // This error is returned when a Phase tries to adopt an object
 // where the revision number is not increasing.
 type RevisionCollisionError struct {
@@ -559,9 +1022,17 @@ func (e RevisionCollisionError) Error() string {
 	return fmt.Sprintf("refusing adoption, revision collision on %s %s", e.ObjectGVK, e.ObjectKey)
 }
 
+// ErrorClass implements ClassifiedError.
+func (e RevisionCollisionError) ErrorClass() ErrorClass {
+	return ErrorClassTerminal
+}
+
+// Creates or updates the object.
+// Existing createOnly objects are only adopted, but never patched.
 func (r *PhaseReconciler) reconcileObject(
 	ctx context.Context, owner PhaseObjectOwner,
 	desiredObj *unstructured.Unstructured, previous []PreviousObjectSet,
+	createOnly bool,
 ) (actualObj *unstructured.Unstructured, err error) {
 	objKey := client.ObjectKeyFromObject(desiredObj)
 	currentObj := desiredObj.DeepCopy()
@@ -599,7 +1070,7 @@ func (r *PhaseReconciler) reconcileObject(
 			"OwnerGVK", owner.ClientObject().GetObjectKind().GroupVersionKind(),
 			"ObjectKey", client.ObjectKeyFromObject(desiredObj),
 			"ObjectGVK", desiredObj.GetObjectKind().GroupVersionKind())
-		setObjectRevision(updatedObj, owner.GetRevision())
+		setObjectRevision(r.identity, updatedObj, owner.GetRevision())
 		r.ownerStrategy.ReleaseController(updatedObj)
 		if err := r.ownerStrategy.SetControllerReference(owner.ClientObject(), updatedObj); err != nil {
 			return nil, err
@@ -617,8 +1088,8 @@ func (r *PhaseReconciler) reconcileObject(
 	}
 
 	// Only issue updates when this instance is already or will be controlled by this instance.
-	if r.ownerStrategy.IsController(owner.ClientObject(), updatedObj) {
-		if err := r.patcher.Patch(ctx, desiredObj, currentObj, updatedObj); err != nil {
+	if !createOnly && r.ownerStrategy.IsController(owner.ClientObject(), updatedObj) {
+		if err := r.patch(ctx, owner, desiredObj, currentObj, updatedObj); err != nil {
 			return nil, err
 		}
 	}
@@ -626,8 +1097,49 @@ func (r *PhaseReconciler) reconcileObject(
 	return updatedObj, nil
 }
 
+// Patches the object and records fields taken over from other field managers,
+// if supported by both the Patcher and the owner.
+func (r *PhaseReconciler) patch(
+	ctx context.Context, owner PhaseObjectOwner,
+	desiredObj, currentObj, updatedObj *unstructured.Unstructured,
+) error {
+	patcher, patcherOK := r.patcher.(ConflictReportingPatcher)
+	recorder, recorderOK := owner.(PhaseObjectConflictRecorder)
+	if !patcherOK || !recorderOK {
+		return r.patcher.Patch(ctx, desiredObj, currentObj, updatedObj)
+	}
+
+	conflicts, err := patcher.PatchReportingConflicts(ctx, desiredObj, currentObj, updatedObj)
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	for _, c := range conflicts {
+		logr.FromContextOrDiscard(ctx).Info("took over fields from other field manager",
+			"ObjectKey", client.ObjectKeyFromObject(updatedObj),
+			"ObjectGVK", updatedObj.GroupVersionKind(),
+			"Manager", c.Manager, "Fields", c.Fields)
+		recorder.RecordObjectConflict(corev1alpha1.ObjectSetObjectConflict{
+			Object:           objectReference(updatedObj),
+			Manager:          c.Manager,
+			Fields:           c.Fields,
+			LastObservedTime: now,
+		})
+	}
+	return nil
+}
+
 type defaultPatcher struct {
-	writer client.Writer
+	writer   client.Writer
+	identity OperatorIdentity
+}
+
+// NewDefaultPatcher returns the Patcher used by default.
+// It updates objects via server-side apply, if they differ from the desired state
+// or if fields previously applied were removed from the desired state.
+// Objects are applied with the field manager of the given operator identity.
+func NewDefaultPatcher(writer client.Writer, identity OperatorIdentity) Patcher {
+	return &defaultPatcher{writer: writer, identity: identity}
 }
 
 func (p *defaultPatcher) Patch(
@@ -637,6 +1149,64 @@ func (p *defaultPatcher) Patch(
 	// deepCopy of currentObj, already updated for owner handling
 	updatedObj *unstructured.Unstructured,
 ) error {
+	_, err := p.PatchReportingConflicts(ctx, desiredObj, currentObj, updatedObj)
+	return err
+}
+
+// PatchReportingConflicts applies the object without ForceOwnership first,
+// to find out about fields changed by other field managers,
+// and takes over these fields by applying again with ForceOwnership.
+// Fields managed by ArgoCD are never taken over from objects tracked by an ArgoCD Application.
+func (p *defaultPatcher) PatchReportingConflicts(
+	ctx context.Context,
+	desiredObj, currentObj, updatedObj *unstructured.Unstructured,
+) ([]ApplyConflict, error) {
+	patch := applyPatchObject(desiredObj, updatedObj)
+
+	base := updatedObj.DeepCopy()
+	unstructured.RemoveNestedField(base.Object, "status")
+
+	// Check for if an update is even needed.
+	// DeepDerivative does not catch fields removed from the desired object,
+	// so the fields last applied are compared against the patch too.
+	if equality.Semantic.DeepDerivative(patch, base) &&
+		!hasRemovedAppliedFields(currentObj, patch, p.identity.FieldOwner()) {
+		return nil, nil
+	}
+
+	patch.SetResourceVersion(currentObj.GetResourceVersion())
+	objectPatch, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("creating patch: %w", err)
+	}
+	err = p.writer.Patch(ctx, updatedObj, client.RawPatch(
+		types.ApplyPatchType, objectPatch),
+		client.FieldOwner(p.identity.FieldOwner()),
+	)
+	conflicts := applyConflicts(err)
+	if len(conflicts) == 0 {
+		if err != nil {
+			return nil, fmt.Errorf("patching object: %w", err)
+		}
+		return nil, nil
+	}
+	if err := argoCDConflict(desiredObj, conflicts); err != nil {
+		return nil, err
+	}
+
+	if err := p.writer.Patch(ctx, updatedObj, client.RawPatch(
+		types.ApplyPatchType, objectPatch),
+		client.FieldOwner(p.identity.FieldOwner()),
+		client.ForceOwnership,
+	); err != nil {
+		return nil, fmt.Errorf("patching object: %w", err)
+	}
+	return conflicts, nil
+}
+
+// Builds the object to send as apply patch.
+// Ensures labels and annotations of updatedObj are kept on desiredObj.
+func applyPatchObject(desiredObj, updatedObj *unstructured.Unstructured) *unstructured.Unstructured {
 	// Ensure desired labels and annotations are present
 	desiredObj.SetLabels(mergeKeysFrom(updatedObj.GetLabels(), desiredObj.GetLabels()))
 	desiredObj.SetAnnotations(mergeKeysFrom(updatedObj.GetAnnotations(), desiredObj.GetAnnotations()))
@@ -647,26 +1217,7 @@ func (p *defaultPatcher) Patch(
 	unstructured.RemoveNestedField(patch.Object, "status")
 	// don't strategic merge ownerReferences - we already take care about that with its own patch.
 	unstructured.RemoveNestedField(patch.Object, "metadata", "ownerReferences")
-
-	base := updatedObj.DeepCopy()
-	unstructured.RemoveNestedField(base.Object, "status")
-
-	// Check for if an update is even needed.
-	if !equality.Semantic.DeepDerivative(patch, base) {
-		patch.SetResourceVersion(currentObj.GetResourceVersion())
-		objectPatch, err := json.Marshal(patch)
-		if err != nil {
-			return fmt.Errorf("creating patch: %w", err)
-		}
-		if err := p.writer.Patch(ctx, updatedObj, client.RawPatch(
-			types.ApplyPatchType, objectPatch),
-			client.FieldOwner("package-operator"),
-			client.ForceOwnership,
-		); err != nil {
-			return fmt.Errorf("patching object: %w", err)
-		}
-	}
-	return nil
+	return patch
 }
 
 func mergeKeysFrom(base, additional map[string]string) map[string]string {
@@ -685,11 +1236,25 @@ func mergeKeysFrom(base, additional map[string]string) map[string]string {
 type defaultAdoptionChecker struct {
 	scheme        *runtime.Scheme
 	ownerStrategy ownerStrategy
+	policy        *AdoptionPolicyChecker
+	identity      OperatorIdentity
+}
+
+// NewDefaultAdoptionChecker returns the AdoptionChecker used by default.
+// It only adopts objects controlled by a previous revision of the owner
+// or objects without controller, that are marked with the AdoptionAnnotation.
+// It ignores the adoption policy of the PackageOperatorConfig.
+// Custom AdoptionCheckers may delegate to it to extend the default rules.
+// Revisions of objects are read from the annotation of the given operator identity.
+func NewDefaultAdoptionChecker(
+	scheme *runtime.Scheme, ownerStrategy ownerStrategy, identity OperatorIdentity,
+) AdoptionChecker {
+	return &defaultAdoptionChecker{ownerStrategy: ownerStrategy, scheme: scheme, identity: identity}
 }
 
 // Check detects whether an ownership change is needed.
 func (c *defaultAdoptionChecker) Check(
-	_ context.Context, owner PhaseObjectOwner, obj client.Object,
+	ctx context.Context, owner PhaseObjectOwner, obj client.Object,
 	previous []PreviousObjectSet,
 ) (needsAdoption bool, err error) {
 	if len(os.Getenv(ForceAdoptionEnvironmentVariable)) > 0 {
@@ -701,7 +1266,7 @@ func (c *defaultAdoptionChecker) Check(
 		return false, nil
 	}
 
-	currentRevision, err := getObjectRevision(obj)
+	currentRevision, err := getObjectRevision(c.identity, obj)
 	if err != nil {
 		return false, fmt.Errorf("getting revision of object: %w", err)
 	}
@@ -711,6 +1276,18 @@ func (c *defaultAdoptionChecker) Check(
 	}
 
 	if !c.isControlledByPreviousRevision(obj, previous) {
+		if isMarkedForAdoption(owner, obj) {
+			return true, nil
+		}
+		policy, err := c.policy.AdoptionPolicy(ctx)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case policy == corev1alpha1.AdoptionPolicyAlways,
+			policy == corev1alpha1.AdoptionPolicyUncontrolled && metav1.GetControllerOf(obj) == nil:
+			return true, nil
+		}
 		return false, ObjectNotOwnedByPreviousRevisionError{
 			CommonObjectPhaseError: CommonObjectPhaseError{
 				OwnerKey:  client.ObjectKeyFromObject(owner.ClientObject()),
@@ -740,6 +1317,22 @@ func (c *defaultAdoptionChecker) Check(
 	return true, nil
 }
 
+// Objects without controller can be explicitly handed over to an owner,
+// by annotating them with the name of the owner or its controlling ObjectDeployment.
+func isMarkedForAdoption(owner PhaseObjectOwner, obj client.Object) bool {
+	adoptInto := obj.GetAnnotations()[corev1alpha1.AdoptionAnnotation]
+	if len(adoptInto) == 0 || metav1.GetControllerOf(obj) != nil {
+		return false
+	}
+
+	ownerObj := owner.ClientObject()
+	if ownerObj.GetName() == adoptInto {
+		return true
+	}
+	ownerController := metav1.GetControllerOf(ownerObj)
+	return ownerController != nil && ownerController.Name == adoptInto
+}
+
 func (c *defaultAdoptionChecker) isControlledByPreviousRevision(
 	obj client.Object, previous []PreviousObjectSet,
 ) bool {
@@ -784,29 +1377,78 @@ func (c *defaultAdoptionChecker) isControlledByPreviousRevision(
 
 const (
 	// Revision annotations holds a revision generation number to order ObjectSets.
+	// Namespaced by the operator identity, see OperatorIdentity.
 	revisionAnnotation = "package-operator.run/revision"
+	// RevisionIndex is the field name of the dynamic cache index over the revision annotation.
+	RevisionIndex = "metadata.annotations.revision"
 )
 
+// DynamicCacheIndexers returns the field indexers to register in the dynamic cache,
+// to look up objects by owner UID and by revision without checking every object.
+func DynamicCacheIndexers(identity OperatorIdentity) dynamiccache.FieldIndexersByGVK {
+	return dynamiccache.FieldIndexersByGVK{
+		schema.GroupVersionKind{}: {
+			{
+				Field:   dynamiccache.OwnerUIDIndex,
+				Indexer: dynamiccache.OwnerUIDIndexer,
+			},
+			{
+				Field:   RevisionIndex,
+				Indexer: dynamiccache.AnnotationIndexer(identity.RevisionAnnotation()),
+			},
+		},
+	}
+}
+
+type indexLister interface {
+	ListByIndex(
+		ctx context.Context, field, value string,
+	) ([]unstructured.Unstructured, error)
+}
+
+// ListObjectsForRevision returns all cached objects owned by the given owner
+// and annotated with the given revision.
+func ListObjectsForRevision(
+	ctx context.Context, lister indexLister,
+	owner client.Object, revision int64,
+) ([]unstructured.Unstructured, error) {
+	objs, err := lister.ListByIndex(ctx, RevisionIndex, strconv.FormatInt(revision, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []unstructured.Unstructured
+	for _, obj := range objs {
+		for _, ownerRef := range obj.GetOwnerReferences() {
+			if ownerRef.UID == owner.GetUID() {
+				owned = append(owned, obj)
+				break
+			}
+		}
+	}
+	return owned, nil
+}
+
 // Retrieves the revision number from a well-known annotation on the given object.
-func getObjectRevision(obj client.Object) (int64, error) {
+func getObjectRevision(identity OperatorIdentity, obj client.Object) (int64, error) {
 	a := obj.GetAnnotations()
 	if a == nil {
 		return 0, nil
 	}
 
-	if len(a[revisionAnnotation]) == 0 {
+	if len(a[identity.RevisionAnnotation()]) == 0 {
 		return 0, nil
 	}
 
-	return strconv.ParseInt(a[revisionAnnotation], 10, 64)
+	return strconv.ParseInt(a[identity.RevisionAnnotation()], 10, 64)
 }
 
 // Stores the revision number in a well-known annotation on the given object.
-func setObjectRevision(obj client.Object, revision int64) {
+func setObjectRevision(identity OperatorIdentity, obj client.Object, revision int64) {
 	a := obj.GetAnnotations()
 	if a == nil {
 		a = map[string]string{}
 	}
-	a[revisionAnnotation] = fmt.Sprintf("%d", revision)
+	a[identity.RevisionAnnotation()] = fmt.Sprintf("%d", revision)
 	obj.SetAnnotations(a)
 }
@@ -2,23 +2,31 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/ownerhandling"
 	"package-operator.run/package-operator/internal/preflight"
+	"package-operator.run/package-operator/internal/probing"
 	"package-operator.run/package-operator/internal/testutil"
+	"package-operator.run/package-operator/internal/testutil/faultinjection"
+	"package-operator.run/package-operator/internal/testutil/restmappermock"
 )
 
 var testScheme = runtime.NewScheme()
@@ -32,6 +40,22 @@ func init() {
 	}
 }
 
+func TestNewPhaseReconciler_options(t *testing.T) {
+	c := testutil.NewClient()
+	os := &ownerStrategyMock{}
+
+	r := NewPhaseReconciler(testScheme, c, nil, nil, os, nil)
+	assert.IsType(t, &defaultAdoptionChecker{}, r.adoptionChecker)
+	assert.IsType(t, &defaultPatcher{}, r.patcher)
+
+	ac := &adoptionCheckerMock{}
+	p := &patcherMock{}
+	r = NewPhaseReconciler(testScheme, c, nil, nil, os, nil,
+		WithAdoptionChecker{ac}, WithPatcher{p})
+	assert.Same(t, ac, r.adoptionChecker)
+	assert.Same(t, p, r.patcher)
+}
+
 func TestPhaseReconciler_TeardownPhase_failing_preflight(t *testing.T) {
 	dynamicCache := &dynamicCacheMock{}
 	ownerStrategy := &ownerStrategyMock{}
@@ -310,6 +334,71 @@ func TestPhaseReconciler_TeardownPhase(t *testing.T) { //nolint:maintidx
 		testClient.AssertCalled(t, "Update", mock.Anything, currentObj, mock.Anything)
 	})
 
+	t.Run("orphan deletion policy", func(t *testing.T) {
+		dynamicCache := &dynamicCacheMock{}
+		ownerStrategy := &ownerStrategyMock{}
+		testClient := testutil.NewClient()
+		preflightChecker := &preflightCheckerMock{}
+		r := &PhaseReconciler{
+			dynamicCache:     dynamicCache,
+			ownerStrategy:    ownerStrategy,
+			writer:           testClient,
+			preflightChecker: preflightChecker,
+		}
+
+		owner := &phaseObjectOwnerMock{}
+		ownerObj := &unstructured.Unstructured{}
+		owner.On("ClientObject").Return(ownerObj)
+		owner.On("GetRevision").Return(int64(5))
+
+		preflightChecker.
+			On("Check", mock.Anything, mock.Anything, mock.Anything).
+			Return([]preflight.Violation{}, nil)
+
+		ownerStrategy.
+			On("SetControllerReference", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+
+		dynamicCache.
+			On("Watch", mock.Anything, ownerObj, mock.Anything).
+			Return(nil)
+		currentObj := &unstructured.Unstructured{}
+		dynamicCache.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				out := args.Get(2).(*unstructured.Unstructured)
+				*out = *currentObj
+			}).
+			Return(nil)
+
+		ownerStrategy.
+			On("IsController", ownerObj, currentObj).
+			Return(true)
+		ownerStrategy.
+			On("RemoveOwner", ownerObj, currentObj).
+			Return(false)
+
+		testClient.
+			On("Update", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+
+		ctx := context.Background()
+		done, err := r.TeardownPhase(ctx, owner, corev1alpha1.ObjectSetTemplatePhase{
+			Objects: []corev1alpha1.ObjectSetObject{
+				{
+					Object:         unstructured.Unstructured{},
+					DeletionPolicy: corev1alpha1.ObjectSetObjectDeletionPolicyOrphan,
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		ownerStrategy.AssertCalled(t, "RemoveOwner", ownerObj, currentObj)
+		testClient.AssertCalled(t, "Update", mock.Anything, currentObj, mock.Anything)
+		testClient.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("external objects", func(t *testing.T) {
 		dynamicCache := &dynamicCacheMock{}
 		ownerStrategy := &ownerStrategyMock{}
@@ -435,12 +524,64 @@ func TestPhaseReconciler_reconcileObject_create(t *testing.T) {
 
 	ctx := context.Background()
 	desired := &unstructured.Unstructured{}
-	actual, err := r.reconcileObject(ctx, owner, desired, nil)
+	actual, err := r.reconcileObject(ctx, owner, desired, nil, false)
 	require.NoError(t, err)
 
 	assert.Same(t, desired, actual)
 }
 
+func TestPhaseReconciler_reconcileObject_adoptionRace(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(testScheme).Build()
+	inj := faultinjection.NewInjector()
+	ownerStrategy := ownerhandling.NewNative(testScheme)
+	p := &patcherMock{}
+	r := NewPhaseReconciler(
+		testScheme, faultinjection.NewWriter(c, inj),
+		faultinjection.NewCache(faultinjection.Watchless(c), inj), c,
+		ownerStrategy, nil, WithPatcher{p})
+	p.On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	previousObj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "rev1", Namespace: "test", UID: "rev1-uid",
+	}}
+	ownerObj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "rev2", Namespace: "test", UID: "rev2-uid",
+	}}
+	owner := &phaseObjectOwnerMock{}
+	owner.On("ClientObject").Return(ownerObj)
+	owner.On("GetRevision").Return(int64(2))
+	previous := []PreviousObjectSet{newPreviousObjectSetMockWithoutRemotes(previousObj)}
+
+	// The previous revision creates the object right before this revision does.
+	inj.Inject(faultinjection.CreateRace(c, func(obj client.Object) {
+		ownerStrategy.ReleaseController(obj)
+		require.NoError(t, ownerStrategy.SetControllerReference(previousObj, obj))
+		setObjectRevision(OperatorIdentity{}, obj, 1)
+	}))
+
+	newDesired := func() *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetName("test")
+		obj.SetNamespace("test")
+		require.NoError(t, ownerStrategy.SetControllerReference(ownerObj, obj))
+		setObjectRevision(OperatorIdentity{}, obj, 2)
+		return obj
+	}
+
+	ctx := context.Background()
+	_, err := r.reconcileObject(ctx, owner, newDesired(), previous, false)
+	assert.True(t, errors.IsAlreadyExists(err), "expected already exists, got: %v", err)
+
+	// The retry adopts the object from the previous revision.
+	actual, err := r.reconcileObject(ctx, owner, newDesired(), previous, false)
+	require.NoError(t, err)
+	assert.True(t, ownerStrategy.IsController(ownerObj, actual))
+	assert.False(t, ownerStrategy.IsController(previousObj, actual))
+	p.AssertNumberOfCalls(t, "Patch", 1)
+}
+
 func TestPhaseReconciler_reconcileObject_update(t *testing.T) {
 	testClient := testutil.NewClient()
 	dynamicCacheMock := &dynamicCacheMock{}
@@ -489,7 +630,7 @@ func TestPhaseReconciler_reconcileObject_update(t *testing.T) {
 	obj := &unstructured.Unstructured{}
 	// set owner refs so we don't run into the panic
 	obj.SetOwnerReferences([]metav1.OwnerReference{{}})
-	actual, err := r.reconcileObject(ctx, owner, obj, nil)
+	actual, err := r.reconcileObject(ctx, owner, obj, nil, false)
 	require.NoError(t, err)
 
 	assert.Equal(t, &unstructured.Unstructured{
@@ -511,6 +652,267 @@ func TestPhaseReconciler_reconcileObject_update(t *testing.T) {
 	}, actual)
 }
 
+func TestPhaseReconciler_reconcileObject_createOnly(t *testing.T) {
+	testClient := testutil.NewClient()
+	dynamicCacheMock := &dynamicCacheMock{}
+	acMock := &adoptionCheckerMock{}
+	ownerStrategy := &ownerStrategyMock{}
+	patcher := &patcherMock{}
+	r := &PhaseReconciler{
+		writer:          testClient,
+		dynamicCache:    dynamicCacheMock,
+		adoptionChecker: acMock,
+		ownerStrategy:   ownerStrategy,
+		patcher:         patcher,
+	}
+	owner := &phaseObjectOwnerMock{}
+	owner.On("ClientObject").Return(&unstructured.Unstructured{})
+
+	acMock.
+		On("Check", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(false, nil)
+	dynamicCacheMock.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	ownerStrategy.
+		On("IsController", mock.Anything, mock.Anything).
+		Return(true)
+
+	ctx := context.Background()
+	obj := &unstructured.Unstructured{}
+	obj.SetOwnerReferences([]metav1.OwnerReference{{}})
+	actual, err := r.reconcileObject(ctx, owner, obj, nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, obj, actual)
+
+	patcher.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	testClient.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+type diffRecordingOwnerMock struct {
+	phaseObjectOwnerMock
+	diff []corev1alpha1.ObjectSetObjectDiff
+}
+
+func (m *diffRecordingOwnerMock) RecordObjectDiff(diff corev1alpha1.ObjectSetObjectDiff) {
+	m.diff = append(m.diff, diff)
+}
+
+func TestPhaseReconciler_reconcilePhaseObject_pausedDiff(t *testing.T) {
+	newObj := func() *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("apps/v1")
+		obj.SetKind("Deployment")
+		obj.SetName("test")
+		obj.SetNamespace("test-ns")
+		return obj
+	}
+
+	t.Run("create", func(t *testing.T) {
+		testClient := testutil.NewClient()
+		uncachedClient := testutil.NewClient()
+		dynamicCache := &dynamicCacheMock{}
+		ownerStrategy := &ownerStrategyMock{}
+		r := &PhaseReconciler{
+			writer:         testClient,
+			uncachedClient: uncachedClient,
+			dynamicCache:   dynamicCache,
+			ownerStrategy:  ownerStrategy,
+		}
+		owner := &diffRecordingOwnerMock{}
+		owner.On("ClientObject").Return(&unstructured.Unstructured{})
+		owner.On("IsPaused").Return(true)
+
+		ownerStrategy.
+			On("SetControllerReference", mock.Anything, mock.Anything).
+			Return(nil)
+		dynamicCache.
+			On("Watch", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		dynamicCache.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.NewNotFound(schema.GroupResource{}, ""))
+		uncachedClient.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+		ctx := context.Background()
+		actual, err := r.reconcilePhaseObject(
+			ctx, owner, corev1alpha1.ObjectSetObject{}, newObj(), nil, nil)
+		require.NoError(t, err)
+		assert.Nil(t, actual)
+
+		testClient.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		assert.Equal(t, []corev1alpha1.ObjectSetObjectDiff{
+			{
+				Object: corev1alpha1.ControlledObjectReference{
+					Kind:      "Deployment",
+					Group:     "apps",
+					Name:      "test",
+					Namespace: "test-ns",
+				},
+				Action: corev1alpha1.ObjectSetObjectDiffActionCreate,
+			},
+		}, owner.diff)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		testClient := testutil.NewClient()
+		dynamicCache := &dynamicCacheMock{}
+		ownerStrategy := &ownerStrategyMock{}
+		r := &PhaseReconciler{
+			writer:        testClient,
+			dynamicCache:  dynamicCache,
+			ownerStrategy: ownerStrategy,
+		}
+		owner := &diffRecordingOwnerMock{}
+		owner.On("ClientObject").Return(&unstructured.Unstructured{})
+		owner.On("IsPaused").Return(true)
+
+		ownerStrategy.
+			On("SetControllerReference", mock.Anything, mock.Anything).
+			Return(nil)
+		dynamicCache.
+			On("Watch", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		dynamicCache.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				obj := args.Get(2).(*unstructured.Unstructured)
+				obj.SetResourceVersion("1")
+				_ = unstructured.SetNestedField(obj.Object, int64(1), "spec", "replicas")
+			}).
+			Return(nil)
+
+		var opts []client.PatchOption
+		testClient.
+			On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				obj := args.Get(1).(*unstructured.Unstructured)
+				obj.SetResourceVersion("2")
+				_ = unstructured.SetNestedField(obj.Object, int64(3), "spec", "replicas")
+				opts = args.Get(3).([]client.PatchOption)
+			}).
+			Return(nil)
+
+		desired := newObj()
+		_ = unstructured.SetNestedField(desired.Object, int64(3), "spec", "replicas")
+
+		ctx := context.Background()
+		actual, err := r.reconcilePhaseObject(
+			ctx, owner, corev1alpha1.ObjectSetObject{}, desired, nil, nil)
+		require.NoError(t, err)
+
+		replicas, _, _ := unstructured.NestedInt64(actual.Object, "spec", "replicas")
+		assert.Equal(t, int64(1), replicas, "must not change actual object")
+		assert.Contains(t, opts, client.DryRunAll)
+		assert.Equal(t, []corev1alpha1.ObjectSetObjectDiff{
+			{
+				Object: corev1alpha1.ControlledObjectReference{
+					Kind:      "Deployment",
+					Group:     "apps",
+					Name:      "test",
+					Namespace: "test-ns",
+				},
+				Action: corev1alpha1.ObjectSetObjectDiffActionUpdate,
+				Fields: []string{"spec.replicas"},
+			},
+		}, owner.diff)
+	})
+
+	t.Run("update object missing cache label", func(t *testing.T) {
+		testClient := testutil.NewClient()
+		uncachedClient := testutil.NewClient()
+		dynamicCache := &dynamicCacheMock{}
+		ownerStrategy := &ownerStrategyMock{}
+		r := &PhaseReconciler{
+			writer:         testClient,
+			uncachedClient: uncachedClient,
+			dynamicCache:   dynamicCache,
+			ownerStrategy:  ownerStrategy,
+		}
+		owner := &diffRecordingOwnerMock{}
+		owner.On("ClientObject").Return(&unstructured.Unstructured{})
+		owner.On("IsPaused").Return(true)
+
+		ownerStrategy.
+			On("SetControllerReference", mock.Anything, mock.Anything).
+			Return(nil)
+		dynamicCache.
+			On("Watch", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil)
+		dynamicCache.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.NewNotFound(schema.GroupResource{}, ""))
+		uncachedClient.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				obj := args.Get(2).(*unstructured.Unstructured)
+				obj.SetResourceVersion("1")
+				_ = unstructured.SetNestedField(obj.Object, int64(1), "spec", "replicas")
+			}).
+			Return(nil)
+		testClient.
+			On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				obj := args.Get(1).(*unstructured.Unstructured)
+				obj.SetResourceVersion("2")
+				_ = unstructured.SetNestedField(obj.Object, int64(3), "spec", "replicas")
+			}).
+			Return(nil)
+
+		desired := newObj()
+		_ = unstructured.SetNestedField(desired.Object, int64(3), "spec", "replicas")
+
+		ctx := context.Background()
+		actual, err := r.reconcilePhaseObject(
+			ctx, owner, corev1alpha1.ObjectSetObject{}, desired, nil, nil)
+		require.NoError(t, err)
+		require.NotNil(t, actual)
+
+		if assert.Len(t, owner.diff, 1) {
+			assert.Equal(t, corev1alpha1.ObjectSetObjectDiffActionUpdate, owner.diff[0].Action)
+		}
+	})
+}
+
+func Test_diffFields(t *testing.T) {
+	a := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": "1",
+			"labels": map[string]interface{}{
+				"a": "1",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"ports":    []interface{}{int64(80)},
+		},
+		"status": map[string]interface{}{
+			"ready": true,
+		},
+	}
+	b := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": "2",
+			"labels": map[string]interface{}{
+				"a": "1",
+				"b": "2",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"ports":    []interface{}{int64(80), int64(443)},
+		},
+	}
+
+	fields := diffFields(a, b, "")
+	assert.ElementsMatch(t, []string{
+		"metadata.labels.b",
+		"spec.ports",
+	}, fields)
+}
+
 func TestPhaseReconciler_desiredObject(t *testing.T) {
 	os := &ownerStrategyMock{}
 	r := &PhaseReconciler{
@@ -550,6 +952,97 @@ func TestPhaseReconciler_desiredObject(t *testing.T) {
 	}, desiredObj)
 }
 
+func TestPhaseReconciler_desiredObject_managerVersion(t *testing.T) {
+	r := &PhaseReconciler{
+		managerVersion: "v1.2.3",
+	}
+
+	ctx := context.Background()
+	owner := &phaseObjectOwnerMock{}
+	ownerObj := &unstructured.Unstructured{}
+	owner.On("ClientObject").Return(ownerObj)
+	owner.On("GetRevision").Return(int64(5))
+
+	phaseObject := corev1alpha1.ObjectSetObject{
+		Object: unstructured.Unstructured{
+			Object: map[string]interface{}{"kind": "test"},
+		},
+	}
+	desiredObj, err := r.desiredObject(ctx, owner, phaseObject)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		revisionAnnotation:       "5",
+		ManagerVersionAnnotation: "v1.2.3",
+	}, desiredObj.GetAnnotations())
+}
+
+func TestPhaseReconciler_desiredObject_ownerMetadata(t *testing.T) {
+	r := &PhaseReconciler{}
+
+	ctx := context.Background()
+	owner := &phaseObjectOwnerMock{}
+	ownerObj := &unstructured.Unstructured{}
+	ownerObj.SetNamespace("test")
+	ownerObj.SetLabels(map[string]string{"tenant": "acme"})
+	owner.On("ClientObject").Return(ownerObj)
+	owner.On("GetRevision").Return(int64(5))
+
+	phaseObject := corev1alpha1.ObjectSetObject{
+		Object: unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"kind": "test",
+				"metadata": map[string]interface{}{
+					"name": "db-${metadata.labels['tenant']}",
+				},
+			},
+		},
+	}
+	desiredObj, err := r.desiredObject(ctx, owner, phaseObject)
+	require.NoError(t, err)
+	assert.Equal(t, "db-acme", desiredObj.GetName())
+	assert.Equal(t, "test", desiredObj.GetNamespace())
+
+	ownerObj.SetLabels(nil)
+	_, err = r.desiredObject(ctx, owner, phaseObject)
+	var refErr OwnerMetadataReferenceError
+	assert.ErrorAs(t, err, &refErr)
+}
+
+func TestPhaseReconciler_desiredObject_mutators(t *testing.T) {
+	var calls []string
+	r := NewPhaseReconcilerWithActuator(
+		testScheme, NewKubernetesPhaseActuator(nil, nil, nil), &ownerStrategyMock{}, nil,
+		WithObjectMutators{
+			ObjectMutatorFunc(func(_ context.Context, _ PhaseObjectOwner, obj *unstructured.Unstructured) error {
+				calls = append(calls, "first")
+				obj.SetLabels(map[string]string{"injected": "True"})
+				return nil
+			}),
+		},
+		WithObjectMutators{
+			ObjectMutatorFunc(func(_ context.Context, _ PhaseObjectOwner, obj *unstructured.Unstructured) error {
+				calls = append(calls, "second")
+				return fmt.Errorf("boom")
+			}),
+		},
+	)
+
+	ctx := context.Background()
+	owner := &phaseObjectOwnerMock{}
+	owner.On("ClientObject").Return(&unstructured.Unstructured{})
+	owner.On("GetRevision").Return(int64(5))
+
+	phaseObject := corev1alpha1.ObjectSetObject{
+		Object: unstructured.Unstructured{
+			Object: map[string]interface{}{"kind": "test"},
+		},
+	}
+	_, err := r.desiredObject(ctx, owner, phaseObject)
+	require.EqualError(t, err, "mutating object: boom")
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
+
 func TestPhaseReconciler_desiredObject_defaultsNamespace(t *testing.T) {
 	os := &ownerStrategyMock{}
 	r := &PhaseReconciler{
@@ -693,8 +1186,64 @@ func Test_defaultAdoptionChecker_Check(t *testing.T) {
 			needsAdoption: false,
 		},
 		{
-			// Object owner is not in previous revision list.
-			name: "object not owned by previous revision",
+			// Object owner is not in previous revision list.
+			name: "object not owned by previous revision",
+			mockPrepare: func(
+				osm *ownerStrategyMock,
+				owner *phaseObjectOwnerMock,
+			) {
+				osm.
+					On("IsController", mock.Anything, mock.Anything).
+					Return(false)
+				ownerObj := &unstructured.Unstructured{
+					Object: map[string]interface{}{},
+				}
+				owner.On("ClientObject").Return(ownerObj)
+				owner.On("GetRevision").Return(int64(1))
+			},
+			previous: []PreviousObjectSet{
+				newPreviousObjectSetMockWithoutRemotes(
+					&unstructured.Unstructured{}),
+			},
+			object: &unstructured.Unstructured{
+				Object: map[string]interface{}{},
+			},
+			errorAs:       &ObjectNotOwnedByPreviousRevisionError{},
+			needsAdoption: false,
+		},
+		{
+			// Object without controller is marked for adoption
+			// by the ObjectDeployment controlling the owner.
+			name: "marked for adoption",
+			mockPrepare: func(
+				osm *ownerStrategyMock,
+				owner *phaseObjectOwnerMock,
+			) {
+				osm.
+					On("IsController", mock.Anything, mock.Anything).
+					Return(false)
+				ownerObj := &unstructured.Unstructured{}
+				ownerObj.SetName("test-abcde")
+				ownerObj.SetOwnerReferences([]metav1.OwnerReference{{
+					Kind: "ObjectDeployment", Name: "test", Controller: pointer.Bool(true),
+				}})
+				owner.On("ClientObject").Return(ownerObj)
+				owner.On("GetRevision").Return(int64(1))
+			},
+			object: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							corev1alpha1.AdoptionAnnotation: "test",
+						},
+					},
+				},
+			},
+			needsAdoption: true,
+		},
+		{
+			// Object is marked for adoption, but controlled by someone else.
+			name: "marked for adoption with controller",
 			mockPrepare: func(
 				osm *ownerStrategyMock,
 				owner *phaseObjectOwnerMock,
@@ -702,18 +1251,28 @@ func Test_defaultAdoptionChecker_Check(t *testing.T) {
 				osm.
 					On("IsController", mock.Anything, mock.Anything).
 					Return(false)
-				ownerObj := &unstructured.Unstructured{
-					Object: map[string]interface{}{},
-				}
+				ownerObj := &unstructured.Unstructured{}
+				ownerObj.SetName("test")
 				owner.On("ClientObject").Return(ownerObj)
 				owner.On("GetRevision").Return(int64(1))
 			},
-			previous: []PreviousObjectSet{
-				newPreviousObjectSetMockWithoutRemotes(
-					&unstructured.Unstructured{}),
-			},
 			object: &unstructured.Unstructured{
-				Object: map[string]interface{}{},
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							corev1alpha1.AdoptionAnnotation: "test",
+						},
+						"ownerReferences": []interface{}{
+							map[string]interface{}{
+								"apiVersion": "apps/v1",
+								"kind":       "Deployment",
+								"name":       "other",
+								"uid":        "1234",
+								"controller": true,
+							},
+						},
+					},
+				},
 			},
 			errorAs:       &ObjectNotOwnedByPreviousRevisionError{},
 			needsAdoption: false,
@@ -779,6 +1338,69 @@ func Test_defaultAdoptionChecker_Check(t *testing.T) {
 	}
 }
 
+func Test_defaultAdoptionChecker_Check_adoptionPolicy(t *testing.T) {
+	foreignController := []metav1.OwnerReference{{
+		APIVersion: "apps/v1", Kind: "Deployment", Name: "other", Controller: pointer.Bool(true),
+	}}
+
+	tests := []struct {
+		name            string
+		policy          corev1alpha1.AdoptionPolicy
+		ownerReferences []metav1.OwnerReference
+		needsAdoption   bool
+	}{
+		{name: "annotated", policy: corev1alpha1.AdoptionPolicyAnnotated},
+		{name: "uncontrolled", policy: corev1alpha1.AdoptionPolicyUncontrolled, needsAdoption: true},
+		{
+			name: "uncontrolled with foreign controller", policy: corev1alpha1.AdoptionPolicyUncontrolled,
+			ownerReferences: foreignController,
+		},
+		{
+			name: "always with foreign controller", policy: corev1alpha1.AdoptionPolicyAlways,
+			ownerReferences: foreignController, needsAdoption: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := &corev1alpha1.PackageOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: corev1alpha1.PackageOperatorConfigName},
+				Spec:       corev1alpha1.PackageOperatorConfigSpec{AdoptionPolicy: test.policy},
+			}
+			os := &ownerStrategyMock{}
+			c := &defaultAdoptionChecker{
+				ownerStrategy: os,
+				scheme:        testScheme,
+				policy: NewAdoptionPolicyChecker(
+					fake.NewClientBuilder().WithScheme(testScheme).WithObjects(config).Build()),
+			}
+
+			ownerObj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			owner := &phaseObjectOwnerMock{}
+			owner.On("ClientObject").Return(ownerObj)
+			owner.On("GetRevision").Return(int64(2))
+			os.On("IsController", mock.Anything, mock.Anything).Return(false)
+
+			obj := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "existing",
+					OwnerReferences: test.ownerReferences,
+				},
+			}
+			needsAdoption, err := c.Check(context.Background(), owner, obj, nil)
+			if test.needsAdoption {
+				require.NoError(t, err)
+			} else {
+				require.ErrorAs(t, err, &ObjectNotOwnedByPreviousRevisionError{})
+			}
+			assert.Equal(t, test.needsAdoption, needsAdoption)
+		})
+	}
+}
+
 func Test_defaultAdoptionChecker_isControlledByPreviousRevision(t *testing.T) {
 	os := &ownerStrategyMock{}
 	ac := &defaultAdoptionChecker{
@@ -953,6 +1575,42 @@ func Test_defaultPatcher_patchObject_noop(t *testing.T) {
 		t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
+func Test_defaultPatcher_patchObject_removedField(t *testing.T) {
+	clientMock := testutil.NewClient()
+	r := &defaultPatcher{
+		writer: clientMock,
+	}
+	ctx := context.Background()
+
+	clientMock.
+		On("Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	// the cluster object still contains a field,
+	// that was removed from the desired object.
+	desiredObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{"a": "1"},
+	}}
+	currentObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{"a": "1", "b": "2"},
+	}}
+	currentObj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:    FieldOwner,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:a":{},"f:b":{}}}`)},
+		},
+	})
+	updatedObj := currentObj.DeepCopy()
+
+	err := r.Patch(ctx, desiredObj, currentObj, updatedObj)
+	require.NoError(t, err)
+
+	clientMock.AssertCalled(
+		t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func Test_mergeKeysFrom(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -1354,6 +2012,245 @@ func TestPhaseReconciler_ReconcilePhase_preflightError(t *testing.T) {
 	require.ErrorAs(t, err, &pErr)
 }
 
+func TestPhaseReconciler_ReconcilePhase_atomicRollback(t *testing.T) {
+	pcm := &preflightCheckerMock{}
+	uncachedClient := testutil.NewClient()
+	writer := testutil.NewClient()
+	os := &ownerStrategyMock{}
+	pr := &PhaseReconciler{
+		scheme:           testScheme,
+		preflightChecker: pcm,
+		uncachedClient:   uncachedClient,
+		writer:           writer,
+		ownerStrategy:    os,
+	}
+
+	ownerCreation := metav1.Now()
+	ownerObj := &unstructured.Unstructured{}
+	ownerObj.SetCreationTimestamp(ownerCreation)
+	owner := &phaseObjectOwnerMock{}
+	owner.On("ClientObject").Return(ownerObj)
+	owner.On("GetRevision").Return(int64(12))
+	owner.On("IsPaused").Return(false)
+
+	pcm.
+		On("Check", mock.Anything, mock.Anything, mock.Anything).
+		Return([]preflight.Violation{{}}, nil)
+	os.On("IsController", mock.Anything, mock.Anything).Return(true)
+	uncachedClient.
+		On("Get", mock.Anything, client.ObjectKey{Name: "created"}, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			obj := args.Get(2).(*unstructured.Unstructured)
+			obj.SetCreationTimestamp(metav1.NewTime(ownerCreation.Add(time.Minute)))
+		}).
+		Return(nil)
+	uncachedClient.
+		On("Get", mock.Anything, client.ObjectKey{Name: "adopted"}, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			obj := args.Get(2).(*unstructured.Unstructured)
+			obj.SetCreationTimestamp(metav1.NewTime(ownerCreation.Add(-time.Hour)))
+		}).
+		Return(nil)
+	uncachedClient.
+		On("Get", mock.Anything, client.ObjectKey{Name: "missing"}, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+	writer.
+		On("Delete", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	newObject := func(name string) corev1alpha1.ObjectSetObject {
+		obj := unstructured.Unstructured{}
+		obj.SetName(name)
+		return corev1alpha1.ObjectSetObject{Object: obj}
+	}
+	phase := corev1alpha1.ObjectSetTemplatePhase{
+		Atomic: true,
+		Objects: []corev1alpha1.ObjectSetObject{
+			newObject("created"), newObject("adopted"), newObject("missing"),
+		},
+	}
+
+	ctx := context.Background()
+	_, _, err := pr.ReconcilePhase(
+		ctx, owner, phase, nil, nil)
+	var pErr *preflight.Error
+	require.ErrorAs(t, err, &pErr)
+
+	writer.AssertNumberOfCalls(t, "Delete", 1)
+	deleted := writer.Calls[0].Arguments.Get(1).(*unstructured.Unstructured)
+	assert.Equal(t, "created", deleted.GetName())
+}
+
+func TestPhaseReconciler_ReconcilePhase_applyPolicy(t *testing.T) {
+	t.Parallel()
+
+	pcm := &preflightCheckerMock{}
+	writer := testutil.NewClient()
+	dynamicCache := &dynamicCacheMock{}
+	os := &ownerStrategyMock{}
+	pr := &PhaseReconciler{
+		scheme:           testScheme,
+		preflightChecker: pcm,
+		writer:           writer,
+		dynamicCache:     dynamicCache,
+		ownerStrategy:    os,
+	}
+
+	owner := &phaseObjectOwnerMock{}
+	owner.On("ClientObject").Return(&unstructured.Unstructured{})
+	owner.On("GetRevision").Return(int64(1))
+	owner.On("IsPaused").Return(false)
+
+	pcm.
+		On("Check", mock.Anything, mock.Anything, mock.Anything).
+		Return([]preflight.Violation{}, nil)
+	os.
+		On("SetControllerReference", mock.Anything, mock.Anything).
+		Return(nil)
+	dynamicCache.
+		On("Watch", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	dynamicCache.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+	writer.
+		On("Create", mock.Anything, mock.MatchedBy(func(obj client.Object) bool {
+			return obj.GetName() == "denied"
+		}), mock.Anything).
+		Return(errors.NewForbidden(schema.GroupResource{}, "denied", fmt.Errorf("admission webhook denied the request")))
+	writer.
+		On("Create", mock.Anything, mock.MatchedBy(func(obj client.Object) bool {
+			return obj.GetName() == "slow"
+		}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			// Hanging webhook.
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(context.DeadlineExceeded)
+	writer.
+		On("Create", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	newObject := func(name string) corev1alpha1.ObjectSetObject {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetName(name)
+		return corev1alpha1.ObjectSetObject{Object: obj}
+	}
+	slow := newObject("slow")
+	slow.ApplyPolicy = &corev1alpha1.ObjectSetApplyPolicy{
+		Timeout:      &metav1.Duration{Duration: 10 * time.Millisecond},
+		RetryBackoff: &metav1.Duration{Duration: time.Minute},
+	}
+	phase := corev1alpha1.ObjectSetTemplatePhase{
+		Name:        "test",
+		ApplyPolicy: &corev1alpha1.ObjectSetApplyPolicy{},
+		Objects: []corev1alpha1.ObjectSetObject{
+			newObject("denied"), slow, newObject("ok"),
+		},
+	}
+
+	ctx := context.Background()
+	probe, err := probing.ParseProbes(ctx, nil)
+	require.NoError(t, err)
+	actual, res, err := pr.ReconcilePhase(
+		ctx, owner, phase, probe, nil)
+	require.NoError(t, err)
+
+	if assert.Len(t, actual, 1) {
+		assert.Equal(t, "ok", actual[0].GetName())
+	}
+	if assert.Len(t, res.FailedProbes, 2) {
+		assert.Contains(t, res.FailedProbes[0], "denied: apply failed")
+		assert.Contains(t, res.FailedProbes[1], "slow: apply failed")
+	}
+	assert.Equal(t, defaultApplyRetryBackoff, res.RecheckAfter)
+}
+
+func TestPhaseReconciler_ReconcilePhase_pausedMissingObject(t *testing.T) {
+	t.Parallel()
+
+	pcm := &preflightCheckerMock{}
+	writer := testutil.NewClient()
+	uncachedClient := testutil.NewClient()
+	dynamicCache := &dynamicCacheMock{}
+	os := &ownerStrategyMock{}
+	pr := &PhaseReconciler{
+		scheme:           testScheme,
+		preflightChecker: pcm,
+		writer:           writer,
+		uncachedClient:   uncachedClient,
+		dynamicCache:     dynamicCache,
+		ownerStrategy:    os,
+	}
+
+	owner := &diffRecordingOwnerMock{}
+	owner.On("ClientObject").Return(&unstructured.Unstructured{})
+	owner.On("GetRevision").Return(int64(1))
+	owner.On("IsPaused").Return(true)
+
+	pcm.
+		On("Check", mock.Anything, mock.Anything, mock.Anything).
+		Return([]preflight.Violation{}, nil)
+	os.
+		On("SetControllerReference", mock.Anything, mock.Anything).
+		Return(nil)
+	dynamicCache.
+		On("Watch", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	dynamicCache.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+	uncachedClient.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+
+	obj := unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName("missing")
+	phase := corev1alpha1.ObjectSetTemplatePhase{
+		Name:    "test",
+		Objects: []corev1alpha1.ObjectSetObject{{Object: obj}},
+	}
+
+	ctx := context.Background()
+	probe, err := probing.ParseProbes(ctx, nil)
+	require.NoError(t, err)
+	actual, res, err := pr.ReconcilePhase(
+		ctx, owner, phase, probe, nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, actual)
+	assert.Equal(t, []string{" ConfigMap /missing: object not found"}, res.FailedProbes)
+	if assert.Len(t, owner.diff, 1) {
+		assert.Equal(t, corev1alpha1.ObjectSetObjectDiffActionCreate, owner.diff[0].Action)
+	}
+	writer.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIsFatalPhaseError(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsFatalPhaseError(fmt.Errorf("wrapped: %w", &preflight.Error{})))
+	assert.True(t, IsFatalPhaseError(RevisionCollisionError{}))
+	assert.True(t, IsFatalPhaseError(errors.NewInvalid(schema.GroupKind{}, "test", nil)))
+	assert.False(t, IsFatalPhaseError(errors.NewConflict(schema.GroupResource{}, "test", nil)))
+	assert.False(t, IsFatalPhaseError(nil))
+}
+
+func TestIsObjectApplyFailure(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsObjectApplyFailure(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)))
+	assert.True(t, IsObjectApplyFailure(errors.NewForbidden(schema.GroupResource{}, "test", nil)))
+	assert.True(t, IsObjectApplyFailure(errors.NewInternalError(fmt.Errorf("failed calling webhook"))))
+	assert.False(t, IsObjectApplyFailure(RevisionCollisionError{}))
+	assert.False(t, IsObjectApplyFailure(errors.NewConflict(schema.GroupResource{}, "test", nil)))
+	assert.False(t, IsObjectApplyFailure(nil))
+}
+
 func hasDynamicCacheLabel(obj corev1alpha1.ObjectSetObject) bool {
 	labels := obj.Object.GetLabels()
 
@@ -1370,3 +2267,154 @@ func (m *preflightCheckerMock) Check(
 	args := m.Called(ctx, owner, obj)
 	return args.Get(0).([]preflight.Violation), args.Error(1)
 }
+
+type recheckProberFake struct {
+	success      bool
+	recheckAfter time.Duration
+}
+
+func (p *recheckProberFake) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	success, message, _ = p.ProbeWithRecheck(obj)
+	return
+}
+
+func (p *recheckProberFake) ProbeWithRecheck(obj *unstructured.Unstructured) (success bool, message string, recheckAfter time.Duration) {
+	if p.success {
+		return true, "", p.recheckAfter
+	}
+	return false, "not ready", p.recheckAfter
+}
+
+func TestRecordingProbe_RecheckAfter(t *testing.T) {
+	t.Parallel()
+
+	obj := &unstructured.Unstructured{}
+	obj.SetName("test")
+
+	rp := newRecordingProbe("phase", &recheckProberFake{recheckAfter: time.Minute})
+	rp.Probe(context.Background(), obj)
+	rp.probe = &recheckProberFake{recheckAfter: 10 * time.Second}
+	rp.Probe(context.Background(), obj)
+	rp.probe = &recheckProberFake{}
+	rp.Probe(context.Background(), obj)
+
+	res := rp.Result()
+	assert.Len(t, res.FailedProbes, 3)
+	assert.Equal(t, 10*time.Second, res.RecheckAfter)
+}
+
+func TestRecordingProbe_RecheckAfter_tolerated(t *testing.T) {
+	t.Parallel()
+
+	obj := &unstructured.Unstructured{}
+	obj.SetName("test")
+
+	rp := newRecordingProbe("phase", &recheckProberFake{success: true, recheckAfter: time.Minute})
+	rp.Probe(context.Background(), obj)
+
+	res := rp.Result()
+	assert.True(t, res.IsZero())
+	assert.Equal(t, time.Minute, res.RecheckAfter)
+}
+
+func TestPhaseReconciler_probeCRD(t *testing.T) {
+	t.Parallel()
+
+	newCRD := func(established bool) *unstructured.Unstructured {
+		status := "False"
+		if established {
+			status = "True"
+		}
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata":   map[string]interface{}{"name": "tests.example.com"},
+			"spec": map[string]interface{}{
+				"group": "example.com",
+				"names": map[string]interface{}{"kind": "Test"},
+			},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Established", "status": status},
+				},
+			},
+		}}
+	}
+	noMatch := &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "example.com", Kind: "Test"}}
+
+	tests := []struct {
+		name                 string
+		obj                  *unstructured.Unstructured
+		restMappingErr       error
+		expectedFailures     int
+		expectedRecheckAfter time.Duration
+	}{
+		{
+			name: "not a CRD",
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+			}},
+		},
+		{
+			name:             "not established",
+			obj:              newCRD(false),
+			expectedFailures: 1,
+		},
+		{
+			name: "established and discoverable",
+			obj:  newCRD(true),
+		},
+		{
+			name:                 "established but not discoverable",
+			obj:                  newCRD(true),
+			restMappingErr:       noMatch,
+			expectedFailures:     1,
+			expectedRecheckAfter: crdDiscoveryRecheckInterval,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			restMapper := &restmappermock.RestMapperMock{}
+			restMapper.
+				On("RESTMapping").
+				Return(&meta.RESTMapping{}, test.restMappingErr)
+			r := &PhaseReconciler{restMapper: restMapper}
+
+			rec := newRecordingProbe("phase", nil)
+			r.probeCRD(&rec, test.obj)
+
+			res := rec.Result()
+			assert.Len(t, res.FailedProbes, test.expectedFailures)
+			assert.Equal(t, test.expectedRecheckAfter, res.RecheckAfter)
+		})
+	}
+}
+
+func TestListObjectsForRevision(t *testing.T) {
+	owner := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{UID: "owner-uid"},
+	}
+	owned := unstructured.Unstructured{}
+	owned.SetName("owned")
+	owned.SetOwnerReferences([]metav1.OwnerReference{{UID: "owner-uid"}})
+	other := unstructured.Unstructured{}
+	other.SetName("other")
+	other.SetOwnerReferences([]metav1.OwnerReference{{UID: "other-uid"}})
+
+	dc := &dynamicCacheMock{}
+	dc.
+		On("ListByIndex", mock.Anything, RevisionIndex, "3").
+		Return([]unstructured.Unstructured{owned, other}, nil)
+
+	ctx := context.Background()
+	objs, err := ListObjectsForRevision(ctx, dc, owner, 3)
+	require.NoError(t, err)
+	if assert.Len(t, objs, 1) {
+		assert.Equal(t, "owned", objs[0].GetName())
+	}
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// ArchivablePhaseObjectOwner is optionally implemented by PhaseObjectOwners,
+// to scale down objects with the ScaleDown deletion policy when archived, instead of deleting them.
+type ArchivablePhaseObjectOwner interface {
+	IsArchived() bool
+}
+
+// Kinds scaled down instead of deleted when archived.
+var scalableGroupKinds = map[schema.GroupKind]struct{}{
+	{Group: "apps", Kind: "Deployment"}:  {},
+	{Group: "apps", Kind: "StatefulSet"}: {},
+}
+
+// Returns true, if the object should be scaled down instead of deleted during teardown.
+// Objects are only scaled down while their owner is archived,
+// they are deleted when the owner itself is deleted.
+func shouldScaleDown(
+	owner PhaseObjectOwner, phaseObject corev1alpha1.ObjectSetObject,
+	obj *unstructured.Unstructured,
+) bool {
+	if phaseObject.DeletionPolicy != corev1alpha1.ObjectSetObjectDeletionPolicyScaleDown {
+		return false
+	}
+	archivable, ok := owner.(ArchivablePhaseObjectOwner)
+	if !ok || !archivable.IsArchived() ||
+		!owner.ClientObject().GetDeletionTimestamp().IsZero() {
+		return false
+	}
+	_, scalable := scalableGroupKinds[obj.GroupVersionKind().GroupKind()]
+	return scalable
+}
+
+// Scales the given object to zero replicas.
+func (r *PhaseReconciler) scaleDown(ctx context.Context, obj *unstructured.Unstructured) error {
+	replicas, found, err := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if err == nil && found && replicas == 0 {
+		return nil
+	}
+
+	patch := client.RawPatch(types.MergePatchType, []byte(`{"spec":{"replicas":0}}`))
+	if err := r.writer.Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("scaling down: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/ownerhandling"
+	"package-operator.run/package-operator/internal/preflight"
+	"package-operator.run/package-operator/internal/testutil/faultinjection"
+)
+
+type archivablePhaseObjectOwnerMock struct {
+	phaseObjectOwnerMock
+	archived bool
+}
+
+func (m *archivablePhaseObjectOwnerMock) IsArchived() bool {
+	return m.archived
+}
+
+func TestPhaseReconciler_TeardownPhase_scaleDown(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	ownerStrategy := ownerhandling.NewNative(scheme)
+
+	ownerObj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "owner", Namespace: "test", UID: "owner-uid",
+	}}
+	newObjects := func() []client.Object {
+		deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "deploy", Namespace: "test",
+		}, Spec: appsv1.DeploymentSpec{Replicas: pointer.Int32(3)}}
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: "config", Namespace: "test",
+		}}
+		for _, obj := range []client.Object{deploy, cm} {
+			require.NoError(t, ownerStrategy.SetControllerReference(ownerObj, obj))
+		}
+		return []client.Object{deploy, cm}
+	}
+	newPhaseObject := func(apiVersion, kind, name string) corev1alpha1.ObjectSetObject {
+		obj := unstructured.Unstructured{}
+		obj.SetAPIVersion(apiVersion)
+		obj.SetKind(kind)
+		obj.SetName(name)
+		return corev1alpha1.ObjectSetObject{
+			Object:         obj,
+			DeletionPolicy: corev1alpha1.ObjectSetObjectDeletionPolicyScaleDown,
+		}
+	}
+	phase := corev1alpha1.ObjectSetTemplatePhase{
+		Name: "deploy",
+		Objects: []corev1alpha1.ObjectSetObject{
+			newPhaseObject("apps/v1", "Deployment", "deploy"),
+			newPhaseObject("v1", "ConfigMap", "config"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		archived        bool
+		expectScaleDown bool
+	}{
+		{name: "archived", archived: true, expectScaleDown: true},
+		{name: "deleted", archived: false, expectScaleDown: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newObjects()...).Build()
+			pcm := &preflightCheckerMock{}
+			pcm.
+				On("Check", mock.Anything, mock.Anything, mock.Anything).
+				Return([]preflight.Violation{}, nil)
+			pr := NewPhaseReconciler(
+				scheme, c, faultinjection.Watchless(c), c,
+				ownerStrategy, pcm)
+
+			owner := &archivablePhaseObjectOwnerMock{archived: test.archived}
+			owner.On("ClientObject").Return(ownerObj)
+			owner.On("GetRevision").Return(int64(1))
+
+			ctx := context.Background()
+			_, err := pr.TeardownPhase(ctx, owner, phase)
+			require.NoError(t, err)
+
+			deploy := &appsv1.Deployment{}
+			err = c.Get(ctx, client.ObjectKey{Name: "deploy", Namespace: "test"}, deploy)
+			if test.expectScaleDown {
+				require.NoError(t, err)
+				assert.Equal(t, int32(0), *deploy.Spec.Replicas)
+				assert.True(t, ownerStrategy.IsController(ownerObj, deploy))
+			} else {
+				assert.True(t, errors.IsNotFound(err), "Deployment must be deleted")
+			}
+
+			// Kinds that can't be scaled are deleted.
+			err = c.Get(ctx, client.ObjectKey{Name: "config", Namespace: "test"}, &corev1.ConfigMap{})
+			assert.True(t, errors.IsNotFound(err), "ConfigMap must be deleted")
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package phaseactuatortest provides conformance tests for controllers.PhaseActuator implementations.
+package phaseactuatortest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"package-operator.run/package-operator/internal/controllers"
+)
+
+const (
+	conformanceNamespace = "default"
+	conformanceName      = "phase-actuator-conformance"
+	// Time to wait for writes to show up in the cache.
+	cacheSyncTimeout  = 10 * time.Second
+	cacheSyncInterval = 100 * time.Millisecond
+)
+
+var configMapGVK = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+// RunPhaseActuatorConformance verifies that the given PhaseActuator behaves
+// the way the PhaseReconciler expects its target to behave.
+// ConfigMap objects are created, updated and deleted in the "default" namespace.
+func RunPhaseActuatorConformance(t *testing.T, actuator controllers.PhaseActuator) {
+	t.Helper()
+
+	ctx := context.Background()
+	owner := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      conformanceName + "-owner",
+			Namespace: conformanceNamespace,
+			UID:       "conformance-owner-uid",
+		},
+	}
+	key := client.ObjectKey{Name: conformanceName, Namespace: conformanceNamespace}
+
+	t.Run("Watch", func(t *testing.T) {
+		require.NoError(t, actuator.Cache().Watch(ctx, owner, newObject()))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		err := actuator.UncachedReader().Get(ctx, key, newObject())
+		assert.True(t, errors.IsNotFound(err), "uncached reader must return NotFound errors, got: %v", err)
+
+		err = actuator.Cache().Get(ctx, key, newObject())
+		assert.True(t, errors.IsNotFound(err), "cache must return NotFound errors, got: %v", err)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		obj := newObject()
+		obj.SetName(key.Name)
+		obj.SetNamespace(key.Namespace)
+		require.NoError(t, unstructured.SetNestedField(obj.Object, "1", "data", "test"))
+		require.NoError(t, actuator.Writer().Create(ctx, obj))
+
+		require.NoError(t, actuator.UncachedReader().Get(ctx, key, newObject()))
+		assertEventuallyInCache(t, actuator, key, "1")
+	})
+
+	t.Run("Patch", func(t *testing.T) {
+		obj := newObject()
+		require.NoError(t, actuator.UncachedReader().Get(ctx, key, obj))
+
+		updated := obj.DeepCopy()
+		require.NoError(t, unstructured.SetNestedField(updated.Object, "2", "data", "test"))
+		require.NoError(t, actuator.Writer().Patch(ctx, updated, client.MergeFrom(obj)))
+
+		assertEventuallyInCache(t, actuator, key, "2")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		obj := newObject()
+		require.NoError(t, actuator.UncachedReader().Get(ctx, key, obj))
+
+		require.NoError(t, unstructured.SetNestedField(obj.Object, "3", "data", "test"))
+		require.NoError(t, actuator.Writer().Update(ctx, obj))
+
+		assertEventuallyInCache(t, actuator, key, "3")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		obj := newObject()
+		require.NoError(t, actuator.UncachedReader().Get(ctx, key, obj))
+		require.NoError(t, actuator.Writer().Delete(ctx, obj))
+
+		err := actuator.UncachedReader().Get(ctx, key, newObject())
+		assert.True(t, errors.IsNotFound(err), "deleted objects must not be found, got: %v", err)
+	})
+}
+
+func newObject() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(configMapGVK)
+	return obj
+}
+
+func assertEventuallyInCache(
+	t *testing.T, actuator controllers.PhaseActuator,
+	key client.ObjectKey, value string,
+) {
+	t.Helper()
+
+	assert.Eventually(t, func() bool {
+		obj := newObject()
+		if err := actuator.Cache().Get(context.Background(), key, obj); err != nil {
+			return false
+		}
+		v, _, _ := unstructured.NestedString(obj.Object, "data", "test")
+		return v == value
+	}, cacheSyncTimeout, cacheSyncInterval, "object must show up in cache with data.test=%s", value)
+}
@@ -0,0 +1,32 @@
+package phaseactuatortest
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/dynamiccache"
+)
+
+func TestRunPhaseActuatorConformance(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+
+	RunPhaseActuatorConformance(t, controllers.NewKubernetesPhaseActuator(
+		c, &watchlessCache{Reader: c}, c))
+}
+
+// Reads straight from the fake client, which needs no watches.
+type watchlessCache struct {
+	client.Reader
+}
+
+func (c *watchlessCache) Watch(
+	context.Context, client.Object, runtime.Object, ...dynamiccache.WatchOption,
+) error {
+	return nil
+}
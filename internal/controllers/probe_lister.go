@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"package-operator.run/package-operator/internal/dynamiccache"
+	"package-operator.run/package-operator/internal/probing"
+)
+
+// ProbeObjectLister lists objects for count probes from the dynamic cache.
+// Listed objects are watched on behalf of the owner,
+// so the watches are released together with all other watches of the owner.
+type ProbeObjectLister struct {
+	cache PhaseCache
+	owner client.Object
+}
+
+var _ probing.ObjectLister = (*ProbeObjectLister)(nil)
+
+// NewProbeObjectLister returns a ProbeObjectLister watching objects for the given owner.
+func NewProbeObjectLister(cache PhaseCache, owner client.Object) *ProbeObjectLister {
+	return &ProbeObjectLister{cache: cache, owner: owner}
+}
+
+func (l *ProbeObjectLister) ListObjects(
+	ctx context.Context, gvk schema.GroupVersionKind,
+	namespace string, selector labels.Selector,
+) ([]unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+
+	// Only cache objects the probe is interested in.
+	watchOpts := []dynamiccache.WatchOption{
+		dynamiccache.Selector{Label: selector},
+	}
+	listOpts := []client.ListOption{
+		client.MatchingLabelsSelector{Selector: selector},
+	}
+	if len(namespace) > 0 {
+		watchOpts = append(watchOpts, dynamiccache.WatchNamespace(namespace))
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+	if err := l.cache.Watch(ctx, l.owner, obj, watchOpts...); err != nil {
+		return nil, fmt.Errorf("watching %s: %w", gvk, err)
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := l.cache.List(ctx, list, listOpts...); err != nil {
+		return nil, fmt.Errorf("listing %s: %w", gvk, err)
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+// Defaults of the controller-runtime workqueue rate limiter.
+const (
+	DefaultRateLimiterBaseDelay = 5 * time.Millisecond
+	DefaultRateLimiterMaxDelay  = 1000 * time.Second
+	DefaultRateLimiterQPS       = 10
+	DefaultRateLimiterBurst     = 100
+)
+
+// RateLimiterConfig configures how fast controllers process their workqueue.
+type RateLimiterConfig struct {
+	// Delay before retrying a failed object, doubled on every consecutive failure.
+	BaseDelay time.Duration
+	// Upper bound of the per-object retry delay.
+	MaxDelay time.Duration
+	// Overall number of objects processed per second.
+	QPS float64
+	// Overall number of objects processed in a burst.
+	Burst int
+}
+
+// NewRateLimiter returns a new rate limiter combining per-object exponential backoff
+// with an overall token bucket, like the controller-runtime default.
+// Every controller needs its own instance, because failures are tracked by request.
+func (c RateLimiterConfig) NewRateLimiter() ratelimiter.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(c.BaseDelay, c.MaxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(c.QPS), c.Burst)},
+	)
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ShardLabel assigns an object to the shard with the given index,
+// e.g. set by a coordinator balancing objects across manager replicas.
+// Objects without this label are assigned by a hash of their namespace and name.
+// ObjectSets propagate this label to their ObjectSetPhases.
+const ShardLabel = "package-operator.run/shard"
+
+// Shard is the partition of objects reconciled by a manager replica,
+// so multiple replicas can actively reconcile disjoint subsets of objects.
+// The zero value owns all objects.
+type Shard struct {
+	// Index of this shard, between 0 and Count-1.
+	Index int
+	// Total number of shards.
+	Count int
+}
+
+// Validate returns an error if the count is negative or the index is out of range.
+func (s Shard) Validate() error {
+	if s.Count < 0 {
+		return fmt.Errorf("invalid shard count %d, must not be negative", s.Count)
+	}
+	count := s.Count
+	if count == 0 {
+		count = 1
+	}
+	if s.Index < 0 || s.Index >= count {
+		return fmt.Errorf("invalid shard index %d, must be between 0 and %d", s.Index, count-1)
+	}
+	return nil
+}
+
+// IsSharded returns true if objects are partitioned across multiple shards.
+func (s Shard) IsSharded() bool {
+	return s.Count > 1
+}
+
+// Owns returns true if the given object is reconciled by this shard.
+func (s Shard) Owns(obj client.Object) bool {
+	if !s.IsSharded() {
+		return true
+	}
+	return s.indexOf(obj, client.ObjectKeyFromObject(obj)) == s.Index
+}
+
+// OwnsDependent returns true if the controller of the given object is reconciled by this shard,
+// so dependents, like ObjectSetPhases, are reconciled together with their owner.
+// Objects without controller are assigned like in Owns.
+func (s Shard) OwnsDependent(obj client.Object) bool {
+	if !s.IsSharded() {
+		return true
+	}
+	key := client.ObjectKeyFromObject(obj)
+	if owner := metav1.GetControllerOf(obj); owner != nil {
+		// Owners are always in the namespace of their dependents or cluster-scoped.
+		key.Name = owner.Name
+	}
+	return s.indexOf(obj, key) == s.Index
+}
+
+func (s Shard) indexOf(obj client.Object, key client.ObjectKey) int {
+	if v, ok := obj.GetLabels()[ShardLabel]; ok {
+		// Invalid labels fall back to the hash, so the object is still reconciled by exactly one shard.
+		if i, err := strconv.Atoi(v); err == nil && i >= 0 {
+			return i % s.Count
+		}
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key.String()))
+	return int(h.Sum32() % uint32(s.Count))
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestShard_Owns(t *testing.T) {
+	t.Parallel()
+
+	newObjectSet := func(name string, labels map[string]string) *corev1alpha1.ObjectSet {
+		return &corev1alpha1.ObjectSet{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "test", Labels: labels,
+		}}
+	}
+
+	t.Run("unsharded", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, Shard{}.Owns(newObjectSet("test", nil)))
+		assert.True(t, Shard{Count: 1}.Owns(newObjectSet("test", nil)))
+	})
+
+	t.Run("disjoint", func(t *testing.T) {
+		t.Parallel()
+
+		shards := []Shard{{Index: 0, Count: 3}, {Index: 1, Count: 3}, {Index: 2, Count: 3}}
+		owned := make([]int, len(shards))
+		for i := 0; i < 100; i++ {
+			obj := newObjectSet(fmt.Sprintf("test-%d", i), nil)
+
+			var owners int
+			for j, s := range shards {
+				if s.Owns(obj) {
+					owners++
+					owned[j]++
+				}
+			}
+			assert.Equal(t, 1, owners, "object must be owned by exactly one shard")
+		}
+		for _, n := range owned {
+			assert.NotZero(t, n)
+		}
+	})
+
+	t.Run("label", func(t *testing.T) {
+		t.Parallel()
+
+		shard := Shard{Index: 1, Count: 2}
+		assert.True(t, shard.Owns(newObjectSet("test", map[string]string{ShardLabel: "1"})))
+		assert.True(t, shard.Owns(newObjectSet("test", map[string]string{ShardLabel: "3"})))
+		assert.False(t, shard.Owns(newObjectSet("test", map[string]string{ShardLabel: "2"})))
+
+		// Invalid labels fall back to the hash.
+		obj := newObjectSet("test", map[string]string{ShardLabel: "banana"})
+		assert.Equal(t, shard.Owns(newObjectSet("test", nil)), shard.Owns(obj))
+	})
+}
+
+func TestShard_OwnsDependent(t *testing.T) {
+	t.Parallel()
+
+	shards := []Shard{{Index: 0, Count: 3}, {Index: 1, Count: 3}, {Index: 2, Count: 3}}
+	for i := 0; i < 100; i++ {
+		objectSet := &corev1alpha1.ObjectSet{ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("test-%d", i), Namespace: "test",
+		}}
+		phase := &corev1alpha1.ObjectSetPhase{ObjectMeta: metav1.ObjectMeta{
+			Name: objectSet.Name + "-remote", Namespace: "test",
+			OwnerReferences: []metav1.OwnerReference{{
+				Kind: "ObjectSet", Name: objectSet.Name, Controller: pointer.Bool(true),
+			}},
+		}}
+		for _, s := range shards {
+			assert.Equal(t, s.Owns(objectSet), s.OwnsDependent(phase),
+				"ObjectSetPhase must be reconciled by the shard of its ObjectSet")
+		}
+	}
+
+	// Labels take precedence, as they are propagated from the owner.
+	phase := &corev1alpha1.ObjectSetPhase{ObjectMeta: metav1.ObjectMeta{
+		Name: "test-remote", Namespace: "test",
+		Labels: map[string]string{ShardLabel: "1"},
+		OwnerReferences: []metav1.OwnerReference{{
+			Kind: "ObjectSet", Name: "test", Controller: pointer.Bool(true),
+		}},
+	}}
+	assert.True(t, Shard{Index: 1, Count: 3}.OwnsDependent(phase))
+}
+
+func TestShard_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Shard{}.Validate())
+	assert.NoError(t, Shard{Index: 2, Count: 3}.Validate())
+	assert.EqualError(t, Shard{Index: 3, Count: 3}.Validate(),
+		"invalid shard index 3, must be between 0 and 2")
+	assert.EqualError(t, Shard{Index: -1, Count: 3}.Validate(),
+		"invalid shard index -1, must be between 0 and 2")
+	assert.EqualError(t, Shard{Count: -1}.Validate(),
+		"invalid shard count -1, must not be negative")
+}
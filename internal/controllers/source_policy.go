@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/preflight"
+)
+
+// ObjectTemplateSourcePolicyChecker is a preflight check enforcing
+// the ClusterObjectTemplate source policy of the PackageOperatorConfig,
+// so ClusterObjectTemplates can't be used to read arbitrary objects, e.g. Secrets.
+// Namespaced ObjectTemplates are already restricted to their own namespace.
+// A nil *ObjectTemplateSourcePolicyChecker allows all sources.
+type ObjectTemplateSourcePolicyChecker struct {
+	client client.Reader
+}
+
+func NewObjectTemplateSourcePolicyChecker(client client.Reader) *ObjectTemplateSourcePolicyChecker {
+	return &ObjectTemplateSourcePolicyChecker{client: client}
+}
+
+// Check reports a violation when the given ClusterObjectTemplate
+// is not allowed to read the given source object.
+func (c *ObjectTemplateSourcePolicyChecker) Check(
+	ctx context.Context, owner, obj client.Object,
+) (violations []preflight.Violation, err error) {
+	if c == nil {
+		return nil, nil
+	}
+	if _, ok := owner.(*corev1alpha1.ClusterObjectTemplate); !ok {
+		return nil, nil
+	}
+
+	config, err := GetPackageOperatorConfig(ctx, c.client)
+	if err != nil {
+		return nil, err
+	}
+	policy := config.Spec.ClusterObjectTemplateSourcePolicy
+	if policy == nil || IsSourceAllowed(*policy, obj) {
+		return nil, nil
+	}
+	return []preflight.Violation{{
+		Position: fmt.Sprintf("%s %s",
+			obj.GetObjectKind().GroupVersionKind().Kind, client.ObjectKeyFromObject(obj)),
+		Error: "Not allowed by the ClusterObjectTemplate source policy of the PackageOperatorConfig.",
+	}}, nil
+}
+
+// IsSourceAllowed returns true when the given object matches
+// an allow rule of the policy and no deny rule.
+func IsSourceAllowed(policy corev1alpha1.ObjectTemplateSourcePolicy, obj client.Object) bool {
+	for _, rule := range policy.Deny {
+		if sourceRuleMatches(rule, obj) {
+			return false
+		}
+	}
+	if len(policy.Allow) == 0 {
+		return true
+	}
+	for _, rule := range policy.Allow {
+		if sourceRuleMatches(rule, obj) {
+			return true
+		}
+	}
+	return false
+}
+
+func sourceRuleMatches(rule corev1alpha1.ObjectTemplateSourceRule, obj client.Object) bool {
+	if len(rule.Namespaces) > 0 && !slices.Contains(rule.Namespaces, obj.GetNamespace()) {
+		return false
+	}
+	if len(rule.Kinds) == 0 {
+		return true
+	}
+
+	gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+	for _, kind := range rule.Kinds {
+		if (kind.Group == "*" || kind.Group == gk.Group) &&
+			(kind.Kind == "*" || kind.Kind == gk.Kind) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func newSource(apiVersion, kind, namespace string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName("test")
+	return obj
+}
+
+func TestIsSourceAllowed(t *testing.T) {
+	t.Parallel()
+
+	policy := corev1alpha1.ObjectTemplateSourcePolicy{
+		Allow: []corev1alpha1.ObjectTemplateSourceRule{
+			{Namespaces: []string{"team-a", "team-b"}},
+			{Kinds: []corev1alpha1.ObjectTemplateSourceRuleKind{{Kind: "ConfigMap"}}},
+		},
+		Deny: []corev1alpha1.ObjectTemplateSourceRule{
+			{
+				Namespaces: []string{"team-b"},
+				Kinds:      []corev1alpha1.ObjectTemplateSourceRuleKind{{Kind: "Secret"}},
+			},
+			{Kinds: []corev1alpha1.ObjectTemplateSourceRuleKind{{Group: "*", Kind: "Token"}}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		obj      client.Object
+		expected bool
+	}{
+		{"allowed namespace", newSource("v1", "Secret", "team-a"), true},
+		{"allowed kind", newSource("v1", "ConfigMap", "other"), true},
+		{"not allowed", newSource("v1", "Secret", "other"), false},
+		{"other group", newSource("example.com/v1", "ConfigMap", "other"), false},
+		{"denied namespace and kind", newSource("v1", "Secret", "team-b"), false},
+		{"denied any group", newSource("example.com/v1", "Token", "team-a"), false},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, test.expected, IsSourceAllowed(policy, test.obj))
+		})
+	}
+
+	assert.True(t, IsSourceAllowed(
+		corev1alpha1.ObjectTemplateSourcePolicy{}, newSource("v1", "Secret", "other")))
+}
+
+func TestObjectTemplateSourcePolicyChecker(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, client.ObjectKey{Name: "cluster"},
+			mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			config := args.Get(2).(*corev1alpha1.PackageOperatorConfig)
+			config.Spec.ClusterObjectTemplateSourcePolicy = &corev1alpha1.ObjectTemplateSourcePolicy{
+				Deny: []corev1alpha1.ObjectTemplateSourceRule{
+					{Kinds: []corev1alpha1.ObjectTemplateSourceRuleKind{{Kind: "Secret"}}},
+				},
+			}
+		}).
+		Return(nil)
+
+	ctx := context.Background()
+	checker := NewObjectTemplateSourcePolicyChecker(c)
+	secret := newSource("v1", "Secret", "kube-system")
+
+	violations, err := checker.Check(ctx, &corev1alpha1.ClusterObjectTemplate{}, secret)
+	require.NoError(t, err)
+	if assert.Len(t, violations, 1) {
+		assert.Equal(t, "Secret kube-system/test", violations[0].Position)
+	}
+
+	// Namespaced ObjectTemplates are not restricted by the policy.
+	violations, err = checker.Check(ctx, &corev1alpha1.ObjectTemplate{}, secret)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	var nilChecker *ObjectTemplateSourcePolicyChecker
+	violations, err = nilChecker.Check(ctx, &corev1alpha1.ClusterObjectTemplate{}, secret)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
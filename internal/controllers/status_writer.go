@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Default time within which successive status updates of the same object are coalesced.
+const DefaultStatusCoalesceInterval = 2 * time.Second
+
+// StatusWriter updates the status of objects, skipping updates
+// that would not change the status semantically
+// and coalescing rapid successive updates of the same object.
+// Coalescing is only safe for controllers that compute the whole status on every reconcile,
+// because deferred updates are only written by the next reconcile.
+type StatusWriter struct {
+	client           client.StatusClient
+	clock            clock.PassiveClock
+	coalesceInterval time.Duration
+
+	lastUpdateMux sync.Mutex
+	lastUpdate    map[types.UID]time.Time
+}
+
+type StatusWriterOption func(w *StatusWriter)
+
+// WithStatusCoalesceInterval sets the time within which
+// successive status updates of the same object are coalesced.
+// 0 disables coalescing.
+func WithStatusCoalesceInterval(interval time.Duration) StatusWriterOption {
+	return func(w *StatusWriter) {
+		w.coalesceInterval = interval
+	}
+}
+
+func NewStatusWriter(c client.StatusClient, opts ...StatusWriterOption) *StatusWriter {
+	w := &StatusWriter{
+		client:           c,
+		clock:            clock.RealClock{},
+		coalesceInterval: DefaultStatusCoalesceInterval,
+		lastUpdate:       map[types.UID]time.Time{},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Update writes the status of obj, unless it is semantically equal to the status of original,
+// which is the object as it was read at the start of the reconcile.
+// When the status of the same object was already written within the coalesce interval,
+// the update is deferred and res is changed to reconcile again after the interval.
+func (w *StatusWriter) Update(
+	ctx context.Context, original, obj client.Object, res ctrl.Result,
+) (ctrl.Result, error) {
+	equal, err := StatusSemanticallyEqual(original, obj)
+	if err != nil {
+		return res, err
+	}
+	if equal {
+		return res, nil
+	}
+
+	if wait := w.coalesceWait(obj.GetUID()); wait > 0 {
+		if res.RequeueAfter == 0 || wait < res.RequeueAfter {
+			res.RequeueAfter = wait
+		}
+		return res, nil
+	}
+
+	if err := w.client.Status().Update(ctx, obj); err != nil {
+		return res, err
+	}
+	w.recordUpdate(obj.GetUID())
+	return res, nil
+}
+
+// Returns the time to wait until the status of the object may be written again.
+func (w *StatusWriter) coalesceWait(uid types.UID) time.Duration {
+	if w.coalesceInterval == 0 || len(uid) == 0 {
+		return 0
+	}
+
+	w.lastUpdateMux.Lock()
+	defer w.lastUpdateMux.Unlock()
+
+	last, ok := w.lastUpdate[uid]
+	if !ok {
+		return 0
+	}
+	return last.Add(w.coalesceInterval).Sub(w.clock.Now())
+}
+
+func (w *StatusWriter) recordUpdate(uid types.UID) {
+	if w.coalesceInterval == 0 || len(uid) == 0 {
+		return
+	}
+
+	w.lastUpdateMux.Lock()
+	defer w.lastUpdateMux.Unlock()
+
+	now := w.clock.Now()
+	// Forget objects that can't be coalesced anymore, so the map does not grow forever.
+	for k, last := range w.lastUpdate {
+		if now.Sub(last) >= w.coalesceInterval {
+			delete(w.lastUpdate, k)
+		}
+	}
+	w.lastUpdate[uid] = now
+}
+
+// StatusSemanticallyEqual compares the status of both objects,
+// ignoring condition timestamps.
+func StatusSemanticallyEqual(a, b client.Object) (bool, error) {
+	aStatus, err := semanticStatus(a)
+	if err != nil {
+		return false, err
+	}
+	bStatus, err := semanticStatus(b)
+	if err != nil {
+		return false, err
+	}
+	return equality.Semantic.DeepEqual(aStatus, bStatus), nil
+}
+
+func semanticStatus(obj client.Object) (interface{}, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("converting to unstructured: %w", err)
+	}
+
+	status, ok := u["status"].(map[string]interface{})
+	if !ok {
+		return u["status"], nil
+	}
+	if conditions, ok := status["conditions"].([]interface{}); ok {
+		for _, c := range conditions {
+			if cond, ok := c.(map[string]interface{}); ok {
+				delete(cond, "lastTransitionTime")
+			}
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestStatusWriter(t *testing.T) {
+	c := testutil.NewClient()
+	c.StatusMock.
+		On("Update", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	clock := clocktesting.NewFakePassiveClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	w := NewStatusWriter(c)
+	w.clock = clock
+
+	original := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "1234"},
+	}
+	meta.SetStatusCondition(&original.Status.Conditions, metav1.Condition{
+		Type:               corev1alpha1.ObjectSetAvailable,
+		Status:             metav1.ConditionFalse,
+		Reason:             corev1alpha1.ReasonProbeFailure,
+		LastTransitionTime: metav1.NewTime(clock.Now().Add(-time.Hour)),
+	})
+	ctx := context.Background()
+
+	// only timestamps changed
+	obj := original.DeepCopy()
+	obj.Status.Conditions[0].LastTransitionTime = metav1.NewTime(clock.Now())
+	res, err := w.Update(ctx, original, obj, ctrl.Result{})
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	c.StatusMock.AssertNumberOfCalls(t, "Update", 0)
+
+	// status changed
+	obj = original.DeepCopy()
+	obj.Status.Conditions[0].Status = metav1.ConditionTrue
+	obj.Status.Conditions[0].Reason = corev1alpha1.ReasonAvailable
+	res, err = w.Update(ctx, original, obj, ctrl.Result{})
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	c.StatusMock.AssertNumberOfCalls(t, "Update", 1)
+
+	// status changed again right after, update is deferred
+	clock.SetTime(clock.Now().Add(500 * time.Millisecond))
+	original = obj
+	obj = original.DeepCopy()
+	obj.Status.Phase = corev1alpha1.ObjectSetStatusPhaseAvailable
+	res, err = w.Update(ctx, original, obj, ctrl.Result{RequeueAfter: time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, res.RequeueAfter)
+	c.StatusMock.AssertNumberOfCalls(t, "Update", 1)
+
+	// next reconcile after the coalesce interval
+	clock.SetTime(clock.Now().Add(res.RequeueAfter))
+	res, err = w.Update(ctx, original, obj, ctrl.Result{})
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	c.StatusMock.AssertNumberOfCalls(t, "Update", 2)
+}
+
+func TestStatusSemanticallyEqual(t *testing.T) {
+	t.Parallel()
+
+	a := &corev1alpha1.ObjectSet{}
+	meta.SetStatusCondition(&a.Status.Conditions, metav1.Condition{
+		Type:               corev1alpha1.ObjectSetAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             corev1alpha1.ReasonAvailable,
+		LastTransitionTime: metav1.NewTime(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)),
+	})
+
+	b := a.DeepCopy()
+	b.Status.Conditions[0].LastTransitionTime = metav1.Now()
+	b.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStatePaused
+	equal, err := StatusSemanticallyEqual(a, b)
+	require.NoError(t, err)
+	assert.True(t, equal)
+
+	b.Status.Conditions[0].ObservedGeneration = 2
+	equal, err = StatusSemanticallyEqual(a, b)
+	require.NoError(t, err)
+	assert.False(t, equal)
+
+	// original status must not be modified by the comparison
+	assert.False(t, a.Status.Conditions[0].LastTransitionTime.IsZero())
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -31,12 +32,12 @@ type OwnerReference struct {
 type informerMap interface {
 	Get(
 		ctx context.Context,
-		gvk schema.GroupVersionKind,
+		key informerKey,
 		obj runtime.Object,
 	) (informer cache.SharedIndexInformer, reader client.Reader, err error)
 	Delete(
 		ctx context.Context,
-		gvk schema.GroupVersionKind,
+		key informerKey,
 	) error
 }
 
@@ -57,7 +58,7 @@ type Cache struct {
 	informerMap informerMap
 
 	informerReferencesMux sync.RWMutex
-	informerReferences    map[schema.GroupVersionKind]map[OwnerReference]struct{}
+	informerReferences    map[informerKey]map[OwnerReference]struct{}
 
 	recorder metricsRecorder
 
@@ -67,6 +68,7 @@ type Cache struct {
 type metricsRecorder interface {
 	RecordDynamicCacheInformers(total int)
 	RecordDynamicCacheObjects(gvk schema.GroupVersionKind, count int)
+	RecordDynamicCacheObjectsTotal(total int)
 }
 
 func NewCache(
@@ -78,7 +80,7 @@ func NewCache(
 ) *Cache {
 	c := &Cache{
 		scheme:             scheme,
-		informerReferences: map[schema.GroupVersionKind]map[OwnerReference]struct{}{},
+		informerReferences: map[informerKey]map[OwnerReference]struct{}{},
 		cacheSource:        &cacheSource{},
 		recorder:           recorder,
 	}
@@ -116,23 +118,58 @@ func (c *Cache) OwnersForGKV(gvk schema.GroupVersionKind) []OwnerReference {
 	c.informerReferencesMux.RLock()
 	defer c.informerReferencesMux.RUnlock()
 
-	refs, ok := c.informerReferences[gvk]
-	if !ok {
-		return nil
+	var ownerRefs []OwnerReference
+	for ownerRef := range c.ownersByGVK()[gvk] {
+		ownerRefs = append(ownerRefs, ownerRef)
 	}
+	return ownerRefs
+}
+
+// Returns the number of owners watching each GroupVersionKind with an active informer.
+func (c *Cache) InformerOwners() map[schema.GroupVersionKind]int {
+	c.informerReferencesMux.RLock()
+	defer c.informerReferencesMux.RUnlock()
 
-	ownerRefs := make([]OwnerReference, len(refs))
-	var i int
-	for ownerRef := range refs {
-		ownerRefs[i] = ownerRef
-		i++
+	ownersByGVK := c.ownersByGVK()
+	owners := make(map[schema.GroupVersionKind]int, len(ownersByGVK))
+	for gvk, refs := range ownersByGVK {
+		owners[gvk] = len(refs)
 	}
-	return ownerRefs
+	return owners
+}
+
+// Merges owners of all informers of the same GroupVersionKind.
+func (c *Cache) ownersByGVK() map[schema.GroupVersionKind]map[OwnerReference]struct{} {
+	owners := map[schema.GroupVersionKind]map[OwnerReference]struct{}{}
+	for key, refs := range c.informerReferences {
+		if _, ok := owners[key.GroupVersionKind]; !ok {
+			owners[key.GroupVersionKind] = map[OwnerReference]struct{}{}
+		}
+		for ref := range refs {
+			owners[key.GroupVersionKind][ref] = struct{}{}
+		}
+	}
+	return owners
+}
+
+// Returns the keys of all informers for the given GroupVersionKind.
+func (c *Cache) informerKeysForGVK(gvk schema.GroupVersionKind) []informerKey {
+	var keys []informerKey
+	for key := range c.informerReferences {
+		if key.GroupVersionKind == gvk {
+			keys = append(keys, key)
+		}
+	}
+	return keys
 }
 
 // Watch the given object type and associate the watch with the given owner.
+// Watches with a namespace or selectors only cache matching objects,
+// using a separate informer for every distinct set of WatchOptions.
+// Watches of namespaced owners are restricted to the owner namespace, if the cache is OwnerNamespaceScoped.
 func (c *Cache) Watch(
 	ctx context.Context, owner client.Object, obj runtime.Object,
+	opts ...WatchOption,
 ) error {
 	c.informerReferencesMux.Lock()
 	defer c.informerReferencesMux.Unlock()
@@ -149,21 +186,33 @@ func (c *Cache) Watch(
 		return err
 	}
 
+	var watchOpts WatchOptions
+	for _, opt := range opts {
+		opt.ApplyToWatchOptions(&watchOpts)
+	}
+	if c.opts.OwnerNamespaceScoped && len(watchOpts.Namespace) == 0 {
+		watchOpts.Namespace = owner.GetNamespace()
+	}
+	key := informerKey{GroupVersionKind: gvk, watchScope: watchOpts.scope()}
+
 	// Remember Owner watching this GVK
-	_, informerExists := c.informerReferences[gvk]
+	_, informerExists := c.informerReferences[key]
 	if !informerExists {
-		c.informerReferences[gvk] = map[OwnerReference]struct{}{}
+		c.informerReferences[key] = map[OwnerReference]struct{}{}
 	}
-	c.informerReferences[gvk][ownerRef] = struct{}{}
+	c.informerReferences[key][ownerRef] = struct{}{}
 
 	if !informerExists {
 		log.Info("adding new watcher",
 			"ownerGV", ownerRef.GroupKind,
 			"forGVK", gvk.String(),
-			"ownerNamespace", owner.GetNamespace())
+			"ownerNamespace", owner.GetNamespace(),
+			"watchNamespace", key.Namespace,
+			"labelSelector", key.Label,
+			"fieldSelector", key.Field)
 
 		// Create/Get Informer
-		informer, _, err := c.informerMap.Get(ctx, gvk, obj)
+		informer, _, err := c.informerMap.Get(ctx, key, obj)
 		if err != nil {
 			return fmt.Errorf("getting informer from InformerMap: %w", err)
 		}
@@ -192,20 +241,21 @@ func (c *Cache) Free(
 		return err
 	}
 
-	for gvk, refs := range c.informerReferences {
+	for key, refs := range c.informerReferences {
 		if _, ok := refs[ownerRef]; ok {
 			delete(refs, ownerRef)
 
 			if len(refs) == 0 {
 				log.Info("releasing watcher",
-					"kind", gvk.Kind, "group", gvk.Group,
-					"ownerNamespace", owner.GetNamespace())
+					"kind", key.Kind, "group", key.Group,
+					"ownerNamespace", owner.GetNamespace(),
+					"watchNamespace", key.Namespace)
 
-				if err := c.informerMap.Delete(ctx, gvk); err != nil {
-					return fmt.Errorf("releasing informer for %v: %w", gvk, err)
+				if err := c.informerMap.Delete(ctx, key); err != nil {
+					return fmt.Errorf("releasing informer for %v: %w", key.GroupVersionKind, err)
 				}
 
-				delete(c.informerReferences, gvk)
+				delete(c.informerReferences, key)
 			}
 		}
 	}
@@ -234,16 +284,33 @@ func (c *Cache) Get(
 	// And that the cache is not deleted while the get call is still in-flight.
 	c.informerReferencesMux.RLock()
 	defer c.informerReferencesMux.RUnlock()
-	if _, ok := c.informerReferences[gvk]; !ok {
+	informerKeys := c.informerKeysForGVK(gvk)
+	if len(informerKeys) == 0 {
 		return &CacheNotStartedError{}
 	}
 
-	_, reader, err := c.informerMap.Get(ctx, gvk, out)
-	if err != nil {
-		return fmt.Errorf("getting Informer from Map: %w", err)
+	// Try all informers that may contain the object.
+	for _, informerKey := range informerKeys {
+		if len(informerKey.Namespace) > 0 && informerKey.Namespace != key.Namespace {
+			continue
+		}
+
+		_, reader, err := c.informerMap.Get(ctx, informerKey, out)
+		if err != nil {
+			return fmt.Errorf("getting Informer from Map: %w", err)
+		}
+
+		err = reader.Get(ctx, key, out, opts...)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		return err
 	}
 
-	return reader.Get(ctx, key, out, opts...)
+	return apierrors.NewNotFound(schema.GroupResource{
+		Group:    gvk.Group,
+		Resource: gvk.Kind,
+	}, key.Name)
 }
 
 // List implements client.Reader.
@@ -269,16 +336,60 @@ func (c *Cache) list(
 	}
 	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
 
-	if _, ok := c.informerReferences[gvk]; !ok {
+	informerKeys := c.informerKeysForGVK(gvk)
+	if len(informerKeys) == 0 {
 		return &CacheNotStartedError{}
 	}
 
-	_, reader, err := c.informerMap.Get(ctx, gvk, out)
-	if err != nil {
-		return fmt.Errorf("getting Informer from Map: %w", err)
+	if len(informerKeys) == 1 {
+		_, reader, err := c.informerMap.Get(ctx, informerKeys[0], out)
+		if err != nil {
+			return fmt.Errorf("getting Informer from Map: %w", err)
+		}
+
+		return reader.List(ctx, out, opts...)
 	}
 
-	return reader.List(ctx, out, opts...)
+	// Merge objects of all informers, skipping objects found in multiple informers.
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	var (
+		items []runtime.Object
+		seen  = map[client.ObjectKey]struct{}{}
+	)
+	for _, informerKey := range informerKeys {
+		if len(informerKey.Namespace) > 0 && len(listOpts.Namespace) > 0 &&
+			informerKey.Namespace != listOpts.Namespace {
+			continue
+		}
+
+		_, reader, err := c.informerMap.Get(ctx, informerKey, out)
+		if err != nil {
+			return fmt.Errorf("getting Informer from Map: %w", err)
+		}
+
+		partialList := out.DeepCopyObject().(client.ObjectList)
+		if err := reader.List(ctx, partialList, opts...); err != nil {
+			return err
+		}
+		partialItems, err := meta.ExtractList(partialList)
+		if err != nil {
+			return fmt.Errorf("extracting list items: %w", err)
+		}
+		for _, item := range partialItems {
+			obj, err := meta.Accessor(item)
+			if err != nil {
+				return fmt.Errorf("accessing list item: %w", err)
+			}
+			objKey := client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+			if _, ok := seen[objKey]; ok {
+				continue
+			}
+			seen[objKey] = struct{}{}
+			items = append(items, item)
+		}
+	}
+	return meta.SetList(out, items)
 }
 
 func (c *Cache) ownerRef(owner client.Object) (OwnerReference, error) {
@@ -309,7 +420,8 @@ func (c *Cache) sampleMetrics(ctx context.Context) {
 	informerCount := len(c.informerReferences)
 	c.recorder.RecordDynamicCacheInformers(informerCount)
 
-	for gvk := range c.informerReferences {
+	var total int
+	for gvk := range c.ownersByGVK() {
 		listObj := &unstructured.UnstructuredList{}
 		listObj.SetGroupVersionKind(schema.GroupVersionKind{
 			Group:   gvk.Group,
@@ -321,5 +433,7 @@ func (c *Cache) sampleMetrics(ctx context.Context) {
 			continue
 		}
 		c.recorder.RecordDynamicCacheObjects(gvk, len(listObj.Items))
+		total += len(listObj.Items)
 	}
+	c.recorder.RecordDynamicCacheObjectsTotal(total)
 }
@@ -8,8 +8,10 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
@@ -62,7 +64,13 @@ func TestCache_OwnersForGVK(t *testing.T) {
 		Version: "v1beta3",
 		Group:   "testing.package-operator.run",
 	}
-	c.informerReferences[gvk] = map[OwnerReference]struct{}{
+	c.informerReferences[informerKey{GroupVersionKind: gvk}] = map[OwnerReference]struct{}{
+		owner1: {},
+	}
+	c.informerReferences[informerKey{
+		GroupVersionKind: gvk,
+		watchScope:       watchScope{Namespace: "test"},
+	}] = map[OwnerReference]struct{}{
 		owner1: {},
 		owner2: {},
 	}
@@ -74,6 +82,22 @@ func TestCache_OwnersForGVK(t *testing.T) {
 	}
 }
 
+func TestCache_InformerOwners(t *testing.T) {
+	c, _, _ := setupTestCache(t)
+
+	gvk := schema.GroupVersionKind{
+		Kind:    "Test",
+		Version: "v1beta3",
+		Group:   "testing.package-operator.run",
+	}
+	c.informerReferences[informerKey{GroupVersionKind: gvk}] = map[OwnerReference]struct{}{
+		{Name: "test1"}: {},
+		{Name: "test2"}: {},
+	}
+
+	assert.Equal(t, map[schema.GroupVersionKind]int{gvk: 2}, c.InformerOwners())
+}
+
 func TestCache_Watch(t *testing.T) {
 	t.Run("default", func(t *testing.T) {
 		c, cacheSource, informerMap := setupTestCache(t)
@@ -99,18 +123,91 @@ func TestCache_Watch(t *testing.T) {
 		err := c.Watch(ctx, owner, obj)
 		require.NoError(t, err)
 
-		informerMap.AssertCalled(t, "Get", mock.Anything, schema.GroupVersionKind{
-			Kind:    "Secret",
-			Version: "v1",
+		informerMap.AssertCalled(t, "Get", mock.Anything, informerKey{
+			GroupVersionKind: schema.GroupVersionKind{
+				Kind:    "Secret",
+				Version: "v1",
+			},
 		}, obj, mock.Anything)
 		cacheSource.AssertCalled(t, "handleNewInformer", mock.Anything)
 	})
 
+	t.Run("scoped", func(t *testing.T) {
+		c, cacheSource, informerMap := setupTestCache(t)
+
+		informerMap.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil, nil)
+		cacheSource.On("handleNewInformer", mock.Anything).Return(nil)
+
+		ctx := context.Background()
+		owner := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test42",
+				Namespace: "test",
+			},
+		}
+		obj := &corev1.Secret{}
+		selector := Selector{
+			Label: labels.SelectorFromSet(labels.Set{"app": "test"}),
+		}
+		require.NoError(t, c.Watch(ctx, owner, obj))
+		require.NoError(t, c.Watch(ctx, owner, obj, WatchNamespace("test"), selector))
+
+		secretGVK := schema.GroupVersionKind{Kind: "Secret", Version: "v1"}
+		informerMap.AssertCalled(t, "Get", mock.Anything, informerKey{
+			GroupVersionKind: secretGVK,
+		}, obj, mock.Anything)
+		informerMap.AssertCalled(t, "Get", mock.Anything, informerKey{
+			GroupVersionKind: secretGVK,
+			watchScope: watchScope{
+				Namespace: "test",
+				Label:     "app=test",
+			},
+		}, obj, mock.Anything)
+		cacheSource.AssertNumberOfCalls(t, "handleNewInformer", 2)
+		assert.Equal(t, map[schema.GroupVersionKind]int{secretGVK: 1}, c.InformerOwners())
+	})
+
+	t.Run("owner namespace scoped", func(t *testing.T) {
+		c, cacheSource, informerMap := setupTestCache(t)
+		OwnerNamespaceScoped(true).ApplyToCacheOptions(&c.opts)
+
+		informerMap.
+			On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil, nil)
+		cacheSource.On("handleNewInformer", mock.Anything).Return(nil)
+
+		ctx := context.Background()
+		owner := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test42",
+				Namespace: "test",
+			},
+		}
+		obj := &corev1.Secret{}
+		require.NoError(t, c.Watch(ctx, owner, obj))
+		require.NoError(t, c.Watch(ctx, owner, obj, WatchNamespace("other")))
+
+		secretGVK := schema.GroupVersionKind{Kind: "Secret", Version: "v1"}
+		informerMap.AssertCalled(t, "Get", mock.Anything, informerKey{
+			GroupVersionKind: secretGVK,
+			watchScope:       watchScope{Namespace: "test"},
+		}, obj, mock.Anything)
+		informerMap.AssertCalled(t, "Get", mock.Anything, informerKey{
+			GroupVersionKind: secretGVK,
+			watchScope:       watchScope{Namespace: "other"},
+		}, obj, mock.Anything)
+		cacheSource.AssertNumberOfCalls(t, "handleNewInformer", 2)
+	})
+
 	t.Run("informer exists", func(t *testing.T) {
 		c, cacheSource, informerMap := setupTestCache(t)
-		c.informerReferences[schema.GroupVersionKind{
-			Kind:    "Secret",
-			Version: "v1",
+		c.informerReferences[informerKey{
+			GroupVersionKind: schema.GroupVersionKind{
+				Kind:    "Secret",
+				Version: "v1",
+			},
 		}] = map[OwnerReference]struct{}{}
 
 		informerMap.
@@ -149,9 +246,11 @@ func TestCache_Free(t *testing.T) {
 	}
 	ref, err := c.ownerRef(owner)
 	require.NoError(t, err)
-	c.informerReferences[schema.GroupVersionKind{
-		Kind:    "Secret",
-		Version: "v1",
+	c.informerReferences[informerKey{
+		GroupVersionKind: schema.GroupVersionKind{
+			Kind:    "Secret",
+			Version: "v1",
+		},
 	}] = map[OwnerReference]struct{}{
 		ref: {},
 	}
@@ -163,9 +262,11 @@ func TestCache_Free(t *testing.T) {
 	err = c.Free(ctx, owner)
 	require.NoError(t, err)
 
-	informerMap.AssertCalled(t, "Delete", mock.Anything, schema.GroupVersionKind{
-		Kind:    "Secret",
-		Version: "v1",
+	informerMap.AssertCalled(t, "Delete", mock.Anything, informerKey{
+		GroupVersionKind: schema.GroupVersionKind{
+			Kind:    "Secret",
+			Version: "v1",
+		},
 	})
 }
 
@@ -179,9 +280,11 @@ func TestCache_Reader(t *testing.T) {
 	}
 	ref, err := c.ownerRef(owner)
 	require.NoError(t, err)
-	c.informerReferences[schema.GroupVersionKind{
-		Kind:    "Secret",
-		Version: "v1",
+	c.informerReferences[informerKey{
+		GroupVersionKind: schema.GroupVersionKind{
+			Kind:    "Secret",
+			Version: "v1",
+		},
 	}] = map[OwnerReference]struct{}{
 		ref: {},
 	}
@@ -221,7 +324,7 @@ func TestCache_Reader(t *testing.T) {
 
 	// "reset" informerReferences to test error case,
 	// when no informer has been registered beforehand.
-	c.informerReferences = map[schema.GroupVersionKind]map[OwnerReference]struct{}{}
+	c.informerReferences = map[informerKey]map[OwnerReference]struct{}{}
 
 	t.Run("Get no informer", func(t *testing.T) {
 		obj := &corev1.Secret{}
@@ -241,6 +344,83 @@ func TestCache_Reader(t *testing.T) {
 	})
 }
 
+func TestCache_Reader_scoped(t *testing.T) {
+	c, _, informerMap := setupTestCache(t)
+	secretGVK := schema.GroupVersionKind{Kind: "Secret", Version: "v1"}
+	clusterKey := informerKey{
+		GroupVersionKind: secretGVK,
+		watchScope:       watchScope{Label: "app=test"},
+	}
+	namespacedKey := informerKey{
+		GroupVersionKind: secretGVK,
+		watchScope:       watchScope{Namespace: "test"},
+	}
+	c.informerReferences[clusterKey] = map[OwnerReference]struct{}{}
+	c.informerReferences[namespacedKey] = map[OwnerReference]struct{}{}
+
+	clusterReader := &readerMock{}
+	clusterReader.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(apierrors.NewNotFound(schema.GroupResource{}, ""))
+	clusterReader.
+		On("List", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*corev1.SecretList)
+			list.Items = []corev1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "other"}},
+			}
+		}).
+		Return(nil)
+	namespacedReader := &readerMock{}
+	namespacedReader.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	namespacedReader.
+		On("List", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*corev1.SecretList)
+			list.Items = []corev1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "test"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "test"}},
+			}
+		}).
+		Return(nil)
+
+	informerMap.
+		On("Get", mock.Anything, clusterKey, mock.Anything).
+		Return(nil, clusterReader, nil)
+	informerMap.
+		On("Get", mock.Anything, namespacedKey, mock.Anything).
+		Return(nil, namespacedReader, nil)
+
+	ctx := context.Background()
+
+	t.Run("Get", func(t *testing.T) {
+		key := client.ObjectKey{Name: "c", Namespace: "test"}
+		require.NoError(t, c.Get(ctx, key, &corev1.Secret{}))
+		namespacedReader.AssertCalled(t, "Get", mock.Anything, key, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Get other namespace", func(t *testing.T) {
+		key := client.ObjectKey{Name: "c", Namespace: "other"}
+		err := c.Get(ctx, key, &corev1.Secret{})
+		require.True(t, apierrors.IsNotFound(err))
+		namespacedReader.AssertNotCalled(t, "Get", mock.Anything, key, mock.Anything, mock.Anything)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		list := &corev1.SecretList{}
+		require.NoError(t, c.List(ctx, list))
+
+		names := make([]string, len(list.Items))
+		for i := range list.Items {
+			names[i] = list.Items[i].Namespace + "/" + list.Items[i].Name
+		}
+		assert.ElementsMatch(t, []string{"test/a", "other/b", "test/c"}, names)
+	})
+}
+
 func TestCache_sampleMetrics(t *testing.T) {
 	c, _, informerMap := setupTestCache(t)
 	recorderMock := &metricsmocks.RecorderMock{}
@@ -253,11 +433,12 @@ func TestCache_sampleMetrics(t *testing.T) {
 		Version: "v1",
 		Kind:    "ConfigMap",
 	}
-	c.informerReferences[secretGVK] = map[OwnerReference]struct{}{}
-	c.informerReferences[configMapGVK] = map[OwnerReference]struct{}{}
+	c.informerReferences[informerKey{GroupVersionKind: secretGVK}] = map[OwnerReference]struct{}{}
+	c.informerReferences[informerKey{GroupVersionKind: configMapGVK}] = map[OwnerReference]struct{}{}
 
 	recorderMock.On("RecordDynamicCacheInformers", mock.Anything)
 	recorderMock.On("RecordDynamicCacheObjects", mock.Anything, mock.Anything)
+	recorderMock.On("RecordDynamicCacheObjectsTotal", mock.Anything)
 
 	reader := &readerMock{}
 	reader.
@@ -282,6 +463,7 @@ func TestCache_sampleMetrics(t *testing.T) {
 	recorderMock.AssertCalled(t, "RecordDynamicCacheInformers", 2)
 	recorderMock.AssertCalled(t, "RecordDynamicCacheObjects", secretGVK, 2)
 	recorderMock.AssertCalled(t, "RecordDynamicCacheObjects", configMapGVK, 1)
+	recorderMock.AssertCalled(t, "RecordDynamicCacheObjectsTotal", 3)
 }
 
 func setupTestCache(t *testing.T) (*Cache, *cacheSourceMock, *informerMapMock) {
@@ -294,7 +476,7 @@ func setupTestCache(t *testing.T) (*Cache, *cacheSourceMock, *informerMapMock) {
 
 	c := &Cache{
 		scheme:             scheme,
-		informerReferences: map[schema.GroupVersionKind]map[OwnerReference]struct{}{},
+		informerReferences: map[informerKey]map[OwnerReference]struct{}{},
 		cacheSource:        cacheSource,
 		informerMap:        informerMap,
 	}
@@ -312,10 +494,10 @@ type informerMapMock struct {
 
 func (m *informerMapMock) Get(
 	ctx context.Context,
-	gvk schema.GroupVersionKind,
+	key informerKey,
 	obj runtime.Object,
 ) (informer cache.SharedIndexInformer, reader client.Reader, err error) {
-	args := m.Called(ctx, gvk, obj)
+	args := m.Called(ctx, key, obj)
 	if i := args.Get(0); i != nil {
 		informer = i.(cache.SharedIndexInformer)
 	}
@@ -328,9 +510,9 @@ func (m *informerMapMock) Get(
 
 func (m *informerMapMock) Delete(
 	ctx context.Context,
-	gvk schema.GroupVersionKind,
+	key informerKey,
 ) error {
-	args := m.Called(ctx, gvk)
+	args := m.Called(ctx, key)
 	return args.Error(0)
 }
 
@@ -0,0 +1,60 @@
+package dynamiccache
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// OwnerUIDIndex is the field name of the index over owner reference UIDs.
+const OwnerUIDIndex = "metadata.ownerReferences.uid"
+
+// OwnerUIDIndexer indexes objects by the UIDs of all their owners.
+func OwnerUIDIndexer(obj client.Object) []string {
+	ownerRefs := obj.GetOwnerReferences()
+	uids := make([]string, len(ownerRefs))
+	for i, ownerRef := range ownerRefs {
+		uids[i] = string(ownerRef.UID)
+	}
+	return uids
+}
+
+// AnnotationIndexer returns an IndexerFunc indexing objects by the value of the given annotation.
+// Objects without the annotation are not indexed.
+func AnnotationIndexer(key string) client.IndexerFunc {
+	return func(obj client.Object) []string {
+		v, ok := obj.GetAnnotations()[key]
+		if !ok || len(v) == 0 {
+			return nil
+		}
+		return []string{v}
+	}
+}
+
+// ListByIndex returns all objects across all watched GroupVersionKinds,
+// that match the given value in the field index.
+// The index has to be registered for every watched GroupVersionKind.
+func (c *Cache) ListByIndex(
+	ctx context.Context, field, value string,
+) ([]unstructured.Unstructured, error) {
+	c.informerReferencesMux.RLock()
+	defer c.informerReferencesMux.RUnlock()
+
+	var objs []unstructured.Unstructured
+	for gvk := range c.ownersByGVK() {
+		listObj := &unstructured.UnstructuredList{}
+		listObj.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   gvk.Group,
+			Version: gvk.Version,
+			Kind:    gvk.Kind + "List",
+		})
+		if err := c.list(ctx, listObj, client.MatchingFields{field: value}); err != nil {
+			return nil, fmt.Errorf("listing %v by index %q: %w", gvk, field, err)
+		}
+		objs = append(objs, listObj.Items...)
+	}
+	return objs, nil
+}
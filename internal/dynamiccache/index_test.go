@@ -0,0 +1,72 @@
+package dynamiccache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestOwnerUIDIndexer(t *testing.T) {
+	obj := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			OwnerReferences: []metav1.OwnerReference{
+				{UID: "123"},
+				{UID: "456"},
+			},
+		},
+	}
+	assert.Equal(t, []string{"123", "456"}, OwnerUIDIndexer(obj))
+	assert.Empty(t, OwnerUIDIndexer(&corev1.Secret{}))
+}
+
+func TestAnnotationIndexer(t *testing.T) {
+	indexer := AnnotationIndexer("test-annotation")
+
+	obj := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"test-annotation": "3",
+			},
+		},
+	}
+	assert.Equal(t, []string{"3"}, indexer(obj))
+	assert.Nil(t, indexer(&corev1.Secret{}))
+}
+
+func TestCache_ListByIndex(t *testing.T) {
+	c, _, informerMap := setupTestCache(t)
+	c.informerReferences[informerKey{
+		GroupVersionKind: schema.GroupVersionKind{
+			Version: "v1",
+			Kind:    "Secret",
+		},
+	}] = map[OwnerReference]struct{}{}
+
+	reader := &readerMock{}
+	reader.
+		On("List", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*unstructured.UnstructuredList)
+			list.Items = make([]unstructured.Unstructured, 2)
+		}).
+		Return(nil)
+	informerMap.
+		On("Get", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, reader, nil)
+
+	ctx := context.Background()
+	objs, err := c.ListByIndex(ctx, OwnerUIDIndex, "123")
+	require.NoError(t, err)
+	assert.Len(t, objs, 2)
+
+	reader.AssertCalled(t, "List", mock.Anything, mock.Anything,
+		[]client.ListOption{client.MatchingFields{OwnerUIDIndex: "123"}})
+}
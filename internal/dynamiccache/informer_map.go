@@ -47,7 +47,7 @@ func NewInformerMap(
 		selectors: selectors.forGVK,
 		indexers:  indexers.forGVK,
 
-		informers:     map[schema.GroupVersionKind]mapEntry{},
+		informers:     map[informerKey]mapEntry{},
 		dynamicClient: dynamic.NewForConfigOrDie(config),
 	}
 }
@@ -75,18 +75,18 @@ type InformerMap struct {
 	// indexers are index functions that create custom field indexes on the cache.
 	indexers func(gvk schema.GroupVersionKind) []FieldIndexer
 
-	informers    map[schema.GroupVersionKind]mapEntry
+	informers    map[informerKey]mapEntry
 	informersMux sync.RWMutex
 
 	// dynamicClient to create new ListWatches.
 	dynamicClient dynamic.Interface
 }
 
-// Get returns a informer for the given GVK.
+// Get returns a informer for the given GVK and watch scope.
 // If no informer is registered, a new Informer will be created.
 func (im *InformerMap) Get(
 	ctx context.Context,
-	gvk schema.GroupVersionKind,
+	key informerKey,
 	obj runtime.Object,
 ) (informer cache.SharedIndexInformer, reader client.Reader, err error) {
 	// Return the informer if it is found
@@ -96,14 +96,14 @@ func (im *InformerMap) Get(
 	) {
 		im.informersMux.RLock()
 		defer im.informersMux.RUnlock()
-		entry, ok := im.informers[gvk]
+		entry, ok := im.informers[key]
 		return entry.Informer, entry.Reader, ok
 	}()
 
 	if !ok {
 		var err error
 		if informer, reader, err = im.addInformerToMap(
-			ctx, gvk, obj); err != nil {
+			ctx, key, obj); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -117,37 +117,38 @@ func (im *InformerMap) Get(
 	return
 }
 
-// Delete shuts down an informer for the given GVK, if one is registered.
+// Delete shuts down an informer for the given GVK and watch scope, if one is registered.
 func (im *InformerMap) Delete(
 	_ context.Context,
-	gvk schema.GroupVersionKind,
+	key informerKey,
 ) error {
 	im.informersMux.Lock()
 	defer im.informersMux.Unlock()
 
-	entry, ok := im.informers[gvk]
+	entry, ok := im.informers[key]
 	if !ok {
 		return nil
 	}
 
 	close(entry.StopCh)
-	delete(im.informers, gvk)
+	delete(im.informers, key)
 	return nil
 }
 
 func (im *InformerMap) addInformerToMap(
-	_ context.Context, gvk schema.GroupVersionKind, obj runtime.Object,
+	_ context.Context, key informerKey, obj runtime.Object,
 ) (informer cache.SharedIndexInformer, reader client.Reader, err error) {
 	im.informersMux.Lock()
 	defer im.informersMux.Unlock()
 
-	// Ensure we are not creating multiple informers for the same type.
-	if entry, ok := im.informers[gvk]; ok {
+	// Ensure we are not creating multiple informers for the same type and scope.
+	if entry, ok := im.informers[key]; ok {
 		return entry.Informer, entry.Reader, nil
 	}
+	gvk := key.GroupVersionKind
 
 	// Create a new Informer and add it to the map.
-	lw, err := im.createListWatch(context.Background(), gvk)
+	lw, err := im.createListWatch(context.Background(), key)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -175,7 +176,7 @@ func (im *InformerMap) addInformerToMap(
 		},
 		StopCh: make(chan struct{}, 1),
 	}
-	im.informers[gvk] = e
+	im.informers[key] = e
 	go e.Informer.Run(e.StopCh)
 
 	return e.Informer, e.Reader, nil
@@ -183,8 +184,10 @@ func (im *InformerMap) addInformerToMap(
 
 // newListWatch returns a new ListWatch object that can be used to create a SharedIndexInformer.
 func (im *InformerMap) createListWatch(
-	ctx context.Context, gvk schema.GroupVersionKind,
+	ctx context.Context, key informerKey,
 ) (*cache.ListWatch, error) {
+	gvk := key.GroupVersionKind
+
 	// Kubernetes APIs work against Resources, not GroupVersionKinds.  Map the
 	// groupVersionKind to the Resource API we will use.
 	mapping, err := im.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
@@ -192,15 +195,21 @@ func (im *InformerMap) createListWatch(
 		return nil, err
 	}
 
-	client := im.dynamicClient.Resource(mapping.Resource)
+	var client dynamic.ResourceInterface = im.dynamicClient.Resource(mapping.Resource)
+	if len(key.Namespace) > 0 &&
+		mapping.Scope.Name() == apimetav1.RESTScopeNameNamespace {
+		client = im.dynamicClient.Resource(mapping.Resource).Namespace(key.Namespace)
+	}
 
 	return &cache.ListWatch{
 		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
 			im.selectors(gvk).ApplyToList(&opts)
+			key.applyToList(&opts)
 			return client.List(ctx, opts)
 		},
 		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
 			im.selectors(gvk).ApplyToList(&opts)
+			key.applyToList(&opts)
 			return client.Watch(ctx, opts)
 		},
 	}, nil
@@ -10,6 +10,10 @@ import (
 var (
 	_ CacheOption = (*FieldIndexersByGVK)(nil)
 	_ CacheOption = (*SelectorsByGVK)(nil)
+	_ CacheOption = (*OwnerNamespaceScoped)(nil)
+
+	_ WatchOption = (*WatchNamespace)(nil)
+	_ WatchOption = (*Selector)(nil)
 )
 
 // FieldIndexers by GroupVersionKind.
@@ -52,6 +56,14 @@ type FieldIndexer struct {
 	Indexer client.IndexerFunc
 }
 
+// Restricts watches without a namespace to the namespace of their owner,
+// so the cache only needs permissions in the namespaces of owners.
+type OwnerNamespaceScoped bool
+
+func (s OwnerNamespaceScoped) ApplyToCacheOptions(opts *CacheOptions) {
+	opts.OwnerNamespaceScoped = bool(s)
+}
+
 // CacheOption customizes an informer creation and cache behavior.
 type CacheOption interface {
 	ApplyToCacheOptions(opts *CacheOptions)
@@ -65,6 +77,8 @@ type CacheOptions struct {
 	Selectors SelectorsByGVK
 	// Time between full cache resyncs.
 	ResyncInterval time.Duration
+	// Restricts watches without a namespace to the namespace of their owner.
+	OwnerNamespaceScoped bool
 }
 
 func (co *CacheOptions) Default() {
@@ -72,3 +86,43 @@ func (co *CacheOptions) Default() {
 		co.ResyncInterval = defaultResyncInterval
 	}
 }
+
+// WatchOption customizes a single watch.
+type WatchOption interface {
+	ApplyToWatchOptions(opts *WatchOptions)
+}
+
+// WatchOptions holds all parameters of a single watch.
+// Watches with different options are served by separate informers.
+type WatchOptions struct {
+	// Restricts the watch to a single namespace.
+	Namespace string
+	// Filters watched objects in addition to the cache-wide Selectors.
+	Selector Selector
+}
+
+func (o *WatchOptions) scope() watchScope {
+	var s watchScope
+	s.Namespace = o.Namespace
+	if o.Selector.Label != nil && !o.Selector.Label.Empty() {
+		s.Label = o.Selector.Label.String()
+	}
+	if o.Selector.Field != nil && !o.Selector.Field.Empty() {
+		s.Field = o.Selector.Field.String()
+	}
+	return s
+}
+
+// Restricts a watch to objects in the given namespace,
+// e.g. to the namespace of a namespaced owner.
+type WatchNamespace string
+
+func (n WatchNamespace) ApplyToWatchOptions(opts *WatchOptions) {
+	opts.Namespace = string(n)
+}
+
+// Filters a watch by the given label and field selectors.
+// defined here, so we don't have to change selector.go.
+func (s Selector) ApplyToWatchOptions(opts *WatchOptions) {
+	opts.Selector = s
+}
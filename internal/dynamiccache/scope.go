@@ -0,0 +1,38 @@
+package dynamiccache
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// watchScope restricts the objects of a single informer.
+// Selectors are stored in their string form to be comparable.
+type watchScope struct {
+	Namespace string
+	Label     string
+	Field     string
+}
+
+// applyToList narrows down ListOptions already populated by the cache-wide Selector.
+func (s watchScope) applyToList(listOpts *metav1.ListOptions) {
+	listOpts.LabelSelector = joinSelectors(listOpts.LabelSelector, s.Label)
+	listOpts.FieldSelector = joinSelectors(listOpts.FieldSelector, s.Field)
+}
+
+// Label and field selector requirements separated by "," are ANDed.
+func joinSelectors(a, b string) string {
+	switch {
+	case len(a) == 0:
+		return b
+	case len(b) == 0:
+		return a
+	default:
+		return a + "," + b
+	}
+}
+
+// informerKey identifies an informer of the InformerMap.
+type informerKey struct {
+	schema.GroupVersionKind
+	watchScope
+}
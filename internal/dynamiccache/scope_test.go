@@ -0,0 +1,62 @@
+package dynamiccache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestWatchOptions_scope(t *testing.T) {
+	var opts WatchOptions
+	for _, opt := range []WatchOption{
+		WatchNamespace("test"),
+		Selector{
+			Label: labels.SelectorFromSet(labels.Set{"app": "test"}),
+			Field: fields.Everything(),
+		},
+	} {
+		opt.ApplyToWatchOptions(&opts)
+	}
+
+	assert.Equal(t, watchScope{
+		Namespace: "test",
+		Label:     "app=test",
+	}, opts.scope())
+}
+
+func Test_watchScope_applyToList(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    watchScope
+		listOpts metav1.ListOptions
+		expected metav1.ListOptions
+	}{
+		{
+			name:     "empty",
+			listOpts: metav1.ListOptions{LabelSelector: "a=b"},
+			expected: metav1.ListOptions{LabelSelector: "a=b"},
+		},
+		{
+			name:     "scope only",
+			scope:    watchScope{Label: "c=d", Field: "metadata.name=test"},
+			expected: metav1.ListOptions{LabelSelector: "c=d", FieldSelector: "metadata.name=test"},
+		},
+		{
+			name:     "joined",
+			scope:    watchScope{Label: "c=d"},
+			listOpts: metav1.ListOptions{LabelSelector: "a=b"},
+			expected: metav1.ListOptions{LabelSelector: "a=b,c=d"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.scope.applyToList(&test.listOpts)
+			assert.Equal(t, test.expected, test.listOpts)
+		})
+	}
+}
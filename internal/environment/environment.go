@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-logr/logr"
 	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/version"
@@ -17,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	hypershiftv1beta1 "package-operator.run/package-operator/internal/controllers/hostedclusters/hypershift/v1beta1"
 )
 
 var _ manager.Runnable = (*Manager)(nil)
@@ -25,6 +27,8 @@ const (
 	environmentProbeInterval    = 1 * time.Minute
 	openShiftClusterVersionName = "version"
 	openShiftProxyName          = "cluster"
+	openShiftConfigNamespace    = "openshift-config"
+	openShiftTrustedCABundleKey = "ca-bundle.crt"
 )
 
 type Sinker interface {
@@ -112,6 +116,12 @@ func (m *Manager) probe(ctx context.Context) (
 	}
 	env.OpenShift = openShiftEnv
 
+	hyperShiftEnv, err := m.hyperShiftEnvironment(ctx)
+	if err != nil {
+		return env, fmt.Errorf("getting HyperShift env: %w", err)
+	}
+	env.HyperShift = hyperShiftEnv
+
 	if isOpenShift {
 		proxy, hasProxy, err := m.openShiftProxyEnvironment(ctx)
 		if err != nil {
@@ -147,6 +157,10 @@ func (m *Manager) openShiftEnvironment(ctx context.Context) (
 		// API not registered in cluster
 		return nil, false, nil
 	}
+	if errors.IsForbidden(err) {
+		// Cluster-scoped objects are not readable when running namespace-scoped.
+		return nil, false, nil
+	}
 	if err != nil {
 		return nil, false, fmt.Errorf("getting OpenShift ClusterVersion: %w", err)
 	}
@@ -165,6 +179,27 @@ func (m *Manager) openShiftEnvironment(ctx context.Context) (
 	}, true, nil
 }
 
+func (m *Manager) hyperShiftEnvironment(ctx context.Context) (
+	hyperShiftEnv *manifestsv1alpha1.PackageEnvironmentHyperShift, err error,
+) {
+	err = m.client.List(ctx, &hypershiftv1beta1.HostedClusterList{}, client.Limit(1))
+	if meta.IsNoMatchError(err) {
+		// API not registered in cluster
+		return nil, nil
+	}
+	if errors.IsForbidden(err) {
+		// Not allowed to list HostedClusters, e.g. when running namespace-scoped.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing HyperShift HostedClusters: %w", err)
+	}
+
+	return &manifestsv1alpha1.PackageEnvironmentHyperShift{
+		ManagementCluster: true,
+	}, nil
+}
+
 func (m *Manager) openShiftProxyEnvironment(ctx context.Context) (
 	openShiftEnv *manifestsv1alpha1.PackageEnvironmentProxy, hasProxy bool, err error,
 ) {
@@ -172,8 +207,9 @@ func (m *Manager) openShiftProxyEnvironment(ctx context.Context) (
 	err = m.client.Get(ctx, client.ObjectKey{
 		Name: openShiftProxyName,
 	}, proxy)
-	if meta.IsNoMatchError(err) || errors.IsNotFound(err) {
-		// API not registered in cluster or no proxy config.
+	if meta.IsNoMatchError(err) || errors.IsNotFound(err) || errors.IsForbidden(err) {
+		// API not registered in cluster, no proxy config
+		// or not readable when running namespace-scoped.
 		return nil, false, nil
 	}
 	if err != nil {
@@ -181,23 +217,51 @@ func (m *Manager) openShiftProxyEnvironment(ctx context.Context) (
 			"getting OpenShift ClusterVersion: %w", err)
 	}
 
+	trustedCABundle, err := m.openShiftTrustedCABundle(ctx, proxy)
+	if err != nil {
+		return nil, false, err
+	}
+
 	var (
 		httpProxy  = proxy.Status.HTTPProxy
 		httpsProxy = proxy.Status.HTTPSProxy
 		noProxy    = proxy.Status.NoProxy
 	)
 
-	if httpProxy == "" && httpsProxy == "" && noProxy == "" {
+	if httpProxy == "" && httpsProxy == "" && noProxy == "" && trustedCABundle == "" {
 		return nil, false, nil
 	}
 
 	return &manifestsv1alpha1.PackageEnvironmentProxy{
-		HTTPProxy:  httpProxy,
-		HTTPSProxy: httpsProxy,
-		NoProxy:    noProxy,
+		HTTPProxy:       httpProxy,
+		HTTPSProxy:      httpsProxy,
+		NoProxy:         noProxy,
+		TrustedCABundle: trustedCABundle,
 	}, true, nil
 }
 
+// Returns the additional CA bundle referenced by the OpenShift Proxy object.
+func (m *Manager) openShiftTrustedCABundle(
+	ctx context.Context, proxy *configv1.Proxy,
+) (string, error) {
+	if len(proxy.Spec.TrustedCA.Name) == 0 {
+		return "", nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := m.client.Get(ctx, client.ObjectKey{
+		Name:      proxy.Spec.TrustedCA.Name,
+		Namespace: openShiftConfigNamespace,
+	}, cm)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("getting OpenShift Proxy trusted CA ConfigMap: %w", err)
+	}
+	return cm.Data[openShiftTrustedCABundleKey], nil
+}
+
 var _ Sinker = (*Sink)(nil)
 
 type Sink struct {
@@ -9,10 +9,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/testutil"
@@ -56,6 +58,13 @@ func TestManager_Init_Kubernetes(t *testing.T) {
 		).
 		Return(&meta.NoKindMatchError{})
 
+	c.
+		On(
+			"List", mock.Anything,
+			mock.AnythingOfType("*v1beta1.HostedClusterList"), mock.Anything,
+		).
+		Return(&meta.NoKindMatchError{})
+
 	mgr := NewManager(c, dc)
 
 	ctx := context.Background()
@@ -117,6 +126,13 @@ func TestManager_Init_OpenShift(t *testing.T) {
 		}).
 		Return(nil)
 
+	c.
+		On(
+			"List", mock.Anything,
+			mock.AnythingOfType("*v1beta1.HostedClusterList"), mock.Anything,
+		).
+		Return(&meta.NoKindMatchError{})
+
 	mgr := NewManager(c, dc)
 
 	ctx := context.Background()
@@ -157,6 +173,42 @@ func TestManager_openShiftEnvironment_API_not_registered(t *testing.T) {
 	assert.Nil(t, openShiftEnv)
 }
 
+func TestManager_hyperShiftEnvironment(t *testing.T) {
+	c := testutil.NewClient()
+
+	c.
+		On(
+			"List", mock.Anything,
+			mock.AnythingOfType("*v1beta1.HostedClusterList"), mock.Anything,
+		).
+		Return(nil)
+
+	ctx := context.Background()
+	mgr := NewManager(c, nil)
+	hyperShiftEnv, err := mgr.hyperShiftEnvironment(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &manifestsv1alpha1.PackageEnvironmentHyperShift{
+		ManagementCluster: true,
+	}, hyperShiftEnv)
+}
+
+func TestManager_hyperShiftEnvironment_notHyperShift(t *testing.T) {
+	c := testutil.NewClient()
+
+	c.
+		On(
+			"List", mock.Anything,
+			mock.AnythingOfType("*v1beta1.HostedClusterList"), mock.Anything,
+		).
+		Return(&meta.NoKindMatchError{})
+
+	ctx := context.Background()
+	mgr := NewManager(c, nil)
+	hyperShiftEnv, err := mgr.hyperShiftEnvironment(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, hyperShiftEnv)
+}
+
 func TestManager_openShiftEnvironment_error(t *testing.T) {
 	c := testutil.NewClient()
 
@@ -186,6 +238,10 @@ func TestManager_openShiftProxyEnvironment_handeledErrors(t *testing.T) {
 			name: "not found",
 			err:  k8serrors.NewNotFound(schema.GroupResource{}, ""),
 		},
+		{
+			name: "forbidden",
+			err:  k8serrors.NewForbidden(schema.GroupResource{}, "", nil),
+		},
 	}
 
 	for _, test := range tests {
@@ -209,6 +265,41 @@ func TestManager_openShiftProxyEnvironment_handeledErrors(t *testing.T) {
 	}
 }
 
+func TestManager_openShiftProxyEnvironment_trustedCA(t *testing.T) {
+	c := testutil.NewClient()
+
+	c.
+		On(
+			"Get", mock.Anything, mock.Anything,
+			mock.AnythingOfType("*v1.Proxy"), mock.Anything,
+		).
+		Run(func(args mock.Arguments) {
+			proxy := args.Get(2).(*configv1.Proxy)
+			proxy.Spec.TrustedCA.Name = "user-ca-bundle"
+		}).
+		Return(nil)
+	c.
+		On(
+			"Get", mock.Anything,
+			client.ObjectKey{Name: "user-ca-bundle", Namespace: "openshift-config"},
+			mock.AnythingOfType("*v1.ConfigMap"), mock.Anything,
+		).
+		Run(func(args mock.Arguments) {
+			cm := args.Get(2).(*corev1.ConfigMap)
+			cm.Data = map[string]string{"ca-bundle.crt": "---CA---"}
+		}).
+		Return(nil)
+
+	ctx := context.Background()
+	mgr := NewManager(c, nil)
+	proxyEnv, hasProxy, err := mgr.openShiftProxyEnvironment(ctx)
+	require.NoError(t, err)
+	assert.True(t, hasProxy)
+	assert.Equal(t, &manifestsv1alpha1.PackageEnvironmentProxy{
+		TrustedCABundle: "---CA---",
+	}, proxyEnv)
+}
+
 func TestManager_openShiftProxyEnvironment_error(t *testing.T) {
 	c := testutil.NewClient()
 
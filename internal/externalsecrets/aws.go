@@ -0,0 +1,216 @@
+package externalsecrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Keys of the Secret referenced by the AWSSecretsManager credentialsSecretRef.
+const (
+	awsAccessKeyIDKey     = "accessKeyID"
+	awsSecretAccessKeyKey = "secretAccessKey"
+	awsSessionTokenKey    = "sessionToken"
+)
+
+const awsSecretsManagerService = "secretsmanager"
+
+type awsCredentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+// Reads secrets from AWS Secrets Manager.
+type awsSecretsManagerProvider struct {
+	client      *http.Client
+	endpoint    string
+	region      string
+	credentials awsCredentials
+	now         func() time.Time
+}
+
+func newAWSSecretsManagerProvider(
+	ctx context.Context, c client.Reader, sp *corev1alpha1.SecretProvider,
+) (Provider, error) {
+	cfg := sp.Spec.AWSSecretsManager
+	if cfg == nil {
+		return nil, &MissingConfigError{Field: "awsSecretsManager"}
+	}
+
+	secret, err := credentialsSecret(ctx, c, sp, cfg.CredentialsSecretRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range []string{awsAccessKeyIDKey, awsSecretAccessKeyKey} {
+		if _, ok := secret.Data[key]; !ok {
+			return nil, &MissingSecretKeyError{Secret: secret.Name, Key: key}
+		}
+	}
+	creds := awsCredentials{
+		accessKeyID:     strings.TrimSpace(string(secret.Data[awsAccessKeyIDKey])),
+		secretAccessKey: strings.TrimSpace(string(secret.Data[awsSecretAccessKeyKey])),
+		sessionToken:    strings.TrimSpace(string(secret.Data[awsSessionTokenKey])),
+	}
+
+	endpoint := cfg.Endpoint
+	if len(endpoint) == 0 {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", awsSecretsManagerService, cfg.Region)
+	}
+	return &awsSecretsManagerProvider{
+		client:      &http.Client{Timeout: requestTimeout},
+		endpoint:    strings.TrimSuffix(endpoint, "/") + "/",
+		region:      cfg.Region,
+		credentials: creds,
+		now:         time.Now,
+	}, nil
+}
+
+type awsGetSecretValueRequest struct {
+	SecretID  string `json:"SecretId"`
+	VersionID string `json:"VersionId,omitempty"`
+}
+
+type awsGetSecretValueResponse struct {
+	SecretString string `json:"SecretString"`
+	SecretBinary []byte `json:"SecretBinary"`
+}
+
+type awsErrorResponse struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+func (p *awsSecretsManagerProvider) GetSecret(
+	ctx context.Context, path, version string,
+) (map[string]interface{}, error) {
+	body, err := json.Marshal(awsGetSecretValueRequest{SecretID: path, VersionID: version})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	signAWSRequestV4(req, body, p.credentials, p.region, awsSecretsManagerService, p.now())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResp := &awsErrorResponse{}
+		_ = json.Unmarshal(respBody, errResp)
+		if strings.HasSuffix(errResp.Type, "ResourceNotFoundException") {
+			return nil, ErrSecretNotFound
+		}
+		if len(errResp.Type) > 0 {
+			return nil, fmt.Errorf("%s: %s", errResp.Type, errResp.Message)
+		}
+		return nil, &UnexpectedStatusError{StatusCode: resp.StatusCode}
+	}
+
+	secret := &awsGetSecretValueResponse{}
+	if err := json.Unmarshal(respBody, secret); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	if len(secret.SecretString) > 0 {
+		return secretData([]byte(secret.SecretString)), nil
+	}
+	return secretData(secret.SecretBinary), nil
+}
+
+// Signs the request with AWS Signature Version 4,
+// covering the host, content-type and all X-Amz-* headers.
+func signAWSRequestV4(
+	req *http.Request, body []byte, creds awsCredentials,
+	region, service string, now time.Time,
+) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if len(creds.sessionToken) > 0 {
+		req.Header.Set("X-Amz-Security-Token", creds.sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "content-type" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if len(path) == 0 {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256(body),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", amzDate, scope, hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.secretAccessKey), date)
+	for _, part := range []string{region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.accessKeyID, scope, signedHeaders, signature))
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,115 @@
+package externalsecrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Example request from the AWS Signature Version 4 documentation.
+func Test_signAWSRequestV4(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequest(http.MethodGet,
+		"https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	signAWSRequestV4(req, nil, awsCredentials{
+		accessKeyID:     "AKIDEXAMPLE",
+		secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 "+
+		"Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+		"SignedHeaders=content-type;host;x-amz-date, "+
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		req.Header.Get("Authorization"))
+}
+
+func TestAWSSecretsManagerProvider(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" ||
+			!strings.Contains(r.Header.Get("Authorization"), "Credential=AKID/") ||
+			r.Header.Get("X-Amz-Security-Token") != "session" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		req := &awsGetSecretValueRequest{}
+		_ = json.NewDecoder(r.Body).Decode(req)
+		switch req.SecretID {
+		case "database":
+			_, _ = w.Write([]byte(`{"SecretString":"{\"password\":\"hunter2\"}"}`))
+		case "token":
+			_, _ = w.Write([]byte(`{"SecretBinary":"dG9rZW4="}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := newCredentialsClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "test"},
+		Data: map[string][]byte{
+			awsAccessKeyIDKey:     []byte("AKID"),
+			awsSecretAccessKeyKey: []byte("secret"),
+			awsSessionTokenKey:    []byte("session"),
+		},
+	})
+	p, err := newAWSSecretsManagerProvider(context.Background(), c, &corev1alpha1.SecretProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+		Spec: corev1alpha1.SecretProviderSpec{
+			AWSSecretsManager: &corev1alpha1.SecretProviderAWSSecretsManager{
+				Region:               "eu-central-1",
+				Endpoint:             srv.URL,
+				CredentialsSecretRef: corev1alpha1.SecretProviderSecretRef{Name: "aws"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	data, err := p.GetSecret(ctx, "database", "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"password": "hunter2"}, data)
+
+	data, err = p.GetSecret(ctx, "token", "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"value": "token"}, data)
+
+	_, err = p.GetSecret(ctx, "missing", "")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
+
+func TestAWSSecretsManagerProvider_missingCredentials(t *testing.T) {
+	t.Parallel()
+
+	c := newCredentialsClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws", Namespace: "test"},
+		Data:       map[string][]byte{awsAccessKeyIDKey: []byte("AKID")},
+	})
+	_, err := newAWSSecretsManagerProvider(context.Background(), c, &corev1alpha1.SecretProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+		Spec: corev1alpha1.SecretProviderSpec{
+			AWSSecretsManager: &corev1alpha1.SecretProviderAWSSecretsManager{
+				CredentialsSecretRef: corev1alpha1.SecretProviderSecretRef{Name: "aws"},
+			},
+		},
+	})
+	assert.EqualError(t, err, `key "secretAccessKey" not found in credentials Secret aws`)
+}
@@ -0,0 +1,116 @@
+package externalsecrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+const (
+	defaultGCPSecretManagerEndpoint = "https://secretmanager.googleapis.com"
+	defaultGCPTokenURL              = "https://oauth2.googleapis.com/token"
+	gcpCloudPlatformScope           = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+var errInvalidGCPServiceAccountKey = errors.New("invalid service account key, expected a JSON key of type service_account")
+
+// Reads secrets from GCP Secret Manager.
+type gcpSecretManagerProvider struct {
+	client    *http.Client
+	endpoint  string
+	projectID string
+}
+
+// Fields of a GCP service account JSON key used to authenticate.
+type gcpServiceAccountKey struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+func newGCPSecretManagerProvider(
+	ctx context.Context, c client.Reader, sp *corev1alpha1.SecretProvider,
+) (Provider, error) {
+	cfg := sp.Spec.GCPSecretManager
+	if cfg == nil {
+		return nil, &MissingConfigError{Field: "gcpSecretManager"}
+	}
+	rawKey, err := secretKey(ctx, c, sp,
+		cfg.ServiceAccountKeySecretRef.Name, cfg.ServiceAccountKeySecretRef.Key)
+	if err != nil {
+		return nil, err
+	}
+	key := &gcpServiceAccountKey{}
+	if err := json.Unmarshal(rawKey, key); err != nil || key.Type != "service_account" {
+		return nil, errInvalidGCPServiceAccountKey
+	}
+
+	tokenURL := key.TokenURI
+	if len(tokenURL) == 0 {
+		tokenURL = defaultGCPTokenURL
+	}
+	jwtConfig := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		Scopes:       []string{gcpCloudPlatformScope},
+		TokenURL:     tokenURL,
+	}
+
+	endpoint := cfg.Endpoint
+	if len(endpoint) == 0 {
+		endpoint = defaultGCPSecretManagerEndpoint
+	}
+	// The context only configures the client used to fetch tokens.
+	tokenCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: requestTimeout})
+	httpClient := jwtConfig.Client(tokenCtx)
+	httpClient.Timeout = requestTimeout
+	return &gcpSecretManagerProvider{
+		client:    httpClient,
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		projectID: cfg.ProjectID,
+	}, nil
+}
+
+type gcpAccessSecretVersionResponse struct {
+	Payload struct {
+		Data []byte `json:"data"`
+	} `json:"payload"`
+}
+
+// Path may be the name of a secret in the configured project
+// or the full resource name of a secret, e.g. projects/<project>/secrets/<name>.
+func (p *gcpSecretManagerProvider) GetSecret(
+	ctx context.Context, path, version string,
+) (map[string]interface{}, error) {
+	if len(version) == 0 {
+		version = "latest"
+	}
+	name := path
+	if !strings.HasPrefix(name, "projects/") {
+		name = fmt.Sprintf("projects/%s/secrets/%s", url.PathEscape(p.projectID), url.PathEscape(path))
+	}
+	u := fmt.Sprintf("%s/v1/%s/versions/%s:access", p.endpoint, name, url.PathEscape(version))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &gcpAccessSecretVersionResponse{}
+	if err := doJSON(p.client, req, resp); err != nil {
+		return nil, err
+	}
+	return secretData(resp.Payload.Data), nil
+}
@@ -0,0 +1,112 @@
+package externalsecrets
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestGCPSecretManagerProvider(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || len(r.Form.Get("assertion")) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"test-token","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("/v1/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/projects/my-project/secrets/database/versions/latest:access":
+			_, _ = w.Write([]byte(`{"payload":{"data":"eyJwYXNzd29yZCI6Imh1bnRlcjIifQ=="}}`))
+		case "/v1/projects/other/secrets/token/versions/1:access":
+			_, _ = w.Write([]byte(`{"payload":{"data":"dG9rZW4="}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key, err := json.Marshal(gcpServiceAccountKey{
+		Type:        "service_account",
+		ClientEmail: "pko@my-project.iam.gserviceaccount.com",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{
+			Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+		})),
+		TokenURI: srv.URL + "/token",
+	})
+	require.NoError(t, err)
+
+	c := newCredentialsClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcp", Namespace: "test"},
+		Data:       map[string][]byte{"key.json": key},
+	})
+	p, err := newGCPSecretManagerProvider(context.Background(), c, &corev1alpha1.SecretProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+		Spec: corev1alpha1.SecretProviderSpec{
+			GCPSecretManager: &corev1alpha1.SecretProviderGCPSecretManager{
+				ProjectID: "my-project",
+				Endpoint:  srv.URL,
+				ServiceAccountKeySecretRef: corev1alpha1.SecretProviderSecretKeyRef{
+					Name: "gcp", Key: "key.json",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	data, err := p.GetSecret(ctx, "database", "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"password": "hunter2"}, data)
+
+	data, err = p.GetSecret(ctx, "projects/other/secrets/token", "1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"value": "token"}, data)
+
+	_, err = p.GetSecret(ctx, "missing", "")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
+
+func TestGCPSecretManagerProvider_invalidKey(t *testing.T) {
+	t.Parallel()
+
+	c := newCredentialsClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcp", Namespace: "test"},
+		Data:       map[string][]byte{"key.json": []byte(`{"type":"authorized_user"}`)},
+	})
+	_, err := newGCPSecretManagerProvider(context.Background(), c, &corev1alpha1.SecretProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+		Spec: corev1alpha1.SecretProviderSpec{
+			GCPSecretManager: &corev1alpha1.SecretProviderGCPSecretManager{
+				ServiceAccountKeySecretRef: corev1alpha1.SecretProviderSecretKeyRef{
+					Name: "gcp", Key: "key.json",
+				},
+			},
+		},
+	})
+	assert.ErrorIs(t, err, errInvalidGCPServiceAccountKey)
+}
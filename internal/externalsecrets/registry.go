@@ -0,0 +1,174 @@
+package externalsecrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// ErrSecretNotFound is returned when the secret does not exist in the external secret manager.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// ErrUnknownProviderType is returned for SecretProviders without a registered ProviderFactory.
+var ErrUnknownProviderType = errors.New("unknown secret provider type")
+
+// Timeout of a single request to an external secret manager.
+const requestTimeout = 10 * time.Second
+
+// Provider reads secrets from an external secret manager.
+type Provider interface {
+	// GetSecret returns the data of the secret at the given path.
+	// An empty version selects the latest version.
+	GetSecret(ctx context.Context, path, version string) (map[string]interface{}, error)
+}
+
+// ProviderFactory configures a Provider from a SecretProvider object.
+// Credentials are read from Secrets in the namespace of the SecretProvider via the given reader.
+type ProviderFactory func(
+	ctx context.Context, c client.Reader, sp *corev1alpha1.SecretProvider,
+) (Provider, error)
+
+// Registry builds Providers for SecretProviders by their type.
+type Registry struct {
+	client client.Reader
+
+	mux       sync.RWMutex
+	factories map[corev1alpha1.SecretProviderType]ProviderFactory
+}
+
+// NewRegistry returns a Registry with factories for all built-in SecretProvider types.
+// The given reader is used to read credentials and should not be cached.
+func NewRegistry(c client.Reader) *Registry {
+	r := &Registry{
+		client:    c,
+		factories: map[corev1alpha1.SecretProviderType]ProviderFactory{},
+	}
+	r.Register(corev1alpha1.SecretProviderTypeVault, newVaultProvider)
+	r.Register(corev1alpha1.SecretProviderTypeAWSSecretsManager, newAWSSecretsManagerProvider)
+	r.Register(corev1alpha1.SecretProviderTypeGCPSecretManager, newGCPSecretManagerProvider)
+	return r
+}
+
+// Register adds or replaces the ProviderFactory for the given SecretProvider type.
+func (r *Registry) Register(t corev1alpha1.SecretProviderType, f ProviderFactory) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.factories[t] = f
+}
+
+// GetSecret reads the secret at the given path from the external secret manager of the SecretProvider.
+func (r *Registry) GetSecret(
+	ctx context.Context, sp *corev1alpha1.SecretProvider, path, version string,
+) (map[string]interface{}, error) {
+	r.mux.RLock()
+	f, ok := r.factories[sp.Spec.Type]
+	r.mux.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProviderType, sp.Spec.Type)
+	}
+
+	p, err := f(ctx, r.client, sp)
+	if err != nil {
+		return nil, fmt.Errorf("configuring %s provider: %w", sp.Spec.Type, err)
+	}
+	data, err := p.GetSecret(ctx, path, version)
+	if err != nil {
+		return nil, fmt.Errorf("getting secret %s from %s: %w", path, sp.Spec.Type, err)
+	}
+	return data, nil
+}
+
+// MissingConfigError is returned when the SecretProvider lacks the configuration of its type.
+type MissingConfigError struct {
+	Field string
+}
+
+func (e *MissingConfigError) Error() string {
+	return fmt.Sprintf(".spec.%s is required", e.Field)
+}
+
+// Returns the Secret with the given name in the namespace of the SecretProvider.
+func credentialsSecret(
+	ctx context.Context, c client.Reader, sp *corev1alpha1.SecretProvider, name string,
+) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{
+		Name: name, Namespace: sp.Namespace,
+	}, secret); err != nil {
+		return nil, fmt.Errorf("getting credentials Secret: %w", err)
+	}
+	return secret, nil
+}
+
+// Returns the value of the given key of a Secret in the namespace of the SecretProvider.
+func secretKey(
+	ctx context.Context, c client.Reader, sp *corev1alpha1.SecretProvider, name, key string,
+) ([]byte, error) {
+	secret, err := credentialsSecret(ctx, c, sp, name)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, &MissingSecretKeyError{Secret: name, Key: key}
+	}
+	return value, nil
+}
+
+// MissingSecretKeyError is returned when a credentials Secret lacks a required key.
+type MissingSecretKeyError struct {
+	Secret, Key string
+}
+
+func (e *MissingSecretKeyError) Error() string {
+	return fmt.Sprintf("key %q not found in credentials Secret %s", e.Key, e.Secret)
+}
+
+// Exposes the content of a secret to source items:
+// JSON objects expose their fields, all other content is exposed as "value".
+func secretData(content []byte) map[string]interface{} {
+	data := map[string]interface{}{}
+	if err := json.Unmarshal(content, &data); err == nil {
+		return data
+	}
+	return map[string]interface{}{"value": string(content)}
+}
+
+// Sends the request and decodes the JSON response into out.
+// Returns ErrSecretNotFound on 404 responses.
+func doJSON(c *http.Client, req *http.Request, out interface{}) error {
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrSecretNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &UnexpectedStatusError{StatusCode: resp.StatusCode}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// UnexpectedStatusError is returned when an external secret manager responds with an error.
+type UnexpectedStatusError struct {
+	StatusCode int
+}
+
+func (e *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("unexpected response status %d", e.StatusCode)
+}
@@ -0,0 +1,70 @@
+package externalsecrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestRegistry_GetSecret(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry(newCredentialsClient())
+	r.Register("Test", func(
+		context.Context, client.Reader, *corev1alpha1.SecretProvider,
+	) (Provider, error) {
+		return providerFn(func(_ context.Context, path, version string) (map[string]interface{}, error) {
+			return map[string]interface{}{"path": path, "version": version}, nil
+		}), nil
+	})
+
+	ctx := context.Background()
+	data, err := r.GetSecret(ctx, &corev1alpha1.SecretProvider{
+		Spec: corev1alpha1.SecretProviderSpec{Type: "Test"},
+	}, "team-a/database", "3")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"path": "team-a/database", "version": "3"}, data)
+
+	_, err = r.GetSecret(ctx, &corev1alpha1.SecretProvider{
+		Spec: corev1alpha1.SecretProviderSpec{Type: "Banana"},
+	}, "test", "")
+	assert.ErrorIs(t, err, ErrUnknownProviderType)
+
+	_, err = r.GetSecret(ctx, &corev1alpha1.SecretProvider{
+		Spec: corev1alpha1.SecretProviderSpec{Type: corev1alpha1.SecretProviderTypeVault},
+	}, "test", "")
+	assert.EqualError(t, err, "configuring Vault provider: .spec.vault is required")
+}
+
+func Test_secretData(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string]interface{}{"user": "admin"}, secretData([]byte(`{"user":"admin"}`)))
+	assert.Equal(t, map[string]interface{}{"value": "hunter2"}, secretData([]byte("hunter2")))
+	assert.Equal(t, map[string]interface{}{"value": "[1,2]"}, secretData([]byte("[1,2]")))
+}
+
+type providerFn func(ctx context.Context, path, version string) (map[string]interface{}, error)
+
+func (fn providerFn) GetSecret(ctx context.Context, path, version string) (map[string]interface{}, error) {
+	return fn(ctx, path, version)
+}
+
+func newCredentialsClient(secrets ...*corev1.Secret) client.Reader {
+	objs := make([]client.Object, len(secrets))
+	for i := range secrets {
+		objs[i] = secrets[i]
+	}
+	return fake.NewClientBuilder().
+		WithScheme(clientgoscheme.Scheme).
+		WithObjects(objs...).
+		Build()
+}
@@ -0,0 +1,99 @@
+package externalsecrets
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Mount path of the Vault KV secrets engine, if not configured.
+const defaultVaultMount = "secret"
+
+var errInvalidVaultCABundle = errors.New("invalid CA bundle")
+
+// Reads secrets from the KV version 2 secrets engine of HashiCorp Vault.
+type vaultProvider struct {
+	client    *http.Client
+	address   string
+	mount     string
+	namespace string
+	token     string
+}
+
+func newVaultProvider(
+	ctx context.Context, c client.Reader, sp *corev1alpha1.SecretProvider,
+) (Provider, error) {
+	cfg := sp.Spec.Vault
+	if cfg == nil {
+		return nil, &MissingConfigError{Field: "vault"}
+	}
+	token, err := secretKey(ctx, c, sp, cfg.TokenSecretRef.Name, cfg.TokenSecretRef.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Timeout: requestTimeout}
+	if len(cfg.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cfg.CABundle) {
+			return nil, errInvalidVaultCABundle
+		}
+		httpClient.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		}
+	}
+
+	mount := cfg.Mount
+	if len(mount) == 0 {
+		mount = defaultVaultMount
+	}
+	return &vaultProvider{
+		client:    httpClient,
+		address:   strings.TrimSuffix(cfg.Address, "/"),
+		mount:     strings.Trim(mount, "/"),
+		namespace: cfg.Namespace,
+		token:     strings.TrimSpace(string(token)),
+	}, nil
+}
+
+type vaultKVResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+func (p *vaultProvider) GetSecret(
+	ctx context.Context, path, version string,
+) (map[string]interface{}, error) {
+	u := fmt.Sprintf("%s/v1/%s/data/%s", p.address, p.mount, strings.TrimPrefix(path, "/"))
+	if len(version) > 0 {
+		u += "?" + url.Values{"version": {version}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if len(p.namespace) > 0 {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp := &vaultKVResponse{}
+	if err := doJSON(p.client, req, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data.Data == nil {
+		// Deleted or destroyed versions have no data.
+		return nil, ErrSecretNotFound
+	}
+	return resp.Data.Data, nil
+}
@@ -0,0 +1,85 @@
+package externalsecrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestVaultProvider(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" || r.Header.Get("X-Vault-Namespace") != "team-a" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.String() {
+		case "/v1/kv/data/app/database?version=2":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"hunter2"},"metadata":{"version":2}}}`))
+		case "/v1/kv/data/app/deleted":
+			_, _ = w.Write([]byte(`{"data":{"data":null,"metadata":{"version":3}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := newCredentialsClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "test"},
+		Data:       map[string][]byte{"token": []byte("s.token\n")},
+	})
+	p, err := newVaultProvider(context.Background(), c, &corev1alpha1.SecretProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+		Spec: corev1alpha1.SecretProviderSpec{
+			Vault: &corev1alpha1.SecretProviderVault{
+				Address:   srv.URL + "/",
+				Mount:     "kv",
+				Namespace: "team-a",
+				TokenSecretRef: corev1alpha1.SecretProviderSecretKeyRef{
+					Name: "vault", Key: "token",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	data, err := p.GetSecret(ctx, "app/database", "2")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"password": "hunter2"}, data)
+
+	_, err = p.GetSecret(ctx, "app/deleted", "")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+	_, err = p.GetSecret(ctx, "app/missing", "")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
+
+func TestVaultProvider_invalidCABundle(t *testing.T) {
+	t.Parallel()
+
+	c := newCredentialsClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "test"},
+		Data:       map[string][]byte{"token": []byte("s.token")},
+	})
+	_, err := newVaultProvider(context.Background(), c, &corev1alpha1.SecretProvider{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+		Spec: corev1alpha1.SecretProviderSpec{
+			Vault: &corev1alpha1.SecretProviderVault{
+				TokenSecretRef: corev1alpha1.SecretProviderSecretKeyRef{
+					Name: "vault", Key: "token",
+				},
+				CABundle: []byte("banana"),
+			},
+		},
+	})
+	assert.ErrorIs(t, err, errInvalidVaultCABundle)
+}
@@ -0,0 +1,171 @@
+// The kubeconfig package contains functionality to follow
+// credential rotations of kubeconfig files without restarting.
+package kubeconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var _ manager.Runnable = (*Watcher)(nil)
+
+const defaultPollInterval = 10 * time.Second
+
+// Watcher watches a kubeconfig file and swaps the credentials
+// used by all clients created from its rest.Config when the file changes.
+// e.g. HyperShift rotates the kubeconfig Secrets of hosted clusters.
+type Watcher struct {
+	path         string
+	pollInterval time.Duration
+
+	host   string
+	config *rest.Config
+
+	mux       sync.RWMutex
+	content   []byte
+	transport http.RoundTripper
+}
+
+type WatcherOption func(w *Watcher)
+
+// WithPollInterval sets the interval in which the kubeconfig file is checked for changes.
+func WithPollInterval(interval time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		w.pollInterval = interval
+	}
+}
+
+// NewWatcher loads the kubeconfig file at the given path.
+func NewWatcher(path string, opts ...WatcherOption) (*Watcher, error) {
+	w := &Watcher{
+		path:         path,
+		pollInterval: defaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	content, cfg, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating transport: %w", err)
+	}
+	w.content = content
+	w.transport = transport
+	w.host = cfg.Host
+
+	// Credentials and TLS settings live in the swappable transport,
+	// so they have to be stripped from the config handed out to clients.
+	w.config = rest.AnonymousClientConfig(cfg)
+	w.config.TLSClientConfig = rest.TLSClientConfig{}
+	w.config.Transport = &roundTripper{watcher: w}
+	return w, nil
+}
+
+// Config returns a rest.Config always using the latest credentials from the kubeconfig file.
+func (w *Watcher) Config() *rest.Config {
+	return rest.CopyConfig(w.config)
+}
+
+// Start polls the kubeconfig file for changes until the context is cancelled.
+func (w *Watcher) Start(ctx context.Context) error {
+	log := logr.FromContextOrDiscard(ctx).WithValues("kubeconfig", w.path)
+
+	t := time.NewTicker(w.pollInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			reloaded, err := w.reload()
+			if err != nil {
+				// Keep using the last known good credentials.
+				log.Error(err, "reloading kubeconfig")
+				continue
+			}
+			if reloaded {
+				log.Info("reloaded kubeconfig")
+			}
+		}
+	}
+}
+
+// reload swaps the transport, if the content of the kubeconfig file changed.
+func (w *Watcher) reload() (reloaded bool, err error) {
+	content, cfg, err := w.load()
+	if err != nil {
+		return false, err
+	}
+
+	w.mux.RLock()
+	unchanged := bytes.Equal(w.content, content)
+	w.mux.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	if cfg.Host != w.host {
+		return false, fmt.Errorf(
+			"server changed from %q to %q, restart required", w.host, cfg.Host)
+	}
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		return false, fmt.Errorf("creating transport: %w", err)
+	}
+
+	w.mux.Lock()
+	oldTransport := w.transport
+	w.content = content
+	w.transport = transport
+	w.mux.Unlock()
+
+	// Don't reuse connections authenticated with the old credentials.
+	utilnet.CloseIdleConnectionsFor(oldTransport)
+	return true, nil
+}
+
+func (w *Watcher) load() ([]byte, *rest.Config, error) {
+	content, err := os.ReadFile(w.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading kubeconfig: %w", err)
+	}
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing kubeconfig: %w", err)
+	}
+	return content, cfg, nil
+}
+
+func (w *Watcher) currentTransport() http.RoundTripper {
+	w.mux.RLock()
+	defer w.mux.RUnlock()
+	return w.transport
+}
+
+// roundTripper delegates to the latest transport of the Watcher.
+type roundTripper struct {
+	watcher *Watcher
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return rt.watcher.currentTransport().RoundTrip(req)
+}
+
+func (rt *roundTripper) CloseIdleConnections() {
+	utilnet.CloseIdleConnectionsFor(rt.watcher.currentTransport())
+}
@@ -0,0 +1,122 @@
+package kubeconfig
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestWatcher(t *testing.T) {
+	t.Parallel()
+
+	var lastAuthorization string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastAuthorization = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	writeKubeconfig(t, path, srv.URL, "token-1")
+
+	w, err := NewWatcher(path)
+	require.NoError(t, err)
+
+	cfg := w.Config()
+	assert.Empty(t, cfg.BearerToken)
+	httpClient, err := rest.HTTPClientFor(cfg)
+	require.NoError(t, err)
+
+	doRequest(t, httpClient, srv.URL)
+	assert.Equal(t, "Bearer token-1", lastAuthorization)
+
+	// no change
+	reloaded, err := w.reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	// rotated credentials
+	writeKubeconfig(t, path, srv.URL, "token-2")
+	reloaded, err = w.reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+
+	doRequest(t, httpClient, srv.URL)
+	assert.Equal(t, "Bearer token-2", lastAuthorization)
+}
+
+func TestWatcher_hostChanged(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	writeKubeconfig(t, path, "https://cluster-1:6443", "token")
+
+	w, err := NewWatcher(path)
+	require.NoError(t, err)
+
+	writeKubeconfig(t, path, "https://cluster-2:6443", "token")
+	reloaded, err := w.reload()
+	require.EqualError(t, err,
+		`server changed from "https://cluster-1:6443" to "https://cluster-2:6443", restart required`)
+	assert.False(t, reloaded)
+}
+
+func TestWatcher_invalid(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	writeKubeconfig(t, path, "https://cluster:6443", "token")
+
+	w, err := NewWatcher(path)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), os.ModePerm))
+	_, err = w.reload()
+	require.Error(t, err)
+
+	_, err = NewWatcher(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
+
+func TestWatcher_Start(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	writeKubeconfig(t, path, "https://cluster:6443", "token")
+
+	w, err := NewWatcher(path)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, w.Start(ctx))
+}
+
+func writeKubeconfig(t *testing.T, path, server, token string) {
+	t.Helper()
+
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters["cluster"] = &clientcmdapi.Cluster{Server: server, InsecureSkipTLSVerify: true}
+	cfg.AuthInfos["user"] = &clientcmdapi.AuthInfo{Token: token}
+	cfg.Contexts["context"] = &clientcmdapi.Context{Cluster: "cluster", AuthInfo: "user"}
+	cfg.CurrentContext = "context"
+	require.NoError(t, clientcmd.WriteToFile(*cfg, path))
+}
+
+func doRequest(t *testing.T, c *http.Client, url string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}
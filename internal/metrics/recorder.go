@@ -1,12 +1,14 @@
 package metrics
 
 import (
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
@@ -18,6 +20,8 @@ import (
 type Recorder struct {
 	dynamicCacheInformers prometheus.Gauge
 	dynamicCacheObjects   *prometheus.GaugeVec
+	dynamicCacheRepairs   prometheus.Counter
+	managedObjects        prometheus.Gauge
 
 	packageAvailability *prometheus.GaugeVec
 	packageCreated      *prometheus.GaugeVec
@@ -26,8 +30,20 @@ type Recorder struct {
 
 	objectSetCreated   *prometheus.GaugeVec
 	objectSetSucceeded *prometheus.GaugeVec
+	objectSets         *prometheus.GaugeVec
+	objectSetConflicts *prometheus.GaugeVec
+
+	// Archived state by ObjectSet UID, to count ObjectSets per state.
+	objectSetArchivedMux sync.Mutex
+	objectSetArchived    map[types.UID]bool
 }
 
+// ObjectSet states reported by the package_operator_object_sets metric.
+const (
+	objectSetStateActive   = "Active"
+	objectSetStateArchived = "Archived"
+)
+
 func NewRecorder() *Recorder {
 	// DynamicCache
 	dynamicCacheInformers := prometheus.NewGauge(
@@ -40,6 +56,16 @@ func NewRecorder() *Recorder {
 			Name: "package_operator_dynamic_cache_objects",
 			Help: "Number of objects for each GVK in the dynamic cache.",
 		}, []string{"pko_gvk"})
+	dynamicCacheRepairs := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "package_operator_dynamic_cache_label_repairs_total",
+			Help: "Number of managed objects that had the dynamic cache label re-added.",
+		})
+	managedObjects := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "package_operator_managed_objects",
+			Help: "Total number of objects managed across all GVKs in the dynamic cache.",
+		})
 
 	// Package
 	packageAvailability := prometheus.NewGaugeVec(
@@ -80,10 +106,24 @@ func NewRecorder() *Recorder {
 			Help: "ObjectSet Unix success timestamp.",
 		}, []string{"pko_name", "pko_namespace", "pko_package_instance"},
 	)
+	objectSets := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "package_operator_object_sets",
+			Help: "Number of ObjectSets and ClusterObjectSets by state, either Active or Archived.",
+		}, []string{"pko_state"},
+	)
+	objectSetConflicts := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "package_operator_object_set_apply_conflicts",
+			Help: "Number of objects with fields recently taken over from another field manager.",
+		}, []string{"pko_name", "pko_namespace", "pko_package_instance", "pko_manager"},
+	)
 
 	return &Recorder{
 		dynamicCacheInformers: dynamicCacheInformers,
 		dynamicCacheObjects:   dynamicCacheObjects,
+		dynamicCacheRepairs:   dynamicCacheRepairs,
+		managedObjects:        managedObjects,
 
 		packageAvailability: packageAvailability,
 		packageCreated:      packageCreated,
@@ -92,16 +132,19 @@ func NewRecorder() *Recorder {
 
 		objectSetCreated:   objectSetCreated,
 		objectSetSucceeded: objectSetSucceeded,
+		objectSets:         objectSets,
+		objectSetConflicts: objectSetConflicts,
+		objectSetArchived:  map[types.UID]bool{},
 	}
 }
 
 // Register metrics into ctrl registry.
 func (r *Recorder) Register() {
 	ctrlmetrics.Registry.MustRegister(
-		r.dynamicCacheInformers, r.dynamicCacheObjects,
+		r.dynamicCacheInformers, r.dynamicCacheObjects, r.dynamicCacheRepairs, r.managedObjects,
 		r.packageAvailability, r.packageCreated, r.packageLoadDuration, r.packageRevision,
 
-		r.objectSetCreated, r.objectSetSucceeded,
+		r.objectSetCreated, r.objectSetSucceeded, r.objectSets, r.objectSetConflicts,
 	)
 }
 
@@ -156,6 +199,7 @@ func (r *Recorder) RecordPackageLoadMetric(pkg GenericPackage, d time.Duration)
 type GenericObjectSet interface {
 	ClientObject() client.Object
 	GetConditions() *[]metav1.Condition
+	GetStatusConflicts() []corev1alpha1.ObjectSetObjectConflict
 }
 
 func (r *Recorder) RecordObjectSetMetrics(objectSet GenericObjectSet) {
@@ -167,8 +211,10 @@ func (r *Recorder) RecordObjectSetMetrics(objectSet GenericObjectSet) {
 		instance = l[manifestsv1alpha1.PackageInstanceLabel]
 	}
 
-	if !obj.GetDeletionTimestamp().IsZero() ||
-		meta.IsStatusConditionTrue(*objectSet.GetConditions(), corev1alpha1.ObjectSetArchived) {
+	archived := meta.IsStatusConditionTrue(*objectSet.GetConditions(), corev1alpha1.ObjectSetArchived)
+	r.recordObjectSetState(obj, archived)
+
+	if !obj.GetDeletionTimestamp().IsZero() || archived {
 		r.objectSetSucceeded.DeleteLabelValues(obj.GetName(), obj.GetNamespace(), instance)
 	} else {
 		succeededCond := meta.FindStatusCondition(*objectSet.GetConditions(), corev1alpha1.ObjectSetSucceeded)
@@ -186,6 +232,43 @@ func (r *Recorder) RecordObjectSetMetrics(objectSet GenericObjectSet) {
 			WithLabelValues(obj.GetName(), obj.GetNamespace(), instance).
 			Set(float64(obj.GetCreationTimestamp().Unix()))
 	}
+
+	// Managers may change between reconciles, so all series of this ObjectSet are replaced.
+	r.objectSetConflicts.DeletePartialMatch(prometheus.Labels{
+		"pko_name": obj.GetName(), "pko_namespace": obj.GetNamespace(),
+	})
+	if obj.GetDeletionTimestamp().IsZero() && !archived {
+		objectsByManager := map[string]int{}
+		for _, c := range objectSet.GetStatusConflicts() {
+			objectsByManager[c.Manager]++
+		}
+		for manager, count := range objectsByManager {
+			r.objectSetConflicts.
+				WithLabelValues(obj.GetName(), obj.GetNamespace(), instance, manager).
+				Set(float64(count))
+		}
+	}
+}
+
+// Tracks the state of every ObjectSet to report the number of ObjectSets per state.
+func (r *Recorder) recordObjectSetState(obj client.Object, archived bool) {
+	r.objectSetArchivedMux.Lock()
+	defer r.objectSetArchivedMux.Unlock()
+
+	if obj.GetDeletionTimestamp().IsZero() {
+		r.objectSetArchived[obj.GetUID()] = archived
+	} else {
+		delete(r.objectSetArchived, obj.GetUID())
+	}
+
+	var archivedCount int
+	for _, a := range r.objectSetArchived {
+		if a {
+			archivedCount++
+		}
+	}
+	r.objectSets.WithLabelValues(objectSetStateActive).Set(float64(len(r.objectSetArchived) - archivedCount))
+	r.objectSets.WithLabelValues(objectSetStateArchived).Set(float64(archivedCount))
 }
 
 // Records the number of active Informers for the cache.
@@ -197,3 +280,13 @@ func (r *Recorder) RecordDynamicCacheInformers(total int) {
 func (r *Recorder) RecordDynamicCacheObjects(gvk schema.GroupVersionKind, count int) {
 	r.dynamicCacheObjects.WithLabelValues(gvk.String()).Set(float64(count))
 }
+
+// Records the number of objects that had their dynamic cache label repaired.
+func (r *Recorder) RecordDynamicCacheLabelRepairs(count int) {
+	r.dynamicCacheRepairs.Add(float64(count))
+}
+
+// Records the total number of objects in the cache across all GVKs.
+func (r *Recorder) RecordDynamicCacheObjectsTotal(total int) {
+	r.managedObjects.Set(float64(total))
+}
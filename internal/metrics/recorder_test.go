@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
@@ -29,6 +30,11 @@ func (m *genericObjectSetMock) GetConditions() *[]metav1.Condition {
 	return args.Get(0).(*[]metav1.Condition)
 }
 
+func (m *genericObjectSetMock) GetStatusConflicts() []corev1alpha1.ObjectSetObjectConflict {
+	args := m.Called()
+	return args.Get(0).([]corev1alpha1.ObjectSetObjectConflict)
+}
+
 func (m *genericObjectSetMock) GetRevision() int64 {
 	args := m.Called()
 	return args.Get(0).(int64)
@@ -194,6 +200,7 @@ func TestRecorder_RecordObjectSetMetrics(t *testing.T) {
 			osMock := &genericObjectSetMock{}
 			osMock.On("ClientObject").Return(obj)
 			osMock.On("GetConditions").Return(&test.conditions)
+			osMock.On("GetStatusConflicts").Return([]corev1alpha1.ObjectSetObjectConflict(nil))
 
 			recorder := NewRecorder()
 			recorder.RecordObjectSetMetrics(osMock)
@@ -213,3 +220,81 @@ func TestRecorder_RecordObjectSetMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestRecorder_RecordObjectSetMetrics_states(t *testing.T) {
+	recorder := NewRecorder()
+	record := func(uid string, archived, deleted bool) {
+		obj := &unstructured.Unstructured{}
+		obj.SetUID(types.UID(uid))
+		if deleted {
+			obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+		}
+		conditions := []metav1.Condition{}
+		if archived {
+			conditions = append(conditions, metav1.Condition{
+				Type:   corev1alpha1.ObjectSetArchived,
+				Status: metav1.ConditionTrue,
+			})
+		}
+
+		osMock := &genericObjectSetMock{}
+		osMock.On("ClientObject").Return(obj)
+		osMock.On("GetConditions").Return(&conditions)
+		osMock.On("GetStatusConflicts").Return([]corev1alpha1.ObjectSetObjectConflict(nil))
+		recorder.RecordObjectSetMetrics(osMock)
+	}
+
+	record("1", false, false)
+	record("2", false, false)
+	record("3", true, false)
+	assert.Equal(t, float64(2), testutil.ToFloat64(recorder.objectSets.WithLabelValues(objectSetStateActive)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.objectSets.WithLabelValues(objectSetStateArchived)))
+
+	record("1", true, false)
+	record("3", true, true)
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.objectSets.WithLabelValues(objectSetStateActive)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.objectSets.WithLabelValues(objectSetStateArchived)))
+}
+
+func TestRecorder_RecordObjectSetMetrics_conflicts(t *testing.T) {
+	recorder := NewRecorder()
+	obj := &unstructured.Unstructured{}
+	obj.SetName("test")
+	obj.SetNamespace("test-ns")
+	conditions := []metav1.Condition{}
+	record := func(conflicts ...corev1alpha1.ObjectSetObjectConflict) {
+		osMock := &genericObjectSetMock{}
+		osMock.On("ClientObject").Return(obj)
+		osMock.On("GetConditions").Return(&conditions)
+		osMock.On("GetStatusConflicts").Return(conflicts)
+		recorder.RecordObjectSetMetrics(osMock)
+	}
+
+	record(
+		corev1alpha1.ObjectSetObjectConflict{Manager: "kubectl-edit", Object: corev1alpha1.ControlledObjectReference{Name: "a"}},
+		corev1alpha1.ObjectSetObjectConflict{Manager: "kubectl-edit", Object: corev1alpha1.ControlledObjectReference{Name: "b"}},
+		corev1alpha1.ObjectSetObjectConflict{Manager: "hpa", Object: corev1alpha1.ControlledObjectReference{Name: "a"}},
+	)
+	assert.Equal(t, float64(2), testutil.ToFloat64(
+		recorder.objectSetConflicts.WithLabelValues("test", "test-ns", "", "kubectl-edit")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.objectSetConflicts.WithLabelValues("test", "test-ns", "", "hpa")))
+
+	record()
+	assert.Equal(t, 0, testutil.CollectAndCount(recorder.objectSetConflicts))
+}
+
+func TestRecorder_RecordDynamicCacheObjectsTotal(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.RecordDynamicCacheObjectsTotal(5)
+
+	assert.Equal(t, float64(5), testutil.ToFloat64(recorder.managedObjects))
+}
+
+func TestRecorder_RecordDynamicCacheLabelRepairs(t *testing.T) {
+	recorder := NewRecorder()
+	recorder.RecordDynamicCacheLabelRepairs(2)
+	recorder.RecordDynamicCacheLabelRepairs(1)
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(recorder.dynamicCacheRepairs))
+}
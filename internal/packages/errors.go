@@ -23,6 +23,8 @@ type (
 	ViolationLocation struct {
 		Path          string
 		DocumentIndex *int
+		// Line within the file, starting at 1. 0 if unknown.
+		Line int
 	}
 )
 
@@ -83,10 +85,14 @@ func (l *ViolationLocation) String() string {
 	if l == nil {
 		return ""
 	}
-	if l.DocumentIndex == nil {
-		return l.Path
+	msg := l.Path
+	if l.DocumentIndex != nil {
+		msg = fmt.Sprintf("%s#%d", msg, *l.DocumentIndex)
 	}
-	return fmt.Sprintf("%s#%d", l.Path, *l.DocumentIndex)
+	if l.Line > 0 {
+		msg = fmt.Sprintf("%s:%d", msg, l.Line)
+	}
+	return msg
 }
 
 const (
@@ -100,10 +106,17 @@ const (
 	ViolationReasonPackageManifestLockInvalid    = "PackageManifestLock invalid"
 	ViolationReasonPackageManifestLockDuplicated = "PackageManifestLock present multiple times"
 	ViolationReasonInvalidYAML                   = "Invalid YAML"
+	ViolationReasonUnknownField                  = "Unknown field"
 	ViolationReasonMissingPhaseAnnotation        = "Missing " + manifestsv1alpha1.PackagePhaseAnnotation + " Annotation"
 	ViolationReasonMissingGVK                    = "GroupVersionKind not set"
 	ViolationDuplicateObject                     = "Duplicate Object"
 	ViolationReasonLabelsInvalid                 = "Labels invalid"
+	ViolationReasonInvalidConditionMap           = "Invalid " + manifestsv1alpha1.PackageConditionMapAnnotation + " Annotation"
+	ViolationReasonInvalidFieldMap               = "Invalid " + manifestsv1alpha1.PackageFieldMapAnnotation + " Annotation"
 	ViolationReasonUnsupportedScope              = "Package unsupported scope"
 	ViolationReasonFixtureMismatch               = "File mismatch against fixture"
+	ViolationReasonSnapshotMismatch              = "File mismatch against test snapshot"
+	ViolationReasonSnapshotMissing               = "Test snapshot missing"
+	ViolationReasonComponentNotDeclared          = "Component not declared in PackageManifest"
+	ViolationReasonComponentNotFound             = "Component not found"
 )
@@ -97,4 +97,11 @@ func TestViolationLocation(t *testing.T) {
 		vl := &ViolationLocation{Path: "test/234.yaml", DocumentIndex: pointer.Int(3)}
 		assert.Equal(t, "test/234.yaml#3", vl.String())
 	})
+
+	t.Run("with line", func(t *testing.T) {
+		t.Parallel()
+
+		vl := &ViolationLocation{Path: "test/234.yaml", Line: 12}
+		assert.Equal(t, "test/234.yaml:12", vl.String())
+	})
 }
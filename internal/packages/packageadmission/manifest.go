@@ -71,6 +71,20 @@ func ValidatePackageManifest(ctx context.Context, scheme *runtime.Scheme, obj *m
 		}
 	}
 
+	specComponents := spec.Child("components")
+	componentNames := map[string]struct{}{}
+	for i, component := range obj.Spec.Components {
+		if el := validation.IsDNS1123Label(component.Name); len(el) > 0 {
+			allErrs = append(allErrs,
+				field.Invalid(specComponents.Index(i).Child("name"), component.Name, strings.Join(el, ", ")))
+		}
+		if _, alreadyExists := componentNames[component.Name]; alreadyExists {
+			allErrs = append(allErrs,
+				field.Invalid(specComponents.Index(i).Child("name"), component.Name, "must be unique"))
+		}
+		componentNames[component.Name] = struct{}{}
+	}
+
 	configErrors := validatePackageManifestConfig(ctx, scheme, &obj.Spec.Config, spec.Child("config"))
 	allErrs = append(allErrs, configErrors...)
 
@@ -85,6 +85,25 @@ func TestValidatePackageManifest(t *testing.T) {
 				"spec.images[0].image: Invalid value: \"\": must be non empty",
 			},
 		},
+		{
+			name: "invalid components",
+			packageManifest: &manifestsv1alpha1.PackageManifest{
+				Spec: manifestsv1alpha1.PackageManifestSpec{
+					Components: []manifestsv1alpha1.PackageManifestComponent{
+						{Name: "console-plugin"},
+						{Name: "console-plugin"},
+						{Name: "Monitoring"},
+					},
+				},
+			},
+			expectedErrors: []string{
+				"metadata.name: Required value",
+				"spec.scopes: Required value",
+				"spec.phases: Required value",
+				"spec.components[1].name: Invalid value: \"console-plugin\": must be unique",
+				"spec.components[2].name: Invalid value: \"Monitoring\": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')",
+			},
+		},
 		{
 			name: "empty image name",
 			packageManifest: &manifestsv1alpha1.PackageManifest{
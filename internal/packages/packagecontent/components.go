@@ -0,0 +1,75 @@
+package packagecontent
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"package-operator.run/package-operator/internal/packages"
+)
+
+// Component returns the files of the given component,
+// relative to the component folder, so the component can be loaded as a package on its own.
+func (f Files) Component(component string) Files {
+	prefix := path.Join(packages.ComponentsFolder, component) + "/"
+	componentFiles := Files{}
+	for filePath, data := range f {
+		if packages.ComponentOf(filePath) == component {
+			componentFiles[strings.TrimPrefix(filePath, prefix)] = data
+		}
+	}
+	return componentFiles
+}
+
+// ComponentFiles returns the files of the given component of a package,
+// after checking that the PackageManifest of the package declares the component.
+// Returns the files unchanged, if component is empty.
+func ComponentFiles(ctx context.Context, scheme *runtime.Scheme, files Files, component string) (Files, error) {
+	if len(component) == 0 {
+		return files, nil
+	}
+
+	var manifestPath string
+	for filePath := range files {
+		if packages.IsManifestFile(filePath) {
+			manifestPath = filePath
+			break
+		}
+	}
+	if len(manifestPath) == 0 {
+		return nil, packages.NewInvalidError(packages.Violation{
+			Reason:  packages.ViolationReasonPackageManifestNotFound,
+			Details: "searched at " + strings.Join(packages.PackageManifestFileNames, ","),
+		})
+	}
+	manifest, err := manifestFromFile(ctx, scheme, manifestPath, files[manifestPath], false)
+	if err != nil {
+		return nil, err
+	}
+
+	var declared bool
+	for _, c := range manifest.Spec.Components {
+		if c.Name == component {
+			declared = true
+			break
+		}
+	}
+	if !declared {
+		return nil, packages.NewInvalidError(packages.Violation{
+			Reason:   packages.ViolationReasonComponentNotDeclared,
+			Details:  component,
+			Location: &packages.ViolationLocation{Path: manifestPath},
+		})
+	}
+
+	componentFiles := files.Component(component)
+	if len(componentFiles) == 0 {
+		return nil, packages.NewInvalidError(packages.Violation{
+			Reason:  packages.ViolationReasonComponentNotFound,
+			Details: "searched at " + path.Join(packages.ComponentsFolder, component),
+		})
+	}
+	return componentFiles, nil
+}
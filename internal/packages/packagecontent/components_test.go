@@ -0,0 +1,85 @@
+package packagecontent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/package-operator/internal/packages"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+func TestComponentFiles(t *testing.T) {
+	t.Parallel()
+
+	files := packagecontent.Files{
+		"manifest.yaml": []byte(`apiVersion: manifests.package-operator.run/v1alpha1
+kind: PackageManifest
+metadata:
+  name: suite
+spec:
+  scopes:
+  - Namespaced
+  phases:
+  - name: deploy
+  components:
+  - name: console-plugin
+  - name: monitoring
+`),
+		"deployment.yaml":                           {1},
+		"components/console-plugin/manifest.yaml":   {2},
+		"components/console-plugin/deploy/cm.yaml":  {3},
+		"components/monitoring-rules/manifest.yaml": {4},
+	}
+
+	tests := []struct {
+		name      string
+		component string
+		expected  packagecontent.Files
+		reason    string
+	}{
+		{
+			name:     "no component",
+			expected: files,
+		},
+		{
+			name:      "component",
+			component: "console-plugin",
+			expected: packagecontent.Files{
+				"manifest.yaml":  {2},
+				"deploy/cm.yaml": {3},
+			},
+		},
+		{
+			name:      "not declared",
+			component: "monitoring-rules",
+			reason:    packages.ViolationReasonComponentNotDeclared,
+		},
+		{
+			name:      "not found",
+			component: "monitoring",
+			reason:    packages.ViolationReasonComponentNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			componentFiles, err := packagecontent.ComponentFiles(
+				context.Background(), testScheme, files, test.component)
+			if len(test.reason) > 0 {
+				var invalidErr *packages.InvalidError
+				require.True(t, errors.As(err, &invalidErr), "expected InvalidError, got: %v", err)
+				assert.Equal(t, test.reason, invalidErr.Violations[0].Reason)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, componentFiles)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package packagecontent
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/package-operator/internal/utils"
+)
+
+var (
+	configMapGK = schema.GroupKind{Kind: "ConfigMap"}
+	secretGK    = schema.GroupKind{Kind: "Secret"}
+)
+
+type contentHashKey struct {
+	schema.GroupKind
+	Namespace, Name string
+}
+
+// Appends a content hash to the names of ConfigMaps and Secrets
+// annotated with the content-hash-suffix annotation, marks them immutable
+// and rewrites references to them in pod templates of all other objects.
+// Returns deep copies, the input is not modified.
+func withContentHashSuffixes(objectsByFile map[string][]unstructured.Unstructured) map[string][]unstructured.Unstructured {
+	out := make(map[string][]unstructured.Unstructured, len(objectsByFile))
+	renames := map[contentHashKey]string{}
+	for path, objects := range objectsByFile {
+		out[path] = make([]unstructured.Unstructured, len(objects))
+		for i := range objects {
+			obj := objects[i].DeepCopy()
+			if renamed, ok := contentHashSuffix(obj); ok {
+				renames[contentHashKey{
+					GroupKind: obj.GroupVersionKind().GroupKind(),
+					Namespace: obj.GetNamespace(),
+					Name:      obj.GetName(),
+				}] = renamed
+				obj.SetName(renamed)
+				obj.Object["immutable"] = true
+			}
+			out[path][i] = *obj
+		}
+	}
+	if len(renames) == 0 {
+		return out
+	}
+
+	for _, objects := range out {
+		for i := range objects {
+			rewritePodTemplateReferences(&objects[i], renames)
+		}
+	}
+	return out
+}
+
+// Checks the content-hash-suffix annotation and returns the new object name.
+// The annotation is removed from every object.
+func contentHashSuffix(obj *unstructured.Unstructured) (string, bool) {
+	annotations := obj.GetAnnotations()
+	enabled := annotations[manifestsv1alpha1.PackageContentHashSuffixAnnotation] == "True"
+	if _, ok := annotations[manifestsv1alpha1.PackageContentHashSuffixAnnotation]; ok {
+		delete(annotations, manifestsv1alpha1.PackageContentHashSuffixAnnotation)
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		obj.SetAnnotations(annotations)
+	}
+	if !enabled {
+		return "", false
+	}
+
+	gk := obj.GroupVersionKind().GroupKind()
+	if gk != configMapGK && gk != secretGK {
+		return "", false
+	}
+
+	content := map[string]interface{}{}
+	for _, field := range []string{"type", "data", "binaryData", "stringData"} {
+		if v, ok := obj.Object[field]; ok {
+			content[field] = v
+		}
+	}
+	return obj.GetName() + "-" + utils.ComputeFNV32Hash(content, nil), true
+}
+
+// Paths to pod specs within well known workload kinds.
+var podSpecPaths = map[schema.GroupKind][]string{
+	{Kind: "Pod"}:                        {"spec"},
+	{Kind: "ReplicationController"}:      {"spec", "template", "spec"},
+	{Group: "apps", Kind: "Deployment"}:  {"spec", "template", "spec"},
+	{Group: "apps", Kind: "StatefulSet"}: {"spec", "template", "spec"},
+	{Group: "apps", Kind: "DaemonSet"}:   {"spec", "template", "spec"},
+	{Group: "apps", Kind: "ReplicaSet"}:  {"spec", "template", "spec"},
+	{Group: "batch", Kind: "Job"}:        {"spec", "template", "spec"},
+	{Group: "batch", Kind: "CronJob"}:    {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+func rewritePodTemplateReferences(obj *unstructured.Unstructured, renames map[contentHashKey]string) {
+	path, ok := podSpecPaths[obj.GroupVersionKind().GroupKind()]
+	if !ok {
+		return
+	}
+	podSpec, ok, _ := unstructured.NestedMap(obj.Object, path...)
+	if !ok {
+		return
+	}
+
+	rename := func(gk schema.GroupKind, m map[string]interface{}, field string) {
+		name, _ := m[field].(string)
+		if renamed, ok := renames[contentHashKey{
+			GroupKind: gk, Namespace: obj.GetNamespace(), Name: name,
+		}]; ok {
+			m[field] = renamed
+		}
+	}
+
+	forEachMap(podSpec["volumes"], func(volume map[string]interface{}) {
+		if cm, ok := volume["configMap"].(map[string]interface{}); ok {
+			rename(configMapGK, cm, "name")
+		}
+		if secret, ok := volume["secret"].(map[string]interface{}); ok {
+			rename(secretGK, secret, "secretName")
+		}
+		projected, _ := volume["projected"].(map[string]interface{})
+		forEachMap(projected["sources"], func(source map[string]interface{}) {
+			if cm, ok := source["configMap"].(map[string]interface{}); ok {
+				rename(configMapGK, cm, "name")
+			}
+			if secret, ok := source["secret"].(map[string]interface{}); ok {
+				rename(secretGK, secret, "name")
+			}
+		})
+	})
+
+	for _, containers := range []string{"initContainers", "containers", "ephemeralContainers"} {
+		forEachMap(podSpec[containers], func(container map[string]interface{}) {
+			forEachMap(container["envFrom"], func(envFrom map[string]interface{}) {
+				if ref, ok := envFrom["configMapRef"].(map[string]interface{}); ok {
+					rename(configMapGK, ref, "name")
+				}
+				if ref, ok := envFrom["secretRef"].(map[string]interface{}); ok {
+					rename(secretGK, ref, "name")
+				}
+			})
+			forEachMap(container["env"], func(env map[string]interface{}) {
+				valueFrom, _ := env["valueFrom"].(map[string]interface{})
+				if ref, ok := valueFrom["configMapKeyRef"].(map[string]interface{}); ok {
+					rename(configMapGK, ref, "name")
+				}
+				if ref, ok := valueFrom["secretKeyRef"].(map[string]interface{}); ok {
+					rename(secretGK, ref, "name")
+				}
+			})
+		})
+	}
+
+	forEachMap(podSpec["imagePullSecrets"], func(ref map[string]interface{}) {
+		rename(secretGK, ref, "name")
+	})
+
+	// NestedMap returns a deep copy, so we have to write the changes back.
+	_ = unstructured.SetNestedMap(obj.Object, podSpec, path...)
+}
+
+func forEachMap(list interface{}, fn func(map[string]interface{})) {
+	items, _ := list.([]interface{})
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			fn(m)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package packagecontent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+)
+
+func TestWithContentHashSuffixes(t *testing.T) {
+	t.Parallel()
+
+	configMap := func(value string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name": "config",
+				"annotations": map[string]interface{}{
+					manifestsv1alpha1.PackageContentHashSuffixAnnotation: "True",
+				},
+			},
+			"data": map[string]interface{}{"key": value},
+		}}
+	}
+	secret := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name": "creds",
+			"annotations": map[string]interface{}{
+				manifestsv1alpha1.PackageContentHashSuffixAnnotation: "True",
+			},
+		},
+		"stringData": map[string]interface{}{"password": "hunter2"},
+	}}
+	deployment := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "app"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"volumes": []interface{}{
+						map[string]interface{}{
+							"name":      "config",
+							"configMap": map[string]interface{}{"name": "config"},
+						},
+						map[string]interface{}{
+							"name":      "other",
+							"configMap": map[string]interface{}{"name": "other"},
+						},
+					},
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name": "app",
+							"env": []interface{}{
+								map[string]interface{}{
+									"name": "PASSWORD",
+									"valueFrom": map[string]interface{}{
+										"secretKeyRef": map[string]interface{}{"name": "creds", "key": "password"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	in := map[string][]unstructured.Unstructured{
+		"config.yaml":     {configMap("a"), secret},
+		"deployment.yaml": {deployment},
+	}
+	out := withContentHashSuffixes(in)
+
+	cm := out["config.yaml"][0]
+	assert.Regexp(t, `^config-\w+$`, cm.GetName())
+	assert.Nil(t, cm.GetAnnotations())
+	assert.Equal(t, true, cm.Object["immutable"])
+	s := out["config.yaml"][1]
+	assert.Regexp(t, `^creds-\w+$`, s.GetName())
+
+	// input is left untouched
+	assert.Equal(t, "config", in["config.yaml"][0].GetName())
+
+	podSpec, _, err := unstructured.NestedMap(out["deployment.yaml"][0].Object, "spec", "template", "spec")
+	require.NoError(t, err)
+	volumes := podSpec["volumes"].([]interface{})
+	assert.Equal(t, cm.GetName(), volumes[0].(map[string]interface{})["configMap"].(map[string]interface{})["name"])
+	assert.Equal(t, "other", volumes[1].(map[string]interface{})["configMap"].(map[string]interface{})["name"])
+	secretRef, _, err := unstructured.NestedString(
+		podSpec["containers"].([]interface{})[0].(map[string]interface{})["env"].([]interface{})[0].(map[string]interface{}),
+		"valueFrom", "secretKeyRef", "name")
+	require.NoError(t, err)
+	assert.Equal(t, s.GetName(), secretRef)
+
+	// content changes result in a new name
+	changed := withContentHashSuffixes(map[string][]unstructured.Unstructured{
+		"config.yaml": {configMap("b")},
+	})
+	assert.NotEqual(t, cm.GetName(), changed["config.yaml"][0].GetName())
+}
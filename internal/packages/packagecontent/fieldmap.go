@@ -0,0 +1,61 @@
+package packagecontent
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+)
+
+type FieldMapParseError struct {
+	Message    string
+	LineNumber int
+}
+
+func (e FieldMapParseError) Error() string {
+	return e.Message + fmt.Sprintf(" in line %d", e.LineNumber)
+}
+
+func ParseFieldMapAnnotation(obj *unstructured.Unstructured) ([]corev1alpha1.FieldMapping, error) {
+	fieldMapAnnotation, ok := obj.GetAnnotations()[manifestsv1alpha1.PackageFieldMapAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	inputMappings := strings.Split(strings.TrimSpace(fieldMapAnnotation), "\n")
+	outputMappings := make([]corev1alpha1.FieldMapping, len(inputMappings))
+	for i, rawMapping := range inputMappings {
+		line := i + 1
+		parts := strings.SplitN(rawMapping, "=>", 2)
+		if len(parts) != 2 {
+			return nil, FieldMapParseError{
+				Message:    fmt.Sprintf("expected 2 part mapping got %d", len(parts)),
+				LineNumber: line,
+			}
+		}
+		source := strings.TrimSpace(parts[0])
+		destination := strings.TrimSpace(parts[1])
+		if len(source) == 0 {
+			return nil, FieldMapParseError{
+				Message:    "source can't be empty",
+				LineNumber: line,
+			}
+		}
+		if len(destination) == 0 {
+			return nil, FieldMapParseError{
+				Message:    "destination can't be empty",
+				LineNumber: line,
+			}
+		}
+
+		outputMappings[i] = corev1alpha1.FieldMapping{
+			Source:      source,
+			Destination: destination,
+		}
+	}
+
+	return outputMappings, nil
+}
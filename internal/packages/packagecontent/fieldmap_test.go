@@ -0,0 +1,76 @@
+package packagecontent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+)
+
+func TestParseFieldMap(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]interface{}{
+					manifestsv1alpha1.PackageFieldMapAnnotation: ".status.endpoint => my-prefix/endpoint\n{.status.ip} => my-prefix/ip",
+				},
+			},
+		},
+	}
+
+	mappings, err := ParseFieldMapAnnotation(obj)
+	require.NoError(t, err)
+	assert.Equal(t, []corev1alpha1.FieldMapping{
+		{Source: ".status.endpoint", Destination: "my-prefix/endpoint"},
+		{Source: "{.status.ip}", Destination: "my-prefix/ip"},
+	}, mappings)
+
+	mappings, err = ParseFieldMapAnnotation(&unstructured.Unstructured{})
+	require.NoError(t, err)
+	assert.Nil(t, mappings)
+}
+
+func TestParseFieldMap_error(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		err        string
+	}{
+		{
+			name:       "missing destination",
+			annotation: ".status.endpoint =>",
+			err:        "destination can't be empty in line 1",
+		},
+		{
+			name:       "missing source",
+			annotation: "=> bla",
+			err:        "source can't be empty in line 1",
+		},
+		{
+			name:       "nothing",
+			annotation: "xxxx",
+			err:        "expected 2 part mapping got 1 in line 1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							manifestsv1alpha1.PackageFieldMapAnnotation: test.annotation,
+						},
+					},
+				},
+			}
+
+			_, err := ParseFieldMapAnnotation(obj)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
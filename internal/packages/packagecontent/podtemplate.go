@@ -0,0 +1,137 @@
+package packagecontent
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// MergePodTemplateMetadata adds labels and annotations to the pod template of well known workload kinds
+// or to the metadata of Pods. Labels and annotations already present are not overridden,
+// so label selectors of the workload keep matching.
+// Returns false, if the object has no pod template.
+func MergePodTemplateMetadata(
+	obj *unstructured.Unstructured, labels, annotations map[string]string,
+) bool {
+	specPath, ok := podSpecPaths[obj.GroupVersionKind().GroupKind()]
+	if !ok {
+		return false
+	}
+	// Pod template metadata is next to the pod spec.
+	metadataPath := append(append([]string{}, specPath[:len(specPath)-1]...), "metadata")
+
+	merge := func(field string, values map[string]string) {
+		if len(values) == 0 {
+			return
+		}
+		path := append(append([]string{}, metadataPath...), field)
+		existing, _, _ := unstructured.NestedStringMap(obj.Object, path...)
+		if existing == nil {
+			existing = map[string]string{}
+		}
+		for k, v := range values {
+			if _, ok := existing[k]; !ok {
+				existing[k] = v
+			}
+		}
+		_ = unstructured.SetNestedStringMap(obj.Object, existing, path...)
+	}
+	merge("labels", labels)
+	merge("annotations", annotations)
+	return true
+}
+
+// MergePodTemplateEnv adds the given environment variables to all containers and init containers
+// of the pod template of a workload. Variables already set by a container are not overridden.
+// Returns false, if the object has no pod template.
+func MergePodTemplateEnv(obj *unstructured.Unstructured, env []corev1.EnvVar) bool {
+	return mutatePodTemplateContainers(obj, func(container map[string]interface{}) {
+		existing, _, _ := unstructured.NestedSlice(container, "env")
+		names := map[string]struct{}{}
+		for _, e := range existing {
+			if m, ok := e.(map[string]interface{}); ok {
+				names[fmt.Sprint(m["name"])] = struct{}{}
+			}
+		}
+		for _, e := range env {
+			if _, ok := names[e.Name]; ok {
+				continue
+			}
+			existing = append(existing, map[string]interface{}{
+				"name": e.Name, "value": e.Value,
+			})
+		}
+		container["env"] = existing
+	})
+}
+
+// AddPodTemplateConfigMapVolume adds a volume backed by the given ConfigMap to the pod template of a workload
+// and mounts it into all containers and init containers.
+// Nothing is changed, if a volume with the same name is already present.
+// Returns false, if the object has no pod template.
+func AddPodTemplateConfigMapVolume(
+	obj *unstructured.Unstructured, volume string, configMap corev1.ConfigMapVolumeSource,
+	mount corev1.VolumeMount,
+) bool {
+	specPath, ok := podSpecPaths[obj.GroupVersionKind().GroupKind()]
+	if !ok {
+		return false
+	}
+
+	volumesPath := append(append([]string{}, specPath...), "volumes")
+	volumes, _, _ := unstructured.NestedSlice(obj.Object, volumesPath...)
+	for _, v := range volumes {
+		if m, ok := v.(map[string]interface{}); ok && m["name"] == volume {
+			return true
+		}
+	}
+
+	items := make([]interface{}, len(configMap.Items))
+	for i, item := range configMap.Items {
+		items[i] = map[string]interface{}{"key": item.Key, "path": item.Path}
+	}
+	configMapSource := map[string]interface{}{"name": configMap.Name}
+	if len(items) > 0 {
+		configMapSource["items"] = items
+	}
+	volumes = append(volumes, map[string]interface{}{
+		"name":      volume,
+		"configMap": configMapSource,
+	})
+	_ = unstructured.SetNestedSlice(obj.Object, volumes, volumesPath...)
+
+	mutatePodTemplateContainers(obj, func(container map[string]interface{}) {
+		mounts, _, _ := unstructured.NestedSlice(container, "volumeMounts")
+		container["volumeMounts"] = append(mounts, map[string]interface{}{
+			"name":      volume,
+			"mountPath": mount.MountPath,
+			"readOnly":  mount.ReadOnly,
+		})
+	})
+	return true
+}
+
+func mutatePodTemplateContainers(
+	obj *unstructured.Unstructured, mutate func(container map[string]interface{}),
+) bool {
+	specPath, ok := podSpecPaths[obj.GroupVersionKind().GroupKind()]
+	if !ok {
+		return false
+	}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		path := append(append([]string{}, specPath...), field)
+		containers, _, _ := unstructured.NestedSlice(obj.Object, path...)
+		if len(containers) == 0 {
+			continue
+		}
+		for i := range containers {
+			if container, ok := containers[i].(map[string]interface{}); ok {
+				mutate(container)
+			}
+		}
+		_ = unstructured.SetNestedSlice(obj.Object, containers, path...)
+	}
+	return true
+}
@@ -0,0 +1,164 @@
+package packagecontent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMergePodTemplateMetadata(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"app": "injected", "team": "payments"}
+	annotations := map[string]string{"sidecar.istio.io/inject": "true"}
+
+	t.Run("Deployment", func(t *testing.T) {
+		t.Parallel()
+
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "test"},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{"app": "test"},
+					},
+				},
+			},
+		}}
+		assert.True(t, MergePodTemplateMetadata(obj, labels, annotations))
+
+		podLabels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "template", "metadata", "labels")
+		assert.Equal(t, map[string]string{"app": "test", "team": "payments"}, podLabels)
+		podAnnotations, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "template", "metadata", "annotations")
+		assert.Equal(t, annotations, podAnnotations)
+		// Object metadata is not touched.
+		assert.Empty(t, obj.GetLabels())
+	})
+
+	t.Run("CronJob", func(t *testing.T) {
+		t.Parallel()
+
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "batch/v1",
+			"kind":       "CronJob",
+		}}
+		assert.True(t, MergePodTemplateMetadata(obj, labels, nil))
+
+		podLabels, _, _ := unstructured.NestedStringMap(
+			obj.Object, "spec", "jobTemplate", "spec", "template", "metadata", "labels")
+		assert.Equal(t, labels, podLabels)
+		_, found, _ := unstructured.NestedFieldNoCopy(
+			obj.Object, "spec", "jobTemplate", "spec", "template", "metadata", "annotations")
+		assert.False(t, found)
+	})
+
+	t.Run("Pod", func(t *testing.T) {
+		t.Parallel()
+
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+		}}
+		assert.True(t, MergePodTemplateMetadata(obj, labels, annotations))
+		assert.Equal(t, labels, obj.GetLabels())
+		assert.Equal(t, annotations, obj.GetAnnotations())
+	})
+
+	t.Run("ConfigMap", func(t *testing.T) {
+		t.Parallel()
+
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+		}}
+		assert.False(t, MergePodTemplateMetadata(obj, labels, annotations))
+		assert.Equal(t, map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"}, obj.Object)
+	})
+}
+
+func TestMergePodTemplateEnv(t *testing.T) {
+	t.Parallel()
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"initContainers": []interface{}{
+						map[string]interface{}{"name": "init"},
+					},
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name": "manager",
+							"env": []interface{}{
+								map[string]interface{}{"name": "HTTP_PROXY", "value": "custom"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+	assert.True(t, MergePodTemplateEnv(obj, []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: "http://proxy"},
+		{Name: "NO_PROXY", Value: "localhost"},
+	}))
+
+	initContainers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "initContainers")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "HTTP_PROXY", "value": "http://proxy"},
+		map[string]interface{}{"name": "NO_PROXY", "value": "localhost"},
+	}, initContainers[0].(map[string]interface{})["env"])
+	containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "HTTP_PROXY", "value": "custom"},
+		map[string]interface{}{"name": "NO_PROXY", "value": "localhost"},
+	}, containers[0].(map[string]interface{})["env"])
+
+	assert.False(t, MergePodTemplateEnv(&unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+	}}, nil))
+}
+
+func TestAddPodTemplateConfigMapVolume(t *testing.T) {
+	t.Parallel()
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "manager"},
+			},
+		},
+	}}
+	for i := 0; i < 2; i++ {
+		assert.True(t, AddPodTemplateConfigMapVolume(obj, "ca", corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "ca-bundle"},
+			Items:                []corev1.KeyToPath{{Key: "ca-bundle.crt", Path: "tls-ca-bundle.pem"}},
+		}, corev1.VolumeMount{MountPath: "/etc/pki", ReadOnly: true}))
+	}
+
+	volumes, _, _ := unstructured.NestedSlice(obj.Object, "spec", "volumes")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name": "ca",
+			"configMap": map[string]interface{}{
+				"name": "ca-bundle",
+				"items": []interface{}{
+					map[string]interface{}{"key": "ca-bundle.crt", "path": "tls-ca-bundle.pem"},
+				},
+			},
+		},
+	}, volumes)
+	containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "containers")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "ca", "mountPath": "/etc/pki", "readOnly": true},
+	}, containers[0].(map[string]interface{})["volumeMounts"])
+}
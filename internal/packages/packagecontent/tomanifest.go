@@ -3,10 +3,12 @@ package packagecontent
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
 
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
@@ -15,7 +17,7 @@ import (
 )
 
 func manifestFromFile(
-	ctx context.Context, scheme *runtime.Scheme, fileName string, manifestBytes []byte,
+	ctx context.Context, scheme *runtime.Scheme, fileName string, manifestBytes []byte, strict bool,
 ) (*manifestsv1alpha1.PackageManifest, error) {
 	// Unmarshal "pre-load" to peek desired GVK.
 	var manifestType metav1.TypeMeta
@@ -82,6 +84,12 @@ func manifestFromFile(
 		return nil, packages.NewInvalidError(violation)
 	}
 
+	if strict || manifest.Spec.Strict {
+		if err := unmarshalStrict(scheme, gvk, fileName, manifestBytes); err != nil {
+			return nil, err
+		}
+	}
+
 	fErr, err := packageadmission.ValidatePackageManifest(ctx, scheme, manifest)
 	if err != nil {
 		return nil, err
@@ -99,7 +107,7 @@ func manifestFromFile(
 }
 
 func manifestLockFromFile(
-	ctx context.Context, scheme *runtime.Scheme, fileName string, manifestBytes []byte,
+	ctx context.Context, scheme *runtime.Scheme, fileName string, manifestBytes []byte, strict bool,
 ) (*manifestsv1alpha1.PackageManifestLock, error) {
 	// Unmarshal "pre-load" to peek desired GVK.
 	var manifestType metav1.TypeMeta
@@ -166,6 +174,12 @@ func manifestLockFromFile(
 		return nil, packages.NewInvalidError(violation)
 	}
 
+	if strict {
+		if err := unmarshalStrict(scheme, gvk, fileName, manifestBytes); err != nil {
+			return nil, err
+		}
+	}
+
 	fErr, err := packageadmission.ValidatePackageManifestLock(ctx, manifest)
 	if err != nil {
 		return nil, err
@@ -181,3 +195,43 @@ func manifestLockFromFile(
 	}
 	return manifest, nil
 }
+
+var unknownFieldRegexp = regexp.MustCompile(`unknown field "([^"]+)"`)
+
+// Decodes the given manifest again, rejecting unknown fields
+// that are silently dropped by the regular decoding.
+func unmarshalStrict(scheme *runtime.Scheme, gvk schema.GroupVersionKind, fileName string, manifestBytes []byte) error {
+	obj, err := scheme.New(gvk)
+	if err != nil {
+		return err
+	}
+	err = yaml.UnmarshalStrict(manifestBytes, obj)
+	if err == nil {
+		return nil
+	}
+
+	violation := packages.Violation{
+		Reason:   packages.ViolationReasonInvalidYAML,
+		Details:  err.Error(),
+		Location: &packages.ViolationLocation{Path: fileName},
+	}
+	if m := unknownFieldRegexp.FindStringSubmatch(err.Error()); m != nil {
+		violation.Reason = packages.ViolationReasonUnknownField
+		violation.Details = m[1]
+		violation.Location.Line = keyLine(manifestBytes, m[1])
+	}
+	return packages.NewInvalidError(violation)
+}
+
+// Returns the first line declaring the given key or 0, if the key can't be found.
+// The decoder does not report the position of unknown fields,
+// so this is a best-effort hint for the package author.
+func keyLine(manifestBytes []byte, key string) int {
+	keyRegexp := regexp.MustCompile(`^\s*(- )?"?` + regexp.QuoteMeta(key) + `"?\s*:`)
+	for i, line := range strings.Split(string(manifestBytes), "\n") {
+		if keyRegexp.MatchString(line) {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -13,13 +13,39 @@ import (
 	"package-operator.run/package-operator/internal/packages"
 )
 
-func PackageFromFiles(ctx context.Context, scheme *runtime.Scheme, files Files) (pkg *Package, err error) {
+type (
+	FromFilesOption func(c *fromFilesConfig)
+
+	fromFilesConfig struct {
+		strictManifests bool
+	}
+)
+
+// Rejects unknown fields in the PackageManifest and PackageManifestLock.
+func WithStrictManifests(c *fromFilesConfig) {
+	c.strictManifests = true
+}
+
+func PackageFromFiles(
+	ctx context.Context, scheme *runtime.Scheme, files Files, opts ...FromFilesOption,
+) (pkg *Package, err error) {
+	var cfg fromFilesConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	pkg = &Package{nil, nil, map[string][]unstructured.Unstructured{}}
 	for path, content := range files {
 		switch {
 		case !packages.IsYAMLFile(path):
 			// skip non YAML files
 			continue
+		case packages.IsPackageTestFile(path):
+			// skip test snapshots
+			continue
+		case packages.IsComponentFile(path):
+			// skip components, they are loaded on their own
+			continue
 		case packages.IsManifestFile(path):
 			if pkg.PackageManifest != nil {
 				err = packages.NewInvalidError(packages.Violation{
@@ -29,7 +55,7 @@ func PackageFromFiles(ctx context.Context, scheme *runtime.Scheme, files Files)
 
 				return
 			}
-			pkg.PackageManifest, err = manifestFromFile(ctx, scheme, path, content)
+			pkg.PackageManifest, err = manifestFromFile(ctx, scheme, path, content, cfg.strictManifests)
 			if err != nil {
 				return nil, err
 			}
@@ -44,7 +70,7 @@ func PackageFromFiles(ctx context.Context, scheme *runtime.Scheme, files Files)
 
 				return
 			}
-			pkg.PackageManifestLock, err = manifestLockFromFile(ctx, scheme, path, content)
+			pkg.PackageManifestLock, err = manifestLockFromFile(ctx, scheme, path, content, cfg.strictManifests)
 			if err != nil {
 				return nil, err
 			}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	pkoapis "package-operator.run/apis"
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/packages"
 	"package-operator.run/package-operator/internal/packages/packagecontent"
 	"package-operator.run/package-operator/internal/packages/packageimport"
@@ -34,6 +38,58 @@ func TestPackageFromFile(t *testing.T) {
 	require.NotNil(t, pkg)
 }
 
+func TestPackageFromFile_SkipsTestFiles(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	files, err := packageimport.Folder(ctx, "testdata")
+	require.NoError(t, err)
+	files["test/case/some-statefulset.yaml"] = []byte("not: [valid")
+
+	pkg, err := packagecontent.PackageFromFiles(ctx, testScheme, files)
+	require.NoError(t, err)
+	require.NotContains(t, pkg.Objects, "test/case/some-statefulset.yaml")
+}
+
+func TestPackageFromFile_Strict(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	files, err := packageimport.Folder(ctx, "testdata")
+	require.NoError(t, err)
+
+	_, err = packagecontent.PackageFromFiles(ctx, testScheme, files, packagecontent.WithStrictManifests)
+	require.EqualError(t, err, `Package validation errors:
+- Unknown field in manifest.yaml:5:
+  catalog`)
+}
+
+func TestPackageFromFile_StrictManifest(t *testing.T) {
+	t.Parallel()
+
+	files := packagecontent.Files{
+		packages.PackageManifestFile: []byte(`apiVersion: manifests.package-operator.run/v1alpha1
+kind: PackageManifest
+metadata:
+  name: test
+spec:
+  strict: true
+  scopes:
+  - Namespaced
+  phases:
+  - name: deploy
+  availabiltyProbes: []
+`),
+	}
+
+	_, err := packagecontent.PackageFromFiles(context.Background(), testScheme, files)
+	require.EqualError(t, err, `Package validation errors:
+- Unknown field in manifest.yaml:11:
+  availabiltyProbes`)
+}
+
 func TestTemplateSpecFromPackage(t *testing.T) {
 	t.Parallel()
 
@@ -46,10 +102,46 @@ func TestTemplateSpecFromPackage(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, pkg)
 
-	spec := packagecontent.TemplateSpecFromPackage(pkg)
+	spec, err := packagecontent.TemplateSpecFromPackage(pkg)
+	require.NoError(t, err)
 	require.NotNil(t, spec)
 }
 
+func TestTemplateSpecFromPackage_DeletionPolicy(t *testing.T) {
+	t.Parallel()
+
+	obj := unstructured.Unstructured{}
+	obj.SetAnnotations(map[string]string{
+		manifestsv1alpha1.PackagePhaseAnnotation:          "deploy",
+		manifestsv1alpha1.PackageDeletionPolicyAnnotation: "Orphan",
+		manifestsv1alpha1.PackageCreateOnlyAnnotation:     "True",
+		manifestsv1alpha1.PackageHookAnnotation:           "Pre",
+	})
+	pkg := &packagecontent.Package{
+		PackageManifest: &manifestsv1alpha1.PackageManifest{
+			Spec: manifestsv1alpha1.PackageManifestSpec{
+				Phases: []manifestsv1alpha1.PackageManifestPhase{{Name: "deploy"}},
+			},
+		},
+		Objects: map[string][]unstructured.Unstructured{
+			"obj.yaml": {obj},
+		},
+	}
+
+	spec, err := packagecontent.TemplateSpecFromPackage(pkg)
+	require.NoError(t, err)
+	require.Len(t, spec.Phases, 1)
+	require.Len(t, spec.Phases[0].Objects, 1)
+
+	phaseObj := spec.Phases[0].Objects[0]
+	assert.Equal(t, corev1alpha1.ObjectSetObjectDeletionPolicyOrphan, phaseObj.DeletionPolicy)
+	assert.True(t, phaseObj.CreateOnly)
+	assert.Equal(t, corev1alpha1.ObjectSetObjectHookPre, phaseObj.Hook)
+	assert.Equal(t, map[string]string{
+		corev1alpha1.ObjectSourceFileAnnotation: "obj.yaml#0",
+	}, phaseObj.Object.GetAnnotations())
+}
+
 func TestPackageManifestLoader_Errors(t *testing.T) {
 	t.Parallel()
 
@@ -101,3 +193,26 @@ func TestPackageManifestLoader_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateSpecFromPackage_invalidFieldMap(t *testing.T) {
+	t.Parallel()
+
+	obj := unstructured.Unstructured{}
+	obj.SetAnnotations(map[string]string{
+		manifestsv1alpha1.PackagePhaseAnnotation:    "deploy",
+		manifestsv1alpha1.PackageFieldMapAnnotation: "metadata.name",
+	})
+	pkg := &packagecontent.Package{
+		PackageManifest: &manifestsv1alpha1.PackageManifest{
+			Spec: manifestsv1alpha1.PackageManifestSpec{
+				Phases: []manifestsv1alpha1.PackageManifestPhase{{Name: "deploy"}},
+			},
+		},
+		Objects: map[string][]unstructured.Unstructured{
+			"obj.yaml": {obj},
+		},
+	}
+
+	_, err := packagecontent.TemplateSpecFromPackage(pkg)
+	require.EqualError(t, err, "obj.yaml#0: parsing field map: expected 2 part mapping got 1 in line 1")
+}
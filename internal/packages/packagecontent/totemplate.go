@@ -1,6 +1,7 @@
 package packagecontent
 
 import (
+	"fmt"
 	"sort"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
@@ -9,17 +10,19 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-func TemplateSpecFromPackage(pkg *Package) (templateSpec corev1alpha1.ObjectSetTemplateSpec) {
+func TemplateSpecFromPackage(pkg *Package) (templateSpec corev1alpha1.ObjectSetTemplateSpec, err error) {
 	collector := newPhaseCollector(pkg.PackageManifest.Spec.Phases...)
 
-	for _, objects := range pkg.Objects {
-		collector.AddObjects(objects...)
+	for path, objects := range withContentHashSuffixes(pkg.Objects) {
+		if err := collector.AddObjects(path, objects...); err != nil {
+			return templateSpec, err
+		}
 	}
 
 	templateSpec.AvailabilityProbes = pkg.PackageManifest.Spec.AvailabilityProbes
 	templateSpec.Phases = append(templateSpec.Phases, collector.Collect()...)
 
-	return
+	return templateSpec, nil
 }
 
 func newPhaseCollector(phases ...manifestsv1alpha1.PackageManifestPhase) phaseCollector {
@@ -29,8 +32,10 @@ func newPhaseCollector(phases ...manifestsv1alpha1.PackageManifestPhase) phaseCo
 		collector[phase.Name] = phaseCollectorEntry{
 			Index: idx,
 			Phase: corev1alpha1.ObjectSetTemplatePhase{
-				Name:  phase.Name,
-				Class: phase.Class,
+				Name:        phase.Name,
+				Class:       phase.Class,
+				Atomic:      phase.Atomic,
+				ApplyPolicy: phase.ApplyPolicy,
 			},
 		}
 	}
@@ -45,14 +50,27 @@ type phaseCollectorEntry struct {
 	Phase corev1alpha1.ObjectSetTemplatePhase
 }
 
-func (c phaseCollector) AddObjects(objs ...unstructured.Unstructured) {
+func (c phaseCollector) AddObjects(path string, objs ...unstructured.Unstructured) error {
 	for i, object := range objs {
 		annotations := object.GetAnnotations()
 		phaseAnnotation := annotations[manifestsv1alpha1.PackagePhaseAnnotation]
 		isExternalObject := annotations[manifestsv1alpha1.PackageExternalObjectAnnotation] == "True"
+		deletionPolicy := annotations[manifestsv1alpha1.PackageDeletionPolicyAnnotation]
+		isCreateOnly := annotations[manifestsv1alpha1.PackageCreateOnlyAnnotation] == "True"
+		hook := annotations[manifestsv1alpha1.PackageHookAnnotation]
 		delete(annotations, manifestsv1alpha1.PackagePhaseAnnotation)
 		delete(annotations, manifestsv1alpha1.PackageConditionMapAnnotation)
+		delete(annotations, manifestsv1alpha1.PackageFieldMapAnnotation)
 		delete(annotations, manifestsv1alpha1.PackageExternalObjectAnnotation)
+		delete(annotations, manifestsv1alpha1.PackageDeletionPolicyAnnotation)
+		delete(annotations, manifestsv1alpha1.PackageCreateOnlyAnnotation)
+		delete(annotations, manifestsv1alpha1.PackageHookAnnotation)
+		if len(path) > 0 {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[corev1alpha1.ObjectSourceFileAnnotation] = fmt.Sprintf("%s#%d", path, i)
+		}
 		if len(annotations) == 0 {
 			// This is important!
 			// When submitted to the API server empty maps will be dropped.
@@ -61,10 +79,13 @@ func (c phaseCollector) AddObjects(objs ...unstructured.Unstructured) {
 			annotations = nil
 		}
 
-		// Any error should have been detected by the validation stage.
 		conditionMapping, err := ParseConditionMapAnnotation(&objs[i])
 		if err != nil {
-			panic(err)
+			return fmt.Errorf("%s#%d: parsing condition map: %w", path, i, err)
+		}
+		fieldMapping, err := ParseFieldMapAnnotation(&objs[i])
+		if err != nil {
+			return fmt.Errorf("%s#%d: parsing field map: %w", path, i, err)
 		}
 
 		object.SetAnnotations(annotations)
@@ -72,6 +93,10 @@ func (c phaseCollector) AddObjects(objs ...unstructured.Unstructured) {
 		objSetObj := corev1alpha1.ObjectSetObject{
 			Object:            object,
 			ConditionMappings: conditionMapping,
+			FieldMappings:     fieldMapping,
+			DeletionPolicy:    corev1alpha1.ObjectSetObjectDeletionPolicy(deletionPolicy),
+			CreateOnly:        isCreateOnly,
+			Hook:              corev1alpha1.ObjectSetObjectHook(hook),
 		}
 
 		if isExternalObject {
@@ -80,6 +105,7 @@ func (c phaseCollector) AddObjects(objs ...unstructured.Unstructured) {
 			c.addObjects(phaseAnnotation, objSetObj)
 		}
 	}
+	return nil
 }
 
 func (c phaseCollector) addObjects(phaseName string, objs ...corev1alpha1.ObjectSetObject) {
@@ -1,6 +1,8 @@
 package packagedeploy
 
 import (
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	pkoapis "package-operator.run/apis"
@@ -12,4 +14,10 @@ func init() {
 	if err := pkoapis.AddToScheme(testScheme); err != nil {
 		panic(err)
 	}
+	if err := apiextensionsv1.AddToScheme(testScheme); err != nil {
+		panic(err)
+	}
+	if err := apiextensions.AddToScheme(testScheme); err != nil {
+		panic(err)
+	}
 }
@@ -18,9 +18,12 @@ import (
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/adapters"
 	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/packages"
 	"package-operator.run/package-operator/internal/packages/packageadmission"
 	"package-operator.run/package-operator/internal/packages/packagecontent"
 	"package-operator.run/package-operator/internal/packages/packageloader"
+	"package-operator.run/package-operator/internal/preflight"
+	"package-operator.run/package-operator/internal/transform"
 )
 
 // PackageDeployer loads package contents from file, wraps it into an ObjectDeployment and deploys it.
@@ -32,6 +35,12 @@ type PackageDeployer struct {
 
 	deploymentReconciler deploymentReconciler
 	packageContentLoader packageContentLoader
+	// Runs template test cases of packages shipping test snapshots.
+	packageTestValidator packageloader.PackageAndFilesValidator
+	// Checks constraints of the PackageManifest against the cluster.
+	constraintChecker constraintChecker
+	// Reads objects of the cluster for the lookup template function.
+	objectLookup objectLookup
 }
 
 type (
@@ -42,10 +51,30 @@ type (
 	deploymentReconciler interface {
 		Reconcile(ctx context.Context, desiredDeploy adapters.ObjectDeploymentAccessor, chunker objectChunker) error
 	}
+
+	constraintChecker interface {
+		Check(
+			ctx context.Context, constraints manifestsv1alpha1.PackageManifestConstraints,
+			env manifestsv1alpha1.PackageEnvironment,
+		) ([]preflight.Violation, error)
+	}
+
+	objectLookup interface {
+		LookupFunc(ctx context.Context, owner client.Object) transform.LookupFunc
+	}
+
+	// Discovers APIs served by the cluster.
+	discoveryClient interface {
+		ServerGroups() (*metav1.APIGroupList, error)
+		ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error)
+	}
 )
 
 // Returns a new namespace-scoped loader for the Package API.
-func NewPackageDeployer(c client.Client, scheme *runtime.Scheme) *PackageDeployer {
+func NewPackageDeployer(
+	c client.Client, scheme *runtime.Scheme,
+	discovery discoveryClient, objectLookup objectLookup,
+) *PackageDeployer {
 	return &PackageDeployer{
 		client: c,
 		scheme: scheme,
@@ -57,6 +86,9 @@ func NewPackageDeployer(c client.Client, scheme *runtime.Scheme) *PackageDeploye
 			packageloader.WithDefaults,
 			packageloader.WithValidators(packageloader.PackageScopeValidator(manifestsv1alpha1.PackageManifestScopeNamespaced)),
 		),
+		packageTestValidator: packageloader.NewTemplateSnapshotValidator(scheme),
+		constraintChecker:    preflight.NewPackageConstraints(discovery),
+		objectLookup:         objectLookup,
 
 		deploymentReconciler: newDeploymentReconciler(
 			scheme, c,
@@ -67,7 +99,10 @@ func NewPackageDeployer(c client.Client, scheme *runtime.Scheme) *PackageDeploye
 }
 
 // Returns a new cluster-scoped loader for the ClusterPackage API.
-func NewClusterPackageDeployer(c client.Client, scheme *runtime.Scheme) *PackageDeployer {
+func NewClusterPackageDeployer(
+	c client.Client, scheme *runtime.Scheme,
+	discovery discoveryClient, objectLookup objectLookup,
+) *PackageDeployer {
 	return &PackageDeployer{
 		client: c,
 		scheme: scheme,
@@ -80,6 +115,9 @@ func NewClusterPackageDeployer(c client.Client, scheme *runtime.Scheme) *Package
 				&packageloader.ObjectPhaseAnnotationValidator{},
 			),
 		),
+		packageTestValidator: packageloader.NewTemplateSnapshotValidator(scheme),
+		constraintChecker:    preflight.NewPackageConstraints(discovery),
+		objectLookup:         objectLookup,
 
 		deploymentReconciler: newDeploymentReconciler(scheme, c, adapters.NewClusterObjectDeployment, adapters.NewClusterObjectSlice,
 			adapters.NewClusterObjectSliceList, newGenericClusterObjectSetList,
@@ -105,10 +143,49 @@ func (l *PackageDeployer) Load(
 	ctx context.Context, pkg adapters.GenericPackageAccessor,
 	files packagecontent.Files, env manifestsv1alpha1.PackageEnvironment,
 ) error {
-	packageContent, err := l.packageContentLoader.FromFiles(ctx, files)
+	desiredDeploy, err := l.render(ctx, pkg, files, env)
+	if err != nil || desiredDeploy == nil {
+		return err
+	}
+
+	chunker := determineChunkingStrategyForPackage(pkg)
+	if err := l.deploymentReconciler.Reconcile(ctx, desiredDeploy, chunker); err != nil {
+		return fmt.Errorf("reconciling ObjectDeployment: %w", err)
+	}
+
+	// Load success
+	meta.RemoveStatusCondition(pkg.GetConditions(), corev1alpha1.PackageInvalid)
+	return nil
+}
+
+// Templates the package contents into the desired ObjectDeployment.
+// Returns nil, if the package is invalid or unsupported,
+// after reporting the reason in the conditions of the package.
+func (l *PackageDeployer) render(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+	files packagecontent.Files, env manifestsv1alpha1.PackageEnvironment,
+) (adapters.ObjectDeploymentAccessor, error) {
+	// Components are loaded as packages on their own.
+	files, err := packagecontent.ComponentFiles(ctx, l.scheme, files, pkg.GetComponent())
 	if err != nil {
 		setInvalidConditionBasedOnLoadError(pkg, err)
-		return nil
+		return nil, nil
+	}
+
+	var loadOpts []packageloader.Option
+	if l.packageTestValidator != nil && hasPackageTests(files) {
+		loadOpts = append(loadOpts, packageloader.WithPackageAndFilesValidators(l.packageTestValidator))
+	}
+	packageContent, err := l.packageContentLoader.FromFiles(ctx, files, loadOpts...)
+	if err != nil {
+		setInvalidConditionBasedOnLoadError(pkg, err)
+		return nil, nil
+	}
+
+	if unsupported, err := l.checkConstraints(ctx, pkg, packageContent.PackageManifest, env); err != nil {
+		return nil, err
+	} else if unsupported {
+		return nil, nil
 	}
 
 	tmplCtx := pkg.TemplateContext()
@@ -117,17 +194,21 @@ func (l *PackageDeployer) Load(
 
 	if tmplCtx.Config != nil {
 		if err := json.Unmarshal(tmplCtx.Config.Raw, &configuration); err != nil {
-			return fmt.Errorf("unmarshal config: %w", err)
+			setInvalidConfigurationCondition(pkg, field.ErrorList{
+				field.Invalid(field.NewPath("spec", "config"), string(tmplCtx.Config.Raw), err.Error()),
+			})
+			return nil, nil
 		}
 	}
+	// Prunes unknown fields and applies defaults declared in the config schema of the PackageManifest.
 	validationErrors, err := packageadmission.AdmitPackageConfiguration(
 		ctx, l.scheme, configuration, packageContent.PackageManifest, field.NewPath("spec", "config"))
 	if err != nil {
-		return fmt.Errorf("validate Package configuration: %w", err)
+		return nil, fmt.Errorf("validate Package configuration: %w", err)
 	}
 	if len(validationErrors) > 0 {
-		setInvalidConditionBasedOnLoadError(pkg, validationErrors.ToAggregate())
-		return nil
+		setInvalidConfigurationCondition(pkg, validationErrors)
+		return nil, nil
 	}
 
 	images := map[string]string{}
@@ -135,43 +216,76 @@ func (l *PackageDeployer) Load(
 		for _, packageImage := range packageContent.PackageManifestLock.Spec.Images {
 			resolvedImage, err := ImageWithDigest(packageImage.Image, packageImage.Digest)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			images[packageImage.Name] = resolvedImage
 		}
 	}
 
+	var ttOpts []packageloader.TemplateTransformerOption
+	if l.objectLookup != nil {
+		ttOpts = append(ttOpts, packageloader.WithLookup(
+			l.objectLookup.LookupFunc(ctx, pkg.ClientObject())))
+	}
 	tt, err := packageloader.NewTemplateTransformer(packageloader.PackageFileTemplateContext{
 		Package:     tmplCtx.Package,
 		Config:      configuration,
 		Images:      images,
 		Environment: tmplCtx.Environment,
-	})
+	}, ttOpts...)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	transformers := []packageloader.Transformer{
+		&packageloader.PackageTransformer{Package: pkg.ClientObject()},
+		&packageloader.InstanceLabelTransformer{
+			PackageName: pkg.ClientObject().GetName(),
+			Templates:   tt,
+		},
+	}
+	if podTemplateMetadata := pkg.GetPodTemplateMetadata(); podTemplateMetadata != nil {
+		transformers = append(transformers, &packageloader.PodTemplateMetadataTransformer{
+			PackageName: pkg.ClientObject().GetName(),
+			Metadata:    *podTemplateMetadata,
+		})
+	}
+	if env.Proxy != nil {
+		transformers = append(transformers, &packageloader.ProxyTransformer{
+			PackageName: pkg.ClientObject().GetName(),
+			Proxy:       env.Proxy,
+		})
+	}
+	if patches := pkg.GetPatches(); len(patches) > 0 {
+		// Last, so patches override changes of all other transformers.
+		transformers = append(transformers, &packageloader.PatchTransformer{
+			Scheme:  l.scheme,
+			Patches: patches,
+		})
 	}
 	packageContent, err = l.packageContentLoader.FromFiles(
 		ctx, files,
 		packageloader.WithFilesTransformers(tt),
-		packageloader.WithTransformers(&packageloader.PackageTransformer{Package: pkg.ClientObject()}))
+		packageloader.WithTransformers(transformers...))
 	if err != nil {
 		setInvalidConditionBasedOnLoadError(pkg, err)
-		return nil
+		return nil, nil
 	}
 
 	desiredDeploy, err := l.desiredObjectDeployment(ctx, pkg, packageContent)
 	if err != nil {
-		return fmt.Errorf("creating desired ObjectDeployment: %w", err)
+		return nil, fmt.Errorf("creating desired ObjectDeployment: %w", err)
 	}
+	return desiredDeploy, nil
+}
 
-	chunker := determineChunkingStrategyForPackage(pkg)
-	if err := l.deploymentReconciler.Reconcile(ctx, desiredDeploy, chunker); err != nil {
-		return fmt.Errorf("reconciling ObjectDeployment: %w", err)
+// hasPackageTests returns true if the package ships test snapshots.
+func hasPackageTests(files packagecontent.Files) bool {
+	for path := range files {
+		if packages.IsPackageTestFile(path) {
+			return true
+		}
 	}
-
-	// Load success
-	meta.RemoveStatusCondition(pkg.GetConditions(), corev1alpha1.PackageInvalid)
-	return nil
+	return false
 }
 
 func (l *PackageDeployer) desiredObjectDeployment(
@@ -192,15 +306,23 @@ func (l *PackageDeployer) desiredObjectDeployment(
 		controllers.ChangeCauseAnnotation: fmt.Sprintf(
 			"Installing %s package.", packageContent.PackageManifest.Name),
 	}
+	if application, ok := pkg.ClientObject().GetAnnotations()[corev1alpha1.ArgoCDApplicationAnnotation]; ok {
+		annotations[corev1alpha1.ArgoCDApplicationAnnotation] = application
+	}
 
 	deploy = l.newObjectDeployment(l.scheme)
-	deploy.ClientObject().SetLabels(labels)
+	deploy.ClientObject().SetLabels(withOperatorIdentity(pkg.ClientObject(), labels))
 	deploy.ClientObject().SetAnnotations(annotations)
 
 	deploy.ClientObject().SetName(pkg.ClientObject().GetName())
 	deploy.ClientObject().SetNamespace(pkg.ClientObject().GetNamespace())
 
-	deploy.SetTemplateSpec(packagecontent.TemplateSpecFromPackage(packageContent))
+	templateSpec, err := packagecontent.TemplateSpecFromPackage(packageContent)
+	if err != nil {
+		return nil, fmt.Errorf("building template spec: %w", err)
+	}
+	templateSpec.AllowCriticalKinds = pkg.GetAllowCriticalKinds()
+	deploy.SetTemplateSpec(templateSpec)
 	deploy.SetSelector(labels)
 
 	if err := controllerutil.SetControllerReference(
@@ -211,14 +333,68 @@ func (l *PackageDeployer) desiredObjectDeployment(
 	return deploy, nil
 }
 
-func setInvalidConditionBasedOnLoadError(pkg adapters.GenericPackageAccessor, err error) {
-	reason := "LoadError"
+// Returns a copy of labels with the operator identity label of the owner,
+// so objects are reconciled by the same Package Operator installation as their owner.
+func withOperatorIdentity(owner client.Object, labels map[string]string) map[string]string {
+	identity, ok := owner.GetLabels()[controllers.OperatorIdentityLabel]
+	if !ok {
+		return labels
+	}
+	withIdentity := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		withIdentity[k] = v
+	}
+	withIdentity[controllers.OperatorIdentityLabel] = identity
+	return withIdentity
+}
+
+// Sets the Unsupported condition, if the cluster does not satisfy the constraints of the package.
+func (l *PackageDeployer) checkConstraints(
+	ctx context.Context, pkg adapters.GenericPackageAccessor,
+	manifest *manifestsv1alpha1.PackageManifest, env manifestsv1alpha1.PackageEnvironment,
+) (unsupported bool, err error) {
+	if l.constraintChecker == nil || manifest.Spec.Constraints == nil {
+		meta.RemoveStatusCondition(pkg.GetConditions(), corev1alpha1.PackageUnsupported)
+		return false, nil
+	}
 
+	violations, err := l.constraintChecker.Check(ctx, *manifest.Spec.Constraints, env)
+	if err != nil {
+		return false, fmt.Errorf("checking package constraints: %w", err)
+	}
+	if len(violations) == 0 {
+		meta.RemoveStatusCondition(pkg.GetConditions(), corev1alpha1.PackageUnsupported)
+		return false, nil
+	}
+
+	meta.SetStatusCondition(pkg.GetConditions(), metav1.Condition{
+		Type:               corev1alpha1.PackageUnsupported,
+		Status:             metav1.ConditionTrue,
+		Reason:             corev1alpha1.ReasonConstraintsNotSatisfied,
+		Message:            (&preflight.Error{Violations: violations}).Error(),
+		ObservedGeneration: pkg.ClientObject().GetGeneration(),
+	})
+	return true, nil
+}
+
+// Reports configuration not matching the config schema of the PackageManifest,
+// listing the path of every offending field.
+func setInvalidConfigurationCondition(pkg adapters.GenericPackageAccessor, errs field.ErrorList) {
+	meta.SetStatusCondition(pkg.GetConditions(), metav1.Condition{
+		Type:               corev1alpha1.PackageInvalid,
+		Status:             metav1.ConditionTrue,
+		Reason:             corev1alpha1.ReasonInvalidConfiguration,
+		Message:            errs.ToAggregate().Error(),
+		ObservedGeneration: pkg.ClientObject().GetGeneration(),
+	})
+}
+
+func setInvalidConditionBasedOnLoadError(pkg adapters.GenericPackageAccessor, err error) {
 	// Can not be determined more precisely
 	meta.SetStatusCondition(pkg.GetConditions(), metav1.Condition{
 		Type:               corev1alpha1.PackageInvalid,
 		Status:             metav1.ConditionTrue,
-		Reason:             reason,
+		Reason:             corev1alpha1.ReasonLoadError,
 		Message:            err.Error(),
 		ObservedGeneration: pkg.ClientObject().GetGeneration(),
 	})
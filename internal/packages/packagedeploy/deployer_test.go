@@ -10,17 +10,21 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/adapters"
+	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/packages/packagecontent"
 	"package-operator.run/package-operator/internal/packages/packageloader"
+	"package-operator.run/package-operator/internal/preflight"
 	"package-operator.run/package-operator/internal/testutil"
 )
 
@@ -39,13 +43,17 @@ type (
 	packageContentLoaderMock struct {
 		mock.Mock
 	}
+
+	discoveryMock struct {
+		mock.Mock
+	}
 )
 
 func TestNewPackageDeployer(t *testing.T) {
 	t.Parallel()
 
 	c := testutil.NewClient()
-	l := NewPackageDeployer(c, testScheme)
+	l := NewPackageDeployer(c, testScheme, &discoveryMock{}, nil)
 	assert.NotNil(t, l)
 }
 
@@ -53,7 +61,7 @@ func TestNewClustePackageDeployer(t *testing.T) {
 	t.Parallel()
 
 	c := testutil.NewClient()
-	l := NewClusterPackageDeployer(c, testScheme)
+	l := NewClusterPackageDeployer(c, testScheme, &discoveryMock{}, nil)
 	assert.NotNil(t, l)
 }
 
@@ -134,6 +142,34 @@ func TestPackageDeployer_Load(t *testing.T) {
 	assert.Nil(t, packageInvalid, "Invalid condition should not be reported")
 }
 
+func TestPackageDeployer_desiredObjectDeployment_operatorIdentity(t *testing.T) {
+	t.Parallel()
+
+	l := &PackageDeployer{
+		scheme:              testScheme,
+		newObjectDeployment: adapters.NewObjectDeployment,
+	}
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test", Namespace: "test",
+				Labels: map[string]string{controllers.OperatorIdentityLabel: "tenant-a"},
+			},
+		},
+	}
+	packageContent := &packagecontent.Package{
+		PackageManifest: &manifestsv1alpha1.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-package"},
+		},
+	}
+
+	deploy, err := l.desiredObjectDeployment(context.Background(), pkg, packageContent)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-a", deploy.ClientObject().GetLabels()[controllers.OperatorIdentityLabel])
+	// The identity is not part of the selector, so existing ObjectSets keep matching.
+	assert.NotContains(t, deploy.GetSelector().MatchLabels, controllers.OperatorIdentityLabel)
+}
+
 func TestPackageDeployer_Load_Error(t *testing.T) {
 	t.Parallel()
 
@@ -178,6 +214,115 @@ func TestPackageDeployer_Load_Error(t *testing.T) {
 	}
 }
 
+func TestPackageDeployer_Load_InvalidConfiguration(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	pcl := &packageContentLoaderMock{}
+	deploymentReconcilerMock := &deploymentReconcilerMock{}
+	l := &PackageDeployer{
+		client:              c,
+		scheme:              testScheme,
+		newObjectDeployment: adapters.NewObjectDeployment,
+
+		packageContentLoader: pcl,
+		deploymentReconciler: deploymentReconcilerMock,
+	}
+	ctx := logr.NewContext(context.Background(), testr.New(t))
+
+	res := &packagecontent.Package{
+		PackageManifest: &manifestsv1alpha1.PackageManifest{
+			Spec: manifestsv1alpha1.PackageManifestSpec{
+				Scopes: []manifestsv1alpha1.PackageManifestScope{
+					manifestsv1alpha1.PackageManifestScopeNamespaced,
+				},
+				Config: manifestsv1alpha1.PackageManifestSpecConfig{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"replicas": {Type: "integer"},
+						},
+					},
+				},
+			},
+		},
+	}
+	pcl.On("FromFiles", mock.Anything, mock.Anything, mock.Anything).Return(res, nil)
+
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test", Namespace: "test",
+			},
+			Spec: corev1alpha1.PackageSpec{
+				Config: &runtime.RawExtension{Raw: []byte(`{"replicas":"three"}`)},
+			},
+		},
+	}
+	err := l.Load(ctx, pkg, packagecontent.Files{}, manifestsv1alpha1.PackageEnvironment{})
+	require.NoError(t, err)
+
+	packageInvalid := meta.FindStatusCondition(pkg.Status.Conditions, corev1alpha1.PackageInvalid)
+	if assert.NotNil(t, packageInvalid) {
+		assert.Equal(t, metav1.ConditionTrue, packageInvalid.Status)
+		assert.Equal(t, "InvalidConfiguration", packageInvalid.Reason)
+		assert.Contains(t, packageInvalid.Message, "spec.config.replicas")
+	}
+	deploymentReconcilerMock.AssertNotCalled(t, "Reconcile", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPackageDeployer_Load_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	pcl := &packageContentLoaderMock{}
+	deploymentReconcilerMock := &deploymentReconcilerMock{}
+	l := &PackageDeployer{
+		client:              c,
+		scheme:              testScheme,
+		newObjectDeployment: adapters.NewObjectDeployment,
+
+		packageContentLoader: pcl,
+		deploymentReconciler: deploymentReconcilerMock,
+		constraintChecker:    preflight.NewPackageConstraints(&discoveryMock{}),
+	}
+	ctx := logr.NewContext(context.Background(), testr.New(t))
+
+	res := &packagecontent.Package{
+		PackageManifest: &manifestsv1alpha1.PackageManifest{
+			Spec: manifestsv1alpha1.PackageManifestSpec{
+				Scopes: []manifestsv1alpha1.PackageManifestScope{
+					manifestsv1alpha1.PackageManifestScopeNamespaced,
+				},
+				Constraints: &manifestsv1alpha1.PackageManifestConstraints{
+					KubernetesVersion: ">= 1.28.0",
+				},
+			},
+		},
+	}
+	pcl.On("FromFiles", mock.Anything, mock.Anything, mock.Anything).Return(res, nil)
+
+	pkg := &adapters.GenericPackage{
+		Package: corev1alpha1.Package{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test", Namespace: "test",
+			},
+		},
+	}
+	err := l.Load(ctx, pkg, packagecontent.Files{}, manifestsv1alpha1.PackageEnvironment{
+		Kubernetes: manifestsv1alpha1.PackageEnvironmentKubernetes{Version: "v1.27.0"},
+	})
+	require.NoError(t, err)
+
+	packageUnsupported := meta.FindStatusCondition(pkg.Status.Conditions, corev1alpha1.PackageUnsupported)
+	if assert.NotNil(t, packageUnsupported) {
+		assert.Equal(t, metav1.ConditionTrue, packageUnsupported.Status)
+		assert.Equal(t, "ConstraintsNotSatisfied", packageUnsupported.Reason)
+		assert.Contains(t, packageUnsupported.Message, `v1.27.0 does not satisfy ">= 1.28.0"`)
+	}
+	deploymentReconcilerMock.AssertNotCalled(t, "Reconcile", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestImageWithDigestOk(t *testing.T) {
 	tests := []struct {
 		image  string
@@ -246,3 +391,15 @@ func (m *packageContentLoaderMock) FromFiles(
 	args := m.Called(ctx, path, opts)
 	return args.Get(0).(*packagecontent.Package), args.Error(1)
 }
+
+func (m *discoveryMock) ServerGroups() (*metav1.APIGroupList, error) {
+	args := m.Called()
+	list, _ := args.Get(0).(*metav1.APIGroupList)
+	return list, args.Error(1)
+}
+
+func (m *discoveryMock) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	args := m.Called(groupVersion)
+	list, _ := args.Get(0).(*metav1.APIResourceList)
+	return list, args.Error(1)
+}
@@ -103,6 +103,10 @@ func (r *DeploymentReconciler) Reconcile(
 			desiredDeploy.ClientObject().GetAnnotations(),
 		)
 		annotations[controllers.ChangeCauseAnnotation] = getChangeCause(actualDeploy, desiredDeploy)
+		// Stop ArgoCD tracking, when the annotation is removed from the package.
+		if _, ok := desiredDeploy.ClientObject().GetAnnotations()[corev1alpha1.ArgoCDApplicationAnnotation]; !ok {
+			delete(annotations, corev1alpha1.ArgoCDApplicationAnnotation)
+		}
 		actualDeploy.ClientObject().SetAnnotations(annotations)
 
 		labels := labels.Merge(
@@ -236,6 +240,11 @@ func (r *DeploymentReconciler) chunkPhase(
 			sliceOwnerLabel: deploy.ClientObject().GetName(),
 		})
 		slice.SetObjects(objectsForSlice)
+		checksum, err := utils.ComputeObjectsChecksum(objectsForSlice)
+		if err != nil {
+			return fmt.Errorf("computing ObjectSlice checksum: %w", err)
+		}
+		slice.SetChecksum(checksum)
 
 		if err := r.reconcileSlice(ctx, deploy, slice); err != nil {
 			return fmt.Errorf("reconcile ObjectSlice: %w", err)
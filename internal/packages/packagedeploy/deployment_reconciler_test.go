@@ -20,6 +20,7 @@ import (
 	"package-operator.run/package-operator/internal/adapters"
 	"package-operator.run/package-operator/internal/controllers"
 	"package-operator.run/package-operator/internal/testutil"
+	"package-operator.run/package-operator/internal/utils"
 )
 
 func Test_DeploymentReconciler_Reconcile(t *testing.T) {
@@ -131,11 +132,14 @@ func Test_DeploymentReconciler_Reconcile(t *testing.T) {
 			Object: unstructured.Unstructured{},
 		},
 	}, createdSlice.Objects)
+	expectedChecksum, err := utils.ComputeObjectsChecksum(createdSlice.Objects)
+	require.NoError(t, err)
+	assert.Equal(t, expectedChecksum, createdSlice.Checksum)
 
 	assert.Equal(t, []corev1alpha1.ObjectSetTemplatePhase{
 		{
 			Name:   "test",
-			Slices: []string{"test-depl-85dfb9bbb4"},
+			Slices: []string{"test-depl-7cfbc46968"},
 		},
 	}, updatedDeployment.Spec.Template.Spec.Phases)
 }
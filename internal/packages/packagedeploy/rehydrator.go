@@ -0,0 +1,130 @@
+package packagedeploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/package-operator/internal/adapters"
+	"package-operator.run/package-operator/internal/controllers"
+	"package-operator.run/package-operator/internal/environment"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+// ErrNotRehydratable is returned for ObjectSets that were not templated from a package image.
+var ErrNotRehydratable = errors.New("ObjectSet was not templated from a package image")
+
+// ObjectSetRehydrator templates the phases of compacted ObjectSets again,
+// from the package image and configuration recorded on the ObjectSet.
+type ObjectSetRehydrator struct {
+	environment.Sink
+
+	client      client.Client
+	scheme      *runtime.Scheme
+	newPackage  adapters.GenericPackageFactory
+	imagePuller imagePuller
+	deployer    *PackageDeployer
+}
+
+type imagePuller interface {
+	Pull(ctx context.Context, image string) (packagecontent.Files, error)
+}
+
+// Returns a new rehydrator for ObjectSets of Packages.
+func NewObjectSetRehydrator(
+	c client.Client, scheme *runtime.Scheme,
+	imagePuller imagePuller, deployer *PackageDeployer,
+) *ObjectSetRehydrator {
+	return &ObjectSetRehydrator{
+		client:      c,
+		scheme:      scheme,
+		newPackage:  adapters.NewGenericPackage,
+		imagePuller: imagePuller,
+		deployer:    deployer,
+	}
+}
+
+// Returns a new rehydrator for ClusterObjectSets of ClusterPackages.
+func NewClusterObjectSetRehydrator(
+	c client.Client, scheme *runtime.Scheme,
+	imagePuller imagePuller, deployer *PackageDeployer,
+) *ObjectSetRehydrator {
+	return &ObjectSetRehydrator{
+		client:      c,
+		scheme:      scheme,
+		newPackage:  adapters.NewGenericClusterPackage,
+		imagePuller: imagePuller,
+		deployer:    deployer,
+	}
+}
+
+// Rehydrate returns the phases of the given ObjectSet, templated from the package image
+// and configuration recorded in its annotations.
+// Everything else, e.g. the component and patches, is taken from the Package as it is now.
+func (r *ObjectSetRehydrator) Rehydrate(
+	ctx context.Context, objectSet client.Object,
+) ([]corev1alpha1.ObjectSetTemplatePhase, error) {
+	if !controllers.IsRehydratable(objectSet) {
+		return nil, ErrNotRehydratable
+	}
+	image := objectSet.GetAnnotations()[manifestsv1alpha1.PackageSourceImageAnnotation]
+
+	pkg := r.newPackage(r.scheme)
+	if err := r.client.Get(ctx, client.ObjectKey{
+		Name:      objectSet.GetLabels()[manifestsv1alpha1.PackageInstanceLabel],
+		Namespace: objectSet.GetNamespace(),
+	}, pkg.ClientObject()); err != nil {
+		return nil, fmt.Errorf("getting Package: %w", err)
+	}
+	pkg.SetImage(image)
+	pkg.SetConfig(recordedConfig(objectSet))
+	// Only report conditions of this templating run.
+	*pkg.GetConditions() = nil
+
+	files, err := r.imagePuller.Pull(ctx, image)
+	if err != nil {
+		return nil, fmt.Errorf("pulling package image: %w", err)
+	}
+
+	var env manifestsv1alpha1.PackageEnvironment
+	if e := r.GetEnvironment(); e != nil {
+		env = *e
+	}
+	deploy, err := r.deployer.render(ctx, pkg, files, env)
+	if err != nil {
+		return nil, err
+	}
+	if deploy == nil {
+		return nil, fmt.Errorf("templating package image: %s", packageLoadFailure(pkg))
+	}
+	return deploy.GetTemplateSpec().Phases, nil
+}
+
+// Returns the package configuration recorded by desiredObjectDeployment.
+func recordedConfig(objectSet client.Object) *runtime.RawExtension {
+	config := objectSet.GetAnnotations()[manifestsv1alpha1.PackageConfigAnnotation]
+	if len(config) == 0 || config == "null" {
+		return nil
+	}
+	return &runtime.RawExtension{Raw: []byte(config)}
+}
+
+// Returns the reason the package could not be templated, as reported in its conditions.
+func packageLoadFailure(pkg adapters.GenericPackageAccessor) string {
+	for _, condType := range []string{
+		corev1alpha1.PackageInvalid, corev1alpha1.PackageUnsupported,
+	} {
+		if cond := meta.FindStatusCondition(*pkg.GetConditions(), condType); cond != nil &&
+			cond.Status == metav1.ConditionTrue {
+			return cond.Message
+		}
+	}
+	return "unknown error"
+}
@@ -0,0 +1,113 @@
+package packagedeploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/package-operator/internal/adapters"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+type imagePullerMock struct {
+	mock.Mock
+}
+
+func (m *imagePullerMock) Pull(ctx context.Context, image string) (packagecontent.Files, error) {
+	args := m.Called(ctx, image)
+	files, _ := args.Get(0).(packagecontent.Files)
+	return files, args.Error(1)
+}
+
+func TestObjectSetRehydrator_Rehydrate(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	pcl := &packageContentLoaderMock{}
+	ip := &imagePullerMock{}
+	r := NewObjectSetRehydrator(c, testScheme, ip, &PackageDeployer{
+		client:               c,
+		scheme:               testScheme,
+		newObjectDeployment:  adapters.NewObjectDeployment,
+		packageContentLoader: pcl,
+	})
+
+	objectSet := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-1",
+			Namespace: "test",
+			Labels:    map[string]string{manifestsv1alpha1.PackageInstanceLabel: "test"},
+			Annotations: map[string]string{
+				manifestsv1alpha1.PackageSourceImageAnnotation: "quay.io/package-operator/test:v1",
+				manifestsv1alpha1.PackageConfigAnnotation:      `{"replicas":2}`,
+			},
+		},
+	}
+
+	var pkg *corev1alpha1.Package
+	c.On("Get", mock.Anything, client.ObjectKey{Name: "test", Namespace: "test"},
+		mock.AnythingOfType("*v1alpha1.Package"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			pkg = args.Get(2).(*corev1alpha1.Package)
+			pkg.Spec.Image = "quay.io/package-operator/test:v2"
+		}).
+		Return(nil)
+	files := packagecontent.Files{"manifest.yaml": []byte("")}
+	ip.On("Pull", mock.Anything, "quay.io/package-operator/test:v1").Return(files, nil)
+
+	obj := unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetName("cm")
+	obj.SetAnnotations(map[string]string{manifestsv1alpha1.PackagePhaseAnnotation: "phase-1"})
+	pcl.On("FromFiles", mock.Anything, files, mock.Anything).Return(&packagecontent.Package{
+		PackageManifest: &manifestsv1alpha1.PackageManifest{
+			Spec: manifestsv1alpha1.PackageManifestSpec{
+				Scopes: []manifestsv1alpha1.PackageManifestScope{
+					manifestsv1alpha1.PackageManifestScopeNamespaced,
+				},
+				Phases: []manifestsv1alpha1.PackageManifestPhase{{Name: "phase-1"}},
+			},
+		},
+		Objects: map[string][]unstructured.Unstructured{"cm.yaml": {obj}},
+	}, nil)
+
+	phases, err := r.Rehydrate(context.Background(), objectSet)
+	require.NoError(t, err)
+	if assert.Len(t, phases, 1) && assert.Len(t, phases[0].Objects, 1) {
+		assert.Equal(t, "cm", phases[0].Objects[0].Object.GetName())
+	}
+	// Templated with the recorded image and config.
+	assert.Equal(t, "quay.io/package-operator/test:v1", pkg.Spec.Image)
+	assert.JSONEq(t, `{"replicas":2}`, string(pkg.Spec.Config.Raw))
+}
+
+func TestObjectSetRehydrator_Rehydrate_notRehydratable(t *testing.T) {
+	t.Parallel()
+
+	r := NewClusterObjectSetRehydrator(testutil.NewClient(), testScheme, &imagePullerMock{}, nil)
+	_, err := r.Rehydrate(context.Background(), &corev1alpha1.ClusterObjectSet{})
+	require.ErrorIs(t, err, ErrNotRehydratable)
+}
+
+func Test_recordedConfig(t *testing.T) {
+	t.Parallel()
+
+	objectSet := &corev1alpha1.ObjectSet{}
+	assert.Nil(t, recordedConfig(objectSet))
+
+	objectSet.Annotations = map[string]string{manifestsv1alpha1.PackageConfigAnnotation: "null"}
+	assert.Nil(t, recordedConfig(objectSet))
+
+	objectSet.Annotations[manifestsv1alpha1.PackageConfigAnnotation] = `{"a":"b"}`
+	if config := recordedConfig(objectSet); assert.NotNil(t, config) {
+		assert.Equal(t, `{"a":"b"}`, string(config.Raw))
+	}
+}
@@ -5,16 +5,9 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/crane"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
-
-	"package-operator.run/package-operator/internal/packages/packagecontent"
 )
 
-func File(dst string, tags []string, files packagecontent.Files) error {
-	image, err := Image(files)
-	if err != nil {
-		return err
-	}
-
+func File(dst string, tags []string, image v1.Image) error {
 	m := map[string]v1.Image{}
 	for _, tag := range tags {
 		m[tag] = image
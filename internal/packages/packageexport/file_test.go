@@ -20,7 +20,9 @@ func TestFile(t *testing.T) {
 	defer func() { assert.Nil(t, os.Remove(f.Name())) }()
 	defer func() { assert.Nil(t, f.Close()) }()
 
-	err = packageexport.File(f.Name(), []string{"chickens:oldest"}, packagecontent.Files{})
+	image, err := packageexport.Image(packagecontent.Files{})
+	assert.Nil(t, err)
+	err = packageexport.File(f.Name(), []string{"chickens:oldest"}, image)
 	assert.Nil(t, err)
 
 	i, err := tarball.ImageFromPath(f.Name(), nil)
@@ -2,8 +2,10 @@ package packageexport
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"path/filepath"
+	"sort"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-containerregistry/pkg/crane"
@@ -15,7 +17,16 @@ import (
 	"package-operator.run/package-operator/internal/packages/packagecontent"
 )
 
-func Image(files packagecontent.Files) (v1.Image, error) {
+// Image builds a package image from the given files.
+// Package manifest files are placed in their own layer in front of all other files,
+// so package metadata can be inspected without extracting all package contents.
+// Files of each component are placed in their own annotated layer behind all other files,
+// so single components can be loaded without extracting the layers of other components.
+func Image(files packagecontent.Files, opts ...ImageOption) (v1.Image, error) {
+	var cfg ImageConfig
+
+	cfg.Option(opts...)
+
 	// Hardcoded to linux/amd64 or kubernetes will refuse to pull the image on our target architecture.
 	// We will drop this after refactoring our in-cluster package loading process to make it architecture agnostic.
 	configFile := &v1.ConfigFile{Architecture: "amd64", OS: "linux", Config: v1.Config{}, RootFS: v1.RootFS{Type: "layers"}}
@@ -23,36 +34,93 @@ func Image(files packagecontent.Files) (v1.Image, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Canonicalized before adding layers, because rewriting layers drops their annotations.
+	image, err = mutate.Canonical(image)
+	if err != nil {
+		return nil, err
+	}
 
-	subFiles := packagecontent.Files{}
+	manifestFiles := packagecontent.Files{}
+	contentFiles := packagecontent.Files{}
+	componentFiles := map[string]packagecontent.Files{}
 	for k, v := range files {
-		subFiles[filepath.Join(packages.ImageFilePrefixPath, k)] = v
+		path := filepath.Join(packages.ImageFilePrefixPath, k)
+		switch component := packages.ComponentOf(k); {
+		case packages.IsManifestFile(k) || packages.IsManifestLockFile(k):
+			manifestFiles[path] = v
+		case len(component) > 0:
+			if componentFiles[component] == nil {
+				componentFiles[component] = packagecontent.Files{}
+			}
+			componentFiles[component][path] = v
+		default:
+			contentFiles[path] = v
+		}
 	}
 
-	layer, err := crane.Layer(subFiles)
-	if err != nil {
-		return nil, err
+	addendums := []mutate.Addendum{}
+	for _, layerFiles := range []packagecontent.Files{manifestFiles, contentFiles} {
+		if len(layerFiles) == 0 {
+			continue
+		}
+
+		layer, err := crane.Layer(layerFiles)
+		if err != nil {
+			return nil, err
+		}
+		addendums = append(addendums, mutate.Addendum{Layer: layer})
+	}
+
+	components := make([]string, 0, len(componentFiles))
+	for component := range componentFiles {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	for _, component := range components {
+		layer, err := crane.Layer(componentFiles[component])
+		if err != nil {
+			return nil, err
+		}
+		addendums = append(addendums, mutate.Addendum{
+			Layer:       layer,
+			Annotations: map[string]string{packages.ComponentLayerAnnotation: component},
+		})
 	}
 
-	image, err = mutate.AppendLayers(image, layer)
+	image, err = mutate.Append(image, addendums...)
 	if err != nil {
 		return nil, fmt.Errorf("create image from layer: %w", err)
 	}
 
-	image, err = mutate.Canonical(image)
-	if err != nil {
-		return nil, err
+	annotations := map[string]string{}
+	for k, v := range cfg.Annotations {
+		annotations[k] = v
+	}
+	if digest, ok := manifestDigest(files); ok {
+		annotations[packages.ManifestDigestAnnotation] = digest
 	}
 
-	return image, nil
+	return mutate.Annotations(image, annotations).(v1.Image), nil
 }
 
-func PushedImage(ctx context.Context, references []string, files packagecontent.Files, opts ...crane.Option) error {
-	image, err := Image(files)
-	if err != nil {
-		return err
+// Returns the sha256 digest of the package manifest, if the files contain one.
+func manifestDigest(files packagecontent.Files) (string, bool) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		if packages.IsManifestFile(path) {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return "", false
 	}
+	// Valid packages contain exactly one manifest, sorting keeps invalid ones deterministic.
+	sort.Strings(paths)
 
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(files[paths[0]])), true
+}
+
+func PushedImage(ctx context.Context, references []string, image v1.Image, opts ...crane.Option) error {
 	opts = append(opts, crane.WithContext(ctx))
 	verboseLogger := logr.FromContextOrDiscard(ctx).V(1)
 	for _, ref := range references {
@@ -65,3 +133,30 @@ func PushedImage(ctx context.Context, references []string, files packagecontent.
 
 	return nil
 }
+
+type ImageConfig struct {
+	// Annotations to add to the image manifest.
+	Annotations map[string]string
+}
+
+func (c *ImageConfig) Option(opts ...ImageOption) {
+	for _, opt := range opts {
+		opt.ConfigureImage(c)
+	}
+}
+
+type ImageOption interface {
+	ConfigureImage(*ImageConfig)
+}
+
+// WithAnnotations adds the given annotations to the image manifest.
+type WithAnnotations map[string]string
+
+func (w WithAnnotations) ConfigureImage(c *ImageConfig) {
+	if c.Annotations == nil {
+		c.Annotations = map[string]string{}
+	}
+	for k, v := range w {
+		c.Annotations[k] = v
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"package-operator.run/package-operator/internal/packages"
 	"package-operator.run/package-operator/internal/packages/packageexport"
 	"package-operator.run/package-operator/internal/testutil"
 )
@@ -16,11 +18,33 @@ func TestImage(t *testing.T) {
 
 	seedingFileMap := map[string][]byte{"manifest.yaml": {5, 6}, "manifest.yml": {7, 8}, "subdir/somethingelse": {9, 10}}
 
-	image, err := packageexport.Image(seedingFileMap)
+	image, err := packageexport.Image(seedingFileMap, packageexport.WithAnnotations{"test": "true"})
+	require.Nil(t, err)
+	layers, err := image.Layers()
+	require.Nil(t, err)
+	require.Len(t, layers, 2)
+
+	manifest, err := image.Manifest()
+	require.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"test": "true",
+		// sha256 of manifest.yaml
+		packages.ManifestDigestAnnotation: "sha256:c42522128b49193de8cd45d8f7589cd7e085e65f138640d57d4482e5f7189623",
+	}, manifest.Annotations)
+}
+
+func TestImage_noManifest(t *testing.T) {
+	t.Parallel()
+
+	image, err := packageexport.Image(map[string][]byte{"subdir/somethingelse": {9, 10}})
 	require.Nil(t, err)
 	layers, err := image.Layers()
 	require.Nil(t, err)
 	require.Len(t, layers, 1)
+
+	manifest, err := image.Manifest()
+	require.Nil(t, err)
+	assert.Empty(t, manifest.Annotations)
 }
 
 func TestPushedImage(t *testing.T) {
@@ -33,7 +57,9 @@ func TestPushedImage(t *testing.T) {
 	ref := "chickens:oldest"
 	seedingFileMap := map[string][]byte{"manifest.yaml": {5, 6}, "manifest.yml": {7, 8}, "subdir/somethingelse": {9, 10}}
 
-	err := packageexport.PushedImage(ctx, []string{ref}, seedingFileMap, reg.CraneOpt)
+	image, err := packageexport.Image(seedingFileMap)
+	require.NoError(t, err)
+	err = packageexport.PushedImage(ctx, []string{ref}, image, reg.CraneOpt)
 	require.NoError(t, err)
 
 	_, err = crane.Pull(ref, reg.CraneOpt)
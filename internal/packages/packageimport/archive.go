@@ -0,0 +1,80 @@
+package packageimport
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+// WriteArchive writes the given files as base64 encoded, gzip compressed tar archive,
+// so they can be transferred via text-only channels like container logs.
+func WriteArchive(w io.Writer, files packagecontent.Files) (err error) {
+	b64 := base64.NewEncoder(base64.StdEncoding, w)
+	gz := gzip.NewWriter(b64)
+	tw := tar.NewWriter(gz)
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		data := files[path]
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     path,
+			Mode:     0o644,
+			Size:     int64(len(data)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return fmt.Errorf("writing header of %s: %w", path, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+	}
+
+	for _, c := range []io.Closer{tw, gz, b64} {
+		if err := c.Close(); err != nil {
+			return fmt.Errorf("closing archive: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReadArchive reads files from an archive written by WriteArchive.
+func ReadArchive(r io.Reader) (packagecontent.Files, error) {
+	gz, err := gzip.NewReader(base64.NewDecoder(base64.StdEncoding, r))
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	defer gz.Close()
+
+	files := packagecontent.Files{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || isFilePathToBeExcluded(hdr.Name) {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", hdr.Name, err)
+		}
+		files[hdr.Name] = data
+	}
+	return files, nil
+}
@@ -0,0 +1,29 @@
+package packageimport
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+func TestArchive(t *testing.T) {
+	files := packagecontent.Files{
+		"manifest.yaml":        []byte("manifest"),
+		"deploy/obj.yaml":      []byte("obj"),
+		"deploy/.hidden.yaml":  []byte("hidden"),
+		"deploy/empty.yaml.gz": {},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteArchive(&buf, files))
+
+	read, err := ReadArchive(&buf)
+	require.NoError(t, err)
+
+	delete(files, "deploy/.hidden.yaml")
+	assert.Equal(t, files, read)
+}
@@ -0,0 +1,72 @@
+package packageimport
+
+import (
+	"container/list"
+	"sync"
+
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+// digestCache is a content-addressed least recently used cache of unpacked package contents.
+// Entries are keyed by image digest, so contents can be shared between all references to the same image.
+type digestCache struct {
+	size int
+
+	lock    sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type digestCacheEntry struct {
+	digest string
+	files  packagecontent.Files
+}
+
+func newDigestCache(size int) *digestCache {
+	return &digestCache{
+		size:    size,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+// Get returns the files cached for the given digest.
+// The returned files must not be modified.
+func (c *digestCache) Get(digest string) (packagecontent.Files, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.entries[digest]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*digestCacheEntry).files, true
+}
+
+// Add stores files for the given digest, evicting the least recently used entry when full.
+func (c *digestCache) Add(digest string, files packagecontent.Files) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if elem, ok := c.entries[digest]; ok {
+		elem.Value.(*digestCacheEntry).files = files
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[digest] = c.lru.PushFront(&digestCacheEntry{digest: digest, files: files})
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*digestCacheEntry).digest)
+	}
+}
+
+// Len returns the number of cached entries.
+func (c *digestCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.lru.Len()
+}
@@ -0,0 +1,32 @@
+package packageimport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+func TestDigestCache(t *testing.T) {
+	t.Parallel()
+
+	c := newDigestCache(2)
+	c.Add("a", packagecontent.Files{"a": nil})
+	c.Add("b", packagecontent.Files{"b": nil})
+
+	// access "a" so "b" becomes the least recently used entry.
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	c.Add("c", packagecontent.Files{"c": nil})
+	assert.Equal(t, 2, c.Len())
+
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	files, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, packagecontent.Files{"a": nil}, files)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+}
@@ -0,0 +1,105 @@
+package packageimport
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"package-operator.run/package-operator/internal/packages"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+var ErrComponentNotFound = errors.New("component not found in package")
+
+// ignorePatterns holds the gitignore-style patterns of a .pkoignore file.
+// Supported are "*", "?" and "[...]" wildcards, "!" to negate a pattern,
+// a trailing "/" to only match folders and a leading "/" to anchor a pattern at the package root.
+// Patterns without "/" match files and folders at any depth.
+type ignorePatterns []ignorePattern
+
+type ignorePattern struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+func parseIgnorePatterns(data []byte) (ignorePatterns, error) {
+	var patterns ignorePatterns
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var p ignorePattern
+		if strings.HasPrefix(line, "!") {
+			p.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			p.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			p.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if _, err := path.Match(line, ""); err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern %q: %w", packages.PackageIgnoreFile, line, err)
+		}
+		p.pattern = line
+		patterns = append(patterns, p)
+	}
+	return patterns, scanner.Err()
+}
+
+// Ignored returns true, if the file at the given slash separated path or any of its parent folders is ignored.
+// The last matching pattern wins.
+func (ps ignorePatterns) Ignored(filePath string) bool {
+	var ignored bool
+	for _, p := range ps {
+		if p.matches(filePath) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
+
+func (p ignorePattern) matches(filePath string) bool {
+	segments := strings.Split(filePath, "/")
+	for i := range segments {
+		isDir := i < len(segments)-1
+		if p.dirOnly && !isDir {
+			continue
+		}
+
+		candidate := segments[i]
+		if p.anchored {
+			candidate = strings.Join(segments[:i+1], "/")
+		}
+		if ok, _ := path.Match(p.pattern, candidate); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectComponent returns the files of the given component of a package,
+// relative to the component folder, so the component can be loaded as a package on its own.
+// Returns the files unchanged, if component is empty.
+func SelectComponent(files packagecontent.Files, component string) (packagecontent.Files, error) {
+	if len(component) == 0 {
+		return files, nil
+	}
+
+	selected := files.Component(component)
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrComponentNotFound, component)
+	}
+	return selected, nil
+}
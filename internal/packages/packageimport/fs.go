@@ -2,6 +2,7 @@ package packageimport
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -10,12 +11,19 @@ import (
 
 	"github.com/go-logr/logr"
 
+	"package-operator.run/package-operator/internal/packages"
 	"package-operator.run/package-operator/internal/packages/packagecontent"
 )
 
 func FS(ctx context.Context, src fs.FS) (packagecontent.Files, error) {
 	verboseLog := logr.FromContextOrDiscard(ctx).V(1)
 	bundle := packagecontent.Files{}
+
+	ignore, err := readIgnorePatterns(src)
+	if err != nil {
+		return nil, err
+	}
+
 	walker := func(path string, entry fs.DirEntry, ioErr error) error {
 		switch {
 		case ioErr != nil:
@@ -24,7 +32,7 @@ func FS(ctx context.Context, src fs.FS) (packagecontent.Files, error) {
 		case entry.Name() == ".":
 			// continue at root
 
-		case isFileToBeExcluded(entry):
+		case isFileToBeExcluded(entry) || ignore.Ignored(path):
 			verboseLog.Info("skipping file in source", "path", path)
 			if entry.IsDir() {
 				return filepath.SkipDir
@@ -52,6 +60,18 @@ func FS(ctx context.Context, src fs.FS) (packagecontent.Files, error) {
 	return bundle, nil
 }
 
+// Reads the .pkoignore file at the root of the source, if present.
+func readIgnorePatterns(src fs.FS) (ignorePatterns, error) {
+	data, err := fs.ReadFile(src, packages.PackageIgnoreFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", packages.PackageIgnoreFile, err)
+	}
+	return parseIgnorePatterns(data)
+}
+
 func Folder(ctx context.Context, path string) (packagecontent.Files, error) {
 	return FS(ctx, os.DirFS(path))
 }
@@ -33,3 +33,28 @@ func TestFS(t *testing.T) {
 	require.Nil(t, err)
 	assert.Equal(t, validEntries, contents)
 }
+
+func TestFS_ignoreFile(t *testing.T) {
+	t.Parallel()
+	ctx := logr.NewContext(context.Background(), testr.New(t))
+
+	memFS := fstest.MapFS{
+		".pkoignore": &fstest.MapFile{Data: []byte(
+			"# docs and scratch files\ndocs/\n*.md\n!README.md\n/scratch.yaml\n")},
+		"manifest.yaml":      &fstest.MapFile{Data: []byte{1}},
+		"README.md":          &fstest.MapFile{Data: []byte{2}},
+		"docs/deploy.yaml":   &fstest.MapFile{Data: []byte{3}},
+		"sub/notes.md":       &fstest.MapFile{Data: []byte{4}},
+		"scratch.yaml":       &fstest.MapFile{Data: []byte{5}},
+		"sub/scratch.yaml":   &fstest.MapFile{Data: []byte{6}},
+		"sub/docs/more.yaml": &fstest.MapFile{Data: []byte{7}},
+	}
+
+	contents, err := packageimport.FS(ctx, memFS)
+	require.NoError(t, err)
+	assert.Equal(t, packagecontent.Files{
+		"manifest.yaml":    {1},
+		"README.md":        {2},
+		"sub/scratch.yaml": {6},
+	}, contents)
+}
@@ -12,16 +12,30 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/google/go-containerregistry/pkg/crane"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 
 	"package-operator.run/package-operator/internal/packages"
 	"package-operator.run/package-operator/internal/packages/packagecontent"
 )
 
-func Image(ctx context.Context, image v1.Image) (m packagecontent.Files, err error) {
+// Image extracts the files of a package image.
+// Files listed in the .pkoignore file of the package are skipped,
+// when selecting a component, layers of other components are not extracted at all.
+func Image(ctx context.Context, image v1.Image, opts ...PullOption) (m packagecontent.Files, err error) {
+	var cfg PullConfig
+
+	cfg.Option(opts...)
+
+	image, err = componentLayers(image, cfg.Component)
+	if err != nil {
+		return nil, err
+	}
+
 	files := packagecontent.Files{}
 	reader := mutate.Extract(image)
 	verboseLog := logr.FromContextOrDiscard(ctx).V(1)
+	var ignore ignorePatterns
 
 	defer func() {
 		if cErr := reader.Close(); err == nil && cErr != nil {
@@ -42,7 +56,25 @@ func Image(ctx context.Context, image v1.Image) (m packagecontent.Files, err err
 			return nil, fmt.Errorf("package image contains files not under the dir %s: %w", packages.ImageFilePrefixPath, err)
 		}
 
-		if isFilePathToBeExcluded(path) {
+		if path == packages.PackageIgnoreFile {
+			data, err := io.ReadAll(tarReader)
+			if err != nil {
+				return nil, fmt.Errorf("read file header from layer: %w", err)
+			}
+			if ignore, err = parseIgnorePatterns(data); err != nil {
+				return nil, err
+			}
+			// Drop ignored files extracted before the .pkoignore file.
+			for p := range files {
+				if ignore.Ignored(p) {
+					delete(files, p)
+				}
+			}
+			continue
+		}
+
+		if isFilePathToBeExcluded(path) || ignore.Ignored(path) ||
+			len(cfg.Component) > 0 && packages.ComponentOf(path) != cfg.Component {
 			verboseLog.Info("skipping file in source", "path", path)
 			continue
 		}
@@ -55,7 +87,39 @@ func Image(ctx context.Context, image v1.Image) (m packagecontent.Files, err err
 		files[path] = data
 	}
 
-	return files, nil
+	return SelectComponent(files, cfg.Component)
+}
+
+// Drops layers annotated to only contain files of other components than the given one.
+// Returns the image unchanged, if component is empty.
+func componentLayers(image v1.Image, component string) (v1.Image, error) {
+	if len(component) == 0 {
+		return image, nil
+	}
+
+	manifest, err := image.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("read image manifest: %w", err)
+	}
+	layers, err := image.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("read image layers: %w", err)
+	}
+	if len(layers) != len(manifest.Layers) {
+		return image, nil
+	}
+
+	selected := make([]v1.Layer, 0, len(layers))
+	for i, desc := range manifest.Layers {
+		if c, ok := desc.Annotations[packages.ComponentLayerAnnotation]; ok && c != component {
+			continue
+		}
+		selected = append(selected, layers[i])
+	}
+	if len(selected) == len(layers) {
+		return image, nil
+	}
+	return mutate.AppendLayers(empty.Image, selected...)
 }
 
 func PulledImage(ctx context.Context, ref string) (packagecontent.Files, error) {
@@ -89,17 +153,31 @@ func (p *Puller) Pull(ctx context.Context, ref string, opts ...PullOption) (pack
 	if cfg.Insecure {
 		craneOpts = append(craneOpts, crane.Insecure)
 	}
+	if cfg.Platform != nil {
+		craneOpts = append(craneOpts, crane.WithPlatform(cfg.Platform))
+	}
 
 	img, err := crane.Pull(ref, craneOpts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return Image(ctx, img)
+	return Image(ctx, img, opts...)
 }
 
 type PullConfig struct {
 	Insecure bool
+	Platform *Platform
+	// Component of the package to load, the whole package is loaded if empty.
+	Component string
+}
+
+// Platform identifies an image within a multi-architecture image index.
+type Platform = v1.Platform
+
+// ParsePlatform parses a platform string in the form of os/arch[/variant].
+func ParsePlatform(platform string) (*Platform, error) {
+	return v1.ParsePlatform(platform)
 }
 
 func (c *PullConfig) Option(opts ...PullOption) {
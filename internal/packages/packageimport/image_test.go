@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"package-operator.run/package-operator/internal/packages"
 	"package-operator.run/package-operator/internal/packages/packagecontent"
 	"package-operator.run/package-operator/internal/packages/packageexport"
 	"package-operator.run/package-operator/internal/packages/packageimport"
@@ -39,3 +40,41 @@ func TestImageLoadSave(t *testing.T) {
 		"subdir/somethingelse": {9, 10},
 	}, reapedFiles)
 }
+
+func TestImage_components(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	image, err := packageexport.Image(packagecontent.Files{
+		".pkoignore":                   []byte("*.md\n"),
+		"manifest.yaml":                {1},
+		"deployment.yaml":              {2},
+		"components/api/manifest.yaml": {3},
+		"components/api/README.md":     {4},
+		"components/ui/manifest.yaml":  {5},
+	})
+	require.NoError(t, err)
+
+	manifest, err := image.Manifest()
+	require.NoError(t, err)
+	require.Len(t, manifest.Layers, 4)
+	assert.Equal(t, "api", manifest.Layers[2].Annotations[packages.ComponentLayerAnnotation])
+	assert.Equal(t, "ui", manifest.Layers[3].Annotations[packages.ComponentLayerAnnotation])
+
+	files, err := packageimport.Image(ctx, image)
+	require.NoError(t, err)
+	assert.Equal(t, packagecontent.Files{
+		"manifest.yaml":                {1},
+		"deployment.yaml":              {2},
+		"components/api/manifest.yaml": {3},
+		"components/ui/manifest.yaml":  {5},
+	}, files)
+
+	files, err = packageimport.Image(ctx, image, packageimport.WithComponent("api"))
+	require.NoError(t, err)
+	assert.Equal(t, packagecontent.Files{"manifest.yaml": {3}}, files)
+
+	_, err = packageimport.Image(ctx, image, packageimport.WithComponent("db"))
+	assert.ErrorIs(t, err, packageimport.ErrComponentNotFound)
+}
@@ -5,3 +5,49 @@ type WithInsecure bool
 func (w WithInsecure) ConfigurePull(c *PullConfig) {
 	c.Insecure = bool(w)
 }
+
+// WithComponent only loads the given component of a package.
+type WithComponent string
+
+func (w WithComponent) ConfigurePull(c *PullConfig) {
+	c.Component = string(w)
+}
+
+// WithMirrors configures registry mirrors to try before the original image source.
+type WithMirrors []RegistryMirror
+
+func (w WithMirrors) ConfigureRegistry(c *RegistryConfig) {
+	c.Mirrors = append(c.Mirrors, w...)
+}
+
+// WithMirrorSource configures a source of registry mirrors, that is looked up on every pull.
+type WithMirrorSource struct{ MirrorSource }
+
+func (w WithMirrorSource) ConfigureRegistry(c *RegistryConfig) {
+	c.MirrorSource = w.MirrorSource
+}
+
+// WithPlatform selects the image to pull from multi-architecture image indexes.
+type WithPlatform struct{ Platform *Platform }
+
+func (w WithPlatform) ConfigurePull(c *PullConfig) {
+	c.Platform = w.Platform
+}
+
+func (w WithPlatform) ConfigureRegistry(c *RegistryConfig) {
+	c.Platform = w.Platform
+}
+
+// WithCacheSize sets the number of unpacked package images to cache by digest.
+type WithCacheSize int
+
+func (w WithCacheSize) ConfigureRegistry(c *RegistryConfig) {
+	c.CacheSize = int(w)
+}
+
+// WithUnpackPodConfig customizes the Pods unpacking package images.
+type WithUnpackPodConfig UnpackPodConfig
+
+func (w WithUnpackPodConfig) ConfigurePodPuller(c *PodPullerConfig) {
+	c.UnpackPod = UnpackPodConfig(w)
+}
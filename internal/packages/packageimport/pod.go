@@ -0,0 +1,346 @@
+package packageimport
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"package-operator.run/package-operator/internal/packages"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+var (
+	// ErrUnpackInProgress is returned by the PodPuller while the unpack Pod has not finished.
+	ErrUnpackInProgress = errors.New("package image unpack in progress")
+	// ErrPackageTooLarge is returned when the package content can't be transferred via container logs.
+	ErrPackageTooLarge = errors.New("package content exceeds the unpack Pod log size limit")
+)
+
+const (
+	// Label identifying Pods unpacking package images.
+	UnpackPodLabel = "package-operator.run/unpack"
+
+	unpackPodNamePrefix = "package-unpack-"
+	unpackToolsPath     = "/tools"
+	unpackContentPath   = "/unpacked"
+	unpackBinaryPath    = unpackToolsPath + "/package-operator-manager"
+
+	// Container writing the package content to its log.
+	unpackDumpContainer = "dump"
+
+	// MaxUnpackArchiveSize is the maximum size of the package archive written to the unpack Pod log.
+	// Kubelet rotates container logs at 10Mi by default (containerLogMaxSize),
+	// larger archives would be truncated.
+	MaxUnpackArchiveSize = 8 << 20
+
+	// Unpack Pods are failed by the kubelet, if they are still running after this deadline.
+	unpackPodActiveDeadline = 30 * time.Minute
+	// Unpack Pods older than this are leftovers, e.g. of deleted Packages, and are removed.
+	unpackPodTTL = time.Hour
+)
+
+// PodPuller unpacks package images via Pods running the package image.
+// Image pulls are done by the container runtime of the node,
+// using node-level pull secrets and mirror configuration,
+// so the manager itself needs no access to image registries.
+//
+// The unpack Pod copies the manager binary into the package image,
+// copies the package content into a shared emptyDir and
+// finally prints it as archive to the log of its last container.
+type PodPuller struct {
+	cfg          PodPullerConfig
+	client       client.Client
+	pods         corev1client.PodsGetter
+	namespace    string
+	managerImage string
+}
+
+// NewPodPuller creates a new PodPuller running unpack Pods in the given namespace.
+// The manager image has to contain the package-operator-manager binary at its root.
+func NewPodPuller(
+	c client.Client, pods corev1client.PodsGetter,
+	namespace, managerImage string,
+	opts ...PodPullerOption,
+) *PodPuller {
+	var cfg PodPullerConfig
+
+	cfg.Option(opts...)
+
+	return &PodPuller{
+		cfg:          cfg,
+		client:       c,
+		pods:         pods,
+		namespace:    namespace,
+		managerImage: managerImage,
+	}
+}
+
+type PodPullerConfig struct {
+	// Customizes the unpack Pods.
+	UnpackPod UnpackPodConfig
+}
+
+func (c *PodPullerConfig) Option(opts ...PodPullerOption) {
+	for _, opt := range opts {
+		opt.ConfigurePodPuller(c)
+	}
+}
+
+type PodPullerOption interface {
+	ConfigurePodPuller(*PodPullerConfig)
+}
+
+// UnpackPodConfig customizes the Pods unpacking package images,
+// e.g. to run them on dedicated infra nodes or in namespaces enforcing the restricted Pod Security Standard.
+type UnpackPodConfig struct {
+	// Compute resources of all unpack containers.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Schedules unpack Pods onto nodes with matching labels.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Allows unpack Pods to be scheduled onto tainted nodes.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// RuntimeClass to run unpack Pods with.
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// Security context of all unpack containers.
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+	// Pod-level security context of unpack Pods.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+}
+
+// ParseUnpackPodConfig parses an UnpackPodConfig from YAML or JSON.
+// Unknown fields are rejected, so typos don't go unnoticed.
+func ParseUnpackPodConfig(data string) (UnpackPodConfig, error) {
+	var cfg UnpackPodConfig
+	if err := yaml.UnmarshalStrict([]byte(data), &cfg); err != nil {
+		return UnpackPodConfig{}, fmt.Errorf("parsing unpack Pod config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Pull returns the content of the given package image.
+// ErrUnpackInProgress is returned until the unpack Pod finished.
+func (p *PodPuller) Pull(ctx context.Context, image string) (packagecontent.Files, error) {
+	pod := &corev1.Pod{}
+	err := p.client.Get(ctx, client.ObjectKey{
+		Name:      unpackPodName(image),
+		Namespace: p.namespace,
+	}, pod)
+	if k8serrors.IsNotFound(err) {
+		if err := p.deleteExpiredPods(ctx); err != nil {
+			return nil, err
+		}
+		if err := p.client.Create(ctx, p.unpackPod(image)); err != nil &&
+			!k8serrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("creating unpack Pod: %w", err)
+		}
+		return nil, ErrUnpackInProgress
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting unpack Pod: %w", err)
+	}
+
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		files, err := p.readFiles(ctx, pod)
+		if err != nil {
+			// Unpack again on the next attempt, instead of reading the same logs forever.
+			return nil, errors.Join(err, p.deletePod(ctx, pod))
+		}
+		return files, p.deletePod(ctx, pod)
+
+	case corev1.PodFailed:
+		if err := p.deletePod(ctx, pod); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unpack Pod failed: %s", podFailureMessage(pod))
+	}
+
+	if msg := podWaitingMessage(pod); len(msg) > 0 {
+		// e.g. the image can't be pulled, kubelet keeps retrying.
+		return nil, fmt.Errorf("unpack Pod waiting: %s", msg)
+	}
+	return nil, ErrUnpackInProgress
+}
+
+func (p *PodPuller) readFiles(ctx context.Context, pod *corev1.Pod) (packagecontent.Files, error) {
+	logs, err := p.pods.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: unpackDumpContainer,
+	}).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading unpack Pod logs: %w", err)
+	}
+	defer logs.Close()
+
+	archive, err := io.ReadAll(io.LimitReader(logs, MaxUnpackArchiveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading unpack Pod logs: %w", err)
+	}
+	if len(archive) > MaxUnpackArchiveSize {
+		return nil, fmt.Errorf("%w of %d bytes", ErrPackageTooLarge, MaxUnpackArchiveSize)
+	}
+
+	files, err := ReadArchive(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("reading package content from unpack Pod: %w", err)
+	}
+	return files, nil
+}
+
+func (p *PodPuller) deletePod(ctx context.Context, pod *corev1.Pod) error {
+	if err := p.client.Delete(ctx, pod); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("deleting unpack Pod: %w", err)
+	}
+	return nil
+}
+
+// Deletes unpack Pods exceeding their TTL.
+// Pods are only deleted by Pull after they finished,
+// but may be left behind if no Package needs their image anymore.
+func (p *PodPuller) deleteExpiredPods(ctx context.Context) error {
+	pods := &corev1.PodList{}
+	if err := p.client.List(ctx, pods,
+		client.InNamespace(p.namespace),
+		client.MatchingLabels{UnpackPodLabel: "True"},
+	); err != nil {
+		return fmt.Errorf("listing unpack Pods: %w", err)
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if time.Since(pod.CreationTimestamp.Time) < unpackPodTTL {
+			continue
+		}
+		if err := p.deletePod(ctx, pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PodPuller) unpackPod(image string) *corev1.Pod {
+	toolsMount := corev1.VolumeMount{Name: "tools", MountPath: unpackToolsPath}
+	contentMount := corev1.VolumeMount{Name: "content", MountPath: unpackContentPath}
+	automount := false
+	activeDeadlineSeconds := int64(unpackPodActiveDeadline.Seconds())
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      unpackPodName(image),
+			Namespace: p.namespace,
+			Labels: map[string]string{
+				UnpackPodLabel: "True",
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                corev1.RestartPolicyNever,
+			AutomountServiceAccountToken: &automount,
+			ActiveDeadlineSeconds:        &activeDeadlineSeconds,
+			InitContainers: []corev1.Container{
+				{
+					Name:         "prepare",
+					Image:        p.managerImage,
+					Args:         []string{"--copy-to=" + unpackBinaryPath},
+					VolumeMounts: []corev1.VolumeMount{toolsMount},
+				},
+				{
+					Name:    "package",
+					Image:   image,
+					Command: []string{unpackBinaryPath},
+					Args: []string{
+						"--copy-package=/" + packages.ImageFilePrefixPath + ":" + unpackContentPath,
+					},
+					VolumeMounts: []corev1.VolumeMount{toolsMount, contentMount},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:         unpackDumpContainer,
+					Image:        p.managerImage,
+					Args:         []string{"--dump-package=" + unpackContentPath},
+					VolumeMounts: []corev1.VolumeMount{contentMount},
+					// Reports errors, like exceeding MaxUnpackArchiveSize, in the container status.
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "tools", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				{Name: "content", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+	p.cfg.UnpackPod.applyTo(pod)
+	return pod
+}
+
+func (c UnpackPodConfig) applyTo(pod *corev1.Pod) {
+	pod.Spec.NodeSelector = c.NodeSelector
+	pod.Spec.Tolerations = c.Tolerations
+	pod.Spec.RuntimeClassName = c.RuntimeClassName
+	pod.Spec.SecurityContext = c.PodSecurityContext
+
+	for _, containers := range [][]corev1.Container{
+		pod.Spec.InitContainers, pod.Spec.Containers,
+	} {
+		for i := range containers {
+			containers[i].Resources = *c.Resources.DeepCopy()
+			if c.SecurityContext != nil {
+				containers[i].SecurityContext = c.SecurityContext.DeepCopy()
+			}
+		}
+	}
+}
+
+// Pods are named after the image, so Packages using the same image share one unpack Pod.
+// IsUnpackPod returns true for unpack Pods created by a PodPuller in the given namespace.
+func IsUnpackPod(obj client.Object, namespace string) bool {
+	_, isPod := obj.(*corev1.Pod)
+	return isPod &&
+		obj.GetNamespace() == namespace &&
+		obj.GetLabels()[UnpackPodLabel] == "True" &&
+		strings.HasPrefix(obj.GetName(), unpackPodNamePrefix)
+}
+
+func unpackPodName(image string) string {
+	return unpackPodNamePrefix + fmt.Sprintf("%x", sha256.Sum256([]byte(image)))[:16]
+}
+
+func podFailureMessage(pod *corev1.Pod) string {
+	var msgs []string
+	statuses := append(append([]corev1.ContainerStatus{},
+		pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if t := status.State.Terminated; t != nil && t.ExitCode != 0 {
+			msgs = append(msgs, fmt.Sprintf(
+				"container %s exited with %d: %s %s", status.Name, t.ExitCode, t.Reason, t.Message))
+		}
+	}
+	if len(msgs) == 0 {
+		return pod.Status.Message
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// Reports containers that are stuck, e.g. because their image can't be pulled.
+func podWaitingMessage(pod *corev1.Pod) string {
+	var msgs []string
+	statuses := append(append([]corev1.ContainerStatus{},
+		pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		w := status.State.Waiting
+		if w == nil || w.Reason == "PodInitializing" || w.Reason == "ContainerCreating" {
+			continue
+		}
+		msgs = append(msgs, fmt.Sprintf("container %s: %s %s", status.Name, w.Reason, w.Message))
+	}
+	return strings.Join(msgs, ", ")
+}
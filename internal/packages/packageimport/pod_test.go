@@ -0,0 +1,224 @@
+package packageimport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+const testImage = "quay.io/package-operator/test:v1"
+
+func TestPodPuller_Pull_createsPod(t *testing.T) {
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.NewNotFound(schema.GroupResource{}, ""))
+	c.
+		On("List", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*corev1.PodList).Items = []corev1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{
+					Name:              "fresh",
+					CreationTimestamp: metav1.Now(),
+				}},
+				{ObjectMeta: metav1.ObjectMeta{
+					Name:              "expired",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * unpackPodTTL)),
+				}},
+			}
+		}).
+		Return(nil)
+	c.
+		On("Delete", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+	var pod *corev1.Pod
+	c.
+		On("Create", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			pod = args.Get(1).(*corev1.Pod)
+		}).
+		Return(nil)
+
+	p := NewPodPuller(c, nil, "pko-system", "quay.io/package-operator/manager:v1")
+	_, err := p.Pull(context.Background(), testImage)
+	require.ErrorIs(t, err, ErrUnpackInProgress)
+
+	require.NotNil(t, pod)
+	assert.Equal(t, unpackPodName(testImage), pod.Name)
+	assert.Equal(t, "pko-system", pod.Namespace)
+	if assert.Len(t, pod.Spec.InitContainers, 2) {
+		assert.Equal(t, "quay.io/package-operator/manager:v1", pod.Spec.InitContainers[0].Image)
+		assert.Equal(t, testImage, pod.Spec.InitContainers[1].Image)
+	}
+	if assert.Len(t, pod.Spec.Containers, 1) {
+		assert.Equal(t, unpackDumpContainer, pod.Spec.Containers[0].Name)
+	}
+	if assert.NotNil(t, pod.Spec.ActiveDeadlineSeconds) {
+		assert.Equal(t, int64(unpackPodActiveDeadline.Seconds()), *pod.Spec.ActiveDeadlineSeconds)
+	}
+
+	// Leftover Pods exceeding their TTL are removed.
+	c.AssertNumberOfCalls(t, "Delete", 1)
+	c.AssertCalled(t, "Delete", mock.Anything, mock.MatchedBy(func(pod *corev1.Pod) bool {
+		return pod.Name == "expired"
+	}), mock.Anything)
+}
+
+func TestPodPuller_Pull_readFailure(t *testing.T) {
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			pod := args.Get(2).(*corev1.Pod)
+			pod.Name = unpackPodName(testImage)
+			pod.Namespace = "pko-system"
+			pod.Status.Phase = corev1.PodSucceeded
+		}).
+		Return(nil)
+	c.
+		On("Delete", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	// The fake clientset returns "fake logs", which is not a valid archive.
+	p := NewPodPuller(c, fake.NewSimpleClientset().CoreV1(), "pko-system", "manager:v1")
+	_, err := p.Pull(context.Background(), testImage)
+	require.Error(t, err)
+
+	// The Pod is unpacked again on the next attempt.
+	c.AssertCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPodPuller_Pull_status(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     corev1.PodStatus
+		inProgress bool
+		deleted    bool
+		errMsg     string
+	}{
+		{
+			name:       "running",
+			status:     corev1.PodStatus{Phase: corev1.PodPending},
+			inProgress: true,
+		},
+		{
+			name: "image pull failing",
+			status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "package",
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "not found"},
+						},
+					},
+				},
+			},
+			errMsg: "unpack Pod waiting: container package: ImagePullBackOff not found",
+		},
+		{
+			name: "failed",
+			status: corev1.PodStatus{
+				Phase: corev1.PodFailed,
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "package",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"},
+						},
+					},
+				},
+			},
+			deleted: true,
+			errMsg:  "unpack Pod failed: container package exited with 1: Error ",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			c := testutil.NewClient()
+			c.
+				On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(2).(*corev1.Pod).Status = test.status
+				}).
+				Return(nil)
+			c.
+				On("Delete", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
+			p := NewPodPuller(c, nil, "pko-system", "manager:v1")
+			_, err := p.Pull(context.Background(), testImage)
+			if test.inProgress {
+				assert.ErrorIs(t, err, ErrUnpackInProgress)
+			} else {
+				assert.EqualError(t, err, test.errMsg)
+			}
+
+			if test.deleted {
+				c.AssertCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				c.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestPodPuller_unpackPodConfig(t *testing.T) {
+	cfg, err := ParseUnpackPodConfig(`
+nodeSelector:
+  node-role.kubernetes.io/infra: ""
+tolerations:
+- key: node-role.kubernetes.io/infra
+  effect: NoSchedule
+runtimeClassName: gvisor
+resources:
+  limits:
+    memory: 256Mi
+securityContext:
+  allowPrivilegeEscalation: false
+podSecurityContext:
+  runAsNonRoot: true
+`)
+	require.NoError(t, err)
+
+	p := NewPodPuller(nil, nil, "pko-system", "quay.io/package-operator/manager:v1",
+		WithUnpackPodConfig(cfg))
+	pod := p.unpackPod(testImage)
+
+	assert.Equal(t, map[string]string{"node-role.kubernetes.io/infra": ""}, pod.Spec.NodeSelector)
+	if assert.Len(t, pod.Spec.Tolerations, 1) {
+		assert.Equal(t, corev1.TaintEffectNoSchedule, pod.Spec.Tolerations[0].Effect)
+	}
+	if assert.NotNil(t, pod.Spec.RuntimeClassName) {
+		assert.Equal(t, "gvisor", *pod.Spec.RuntimeClassName)
+	}
+	if assert.NotNil(t, pod.Spec.SecurityContext) {
+		assert.True(t, *pod.Spec.SecurityContext.RunAsNonRoot)
+	}
+	containers := append(append([]corev1.Container{},
+		pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, c := range containers {
+		assert.Equal(t, "256Mi", c.Resources.Limits.Memory().String(), c.Name)
+		if assert.NotNil(t, c.SecurityContext, c.Name) {
+			assert.False(t, *c.SecurityContext.AllowPrivilegeEscalation, c.Name)
+		}
+	}
+}
+
+func TestParseUnpackPodConfig_unknownField(t *testing.T) {
+	_, err := ParseUnpackPodConfig(`nodeSelectors: {}`)
+	require.Error(t, err)
+}
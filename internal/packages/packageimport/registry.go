@@ -2,9 +2,15 @@ package packageimport
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"sync"
 
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/name"
+
 	"package-operator.run/package-operator/internal/packages/packagecontent"
 	"package-operator.run/package-operator/internal/utils"
 )
@@ -12,22 +18,122 @@ import (
 // Registry handles pulling images from a registry during PKO runtime.
 type Registry struct {
 	registryHostOverrides map[string]string
+	cfg                   RegistryConfig
 
-	pullImage    pullImageFn
-	inFlight     map[string][]chan<- response
-	inFlightLock sync.Mutex
+	pullImage     pullImageFn
+	resolveDigest resolveDigestFn
+	listTags      listTagsFn
+	cache         *digestCache
+	inFlight      map[string][]chan<- response
+	inFlightLock  sync.Mutex
 }
 
-type pullImageFn func(ctx context.Context, ref string) (packagecontent.Files, error)
+type (
+	pullImageFn     func(ctx context.Context, ref string) (packagecontent.Files, error)
+	resolveDigestFn func(ctx context.Context, ref string) (string, error)
+	listTagsFn      func(ctx context.Context, repository string) ([]string, error)
+)
+
+func NewRegistry(registryHostOverrides map[string]string, opts ...RegistryOption) *Registry {
+	var cfg RegistryConfig
 
-func NewRegistry(registryHostOverrides map[string]string) *Registry {
-	return &Registry{
+	cfg.Option(opts...)
+
+	r := &Registry{
 		registryHostOverrides: registryHostOverrides,
-		pullImage:             PulledImage,
+		cfg:                   cfg,
 		inFlight:              make(map[string][]chan<- response),
 	}
+	r.pullImage = func(ctx context.Context, ref string) (packagecontent.Files, error) {
+		var pullOpts []PullOption
+		if r.cfg.Platform != nil {
+			pullOpts = append(pullOpts, WithPlatform{Platform: r.cfg.Platform})
+		}
+		return NewPuller().Pull(ctx, ref, pullOpts...)
+	}
+	r.resolveDigest = func(ctx context.Context, ref string) (string, error) {
+		craneOpts := []crane.Option{crane.WithContext(ctx)}
+		if r.cfg.Platform != nil {
+			craneOpts = append(craneOpts, crane.WithPlatform(r.cfg.Platform))
+		}
+		return crane.Digest(ref, craneOpts...)
+	}
+	r.listTags = func(ctx context.Context, repository string) ([]string, error) {
+		return crane.ListTags(repository, crane.WithContext(ctx))
+	}
+	if r.cfg.CacheSize > 0 {
+		r.cache = newDigestCache(r.cfg.CacheSize)
+	}
+	return r
+}
+
+type RegistryConfig struct {
+	// Mirrors to try before pulling from the original source.
+	Mirrors []RegistryMirror
+	// Provides additional mirrors, tried after Mirrors.
+	// Looked up on every pull, so mirrors can change while running.
+	MirrorSource MirrorSource
+	// Platform to select from multi-architecture package images.
+	// Defaults to linux/amd64, if unset.
+	Platform *Platform
+	// Number of unpacked package images to keep in memory, keyed by image digest.
+	// Packages referencing the same image digest are only pulled and unpacked once.
+	// Caching is disabled, if 0.
+	CacheSize int
+}
+
+func (c *RegistryConfig) Option(opts ...RegistryOption) {
+	for _, opt := range opts {
+		opt.ConfigureRegistry(c)
+	}
+}
+
+type RegistryOption interface {
+	ConfigureRegistry(*RegistryConfig)
+}
+
+// RegistryMirror redirects pulls of images under the Source repository prefix
+// to the given Mirror repository prefixes, similar to an ImageContentSourcePolicy.
+type RegistryMirror struct {
+	// Repository prefix to mirror, e.g. quay.io/package-operator.
+	Source string
+	// Repository prefixes to try in order, e.g. mirror.local/package-operator.
+	Mirrors []string
+}
+
+// MirrorSource provides registry mirrors at the time an image is pulled.
+type MirrorSource interface {
+	RegistryMirrors(ctx context.Context) ([]RegistryMirror, error)
+}
+
+// ParseRegistryMirrors parses a list of registry mirrors in the form of
+// <source>=<mirror>[|<mirror>...][,<source>=<mirror>...].
+func ParseRegistryMirrors(flag string) ([]RegistryMirror, error) {
+	if len(flag) == 0 {
+		return nil, nil
+	}
+
+	var mirrors []RegistryMirror
+	for _, entry := range strings.Split(flag, ",") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRegistryMirror, entry)
+		}
+
+		mirror := RegistryMirror{Source: strings.TrimSuffix(parts[0], "/")}
+		for _, m := range strings.Split(parts[1], "|") {
+			if len(m) == 0 {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidRegistryMirror, entry)
+			}
+			mirror.Mirrors = append(mirror.Mirrors, strings.TrimSuffix(m, "/"))
+		}
+		mirrors = append(mirrors, mirror)
+	}
+	return mirrors, nil
 }
 
+var ErrInvalidRegistryMirror = errors.New("invalid registry mirror, expected <source>=<mirror>[|<mirror>...]")
+
 func (r *Registry) Pull(ctx context.Context, image string) (packagecontent.Files, error) {
 	image, err := r.applyOverride(image)
 	if err != nil {
@@ -39,6 +145,29 @@ func (r *Registry) Pull(ctx context.Context, image string) (packagecontent.Files
 	return res.Files, res.Err
 }
 
+// ListTags returns all tags of the given OCI repository.
+func (r *Registry) ListTags(ctx context.Context, repository string) ([]string, error) {
+	repository, err := r.applyOverride(repository)
+	if err != nil {
+		return nil, err
+	}
+	// Overrides add the "latest" tag to the repository.
+	ref, err := name.ParseReference(repository)
+	if err != nil {
+		return nil, fmt.Errorf("parsing repository: %w", err)
+	}
+	return r.listTags(ctx, ref.Context().Name())
+}
+
+// Digest resolves the digest of the given image.
+func (r *Registry) Digest(ctx context.Context, image string) (string, error) {
+	image, err := r.applyOverride(image)
+	if err != nil {
+		return "", err
+	}
+	return r.resolveDigest(ctx, image)
+}
+
 func (r *Registry) applyOverride(image string) (string, error) {
 	for original, override := range r.registryHostOverrides {
 		if strings.HasPrefix(image, original) {
@@ -48,6 +177,107 @@ func (r *Registry) applyOverride(image string) (string, error) {
 	return image, nil
 }
 
+// mirrors returns all configured mirrors,
+// ignoring the mirror source if it can't be read.
+func (r *Registry) mirrors(ctx context.Context) []RegistryMirror {
+	if r.cfg.MirrorSource == nil {
+		return r.cfg.Mirrors
+	}
+	dynamic, err := r.cfg.MirrorSource.RegistryMirrors(ctx)
+	if err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "looking up registry mirrors")
+		return r.cfg.Mirrors
+	}
+	mirrors := make([]RegistryMirror, 0, len(r.cfg.Mirrors)+len(dynamic))
+	mirrors = append(mirrors, r.cfg.Mirrors...)
+	return append(mirrors, dynamic...)
+}
+
+// pullCandidates returns the list of image references to try in order,
+// starting with matching mirrors and ending with the image itself.
+func (r *Registry) pullCandidates(ctx context.Context, image string) []string {
+	var candidates []string
+	for _, mirror := range r.mirrors(ctx) {
+		if !hasRepositoryPrefix(image, mirror.Source) {
+			continue
+		}
+		for _, m := range mirror.Mirrors {
+			candidates = append(candidates, m+strings.TrimPrefix(image, mirror.Source))
+		}
+	}
+	return append(candidates, image)
+}
+
+// hasRepositoryPrefix checks if the prefix matches the image reference
+// on a path, tag or digest boundary.
+func hasRepositoryPrefix(image, prefix string) bool {
+	if !strings.HasPrefix(image, prefix) {
+		return false
+	}
+	if len(image) == len(prefix) {
+		return true
+	}
+	switch image[len(prefix)] {
+	case '/', ':', '@':
+		return true
+	}
+	return false
+}
+
+// pullWithMirrors tries to pull the image from all configured mirrors,
+// before falling back to the original image reference.
+func (r *Registry) pullWithMirrors(ctx context.Context, image string) (packagecontent.Files, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	candidates := r.pullCandidates(ctx, image)
+	if len(candidates) == 1 {
+		return r.pullCached(ctx, image)
+	}
+
+	errs := make([]error, 0, len(candidates))
+	for _, candidate := range candidates {
+		files, err := r.pullCached(ctx, candidate)
+		if err == nil {
+			return files, nil
+		}
+		if candidate != image {
+			log.Info("pulling from mirror failed", "image", image, "mirror", candidate, "error", err.Error())
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", candidate, err))
+	}
+	return nil, errors.Join(errs...)
+}
+
+// pullCached resolves the digest of the given image reference
+// and only pulls the image if its contents are not already cached.
+func (r *Registry) pullCached(ctx context.Context, image string) (packagecontent.Files, error) {
+	if r.cache == nil {
+		return r.pullImage(ctx, image)
+	}
+
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, fmt.Errorf("parsing image reference: %w", err)
+	}
+
+	digest, err := r.resolveDigest(ctx, ref.String())
+	if err != nil {
+		return nil, fmt.Errorf("resolving image digest: %w", err)
+	}
+	if files, ok := r.cache.Get(digest); ok {
+		logr.FromContextOrDiscard(ctx).V(1).Info("using cached package contents", "image", image, "digest", digest)
+		return files, nil
+	}
+
+	// Pull by digest, to ensure cached contents match the resolved digest.
+	files, err := r.pullImage(ctx, ref.Context().Digest(digest).String())
+	if err != nil {
+		return nil, err
+	}
+	r.cache.Add(digest, files)
+	return files, nil
+}
+
 // handleRequest first checks if the provided image is already being pulled.
 // If it is not, a new go routine is started to pull the image and trigger
 // response handling. Then a new receiver is registered to listen for the response.
@@ -61,7 +291,7 @@ func (r *Registry) handleRequest(ctx context.Context, image string) <-chan respo
 
 	if _, inFlight := r.inFlight[image]; !inFlight {
 		go func(ctx context.Context, image string) {
-			files, err := r.pullImage(ctx, image)
+			files, err := r.pullWithMirrors(ctx, image)
 
 			r.handleResponse(image, response{
 				Files: files,
@@ -2,6 +2,7 @@ package packageimport
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -109,3 +110,169 @@ func (m *imagePullerMock) Pull(
 	args := m.Called(ctx, ref)
 	return args.Get(0).(packagecontent.Files), args.Error(1)
 }
+
+func TestRegistry_Mirrors(t *testing.T) {
+	ipm := &imagePullerMock{}
+	ipm.
+		On("Pull", mock.Anything, "mirror-a.local/pko/test:v1").
+		Return(packagecontent.Files(nil), errors.New("not found"))
+	ipm.
+		On("Pull", mock.Anything, "mirror-b.local/pko/test:v1").
+		Return(packagecontent.Files{"test.yaml": []byte("test")}, nil)
+
+	r := NewRegistry(nil, WithMirrors{
+		{Source: "quay.io/package-operator", Mirrors: []string{"mirror-a.local/pko", "mirror-b.local/pko"}},
+	})
+	r.pullImage = ipm.Pull
+
+	files, err := r.Pull(context.Background(), "quay.io/package-operator/test:v1")
+	require.NoError(t, err)
+	assert.Equal(t, packagecontent.Files{"test.yaml": []byte("test")}, files)
+	ipm.AssertNotCalled(t, "Pull", mock.Anything, "quay.io/package-operator/test:v1")
+}
+
+func TestRegistry_MirrorsFallback(t *testing.T) {
+	ipm := &imagePullerMock{}
+	ipm.
+		On("Pull", mock.Anything, "mirror.local/pko/test:v1").
+		Return(packagecontent.Files(nil), errors.New("not found"))
+	ipm.
+		On("Pull", mock.Anything, "quay.io/package-operator/test:v1").
+		Return(packagecontent.Files{}, nil)
+
+	r := NewRegistry(nil, WithMirrors{
+		{Source: "quay.io/package-operator", Mirrors: []string{"mirror.local/pko"}},
+	})
+	r.pullImage = ipm.Pull
+
+	_, err := r.Pull(context.Background(), "quay.io/package-operator/test:v1")
+	require.NoError(t, err)
+	ipm.AssertNumberOfCalls(t, "Pull", 2)
+}
+
+func TestRegistry_pullCandidates(t *testing.T) {
+	r := NewRegistry(nil, WithMirrors{
+		{Source: "quay.io/pko", Mirrors: []string{"mirror.local/pko"}},
+	})
+
+	assert.Equal(t, []string{"mirror.local/pko/test:v1", "quay.io/pko/test:v1"},
+		r.pullCandidates(context.Background(), "quay.io/pko/test:v1"))
+	// must match on a path boundary
+	assert.Equal(t, []string{"quay.io/pkoextra/test:v1"},
+		r.pullCandidates(context.Background(), "quay.io/pkoextra/test:v1"))
+}
+
+type mirrorSourceMock struct {
+	mock.Mock
+}
+
+func (m *mirrorSourceMock) RegistryMirrors(ctx context.Context) ([]RegistryMirror, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]RegistryMirror), args.Error(1)
+}
+
+func TestRegistry_pullCandidates_mirrorSource(t *testing.T) {
+	ms := &mirrorSourceMock{}
+	r := NewRegistry(nil,
+		WithMirrors{{Source: "quay.io/pko", Mirrors: []string{"a.local/pko"}}},
+		WithMirrorSource{ms},
+	)
+
+	ms.On("RegistryMirrors", mock.Anything).
+		Return([]RegistryMirror{{Source: "quay.io/pko", Mirrors: []string{"b.local/pko"}}}, nil).
+		Once()
+	assert.Equal(t, []string{"a.local/pko/test:v1", "b.local/pko/test:v1", "quay.io/pko/test:v1"},
+		r.pullCandidates(context.Background(), "quay.io/pko/test:v1"))
+
+	// static mirrors are still used when the source fails
+	ms.On("RegistryMirrors", mock.Anything).
+		Return([]RegistryMirror(nil), errors.New("explosion"))
+	assert.Equal(t, []string{"a.local/pko/test:v1", "quay.io/pko/test:v1"},
+		r.pullCandidates(context.Background(), "quay.io/pko/test:v1"))
+}
+
+func TestParseRegistryMirrors(t *testing.T) {
+	mirrors, err := ParseRegistryMirrors("quay.io/pko=a.local/pko|b.local/pko/,docker.io=c.local")
+	require.NoError(t, err)
+	assert.Equal(t, []RegistryMirror{
+		{Source: "quay.io/pko", Mirrors: []string{"a.local/pko", "b.local/pko"}},
+		{Source: "docker.io", Mirrors: []string{"c.local"}},
+	}, mirrors)
+
+	_, err = ParseRegistryMirrors("quay.io")
+	require.ErrorIs(t, err, ErrInvalidRegistryMirror)
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	const digest = "sha256:6c3c624b58dbbcd3c0dd82b4c53f04194d1247c6eebdaab7c610cf7d66709b3b"
+
+	ipm := &imagePullerMock{}
+	ipm.
+		On("Pull", mock.Anything, "quay.io/package-operator/test@"+digest).
+		Return(packagecontent.Files{"test.yaml": []byte("test")}, nil)
+
+	r := NewRegistry(nil, WithCacheSize(1))
+	r.pullImage = ipm.Pull
+	r.resolveDigest = func(_ context.Context, ref string) (string, error) {
+		return digest, nil
+	}
+
+	ctx := context.Background()
+	for _, image := range []string{
+		"quay.io/package-operator/test:v1",
+		"quay.io/package-operator/test:latest",
+		"quay.io/package-operator/test@" + digest,
+	} {
+		files, err := r.Pull(ctx, image)
+		require.NoError(t, err)
+		assert.Equal(t, packagecontent.Files{"test.yaml": []byte("test")}, files)
+	}
+
+	ipm.AssertNumberOfCalls(t, "Pull", 1)
+}
+
+func TestRegistry_CacheResolveError(t *testing.T) {
+	ipm := &imagePullerMock{}
+
+	r := NewRegistry(nil, WithCacheSize(1))
+	r.pullImage = ipm.Pull
+	r.resolveDigest = func(_ context.Context, ref string) (string, error) {
+		return "", errors.New("explosion")
+	}
+
+	_, err := r.Pull(context.Background(), "quay.io/package-operator/test:v1")
+	require.EqualError(t, err, "resolving image digest: explosion")
+	ipm.AssertNotCalled(t, "Pull", mock.Anything, mock.Anything)
+}
+
+func TestRegistry_ListTags(t *testing.T) {
+	r := NewRegistry(map[string]string{
+		"quay.io": "localhost:123",
+	})
+	var listed string
+	r.listTags = func(_ context.Context, repository string) ([]string, error) {
+		listed = repository
+		return []string{"v1.0.0"}, nil
+	}
+
+	tags, err := r.ListTags(context.Background(), "quay.io/package-operator/test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, tags)
+	assert.Equal(t, "localhost:123/package-operator/test", listed)
+}
+
+func TestRegistry_Digest(t *testing.T) {
+	r := NewRegistry(map[string]string{
+		"quay.io": "localhost:123",
+	})
+	var resolved string
+	r.resolveDigest = func(_ context.Context, ref string) (string, error) {
+		resolved = ref
+		return "sha256:123", nil
+	}
+
+	digest, err := r.Digest(context.Background(), "quay.io/package-operator/test:v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:123", digest)
+	assert.Equal(t, "localhost:123/package-operator/test:v1.0.0", resolved)
+}
@@ -0,0 +1,102 @@
+package packageimport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+// SourceArchiveKey is the ConfigMap and Secret key holding package contents as archive written by WriteArchive.
+// ConfigMap and Secret keys can't contain directories, so packages with directories have to be archived.
+const SourceArchiveKey = "package.tar.gz.b64"
+
+// ErrNoLocalPackageDir is returned when loading local paths without a local package directory configured.
+var ErrNoLocalPackageDir = errors.New("no local package directory configured")
+
+// SourceLoader loads package contents from ConfigMaps, Secrets and
+// directories mounted into the manager, bypassing image registries entirely,
+// so packages can be installed in disconnected clusters.
+type SourceLoader struct {
+	client          client.Reader
+	localPackageDir string
+}
+
+// NewSourceLoader creates a new SourceLoader.
+// Local paths are resolved relative to localPackageDir, an empty dir disables local paths.
+func NewSourceLoader(c client.Reader, localPackageDir string) *SourceLoader {
+	return &SourceLoader{
+		client:          c,
+		localPackageDir: localPackageDir,
+	}
+}
+
+// LoadConfigMap returns the package contents stored in the given ConfigMap.
+func (l *SourceLoader) LoadConfigMap(ctx context.Context, key client.ObjectKey) (packagecontent.Files, error) {
+	cm := &corev1.ConfigMap{}
+	if err := l.client.Get(ctx, key, cm); err != nil {
+		return nil, fmt.Errorf("getting package source ConfigMap: %w", err)
+	}
+
+	data := make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+	for k, v := range cm.Data {
+		data[k] = []byte(v)
+	}
+	for k, v := range cm.BinaryData {
+		data[k] = v
+	}
+	return filesFromKeys(data)
+}
+
+// LoadSecret returns the package contents stored in the given Secret.
+func (l *SourceLoader) LoadSecret(ctx context.Context, key client.ObjectKey) (packagecontent.Files, error) {
+	secret := &corev1.Secret{}
+	if err := l.client.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("getting package source Secret: %w", err)
+	}
+	return filesFromKeys(secret.Data)
+}
+
+// LoadLocalPath returns the package contents of a directory below the local package directory.
+func (l *SourceLoader) LoadLocalPath(ctx context.Context, path string) (packagecontent.Files, error) {
+	if len(l.localPackageDir) == 0 {
+		return nil, ErrNoLocalPackageDir
+	}
+	if !filepath.IsLocal(path) {
+		return nil, fmt.Errorf("local path %q has to be within the local package directory", path)
+	}
+
+	files, err := Folder(ctx, filepath.Join(l.localPackageDir, path))
+	if err != nil {
+		return nil, fmt.Errorf("loading local path %q: %w", path, err)
+	}
+	return files, nil
+}
+
+// Every key is a file at the package root, except the archive key.
+func filesFromKeys(data map[string][]byte) (packagecontent.Files, error) {
+	files := packagecontent.Files{}
+	for key, value := range data {
+		if key != SourceArchiveKey {
+			if !isFilenameToBeExcluded(key) {
+				files[key] = value
+			}
+			continue
+		}
+
+		archived, err := ReadArchive(bytes.NewReader(value))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", key, err)
+		}
+		for path, content := range archived {
+			files[path] = content
+		}
+	}
+	return files, nil
+}
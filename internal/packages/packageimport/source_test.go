@@ -0,0 +1,90 @@
+package packageimport
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestSourceLoader_LoadConfigMap(t *testing.T) {
+	var archive bytes.Buffer
+	require.NoError(t, WriteArchive(&archive, packagecontent.Files{
+		"deploy/obj.yaml": []byte("obj"),
+	}))
+
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, client.ObjectKey{Name: "pkg", Namespace: "test"},
+			mock.AnythingOfType("*v1.ConfigMap"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			cm := args.Get(2).(*corev1.ConfigMap)
+			cm.Data = map[string]string{
+				"manifest.yaml":  "manifest",
+				".hidden":        "hidden",
+				SourceArchiveKey: archive.String(),
+			}
+			cm.BinaryData = map[string][]byte{"binary": {1, 2}}
+		}).
+		Return(nil)
+
+	l := NewSourceLoader(c, "")
+	files, err := l.LoadConfigMap(context.Background(), client.ObjectKey{Name: "pkg", Namespace: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, packagecontent.Files{
+		"manifest.yaml":   []byte("manifest"),
+		"deploy/obj.yaml": []byte("obj"),
+		"binary":          {1, 2},
+	}, files)
+}
+
+func TestSourceLoader_LoadSecret(t *testing.T) {
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, mock.Anything,
+			mock.AnythingOfType("*v1.Secret"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			secret := args.Get(2).(*corev1.Secret)
+			secret.Data = map[string][]byte{"manifest.yaml": []byte("manifest")}
+		}).
+		Return(nil)
+
+	l := NewSourceLoader(c, "")
+	files, err := l.LoadSecret(context.Background(), client.ObjectKey{Name: "pkg", Namespace: "test"})
+	require.NoError(t, err)
+	assert.Equal(t, packagecontent.Files{"manifest.yaml": []byte("manifest")}, files)
+}
+
+func TestSourceLoader_LoadLocalPath(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "my-package", "deploy"), 0o755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "my-package", "manifest.yaml"), []byte("manifest"), 0o600))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "my-package", "deploy", "obj.yaml"), []byte("obj"), 0o600))
+
+	ctx := context.Background()
+	l := NewSourceLoader(nil, dir)
+	files, err := l.LoadLocalPath(ctx, "my-package")
+	require.NoError(t, err)
+	assert.Equal(t, packagecontent.Files{
+		"manifest.yaml":   []byte("manifest"),
+		"deploy/obj.yaml": []byte("obj"),
+	}, files)
+
+	_, err = l.LoadLocalPath(ctx, "../my-package")
+	assert.EqualError(t, err, `local path "../my-package" has to be within the local package directory`)
+
+	_, err = NewSourceLoader(nil, "").LoadLocalPath(ctx, "my-package")
+	assert.ErrorIs(t, err, ErrNoLocalPackageDir)
+}
@@ -16,6 +16,7 @@ type (
 		transformers             []Transformer
 		filesTransformers        []FilesTransformer
 		packageAndFilesValidator []PackageAndFilesValidator
+		strictManifests          bool
 	}
 	Option func(l *Loader)
 
@@ -52,12 +53,21 @@ func WithFilesTransformers(transformers ...FilesTransformer) Option {
 	return func(l *Loader) { l.filesTransformers = append(l.filesTransformers, transformers...) }
 }
 
+// Rejects unknown fields in the PackageManifest and PackageManifestLock,
+// instead of silently ignoring them.
+func WithStrictManifests(l *Loader) {
+	l.strictManifests = true
+}
+
 func WithDefaults(l *Loader) {
-	WithValidators(&ObjectDuplicateValidator{}, &ObjectGVKValidator{}, &ObjectLabelsValidator{}, &ObjectPhaseAnnotationValidator{})(l)
+	WithValidators(
+		&ObjectDuplicateValidator{}, &ObjectGVKValidator{}, &ObjectLabelsValidator{},
+		&ObjectMappingValidator{}, &ObjectPhaseAnnotationValidator{},
+	)(l)
 }
 
 func New(scheme *runtime.Scheme, opts ...Option) *Loader {
-	l := &Loader{scheme, []Validator{}, []Transformer{}, []FilesTransformer{}, []PackageAndFilesValidator{}}
+	l := &Loader{scheme, []Validator{}, []Transformer{}, []FilesTransformer{}, []PackageAndFilesValidator{}, false}
 	for _, opt := range opts {
 		opt(l)
 	}
@@ -73,6 +83,7 @@ func (l Loader) FromFiles(ctx context.Context, files packagecontent.Files, opts
 			append([]Transformer{}, l.transformers...),
 			append([]FilesTransformer{}, l.filesTransformers...),
 			append([]PackageAndFilesValidator{}, l.packageAndFilesValidator...),
+			l.strictManifests,
 		}
 
 		for _, opt := range opts {
@@ -86,7 +97,11 @@ func (l Loader) FromFiles(ctx context.Context, files packagecontent.Files, opts
 		}
 	}
 
-	pkg, err := packagecontent.PackageFromFiles(ctx, l.scheme, files)
+	var fromFilesOpts []packagecontent.FromFilesOption
+	if l.strictManifests {
+		fromFilesOpts = append(fromFilesOpts, packagecontent.WithStrictManifests)
+	}
+	pkg, err := packagecontent.PackageFromFiles(ctx, l.scheme, files, fromFilesOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("convert files to package: %w", err)
 	}
@@ -3,6 +3,7 @@ package packageloader_test
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -13,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 
 	pkoapis "package-operator.run/apis"
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
@@ -71,7 +73,8 @@ func TestLoader(t *testing.T) {
 		},
 	}, pc.PackageManifest)
 
-	spec := packagecontent.TemplateSpecFromPackage(pc)
+	spec, err := packagecontent.TemplateSpecFromPackage(pc)
+	require.NoError(t, err)
 	assert.Equal(t, expectedProbes, spec.AvailabilityProbes)
 	assert.Equal(t, []corev1alpha1.ObjectSetTemplatePhase{
 		{
@@ -82,8 +85,11 @@ func TestLoader(t *testing.T) {
 						Object: map[string]interface{}{
 							"apiVersion": "v1",
 							"kind":       "ConfigMap",
-							"metadata":   map[string]interface{}{"name": "some-configmap"},
-							"data":       map[string]interface{}{"foo": "bar", "hello": "world"},
+							"metadata": map[string]interface{}{
+								"name":        "some-configmap",
+								"annotations": map[string]interface{}{corev1alpha1.ObjectSourceFileAnnotation: "subdir/certain-configmap.yaml#0"},
+							},
+							"data": map[string]interface{}{"foo": "bar", "hello": "world"},
 						},
 					},
 				},
@@ -92,7 +98,10 @@ func TestLoader(t *testing.T) {
 						Object: map[string]interface{}{
 							"apiVersion": "v1",
 							"kind":       "ServiceAccount",
-							"metadata":   map[string]interface{}{"name": "some-service-account"},
+							"metadata": map[string]interface{}{
+								"name":        "some-service-account",
+								"annotations": map[string]interface{}{corev1alpha1.ObjectSourceFileAnnotation: "subdir/some-serviceaccount.yaml#0"},
+							},
 						},
 					},
 				},
@@ -106,8 +115,12 @@ func TestLoader(t *testing.T) {
 						Object: map[string]interface{}{
 							"apiVersion": "apps/v1",
 							"kind":       "Deployment",
-							"metadata":   map[string]interface{}{"name": "controller-manager", "namespace": "test123-ns"},
-							"spec":       map[string]interface{}{"replicas": int64(1)},
+							"metadata": map[string]interface{}{
+								"name":        "controller-manager",
+								"namespace":   "test123-ns",
+								"annotations": map[string]interface{}{corev1alpha1.ObjectSourceFileAnnotation: "deployment.yml#0"},
+							},
+							"spec": map[string]interface{}{"replicas": int64(1)},
 						},
 					},
 				},
@@ -116,8 +129,11 @@ func TestLoader(t *testing.T) {
 						Object: map[string]interface{}{
 							"apiVersion": "apps/v1",
 							"kind":       "StatefulSet",
-							"metadata":   map[string]interface{}{"name": "some-stateful-set-1"},
-							"spec":       map[string]interface{}{},
+							"metadata": map[string]interface{}{
+								"name":        "some-stateful-set-1",
+								"annotations": map[string]interface{}{corev1alpha1.ObjectSourceFileAnnotation: "some-statefulset.yaml#0"},
+							},
+							"spec": map[string]interface{}{},
 						},
 					},
 				},
@@ -225,6 +241,105 @@ func TestTemplateTestValidator(t *testing.T) {
 	require.Equal(t, expectedErr, err.Error())
 }
 
+func TestTemplateSnapshotValidator(t *testing.T) {
+	t.Parallel()
+
+	pc := &packagecontent.Package{PackageManifest: &manifestsv1alpha1.PackageManifest{
+		Test: manifestsv1alpha1.PackageManifestTest{
+			Template: []manifestsv1alpha1.PackageManifestTestCaseTemplate{
+				{
+					Name: "t1",
+					Context: manifestsv1alpha1.TemplateContext{
+						Package: manifestsv1alpha1.TemplateContextPackage{
+							TemplateContextObjectMeta: manifestsv1alpha1.
+								TemplateContextObjectMeta{
+								Name:      "pkg-name",
+								Namespace: "pkg-namespace",
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	snapshot1 := strings.ReplaceAll(testFile1Content, "{{.package.metadata.name}}", "pkg-name")
+	snapshot2 := strings.ReplaceAll(testFile2Content, "{{.package.metadata.namespace}}", "pkg-namespace")
+
+	tests := []struct {
+		name        string
+		files       packagecontent.Files
+		expectedErr string
+	}{
+		{
+			name: "no snapshots",
+			files: packagecontent.Files{
+				"manifest.yaml":     []byte(testPackageManifestContent),
+				"file.yaml.gotmpl":  []byte(testFile1Content),
+				"file2.yaml.gotmpl": []byte(testFile2Content),
+			},
+		},
+		{
+			name: "matching snapshots",
+			files: packagecontent.Files{
+				"manifest.yaml":        []byte(testPackageManifestContent),
+				"file.yaml.gotmpl":     []byte(testFile1Content),
+				"file2.yaml.gotmpl":    []byte(testFile2Content),
+				"test/t1/file.yaml":    []byte(snapshot1),
+				"test/t1/file2.yaml":   []byte(snapshot2),
+				"test/other/file.yaml": []byte("ignored"),
+			},
+		},
+		{
+			name: "mismatch",
+			files: packagecontent.Files{
+				"manifest.yaml":      []byte(testPackageManifestContent),
+				"file.yaml.gotmpl":   []byte(testFile1UpdatedContent),
+				"file2.yaml.gotmpl":  []byte(testFile2Content),
+				"test/t1/file.yaml":  []byte(snapshot1),
+				"test/t1/file2.yaml": []byte(snapshot2),
+			},
+			expectedErr: `Package validation errors:
+- Test "t1": File mismatch against test snapshot in file.yaml.gotmpl:
+  --- SNAPSHOT/file.yaml
+  +++ ACTUAL/file.yaml
+  @@ -4,4 +4,4 @@
+     name: testfile1
+     annotations:
+       package-operator.run/phase: tesxx
+  -property: pkg-name
+  +property: pkg-namexxx`,
+		},
+		{
+			name: "missing snapshot",
+			files: packagecontent.Files{
+				"manifest.yaml":     []byte(testPackageManifestContent),
+				"file.yaml.gotmpl":  []byte(testFile1Content),
+				"file2.yaml.gotmpl": []byte(testFile2Content),
+				"test/t1/file.yaml": []byte(snapshot1),
+			},
+			expectedErr: `Package validation errors:
+- Test "t1": Test snapshot missing in file2.yaml.gotmpl`,
+		},
+	}
+
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := logr.NewContext(context.Background(), testr.New(t))
+			tsv := packageloader.NewTemplateSnapshotValidator(testScheme)
+			err := tsv.ValidatePackageAndFiles(ctx, pc, test.files)
+			if len(test.expectedErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
+
 func TestCommonObjectLabelsTransformer(t *testing.T) {
 	t.Parallel()
 
@@ -251,6 +366,274 @@ func TestCommonObjectLabelsTransformer(t *testing.T) {
 	}, obj.GetLabels())
 }
 
+func TestInstanceLabelTransformer(t *testing.T) {
+	t.Parallel()
+
+	newPackageContent := func() *packagecontent.Package {
+		deployment := unstructured.Unstructured{}
+		deployment.SetAPIVersion("apps/v1")
+		deployment.SetKind("Deployment")
+		return &packagecontent.Package{
+			PackageManifest: &manifestsv1alpha1.PackageManifest{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-cool-pkg"},
+			},
+			Objects: map[string][]unstructured.Unstructured{
+				"test.yaml": {deployment},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		template       string
+		expectedLabels map[string]string
+	}{
+		{
+			name:     "instanceName not used",
+			template: "test",
+		},
+		{
+			name:     "instanceName used",
+			template: `{{instanceName "test"}}`,
+			expectedLabels: map[string]string{
+				manifestsv1alpha1.PackageInstanceLabel: "sepp",
+				manifestsv1alpha1.PackageLabel:         "my-cool-pkg",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt, err := packageloader.NewTemplateTransformer(
+				packageloader.PackageFileTemplateContext{
+					Package: manifestsv1alpha1.TemplateContextPackage{
+						TemplateContextObjectMeta: manifestsv1alpha1.TemplateContextObjectMeta{Name: "sepp"},
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			err = tt.TransformPackageFiles(ctx, packagecontent.Files{
+				"test.yaml.gotmpl": []byte(test.template),
+			})
+			require.NoError(t, err)
+
+			ilt := &packageloader.InstanceLabelTransformer{
+				PackageName: "sepp",
+				Templates:   tt,
+			}
+			packageContent := newPackageContent()
+			require.NoError(t, ilt.TransformPackage(ctx, packageContent))
+
+			podLabels, _, err := unstructured.NestedStringMap(
+				packageContent.Objects["test.yaml"][0].Object,
+				"spec", "template", "metadata", "labels")
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedLabels, podLabels)
+		})
+	}
+}
+
+func TestProxyTransformer(t *testing.T) {
+	t.Parallel()
+
+	newDeployment := func(name string, annotations map[string]string) unstructured.Unstructured {
+		deployment := unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "manager"},
+						},
+					},
+				},
+			},
+		}}
+		deployment.SetAPIVersion("apps/v1")
+		deployment.SetKind("Deployment")
+		deployment.SetName(name)
+		deployment.SetNamespace("test")
+		deployment.SetAnnotations(annotations)
+		return deployment
+	}
+
+	packageContent := &packagecontent.Package{
+		PackageManifest: &manifestsv1alpha1.PackageManifest{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cool-pkg"},
+		},
+		Objects: map[string][]unstructured.Unstructured{
+			"test.yaml": {
+				newDeployment("opt-in", map[string]string{
+					manifestsv1alpha1.PackagePhaseAnnotation:       "deploy",
+					manifestsv1alpha1.PackageInjectProxyAnnotation: "True",
+				}),
+				newDeployment("opt-out", map[string]string{
+					manifestsv1alpha1.PackagePhaseAnnotation: "deploy",
+				}),
+			},
+		},
+	}
+
+	pt := &packageloader.ProxyTransformer{
+		PackageName: "sepp",
+		Proxy: &manifestsv1alpha1.PackageEnvironmentProxy{
+			HTTPProxy:       "http://proxy:3128",
+			NoProxy:         ".cluster.local",
+			TrustedCABundle: "---CERT---",
+		},
+	}
+	require.NoError(t, pt.TransformPackage(context.Background(), packageContent))
+
+	objects := packageContent.Objects["test.yaml"]
+	require.Len(t, objects, 3)
+
+	containers, _, err := unstructured.NestedSlice(objects[0].Object, "spec", "template", "spec", "containers")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "HTTP_PROXY", "value": "http://proxy:3128"},
+		map[string]interface{}{"name": "NO_PROXY", "value": ".cluster.local"},
+	}, containers[0].(map[string]interface{})["env"])
+	volumes, _, err := unstructured.NestedSlice(objects[0].Object, "spec", "template", "spec", "volumes")
+	require.NoError(t, err)
+	assert.Len(t, volumes, 1)
+
+	_, found, _ := unstructured.NestedFieldNoCopy(objects[1].Object, "spec", "template", "spec", "containers", "env")
+	assert.False(t, found)
+
+	caBundle := objects[2]
+	assert.Equal(t, "ConfigMap", caBundle.GetKind())
+	assert.Equal(t, "opt-in-trusted-ca", caBundle.GetName())
+	assert.Equal(t, "test", caBundle.GetNamespace())
+	assert.Equal(t, "deploy", caBundle.GetAnnotations()[manifestsv1alpha1.PackagePhaseAnnotation])
+	data, _, _ := unstructured.NestedStringMap(caBundle.Object, "data")
+	assert.Equal(t, map[string]string{"ca-bundle.crt": "---CERT---"}, data)
+}
+
+func TestPatchTransformer(t *testing.T) {
+	t.Parallel()
+
+	newPackage := func() *packagecontent.Package {
+		deployment := unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"replicas": int64(1),
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "manager", "image": "manager:v1"},
+							map[string]interface{}{"name": "proxy", "image": "proxy:v1"},
+						},
+					},
+				},
+			},
+		}}
+		deployment.SetAPIVersion("apps/v1")
+		deployment.SetKind("Deployment")
+		deployment.SetName("operator")
+		deployment.SetNamespace("test")
+
+		custom := unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"size": "small", "tier": "free"},
+		}}
+		custom.SetAPIVersion("example.com/v1")
+		custom.SetKind("Database")
+		custom.SetName("db")
+		custom.SetNamespace("test")
+
+		return &packagecontent.Package{
+			PackageManifest: &manifestsv1alpha1.PackageManifest{},
+			Objects: map[string][]unstructured.Unstructured{
+				"test.yaml": {deployment, custom},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		patch  corev1alpha1.PackageObjectPatch
+		path   []string
+		expect interface{}
+		err    error
+	}{
+		{
+			name: "JSON6902",
+			patch: corev1alpha1.PackageObjectPatch{
+				Target: corev1alpha1.PackageObjectPatchTarget{Group: "apps", Kind: "Deployment", Name: "operator"},
+				Patch:  "- op: replace\n  path: /spec/replicas\n  value: 3",
+			},
+			path:   []string{"spec", "replicas"},
+			expect: int64(3),
+		},
+		{
+			name: "StrategicMerge",
+			patch: corev1alpha1.PackageObjectPatch{
+				Target: corev1alpha1.PackageObjectPatchTarget{
+					Group: "apps", Kind: "Deployment", Name: "operator", Namespace: "test",
+				},
+				Type:  corev1alpha1.PackageObjectPatchTypeStrategicMerge,
+				Patch: `{"spec": {"template": {"spec": {"containers": [{"name": "proxy", "image": "proxy:v2"}]}}}}`,
+			},
+			path: []string{"spec", "template", "spec", "containers"},
+			expect: []interface{}{
+				map[string]interface{}{"name": "manager", "image": "manager:v1"},
+				map[string]interface{}{"name": "proxy", "image": "proxy:v2"},
+			},
+		},
+		{
+			name: "StrategicMerge of unknown kind",
+			patch: corev1alpha1.PackageObjectPatch{
+				Target: corev1alpha1.PackageObjectPatchTarget{Group: "example.com", Kind: "Database", Name: "db"},
+				Type:   corev1alpha1.PackageObjectPatchTypeStrategicMerge,
+				Patch:  "spec:\n  size: large",
+			},
+			path:   []string{"spec"},
+			expect: map[string]interface{}{"size": "large", "tier": "free"},
+		},
+		{
+			name: "no target",
+			patch: corev1alpha1.PackageObjectPatch{
+				Target: corev1alpha1.PackageObjectPatchTarget{
+					Group: "apps", Kind: "Deployment", Name: "operator", Namespace: "other",
+				},
+				Patch: "[]",
+			},
+			err: packageloader.ErrPatchTargetNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			packageContent := newPackage()
+			pt := &packageloader.PatchTransformer{
+				Scheme:  clientgoscheme.Scheme,
+				Patches: []corev1alpha1.PackageObjectPatch{test.patch},
+			}
+			err := pt.TransformPackage(context.Background(), packageContent)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			var patched unstructured.Unstructured
+			for _, obj := range packageContent.Objects["test.yaml"] {
+				if obj.GetName() == test.patch.Target.Name {
+					patched = obj
+				}
+			}
+			actual, _, err := unstructured.NestedFieldNoCopy(patched.Object, test.path...)
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, actual)
+		})
+	}
+}
+
 func TestTemplateTransformer(t *testing.T) {
 	t.Parallel()
 	t.Run("success", func(t *testing.T) {
@@ -284,6 +667,65 @@ func TestTemplateTransformer(t *testing.T) {
 		assert.Equal(t, string(template), string(fm["something"]))
 	})
 
+	t.Run("instanceName", func(t *testing.T) {
+		t.Parallel()
+
+		tt, err := packageloader.NewTemplateTransformer(
+			packageloader.PackageFileTemplateContext{
+				Package: manifestsv1alpha1.TemplateContextPackage{
+					TemplateContextObjectMeta: manifestsv1alpha1.TemplateContextObjectMeta{Name: "test"},
+				},
+			},
+		)
+		require.NoError(t, err)
+		assert.False(t, tt.UsesInstanceName())
+
+		longName := strings.Repeat("a", 60)
+		fm := packagecontent.Files{
+			"test.yaml.gotmpl": []byte(`{{instanceName "my-app"}}#{{instanceName "` + longName + `"}}`),
+		}
+
+		ctx := context.Background()
+		err = tt.TransformPackageFiles(ctx, fm)
+		require.NoError(t, err)
+
+		names := strings.Split(string(fm["test.yaml"]), "#")
+		assert.Equal(t, "my-app-test", names[0])
+		assert.Len(t, names[1], 63)
+		assert.True(t, strings.HasPrefix(names[1], strings.Repeat("a", 54)+"-"))
+		assert.True(t, tt.UsesInstanceName())
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		t.Parallel()
+
+		tt, err := packageloader.NewTemplateTransformer(
+			packageloader.PackageFileTemplateContext{
+				Package: manifestsv1alpha1.TemplateContextPackage{
+					TemplateContextObjectMeta: manifestsv1alpha1.TemplateContextObjectMeta{Name: "test"},
+				},
+			},
+			packageloader.WithLookup(func(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+				if apiVersion != "storage.k8s.io/v1" || kind != "StorageClass" || name != "gp3" {
+					return map[string]interface{}{}, nil
+				}
+				return map[string]interface{}{"provisioner": "ebs.csi.aws.com"}, nil
+			}),
+		)
+		require.NoError(t, err)
+
+		fm := packagecontent.Files{
+			"test.yaml.gotmpl": []byte(
+				`{{ with lookup "storage.k8s.io/v1" "StorageClass" "" "gp3" }}{{ .provisioner }}{{ end }}#` +
+					`{{ with lookup "storage.k8s.io/v1" "StorageClass" "" "gp2" }}{{ .provisioner }}{{ end }}`),
+		}
+
+		ctx := context.Background()
+		err = tt.TransformPackageFiles(ctx, fm)
+		require.NoError(t, err)
+		assert.Equal(t, "ebs.csi.aws.com#", string(fm["test.yaml"]))
+	})
+
 	t.Run("invalid template", func(t *testing.T) {
 		t.Parallel()
 		tt, err := packageloader.NewTemplateTransformer(
@@ -406,6 +848,33 @@ func TestObjectLabelsValidator(t *testing.T) {
 	require.EqualError(t, err, errString)
 }
 
+func TestObjectMappingValidator(t *testing.T) {
+	t.Parallel()
+
+	omv := &packageloader.ObjectMappingValidator{}
+
+	okObj := unstructured.Unstructured{}
+	okObj.SetAnnotations(map[string]string{
+		manifestsv1alpha1.PackageFieldMapAnnotation: "metadata.name => status.name",
+	})
+	failObj := unstructured.Unstructured{}
+	failObj.SetAnnotations(map[string]string{
+		manifestsv1alpha1.PackageFieldMapAnnotation: "metadata.name",
+	})
+
+	packageContent := &packagecontent.Package{
+		Objects: map[string][]unstructured.Unstructured{
+			"test.yaml": {okObj, failObj},
+		},
+	}
+	ctx := context.Background()
+	err := omv.ValidatePackage(ctx, packageContent)
+	errString := `Package validation errors:
+- Invalid package-operator.run/field-map Annotation in test.yaml#1:
+  expected 2 part mapping got 1 in line 1`
+	require.EqualError(t, err, errString)
+}
+
 func TestPackageScopeValidator(t *testing.T) {
 	t.Parallel()
 
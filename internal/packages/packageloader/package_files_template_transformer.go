@@ -3,8 +3,12 @@ package packageloader
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"text/template"
 
 	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
 	"package-operator.run/package-operator/internal/packages"
@@ -16,7 +20,11 @@ var _ FilesTransformer = (*PackageFileTemplateTransformer)(nil)
 
 // Runs a go-template transformer on all .yml or .yaml files.
 type PackageFileTemplateTransformer struct {
-	tctx map[string]interface{}
+	tctx   map[string]interface{}
+	funcs  template.FuncMap
+	lookup transform.LookupFunc
+	// true, if any template called instanceName.
+	instanceNameUsed bool
 }
 
 func workaroundnovalue(actualCtx map[string]interface{}) {
@@ -41,7 +49,20 @@ type PackageFileTemplateContext struct {
 	Environment manifestsv1alpha1.PackageEnvironment     `json:"environment"`
 }
 
-func NewTemplateTransformer(tmplCtx PackageFileTemplateContext) (*PackageFileTemplateTransformer, error) {
+// TemplateTransformerOption configures a PackageFileTemplateTransformer.
+type TemplateTransformerOption func(t *PackageFileTemplateTransformer)
+
+// WithLookup makes objects of the cluster available to templates via the lookup function.
+// Without it, lookups return an empty map.
+func WithLookup(lookup transform.LookupFunc) TemplateTransformerOption {
+	return func(t *PackageFileTemplateTransformer) {
+		t.lookup = lookup
+	}
+}
+
+func NewTemplateTransformer(
+	tmplCtx PackageFileTemplateContext, opts ...TemplateTransformerOption,
+) (*PackageFileTemplateTransformer, error) {
 	p, err := json.Marshal(tmplCtx)
 	if err != nil {
 		return nil, err
@@ -54,16 +75,56 @@ func NewTemplateTransformer(tmplCtx PackageFileTemplateContext) (*PackageFileTem
 
 	workaroundnovalue(actualCtx)
 
-	return &PackageFileTemplateTransformer{actualCtx}, nil
+	t := &PackageFileTemplateTransformer{tctx: actualCtx}
+	for _, opt := range opts {
+		opt(t)
+	}
+	instanceName := instanceNameFunc(tmplCtx.Package.Name)
+	t.funcs = template.FuncMap{
+		"instanceName": func(name string) string {
+			t.instanceNameUsed = true
+			return instanceName(name)
+		},
+	}
+	return t, nil
+}
+
+// UsesInstanceName returns true, if the package templates
+// are suffixing object names with the Package instance name.
+func (t *PackageFileTemplateTransformer) UsesInstanceName() bool {
+	return t.instanceNameUsed
+}
+
+// Maximum length of names returned by instanceName.
+// Most object names have to be valid DNS labels, e.g. for Services.
+const maxInstanceNameLength = 63
+
+// instanceNameFunc returns a template function suffixing names with the name of the Package instance,
+// so the same package can be installed multiple times into the same namespace:
+// {{ instanceName "my-app" }} renders to "my-app-<package name>".
+// Names exceeding 63 characters are truncated and suffixed with a hash to stay unique.
+func instanceNameFunc(instance string) func(name string) string {
+	return func(name string) string {
+		instanceName := name + "-" + instance
+		if len(instanceName) <= maxInstanceNameLength {
+			return instanceName
+		}
+
+		sum := sha256.Sum256([]byte(instanceName))
+		hash := hex.EncodeToString(sum[:])[:8]
+		prefix := strings.TrimRight(instanceName[:maxInstanceNameLength-len(hash)-1], "-.")
+		return prefix + "-" + hash
+	}
 }
 
 func (t *PackageFileTemplateTransformer) transform(_ context.Context, path string, content []byte) ([]byte, error) {
-	if !packages.IsTemplateFile(path) {
-		// Not a template file, skip.
+	if !packages.IsTemplateFile(path) || packages.IsPackageTestFile(path) || packages.IsComponentFile(path) {
+		// Not a template file, a test snapshot or part of a component, skip.
 		return content, nil
 	}
 
-	template, err := transform.TemplateWithSprigFuncs(string(content))
+	template, err := transform.TemplateWithSprigFuncs(
+		string(content), t.funcs, transform.LookupFuncs(t.lookup))
 	if err != nil {
 		return nil, fmt.Errorf(
 			"parsing template from %s: %w", path, err)
@@ -0,0 +1,117 @@
+package packageloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+var _ Transformer = (*PatchTransformer)(nil)
+
+var ErrPatchTargetNotFound = errors.New("no object of the package matches the patch target")
+
+// PatchTransformer applies the patches requested by a Package to the objects of the package.
+// Every patch has to match at least one object,
+// so patches no longer applying to a new version of the package are not silently dropped.
+type PatchTransformer struct {
+	// Scheme to look up strategic merge patch metadata of well known kinds.
+	Scheme  *runtime.Scheme
+	Patches []corev1alpha1.PackageObjectPatch
+}
+
+func (t *PatchTransformer) TransformPackage(ctx context.Context, packageContent *packagecontent.Package) error {
+	matched := make([]bool, len(t.Patches))
+	if err := TransformEachObject(ctx, packageContent, func(
+		_ context.Context, path string, _ int, _ *manifestsv1alpha1.PackageManifest, obj *unstructured.Unstructured,
+	) error {
+		for i, patch := range t.Patches {
+			if !patchTargetMatches(patch.Target, obj) {
+				continue
+			}
+			matched[i] = true
+			if err := t.apply(obj, patch); err != nil {
+				return fmt.Errorf("spec.patches[%d] on %s %s in %s: %w", i, obj.GetKind(), obj.GetName(), path, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for i, ok := range matched {
+		if !ok {
+			target := t.Patches[i].Target
+			return fmt.Errorf("spec.patches[%d] targeting %s %s: %w", i, target.Kind, target.Name, ErrPatchTargetNotFound)
+		}
+	}
+	return nil
+}
+
+func patchTargetMatches(target corev1alpha1.PackageObjectPatchTarget, obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	return gvk.Group == target.Group &&
+		gvk.Kind == target.Kind &&
+		obj.GetName() == target.Name &&
+		(len(target.Namespace) == 0 || obj.GetNamespace() == target.Namespace)
+}
+
+func (t *PatchTransformer) apply(obj *unstructured.Unstructured, patch corev1alpha1.PackageObjectPatch) error {
+	patchJSON, err := yaml.YAMLToJSON([]byte(patch.Patch))
+	if err != nil {
+		return fmt.Errorf("parsing patch: %w", err)
+	}
+	original, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	switch patch.Type {
+	case corev1alpha1.PackageObjectPatchTypeJSON6902, "":
+		p, err := jsonpatch.DecodePatch(patchJSON)
+		if err != nil {
+			return fmt.Errorf("decoding JSON patch: %w", err)
+		}
+		if patched, err = p.Apply(original); err != nil {
+			return fmt.Errorf("applying JSON patch: %w", err)
+		}
+
+	case corev1alpha1.PackageObjectPatchTypeStrategicMerge:
+		if patched, err = t.strategicMerge(obj, original, patchJSON); err != nil {
+			return fmt.Errorf("applying strategic merge patch: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("unsupported patch type %q", patch.Type)
+	}
+
+	result := &unstructured.Unstructured{}
+	if err := result.UnmarshalJSON(patched); err != nil {
+		return fmt.Errorf("decoding patched object: %w", err)
+	}
+	obj.Object = result.Object
+	return nil
+}
+
+// Kinds without strategic merge patch metadata in the scheme, e.g. custom resources,
+// are merged as JSON merge patch, same as kubectl does.
+func (t *PatchTransformer) strategicMerge(obj *unstructured.Unstructured, original, patch []byte) ([]byte, error) {
+	dataStruct, err := t.Scheme.New(obj.GroupVersionKind())
+	if runtime.IsNotRegisteredError(err) {
+		return jsonpatch.MergePatch(original, patch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return strategicpatch.StrategicMergePatch(original, patch, dataStruct)
+}
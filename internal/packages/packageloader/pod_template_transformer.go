@@ -0,0 +1,62 @@
+package packageloader
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+var (
+	_ Transformer = (*PodTemplateMetadataTransformer)(nil)
+	_ Transformer = (*InstanceLabelTransformer)(nil)
+)
+
+// PodTemplateMetadataTransformer adds the pod template metadata requested by a Package
+// to the pod templates of all workloads of the package.
+type PodTemplateMetadataTransformer struct {
+	PackageName string
+	Metadata    corev1alpha1.PackagePodTemplateMetadata
+}
+
+func (t *PodTemplateMetadataTransformer) TransformPackage(ctx context.Context, packageContent *packagecontent.Package) error {
+	return TransformEachObject(ctx, packageContent, t.transform)
+}
+
+func (t *PodTemplateMetadataTransformer) transform(
+	_ context.Context, _ string, _ int, packageManifest *manifestsv1alpha1.PackageManifest, obj *unstructured.Unstructured,
+) error {
+	podLabels := t.Metadata.Labels
+	if t.Metadata.PackageLabels {
+		podLabels = labels.Merge(podLabels, commonLabels(packageManifest, t.PackageName))
+	}
+	packagecontent.MergePodTemplateMetadata(obj, podLabels, t.Metadata.Annotations)
+	return nil
+}
+
+// InstanceLabelTransformer adds the package labels to the pod templates of all workloads,
+// if the package templates are suffixing object names with the Package instance name.
+// Allows label selectors of packages installed multiple times into the same namespace
+// to tell the pods of each instance apart.
+type InstanceLabelTransformer struct {
+	PackageName string
+	Templates   *PackageFileTemplateTransformer
+}
+
+func (t *InstanceLabelTransformer) TransformPackage(ctx context.Context, packageContent *packagecontent.Package) error {
+	if !t.Templates.UsesInstanceName() {
+		return nil
+	}
+	return TransformEachObject(ctx, packageContent, t.transform)
+}
+
+func (t *InstanceLabelTransformer) transform(
+	_ context.Context, _ string, _ int, packageManifest *manifestsv1alpha1.PackageManifest, obj *unstructured.Unstructured,
+) error {
+	packagecontent.MergePodTemplateMetadata(obj, commonLabels(packageManifest, t.PackageName), nil)
+	return nil
+}
@@ -0,0 +1,90 @@
+package packageloader
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+var _ Transformer = (*ProxyTransformer)(nil)
+
+const (
+	proxyTrustedCAVolume    = "trusted-ca-bundle"
+	proxyTrustedCAMountPath = "/etc/pki/ca-trust/extracted/pem"
+	proxyTrustedCAKey       = "ca-bundle.crt"
+	proxyTrustedCAFile      = "tls-ca-bundle.pem"
+)
+
+// ProxyTransformer injects the cluster-wide proxy configuration into all workloads
+// opting in via the package-operator.run/inject-proxy annotation.
+// If a trusted CA bundle is configured, it is added as ConfigMap next to each workload and mounted into all containers.
+type ProxyTransformer struct {
+	PackageName string
+	Proxy       *manifestsv1alpha1.PackageEnvironmentProxy
+}
+
+func (t *ProxyTransformer) TransformPackage(_ context.Context, packageContent *packagecontent.Package) error {
+	if t.Proxy == nil {
+		return nil
+	}
+
+	env := t.env()
+	for path, objects := range packageContent.Objects {
+		for i := range objects {
+			obj := &packageContent.Objects[path][i]
+			if !strings.EqualFold(obj.GetAnnotations()[manifestsv1alpha1.PackageInjectProxyAnnotation], "True") {
+				continue
+			}
+			if !packagecontent.MergePodTemplateEnv(obj, env) || len(t.Proxy.TrustedCABundle) == 0 {
+				continue
+			}
+
+			caBundle := t.trustedCAConfigMap(packageContent.PackageManifest, obj)
+			packagecontent.AddPodTemplateConfigMapVolume(obj, proxyTrustedCAVolume, corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: caBundle.GetName()},
+				Items:                []corev1.KeyToPath{{Key: proxyTrustedCAKey, Path: proxyTrustedCAFile}},
+			}, corev1.VolumeMount{MountPath: proxyTrustedCAMountPath, ReadOnly: true})
+			packageContent.Objects[path] = append(packageContent.Objects[path], caBundle)
+		}
+	}
+	return nil
+}
+
+func (t *ProxyTransformer) env() []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, v := range []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: t.Proxy.HTTPProxy},
+		{Name: "HTTPS_PROXY", Value: t.Proxy.HTTPSProxy},
+		{Name: "NO_PROXY", Value: t.Proxy.NoProxy},
+	} {
+		if len(v.Value) > 0 {
+			env = append(env, v)
+		}
+	}
+	return env
+}
+
+// ConfigMap holding the trusted CA bundle for the given workload, rolled out in the same phase.
+func (t *ProxyTransformer) trustedCAConfigMap(
+	packageManifest *manifestsv1alpha1.PackageManifest, workload *unstructured.Unstructured,
+) unstructured.Unstructured {
+	caBundle := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"data": map[string]interface{}{
+			proxyTrustedCAKey: t.Proxy.TrustedCABundle,
+		},
+	}}
+	caBundle.SetName(workload.GetName() + "-trusted-ca")
+	caBundle.SetNamespace(workload.GetNamespace())
+	caBundle.SetLabels(commonLabels(packageManifest, t.PackageName))
+	caBundle.SetAnnotations(map[string]string{
+		manifestsv1alpha1.PackagePhaseAnnotation: workload.GetAnnotations()[manifestsv1alpha1.PackagePhaseAnnotation],
+	})
+	return caBundle
+}
@@ -23,6 +23,7 @@ type (
 	ObjectDuplicateValidator       struct{}
 	ObjectGVKValidator             struct{}
 	ObjectLabelsValidator          struct{}
+	ObjectMappingValidator         struct{}
 	PackageScopeValidator          manifestsv1alpha1.PackageManifestScope
 )
 
@@ -32,6 +33,7 @@ var (
 	_ Validator = (*ObjectDuplicateValidator)(nil)
 	_ Validator = (*ObjectGVKValidator)(nil)
 	_ Validator = (*ObjectLabelsValidator)(nil)
+	_ Validator = (*ObjectMappingValidator)(nil)
 	_ Validator = (PackageScopeValidator)("")
 )
 
@@ -143,6 +145,34 @@ func (*ObjectLabelsValidator) validate(_ context.Context, path string, index int
 	return nil
 }
 
+func (v *ObjectMappingValidator) ValidatePackage(ctx context.Context, packageContent *packagecontent.Package) error {
+	return ValidateEachObject(ctx, packageContent, v.validate)
+}
+
+// Condition and field map annotations are parsed when building the ObjectSetTemplateSpec.
+func (*ObjectMappingValidator) validate(_ context.Context, path string, index int, obj unstructured.Unstructured) error {
+	location := &packages.ViolationLocation{
+		Path:          path,
+		DocumentIndex: pointer.Int(index),
+	}
+	var errors []error
+	if _, err := packagecontent.ParseConditionMapAnnotation(&obj); err != nil {
+		errors = append(errors, packages.NewInvalidError(packages.Violation{
+			Reason:   packages.ViolationReasonInvalidConditionMap,
+			Details:  err.Error(),
+			Location: location,
+		}))
+	}
+	if _, err := packagecontent.ParseFieldMapAnnotation(&obj); err != nil {
+		errors = append(errors, packages.NewInvalidError(packages.Violation{
+			Reason:   packages.ViolationReasonInvalidFieldMap,
+			Details:  err.Error(),
+			Location: location,
+		}))
+	}
+	return packages.NewInvalidAggregate(errors...)
+}
+
 func (scope PackageScopeValidator) ValidatePackage(_ context.Context, packageContent *packagecontent.Package) error {
 	if !slices.Contains(packageContent.PackageManifest.Spec.Scopes, manifestsv1alpha1.PackageManifestScope(scope)) {
 		// Package does not support installation in this scope.
@@ -59,39 +59,11 @@ func (v TemplateTestValidator) runTestCase(
 	testCase manifestsv1alpha1.PackageManifestTestCaseTemplate,
 ) error {
 	log := logr.FromContextOrDiscard(ctx)
-	fileMap = maps.Clone(fileMap)
-
-	configuration := map[string]interface{}{}
-	if testCase.Context.Config != nil {
-		if err := json.Unmarshal(testCase.Context.Config.Raw, &configuration); err != nil {
-			return err
-		}
-	}
-
-	if _, err := packageadmission.AdmitPackageConfiguration(ctx, v.scheme, configuration, manifest, nil); err != nil {
-		return err
-	}
-
-	tt, err := NewTemplateTransformer(PackageFileTemplateContext{
-		Package:     testCase.Context.Package,
-		Config:      configuration,
-		Images:      utils.GenerateStaticImages(manifest),
-		Environment: testCase.Context.Environment,
-	})
+	fileMap, err := renderTemplateTestCase(ctx, v.scheme, fileMap, manifest, testCase)
 	if err != nil {
 		return err
 	}
 
-	if err := tt.TransformPackageFiles(ctx, fileMap); err != nil {
-		return err
-	}
-
-	// test-load templated files
-	loader := New(v.scheme, WithDefaults)
-	if _, err = loader.FromFiles(ctx, fileMap); err != nil {
-		return fmt.Errorf("loading package from files: %w", err)
-	}
-
 	// check if test figures exist
 	testFixturePath := filepath.Join(v.fixturesFolderPath, testCase.Name)
 	_, err = os.Stat(testFixturePath)
@@ -148,6 +120,48 @@ func (v TemplateTestValidator) runTestCase(
 	return nil
 }
 
+// renderTemplateTestCase templates a copy of the given files using the context of the test case
+// and ensures that the result can be loaded as a package.
+func renderTemplateTestCase(
+	ctx context.Context, scheme *runtime.Scheme, fileMap packagecontent.Files,
+	manifest *manifestsv1alpha1.PackageManifest,
+	testCase manifestsv1alpha1.PackageManifestTestCaseTemplate,
+) (packagecontent.Files, error) {
+	fileMap = maps.Clone(fileMap)
+
+	configuration := map[string]interface{}{}
+	if testCase.Context.Config != nil {
+		if err := json.Unmarshal(testCase.Context.Config.Raw, &configuration); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := packageadmission.AdmitPackageConfiguration(ctx, scheme, configuration, manifest, nil); err != nil {
+		return nil, err
+	}
+
+	tt, err := NewTemplateTransformer(PackageFileTemplateContext{
+		Package:     testCase.Context.Package,
+		Config:      configuration,
+		Images:      utils.GenerateStaticImages(manifest),
+		Environment: testCase.Context.Environment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tt.TransformPackageFiles(ctx, fileMap); err != nil {
+		return nil, err
+	}
+
+	// test-load templated files
+	loader := New(scheme, WithDefaults)
+	if _, err = loader.FromFiles(ctx, fileMap); err != nil {
+		return nil, fmt.Errorf("loading package from files: %w", err)
+	}
+	return fileMap, nil
+}
+
 func renderTemplateFiles(folder string, fileMap packagecontent.Files) error {
 	for relPath := range fileMap {
 		if !packages.IsTemplateFile(relPath) {
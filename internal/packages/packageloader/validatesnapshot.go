@@ -0,0 +1,129 @@
+package packageloader
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"package-operator.run/package-operator/internal/packages"
+	"package-operator.run/package-operator/internal/packages/packagecontent"
+)
+
+// TemplateSnapshotValidator renders the template test cases of a package
+// and compares the results against the snapshots shipped within the package test folder.
+// Expected results of test case "xyz" are located at "test/xyz/<path of the rendered file>".
+// Test cases without snapshots are only checked to render into a loadable package.
+type TemplateSnapshotValidator struct {
+	scheme *runtime.Scheme
+}
+
+var _ PackageAndFilesValidator = (*TemplateSnapshotValidator)(nil)
+
+func NewTemplateSnapshotValidator(scheme *runtime.Scheme) *TemplateSnapshotValidator {
+	return &TemplateSnapshotValidator{scheme: scheme}
+}
+
+func (v TemplateSnapshotValidator) ValidatePackageAndFiles(
+	ctx context.Context, pkg *packagecontent.Package, fileMap packagecontent.Files,
+) error {
+	log := logr.FromContextOrDiscard(ctx).V(1)
+
+	for _, testCase := range pkg.PackageManifest.Test.Template {
+		log.Info("running template snapshot test case", "name", testCase.Name)
+
+		rendered, err := renderTemplateTestCase(ctx, v.scheme, fileMap, pkg.PackageManifest, testCase)
+		if err != nil {
+			return fmt.Errorf("test %q: %w", testCase.Name, err)
+		}
+
+		snapshotFolder := path.Join(packages.PackageTestFolder, testCase.Name) + "/"
+		if !hasFileWithPrefix(fileMap, snapshotFolder) {
+			continue
+		}
+
+		violations, err := compareSnapshots(testCase.Name, snapshotFolder, fileMap, rendered)
+		if err != nil {
+			return err
+		}
+		// Fail fast on the first test case with violations.
+		if len(violations) > 0 {
+			return packages.NewInvalidError(violations...)
+		}
+	}
+
+	return nil
+}
+
+func compareSnapshots(
+	testCaseName, snapshotFolder string,
+	fileMap, rendered packagecontent.Files,
+) ([]packages.Violation, error) {
+	relPaths := make([]string, 0, len(fileMap))
+	for relPath := range fileMap {
+		if !packages.IsTemplateFile(relPath) || packages.IsPackageTestFile(relPath) || packages.IsComponentFile(relPath) {
+			// only rendered template files are compared against snapshots.
+			continue
+		}
+		relPaths = append(relPaths, relPath)
+	}
+	sort.Strings(relPaths)
+
+	var violations []packages.Violation
+	for _, relPath := range relPaths {
+		renderedPath := packages.StripTemplateSuffix(relPath)
+		snapshot, ok := fileMap[snapshotFolder+renderedPath]
+		if !ok {
+			violations = append(violations, packages.Violation{
+				Reason:   fmt.Sprintf("Test %q: %s", testCaseName, packages.ViolationReasonSnapshotMissing),
+				Location: &packages.ViolationLocation{Path: relPath},
+			})
+			continue
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(snapshot),
+			B:        splitLines(rendered[renderedPath]),
+			FromFile: "SNAPSHOT/" + renderedPath,
+			ToFile:   "ACTUAL/" + renderedPath,
+			Context:  3,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(diff) == 0 {
+			continue
+		}
+
+		violations = append(violations, packages.Violation{
+			Reason:   fmt.Sprintf("Test %q: %s", testCaseName, packages.ViolationReasonSnapshotMismatch),
+			Details:  strings.TrimSpace(diff),
+			Location: &packages.ViolationLocation{Path: relPath},
+		})
+	}
+	return violations, nil
+}
+
+// splitLines splits content into lines, keeping line endings.
+// Unlike difflib.SplitLines, a trailing newline does not produce an additional empty line.
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func hasFileWithPrefix(fileMap packagecontent.Files, prefix string) bool {
+	for relPath := range fileMap {
+		if strings.HasPrefix(relPath, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -15,13 +15,33 @@ const (
 	// Default location for the PackageManifestLock file.
 	PackageManifestLockFile = "manifest.lock.yaml"
 
+	// Location of the PackageCatalog file in catalog images.
+	PackageCatalogFile = "catalog.yaml"
+
 	// Files suffix for all go template files that need pre-processing.
 	// .gotmpl is the suffix that is being used by the go language server gopls.
 	// https://go-review.googlesource.com/c/tools/+/363360/7/gopls/doc/features.md#29
 	TemplateFileSuffix = ".gotmpl"
 
+	// PackageTestFolder contains expected rendering results for the template test cases of a package.
+	// Files within this folder are never loaded as package objects.
+	PackageTestFolder = "test"
+
 	// ImageFilePrefixPath defines under which subfolder files within a package container should be located.
 	ImageFilePrefixPath = "package"
+
+	// PackageIgnoreFile lists files not to load from the package, one gitignore-style pattern per line.
+	PackageIgnoreFile = ".pkoignore"
+
+	// ComponentsFolder contains the components of a package, one folder per component.
+	// Each component is a package on its own, that can be loaded without the rest of the package.
+	ComponentsFolder = "components"
+
+	// ComponentLayerAnnotation is set on layers of package images, that only contain the files of a single component.
+	ComponentLayerAnnotation = "package-operator.run/component"
+
+	// ManifestDigestAnnotation is set on package images to the sha256 digest of the PackageManifest file.
+	ManifestDigestAnnotation = "package-operator.run/manifest-digest"
 )
 
 var (
@@ -31,6 +51,8 @@ var (
 
 	PackageManifestLockFileNames = []string{"manifest.lock.yaml"}
 	PackageManifestLockGroupKind = schema.GroupKind{Group: manifestsv1alpha1.GroupVersion.Group, Kind: "PackageManifestLock"}
+
+	PackageCatalogGroupKind = schema.GroupKind{Group: manifestsv1alpha1.GroupVersion.Group, Kind: "PackageCatalog"}
 )
 
 // Is path suffixed by .gotmpl.
@@ -39,6 +61,23 @@ func IsTemplateFile(path string) bool { return strings.HasSuffix(path, TemplateF
 // StripTemplateSuffix removes a .gotmpl suffix from a string if present.
 func StripTemplateSuffix(path string) string { return strings.TrimSuffix(path, TemplateFileSuffix) }
 
+// Is path located within the package test folder.
+func IsPackageTestFile(path string) bool { return strings.HasPrefix(path, PackageTestFolder+"/") }
+
+// Is path located within the components folder.
+// Components are only loaded when selected, never as part of the package containing them.
+func IsComponentFile(path string) bool { return strings.HasPrefix(path, ComponentsFolder+"/") }
+
+// ComponentOf returns the name of the component the path belongs to,
+// or an empty string, if the path is not located within the components folder.
+func ComponentOf(path string) string {
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) < 3 || parts[0] != ComponentsFolder {
+		return ""
+	}
+	return parts[1]
+}
+
 // Is path suffixed by .yml or .yaml.
 func IsYAMLFile(path string) bool {
 	return strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml")
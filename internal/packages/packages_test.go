@@ -55,6 +55,32 @@ func TestIsTemplateFile(t *testing.T) {
 	}
 }
 
+func TestIsPackageTestFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		out  bool
+	}{
+		{path: "test/case/deployment.yaml", out: true},
+		{path: "test/case", out: true},
+		{path: "test", out: false},
+		{path: "test.yaml", out: false},
+		{path: "tests/deployment.yaml", out: false},
+		{path: "sub/test/deployment.yaml", out: false},
+	}
+
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.path, func(t *testing.T) {
+			t.Parallel()
+
+			out := IsPackageTestFile(test.path)
+			assert.Equal(t, test.out, out)
+		})
+	}
+}
+
 func TestIsManifestFile(t *testing.T) {
 	t.Parallel()
 
@@ -79,3 +105,26 @@ func TestIsManifestFile(t *testing.T) {
 		})
 	}
 }
+
+func TestComponentOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		out  string
+	}{
+		{path: "components/api/manifest.yaml", out: "api"},
+		{path: "components/api/sub/deployment.yaml", out: "api"},
+		{path: "components/readme.md", out: ""},
+		{path: "deploy/components/api/manifest.yaml", out: ""},
+	}
+
+	for i := range tests {
+		test := tests[i]
+		t.Run(test.path, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.out, ComponentOf(test.path))
+		})
+	}
+}
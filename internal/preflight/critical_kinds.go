@@ -0,0 +1,132 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Kinds that can take down or take over a whole cluster.
+var criticalKinds = map[schema.GroupKind]struct{}{
+	{Kind: "Node"}: {},
+	{Group: "apiregistration.k8s.io", Kind: "APIService"}: {},
+}
+
+// Admission webhook configurations are only critical, when intercepting Kubernetes API groups.
+var webhookConfigurationKinds = map[schema.GroupKind]struct{}{
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}: {},
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:   {},
+}
+
+// Kubernetes API groups without the .k8s.io suffix.
+var coreAPIGroups = map[string]struct{}{
+	"": {}, "*": {}, "apps": {}, "batch": {}, "autoscaling": {}, "policy": {},
+}
+
+// Prevents cluster-critical kinds from being managed,
+// unless the owner explicitly allows them via spec.allowCriticalKinds.
+type CriticalKinds struct{}
+
+var _ checker = (*CriticalKinds)(nil)
+
+func NewCriticalKinds() *CriticalKinds {
+	return &CriticalKinds{}
+}
+
+func (p *CriticalKinds) Check(
+	ctx context.Context, owner,
+	obj client.Object,
+) (violations []Violation, err error) {
+	defer addPositionToViolations(ctx, obj, &violations)
+
+	if allowsCriticalKinds(owner) {
+		return
+	}
+
+	critical, err := isCriticalObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	if critical {
+		violations = append(violations, Violation{
+			Error: "Cluster-critical kind, requires spec.allowCriticalKinds.",
+		})
+	}
+	return
+}
+
+// IsCriticalObject returns true if the object is of a cluster-critical kind.
+func IsCriticalObject(obj *unstructured.Unstructured) bool {
+	gk := obj.GroupVersionKind().GroupKind()
+	if _, ok := criticalKinds[gk]; ok {
+		return true
+	}
+	if _, ok := webhookConfigurationKinds[gk]; ok {
+		return interceptsCoreAPIGroups(obj)
+	}
+	return false
+}
+
+func isCriticalObject(obj client.Object) (bool, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return IsCriticalObject(u), nil
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return false, fmt.Errorf("converting to unstructured: %w", err)
+	}
+	return IsCriticalObject(&unstructured.Unstructured{Object: u}), nil
+}
+
+func interceptsCoreAPIGroups(webhookConfiguration *unstructured.Unstructured) bool {
+	webhooks, _, _ := unstructured.NestedSlice(webhookConfiguration.Object, "webhooks")
+	for _, webhook := range webhooks {
+		webhookObj, ok := webhook.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rules, _, _ := unstructured.NestedSlice(webhookObj, "rules")
+		for _, rule := range rules {
+			ruleObj, ok := rule.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			groups, _, _ := unstructured.NestedStringSlice(ruleObj, "apiGroups")
+			for _, group := range groups {
+				if isCoreAPIGroup(group) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func isCoreAPIGroup(group string) bool {
+	if _, ok := coreAPIGroups[group]; ok {
+		return true
+	}
+	return strings.HasSuffix(group, ".k8s.io")
+}
+
+func allowsCriticalKinds(owner client.Object) bool {
+	switch o := owner.(type) {
+	case *corev1alpha1.ObjectSet:
+		return o.Spec.AllowCriticalKinds
+	case *corev1alpha1.ClusterObjectSet:
+		return o.Spec.AllowCriticalKinds
+	case *corev1alpha1.ObjectSetPhase:
+		return o.Spec.AllowCriticalKinds
+	case *corev1alpha1.ClusterObjectSetPhase:
+		return o.Spec.AllowCriticalKinds
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package preflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func newWebhookConfiguration(apiGroups ...interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "admissionregistration.k8s.io/v1",
+		"kind":       "ValidatingWebhookConfiguration",
+		"metadata":   map[string]interface{}{"name": "test"},
+		"webhooks": []interface{}{
+			map[string]interface{}{
+				"name": "test.example.com",
+				"rules": []interface{}{
+					map[string]interface{}{"apiGroups": apiGroups},
+				},
+			},
+		},
+	}}
+}
+
+func TestIsCriticalObject(t *testing.T) {
+	t.Parallel()
+
+	node := &unstructured.Unstructured{}
+	node.SetAPIVersion("v1")
+	node.SetKind("Node")
+	assert.True(t, IsCriticalObject(node))
+
+	apiService := &unstructured.Unstructured{}
+	apiService.SetAPIVersion("apiregistration.k8s.io/v1")
+	apiService.SetKind("APIService")
+	assert.True(t, IsCriticalObject(apiService))
+
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	assert.False(t, IsCriticalObject(cm))
+
+	assert.True(t, IsCriticalObject(newWebhookConfiguration("")))
+	assert.True(t, IsCriticalObject(newWebhookConfiguration("example.com", "*")))
+	assert.True(t, IsCriticalObject(newWebhookConfiguration("rbac.authorization.k8s.io")))
+	assert.False(t, IsCriticalObject(newWebhookConfiguration("example.com")))
+}
+
+func TestCriticalKinds(t *testing.T) {
+	t.Parallel()
+
+	node := &unstructured.Unstructured{}
+	node.SetAPIVersion("v1")
+	node.SetKind("Node")
+	node.SetName("test")
+
+	tests := []struct {
+		name               string
+		owner              client.Object
+		expectedViolations []Violation
+	}{
+		{
+			name:  "not allowed",
+			owner: &corev1alpha1.ClusterObjectSet{},
+			expectedViolations: []Violation{{
+				Position: "Node /test",
+				Error:    "Cluster-critical kind, requires spec.allowCriticalKinds.",
+			}},
+		},
+		{
+			name: "allowed",
+			owner: &corev1alpha1.ClusterObjectSet{
+				Spec: corev1alpha1.ClusterObjectSetSpec{
+					ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{AllowCriticalKinds: true},
+				},
+			},
+		},
+		{
+			name: "allowed on phase",
+			owner: &corev1alpha1.ClusterObjectSetPhase{
+				Spec: corev1alpha1.ClusterObjectSetPhaseSpec{AllowCriticalKinds: true},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			violations, err := NewCriticalKinds().Check(context.Background(), test.owner, node)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedViolations, violations)
+		})
+	}
+}
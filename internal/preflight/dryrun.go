@@ -16,10 +16,14 @@ import (
 )
 
 type DryRun struct {
-	client client.Writer
+	client     client.Writer
+	fieldOwner string
 }
 
-func NewDryRun(client client.Writer) *DryRun { return &DryRun{client: client} }
+// NewDryRun returns a checker that dry-runs applying objects as the given field owner.
+func NewDryRun(client client.Writer, fieldOwner string) *DryRun {
+	return &DryRun{client: client, fieldOwner: fieldOwner}
+}
 
 func (p *DryRun) Check(ctx context.Context, _, obj client.Object) (violations []Violation, err error) {
 	defer addPositionToViolations(ctx, obj, &violations)
@@ -31,7 +35,7 @@ func (p *DryRun) Check(ctx context.Context, _, obj client.Object) (violations []
 
 	patch := client.RawPatch(types.ApplyPatchType, objectPatch)
 	dst := obj.DeepCopyObject().(*unstructured.Unstructured)
-	err = p.client.Patch(ctx, dst, patch, client.FieldOwner("package-operator"), client.ForceOwnership, client.DryRunAll)
+	err = p.client.Patch(ctx, dst, patch, client.FieldOwner(p.fieldOwner), client.ForceOwnership, client.DryRunAll)
 
 	if k8serrs.IsNotFound(err) {
 		err = p.client.Create(ctx, obj.DeepCopyObject().(client.Object), client.DryRunAll)
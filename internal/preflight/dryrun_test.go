@@ -37,7 +37,7 @@ func TestDryRun(t *testing.T) {
 	obj.SetNamespace("test-ns")
 	obj.SetKind("Hans")
 
-	dr := preflight.NewDryRun(c)
+	dr := preflight.NewDryRun(c, "package-operator")
 	v, err := dr.Check(context.Background(), obj, obj)
 	require.Error(t, err)
 	assert.Len(t, v, 0)
@@ -76,7 +76,7 @@ func TestDryRunViolations(t *testing.T) {
 			obj.SetNamespace("test-ns")
 			obj.SetKind("Hans")
 
-			dr := preflight.NewDryRun(c)
+			dr := preflight.NewDryRun(c, "package-operator")
 			v, err := dr.Check(context.Background(), obj, obj)
 			require.NoError(t, err)
 			assert.Len(t, v, 1)
@@ -103,7 +103,7 @@ func TestDryRun_alreadyExists(t *testing.T) {
 	obj.SetNamespace("test-ns")
 	obj.SetKind("Hans")
 
-	dr := preflight.NewDryRun(c)
+	dr := preflight.NewDryRun(c, "package-operator")
 	v, err := dr.Check(context.Background(), obj, obj)
 	require.NoError(t, err)
 	assert.Len(t, v, 0)
@@ -128,7 +128,7 @@ func TestDryRun_notFround(t *testing.T) {
 	obj.SetNamespace("test-ns")
 	obj.SetKind("Hans")
 
-	dr := preflight.NewDryRun(c)
+	dr := preflight.NewDryRun(c, "package-operator")
 	v, err := dr.Check(context.Background(), obj, obj)
 	require.NoError(t, err)
 	assert.Len(t, v, 1)
@@ -147,7 +147,7 @@ func TestDryRun_emptyreason(t *testing.T) {
 	obj.SetNamespace("test-ns")
 	obj.SetKind("Hans")
 
-	dr := preflight.NewDryRun(c)
+	dr := preflight.NewDryRun(c, "package-operator")
 	v, err := dr.Check(context.Background(), obj, obj)
 	require.NoError(t, err)
 	require.Len(t, v, 1)
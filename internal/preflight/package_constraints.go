@@ -0,0 +1,129 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+)
+
+type serverAPIDiscoverer interface {
+	ServerGroups() (*metav1.APIGroupList, error)
+	ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error)
+}
+
+// Checks the constraints of a PackageManifest against the cluster,
+// before any object of the package is applied.
+type PackageConstraints struct {
+	discovery serverAPIDiscoverer
+}
+
+func NewPackageConstraints(discovery serverAPIDiscoverer) *PackageConstraints {
+	return &PackageConstraints{
+		discovery: discovery,
+	}
+}
+
+func (p *PackageConstraints) Check(
+	_ context.Context,
+	constraints manifestsv1alpha1.PackageManifestConstraints,
+	env manifestsv1alpha1.PackageEnvironment,
+) (violations []Violation, err error) {
+	if len(constraints.KubernetesVersion) > 0 {
+		if v := checkVersion(constraints.KubernetesVersion, env.Kubernetes.Version); len(v) > 0 {
+			violations = append(violations, Violation{
+				Position: "Kubernetes version",
+				Error:    v,
+			})
+		}
+	}
+
+	if len(constraints.OpenShiftVersion) > 0 {
+		var v string
+		if env.OpenShift == nil {
+			v = "cluster is not an OpenShift cluster"
+		} else {
+			v = checkVersion(constraints.OpenShiftVersion, env.OpenShift.Version)
+		}
+		if len(v) > 0 {
+			violations = append(violations, Violation{
+				Position: "OpenShift version",
+				Error:    v,
+			})
+		}
+	}
+
+	if len(constraints.APIGroups) > 0 {
+		groupList, err := p.discovery.ServerGroups()
+		if err != nil {
+			return nil, fmt.Errorf("discovering API groups: %w", err)
+		}
+		served := map[string]struct{}{}
+		for _, group := range groupList.Groups {
+			served[group.Name] = struct{}{}
+		}
+		for _, group := range constraints.APIGroups {
+			if _, ok := served[group]; !ok {
+				violations = append(violations, Violation{
+					Position: "API group " + group,
+					Error:    "not served by the cluster",
+				})
+			}
+		}
+	}
+
+	for _, gvk := range constraints.APIs {
+		served, err := p.isKindServed(schema.GroupVersionKind(gvk))
+		if err != nil {
+			return nil, err
+		}
+		if !served {
+			violations = append(violations, Violation{
+				Position: "API " + schema.GroupVersionKind(gvk).String(),
+				Error:    "not served by the cluster",
+			})
+		}
+	}
+	return violations, nil
+}
+
+func (p *PackageConstraints) isKindServed(gvk schema.GroupVersionKind) (bool, error) {
+	resourceList, err := p.discovery.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("discovering APIs of %s: %w", gvk.GroupVersion(), err)
+	}
+	for _, resource := range resourceList.APIResources {
+		if resource.Kind == gvk.Kind {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Returns a description of the violation or an empty string,
+// if the version satisfies the constraint.
+func checkVersion(constraint, version string) string {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return fmt.Sprintf("invalid constraint %q: %v", constraint, err)
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return fmt.Sprintf("unknown version %q", version)
+	}
+	// Distributions add pre-release suffixes like "v1.27.3-eks-a5565ad",
+	// which would otherwise fail any constraint without pre-release.
+	release := semver.New(v.Major(), v.Minor(), v.Patch(), "", "")
+	if !c.Check(release) {
+		return fmt.Sprintf("%s does not satisfy %q", version, constraint)
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+)
+
+type discoveryMock struct {
+	mock.Mock
+}
+
+func (m *discoveryMock) ServerGroups() (*metav1.APIGroupList, error) {
+	args := m.Called()
+	list, _ := args.Get(0).(*metav1.APIGroupList)
+	return list, args.Error(1)
+}
+
+func (m *discoveryMock) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	args := m.Called(groupVersion)
+	list, _ := args.Get(0).(*metav1.APIResourceList)
+	return list, args.Error(1)
+}
+
+func TestPackageConstraints(t *testing.T) {
+	t.Parallel()
+
+	env := manifestsv1alpha1.PackageEnvironment{
+		Kubernetes: manifestsv1alpha1.PackageEnvironmentKubernetes{Version: "v1.27.3-eks-a5565ad"},
+	}
+	openShiftEnv := manifestsv1alpha1.PackageEnvironment{
+		Kubernetes: manifestsv1alpha1.PackageEnvironmentKubernetes{Version: "v1.26.0"},
+		OpenShift:  &manifestsv1alpha1.PackageEnvironmentOpenShift{Version: "4.13.2"},
+	}
+
+	tests := []struct {
+		name               string
+		constraints        manifestsv1alpha1.PackageManifestConstraints
+		env                manifestsv1alpha1.PackageEnvironment
+		expectedViolations []Violation
+	}{
+		{
+			name:        "no constraints",
+			constraints: manifestsv1alpha1.PackageManifestConstraints{},
+			env:         env,
+		},
+		{
+			name:        "kubernetes version satisfied",
+			constraints: manifestsv1alpha1.PackageManifestConstraints{KubernetesVersion: ">= 1.25.0"},
+			env:         env,
+		},
+		{
+			name:        "kubernetes version not satisfied",
+			constraints: manifestsv1alpha1.PackageManifestConstraints{KubernetesVersion: ">= 1.28.0"},
+			env:         env,
+			expectedViolations: []Violation{{
+				Position: "Kubernetes version",
+				Error:    `v1.27.3-eks-a5565ad does not satisfy ">= 1.28.0"`,
+			}},
+		},
+		{
+			name:        "invalid constraint",
+			constraints: manifestsv1alpha1.PackageManifestConstraints{KubernetesVersion: "banana"},
+			env:         env,
+			expectedViolations: []Violation{{
+				Position: "Kubernetes version",
+				Error:    `invalid constraint "banana": improper constraint: banana`,
+			}},
+		},
+		{
+			name:        "openshift version satisfied",
+			constraints: manifestsv1alpha1.PackageManifestConstraints{OpenShiftVersion: ">= 4.12.0"},
+			env:         openShiftEnv,
+		},
+		{
+			name:        "not openshift",
+			constraints: manifestsv1alpha1.PackageManifestConstraints{OpenShiftVersion: ">= 4.12.0"},
+			env:         env,
+			expectedViolations: []Violation{{
+				Position: "OpenShift version",
+				Error:    "cluster is not an OpenShift cluster",
+			}},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			pc := NewPackageConstraints(&discoveryMock{})
+			violations, err := pc.Check(context.Background(), test.constraints, test.env)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedViolations, violations)
+		})
+	}
+}
+
+func TestPackageConstraints_APIs(t *testing.T) {
+	t.Parallel()
+
+	dm := &discoveryMock{}
+	dm.On("ServerGroups").Return(&metav1.APIGroupList{
+		Groups: []metav1.APIGroup{{Name: "apps"}, {Name: "monitoring.coreos.com"}},
+	}, nil)
+	dm.On("ServerResourcesForGroupVersion", "monitoring.coreos.com/v1").Return(&metav1.APIResourceList{
+		APIResources: []metav1.APIResource{{Kind: "ServiceMonitor"}},
+	}, nil)
+	dm.On("ServerResourcesForGroupVersion", "route.openshift.io/v1").
+		Return(nil, apierrors.NewNotFound(schema.GroupResource{}, ""))
+
+	pc := NewPackageConstraints(dm)
+	violations, err := pc.Check(context.Background(), manifestsv1alpha1.PackageManifestConstraints{
+		APIGroups: []string{"apps", "cert-manager.io"},
+		APIs: []metav1.GroupVersionKind{
+			{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"},
+			{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"},
+			{Group: "route.openshift.io", Version: "v1", Kind: "Route"},
+		},
+	}, manifestsv1alpha1.PackageEnvironment{})
+	require.NoError(t, err)
+	assert.Equal(t, []Violation{
+		{Position: "API group cert-manager.io", Error: "not served by the cluster"},
+		{Position: "API monitoring.coreos.com/v1, Kind=PodMonitor", Error: "not served by the cluster"},
+		{Position: "API route.openshift.io/v1, Kind=Route", Error: "not served by the cluster"},
+	}, violations)
+}
+
+func TestPackageConstraints_discoveryError(t *testing.T) {
+	t.Parallel()
+
+	dm := &discoveryMock{}
+	dm.On("ServerGroups").Return(nil, errors.New("boom"))
+
+	pc := NewPackageConstraints(dm)
+	_, err := pc.Check(context.Background(), manifestsv1alpha1.PackageManifestConstraints{
+		APIGroups: []string{"apps"},
+	}, manifestsv1alpha1.PackageEnvironment{})
+	require.ErrorContains(t, err, "boom")
+}
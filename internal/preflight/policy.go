@@ -0,0 +1,168 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Paths of pod specs within workload objects.
+var podSpecPaths = [][]string{
+	{"spec", "template", "spec"},
+	{"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+var podContainerFields = []string{"initContainers", "containers", "ephemeralContainers"}
+
+// Denies objects not allowed by the preflight policy of the PackageOperatorConfig,
+// so platform teams can bound what Packages are allowed to deploy.
+type Policy struct {
+	client client.Reader
+}
+
+var _ checker = (*Policy)(nil)
+
+func NewPolicy(client client.Reader) *Policy {
+	return &Policy{client: client}
+}
+
+func (p *Policy) Check(
+	ctx context.Context, owner,
+	obj client.Object,
+) (violations []Violation, err error) {
+	defer addPositionToViolations(ctx, obj, &violations)
+
+	policy, err := p.getPolicy(ctx)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+	if !policyAppliesToOwner(policy, owner) {
+		return
+	}
+
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		o, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, fmt.Errorf("converting to unstructured: %w", err)
+		}
+		u = &unstructured.Unstructured{Object: o}
+	}
+
+	for _, msg := range policyViolations(policy, u) {
+		violations = append(violations, Violation{Error: msg})
+	}
+	return
+}
+
+func (p *Policy) getPolicy(ctx context.Context) (*corev1alpha1.PreflightPolicy, error) {
+	config := &corev1alpha1.PackageOperatorConfig{}
+	err := p.client.Get(ctx, client.ObjectKey{
+		Name: corev1alpha1.PackageOperatorConfigName,
+	}, config)
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) || errors.IsForbidden(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting PackageOperatorConfig: %w", err)
+	}
+	return config.Spec.PreflightPolicy, nil
+}
+
+func policyAppliesToOwner(policy *corev1alpha1.PreflightPolicy, owner client.Object) bool {
+	if len(policy.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range policy.Namespaces {
+		if ns == owner.GetNamespace() {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns a message for each way the object violates the policy.
+func policyViolations(policy *corev1alpha1.PreflightPolicy, obj *unstructured.Unstructured) []string {
+	var msgs []string
+	gk := obj.GroupVersionKind().GroupKind()
+	for _, k := range policy.DeniedKinds {
+		if matchesWildcard(k.Group, gk.Group) && matchesWildcard(k.Kind, gk.Kind) {
+			msgs = append(msgs, "Kind denied by preflight policy.")
+			break
+		}
+	}
+
+	if gk.Group == "rbac.authorization.k8s.io" &&
+		(gk.Kind == "RoleBinding" || gk.Kind == "ClusterRoleBinding") {
+		roleKind, _, _ := unstructured.NestedString(obj.Object, "roleRef", "kind")
+		roleName, _, _ := unstructured.NestedString(obj.Object, "roleRef", "name")
+		if roleKind == "ClusterRole" && contains(policy.DeniedClusterRoles, roleName) {
+			msgs = append(msgs, fmt.Sprintf(
+				"Binding to ClusterRole %q denied by preflight policy.", roleName))
+		}
+	}
+
+	for _, podSpec := range podSpecs(obj) {
+		if policy.DenyHostPathVolumes {
+			volumes, _, _ := unstructured.NestedSlice(podSpec, "volumes")
+			for _, v := range volumes {
+				volume, _ := v.(map[string]interface{})
+				if _, ok := volume["hostPath"]; ok {
+					msgs = append(msgs, fmt.Sprintf(
+						"hostPath volume %q denied by preflight policy.", volume["name"]))
+				}
+			}
+		}
+
+		if policy.DenyPrivilegedContainers {
+			for _, field := range podContainerFields {
+				containers, _, _ := unstructured.NestedSlice(podSpec, field)
+				for _, c := range containers {
+					container, _ := c.(map[string]interface{})
+					privileged, _, _ := unstructured.NestedBool(container, "securityContext", "privileged")
+					if privileged {
+						msgs = append(msgs, fmt.Sprintf(
+							"Privileged container %q denied by preflight policy.", container["name"]))
+					}
+				}
+			}
+		}
+	}
+	return msgs
+}
+
+// Returns the pod specs of Pods and workloads with pod templates.
+func podSpecs(obj *unstructured.Unstructured) []map[string]interface{} {
+	var specs []map[string]interface{}
+	if obj.GetKind() == "Pod" && obj.GroupVersionKind().Group == "" {
+		if spec, ok, _ := unstructured.NestedMap(obj.Object, "spec"); ok {
+			specs = append(specs, spec)
+		}
+	}
+	for _, path := range podSpecPaths {
+		if spec, ok, _ := unstructured.NestedMap(obj.Object, path...); ok {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+func matchesWildcard(pattern, value string) bool {
+	return pattern == "*" || pattern == value
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
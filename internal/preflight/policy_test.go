@@ -0,0 +1,162 @@
+package preflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestPolicy(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, client.ObjectKey{Name: "cluster"},
+			mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			config := args.Get(2).(*corev1alpha1.PackageOperatorConfig)
+			config.Spec.PreflightPolicy = &corev1alpha1.PreflightPolicy{
+				Namespaces:               []string{"tenant"},
+				DeniedKinds:              []corev1alpha1.PreflightPolicyKind{{Group: "*", Kind: "PriorityClass"}},
+				DeniedClusterRoles:       []string{"cluster-admin"},
+				DenyHostPathVolumes:      true,
+				DenyPrivilegedContainers: true,
+			}
+		}).
+		Return(nil)
+
+	tenantOwner := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "tenant"},
+	}
+	platformOwner := &corev1alpha1.ObjectSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "platform"},
+	}
+
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "test"},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":            "test",
+							"securityContext": map[string]interface{}{"privileged": true},
+						},
+					},
+					"volumes": []interface{}{
+						map[string]interface{}{
+							"name":     "host",
+							"hostPath": map[string]interface{}{"path": "/"},
+						},
+					},
+				},
+			},
+		},
+	}}
+	binding := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "rbac.authorization.k8s.io/v1",
+		"kind":       "ClusterRoleBinding",
+		"metadata":   map[string]interface{}{"name": "test"},
+		"roleRef": map[string]interface{}{
+			"kind": "ClusterRole",
+			"name": "cluster-admin",
+		},
+	}}
+	priorityClass := &unstructured.Unstructured{}
+	priorityClass.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: "scheduling.k8s.io", Version: "v1", Kind: "PriorityClass",
+	})
+	priorityClass.SetName("test")
+
+	tests := []struct {
+		name     string
+		owner    client.Object
+		obj      *unstructured.Unstructured
+		expected []Violation
+	}{
+		{
+			name:  "privileged workload",
+			owner: tenantOwner,
+			obj:   deployment,
+			expected: []Violation{
+				{
+					Position: "Deployment /test",
+					Error:    `hostPath volume "host" denied by preflight policy.`,
+				},
+				{
+					Position: "Deployment /test",
+					Error:    `Privileged container "test" denied by preflight policy.`,
+				},
+			},
+		},
+		{
+			name:  "cluster-admin binding",
+			owner: tenantOwner,
+			obj:   binding,
+			expected: []Violation{
+				{
+					Position: "ClusterRoleBinding /test",
+					Error:    `Binding to ClusterRole "cluster-admin" denied by preflight policy.`,
+				},
+			},
+		},
+		{
+			name:  "denied kind",
+			owner: tenantOwner,
+			obj:   priorityClass,
+			expected: []Violation{
+				{
+					Position: "PriorityClass /test",
+					Error:    "Kind denied by preflight policy.",
+				},
+			},
+		},
+		{
+			name:  "other namespace",
+			owner: platformOwner,
+			obj:   deployment,
+		},
+	}
+
+	p := NewPolicy(c)
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := p.Check(context.Background(), test.owner, test.obj)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, v)
+		})
+	}
+}
+
+func TestPolicy_noConfig(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(apierrors.NewNotFound(schema.GroupResource{}, ""))
+
+	pod := &unstructured.Unstructured{}
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+
+	v, err := NewPolicy(c).Check(context.Background(), &corev1alpha1.ObjectSet{}, pod)
+	require.NoError(t, err)
+	assert.Empty(t, v)
+}
@@ -60,6 +60,9 @@ func addPositionToViolations(
 	objPosition := fmt.Sprintf("%s %s",
 		obj.GetObjectKind().GroupVersionKind().Kind,
 		client.ObjectKeyFromObject(obj))
+	if source := obj.GetAnnotations()[corev1alpha1.ObjectSourceFileAnnotation]; len(source) > 0 {
+		objPosition = fmt.Sprintf("%s from %s", objPosition, source)
+	}
 
 	phase, ok := phaseFromContext(ctx)
 	if ok {
@@ -75,6 +75,24 @@ func Test_addPositionToViolations_withPhase(t *testing.T) {
 		violations[0].Position)
 }
 
+func Test_addPositionToViolations_withSourceFile(t *testing.T) {
+	ctx := context.Background()
+	obj := &unstructured.Unstructured{}
+	obj.SetName("test")
+	obj.SetNamespace("testns")
+	obj.SetKind("Something")
+	obj.SetAnnotations(map[string]string{
+		corev1alpha1.ObjectSourceFileAnnotation: "deploy/something.yaml#1",
+	})
+	violations := []Violation{
+		{},
+	}
+
+	addPositionToViolations(ctx, obj, &violations)
+
+	assert.Equal(t, "Something testns/test from deploy/something.yaml#1", violations[0].Position)
+}
+
 func TestList(t *testing.T) {
 	var called bool
 	list := List{
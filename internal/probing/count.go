@@ -0,0 +1,83 @@
+package probing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Objects counted by a count probe don't necessarily emit events for the probed object,
+// so failing objects need to be probed again periodically.
+const defaultCountProbeRecheckInterval = 10 * time.Second
+
+// ObjectLister lists objects for probes checking a set of objects,
+// instead of only the probed object.
+type ObjectLister interface {
+	ListObjects(
+		ctx context.Context, gvk schema.GroupVersionKind,
+		namespace string, selector labels.Selector,
+	) ([]unstructured.Unstructured, error)
+}
+
+// countProbe lists objects from the namespace of the probed object
+// and checks that enough of them pass the given Prober.
+type countProbe struct {
+	Prober
+	GroupVersionKind schema.GroupVersionKind
+	Selector         labels.Selector
+	// Minimum number of listed objects that have to pass.
+	// If nil, all listed objects have to pass.
+	MinCount *int
+
+	ctx    context.Context
+	lister ObjectLister
+}
+
+var _ RecheckProber = (*countProbe)(nil)
+
+func (cp *countProbe) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	success, message, _ = cp.ProbeWithRecheck(obj)
+	return
+}
+
+func (cp *countProbe) ProbeWithRecheck(obj *unstructured.Unstructured) (success bool, message string, recheckAfter time.Duration) {
+	if success, message = cp.probe(obj); success {
+		return true, "", 0
+	}
+	// add listed kind and selector as context to error message.
+	return false, fmt.Sprintf("count %s %q: %s", cp.GroupVersionKind.Kind, cp.Selector.String(), message),
+		defaultCountProbeRecheckInterval
+}
+
+func (cp *countProbe) probe(obj *unstructured.Unstructured) (success bool, message string) {
+	if cp.lister == nil {
+		return false, "listing objects is not supported"
+	}
+
+	objs, err := cp.lister.ListObjects(cp.ctx, cp.GroupVersionKind, obj.GetNamespace(), cp.Selector)
+	if err != nil {
+		return false, fmt.Sprintf("listing objects: %v", err)
+	}
+
+	var passed int
+	for i := range objs {
+		if ok, _ := cp.Prober.Probe(&objs[i]); ok {
+			passed++
+		}
+	}
+
+	if cp.MinCount == nil {
+		if passed < len(objs) {
+			return false, fmt.Sprintf("%d/%d passed", passed, len(objs))
+		}
+		return true, ""
+	}
+	if passed < *cp.MinCount {
+		return false, fmt.Sprintf("%d/%d passed, need %d", passed, len(objs), *cp.MinCount)
+	}
+	return true, ""
+}
@@ -0,0 +1,182 @@
+package probing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+var _ ObjectLister = (*objectListerMock)(nil)
+
+type objectListerMock struct {
+	mock.Mock
+}
+
+func (m *objectListerMock) ListObjects(
+	ctx context.Context, gvk schema.GroupVersionKind,
+	namespace string, selector labels.Selector,
+) ([]unstructured.Unstructured, error) {
+	args := m.Called(ctx, gvk, namespace, selector)
+	objs, _ := args.Get(0).([]unstructured.Unstructured)
+	return objs, args.Error(1)
+}
+
+func newReadyPod(name string, ready bool) unstructured.Unstructured {
+	status := "False"
+	if ready {
+		status = "True"
+	}
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": name},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": status},
+			},
+		},
+	}}
+}
+
+func TestCountProbe(t *testing.T) {
+	t.Parallel()
+
+	two := 2
+	tests := []struct {
+		name     string
+		minCount *int
+		pods     []unstructured.Unstructured
+		succeeds bool
+		message  string
+	}{
+		{
+			name:     "all ready",
+			pods:     []unstructured.Unstructured{newReadyPod("a", true), newReadyPod("b", true)},
+			succeeds: true,
+		},
+		{
+			name:     "nothing listed",
+			succeeds: true,
+		},
+		{
+			name:    "not all ready",
+			pods:    []unstructured.Unstructured{newReadyPod("a", true), newReadyPod("b", false)},
+			message: `count Pod "app=test": 1/2 passed`,
+		},
+		{
+			name:     "min count reached",
+			minCount: &two,
+			pods: []unstructured.Unstructured{
+				newReadyPod("a", true), newReadyPod("b", false), newReadyPod("c", true),
+			},
+			succeeds: true,
+		},
+		{
+			name:     "min count not reached",
+			minCount: &two,
+			pods:     []unstructured.Unstructured{newReadyPod("a", true), newReadyPod("b", false)},
+			message:  `count Pod "app=test": 1/2 passed, need 2`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			gvk := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+			selector := labels.SelectorFromSet(labels.Set{"app": "test"})
+
+			lister := &objectListerMock{}
+			lister.
+				On("ListObjects", mock.Anything, gvk, "test-ns", selector).
+				Return(test.pods, nil)
+
+			cp := &countProbe{
+				Prober:           NewConditionProbe("Ready", "True"),
+				GroupVersionKind: gvk,
+				Selector:         selector,
+				MinCount:         test.minCount,
+				ctx:              context.Background(),
+				lister:           lister,
+			}
+
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			obj.SetNamespace("test-ns")
+
+			s, m, recheckAfter := cp.ProbeWithRecheck(obj)
+			assert.Equal(t, test.succeeds, s)
+			assert.Equal(t, test.message, m)
+			if test.succeeds {
+				assert.Zero(t, recheckAfter)
+			} else {
+				assert.Equal(t, defaultCountProbeRecheckInterval, recheckAfter)
+			}
+		})
+	}
+}
+
+var errTest = errors.New("explosion")
+
+func TestCountProbe_listError(t *testing.T) {
+	t.Parallel()
+
+	lister := &objectListerMock{}
+	lister.
+		On("ListObjects", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errTest)
+
+	cp := &countProbe{
+		Prober:   NewConditionProbe("Ready", "True"),
+		Selector: labels.Everything(),
+		ctx:      context.Background(),
+		lister:   lister,
+	}
+	s, m := cp.Probe(&unstructured.Unstructured{Object: map[string]interface{}{}})
+	assert.False(t, s)
+	assert.Contains(t, m, "listing objects: explosion")
+}
+
+func TestParseProbes_count(t *testing.T) {
+	t.Parallel()
+
+	minCount := int32(3)
+	lister := &objectListerMock{}
+	p, err := ParseProbes(context.Background(), []corev1alpha1.Probe{
+		{
+			Count: &corev1alpha1.ProbeCountSpec{
+				APIVersion: "monitoring.coreos.com/v1",
+				Kind:       "PrometheusRule",
+				Condition: &corev1alpha1.ProbeConditionSpec{
+					Type:   "Accepted",
+					Status: "True",
+				},
+				MinCount: &minCount,
+			},
+		},
+	}, WithObjectLister{ObjectLister: lister})
+	require.NoError(t, err)
+
+	nested := p.(*statusObservedGenerationProbe).Prober.(list)
+	require.Len(t, nested, 1)
+	require.IsType(t, &countProbe{}, nested[0])
+
+	cp := nested[0].(*countProbe)
+	assert.Equal(t, schema.GroupVersionKind{
+		Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule",
+	}, cp.GroupVersionKind)
+	assert.True(t, cp.Selector.Empty())
+	if assert.NotNil(t, cp.MinCount) {
+		assert.Equal(t, 3, *cp.MinCount)
+	}
+	assert.Same(t, lister, cp.lister)
+}
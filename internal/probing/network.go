@@ -0,0 +1,232 @@
+package probing
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	defaultNetworkProbeTimeout = time.Second
+	// Network endpoints don't emit events when they become reachable,
+	// so failing objects need to be probed again periodically.
+	defaultNetworkProbeRecheckInterval = 10 * time.Second
+)
+
+var (
+	errUnsupportedKind   = errors.New("only Services and Pods can be reached")
+	errNoPodIP           = errors.New("pod has no IP assigned")
+	errNamedPortNotFound = errors.New("named port not found")
+)
+
+// dialContextFn opens network connections, matches (*net.Dialer).DialContext.
+type dialContextFn func(ctx context.Context, network, address string) (net.Conn, error)
+
+// httpGetProbe performs an in-cluster HTTP GET request against
+// the endpoint of the probed Service or Pod.
+type httpGetProbe struct {
+	Path               string
+	Port               intstr.IntOrString
+	Scheme             string
+	ExpectedStatusCode int
+	Timeout            time.Duration
+
+	dialContext dialContextFn
+}
+
+var _ RecheckProber = (*httpGetProbe)(nil)
+
+func (hp *httpGetProbe) ProbeWithRecheck(obj *unstructured.Unstructured) (success bool, message string, recheckAfter time.Duration) {
+	if success, message = hp.Probe(obj); success {
+		return true, "", 0
+	}
+	return false, message, defaultNetworkProbeRecheckInterval
+}
+
+func (hp *httpGetProbe) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	defer func() {
+		if success {
+			return
+		}
+		// add probed endpoint as context to error message.
+		message = fmt.Sprintf("httpGet %s:%s: %s", hp.Path, hp.Port.String(), message)
+	}()
+
+	hostPort, err := resolveEndpoint(obj, hp.Port)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	scheme := strings.ToLower(hp.Scheme)
+	if len(scheme) == 0 {
+		scheme = "http"
+	}
+	path := hp.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), networkProbeTimeout(hp.Timeout))
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, scheme+"://"+hostPort+path, nil)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: dialerOrDefault(hp.dialContext),
+			// Probes target in-cluster endpoints, which commonly serve self-signed certificates.
+			// Same behavior as kubelet HTTPS probes.
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+			DisableKeepAlives: true,
+		},
+		// Don't follow redirects, 3xx responses are treated as success.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, "request failed: " + err.Error()
+	}
+	defer resp.Body.Close()
+
+	if hp.ExpectedStatusCode != 0 {
+		if resp.StatusCode != hp.ExpectedStatusCode {
+			return false, fmt.Sprintf("status code %d, expected %d", resp.StatusCode, hp.ExpectedStatusCode)
+		}
+		return true, ""
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return false, fmt.Sprintf("status code %d", resp.StatusCode)
+	}
+	return true, ""
+}
+
+// tcpSocketProbe opens a TCP connection against
+// the endpoint of the probed Service or Pod.
+type tcpSocketProbe struct {
+	Port    intstr.IntOrString
+	Timeout time.Duration
+
+	dialContext dialContextFn
+}
+
+var _ RecheckProber = (*tcpSocketProbe)(nil)
+
+func (tp *tcpSocketProbe) ProbeWithRecheck(obj *unstructured.Unstructured) (success bool, message string, recheckAfter time.Duration) {
+	if success, message = tp.Probe(obj); success {
+		return true, "", 0
+	}
+	return false, message, defaultNetworkProbeRecheckInterval
+}
+
+func (tp *tcpSocketProbe) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	defer func() {
+		if success {
+			return
+		}
+		// add probed port as context to error message.
+		message = fmt.Sprintf("tcpSocket %s: %s", tp.Port.String(), message)
+	}()
+
+	hostPort, err := resolveEndpoint(obj, tp.Port)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), networkProbeTimeout(tp.Timeout))
+	defer cancel()
+
+	conn, err := dialerOrDefault(tp.dialContext)(ctx, "tcp", hostPort)
+	if err != nil {
+		return false, "connection failed: " + err.Error()
+	}
+	_ = conn.Close()
+	return true, ""
+}
+
+func dialerOrDefault(dial dialContextFn) dialContextFn {
+	if dial != nil {
+		return dial
+	}
+	return (&net.Dialer{}).DialContext
+}
+
+func networkProbeTimeout(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return defaultNetworkProbeTimeout
+	}
+	return timeout
+}
+
+// resolveEndpoint returns the host:port to reach the given Service or Pod object.
+func resolveEndpoint(obj *unstructured.Unstructured, port intstr.IntOrString) (string, error) {
+	var (
+		host  string
+		ports []interface{}
+	)
+	switch obj.GroupVersionKind().GroupKind().String() {
+	case "Service":
+		host = fmt.Sprintf("%s.%s.svc", obj.GetName(), obj.GetNamespace())
+		ports, _, _ = unstructured.NestedSlice(obj.Object, "spec", "ports")
+
+	case "Pod":
+		podIP, _, _ := unstructured.NestedString(obj.Object, "status", "podIP")
+		if len(podIP) == 0 {
+			return "", errNoPodIP
+		}
+		host = podIP
+		containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "containers")
+		for _, containerI := range containers {
+			container, ok := containerI.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			containerPorts, _, _ := unstructured.NestedSlice(container, "ports")
+			ports = append(ports, containerPorts...)
+		}
+
+	default:
+		return "", errUnsupportedKind
+	}
+
+	if port.Type == intstr.Int {
+		return net.JoinHostPort(host, strconv.Itoa(port.IntValue())), nil
+	}
+
+	portNumber, ok := lookupNamedPort(ports, port.StrVal)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", errNamedPortNotFound, port.StrVal)
+	}
+	return net.JoinHostPort(host, strconv.FormatInt(portNumber, 10)), nil
+}
+
+// lookupNamedPort searches the given port list for a port with the given name.
+// Service ports are addressed via .port and container ports via .containerPort.
+func lookupNamedPort(ports []interface{}, name string) (int64, bool) {
+	for _, portI := range ports {
+		port, ok := portI.(map[string]interface{})
+		if !ok || port["name"] != name {
+			continue
+		}
+		if p, ok, err := unstructured.NestedInt64(port, "port"); err == nil && ok {
+			return p, true
+		}
+		if p, ok, err := unstructured.NestedInt64(port, "containerPort"); err == nil && ok {
+			return p, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,183 @@
+package probing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestHTTPGetProbe(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/created":
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	var dialed string
+	dialToServer := func(ctx context.Context, network, address string) (net.Conn, error) {
+		dialed = address
+		return (&net.Dialer{}).DialContext(ctx, network, srv.Listener.Addr().String())
+	}
+
+	tests := []struct {
+		name     string
+		probe    *httpGetProbe
+		succeeds bool
+		message  string
+	}{
+		{
+			name: "succeeds",
+			probe: &httpGetProbe{
+				Path: "/healthz", Port: intstr.FromString("http"),
+			},
+			succeeds: true,
+		},
+		{
+			name: "unhealthy",
+			probe: &httpGetProbe{
+				Path: "/other", Port: intstr.FromInt(8080),
+			},
+			message: "httpGet /other:8080: status code 503",
+		},
+		{
+			name: "unexpected status code",
+			probe: &httpGetProbe{
+				Path: "/created", Port: intstr.FromInt(8080), ExpectedStatusCode: http.StatusOK,
+			},
+			message: "httpGet /created:8080: status code 201, expected 200",
+		},
+		{
+			name: "named port not found",
+			probe: &httpGetProbe{
+				Path: "/healthz", Port: intstr.FromString("metrics"),
+			},
+			message: `httpGet /healthz:metrics: named port not found: "metrics"`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.probe.dialContext = dialToServer
+			s, m := test.probe.Probe(serviceObject())
+			assert.Equal(t, test.succeeds, s)
+			assert.Equal(t, test.message, m)
+		})
+	}
+	assert.Equal(t, "test.test-ns.svc:8080", dialed)
+
+	unreachable := &httpGetProbe{
+		Path: "/healthz", Port: intstr.FromInt(8080),
+		dialContext: func(context.Context, string, string) (net.Conn, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	s, m := unreachable.Probe(serviceObject())
+	assert.False(t, s)
+	assert.Equal(t,
+		`httpGet /healthz:8080: request failed: Get "http://test.test-ns.svc:8080/healthz": connection refused`, m)
+}
+
+func TestTCPSocketProbe(t *testing.T) {
+	var dialed string
+	p := &tcpSocketProbe{
+		Port: intstr.FromString("postgres"),
+		dialContext: func(_ context.Context, _, address string) (net.Conn, error) {
+			dialed = address
+			client, server := net.Pipe()
+			_ = server.Close()
+			return client, nil
+		},
+	}
+	s, m := p.Probe(podObject())
+	assert.True(t, s)
+	assert.Empty(t, m)
+	assert.Equal(t, "10.0.0.5:5432", dialed)
+
+	p.dialContext = func(context.Context, string, string) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	}
+	s, m = p.Probe(podObject())
+	assert.False(t, s)
+	assert.Equal(t, "tcpSocket postgres: connection failed: connection refused", m)
+}
+
+func TestResolveEndpoint(t *testing.T) {
+	_, err := resolveEndpoint(&unstructured.Unstructured{
+		Object: map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"},
+	}, intstr.FromInt(80))
+	require.ErrorIs(t, err, errUnsupportedKind)
+
+	pod := podObject()
+	unstructured.RemoveNestedField(pod.Object, "status")
+	_, err = resolveEndpoint(pod, intstr.FromInt(80))
+	require.ErrorIs(t, err, errNoPodIP)
+
+	hostPort, err := resolveEndpoint(podObject(), intstr.FromInt(9090))
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5:9090", hostPort)
+}
+
+func serviceObject() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata": map[string]interface{}{
+				"name":      "test",
+				"namespace": "test-ns",
+			},
+			"spec": map[string]interface{}{
+				"ports": []interface{}{
+					map[string]interface{}{
+						"name": "http",
+						"port": int64(8080),
+					},
+				},
+			},
+		},
+	}
+}
+
+func podObject() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":      "test",
+				"namespace": "test-ns",
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{
+						"name": "db",
+						"ports": []interface{}{
+							map[string]interface{}{
+								"name":          "postgres",
+								"containerPort": int64(5432),
+							},
+						},
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"podIP": "10.0.0.5",
+			},
+		},
+	}
+}
@@ -3,20 +3,87 @@ package probing
 import (
 	"context"
 	"fmt"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 )
 
+// ParseOption customizes how probes are compiled.
+type ParseOption interface {
+	ApplyToParseOptions(opts *ParseOptions)
+}
+
+// ParseOptions holds all parameters to compile probes with.
+type ParseOptions struct {
+	// Lists objects for count probes.
+	// Count probes fail, if no ObjectLister is given.
+	ObjectLister ObjectLister
+	// Counts consecutive failures of probes with a failure threshold.
+	// Every failure is reported, if no FailureTracker is given.
+	FailureTracker *FailureTracker
+	// UID of the object owning the probes, scoping failures in the FailureTracker.
+	Owner types.UID
+	// Clock to check initial delays and periods against.
+	Clock Clock
+}
+
+// WithObjectLister sets the ObjectLister used by count probes.
+type WithObjectLister struct{ ObjectLister }
+
+func (w WithObjectLister) ApplyToParseOptions(opts *ParseOptions) {
+	opts.ObjectLister = w.ObjectLister
+}
+
+// WithFailureTracker sets the FailureTracker and the owner of the probes,
+// to tolerate transient failures of objects that passed their probes before.
+type WithFailureTracker struct {
+	*FailureTracker
+	Owner types.UID
+}
+
+func (w WithFailureTracker) ApplyToParseOptions(opts *ParseOptions) {
+	opts.FailureTracker = w.FailureTracker
+	opts.Owner = w.Owner
+}
+
+// WithClock sets the Clock used by probes with an initial delay or period.
+type WithClock struct{ Clock }
+
+func (w WithClock) ApplyToParseOptions(opts *ParseOptions) {
+	opts.Clock = w.Clock
+}
+
 // Parse takes a list of ObjectSetProbes (commonly defined within a ObjectSetPhaseSpec)
 // and compiles a single Prober to test objects with.
-func Parse(ctx context.Context, packageProbes []corev1alpha1.ObjectSetProbe) (Prober, error) {
+func Parse(ctx context.Context, packageProbes []corev1alpha1.ObjectSetProbe, opts ...ParseOption) (Prober, error) {
+	var parseOpts ParseOptions
+	for _, opt := range opts {
+		opt.ApplyToParseOptions(&parseOpts)
+	}
+	if parseOpts.Clock == nil {
+		parseOpts.Clock = realClock{}
+	}
+
 	probeList := make(list, len(packageProbes))
 	for i, pkgProbe := range packageProbes {
-		probe := ParseProbes(ctx, pkgProbe.Probes)
-		var err error
+		probe, err := ParseProbes(ctx, pkgProbe.Probes, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("parsing probe #%d: %w", i, err)
+		}
+		if pkgProbe.RecheckIntervalSeconds > 0 {
+			probe = &recheckProbe{
+				Prober:   probe,
+				Interval: time.Duration(pkgProbe.RecheckIntervalSeconds) * time.Second,
+			}
+		}
+		if pkgProbe.InitialDelaySeconds > 0 || pkgProbe.PeriodSeconds > 0 || pkgProbe.FailureThreshold > 1 {
+			probe = newThresholdProbe(probe, i, pkgProbe, parseOpts)
+		}
 		probe, err = ParseSelector(ctx, pkgProbe.Selector, probe)
 		if err != nil {
 			return nil, fmt.Errorf("parsing selector of probe #%d: %w", i, err)
@@ -52,7 +119,12 @@ func ParseSelector(_ context.Context, selector corev1alpha1.ProbeSelector, probe
 }
 
 // ParseProbes takes a []corev1alpha1.Probe and compiles it into a Prober.
-func ParseProbes(_ context.Context, probeSpecs []corev1alpha1.Probe) Prober {
+func ParseProbes(ctx context.Context, probeSpecs []corev1alpha1.Probe, opts ...ParseOption) (Prober, error) {
+	var parseOpts ParseOptions
+	for _, opt := range opts {
+		opt.ApplyToParseOptions(&parseOpts)
+	}
+
 	var probeList list
 	for _, probeSpec := range probeSpecs {
 		var probe Prober
@@ -70,6 +142,28 @@ func ParseProbes(_ context.Context, probeSpecs []corev1alpha1.Probe) Prober {
 				probeSpec.Condition.Status,
 			)
 
+		case probeSpec.HTTPGet != nil:
+			probe = &httpGetProbe{
+				Path:               probeSpec.HTTPGet.Path,
+				Port:               probeSpec.HTTPGet.Port,
+				Scheme:             probeSpec.HTTPGet.Scheme,
+				ExpectedStatusCode: int(probeSpec.HTTPGet.ExpectedStatusCode),
+				Timeout:            time.Duration(probeSpec.HTTPGet.TimeoutSeconds) * time.Second,
+			}
+
+		case probeSpec.TCPSocket != nil:
+			probe = &tcpSocketProbe{
+				Port:    probeSpec.TCPSocket.Port,
+				Timeout: time.Duration(probeSpec.TCPSocket.TimeoutSeconds) * time.Second,
+			}
+
+		case probeSpec.Count != nil:
+			var err error
+			probe, err = parseCountProbe(ctx, *probeSpec.Count, parseOpts)
+			if err != nil {
+				return nil, err
+			}
+
 		default:
 			// probe has no known config
 			continue
@@ -78,5 +172,53 @@ func ParseProbes(_ context.Context, probeSpecs []corev1alpha1.Probe) Prober {
 	}
 
 	// Always check .status.observedCondition, if present.
-	return &statusObservedGenerationProbe{Prober: probeList}
+	return &statusObservedGenerationProbe{Prober: probeList}, nil
+}
+
+// parseCountProbe compiles a corev1alpha1.ProbeCountSpec into a Prober.
+func parseCountProbe(
+	ctx context.Context, countSpec corev1alpha1.ProbeCountSpec, parseOpts ParseOptions,
+) (Prober, error) {
+	gv, err := schema.ParseGroupVersion(countSpec.APIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parsing count apiVersion: %w", err)
+	}
+
+	selector := labels.Everything()
+	if countSpec.Selector != nil {
+		selector, err = metav1.LabelSelectorAsSelector(countSpec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing count selector: %w", err)
+		}
+	}
+
+	var objProbes list
+	if countSpec.Condition != nil {
+		objProbes = append(objProbes, NewConditionProbe(
+			countSpec.Condition.Type,
+			countSpec.Condition.Status,
+		))
+	}
+	if countSpec.FieldsEqual != nil {
+		objProbes = append(objProbes, &fieldsEqualProbe{
+			FieldA: countSpec.FieldsEqual.FieldA,
+			FieldB: countSpec.FieldsEqual.FieldB,
+		})
+	}
+
+	var minCount *int
+	if countSpec.MinCount != nil {
+		c := int(*countSpec.MinCount)
+		minCount = &c
+	}
+
+	return &countProbe{
+		Prober:           &statusObservedGenerationProbe{Prober: objProbes},
+		GroupVersionKind: gv.WithKind(countSpec.Kind),
+		Selector:         selector,
+		MinCount:         minCount,
+
+		ctx:    ctx,
+		lister: parseOpts.ObjectLister,
+	}, nil
 }
@@ -3,10 +3,12 @@ package probing
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 )
@@ -39,6 +41,33 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParse_RecheckInterval(t *testing.T) {
+	ctx := context.Background()
+	osp := []corev1alpha1.ObjectSetProbe{
+		{
+			Selector: corev1alpha1.ProbeSelector{
+				Kind: &corev1alpha1.PackageProbeKindSpec{
+					Kind:  "Job",
+					Group: "batch",
+				},
+			},
+			RecheckIntervalSeconds: 600,
+		},
+	}
+
+	p, err := Parse(ctx, osp)
+	require.NoError(t, err)
+	require.IsType(t, list{}, p)
+
+	if assert.Len(t, p, 1) {
+		list := p.(list)
+		require.IsType(t, &kindSelector{}, list[0])
+		ks := list[0].(*kindSelector)
+		require.IsType(t, &recheckProbe{}, ks.Prober)
+		assert.Equal(t, 10*time.Minute, ks.Prober.(*recheckProbe).Interval)
+	}
+}
+
 func TestParseSelector(t *testing.T) {
 	ctx := context.Background()
 	p, err := ParseSelector(ctx, corev1alpha1.ProbeSelector{
@@ -72,11 +101,25 @@ func TestParseProbes(t *testing.T) {
 			Status: "asdf",
 		},
 	}
+	hp := corev1alpha1.Probe{
+		HTTPGet: &corev1alpha1.ProbeHTTPGetSpec{
+			Path:               "/healthz",
+			Port:               intstr.FromString("http"),
+			ExpectedStatusCode: 204,
+			TimeoutSeconds:     3,
+		},
+	}
+	tp := corev1alpha1.Probe{
+		TCPSocket: &corev1alpha1.ProbeTCPSocketSpec{
+			Port: intstr.FromInt(5432),
+		},
+	}
 	emptyConfigProbe := corev1alpha1.Probe{}
 
-	p := ParseProbes(context.Background(), []corev1alpha1.Probe{
-		fep, cp, emptyConfigProbe,
+	p, err := ParseProbes(context.Background(), []corev1alpha1.Probe{
+		fep, cp, hp, tp, emptyConfigProbe,
 	})
+	require.NoError(t, err)
 	// everything should be wrapped
 	require.IsType(t, &statusObservedGenerationProbe{}, p)
 
@@ -84,7 +127,7 @@ func TestParseProbes(t *testing.T) {
 	nested := ogProbe.Prober
 	require.IsType(t, list{}, nested)
 
-	if assert.Len(t, nested, 2) {
+	if assert.Len(t, nested, 4) {
 		nestedList := nested.(list)
 		assert.Equal(t, &fieldsEqualProbe{
 			FieldA: "asdf",
@@ -94,5 +137,14 @@ func TestParseProbes(t *testing.T) {
 			Type:   "asdf",
 			Status: "asdf",
 		}, nestedList[1])
+		assert.Equal(t, &httpGetProbe{
+			Path:               "/healthz",
+			Port:               intstr.FromString("http"),
+			ExpectedStatusCode: 204,
+			Timeout:            3 * time.Second,
+		}, nestedList[2])
+		assert.Equal(t, &tcpSocketProbe{
+			Port: intstr.FromInt(5432),
+		}, nestedList[3])
 	}
 }
@@ -3,6 +3,7 @@ package probing
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -12,21 +13,75 @@ type Prober interface {
 	Probe(obj *unstructured.Unstructured) (success bool, message string)
 }
 
+// RecheckProber is implemented by Probers that can suggest
+// an interval after which a failing object should be probed again.
+// Passing objects may also get a suggestion, e.g. while their failures are tolerated.
+type RecheckProber interface {
+	Prober
+	ProbeWithRecheck(obj *unstructured.Unstructured) (success bool, message string, recheckAfter time.Duration)
+}
+
+// ProbeWithRecheck probes the given object and returns the suggested recheck interval of the Prober.
+// A recheckAfter of 0 means that the Prober has no suggestion.
+func ProbeWithRecheck(p Prober, obj *unstructured.Unstructured) (success bool, message string, recheckAfter time.Duration) {
+	if rp, ok := p.(RecheckProber); ok {
+		return rp.ProbeWithRecheck(obj)
+	}
+	success, message = p.Probe(obj)
+	return success, message, 0
+}
+
 type list []Prober
 
-var _ Prober = (list)(nil)
+var _ RecheckProber = (list)(nil)
 
 func (p list) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	success, message, _ = p.ProbeWithRecheck(obj)
+	return
+}
+
+// ProbeWithRecheck suggests the shortest recheck interval of all probes.
+func (p list) ProbeWithRecheck(obj *unstructured.Unstructured) (success bool, message string, recheckAfter time.Duration) {
 	var messages []string
 	for _, probe := range p {
-		if success, message := probe.Probe(obj); !success {
-			messages = append(messages, message)
+		success, message, probeRecheckAfter := ProbeWithRecheck(probe, obj)
+		recheckAfter = minRecheckAfter(recheckAfter, probeRecheckAfter)
+		if success {
+			continue
 		}
+		messages = append(messages, message)
 	}
 	if len(messages) > 0 {
-		return false, strings.Join(messages, ", ")
+		return false, strings.Join(messages, ", "), recheckAfter
 	}
-	return true, ""
+	return true, "", recheckAfter
+}
+
+// minRecheckAfter returns the shorter of two recheck intervals, ignoring unset (0) intervals.
+func minRecheckAfter(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// recheckProbe wraps the given Prober and suggests a fixed interval to probe failing objects again.
+type recheckProbe struct {
+	Prober
+	Interval time.Duration
+}
+
+var _ RecheckProber = (*recheckProbe)(nil)
+
+func (rp *recheckProbe) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	return rp.Prober.Probe(obj)
+}
+
+func (rp *recheckProbe) ProbeWithRecheck(obj *unstructured.Unstructured) (success bool, message string, recheckAfter time.Duration) {
+	if success, message = rp.Prober.Probe(obj); success {
+		return true, "", 0
+	}
+	return false, message, rp.Interval
 }
 
 // conditionProbe checks if the object's condition is set and in a certain status.
@@ -130,13 +185,21 @@ type statusObservedGenerationProbe struct {
 	Prober
 }
 
-var _ Prober = (*statusObservedGenerationProbe)(nil)
+var _ RecheckProber = (*statusObservedGenerationProbe)(nil)
 
 func (cg *statusObservedGenerationProbe) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	success, message, _ = cg.ProbeWithRecheck(obj)
+	return
+}
+
+func (cg *statusObservedGenerationProbe) ProbeWithRecheck(
+	obj *unstructured.Unstructured,
+) (success bool, message string, recheckAfter time.Duration) {
 	if observedGeneration, ok, err := unstructured.NestedInt64(
 		obj.Object, "status", "observedGeneration",
 	); err == nil && ok && observedGeneration != obj.GetGeneration() {
-		return false, ".status outdated"
+		// the object will change, when the status is updated.
+		return false, ".status outdated", 0
 	}
-	return cg.Prober.Probe(obj)
+	return ProbeWithRecheck(cg.Prober, obj)
 }
@@ -2,6 +2,7 @@ package probing
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -39,6 +40,36 @@ func TestList(t *testing.T) {
 	assert.Equal(t, "error from prober1, error from prober2", m)
 }
 
+func TestList_ProbeWithRecheck(t *testing.T) {
+	t.Parallel()
+
+	failing := &proberMock{}
+	failing.
+		On("Probe", mock.Anything).
+		Return(false, "error")
+	succeeding := &proberMock{}
+	succeeding.
+		On("Probe", mock.Anything).
+		Return(true, "")
+
+	l := list{
+		&recheckProbe{Prober: failing, Interval: 10 * time.Minute},
+		&recheckProbe{Prober: failing, Interval: time.Minute},
+		// succeeding probes don't contribute to the recheck interval.
+		&recheckProbe{Prober: succeeding, Interval: time.Second},
+		failing,
+	}
+
+	s, m, recheckAfter := ProbeWithRecheck(l, &unstructured.Unstructured{})
+	assert.False(t, s)
+	assert.Equal(t, "error, error, error", m)
+	assert.Equal(t, time.Minute, recheckAfter)
+
+	s, _, recheckAfter = ProbeWithRecheck(list{succeeding}, &unstructured.Unstructured{})
+	assert.True(t, s)
+	assert.Zero(t, recheckAfter)
+}
+
 func TestCondition(t *testing.T) {
 	c := &conditionProbe{
 		Type:   "Available",
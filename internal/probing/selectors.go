@@ -1,6 +1,8 @@
 package probing
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -12,16 +14,23 @@ type kindSelector struct {
 	schema.GroupKind
 }
 
+var _ RecheckProber = (*kindSelector)(nil)
+
 func (kp *kindSelector) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	success, message, _ = kp.ProbeWithRecheck(obj)
+	return
+}
+
+func (kp *kindSelector) ProbeWithRecheck(obj *unstructured.Unstructured) (success bool, message string, recheckAfter time.Duration) {
 	gvk := obj.GetObjectKind().GroupVersionKind()
 	if kp.Kind == gvk.Kind &&
 		kp.Group == gvk.Group {
-		return kp.Prober.Probe(obj)
+		return ProbeWithRecheck(kp.Prober, obj)
 	}
 
 	// We want to _skip_ objects, that don't match.
 	// So this probe succeeds by default.
-	return true, ""
+	return true, "", 0
 }
 
 type selectorSelector struct {
@@ -29,12 +38,19 @@ type selectorSelector struct {
 	labels.Selector
 }
 
+var _ RecheckProber = (*selectorSelector)(nil)
+
 func (ss *selectorSelector) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	success, message, _ = ss.ProbeWithRecheck(obj)
+	return
+}
+
+func (ss *selectorSelector) ProbeWithRecheck(obj *unstructured.Unstructured) (success bool, message string, recheckAfter time.Duration) {
 	if !ss.Selector.Matches(labels.Set(obj.GetLabels())) {
 		// We want to _skip_ objects, that don't match.
 		// So this probe succeeds by default.
-		return true, ""
+		return true, "", 0
 	}
 
-	return ss.Prober.Probe(obj)
+	return ProbeWithRecheck(ss.Prober, obj)
 }
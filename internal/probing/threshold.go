@@ -0,0 +1,167 @@
+package probing
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Period of probes with a failure threshold, if not configured.
+const defaultThresholdProbePeriod = 10 * time.Second
+
+// Clock returns the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// FailureTracker remembers consecutive probe failures of objects across reconciles,
+// so transient failures of objects that passed their probes before can be tolerated.
+// Failures are only kept in memory.
+type FailureTracker struct {
+	mu      sync.Mutex
+	records map[failureKey]*failureRecord
+}
+
+type failureKey struct {
+	// UID of the object owning the probes, e.g. an ObjectSet.
+	owner types.UID
+	// Index of the probe within the owner.
+	probe     int
+	groupKind schema.GroupKind
+	object    types.NamespacedName
+}
+
+type failureRecord struct {
+	passed      bool
+	failures    int
+	lastFailure time.Time
+}
+
+// NewFailureTracker returns an empty FailureTracker.
+func NewFailureTracker() *FailureTracker {
+	return &FailureTracker{records: map[failureKey]*failureRecord{}}
+}
+
+// Forget drops all failures recorded for probes of the given owner,
+// e.g. when the owner is deleted.
+func (t *FailureTracker) Forget(owner types.UID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key := range t.records {
+		if key.owner == owner {
+			delete(t.records, key)
+		}
+	}
+}
+
+// Records a probe result and returns the number of consecutive failures
+// and whether the object passed the probe before.
+// Failures within the given period of the last counted failure are not counted again.
+func (t *FailureTracker) record(
+	key failureKey, success bool, now time.Time, period time.Duration,
+) (failures int, passed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rec, ok := t.records[key]
+	if !ok {
+		rec = &failureRecord{}
+		t.records[key] = rec
+	}
+
+	if success {
+		rec.passed = true
+		rec.failures = 0
+		return 0, true
+	}
+	if rec.failures == 0 || now.Sub(rec.lastFailure) >= period {
+		rec.failures++
+		rec.lastFailure = now
+	}
+	return rec.failures, rec.passed
+}
+
+// thresholdProbe wraps the given Prober, delaying probes of new objects
+// and tolerating transient failures of objects that passed before.
+type thresholdProbe struct {
+	Prober
+	InitialDelay     time.Duration
+	Period           time.Duration
+	FailureThreshold int
+
+	clock   Clock
+	tracker *FailureTracker
+	owner   types.UID
+	index   int
+}
+
+var _ RecheckProber = (*thresholdProbe)(nil)
+
+// Wraps the given Prober according to the timing of the ObjectSetProbe at the given index.
+func newThresholdProbe(
+	probe Prober, index int, spec corev1alpha1.ObjectSetProbe, opts ParseOptions,
+) *thresholdProbe {
+	tp := &thresholdProbe{
+		Prober:           probe,
+		InitialDelay:     time.Duration(spec.InitialDelaySeconds) * time.Second,
+		Period:           time.Duration(spec.PeriodSeconds) * time.Second,
+		FailureThreshold: int(spec.FailureThreshold),
+
+		clock:   opts.Clock,
+		tracker: opts.FailureTracker,
+		owner:   opts.Owner,
+		index:   index,
+	}
+	if tp.Period == 0 && tp.FailureThreshold > 1 {
+		tp.Period = defaultThresholdProbePeriod
+	}
+	return tp
+}
+
+func (tp *thresholdProbe) Probe(obj *unstructured.Unstructured) (success bool, message string) {
+	success, message, _ = tp.ProbeWithRecheck(obj)
+	return
+}
+
+func (tp *thresholdProbe) ProbeWithRecheck(
+	obj *unstructured.Unstructured,
+) (success bool, message string, recheckAfter time.Duration) {
+	now := tp.clock.Now()
+	if remaining := obj.GetCreationTimestamp().Add(tp.InitialDelay).Sub(now); remaining > 0 {
+		return false, "initial delay not passed", remaining
+	}
+
+	success, message, recheckAfter = ProbeWithRecheck(tp.Prober, obj)
+	if tp.FailureThreshold <= 1 || tp.tracker == nil {
+		if success {
+			return true, "", 0
+		}
+		return false, message, minRecheckAfter(recheckAfter, tp.Period)
+	}
+
+	failures, passed := tp.tracker.record(failureKey{
+		owner:     tp.owner,
+		probe:     tp.index,
+		groupKind: obj.GroupVersionKind().GroupKind(),
+		object:    types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()},
+	}, success, now, tp.Period)
+	switch {
+	case success:
+		return true, "", 0
+	case passed && failures < tp.FailureThreshold:
+		// Tolerated, but the object might not emit events when it stays failing.
+		return true, "", tp.Period
+	default:
+		return false, message, minRecheckAfter(recheckAfter, tp.Period)
+	}
+}
@@ -0,0 +1,136 @@
+package probing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func TestThresholdProbe_initialDelay(t *testing.T) {
+	t.Parallel()
+
+	// Creation timestamps only have second precision.
+	now := time.Now().Truncate(time.Second)
+	clock := clocktesting.NewFakePassiveClock(now)
+	prober := &proberMock{}
+	prober.On("Probe", mock.Anything).Return(true, "")
+
+	tp := newThresholdProbe(prober, 0, corev1alpha1.ObjectSetProbe{
+		InitialDelaySeconds: 30,
+	}, ParseOptions{Clock: clock})
+
+	obj := &unstructured.Unstructured{}
+	obj.SetCreationTimestamp(metav1.NewTime(now.Add(-10 * time.Second)))
+
+	s, m, recheckAfter := tp.ProbeWithRecheck(obj)
+	assert.False(t, s)
+	assert.Equal(t, "initial delay not passed", m)
+	assert.Equal(t, 20*time.Second, recheckAfter)
+	prober.AssertNotCalled(t, "Probe", mock.Anything)
+
+	clock.SetTime(now.Add(20 * time.Second))
+	s, _, _ = tp.ProbeWithRecheck(obj)
+	assert.True(t, s)
+}
+
+func TestThresholdProbe_failureThreshold(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	clock := clocktesting.NewFakePassiveClock(now)
+	prober := &toggleProber{}
+
+	tp := newThresholdProbe(prober, 0, corev1alpha1.ObjectSetProbe{
+		FailureThreshold: 3,
+	}, ParseOptions{Clock: clock, FailureTracker: NewFailureTracker()})
+	obj := &unstructured.Unstructured{}
+
+	// Objects that never passed fail right away.
+	prober.success = false
+	s, _, recheckAfter := tp.ProbeWithRecheck(obj)
+	assert.False(t, s)
+	assert.Equal(t, defaultThresholdProbePeriod, recheckAfter)
+
+	prober.success = true
+	s, _, _ = tp.ProbeWithRecheck(obj)
+	assert.True(t, s)
+
+	// Failures are tolerated until the threshold is crossed.
+	prober.success = false
+	s, _, recheckAfter = tp.ProbeWithRecheck(obj)
+	assert.True(t, s)
+	assert.Equal(t, defaultThresholdProbePeriod, recheckAfter)
+
+	// Failures within the period are not counted again.
+	clock.SetTime(now.Add(time.Second))
+	s, _, _ = tp.ProbeWithRecheck(obj)
+	assert.True(t, s)
+
+	clock.SetTime(now.Add(defaultThresholdProbePeriod + time.Second))
+	s, _, _ = tp.ProbeWithRecheck(obj)
+	assert.True(t, s)
+
+	clock.SetTime(now.Add(2*defaultThresholdProbePeriod + time.Second))
+	s, m, _ := tp.ProbeWithRecheck(obj)
+	assert.False(t, s)
+	assert.Equal(t, "error", m)
+
+	// Passing resets the count.
+	prober.success = true
+	s, _, _ = tp.ProbeWithRecheck(obj)
+	assert.True(t, s)
+	prober.success = false
+	s, _, _ = tp.ProbeWithRecheck(obj)
+	assert.True(t, s)
+}
+
+func TestFailureTracker_Forget(t *testing.T) {
+	t.Parallel()
+
+	tracker := NewFailureTracker()
+	key := failureKey{owner: "owner"}
+	tracker.record(key, true, time.Now(), time.Second)
+	tracker.record(failureKey{owner: "other"}, true, time.Now(), time.Second)
+
+	tracker.Forget("owner")
+	assert.Len(t, tracker.records, 1)
+	_, passed := tracker.record(key, false, time.Now(), time.Second)
+	assert.False(t, passed)
+}
+
+func TestParse_thresholds(t *testing.T) {
+	t.Parallel()
+
+	p, err := Parse(context.Background(), []corev1alpha1.ObjectSetProbe{{
+		PeriodSeconds:    5,
+		FailureThreshold: 2,
+	}}, WithFailureTracker{FailureTracker: NewFailureTracker(), Owner: "owner"})
+	require.NoError(t, err)
+
+	tp, ok := p.(list)[0].(*thresholdProbe)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Second, tp.Period)
+	assert.Equal(t, 2, tp.FailureThreshold)
+	assert.Equal(t, "owner", string(tp.owner))
+}
+
+// Prober failing with "error", until success is set.
+type toggleProber struct {
+	success bool
+}
+
+func (p *toggleProber) Probe(*unstructured.Unstructured) (success bool, message string) {
+	if p.success {
+		return true, ""
+	}
+	return false, "error"
+}
@@ -0,0 +1,41 @@
+package testutil
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+// NewCRDValidator returns a function evaluating the x-kubernetes-validations rules
+// of the CRD at the given path against an object.
+func NewCRDValidator(t *testing.T, path string) func(obj map[string]interface{}) field.ErrorList {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, yaml.Unmarshal(b, crd))
+	require.Len(t, crd.Spec.Versions, 1)
+
+	props := &apiextensions.JSONSchemaProps{}
+	require.NoError(t, apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(
+		crd.Spec.Versions[0].Schema.OpenAPIV3Schema, props, nil))
+	structural, err := schema.NewStructural(props)
+	require.NoError(t, err)
+
+	validator := cel.NewValidator(structural, true, cel.PerCallLimit)
+	require.NotNil(t, validator)
+
+	return func(obj map[string]interface{}) field.ErrorList {
+		errs, _ := validator.Validate(context.Background(), nil, structural, obj, nil, cel.RuntimeCELCostBudget)
+		return errs
+	}
+}
@@ -3,6 +3,7 @@ package dynamiccachemocks
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,11 +38,20 @@ func (c *DynamicCacheMock) Free(ctx context.Context, obj client.Object) error {
 
 func (c *DynamicCacheMock) Watch(
 	ctx context.Context, owner client.Object, obj runtime.Object,
+	_ ...dynamiccache.WatchOption,
 ) error {
 	args := c.Called(ctx, owner, obj)
 	return args.Error(0)
 }
 
+func (c *DynamicCacheMock) ListByIndex(
+	ctx context.Context, field, value string,
+) ([]unstructured.Unstructured, error) {
+	args := c.Called(ctx, field, value)
+	objs, _ := args.Get(0).([]unstructured.Unstructured)
+	return objs, args.Error(1)
+}
+
 func (c *DynamicCacheMock) OwnersForGKV(gvk schema.GroupVersionKind) []dynamiccache.OwnerReference {
 	args := c.Called(gvk)
 	return args.Get(0).([]dynamiccache.OwnerReference)
@@ -0,0 +1,167 @@
+// Package faultinjection wraps clients, caches and event sources used by controllers
+// with programmable faults, so controller resilience can be tested deterministically.
+//
+// Faults are registered on an Injector, which is shared by all wrappers of a test:
+//
+//	inj := faultinjection.NewInjector()
+//	inj.Inject(faultinjection.ConflictOnce(faultinjection.VerbPatch))
+//	writer := faultinjection.NewWriter(c, inj)
+package faultinjection
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Verb identifies the intercepted operation.
+type Verb string
+
+const (
+	VerbGet         Verb = "Get"
+	VerbList        Verb = "List"
+	VerbCreate      Verb = "Create"
+	VerbUpdate      Verb = "Update"
+	VerbPatch       Verb = "Patch"
+	VerbDelete      Verb = "Delete"
+	VerbDeleteAllOf Verb = "DeleteAllOf"
+	VerbWatch       Verb = "Watch"
+	// Delivery of an event from an event source to an event handler.
+	VerbEvent Verb = "Event"
+)
+
+// Fault describes how calls matching it misbehave.
+type Fault struct {
+	// Verb the fault applies to, empty matches all verbs.
+	Verb Verb
+	// Optional filter to only match calls for specific objects.
+	// obj is nil for List and DeleteAllOf calls.
+	Match func(obj client.Object) bool
+	// Delays the call, returns the context error if the context is done first.
+	Delay time.Duration
+	// Executed before the call, e.g. to simulate a concurrent actor.
+	// A returned error is returned from the call instead.
+	Before func(ctx context.Context, obj client.Object) error
+	// Returned instead of performing the call.
+	// For VerbEvent, any matching fault with a non-nil Err drops the event.
+	Err error
+	// Number of times the fault is injected, 0 injects it forever.
+	Times int
+}
+
+// Call records an intercepted call.
+type Call struct {
+	Verb Verb
+	// Key of the object, empty for List and DeleteAllOf calls.
+	Key client.ObjectKey
+	// Error that was injected, if any.
+	Err error
+}
+
+// Injector holds the faults shared by all wrappers of a test.
+// It is safe for concurrent use.
+type Injector struct {
+	mu     sync.Mutex
+	faults []*injectedFault
+	calls  []Call
+}
+
+type injectedFault struct {
+	Fault
+	remaining int
+}
+
+func NewInjector() *Injector {
+	return &Injector{}
+}
+
+// Inject registers a new fault.
+// When multiple faults match a call, they are applied in registration order
+// and the first error is returned.
+func (i *Injector) Inject(faults ...Fault) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, f := range faults {
+		i.faults = append(i.faults, &injectedFault{Fault: f, remaining: f.Times})
+	}
+}
+
+// Reset removes all faults and recorded calls.
+func (i *Injector) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.faults = nil
+	i.calls = nil
+}
+
+// Calls returns all intercepted calls in order.
+func (i *Injector) Calls() []Call {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]Call(nil), i.calls...)
+}
+
+// intercept applies all faults matching the call.
+// A non-nil error must be returned to the caller instead of performing the call.
+func (i *Injector) intercept(ctx context.Context, verb Verb, obj client.Object) error {
+	faults := i.take(verb, obj)
+
+	var err error
+	for _, f := range faults {
+		if f.Delay > 0 {
+			t := time.NewTimer(f.Delay)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				err = ctx.Err()
+			case <-t.C:
+			}
+		}
+		if err == nil && f.Before != nil {
+			err = f.Before(ctx, obj)
+		}
+		if err == nil {
+			err = f.Err
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	call := Call{Verb: verb, Err: err}
+	if obj != nil {
+		call.Key = client.ObjectKeyFromObject(obj)
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.calls = append(i.calls, call)
+	return err
+}
+
+// Returns matching faults and consumes one injection of each.
+func (i *Injector) take(verb Verb, obj client.Object) []Fault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var (
+		matching []Fault
+		active   = i.faults[:0]
+	)
+	for _, f := range i.faults {
+		if (len(f.Verb) == 0 || f.Verb == verb) &&
+			(f.Match == nil || f.Match(obj)) {
+			matching = append(matching, f.Fault)
+			if f.Times > 0 {
+				f.remaining--
+				if f.remaining == 0 {
+					continue
+				}
+			}
+		}
+		active = append(active, f)
+	}
+	i.faults = active
+	return matching
+}
@@ -0,0 +1,125 @@
+package faultinjection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+func newConfigMap(name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+	}
+}
+
+func TestWriter_conflict(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+	inj := NewInjector()
+	w := NewWriter(c, inj)
+	inj.Inject(Conflict(VerbCreate, 2))
+
+	ctx := context.Background()
+	err := w.Create(ctx, newConfigMap("test"))
+	assert.True(t, apierrors.IsConflict(err), "expected conflict, got: %v", err)
+	err = w.Create(ctx, newConfigMap("test"))
+	assert.True(t, apierrors.IsConflict(err), "expected conflict, got: %v", err)
+	require.NoError(t, w.Create(ctx, newConfigMap("test")))
+
+	calls := inj.Calls()
+	if assert.Len(t, calls, 3) {
+		assert.Equal(t, client.ObjectKey{Name: "test", Namespace: "default"}, calls[2].Key)
+		assert.NoError(t, calls[2].Err)
+	}
+}
+
+func TestWriter_createRace(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+	inj := NewInjector()
+	w := NewWriter(c, inj)
+	inj.Inject(CreateRace(c, func(obj client.Object) {
+		obj.SetLabels(map[string]string{"winner": "other"})
+	}))
+
+	ctx := context.Background()
+	err := w.Create(ctx, newConfigMap("test"))
+	assert.True(t, apierrors.IsAlreadyExists(err), "expected already exists, got: %v", err)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "test", Namespace: "default"}, cm))
+	assert.Equal(t, "other", cm.Labels["winner"])
+}
+
+func TestReader_match(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).
+		WithObjects(newConfigMap("a"), newConfigMap("b")).Build()
+	inj := NewInjector()
+	cache := NewCache(Watchless(c), inj)
+	inj.Inject(Fault{
+		Verb:  VerbGet,
+		Match: ForObject(client.ObjectKey{Name: "a", Namespace: "default"}),
+		Err:   apierrors.NewServiceUnavailable("injected"),
+	})
+
+	ctx := context.Background()
+	err := cache.Get(ctx, client.ObjectKey{Name: "a", Namespace: "default"}, &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsServiceUnavailable(err))
+	require.NoError(t, cache.Get(ctx, client.ObjectKey{Name: "b", Namespace: "default"}, &corev1.ConfigMap{}))
+	require.NoError(t, cache.Watch(ctx, newConfigMap("owner"), &corev1.ConfigMap{}))
+
+	inj.Reset()
+	require.NoError(t, cache.Get(ctx, client.ObjectKey{Name: "a", Namespace: "default"}, &corev1.ConfigMap{}))
+}
+
+func TestInjector_slow(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+	inj := NewInjector()
+	w := NewWriter(c, inj)
+	inj.Inject(Slow(VerbCreate, time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := w.Create(ctx, newConfigMap("test"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+type sourceMock struct {
+	handler handler.EventHandler
+}
+
+func (s *sourceMock) Start(
+	_ context.Context, h handler.EventHandler,
+	_ workqueue.RateLimitingInterface, _ ...predicate.Predicate,
+) error {
+	s.handler = h
+	return nil
+}
+
+func TestSource_dropEvents(t *testing.T) {
+	inj := NewInjector()
+	src := &sourceMock{}
+	inj.Inject(DropEvents(1))
+
+	var received int
+	h := handler.Funcs{
+		CreateFunc: func(event.CreateEvent, workqueue.RateLimitingInterface) { received++ },
+	}
+	require.NoError(t, NewSource(src, inj).Start(context.Background(), h, nil))
+
+	src.handler.Create(event.CreateEvent{Object: newConfigMap("test")}, nil)
+	assert.Equal(t, 0, received)
+	src.handler.Create(event.CreateEvent{Object: newConfigMap("test")}, nil)
+	assert.Equal(t, 1, received)
+}
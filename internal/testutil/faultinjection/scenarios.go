@@ -0,0 +1,63 @@
+package faultinjection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrDropped is used to drop events via VerbEvent faults.
+var ErrDropped = errors.New("event dropped by fault injection")
+
+// ForObject returns a Match function selecting objects with the given key.
+func ForObject(key client.ObjectKey) func(obj client.Object) bool {
+	return func(obj client.Object) bool {
+		return obj != nil && client.ObjectKeyFromObject(obj) == key
+	}
+}
+
+// Conflict fails the given number of calls with a Conflict error,
+// like concurrent writers modifying the same object would.
+func Conflict(verb Verb, times int) Fault {
+	return Fault{
+		Verb:  verb,
+		Err:   apierrors.NewConflict(schema.GroupResource{}, "", errors.New("injected conflict")),
+		Times: times,
+	}
+}
+
+// ConflictOnce fails the next call with a Conflict error.
+func ConflictOnce(verb Verb) Fault {
+	return Conflict(verb, 1)
+}
+
+// Slow delays all calls by the given duration.
+func Slow(verb Verb, delay time.Duration) Fault {
+	return Fault{Verb: verb, Delay: delay}
+}
+
+// DropEvents drops the given number of events.
+func DropEvents(times int) Fault {
+	return Fault{Verb: VerbEvent, Err: ErrDropped, Times: times}
+}
+
+// CreateRace creates the object via the given writer right before the next Create call,
+// simulating another actor winning the race, so the call fails with AlreadyExists.
+// Use it to test adoption of objects created concurrently.
+func CreateRace(other client.Writer, mutate func(obj client.Object)) Fault {
+	return Fault{
+		Verb: VerbCreate,
+		Before: func(ctx context.Context, obj client.Object) error {
+			competing := obj.DeepCopyObject().(client.Object)
+			if mutate != nil {
+				mutate(competing)
+			}
+			return other.Create(ctx, competing)
+		},
+		Times: 1,
+	}
+}
@@ -0,0 +1,202 @@
+package faultinjection
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"package-operator.run/package-operator/internal/dynamiccache"
+)
+
+// Writer injects faults into client.Writer calls.
+type Writer struct {
+	client.Writer
+	injector *Injector
+}
+
+var _ client.Writer = (*Writer)(nil)
+
+func NewWriter(w client.Writer, injector *Injector) *Writer {
+	return &Writer{Writer: w, injector: injector}
+}
+
+func (w *Writer) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := w.injector.intercept(ctx, VerbCreate, obj); err != nil {
+		return err
+	}
+	return w.Writer.Create(ctx, obj, opts...)
+}
+
+func (w *Writer) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := w.injector.intercept(ctx, VerbDelete, obj); err != nil {
+		return err
+	}
+	return w.Writer.Delete(ctx, obj, opts...)
+}
+
+func (w *Writer) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := w.injector.intercept(ctx, VerbUpdate, obj); err != nil {
+		return err
+	}
+	return w.Writer.Update(ctx, obj, opts...)
+}
+
+func (w *Writer) Patch(
+	ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption,
+) error {
+	if err := w.injector.intercept(ctx, VerbPatch, obj); err != nil {
+		return err
+	}
+	return w.Writer.Patch(ctx, obj, patch, opts...)
+}
+
+func (w *Writer) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if err := w.injector.intercept(ctx, VerbDeleteAllOf, nil); err != nil {
+		return err
+	}
+	return w.Writer.DeleteAllOf(ctx, obj, opts...)
+}
+
+// Reader injects faults into client.Reader calls.
+type Reader struct {
+	client.Reader
+	injector *Injector
+}
+
+var _ client.Reader = (*Reader)(nil)
+
+func NewReader(r client.Reader, injector *Injector) *Reader {
+	return &Reader{Reader: r, injector: injector}
+}
+
+func (r *Reader) Get(
+	ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption,
+) error {
+	// Faults match against the requested key, obj may still be empty.
+	requested := obj.DeepCopyObject().(client.Object)
+	requested.SetName(key.Name)
+	requested.SetNamespace(key.Namespace)
+	if err := r.injector.intercept(ctx, VerbGet, requested); err != nil {
+		return err
+	}
+	return r.Reader.Get(ctx, key, obj, opts...)
+}
+
+func (r *Reader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := r.injector.intercept(ctx, VerbList, nil); err != nil {
+		return err
+	}
+	return r.Reader.List(ctx, list, opts...)
+}
+
+// WatchReader is the interface of caches reading watched objects, e.g. *dynamiccache.Cache.
+type WatchReader interface {
+	client.Reader
+	Watch(
+		ctx context.Context, owner client.Object, obj runtime.Object,
+		opts ...dynamiccache.WatchOption,
+	) error
+}
+
+// Cache injects faults into calls to a dynamic cache,
+// it can be used in place of the PhaseCache of a PhaseReconciler.
+type Cache struct {
+	*Reader
+	cache WatchReader
+}
+
+// NewCache wraps a WatchReader, use Watchless to wrap readers without watch support.
+func NewCache(cache WatchReader, injector *Injector) *Cache {
+	return &Cache{
+		Reader: NewReader(cache, injector),
+		cache:  cache,
+	}
+}
+
+// Watch injects faults matching the owner of the watch.
+func (c *Cache) Watch(
+	ctx context.Context, owner client.Object, obj runtime.Object,
+	opts ...dynamiccache.WatchOption,
+) error {
+	if err := c.injector.intercept(ctx, VerbWatch, owner); err != nil {
+		return err
+	}
+	return c.cache.Watch(ctx, owner, obj, opts...)
+}
+
+// Watchless adapts a reader that needs no watches, e.g. a fake client, into a WatchReader.
+func Watchless(r client.Reader) WatchReader {
+	return watchlessReader{Reader: r}
+}
+
+type watchlessReader struct {
+	client.Reader
+}
+
+func (watchlessReader) Watch(
+	context.Context, client.Object, runtime.Object, ...dynamiccache.WatchOption,
+) error {
+	return nil
+}
+
+// Source drops events of the wrapped source.Source,
+// when a VerbEvent fault with non-nil Err matches the event object.
+type Source struct {
+	source.Source
+	injector *Injector
+}
+
+var _ source.Source = (*Source)(nil)
+
+func NewSource(src source.Source, injector *Injector) *Source {
+	return &Source{Source: src, injector: injector}
+}
+
+func (s *Source) Start(
+	ctx context.Context, h handler.EventHandler,
+	queue workqueue.RateLimitingInterface, predicates ...predicate.Predicate,
+) error {
+	return s.Source.Start(ctx, &droppingHandler{
+		ctx: ctx, handler: h, injector: s.injector,
+	}, queue, predicates...)
+}
+
+type droppingHandler struct {
+	ctx      context.Context
+	handler  handler.EventHandler
+	injector *Injector
+}
+
+func (h *droppingHandler) drop(obj client.Object) bool {
+	return h.injector.intercept(h.ctx, VerbEvent, obj) != nil
+}
+
+func (h *droppingHandler) Create(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	if !h.drop(e.Object) {
+		h.handler.Create(e, q)
+	}
+}
+
+func (h *droppingHandler) Update(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	if !h.drop(e.ObjectNew) {
+		h.handler.Update(e, q)
+	}
+}
+
+func (h *droppingHandler) Delete(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	if !h.drop(e.Object) {
+		h.handler.Delete(e, q)
+	}
+}
+
+func (h *droppingHandler) Generic(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	if !h.drop(e.Object) {
+		h.handler.Generic(e, q)
+	}
+}
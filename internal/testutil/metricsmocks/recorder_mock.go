@@ -16,3 +16,7 @@ func (r *RecorderMock) RecordDynamicCacheInformers(informerCount int) {
 func (r *RecorderMock) RecordDynamicCacheObjects(gvk schema.GroupVersionKind, count int) {
 	r.Called(gvk, count)
 }
+
+func (r *RecorderMock) RecordDynamicCacheObjectsTotal(total int) {
+	r.Called(total)
+}
@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"package-operator.run/package-operator/internal/version"
+)
+
+// Name of the tracer creating all package-operator spans.
+const TracerName = "package-operator.run/package-operator"
+
+// Span attribute keys.
+const (
+	PhaseKey     = attribute.Key("package-operator.phase")
+	ImageKey     = attribute.Key("package-operator.image")
+	KindKey      = attribute.Key("k8s.object.kind")
+	NamespaceKey = attribute.Key("k8s.object.namespace")
+	NameKey      = attribute.Key("k8s.object.name")
+)
+
+// Start starts a new span from the globally registered TracerProvider.
+// Spans are dropped, unless a TracerProvider was registered via Setup.
+func Start(
+	ctx context.Context, name string, attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the given error on the span, if not nil, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ObjectAttributes returns span attributes identifying the given object.
+func ObjectAttributes(obj client.Object) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		KindKey.String(obj.GetObjectKind().GroupVersionKind().Kind),
+		NamespaceKey.String(obj.GetNamespace()),
+		NameKey.String(obj.GetName()),
+	}
+}
+
+// RequestAttributes returns span attributes identifying the object of a reconcile request.
+func RequestAttributes(req ctrl.Request) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		NamespaceKey.String(req.Namespace),
+		NameKey.String(req.Name),
+	}
+}
+
+// Config configures span export.
+type Config struct {
+	// OTLP gRPC endpoint to export spans to, tracing is disabled if empty.
+	Endpoint string
+	// Disables transport security when connecting to the endpoint.
+	Insecure bool
+	// Fraction of reconciles to trace, between 0 and 1.
+	SampleRatio float64
+}
+
+// Setup registers a global TracerProvider exporting spans via OTLP.
+// The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	if len(cfg.Endpoint) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("package-operator"),
+		semconv.ServiceVersion(version.Get().ApplicationVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("creating trace resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tp.Shutdown, nil
+}
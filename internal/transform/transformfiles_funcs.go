@@ -2,12 +2,16 @@ package transform
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
 )
 
+// ErrLookupNameRequired is returned by the lookup template function, when called without an object name.
+var ErrLookupNameRequired = errors.New("name is required")
+
 // allow all sprig functions except dates, random, crypto, os, network and filepath.
 var allowedFuncNames = map[string]struct{}{
 	"hello": {},
@@ -207,8 +211,14 @@ var allowedFuncNames = map[string]struct{}{
 	"urlJoin":  {},
 }
 
-func TemplateWithSprigFuncs(content string) (*template.Template, error) {
-	return template.New("").Option("missingkey=error").Funcs(SprigFuncs()).Parse(content)
+// TemplateWithSprigFuncs parses the given template with the allowed sprig functions
+// and optional additional functions available.
+func TemplateWithSprigFuncs(content string, extraFuncs ...template.FuncMap) (*template.Template, error) {
+	t := template.New("").Option("missingkey=error").Funcs(SprigFuncs())
+	for _, funcs := range extraFuncs {
+		t = t.Funcs(funcs)
+	}
+	return t.Parse(content)
 }
 
 func SprigFuncs() template.FuncMap {
@@ -242,3 +252,25 @@ func base64decodeMap(data map[string]interface{}) (
 
 	return decodedData, nil
 }
+
+// LookupFunc reads an object from the cluster for the lookup template function.
+// Implementations return an empty map, if the object does not exist.
+type LookupFunc func(apiVersion, kind, namespace, name string) (map[string]interface{}, error)
+
+// LookupFuncs returns the lookup template function reading objects via the given LookupFunc:
+// {{ lookup "storage.k8s.io/v1" "StorageClass" "" "gp3" }}.
+// Lookups return an empty map, if no LookupFunc is given,
+// e.g. when rendering packages without access to a cluster.
+func LookupFuncs(lookup LookupFunc) template.FuncMap {
+	return template.FuncMap{
+		"lookup": func(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+			if len(name) == 0 {
+				return nil, fmt.Errorf("lookup %s %s: %w", apiVersion, kind, ErrLookupNameRequired)
+			}
+			if lookup == nil {
+				return map[string]interface{}{}, nil
+			}
+			return lookup(apiVersion, kind, namespace, name)
+		},
+	}
+}
@@ -1,6 +1,7 @@
 package transform
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -95,3 +96,59 @@ func Test_base64decodeMap(t *testing.T) {
 		"test": "abcdef",
 	}, out)
 }
+
+func TestLookupFuncs(t *testing.T) {
+	lookup := func(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+		if name != "gp3" {
+			return map[string]interface{}{}, nil
+		}
+		return map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata":   map[string]interface{}{"name": name},
+		}, nil
+	}
+
+	tests := []struct {
+		name     string
+		lookup   LookupFunc
+		input    string
+		expected string
+	}{
+		{
+			name:     "found",
+			lookup:   lookup,
+			input:    `{{ (lookup "storage.k8s.io/v1" "StorageClass" "" "gp3").metadata.name }}`,
+			expected: "gp3",
+		},
+		{
+			name:     "not found",
+			lookup:   lookup,
+			input:    `{{ if lookup "storage.k8s.io/v1" "StorageClass" "" "gp2" }}found{{ else }}missing{{ end }}`,
+			expected: "missing",
+		},
+		{
+			name:     "no cluster",
+			input:    `{{ if lookup "storage.k8s.io/v1" "StorageClass" "" "gp3" }}found{{ else }}missing{{ end }}`,
+			expected: "missing",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := TemplateWithSprigFuncs(test.input, LookupFuncs(test.lookup))
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, tmpl.Execute(&out, nil))
+			assert.Equal(t, test.expected, out.String())
+		})
+	}
+
+	t.Run("name required", func(t *testing.T) {
+		tmpl, err := TemplateWithSprigFuncs(`{{ lookup "v1" "ConfigMap" "" "" }}`, LookupFuncs(lookup))
+		require.NoError(t, err)
+		err = tmpl.Execute(&bytes.Buffer{}, nil)
+		require.ErrorIs(t, err, ErrLookupNameRequired)
+	})
+}
@@ -1,6 +1,10 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
 )
 
@@ -11,3 +15,15 @@ func GetObjectsFromPhases(phases []corev1alpha1.ObjectSetTemplatePhase) []corev1
 	}
 	return result
 }
+
+// ComputeObjectsChecksum returns a SHA-256 checksum of the JSON encoded objects.
+// JSON is hashed instead of the in-memory representation,
+// so the checksum stays stable when objects are read back from the API server.
+func ComputeObjectsChecksum(objects []corev1alpha1.ObjectSetObject) (string, error) {
+	j, err := json.Marshal(objects)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(j)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,76 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/admission/v1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+// Defaults new ObjectSets before they are validated.
+type GenericObjectSetDefaultingWebhookHandler[T objectSets] struct {
+	decoder *admission.Decoder
+	log     logr.Logger
+}
+
+func NewObjectSetDefaultingWebhookHandler(
+	log logr.Logger,
+) *GenericObjectSetDefaultingWebhookHandler[corev1alpha1.ObjectSet] {
+	return &GenericObjectSetDefaultingWebhookHandler[corev1alpha1.ObjectSet]{
+		log: log,
+	}
+}
+
+func NewClusterObjectSetDefaultingWebhookHandler(
+	log logr.Logger,
+) *GenericObjectSetDefaultingWebhookHandler[corev1alpha1.ClusterObjectSet] {
+	return &GenericObjectSetDefaultingWebhookHandler[corev1alpha1.ClusterObjectSet]{
+		log: log,
+	}
+}
+
+func (wh *GenericObjectSetDefaultingWebhookHandler[T]) Handle(
+	_ context.Context, req admission.Request,
+) admission.Response {
+	if req.Operation != v1.Operation(admissionv1beta1.Create) {
+		return admission.Allowed("operation allowed")
+	}
+
+	obj := new(T)
+	if err := wh.decoder.Decode(req, any(obj).(client.Object)); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	defaultGenericObjectSet(obj)
+
+	defaulted, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+}
+
+func (wh *GenericObjectSetDefaultingWebhookHandler[T]) InjectDecoder(d *admission.Decoder) error {
+	wh.decoder = d
+	return nil
+}
+
+func defaultGenericObjectSet[T objectSets](obj *T) {
+	var lifecycleState *corev1alpha1.ObjectSetLifecycleState
+	switch v := any(obj).(type) {
+	case *corev1alpha1.ClusterObjectSet:
+		lifecycleState = &v.Spec.LifecycleState
+	case *corev1alpha1.ObjectSet:
+		lifecycleState = &v.Spec.LifecycleState
+	}
+
+	if len(*lifecycleState) == 0 {
+		*lifecycleState = corev1alpha1.ObjectSetLifecycleStateActive
+	}
+}
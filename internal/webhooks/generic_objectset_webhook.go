@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
 )
 
 type objectSets interface {
@@ -70,6 +71,8 @@ func (wh *GenericObjectSetWebhookHandler[T]) Handle(_ context.Context, req admis
 	}
 
 	switch req.Operation {
+	case v1.Operation(admissionv1beta1.Create):
+		return wh.validateCreate(obj)
 	case v1.Operation(admissionv1beta1.Update):
 		oldObj := wh.newObjectSet()
 		if err := wh.decoder.DecodeRaw(
@@ -87,6 +90,13 @@ func (wh *GenericObjectSetWebhookHandler[T]) InjectDecoder(d *admission.Decoder)
 	return nil
 }
 
+func (wh *GenericObjectSetWebhookHandler[T]) validateCreate(obj *T) admission.Response {
+	if errs := validateGenericObjectSetSpec(obj); len(errs) > 0 {
+		return admission.Denied(errs.ToAggregate().Error())
+	}
+	return admission.Allowed("operation allowed")
+}
+
 func (wh *GenericObjectSetWebhookHandler[T]) validateUpdate(
 	obj, oldObj *T,
 ) admission.Response {
@@ -105,7 +115,9 @@ func validateGenericObjectSetImmutability[T objectSets](obj, oldObj *T) error {
 	specFields := field.NewPath("spec")
 	if !equality.Semantic.DeepEqual(
 		newFields.ObjectSetTemplateSpec,
-		oldFields.ObjectSetTemplateSpec) {
+		oldFields.ObjectSetTemplateSpec) &&
+		!isArchiveCompaction(obj, oldObj) &&
+		!isRehydration(obj, oldObj) {
 		allErrs = append(allErrs,
 			field.Invalid(specFields.Child("phases"), "", "is immutable"))
 		allErrs = append(allErrs,
@@ -118,12 +130,67 @@ func validateGenericObjectSetImmutability[T objectSets](obj, oldObj *T) error {
 			field.Invalid(specFields.Child("previous"), "", "is immutable"))
 	}
 
+	// Compacted ObjectSets lost their full object definitions,
+	// so they can only be reconciled again after being rehydrated from their package image.
+	if isCompacted(oldObj) {
+		if objectSetLifecycleState(obj) != corev1alpha1.ObjectSetLifecycleStateArchived &&
+			!controllers.IsRehydratable(any(oldObj).(client.Object)) {
+			allErrs = append(allErrs, field.Forbidden(specFields.Child("lifecycleState"),
+				"compacted ObjectSets not templated from a package image can't leave the Archived state"))
+		}
+		if !isCompacted(obj) && !isRehydration(obj, oldObj) {
+			allErrs = append(allErrs, field.Forbidden(
+				field.NewPath("metadata", "annotations").Key(corev1alpha1.ObjectSetCompactedAnnotation),
+				"can only be removed when rehydrating phase objects"))
+		}
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return allErrs.ToAggregate()
 }
 
+// Phase objects of archived ObjectSets may be compacted once,
+// as long as the identity of all objects is retained.
+func isArchiveCompaction[T objectSets](obj, oldObj *T) bool {
+	if !isCompacted(obj) ||
+		objectSetLifecycleState(oldObj) != corev1alpha1.ObjectSetLifecycleStateArchived {
+		return false
+	}
+
+	compacted := objectSetImmutableFields(oldObj).ObjectSetTemplateSpec
+	compacted.Phases = controllers.CompactPhases(compacted.Phases)
+	return equality.Semantic.DeepEqual(objectSetImmutableFields(obj).ObjectSetTemplateSpec, compacted)
+}
+
+// Phase objects of compacted ObjectSets leaving the Archived state are restored once,
+// as long as they have the same identity as before.
+func isRehydration[T objectSets](obj, oldObj *T) bool {
+	if !isCompacted(oldObj) || isCompacted(obj) ||
+		objectSetLifecycleState(obj) == corev1alpha1.ObjectSetLifecycleStateArchived {
+		return false
+	}
+
+	compacted := objectSetImmutableFields(obj).ObjectSetTemplateSpec
+	compacted.Phases = controllers.CompactPhases(compacted.Phases)
+	return equality.Semantic.DeepEqual(compacted, objectSetImmutableFields(oldObj).ObjectSetTemplateSpec)
+}
+
+func isCompacted[T objectSets](obj *T) bool {
+	return controllers.IsCompacted(any(obj).(client.Object))
+}
+
+func objectSetLifecycleState[T objectSets](obj *T) corev1alpha1.ObjectSetLifecycleState {
+	switch v := any(obj).(type) {
+	case *corev1alpha1.ClusterObjectSet:
+		return v.Spec.LifecycleState
+	case *corev1alpha1.ObjectSet:
+		return v.Spec.LifecycleState
+	}
+	return ""
+}
+
 type genericImmutableFields struct {
 	Previous                           []corev1alpha1.PreviousRevisionReference `json:"previous,omitempty"`
 	corev1alpha1.ObjectSetTemplateSpec `json:",inline"`
@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	manifestsv1alpha1 "package-operator.run/apis/manifests/v1alpha1"
+	"package-operator.run/package-operator/internal/controllers"
+)
+
+func TestValidateGenericObjectSetImmutability_compaction(t *testing.T) {
+	t.Parallel()
+
+	cm := newTestObjectSetObject("ConfigMap", "cm")
+	cm.Object.SetLabels(map[string]string{"app": "test"})
+
+	oldObj := &corev1alpha1.ObjectSet{}
+	oldObj.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStateArchived
+	oldObj.Spec.Phases = []corev1alpha1.ObjectSetTemplatePhase{
+		{Name: "a", Objects: []corev1alpha1.ObjectSetObject{cm}},
+	}
+
+	compacted := oldObj.DeepCopy()
+	compacted.Annotations = map[string]string{corev1alpha1.ObjectSetCompactedAnnotation: "True"}
+	compacted.Spec.Phases = controllers.CompactPhases(oldObj.Spec.Phases)
+	assert.NoError(t, validateGenericObjectSetImmutability(compacted, oldObj))
+
+	// Compaction must retain object identity.
+	renamed := compacted.DeepCopy()
+	renamed.Spec.Phases[0].Objects[0].Object = unstructured.Unstructured{}
+	assert.Error(t, validateGenericObjectSetImmutability(renamed, oldObj))
+
+	// Only archived ObjectSets may be compacted.
+	active := oldObj.DeepCopy()
+	active.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStateActive
+	assert.Error(t, validateGenericObjectSetImmutability(compacted, active))
+}
+
+func TestValidateGenericObjectSetImmutability_compactedStaysArchived(t *testing.T) {
+	t.Parallel()
+
+	oldObj := &corev1alpha1.ClusterObjectSet{}
+	oldObj.Annotations = map[string]string{corev1alpha1.ObjectSetCompactedAnnotation: "True"}
+	oldObj.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStateArchived
+	assert.NoError(t, validateGenericObjectSetImmutability(oldObj.DeepCopy(), oldObj))
+
+	// Not templated from a package image, so it can't be rehydrated.
+	for _, state := range []corev1alpha1.ObjectSetLifecycleState{
+		corev1alpha1.ObjectSetLifecycleStateActive,
+		corev1alpha1.ObjectSetLifecycleStatePaused,
+	} {
+		reactivated := oldObj.DeepCopy()
+		reactivated.Spec.LifecycleState = state
+		assert.ErrorContains(t, validateGenericObjectSetImmutability(reactivated, oldObj), "spec.lifecycleState")
+	}
+
+	uncompacted := oldObj.DeepCopy()
+	uncompacted.Annotations = nil
+	assert.ErrorContains(t, validateGenericObjectSetImmutability(uncompacted, oldObj), "metadata.annotations")
+}
+
+func TestValidateGenericObjectSetImmutability_rehydration(t *testing.T) {
+	t.Parallel()
+
+	cm := newTestObjectSetObject("ConfigMap", "cm")
+	cm.Object.SetLabels(map[string]string{"app": "test"})
+	phases := []corev1alpha1.ObjectSetTemplatePhase{
+		{Name: "a", Objects: []corev1alpha1.ObjectSetObject{cm}},
+	}
+
+	archived := &corev1alpha1.ObjectSet{}
+	archived.Labels = map[string]string{manifestsv1alpha1.PackageInstanceLabel: "test"}
+	archived.Annotations = map[string]string{
+		corev1alpha1.ObjectSetCompactedAnnotation:      "True",
+		manifestsv1alpha1.PackageSourceImageAnnotation: "quay.io/package-operator/test:v1",
+	}
+	archived.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStateArchived
+	archived.Spec.Phases = controllers.CompactPhases(phases)
+
+	// Rolling back to a compacted ObjectSet templated from a package image.
+	reactivated := archived.DeepCopy()
+	reactivated.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStateActive
+	assert.NoError(t, validateGenericObjectSetImmutability(reactivated, archived))
+
+	rehydrated := reactivated.DeepCopy()
+	delete(rehydrated.Annotations, corev1alpha1.ObjectSetCompactedAnnotation)
+	rehydrated.Spec.Phases = phases
+	assert.NoError(t, validateGenericObjectSetImmutability(rehydrated, reactivated))
+
+	// Rehydration must restore objects with the same identity.
+	renamed := rehydrated.DeepCopy()
+	renamed.Spec.Phases[0].Objects[0].Object.SetName("other")
+	assert.Error(t, validateGenericObjectSetImmutability(renamed, reactivated))
+
+	// Archived ObjectSets stay compacted.
+	stillArchived := rehydrated.DeepCopy()
+	stillArchived.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStateArchived
+	assert.Error(t, validateGenericObjectSetImmutability(stillArchived, archived))
+}
@@ -0,0 +1,146 @@
+package webhooks
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/preflight"
+)
+
+// Validates the spec of new ObjectSets,
+// so authoring errors are reported on admission instead of failing reconciliation.
+func validateGenericObjectSetSpec[T objectSets](obj *T) field.ErrorList {
+	fields := objectSetImmutableFields(obj)
+	specPath := field.NewPath("spec")
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validatePreviousRevisions(fields.Previous, specPath.Child("previous"))...)
+	allErrs = append(allErrs, validateObjectSetTemplateSpec(fields.ObjectSetTemplateSpec, specPath)...)
+	if !fields.AllowCriticalKinds {
+		allErrs = append(allErrs, validateNoCriticalKinds(fields.ObjectSetTemplateSpec, specPath)...)
+	}
+	return allErrs
+}
+
+func validatePreviousRevisions(
+	previous []corev1alpha1.PreviousRevisionReference, path *field.Path,
+) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.New[string]()
+	for i, prev := range previous {
+		namePath := path.Index(i).Child("name")
+		switch {
+		case len(prev.Name) == 0:
+			allErrs = append(allErrs, field.Required(namePath, ""))
+		case names.Has(prev.Name):
+			allErrs = append(allErrs, field.Duplicate(namePath, prev.Name))
+		}
+		names.Insert(prev.Name)
+	}
+	return allErrs
+}
+
+func validateObjectSetTemplateSpec(
+	spec corev1alpha1.ObjectSetTemplateSpec, path *field.Path,
+) field.ErrorList {
+	var allErrs field.ErrorList
+
+	phaseNames := sets.New[string]()
+	for i, phase := range spec.Phases {
+		phasePath := path.Child("phases").Index(i)
+
+		switch {
+		case len(phase.Name) == 0:
+			allErrs = append(allErrs, field.Required(phasePath.Child("name"), ""))
+		case phaseNames.Has(phase.Name):
+			allErrs = append(allErrs, field.Duplicate(phasePath.Child("name"), phase.Name))
+		}
+		phaseNames.Insert(phase.Name)
+
+		if len(phase.Objects) == 0 && len(phase.ExternalObjects) == 0 && len(phase.Slices) == 0 {
+			allErrs = append(allErrs, field.Required(phasePath, "phase must contain objects"))
+		}
+		for j, obj := range phase.Objects {
+			allErrs = append(allErrs, validateObjectSetObject(obj, phasePath.Child("objects").Index(j))...)
+		}
+		for j, obj := range phase.ExternalObjects {
+			allErrs = append(allErrs, validateObjectSetObject(obj, phasePath.Child("externalObjects").Index(j))...)
+		}
+	}
+
+	for i, probe := range spec.AvailabilityProbes {
+		probePath := path.Child("availabilityProbes").Index(i)
+		allErrs = append(allErrs, validateProbeSelector(
+			probe.Selector, probePath.Child("selector"))...)
+		for j, p := range probe.Probes {
+			if p.Count != nil {
+				allErrs = append(allErrs, validateCountProbe(
+					*p.Count, probePath.Child("probes").Index(j).Child("count"))...)
+			}
+		}
+	}
+	return allErrs
+}
+
+// Cluster-critical kinds require an explicit opt-in via spec.allowCriticalKinds.
+func validateNoCriticalKinds(
+	spec corev1alpha1.ObjectSetTemplateSpec, path *field.Path,
+) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, phase := range spec.Phases {
+		for j := range phase.Objects {
+			if preflight.IsCriticalObject(&phase.Objects[j].Object) {
+				allErrs = append(allErrs, field.Forbidden(
+					path.Child("phases").Index(i).Child("objects").Index(j),
+					"cluster-critical kind requires spec.allowCriticalKinds"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateObjectSetObject(obj corev1alpha1.ObjectSetObject, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	objPath := path.Child("object")
+	if len(obj.Object.GetKind()) == 0 {
+		allErrs = append(allErrs, field.Required(objPath.Child("kind"), ""))
+	}
+	if len(obj.Object.GetName()) == 0 {
+		allErrs = append(allErrs, field.Required(objPath.Child("metadata", "name"), ""))
+	}
+	return allErrs
+}
+
+func validateProbeSelector(selector corev1alpha1.ProbeSelector, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if selector.Kind != nil && len(selector.Kind.Kind) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("kind", "kind"), ""))
+	}
+	if selector.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(selector.Selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("selector"), selector.Selector, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateCountProbe(count corev1alpha1.ProbeCountSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(count.APIVersion) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("apiVersion"), ""))
+	} else if _, err := schema.ParseGroupVersion(count.APIVersion); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("apiVersion"), count.APIVersion, err.Error()))
+	}
+	if len(count.Kind) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("kind"), ""))
+	}
+	if count.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(count.Selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("selector"), count.Selector, err.Error()))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,163 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+func newTestObjectSetObject(kind, name string) corev1alpha1.ObjectSetObject {
+	obj := unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind(kind)
+	obj.SetName(name)
+	return corev1alpha1.ObjectSetObject{Object: obj}
+}
+
+func TestValidateGenericObjectSetSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		spec           corev1alpha1.ObjectSetSpec
+		expectedFields []string
+	}{
+		{
+			name: "valid",
+			spec: corev1alpha1.ObjectSetSpec{
+				Previous: []corev1alpha1.PreviousRevisionReference{{Name: "rev1"}},
+				ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+					Phases: []corev1alpha1.ObjectSetTemplatePhase{
+						{Name: "a", Objects: []corev1alpha1.ObjectSetObject{newTestObjectSetObject("ConfigMap", "cm")}},
+						{Name: "b", Slices: []string{"slice"}},
+					},
+					AvailabilityProbes: []corev1alpha1.ObjectSetProbe{{
+						Selector: corev1alpha1.ProbeSelector{
+							Kind:     &corev1alpha1.PackageProbeKindSpec{Kind: "ConfigMap"},
+							Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+						},
+					}},
+				},
+			},
+		},
+		{
+			name: "invalid previous revisions",
+			spec: corev1alpha1.ObjectSetSpec{
+				Previous: []corev1alpha1.PreviousRevisionReference{{Name: "rev1"}, {Name: "rev1"}, {}},
+			},
+			expectedFields: []string{"spec.previous[1].name", "spec.previous[2].name"},
+		},
+		{
+			name: "invalid phases",
+			spec: corev1alpha1.ObjectSetSpec{
+				ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+					Phases: []corev1alpha1.ObjectSetTemplatePhase{
+						{Name: "a", Objects: []corev1alpha1.ObjectSetObject{newTestObjectSetObject("", "")}},
+						{Name: "a", ExternalObjects: []corev1alpha1.ObjectSetObject{newTestObjectSetObject("Secret", "")}},
+						{Name: "empty"},
+					},
+				},
+			},
+			expectedFields: []string{
+				"spec.phases[0].objects[0].object.kind",
+				"spec.phases[0].objects[0].object.metadata.name",
+				"spec.phases[1].name",
+				"spec.phases[1].externalObjects[0].object.metadata.name",
+				"spec.phases[2]",
+			},
+		},
+		{
+			name: "invalid probe selector",
+			spec: corev1alpha1.ObjectSetSpec{
+				ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+					AvailabilityProbes: []corev1alpha1.ObjectSetProbe{{
+						Selector: corev1alpha1.ProbeSelector{
+							Kind: &corev1alpha1.PackageProbeKindSpec{Group: "apps"},
+							Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "app", Operator: "Banana"},
+							}},
+						},
+					}},
+				},
+			},
+			expectedFields: []string{
+				"spec.availabilityProbes[0].selector.kind.kind",
+				"spec.availabilityProbes[0].selector.selector",
+			},
+		},
+		{
+			name: "invalid count probe",
+			spec: corev1alpha1.ObjectSetSpec{
+				ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+					AvailabilityProbes: []corev1alpha1.ObjectSetProbe{{
+						Probes: []corev1alpha1.Probe{{
+							Count: &corev1alpha1.ProbeCountSpec{
+								APIVersion: "a/b/c",
+								Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+									{Key: "app", Operator: "Banana"},
+								}},
+							},
+						}},
+					}},
+				},
+			},
+			expectedFields: []string{
+				"spec.availabilityProbes[0].probes[0].count.apiVersion",
+				"spec.availabilityProbes[0].probes[0].count.kind",
+				"spec.availabilityProbes[0].probes[0].count.selector",
+			},
+		},
+		{
+			name: "critical kind not allowed",
+			spec: corev1alpha1.ObjectSetSpec{
+				ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+					Phases: []corev1alpha1.ObjectSetTemplatePhase{
+						{Name: "a", Objects: []corev1alpha1.ObjectSetObject{newTestObjectSetObject("Node", "node")}},
+					},
+				},
+			},
+			expectedFields: []string{"spec.phases[0].objects[0]"},
+		},
+		{
+			name: "critical kind allowed",
+			spec: corev1alpha1.ObjectSetSpec{
+				ObjectSetTemplateSpec: corev1alpha1.ObjectSetTemplateSpec{
+					AllowCriticalKinds: true,
+					Phases: []corev1alpha1.ObjectSetTemplatePhase{
+						{Name: "a", Objects: []corev1alpha1.ObjectSetObject{newTestObjectSetObject("Node", "node")}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := validateGenericObjectSetSpec(&corev1alpha1.ObjectSet{Spec: test.spec})
+			fields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.ElementsMatch(t, test.expectedFields, fields)
+		})
+	}
+}
+
+func TestDefaultGenericObjectSet(t *testing.T) {
+	t.Parallel()
+
+	objectSet := &corev1alpha1.ClusterObjectSet{}
+	defaultGenericObjectSet(objectSet)
+	assert.Equal(t, corev1alpha1.ObjectSetLifecycleStateActive, objectSet.Spec.LifecycleState)
+
+	objectSet.Spec.LifecycleState = corev1alpha1.ObjectSetLifecycleStatePaused
+	defaultGenericObjectSet(objectSet)
+	assert.Equal(t, corev1alpha1.ObjectSetLifecycleStatePaused, objectSet.Spec.LifecycleState)
+}
@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "k8s.io/api/admission/v1"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"package-operator.run/package-operator/internal/adapters"
+	"package-operator.run/package-operator/internal/controllers"
+)
+
+// Rejects Packages referencing their image by tag,
+// when the PackageOperatorConfig requires images to be referenced by digest.
+// Digests are resolved by the Package controller,
+// which knows the registry host overrides, mirrors and pull credentials.
+type PackageImageDigestWebhookHandler struct {
+	decoder    *admission.Decoder
+	log        logr.Logger
+	scheme     *runtime.Scheme
+	newPackage adapters.GenericPackageFactory
+	policy     *controllers.ImageDigestPolicyChecker
+}
+
+func NewPackageImageDigestWebhookHandler(
+	log logr.Logger, scheme *runtime.Scheme, client client.Reader,
+) *PackageImageDigestWebhookHandler {
+	return &PackageImageDigestWebhookHandler{
+		log:        log,
+		scheme:     scheme,
+		newPackage: adapters.NewGenericPackage,
+		policy:     controllers.NewImageDigestPolicyChecker(client),
+	}
+}
+
+func NewClusterPackageImageDigestWebhookHandler(
+	log logr.Logger, scheme *runtime.Scheme, client client.Reader,
+) *PackageImageDigestWebhookHandler {
+	return &PackageImageDigestWebhookHandler{
+		log:        log,
+		scheme:     scheme,
+		newPackage: adapters.NewGenericClusterPackage,
+		policy:     controllers.NewImageDigestPolicyChecker(client),
+	}
+}
+
+func (wh *PackageImageDigestWebhookHandler) Handle(
+	ctx context.Context, req admission.Request,
+) admission.Response {
+	if req.Operation != v1.Operation(admissionv1beta1.Create) &&
+		req.Operation != v1.Operation(admissionv1beta1.Update) {
+		return admission.Allowed("operation allowed")
+	}
+
+	required, err := wh.policy.IsDigestRequired(ctx)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if !required {
+		return admission.Allowed("image digests not required")
+	}
+
+	pkg := wh.newPackage(wh.scheme)
+	if err := wh.decoder.Decode(req, pkg.ClientObject()); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	image := pkg.GetImage()
+	if len(image) == 0 {
+		return admission.Allowed("package not sourced from an image")
+	}
+
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return admission.Denied(fmt.Sprintf("parsing image: %s", err))
+	}
+	if _, ok := ref.(name.Digest); !ok {
+		return admission.Denied(fmt.Sprintf(
+			"the PackageOperatorConfig requires package images to be referenced by digest, got %s", image))
+	}
+	return admission.Allowed("image referenced by digest")
+}
+
+func (wh *PackageImageDigestWebhookHandler) InjectDecoder(d *admission.Decoder) error {
+	wh.decoder = d
+	return nil
+}
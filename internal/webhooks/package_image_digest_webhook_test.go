@@ -0,0 +1,100 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "package-operator.run/apis/core/v1alpha1"
+	"package-operator.run/package-operator/internal/testutil"
+)
+
+func TestPackageImageDigestWebhookHandler(t *testing.T) {
+	t.Parallel()
+
+	const digest = "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	tests := []struct {
+		name          string
+		required      bool
+		image         string
+		expectAllowed bool
+	}{
+		{
+			name:          "not required",
+			image:         "quay.io/example/test:v1.0.0",
+			expectAllowed: true,
+		},
+		{
+			name:     "tag",
+			required: true,
+			image:    "quay.io/example/test:v1.0.0",
+		},
+		{
+			name:          "digest",
+			required:      true,
+			image:         "quay.io/example/test@" + digest,
+			expectAllowed: true,
+		},
+		{
+			name:          "tag and digest",
+			required:      true,
+			image:         "quay.io/example/test:v1.0.0@" + digest,
+			expectAllowed: true,
+		},
+		{
+			name:     "invalid",
+			required: true,
+			image:    "quay.io/example/test:",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := testutil.NewTestSchemeWithCoreV1Alpha1()
+			c := testutil.NewClient()
+			c.On("Get", mock.Anything, mock.Anything,
+				mock.AnythingOfType("*v1alpha1.PackageOperatorConfig"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					config := args.Get(2).(*corev1alpha1.PackageOperatorConfig)
+					config.Spec.RequireImageDigests = test.required
+				}).
+				Return(nil)
+
+			wh := NewPackageImageDigestWebhookHandler(testr.New(t), scheme, c)
+			decoder, err := admission.NewDecoder(scheme)
+			require.NoError(t, err)
+			require.NoError(t, wh.InjectDecoder(decoder))
+
+			res := wh.Handle(context.Background(), newPackageRequest(t, test.image))
+			assert.Equal(t, test.expectAllowed, res.Allowed)
+			assert.Empty(t, res.Patches)
+		})
+	}
+}
+
+func newPackageRequest(t *testing.T, image string) admission.Request {
+	t.Helper()
+
+	pkg := &corev1alpha1.Package{
+		Spec: corev1alpha1.PackageSpec{Image: image},
+	}
+	pkg.SetGroupVersionKind(corev1alpha1.GroupVersion.WithKind("Package"))
+	raw, err := json.Marshal(pkg)
+	require.NoError(t, err)
+
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}}
+}
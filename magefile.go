@@ -442,6 +442,7 @@ func (l Locations) PluginIntegrationTestExecReport() string {
 	return filepath.Join(l.IntegrationTestCache(), "kubectl-package-exec.json")
 }
 func (l Locations) IntegrationTestLogs() string { return filepath.Join(l.Cache(), "dev-env-logs") }
+func (l Locations) LoadTestReport() string      { return filepath.Join(l.Cache(), "load", "report.json") }
 func (l Locations) ImageCache(imageName string) string {
 	return filepath.Join(l.Cache(), "image", imageName)
 }
@@ -718,6 +719,30 @@ func (Test) packageOperatorIntegration(ctx context.Context, filter string) {
 	}
 }
 
+// Creates many ObjectDeployments on the cluster your KUBECONFIG is pointing at
+// and reports reconcile throughput, API requests and memory of Package Operator.
+// Configured via PKO_LOAD_PACKAGES, PKO_LOAD_OBJECTS, PKO_LOAD_PARALLELISM, PKO_LOAD_TIMEOUT and PKO_LOAD_REPORT.
+func (Test) Load() {
+	if _, ok := os.LookupEnv("PKO_LOAD_PACKAGES"); !ok {
+		os.Setenv("PKO_LOAD_PACKAGES", "100")
+	}
+	if _, ok := os.LookupEnv("PKO_LOAD_REPORT"); !ok {
+		os.Setenv("PKO_LOAD_REPORT", locations.LoadTestReport())
+	}
+	if err := os.MkdirAll(filepath.Dir(os.Getenv("PKO_LOAD_REPORT")), os.ModePerm); err != nil {
+		panic(err)
+	}
+
+	// count=1 will force a new run, instead of using the cache
+	args := []string{
+		"test", "-v", "-count=1", "-timeout=2h",
+		"./integration/load/...",
+	}
+	if err := sh.Run("go", args...); err != nil {
+		panic(err)
+	}
+}
+
 func (Test) kubectlPackageIntegration() {
 	tmp, err := os.MkdirTemp("", "kubectl-package-integration-cov-*")
 	if err != nil {
@@ -1158,6 +1183,10 @@ func (d Dev) deployPackageOperatorWebhook(ctx context.Context, cluster *dev.Clus
 		filepath.Join("config", "deploy", "webhook", "objectsetphasevalidatingwebhookconfig.yaml"),
 		filepath.Join("config", "deploy", "webhook", "clusterobjectsetvalidatingwebhookconfig.yaml"),
 		filepath.Join("config", "deploy", "webhook", "clusterobjectsetphasevalidatingwebhookconfig.yaml"),
+		filepath.Join("config", "deploy", "webhook", "objectsetmutatingwebhookconfig.yaml"),
+		filepath.Join("config", "deploy", "webhook", "clusterobjectsetmutatingwebhookconfig.yaml"),
+		filepath.Join("config", "deploy", "webhook", "packagevalidatingwebhookconfig.yaml"),
+		filepath.Join("config", "deploy", "webhook", "clusterpackagevalidatingwebhookconfig.yaml"),
 	}); err != nil {
 		panic(fmt.Errorf("deploy package-operator-webhook dependencies: %w", err))
 	}